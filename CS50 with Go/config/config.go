@@ -0,0 +1,190 @@
+// Package config is the defaults the commands share, from a YAML file:
+// the user's, ~/.config/cs50go/config.yaml, and then the repo's own,
+// .cs50go.yaml in "CS50 with Go", over it. cs50go and journal load it when
+// they start; the rest ask Get.
+//
+//	color: never
+//	editor: code --wait
+//	format: json
+//	data_dir: ~/Dropbox/cs50go
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CONFIG_ENV names a config file to read in place of the user's.
+const CONFIG_ENV = "CS50GO_CONFIG"
+
+// REPO_FILE is the repo's config, in its root, for settings that go with
+// the checkout rather than the person.
+const REPO_FILE = ".cs50go.yaml"
+
+// Config is every setting. An empty one is left to the command: its own
+// default, or the environment's, like $EDITOR.
+type Config struct {
+	Language string `yaml:"language"`  // of the messages: en
+	Color    string `yaml:"color"`     // auto, always or never
+	Editor   string `yaml:"editor"`    // a command, with arguments; $EDITOR without it
+	Format   string `yaml:"format"`    // of reports that can be either: text or json
+	DataDir  string `yaml:"data_dir"`  // for progress, cards and specs; $XDG_DATA_HOME without it
+	CacheDir string `yaml:"cache_dir"` // for the built exercises; the user's cache without it
+}
+
+// DEFAULTS are the settings with no file at all.
+var DEFAULTS = Config{Language: "en", Color: "auto", Format: "text"}
+
+// The values the settings with a choice can take.
+var (
+	COLORS  = []string{"auto", "always", "never"}
+	FORMATS = []string{"text", "json"}
+)
+
+var (
+	mu      sync.Mutex
+	current = DEFAULTS
+)
+
+// Load reads the user's config and then root's over it, when root isn't
+// "", and makes that what Get returns. A file that isn't there is no
+// settings; one that is must parse, with no setting it doesn't know.
+func Load(root string) (Config, error) {
+	c := DEFAULTS
+	user, err := userFile()
+	if err != nil {
+		return c, err
+	}
+	paths := []string{user}
+	if root != "" {
+		paths = append(paths, filepath.Join(root, REPO_FILE))
+	}
+	for _, path := range paths {
+		if err := c.read(path); err != nil {
+			return DEFAULTS, err
+		}
+	}
+	Set(c)
+	return c, nil
+}
+
+// userFile is $CS50GO_CONFIG, or config.yaml in cs50go's folder of the
+// user's config directory.
+func userFile() (string, error) {
+	if path := os.Getenv(CONFIG_ENV); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cs50go", "config.yaml"), nil
+}
+
+// read sets what path sets, leaving the rest of c be. Directories are
+// from the file's own, or the home directory with ~/.
+func (c *Config) read(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var file Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := file.check(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, dir := range []*string{&file.DataDir, &file.CacheDir} {
+		if *dir, err = resolve(*dir, filepath.Dir(path)); err != nil {
+			return err
+		}
+	}
+	for _, pair := range [][2]*string{
+		{&c.Language, &file.Language}, {&c.Color, &file.Color}, {&c.Editor, &file.Editor},
+		{&c.Format, &file.Format}, {&c.DataDir, &file.DataDir}, {&c.CacheDir, &file.CacheDir},
+	} {
+		if *pair[1] != "" {
+			*pair[0] = *pair[1]
+		}
+	}
+	return nil
+}
+
+// check makes sure the settings with a choice have one of theirs.
+func (c Config) check() error {
+	switch {
+	case c.Color != "" && !slices.Contains(COLORS, c.Color):
+		return fmt.Errorf("color is %q, not one of %s", c.Color, strings.Join(COLORS, ", "))
+	case c.Format != "" && !slices.Contains(FORMATS, c.Format):
+		return fmt.Errorf("format is %q, not one of %s", c.Format, strings.Join(FORMATS, ", "))
+	}
+	return nil
+}
+
+// resolve makes dir absolute: from home for ~/, or else from base.
+func resolve(dir, base string) (string, error) {
+	switch {
+	case dir == "" || filepath.IsAbs(dir):
+		return dir, nil
+	case dir == "~" || strings.HasPrefix(dir, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, dir[1:]), nil
+	}
+	return filepath.Join(base, dir), nil
+}
+
+// Get is the config Load read, or DEFAULTS before it.
+func Get() Config {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// Set makes c what Get returns, for tests and for Load.
+func Set(c Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = c
+}
+
+// UseColor reports whether to colour output that goes to a terminal or
+// not: always, never, or for auto when it's a terminal and NO_COLOR isn't
+// set.
+func (c Config) UseColor(terminal bool) bool {
+	switch c.Color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return terminal && os.Getenv("NO_COLOR") == ""
+}
+
+// EditorCommand is the editor to open a file in, split into the program
+// and its arguments: the config's, $EDITOR, or vi.
+func (c Config) EditorCommand() []string {
+	for _, editor := range []string{c.Editor, os.Getenv("EDITOR")} {
+		if fields := strings.Fields(editor); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
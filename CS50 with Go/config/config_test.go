@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	user := filepath.Join(home, "config.yaml")
+	t.Setenv(CONFIG_ENV, user)
+	root := t.TempDir()
+	t.Cleanup(func() { Set(DEFAULTS) })
+
+	tests := []struct {
+		name       string
+		user, repo string
+		want       Config
+		err        string
+	}{
+		{"no files", "", "", DEFAULTS, ""},
+		{"the user's", "color: never\neditor: nano -w\ndata_dir: ~/cs50\n", "",
+			Config{Language: "en", Color: "never", Editor: "nano -w", Format: "text", DataDir: filepath.Join(home, "cs50")}, ""},
+		{"the repo's over it", "color: never\nformat: json\n", "color: always\ncache_dir: build\n",
+			Config{Language: "en", Color: "always", Format: "json", CacheDir: filepath.Join(root, "build")}, ""},
+		{"an empty file", "# nothing yet\n", "", DEFAULTS, ""},
+		{"a typo", "colour: never\n", "", DEFAULTS, "field colour not found"},
+		{"no such colour", "", "color: sometimes\n", DEFAULTS, `color is "sometimes", not one of auto, always, never`},
+		{"no such format", "format: yaml\n", "", DEFAULTS, `format is "yaml"`},
+		{"not YAML", "color: [\n", "", DEFAULTS, "config.yaml"},
+	}
+	for _, test := range tests {
+		for path, content := range map[string]string{user: test.user, filepath.Join(root, REPO_FILE): test.repo} {
+			os.Remove(path)
+			if content != "" {
+				os.WriteFile(path, []byte(content), 0o644)
+			}
+		}
+		Set(DEFAULTS)
+		c, err := Load(root)
+		switch {
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: Load = %v, want %q", test.name, err, test.err)
+		case test.err == "" && err != nil:
+			t.Errorf("%s: Load = %v", test.name, err)
+		case c != test.want || Get() != test.want:
+			t.Errorf("%s: Load = %+v, Get = %+v, want %+v", test.name, c, Get(), test.want)
+		}
+	}
+
+	// Outside the repo there's only the user's.
+	os.WriteFile(user, []byte("editor: ed\n"), 0o644)
+	if c, err := Load(""); err != nil || c.Editor != "ed" || c.CacheDir != "" {
+		t.Errorf("Load(\"\") = %+v, %v", c, err)
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	for _, test := range []struct {
+		color    string
+		noColor  string
+		terminal bool
+		want     bool
+	}{
+		{"auto", "", true, true},
+		{"auto", "", false, false},
+		{"auto", "1", true, false},
+		{"always", "1", false, true},
+		{"never", "", true, false},
+	} {
+		t.Setenv("NO_COLOR", test.noColor)
+		if got := (Config{Color: test.color}).UseColor(test.terminal); got != test.want {
+			t.Errorf("color %s, NO_COLOR=%q, terminal %v: UseColor = %v", test.color, test.noColor, test.terminal, got)
+		}
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("EDITOR", "vim -p")
+	for _, test := range []struct {
+		editor string
+		want   []string
+	}{
+		{"code --wait", []string{"code", "--wait"}},
+		{"", []string{"vim", "-p"}},
+	} {
+		if got := (Config{Editor: test.editor}).EditorCommand(); !slices.Equal(got, test.want) {
+			t.Errorf("editor %q: EditorCommand = %q, want %q", test.editor, got, test.want)
+		}
+	}
+	t.Setenv("EDITOR", "")
+	if got := (Config{}).EditorCommand(); !slices.Equal(got, []string{"vi"}) {
+		t.Errorf("with no editor at all, EditorCommand = %q", got)
+	}
+}
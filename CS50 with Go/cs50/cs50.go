@@ -0,0 +1,263 @@
+package cs50
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// GetChar prompts the user and returns a single character
+func GetChar(prompt string) rune {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        if len(input) == 1 {
+            return rune(input[0])
+        }
+        fmt.Println("Invalid input. Please enter a single character.")
+    }
+}
+
+// GetDouble prompts the user and returns a double (float64)
+func GetDouble(prompt string) float64 {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        num, err := strconv.ParseFloat(input, 64)
+        if err == nil {
+            return num
+        }
+        fmt.Println("Invalid input. Please enter a number (double).")
+    }
+}
+
+// GetFloat prompts the user and returns a float32
+func GetFloat(prompt string) float32 {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        num, err := strconv.ParseFloat(input, 32)
+        if err == nil {
+            return float32(num)
+        }
+        fmt.Println("Invalid input. Please enter a number (float).")
+    }
+}
+
+// GetInt prompts the user and returns an integer
+func GetInt(prompt string) int {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        num, err := strconv.Atoi(input)
+        if err == nil {
+            return num
+        }
+        fmt.Println("Invalid input. Please enter an integer.")
+    }
+}
+
+//---generate when need to use---//
+
+// GetLong prompts the user and returns a long
+func GetLong(prompt string) int64 {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        num, err := strconv.ParseInt(input, 10, 64)
+        if err == nil {
+            return num
+        }
+        fmt.Println("Invalid input. Please enter a long integer.")
+    }
+}
+
+// GetCurrency prompts the user for an amount of money like "4.20", "$4.20"
+// or "4" and returns it in cents (or satang, or pence). Parsed exactly, no
+// float rounding: "0.1" is 10, not 9.999...
+func GetCurrency(prompt string) int {
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        if cents, ok := parseCurrency(input); ok {
+            return cents
+        }
+        fmt.Println("Invalid input. Please enter an amount like 4.20.")
+    }
+}
+
+func parseCurrency(input string) (int, bool) {
+    negative := strings.HasPrefix(input, "-")
+    input = strings.TrimPrefix(input, "-")
+    input = strings.TrimLeft(input, "$฿£€ ")
+
+    whole, fraction, hasPoint := strings.Cut(input, ".")
+    if whole == "" && fraction == "" || len(fraction) > 2 || hasPoint && fraction == "" {
+        return 0, false
+    }
+    for len(fraction) < 2 {
+        fraction += "0"
+    }
+    if whole == "" {
+        whole = "0"
+    }
+    units, err1 := strconv.Atoi(whole)
+    cents, err2 := strconv.Atoi(fraction)
+    if err1 != nil || err2 != nil || units < 0 || cents < 0 || strings.ContainsAny(whole+fraction, "+-") {
+        return 0, false
+    }
+    if units > (math.MaxInt-cents)/100 {
+        return 0, false // more cents than an int holds
+    }
+    if negative {
+        return -(units*100 + cents), true
+    }
+    return units*100 + cents, true
+}
+
+// GetChoice prints options as a numbered menu and prompts until the user
+// picks one, by number or by name. It returns the chosen option's index.
+func GetChoice(prompt string, options []string) int {
+    for i, option := range options {
+        fmt.Printf("%d. %s\n", i+1, option)
+    }
+    for {
+        input := readLine(prompt)
+        input = strings.TrimSpace(input)
+        if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(options) {
+            return n - 1
+        }
+        for i, option := range options {
+            if strings.EqualFold(input, option) {
+                return i
+            }
+        }
+        fmt.Printf("Invalid choice. Please enter a number from 1 to %d.\n", len(options))
+    }
+}
+
+// GetLongLong prompts the user and returns a long_long [Prompt when need to use.]
+
+// GetString prompts the user and returns a string
+func GetString(prompt string) string {
+    return strings.TrimSpace(readLine(prompt))
+}
+
+// GetPassword prompts the user and returns a line typed without showing it
+// on the terminal. Echo is switched off with stty, so on a terminal without
+// it (or when input is piped) the line is read as usual. Spaces are kept.
+func GetPassword(prompt string) string {
+    if stty("-echo") {
+        defer func() {
+            stty("echo")
+            fmt.Println() // the Enter key wasn't echoed either
+        }()
+    }
+    return readLine(prompt)
+}
+
+// stty runs stty on the terminal behind standard input.
+func stty(setting string) bool {
+    cmd := exec.Command("stty", setting)
+    cmd.Stdin = os.Stdin
+    return cmd.Run() == nil
+}
+
+//---SQL, like `from cs50 import SQL` in week 7---//
+
+// SQL is a database opened from a URL such as "sqlite:///birthdays.db".
+// cs50 doesn't import a driver itself, so the program has to register one:
+//
+//	import _ "modernc.org/sqlite"
+type SQL struct {
+    DB *sql.DB
+}
+
+// Row is one result row, keyed by column name. TEXT comes back as a string,
+// INTEGER as int64 and REAL as float64, NULL as nil.
+type Row map[string]any
+
+// OpenSQL opens a SQLite database. Like CS50's library it fails when the
+// file doesn't exist instead of creating an empty one; "sqlite:///:memory:"
+// is a fresh in-memory database.
+func OpenSQL(url string) (*SQL, error) {
+    path, ok := strings.CutPrefix(url, "sqlite:///")
+    if !ok || path == "" {
+        return nil, fmt.Errorf("cs50: unsupported database URL %q (want sqlite:///file.db)", url)
+    }
+    if path == ":memory:" {
+        db, err := sql.Open("sqlite", path)
+        if err != nil {
+            return nil, err
+        }
+        db.SetMaxOpenConns(1) // every connection to :memory: is another database
+        return &SQL{DB: db}, nil
+    }
+    if _, err := os.Stat(path); err != nil {
+        return nil, fmt.Errorf("cs50: %s does not exist", path)
+    }
+    db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+    if err != nil {
+        return nil, err
+    }
+    return &SQL{DB: db}, nil
+}
+
+// Query runs a SELECT and returns every row. Use ? placeholders for values:
+//
+//	rows, err := db.Query("SELECT * FROM birthdays WHERE month = ?", month)
+func (db *SQL) Query(query string, args ...any) ([]Row, error) {
+    rows, err := db.DB.Query(query, args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    columns, err := rows.Columns()
+    if err != nil {
+        return nil, err
+    }
+    values := make([]any, len(columns))
+    pointers := make([]any, len(columns))
+    for i := range values {
+        pointers[i] = &values[i]
+    }
+    var result []Row
+    for rows.Next() {
+        if err := rows.Scan(pointers...); err != nil {
+            return nil, err
+        }
+        row := make(Row, len(columns))
+        for i, column := range columns {
+            if b, ok := values[i].([]byte); ok {
+                row[column] = string(b)
+            } else {
+                row[column] = values[i]
+            }
+        }
+        result = append(result, row)
+    }
+    return result, rows.Err()
+}
+
+// Execute runs any other statement. For INSERT it returns the new row's id,
+// otherwise the number of rows changed, like CS50's db.execute.
+func (db *SQL) Execute(query string, args ...any) (int64, error) {
+    result, err := db.DB.Exec(query, args...)
+    if err != nil {
+        return 0, err
+    }
+    if fields := strings.Fields(query); len(fields) > 0 && strings.EqualFold(fields[0], "INSERT") {
+        return result.LastInsertId()
+    }
+    return result.RowsAffected()
+}
+
+// Close closes the database.
+func (db *SQL) Close() error {
+    return db.DB.Close()
+}
@@ -0,0 +1,51 @@
+package cs50
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestParseCurrency(t *testing.T) {
+	maxUnits := strconv.Itoa(math.MaxInt / 100)
+	tests := []struct {
+		input string
+		cents int
+		ok    bool
+	}{
+		{"4.20", 420, true},
+		{"$4.20", 420, true},
+		{"4", 400, true},
+		{"4.2", 420, true},
+		{".5", 50, true},
+		{"0.1", 10, true}, // exact, not 9.999... cents
+		{"฿100", 10000, true},
+		{"£ 3.05", 305, true},
+		{"-4.20", -420, true},
+		{"-$4.20", -420, true},
+		{"$-4.20", 0, false}, // the sign goes before the symbol
+		{"--4", 0, false},
+		{"+4", 0, false},
+		{"$1,234.5", 0, false}, // no thousands separators
+		{"1234.5", 123450, true},
+		{"4.205", 0, false}, // no fractions of a cent
+		{"4.", 0, false},
+		{"4.2.0", 0, false},
+		{"", 0, false},
+		{"$", 0, false},
+		{"-", 0, false},
+		{"four", 0, false},
+		{maxUnits + ".07", math.MaxInt/100*100 + 7, true},
+		{maxUnits + ".08", 0, false}, // one cent more than an int holds
+		{strconv.Itoa(math.MaxInt/100 + 1), 0, false},
+		{strconv.Itoa(math.MaxInt), 0, false},
+		{"-" + maxUnits + ".07", -(math.MaxInt/100*100 + 7), true},
+		{"99999999999999999999", 0, false},
+	}
+	for _, tt := range tests {
+		cents, ok := parseCurrency(tt.input)
+		if cents != tt.cents || ok != tt.ok {
+			t.Errorf("parseCurrency(%q) = %d, %v, want %d, %v", tt.input, cents, ok, tt.cents, tt.ok)
+		}
+	}
+}
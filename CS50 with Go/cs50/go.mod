@@ -0,0 +1,3 @@
+module cs50
+
+go 1.24.4
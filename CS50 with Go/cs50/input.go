@@ -0,0 +1,23 @@
+//go:build !(js && wasm)
+
+package cs50
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdin is shared by every Get function: a reader of its own would buffer
+// lines meant for the next prompt and lose them, which only shows when the
+// input is piped in all at once.
+var stdin = bufio.NewReader(os.Stdin)
+
+// readLine prints prompt and reads a line from standard input, without its
+// line ending. At the end of the input it returns what's left, often "".
+func readLine(prompt string) string {
+	fmt.Print(prompt)
+	input, _ := stdin.ReadString('\n')
+	return strings.TrimRight(input, "\r\n")
+}
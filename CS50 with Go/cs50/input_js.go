@@ -0,0 +1,16 @@
+//go:build js && wasm
+
+package cs50
+
+import "syscall/js"
+
+// readLine asks with the browser's prompt() dialog, since a page has no
+// standard input. Cancel gives "", like the end of piped input. Errors
+// like "Invalid input" still go to standard output, the browser console.
+func readLine(prompt string) string {
+	answer := js.Global().Call("prompt", prompt)
+	if answer.Type() != js.TypeString {
+		return ""
+	}
+	return answer.String()
+}
@@ -0,0 +1,35 @@
+//go:build js && wasm
+
+package cs50
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// Run with GOOS=js GOARCH=wasm go test -exec "$(go env GOROOT)/lib/wasm/go_js_wasm_exec" .
+// (needs Node.js).
+func TestPrompt(t *testing.T) {
+	var asked []string
+	answers := []any{"four", "4", nil, " Kevin "}
+	prompt := js.FuncOf(func(this js.Value, args []js.Value) any {
+		asked = append(asked, args[0].String())
+		answer := answers[0]
+		answers = answers[1:]
+		return answer
+	})
+	defer prompt.Release()
+	js.Global().Set("prompt", prompt)
+
+	// A bad answer asks again, like a bad line on standard input
+	if n := GetInt("Height: "); n != 4 || len(asked) != 2 || asked[1] != "Height: " {
+		t.Errorf("GetInt = %d after %q", n, asked)
+	}
+	// Cancel is an empty answer
+	if s := GetString("Name: "); s != "" {
+		t.Errorf("GetString after Cancel = %q", s)
+	}
+	if s := GetString("Name: "); s != "Kevin" {
+		t.Errorf("GetString = %q", s)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"exercises"
+	"exitcode"
+)
+
+// COMMANDS are cs50go's own commands, for completing the first word.
+var COMMANDS = []candidate{
+	{"list", "the exercises, by week"},
+	{"help", "what an exercise does and where it lives"},
+	{"build", "build them all, or some, and report failures"},
+	{"completion", "a script for the shell's tab completion"},
+}
+
+// SHELLS are the completion scripts. Each asks cs50go __complete for the
+// words that fit, as WORD<tab>DESCRIPTION lines, so the problems are the
+// registry's at the time, and falls back on file names when there are
+// none, for an exercise's own arguments.
+var SHELLS = map[string]string{
+	"bash": `# bash completion for cs50go. In ~/.bashrc:
+#     source <(cs50go completion bash)
+_cs50go() {
+	local IFS=$'\n' line
+	COMPREPLY=()
+	for line in $(cs50go __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null); do
+		COMPREPLY+=("${line%%$'\t'*}")
+	done
+}
+complete -o default -F _cs50go cs50go
+`,
+	"zsh": `#compdef cs50go
+# zsh completion for cs50go. In ~/.zshrc, after compinit:
+#     source <(cs50go completion zsh)
+_cs50go() {
+	local -a lines described
+	local line
+	lines=("${(@f)$(cs50go __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	for line in $lines; do
+		[[ -n $line ]] && described+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+	done
+	if (( ${#described} )); then
+		_describe 'cs50go' described
+	else
+		_files
+	fi
+}
+compdef _cs50go cs50go
+`,
+	"fish": `# fish completion for cs50go. In ~/.config/fish/config.fish:
+#     cs50go completion fish | source
+function __cs50go_complete
+	set -l words (commandline -opc)
+	set -e words[1]
+	cs50go __complete $words (commandline -ct) 2>/dev/null
+end
+complete -c cs50go -f -n '__cs50go_complete | string length -q' -a '(__cs50go_complete)'
+complete -c cs50go -F -n 'not __cs50go_complete | string length -q'
+`,
+	"powershell": `# PowerShell completion for cs50go. In $PROFILE:
+#     cs50go completion powershell | Out-String | Invoke-Expression
+Register-ArgumentCompleter -Native -CommandName cs50go -ScriptBlock {
+	param($wordToComplete, $commandAst, $cursorPosition)
+	$words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+	if ($wordToComplete -eq '') { $words += '' }
+	cs50go __complete @words 2>$null | ForEach-Object {
+		$word, $description = $_ -split "` + "`t" + `", 2
+		[System.Management.Automation.CompletionResult]::new($word, $word, 'ParameterValue', $description)
+	}
+}
+`,
+}
+
+// candidate is a word the shell can complete to, with what it is.
+type candidate struct {
+	Word        string
+	Description string
+}
+
+func completionCommand(args []string, w io.Writer) error {
+	if len(args) != 1 || SHELLS[args[0]] == "" {
+		return exitcode.Usage("Usage: cs50go completion bash|zsh|fish|powershell", nil)
+	}
+	fmt.Fprint(w, SHELLS[args[0]])
+	return nil
+}
+
+// completeCommand prints the words that complete the last of args, which
+// the shell passes as it is so far, "" after a space.
+func completeCommand(args []string, w io.Writer) {
+	for _, c := range complete(args) {
+		fmt.Fprintf(w, "%s\t%s\n", c.Word, c.Description)
+	}
+}
+
+// complete is what can come next after the words before the last of args,
+// starting with it: a command or a problem first, a problem after help and
+// build, and a shell after completion. After a problem it's nothing, so
+// the shell offers files, for its arguments.
+func complete(args []string) []candidate {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	word := args[len(args)-1]
+	var all []candidate
+	switch {
+	case len(args) == 1:
+		all = append(append(all, COMMANDS...), problems(word, false)...)
+	case args[0] == "help" && len(args) == 2:
+		all = problems(word, false)
+	case args[0] == "build":
+		all = problems(word, true)
+	case args[0] == "completion" && len(args) == 2:
+		for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+			all = append(all, candidate{shell, "a script for " + shell})
+		}
+	}
+	var fit []candidate
+	for _, c := range all {
+		if strings.HasPrefix(c.Word, word) {
+			fit = append(fit, c)
+		}
+	}
+	return fit
+}
+
+// problems are the registered exercises by name, or by ID, "week1/credit",
+// once word starts like one with "we", so a list of names isn't doubled;
+// build only takes the programs.
+func problems(word string, programs bool) []candidate {
+	var all []candidate
+	for _, e := range exercises.All() {
+		if _, ok := e.(exercises.Program); programs && !ok {
+			continue
+		}
+		name := e.Name()
+		if strings.HasPrefix(word, "we") {
+			name = exercises.ID(e)
+		}
+		all = append(all, candidate{name, e.Summary()})
+	}
+	return all
+}
@@ -0,0 +1,248 @@
+// cs50go: every program in the journal behind one command. It finds the
+// exercise, builds it (Go's build cache makes that instant after the first
+// time) and runs it with your arguments, input and output.
+//
+//	./cs50go                          list the exercises by week
+//	./cs50go credit                   run week 1's credit
+//	./cs50go recover card.raw
+//	./cs50go help speller             what speller does and how to run it
+//	./cs50go build                    build everything, to see what's broken
+//	./cs50go completion bash          tab completion, problems and all
+//	./cs50go -cpuprofile cpu.pprof speller texts/holmes.txt
+//	./cs50go -v credit                what credit does with the digits, logged
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"config"
+	"exercises"
+	"exitcode"
+	"i18n"
+	"logging"
+	"profiling"
+)
+
+const USAGE = `Usage:
+  cs50go [list]              the exercises, by week
+  cs50go EXERCISE [ARGS...]  run one, e.g. cs50go readability or cs50go week2/readability
+  cs50go [-cpuprofile FILE] [-memprofile FILE] [-trace FILE] [-pprof ADDR] EXERCISE [ARGS...]
+                             run one and profile it, if it calls profiling.Start
+  cs50go [-v | -vv] [-log-format text|json] EXERCISE [ARGS...]
+                             run one with debug logs, or trace, or as JSON
+  cs50go help EXERCISE       what it does and where it lives
+  cs50go build [EXERCISE...] build them all (or some) and report failures
+  cs50go completion bash|zsh|fish|powershell
+                             a script for tab completion, to source
+
+Run from inside the repo, or set CS50GO_ROOT to it.`
+
+func main() {
+	exitcode.Main(func(ctx context.Context, args []string) error {
+		// Outside the repo there's only the user's config to read.
+		root, _ := exercises.Root()
+		c, err := config.Load(root)
+		if err == nil {
+			// The psets' messages are in the config's language.
+			err = i18n.Setenv(c.Language)
+		}
+		if err != nil {
+			return failed(err)
+		}
+		return run(ctx, args, os.Stdin, os.Stdout, os.Stderr)
+	})
+}
+
+// run handles one command. Its error is of an exitcode kind, or the
+// exercise's exit when it ran and didn't end with 0, so cs50go exits as it
+// did.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	prof, logs, args, err := runFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+	if (prof.Any() || logs != logging.DEFAULTS) && (len(args) == 0 || slices.Contains(COMMAND_NAMES, args[0])) {
+		return exitcode.Usage("cs50go: the profiling and logging flags go before an exercise to run\n"+USAGE, nil)
+	}
+
+	switch {
+	case len(args) == 0 || args[0] == "list":
+		list(stdout)
+		return nil
+	case args[0] == "completion":
+		return completionCommand(args[1:], stdout)
+	case args[0] == "__complete":
+		// The completion scripts' way in: not in the usage.
+		completeCommand(args[1:], stdout)
+		return nil
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		return failed(err)
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		if len(args) != 2 {
+			fmt.Fprintln(stdout, USAGE)
+			return nil
+		}
+		return help(root, args[1], stdout)
+	case "build":
+		return buildCommand(root, args[1:], stdout)
+	}
+
+	e, ok := exercises.Lookup(args[0])
+	if !ok {
+		return exitcode.Usage(fmt.Sprintf("cs50go: no exercise %q\n%s", args[0], USAGE), nil)
+	}
+	env, err := prof.Env()
+	if err != nil {
+		return failed(err)
+	}
+	env = append(env, logs.Env()...)
+	start := time.Now()
+	err = e.Run(ctx, exercises.IO{Args: args[1:], Env: env, Stdin: stdin, Stdout: stdout, Stderr: stderr})
+	reportProfiles(stderr, e.Name(), prof, start)
+	var exit interface{ ExitCode() int }
+	if err != nil && !errors.As(err, &exit) {
+		// It didn't run: it doesn't build, say.
+		return exitcode.Data(err.Error(), nil)
+	}
+	return err
+}
+
+// failed is what cs50go can't get past, the repo or the config: a data
+// error, said as cs50go's.
+func failed(err error) error {
+	return exitcode.Data("cs50go: "+err.Error(), nil)
+}
+
+// COMMAND_NAMES are what's a command rather than an exercise, first.
+var COMMAND_NAMES = []string{"list", "help", "-h", "-help", "--help", "build", "completion", "__complete"}
+
+// runFlags takes the profiling and logging flags off the front of args,
+// the ones before the exercise. The program gets them as CS50GO_CPUPROFILE,
+// CS50GO_LOG and the rest, for profiling.Start and logging.Init. The flag
+// package says what's wrong with them on stderr; the error is a usage one.
+func runFlags(args []string, stderr io.Writer) (profiling.Settings, logging.Settings, []string, error) {
+	var s profiling.Settings
+	logs := logging.DEFAULTS
+	if len(args) == 0 || len(args[0]) < 2 || args[0][0] != '-' || slices.Contains(COMMAND_NAMES, args[0]) {
+		return s, logs, args, nil
+	}
+	flags := flag.NewFlagSet("cs50go", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {} // USAGE, after what's wrong
+	flags.StringVar(&s.CPUProfile, "cpuprofile", "", "write a CPU profile of the exercise to `file`")
+	flags.StringVar(&s.MemProfile, "memprofile", "", "write its heap, as it ends, to `file`")
+	flags.StringVar(&s.Trace, "trace", "", "write an execution trace to `file`")
+	flags.StringVar(&s.HTTP, "pprof", "", "serve net/http/pprof on `addr` while it runs, like localhost:6060")
+	verbose := flags.Bool("v", false, "log at debug, what it used to print to see what it was doing")
+	veryVerbose := flags.Bool("vv", false, "log at trace, even more")
+	flags.StringVar(&logs.Format, "log-format", logs.Format, "log as `text` or json")
+	if err := flags.Parse(args); err != nil {
+		return s, logs, nil, exitcode.Usage(USAGE, nil)
+	}
+	if *veryVerbose {
+		logs.Level = logging.Verbosity(2)
+	} else if *verbose {
+		logs.Level = logging.Verbosity(1)
+	}
+	if !slices.Contains(logging.FORMATS, logs.Format) {
+		return s, logs, nil, exitcode.Usage(fmt.Sprintf("cs50go: no log format %q: text or json", logs.Format), nil)
+	}
+	return s, logs, flags.Args(), nil
+}
+
+// reportProfiles says where the profiles name wrote are and how to read
+// them, or that it didn't write one: only a program that calls
+// profiling.Start does.
+func reportProfiles(w io.Writer, name string, prof profiling.Settings, start time.Time) {
+	written := prof.Written(start)
+	for _, path := range []string{prof.CPUProfile, prof.MemProfile, prof.Trace} {
+		if path != "" && path != written.CPUProfile && path != written.MemProfile && path != written.Trace {
+			fmt.Fprintf(w, "cs50go: %s didn't write %s; it doesn't call profiling.Start.\n", name, path)
+		}
+	}
+	for _, line := range written.Describe() {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// list prints every exercise under its week.
+func list(w io.Writer) {
+	week := 0
+	for _, e := range exercises.All() {
+		if e.Week() != week {
+			week = e.Week()
+			fmt.Fprintf(w, "Week %d\n", week)
+		}
+		fmt.Fprintf(w, "  %-20s%s\n", e.Name(), e.Summary())
+	}
+}
+
+// help prints what name does: its summary, and for a program where it
+// lives and the comment its main file starts with.
+func help(root, name string, stdout io.Writer) error {
+	e, ok := exercises.Lookup(name)
+	if !ok {
+		return exitcode.Usage(fmt.Sprintf("cs50go: no exercise %q", name), nil)
+	}
+	p, ok := e.(exercises.Program)
+	if !ok {
+		fmt.Fprintf(stdout, "%s: %s (week %d)\n", e.Name(), e.Summary(), e.Week())
+		return nil
+	}
+	doc, err := p.Doc(root)
+	if err != nil {
+		return exitcode.Data(err.Error(), nil)
+	}
+	fmt.Fprintf(stdout, "%s: %s (week %d, %s/%s)\n", p.Name(), p.Summary(), p.Week(), p.Dir, p.File)
+	if doc != "" {
+		fmt.Fprintf(stdout, "\n%s\n", doc)
+	}
+	fmt.Fprintf(stdout, "\ncs50go %s -h lists its flags, if it has any.\n", p.Name())
+	return nil
+}
+
+// buildCommand builds the named programs, or all of them, and lists the
+// ones that don't build. Any that doesn't is a data error.
+func buildCommand(root string, names []string, w io.Writer) error {
+	chosen := exercises.PROGRAMS
+	if len(names) > 0 {
+		chosen = nil
+		for _, name := range names {
+			e, ok := exercises.Lookup(name)
+			if !ok {
+				return exitcode.Usage(fmt.Sprintf("cs50go: no exercise %q", name), nil)
+			}
+			p, ok := e.(exercises.Program)
+			if !ok {
+				return exitcode.Usage(fmt.Sprintf("cs50go: %s isn't a program in the repo, with nothing to build", name), nil)
+			}
+			chosen = append(chosen, p)
+		}
+	}
+	failed := 0
+	for _, e := range chosen {
+		if _, err := e.Build(root); err != nil {
+			fmt.Fprintln(w, err)
+			failed++
+		}
+	}
+	summary := fmt.Sprintf("%d built, %d failed.", len(chosen)-failed, failed)
+	if failed > 0 {
+		return exitcode.Data(summary, nil)
+	}
+	fmt.Fprintln(w, summary)
+	return nil
+}
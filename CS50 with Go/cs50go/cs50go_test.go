@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"exercises"
+	"exitcode"
+)
+
+func TestRun(t *testing.T) {
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CS50GO_ROOT", root)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	tests := []struct {
+		args []string
+		code int
+		want string
+		slow bool // builds an exercise
+	}{
+		{nil, 0, "Week 4\n  recover", false},
+		{[]string{"help", "speller"}, 0, "spell-checker", false},
+		{[]string{"help", "credit"}, 0, "week1-C/pset-w-go/credit/credit.go", false},
+		{[]string{"help", "week5/speller"}, 0, "spell-checker", false},
+		{[]string{"help"}, 0, "Usage:", false},
+		{[]string{"help", "caesar"}, 1, `no exercise "caesar"`, false},
+		{[]string{"tetris"}, 1, `no exercise "tetris"`, false},
+		{[]string{"completion", "bash"}, 0, "complete -o default -F _cs50go cs50go", false},
+		{[]string{"completion", "tcsh"}, 1, "Usage: cs50go completion bash|zsh|fish|powershell", false},
+		{[]string{"__complete", "help", "cre"}, 0, "credit\tis a card number", false},
+		{[]string{"no-vowels", "pseudocode"}, 0, "ps3ud0c0d3", true},
+		{[]string{"no-vowels"}, 1, "Usage: ./no-vowels word", true}, // its exit code comes through
+		{[]string{"build", "no-vowels", "half"}, 0, "2 built, 0 failed.", true},
+		{[]string{"-v", "list"}, 1, "go before an exercise", false},
+		{[]string{"-log-format", "xml", "recover"}, 1, `no log format "xml"`, false},
+		{[]string{"-nosuchflag", "recover"}, 1, "flag provided but not defined: -nosuchflag\nUsage:", false},
+		{[]string{"build", "tetris"}, 1, `no exercise "tetris"`, false},
+		{[]string{"-v", "recover", "/dev/null"}, 0, "level=DEBUG msg=recovering image=/dev/null", true},
+		{[]string{"-log-format", "json", "recover", "/no/card.raw"}, 1, `"level":"ERROR","msg":"can't open the image"`, true},
+	}
+	for _, tt := range tests {
+		if tt.slow && testing.Short() {
+			continue
+		}
+		var out strings.Builder
+		err := run(context.Background(), tt.args, strings.NewReader(""), &out, &out)
+		code := exitcode.Handle(&out, err)
+		if code != tt.code || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("cs50go %q = %d\n%s\nwant %d and %q", tt.args, code, out.String(), tt.code, tt.want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	for _, test := range []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"b"}, []string{"build", "bulbs", "bsearch", "birthdays"}},
+		{[]string{"week1/c"}, []string{"week1/cash", "week1/credit", "week1/convert"}},
+		{[]string{"help", "sp"}, []string{"speller"}},
+		{[]string{"help", "credit", ""}, nil},
+		{[]string{"build", "half", "week2/w"}, []string{"week2/wordle", "week2/wasm"}},
+		{[]string{"completion", "p"}, []string{"powershell"}},
+		{[]string{"recover", ""}, nil}, // its arguments are files
+		{[]string{"zzz"}, nil},
+	} {
+		var got []string
+		for _, c := range complete(test.args) {
+			got = append(got, c.Word)
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("complete(%q) = %q, want %q", test.args, got, test.want)
+		}
+	}
+	if all := complete(nil); len(all) != len(COMMANDS)+len(exercises.All()) || all[0].Word != "list" {
+		t.Errorf("complete(nil) has %d words, starting %v", len(all), all[0])
+	}
+}
@@ -0,0 +1,27 @@
+module cs50go
+
+go 1.24.4
+
+require (
+	config v0.0.0
+	exercises v0.0.0
+	exitcode v0.0.0
+	i18n v0.0.0
+	logging v0.0.0
+	profiling v0.0.0
+)
+
+require (
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	runctx v0.0.0 // indirect
+)
+
+replace (
+	config => ../config
+	exercises => ../exercises
+	exitcode => ../exitcode
+	i18n => ../i18n
+	logging => ../logging
+	profiling => ../profiling
+	runctx => ../runctx
+)
@@ -0,0 +1,129 @@
+package exercises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"config"
+)
+
+// ROOT_ENV names the journal's directory when the commands run outside it.
+const ROOT_ENV = "CS50GO_ROOT"
+
+// Build compiles e into the cache directory and returns the binary. Go's
+// build cache makes that instant when nothing changed.
+func (e Program) Build(root string) (string, error) {
+	dir, err := binDir()
+	if err != nil {
+		return "", err
+	}
+	bin := filepath.Join(dir, e.name)
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+	cmd := exec.Command("go", "build", "-o", bin, e.target(root))
+	cmd.Dir = e.Path(root)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("building %s: %v\n%s", e.name, err, out)
+	}
+	return bin, nil
+}
+
+// Run builds e and runs it with stdio, in stdio.Dir or the current
+// directory. An exit code other than 0 is an *exec.ExitError.
+func (e Program) Run(ctx context.Context, stdio IO) error {
+	root, err := Root()
+	if err != nil {
+		return err
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, bin, stdio.Args...)
+	cmd.Dir = stdio.Dir
+	if len(stdio.Env) > 0 {
+		cmd.Env = append(os.Environ(), stdio.Env...)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdio.Stdin, stdio.Stdout, stdio.Stderr
+	return cmd.Run()
+}
+
+// Check is what go thinks of e: it builds, and go vet and its tests pass.
+// A loose file's tests are FILE_test.go next to it; without them it's just
+// go vet. journal check's cases, from the spec, go further.
+func (e Program) Check(ctx context.Context) error {
+	root, err := Root()
+	if err != nil {
+		return err
+	}
+	if _, err := e.Build(root); err != nil {
+		return err
+	}
+	args := []string{"test", "."}
+	if e.Loose(root) {
+		args = []string{"vet", e.File}
+		tests := strings.TrimSuffix(e.File, ".go") + "_test.go"
+		if _, err := os.Stat(filepath.Join(e.Path(root), tests)); err == nil {
+			args = []string{"test", e.File, tests}
+		}
+	}
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = e.Path(root)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go %s %s: %v\n%s", args[0], e.name, err, out)
+	}
+	return nil
+}
+
+// binDir is where the built exercises are kept: in the config's cache_dir,
+// or the user's cache.
+func binDir() (string, error) {
+	cache := config.Get().CacheDir
+	if cache == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		cache = filepath.Join(dir, "cs50go")
+	}
+	dir := filepath.Join(cache, "bin")
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
+// Root is the "CS50 with Go" directory: $CS50GO_ROOT, or the first
+// directory up from here with go.work and week1-C in it.
+func Root() (string, error) {
+	if root := os.Getenv(ROOT_ENV); root != "" {
+		return root, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if isRoot(dir) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("can't find the journal; run it from inside the repo or set " + ROOT_ENV)
+		}
+		dir = parent
+	}
+}
+
+func isRoot(dir string) bool {
+	for _, name := range []string{"go.work", "week1-C"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+// Package exercises is the list of runnable programs in the journal, where
+// they live and how to build them, for the commands that run them: cs50go
+// runs one, journal check tests one.
+//
+//	e, ok := exercises.Find("week1/credit")
+//	root, err := exercises.Root()
+//	bin, err := e.Build(root)
+//
+// The commands that only run, check and list problems take any Exercise
+// that's registered, of which the programs are the most.
+package exercises
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Program is one runnable program in the journal: an Exercise that's
+// built from the tree and run.
+type Program struct {
+	name    string // the subcommand
+	week    int
+	Dir     string // from the repo root, with forward slashes
+	File    string // the file with main: built on its own outside a module
+	summary string
+}
+
+// PROGRAMS are every program in the journal that runs, by week. Starters
+// that don't compile on purpose (the recipe distribution code) aren't here.
+var PROGRAMS = []Program{
+	{"hello", 1, "week1-C/pset-w-go/world", "hello.go", "hello, world, and hello to you"},
+	{"mario", 1, "week1-C/pset-w-go/mario-less", "mario.go", "a right-aligned pyramid of #s"},
+	{"cash", 1, "week1-C/pset-w-go/cash", "cash.go", "the fewest coins for some change"},
+	{"credit", 1, "week1-C/pset-w-go/credit", "credit.go", "is a card number AMEX, MASTERCARD, VISA or INVALID?"},
+	{"convert", 1, "week1-C/pset-w-go/convert", "convert.go", "temperature, length and weight conversions"},
+	{"guess", 1, "week1-C/pset-w-go/guess", "guess.go", "guess the number, with best scores"},
+	{"half", 1, "week1-C/pset-w-go/half", "half.go", "split a bill after tax and tip"},
+	{"population", 1, "week1-C/pset-w-go/population", "population.go", "years for a llama population to grow"},
+	{"tip", 1, "week1-C/pset-w-go/tip", "tip.go", "add a tip and split the meal"},
+
+	{"bulbs", 2, "week2-Array/bulbs", "bulbs.go", "a message as rows of light bulbs"},
+	{"hours", 2, "week2-Array/hours", "hours.go", "a week of working hours as a schedule"},
+	{"no-vowels", 2, "week2-Array/no-vowels", "no-vowels.go", "a word in l33t"},
+	{"password", 2, "week2-Array/password", "password.go", "is a password strong enough?"},
+	{"readability", 2, "week2-Array/readability-problemset2-2", "readability.go", "the Coleman-Liau grade of some text"},
+	{"readability-manual", 2, "week2-Array/readability-problemset2-2", "readability-maual.go", "readability, counting by hand"},
+	{"substitution", 2, "week2-Array/readability-problemset2-2", "substitution.go", "encrypt with a substitution key"},
+	{"scrabble", 2, "week2-Array/scrabble", "scrabble.go", "two words, the higher score wins; -game for a board"},
+	{"wordle", 2, "week2-Array/wordle", "wordle.go", "guess a 5-8 letter word in six tries"},
+	{"wasm", 2, "week2-Array/wasm", "main.go", "readability and substitution from the WebAssembly build"},
+
+	{"popular-skill", 3, "week3-Algorithms", "popular-skill.go", "hello, name"},
+	{"plurality", 3, "week3-Algorithms/plurality", "plurality.go", "one vote each, most votes wins"},
+	{"runoff", 3, "week3-Algorithms/runoff", "runoff.go", "instant runoff on ranked ballots"},
+	{"tideman", 3, "week3-Algorithms/tideman", "tideman.go", "ranked pairs on ranked ballots"},
+	{"election", 3, "week3-Algorithms/election", "election.go", "every voting method on the same ballots"},
+	{"bsearch", 3, "week3-Algorithms/search/bsearch", "bsearch.go", "watch binary search halve the range"},
+	{"sortlab", 3, "week3-Algorithms/sortlab", "sortlab.go", "time the mystery sorts and tell them apart"},
+
+	{"recover", 4, "week4-Memory", "play-recover.go", "JPEGs back from a forensic image: recover card.raw"},
+	{"filter", 4, "week4-Memory/filter", "filter.go", "grayscale, sepia, reflect, blur and edges"},
+	{"volume", 4, "week4-Memory/volume", "volume.go", "scale a WAV file's volume"},
+	{"audiofx", 4, "week4-Memory/audiofx", "audiofx.go", "fade, reverse and speed up a WAV file"},
+	{"sinegen", 4, "week4-Memory/wav/sinegen", "sinegen.go", "write a sine wave WAV file"},
+
+	{"speller", 5, "week5-Data-Strucutes/speller", "speller.go", "spell-check a text; speller bench compares structures"},
+	{"inheritance", 5, "week5-Data-Strucutes/tree/inheritance", "inheritance.go", "blood types down three generations"},
+	{"recipe", 5, "week5-Data-Strucutes/tree/recipe", "recipe.go", "a recipe's ingredients as a tree"},
+	{"recipe-finished", 5, "week5-Data-Strucutes/finished-distributionCode", "recipe-finished.go", "the recipe exercise, finished by hand"},
+	{"maze", 5, "week5-Data-Strucutes/dsu/maze", "maze.go", "a random maze from union-find"},
+	{"degrees", 5, "week5-Data-Strucutes/graph/degrees", "degrees.go", "degrees of separation between actors"},
+	{"listviz", 5, "week5-Data-Strucutes/linkedlist/listviz", "listviz.go", "draw a linked list after every operation"},
+	{"scheduler", 5, "week5-Data-Strucutes/pqueue/scheduler", "scheduler.go", "the most urgent task runs next"},
+
+	{"dna", 6, "week6-Python/dna", "dna.go", "whose DNA is it?"},
+	{"worldcup", 6, "week6-Python/worldcup", "worldcup.go", "simulate the World Cup many times"},
+
+	{"csvdb", 7, "week7-SQL/csvdb", "csvdb.go", "load CSV files into SQLite"},
+	{"sqllab", 7, "week7-SQL/sqllab", "sqllab.go", "the songs and movies queries"},
+	{"movies", 7, "week7-SQL/movies/cmd/movies", "main.go", "the movies answers as typed Go"},
+	{"fiftyville", 7, "week7-SQL/fiftyville", "fiftyville.go", "an investigation console for the mystery"},
+
+	{"serve", 8, "week8-HTML-CSS-JS/serve", "serve.go", "serve a directory over HTTP"},
+	{"trivia", 8, "week8-HTML-CSS-JS/trivia", "trivia.go", "the trivia lab as a web app"},
+	{"birthdays", 8, "week8-HTML-CSS-JS/birthdays", "birthdays.go", "the birthdays lab as a web app"},
+
+	{"finance", 9, "week9-Flask/finance", "finance.go", "C$50 Finance as a web app"},
+	{"api", 9, "week9-Flask/api", "api.go", "the psets as JSON endpoints"},
+}
+
+// Find returns the program called name, or id: "credit" or "week1/credit".
+// Lookup finds any exercise; Find is for the commands that need its files.
+func Find(name string) (Program, bool) {
+	for _, e := range PROGRAMS {
+		if e.name == name || e.ID() == name {
+			return e, true
+		}
+	}
+	return Program{}, false
+}
+
+func (e Program) Name() string    { return e.name }
+func (e Program) Week() int       { return e.week }
+func (e Program) Summary() string { return e.summary }
+
+// ID is the program's week and name, "week1/credit".
+func (e Program) ID() string {
+	return ID(e)
+}
+
+// Path is e's directory under root.
+func (e Program) Path(root string) string {
+	return filepath.Join(root, filepath.FromSlash(e.Dir))
+}
+
+// Loose reports whether e is a file on its own, outside any module below
+// root, like week4-Memory/play-recover.go, rather than a package.
+func (e Program) Loose(root string) bool {
+	for dir := e.Path(root); len(dir) > len(root); dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// target is what `go build` builds in e.Dir: the package, when it's in a
+// module, or else the one file, since folders of loose programs like
+// readability-problemset2-2 have a main in every file.
+func (e Program) target(root string) string {
+	if e.Loose(root) {
+		return e.File
+	}
+	return "."
+}
+
+// Notes is the Markdown file where e's notes are: FILE.md next to its
+// main file, like play-recover.md, or the folder's README. When there are
+// none yet it's where they should go: the README for a package, FILE.md
+// for a loose file, whose folder has other programs in it.
+func (e Program) Notes(root string) string {
+	dir := e.Path(root)
+	own := strings.TrimSuffix(e.File, ".go") + ".md"
+	for _, name := range []string{own, "README.md", "readme.md"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return filepath.Join(dir, name)
+		}
+	}
+	if e.Loose(root) {
+		return filepath.Join(dir, own)
+	}
+	return filepath.Join(dir, "README.md")
+}
+
+// Doc is the comment at the top of e's main file, which is where the
+// exercises explain themselves, or "" when it has none.
+func (e Program) Doc(root string) (string, error) {
+	path := filepath.Join(e.Path(root), e.File)
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+	var doc strings.Builder
+	for _, c := range f.Comments {
+		if c.End() < f.Package {
+			doc.WriteString(c.Text())
+		}
+	}
+	return strings.TrimSpace(doc.String()), nil
+}
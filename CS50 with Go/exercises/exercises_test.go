@@ -0,0 +1,174 @@
+package exercises
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Every exercise points at a real program, once, in week order.
+func TestExercises(t *testing.T) {
+	// cs50go's own commands can't be exercise names
+	seen := map[string]bool{"list": true, "help": true, "build": true}
+	week := 0
+	for _, e := range PROGRAMS {
+		if seen[e.name] {
+			t.Errorf("%s: name taken", e.name)
+		}
+		seen[e.name] = true
+		if e.week < week {
+			t.Errorf("%s: week %d after week %d", e.name, e.week, week)
+		}
+		week = e.week
+
+		path := filepath.Join(e.Path(".."), e.File)
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Errorf("%s: %v", e.name, err)
+			continue
+		}
+		hasMain := false
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "main" && fn.Recv == nil {
+				hasMain = true
+			}
+		}
+		if f.Name.Name != "main" || !hasMain {
+			t.Errorf("%s: %s has no func main", e.name, path)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	for _, name := range []string{"credit", "week1/credit"} {
+		if e, ok := Find(name); !ok || e.Dir != "week1-C/pset-w-go/credit" {
+			t.Errorf("Find(%q) = %+v, %v", name, e, ok)
+		}
+	}
+	for _, name := range []string{"week2/credit", "caesar", ""} {
+		if e, ok := Find(name); ok {
+			t.Errorf("Find(%q) = %+v", name, e)
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	t.Setenv(ROOT_ENV, "")
+	root, err := Root()
+	if want, _ := filepath.Abs(".."); err != nil || root != want {
+		t.Errorf("Root() = %q, %v, want %q", root, err, want)
+	}
+	t.Setenv(ROOT_ENV, "/somewhere/else")
+	if root, _ := Root(); root != "/somewhere/else" {
+		t.Errorf("Root() with %s = %q", ROOT_ENV, root)
+	}
+	t.Chdir(os.TempDir())
+	t.Setenv(ROOT_ENV, "")
+	if _, err := Root(); err == nil {
+		t.Error("Root() outside the journal: want error")
+	}
+}
+
+func TestTarget(t *testing.T) {
+	for name, want := range map[string]string{"half": ".", "inheritance": ".", "credit": "credit.go", "substitution": "substitution.go"} {
+		e, _ := Find(name)
+		if got := e.target(".."); got != want {
+			t.Errorf("%s: target %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNotes(t *testing.T) {
+	for name, want := range map[string]string{
+		"recover":      "week4-Memory/play-recover.md",
+		"credit":       "week1-C/pset-w-go/credit/readme.md",
+		"dna":          "week6-Python/dna/README.md",
+		"substitution": "week2-Array/readability-problemset2-2/substitution.md", // none yet
+	} {
+		e, _ := Find(name)
+		if got := e.Notes(".."); got != filepath.Join("..", filepath.FromSlash(want)) {
+			t.Errorf("%s: notes %q, want %q", name, got, want)
+		}
+	}
+}
+
+// fake is an exercise that isn't a program, as a plugin would be.
+type fake struct{ name string }
+
+func (f fake) Name() string                    { return f.name }
+func (f fake) Week() int                       { return 2 }
+func (f fake) Summary() string                 { return "a fake" }
+func (f fake) Run(context.Context, IO) error   { return nil }
+func (f fake) Check(ctx context.Context) error { return ctx.Err() }
+
+func TestRegister(t *testing.T) {
+	saved := registry
+	t.Cleanup(func() { registry = saved })
+	Register(fake{"fake"})
+
+	all := All()
+	if len(all) != len(PROGRAMS)+1 {
+		t.Fatalf("All() has %d, want %d", len(all), len(PROGRAMS)+1)
+	}
+	// After week 2's programs and before week 3's.
+	i := slices.IndexFunc(all, func(e Exercise) bool { return e.Name() == "fake" })
+	if i < 0 || all[i-1].Week() != 2 || all[i+1].Week() != 3 {
+		t.Errorf("fake is at %d of All(), between %v and %v", i, all[i-1], all[i+1])
+	}
+	for _, name := range []string{"fake", "week2/fake"} {
+		if e, ok := Lookup(name); !ok || e != (fake{"fake"}) {
+			t.Errorf("Lookup(%q) = %v, %v", name, e, ok)
+		}
+	}
+	if e, ok := Lookup("credit"); !ok || ID(e) != "week1/credit" {
+		t.Errorf("Lookup(credit) = %v, %v", e, ok)
+	}
+	if _, ok := Find("fake"); ok {
+		t.Error("Find(fake) found a program")
+	}
+
+	for _, name := range []string{"fake", "credit", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) didn't panic", name)
+				}
+			}()
+			Register(fake{name})
+		}()
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds half and runs go test")
+	}
+	t.Setenv(ROOT_ENV, "..")
+	e, _ := Find("half")
+	var out strings.Builder
+	err := e.Run(context.Background(), IO{Stdin: strings.NewReader("50\n10\n20\n"), Stdout: &out, Stderr: &out})
+	if err != nil || !strings.Contains(out.String(), "You will owe $33.00 each!") {
+		t.Errorf("Run(half) = %v, printing %q", err, out.String())
+	}
+	if err := e.Check(context.Background()); err != nil {
+		t.Errorf("Check(half) = %v", err)
+	}
+	// A loose file's tests run too, on their own.
+	for _, name := range []string{"credit", "recover"} {
+		e, _ := Find(name)
+		if err := e.Check(context.Background()); err != nil {
+			t.Errorf("Check(%s) = %v", name, err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := e.Run(ctx, IO{}); err == nil {
+		t.Error("Run(half) after ctx was done: want error")
+	}
+}
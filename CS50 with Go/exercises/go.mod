@@ -0,0 +1,9 @@
+module exercises
+
+go 1.24.4
+
+require config v0.0.0
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
+replace config => ../config
@@ -0,0 +1,77 @@
+package exercises
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Exercise is a problem as the commands that run, check and list them see
+// it: cs50go, journal check, the dashboard and the progress tracker. Every
+// Program is one. A problem that isn't a program in the tree, or needs
+// more than one to run, implements it and calls Register, and all of them
+// have it.
+type Exercise interface {
+	Name() string // the subcommand, "credit"
+	Week() int
+	Summary() string // a line, for the lists
+	// Run runs it on stdio until it ends or ctx is done. An error with an
+	// ExitCode method, like *exec.ExitError's, is the exit code it ended
+	// with; any other is that it couldn't run.
+	Run(ctx context.Context, stdio IO) error
+	// Check tests it, with nil when it passes.
+	Check(ctx context.Context) error
+}
+
+// IO is what an exercise runs with.
+type IO struct {
+	Args   []string
+	Dir    string   // where it runs; "" is the current directory
+	Env    []string // KEY=value, added to the environment, like cs50go's profiling
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// registry is every exercise, in the order they were registered.
+var registry []Exercise
+
+func init() {
+	for _, p := range PROGRAMS {
+		Register(p)
+	}
+}
+
+// Register adds e to the exercises. Two with one name is a mistake in the
+// code, so it panics, like http.Handle.
+func Register(e Exercise) {
+	if _, ok := Lookup(e.Name()); ok || e.Name() == "" {
+		panic(fmt.Sprintf("exercises: Register of %q twice, or with no name", e.Name()))
+	}
+	registry = append(registry, e)
+}
+
+// All is every exercise, by week, and in the order they were registered
+// within one.
+func All() []Exercise {
+	all := slices.Clone(registry)
+	slices.SortStableFunc(all, func(a, b Exercise) int { return a.Week() - b.Week() })
+	return all
+}
+
+// Lookup returns the exercise called name, or id: "credit" or
+// "week1/credit".
+func Lookup(name string) (Exercise, bool) {
+	for _, e := range registry {
+		if e.Name() == name || ID(e) == name {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// ID is e's week and name, "week1/credit", which progress is kept by.
+func ID(e Exercise) string {
+	return fmt.Sprintf("week%d/%s", e.Week(), e.Name())
+}
@@ -0,0 +1,146 @@
+// Package exitcode is what an error means for how a program exits. A
+// program's run returns an error of a kind instead of calling os.Exit
+// wherever it is, and Main, the one place it exits, prints it and picks
+// the code:
+//
+//	func main() {
+//		exitcode.Main(run)
+//	}
+//
+//	func run(ctx context.Context, args []string) error {
+//		if len(args) != 1 {
+//			return exitcode.Usage("Usage: ./recover IMAGE", nil)
+//		}
+//		...
+//	}
+//
+// run is a plain function then, to call from a test with its arguments and
+// look at what it returned.
+package exitcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"runctx"
+)
+
+// The codes, one a kind.
+const (
+	OK          = 0
+	USAGE       = 1                       // the arguments are wrong: CS50's "return 1"
+	DATA        = 2                       // the input, or a file, is bad or can't be used
+	INTERNAL    = 70                      // a bug, or what nothing classified: sysexits' EX_SOFTWARE
+	INTERRUPTED = runctx.EXIT_INTERRUPTED // Ctrl-C; kill is runctx.EXIT_TERMINATED
+)
+
+// The kinds, for errors.Is.
+var (
+	ErrUsage    = errors.New("usage")
+	ErrData     = errors.New("bad data")
+	ErrInternal = errors.New("internal error")
+)
+
+// kindError is an error of a kind: a message, and the error behind it,
+// if there is one.
+type kindError struct {
+	kind error
+	msg  string
+	err  error
+}
+
+func (e *kindError) Error() string {
+	if e.err == nil {
+		return e.msg
+	}
+	return e.msg + ": " + e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	if e.err == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.err}
+}
+
+// Usage is a usage error: msg is what to show, like the usage line or
+// what's wrong with a key, and err what went wrong, or nil.
+func Usage(msg string, err error) error {
+	return &kindError{ErrUsage, msg, err}
+}
+
+// Data is a data error: a file that can't be read or written, input that
+// makes no sense.
+func Data(msg string, err error) error {
+	return &kindError{ErrData, msg, err}
+}
+
+// Internal is an internal error, which shouldn't happen.
+func Internal(msg string, err error) error {
+	return &kindError{ErrInternal, msg, err}
+}
+
+// exited is an error that is another program's exit code, like
+// *exec.ExitError: a launcher's run returns it to exit as the program did.
+type exited interface {
+	ExitCode() int
+}
+
+// Code is the exit code for err: 0 for nil, the signal's when a signal
+// stopped the program, the code of a program that exited, and INTERNAL for
+// an error of no kind.
+func Code(err error) int {
+	var cause runctx.Interrupted
+	var exit exited
+	switch {
+	case err == nil:
+		return OK
+	case errors.As(err, &cause):
+		return cause.Code()
+	case errors.Is(err, context.Canceled):
+		return INTERRUPTED
+	case errors.As(err, &exit) && exit.ExitCode() >= 0:
+		// -1 is a program a signal killed, which didn't exit.
+		return exit.ExitCode()
+	case errors.Is(err, ErrUsage):
+		return USAGE
+	case errors.Is(err, ErrData):
+		return DATA
+	}
+	return INTERNAL
+}
+
+// Main runs run with the program's context and its arguments, reports
+// the error it returns, if any, and exits with its code through runctx, so
+// the cleanups run: the one place a program exits.
+func Main(run func(ctx context.Context, args []string) error) {
+	runctx.Main(func(ctx context.Context) int {
+		return Handle(os.Stderr, run(ctx, os.Args[1:]))
+	})
+}
+
+// Handle reports err and returns its code. A message alone, a usage line,
+// is the program's to say, so it's written on w as it is; an error behind
+// it is logged, and so is an error of no kind, as -v and --log-format say.
+// An interruption says nothing a user doesn't know, and a program that
+// exited has said what it had to.
+func Handle(w io.Writer, err error) int {
+	code := Code(err)
+	var kind *kindError
+	var exit exited
+	switch {
+	case err == nil || code == INTERRUPTED || code == runctx.EXIT_TERMINATED:
+	case errors.As(err, &exit) && exit.ExitCode() >= 0:
+	case !errors.As(err, &kind):
+		slog.Error("internal error", "err", err)
+	case kind.err == nil:
+		fmt.Fprintln(w, err)
+	default:
+		slog.Error(kind.msg, "err", kind.err)
+	}
+	return code
+}
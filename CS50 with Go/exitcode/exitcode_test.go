@@ -0,0 +1,83 @@
+package exitcode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"syscall"
+	"testing"
+
+	"runctx"
+)
+
+// exitedWith is what *exec.ExitError is to Code: a program's exit code.
+type exitedWith int
+
+func (e exitedWith) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitedWith) ExitCode() int { return int(e) }
+
+func TestCode(t *testing.T) {
+	missing := &os.PathError{Op: "open", Path: "card.raw", Err: os.ErrNotExist}
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, OK},
+		{Usage("Usage: ./substitution key", nil), USAGE},
+		{fmt.Errorf("key: %w", Usage("Key must contain 26 characters.", nil)), USAGE},
+		{Usage("can't open the image", missing), USAGE},
+		{Data("can't read the image", missing), DATA},
+		{Internal("can't print the report", errors.New("render: int isn't a struct")), INTERNAL},
+		{missing, INTERNAL},
+		{context.Canceled, INTERRUPTED},
+		{runctx.Interrupted{Signal: os.Interrupt}, INTERRUPTED},
+		{fmt.Errorf("reading: %w", runctx.Interrupted{Signal: syscall.SIGTERM}), runctx.EXIT_TERMINATED},
+		{exitedWith(3), 3},
+		{fmt.Errorf("credit: %w", exitedWith(1)), 1},
+		{exitedWith(-1), INTERNAL},
+	}
+	for _, test := range tests {
+		if got := Code(test.err); got != test.want {
+			t.Errorf("Code(%v) = %d, want %d", test.err, got, test.want)
+		}
+	}
+	if err := Data("can't open the image", missing); !errors.Is(err, os.ErrNotExist) || err.Error() != "can't open the image: "+missing.Error() {
+		t.Errorf("Data(..., %v) = %v, which should wrap it", missing, err)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	var log bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&log, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+	tests := []struct {
+		err       error
+		out, logs string
+		code      int
+	}{
+		{nil, "", "", OK},
+		{Usage("Usage: ./substitution key", nil), "Usage: ./substitution key\n", "", USAGE},
+		{Usage("can't open the image", os.ErrNotExist), "", "level=ERROR msg=\"can't open the image\" err=\"file does not exist\"\n", USAGE},
+		{Data("can't read the image", errors.New("EIO")), "", "level=ERROR msg=\"can't read the image\" err=EIO\n", DATA},
+		{errors.New("nil map"), "", "level=ERROR msg=\"internal error\" err=\"nil map\"\n", INTERNAL},
+		{runctx.Interrupted{Signal: os.Interrupt}, "", "", INTERRUPTED},
+		{exitedWith(4), "", "", 4},
+	}
+	for _, test := range tests {
+		var out bytes.Buffer
+		log.Reset()
+		if code := Handle(&out, test.err); code != test.code || out.String() != test.out || log.String() != test.logs {
+			t.Errorf("Handle(%v) = %d, %q, logging %q, want %d, %q, %q", test.err, code, out.String(), log.String(), test.code, test.out, test.logs)
+		}
+	}
+}
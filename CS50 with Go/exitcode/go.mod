@@ -0,0 +1,7 @@
+module exitcode
+
+go 1.24.4
+
+require runctx v0.0.0
+
+replace runctx => ../runctx
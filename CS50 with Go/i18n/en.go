@@ -0,0 +1,32 @@
+package i18n
+
+// EN is every message, in English: what the programs printed before there
+// was a Thai one, to the byte, since their checks compare it.
+var EN = map[string]string{
+	"mario.prompt": "Actual Height= ",
+
+	"cash.prompt":           "Change owed: %s",
+	"cash.unknown_currency": "Unknown currency %q (want one of %s)",
+	"cash.too_small":        "(%d too small for any coin)",
+
+	"credit.prompt":   "creditnumber: ",
+	"credit.checksum": "Yee! that's credit card for sure now let me see what is your card ^_^, Pls wait a second.",
+
+	"no-vowels.usage": "Usage: ./no-vowels word",
+
+	"readability.prompt": "Book Detail : ",
+	"readability.before": "Before Grade 1",
+	"readability.grade":  "Grade %d",
+	"readability.16plus": "Grade 16+",
+
+	"substitution.usage":      "Usage: ./substitution key",
+	"substitution.length":     "Key must contain 26 characters.",
+	"substitution.alpha":      "Key must only contain alphabetic characters. ",
+	"substitution.repeated":   "Key must not contain repeated characters.",
+	"substitution.plaintext":  "plaintext: ",
+	"substitution.ciphertext": "ciphertext: ",
+
+	"scrabble.player": "Player %d: ",
+	"scrabble.wins":   "Player %d wins!",
+	"scrabble.tie":    "Tie!",
+}
@@ -0,0 +1,3 @@
+module i18n
+
+go 1.24.4
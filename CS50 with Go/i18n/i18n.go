@@ -0,0 +1,135 @@
+// Package i18n is the psets' messages in English and Thai: their prompts,
+// usage lines and verdicts, by key, so a program prints the same thing in
+// either.
+//
+//	func main() {
+//		i18n.Init() // takes --lang th off os.Args
+//		text := cs50.GetString(i18n.T("readability.prompt"))
+//		fmt.Println(i18n.T("readability.grade", 7))
+//	}
+//
+// The language is --lang, or $CS50GO_LANG, which cs50go sets from the
+// config's language, or English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// LANG_ENV names the language when there's no --lang.
+const LANG_ENV = "CS50GO_LANG"
+
+// CATALOGS are the messages by language, and then by key. Every key is in
+// English; a language without one falls back on English's.
+var CATALOGS = map[string]map[string]string{
+	"en": EN,
+	"th": TH,
+}
+
+var (
+	mu   sync.Mutex
+	lang = "en"
+)
+
+// Init takes --lang LANG, or --lang=LANG, off os.Args, with one dash too,
+// before any flag.Parse, and sets the language from it or $CS50GO_LANG.
+// A language with no catalog is a usage error, so it exits with 2, as
+// flag.Parse does.
+func Init() {
+	args, err := Args(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Args = args
+}
+
+// Args returns args without --lang and its value, and sets the language:
+// the one given, or $CS50GO_LANG's.
+func Args(args []string) ([]string, error) {
+	chosen := os.Getenv(LANG_ENV)
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "lang" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("--lang needs a language: %s", strings.Join(Languages(), " or "))
+			}
+			i++
+			value = args[i]
+		}
+		chosen = value
+	}
+	if chosen == "" {
+		chosen = "en"
+	}
+	return rest, Set(chosen)
+}
+
+// Set makes l the language, "en" or "th".
+func Set(l string) error {
+	if _, ok := CATALOGS[l]; !ok {
+		return fmt.Errorf("no %q messages: the languages are %s", l, strings.Join(Languages(), " and "))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	lang = l
+	return nil
+}
+
+// Setenv makes l, the config's language, the one for the programs a tool
+// runs from here on, unless $CS50GO_LANG already says which.
+func Setenv(l string) error {
+	if os.Getenv(LANG_ENV) != "" {
+		return nil
+	}
+	if _, ok := CATALOGS[l]; !ok {
+		return fmt.Errorf("language is %q, not one of %s", l, strings.Join(Languages(), ", "))
+	}
+	return os.Setenv(LANG_ENV, l)
+}
+
+// Lang is the language the messages are in.
+func Lang() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return lang
+}
+
+// Languages are the languages there are catalogs for, sorted.
+func Languages() []string {
+	var all []string
+	for l := range CATALOGS {
+		all = append(all, l)
+	}
+	slices.Sort(all)
+	return all
+}
+
+// T is the message key in the language, with args filled in as by
+// fmt.Sprintf. A key no catalog has is itself, so it shows up.
+func T(key string, args ...any) string {
+	message, ok := CATALOGS[Lang()][key]
+	if !ok {
+		if message, ok = EN[key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// VERB is a fmt verb, with its flags and width.
+var VERB = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+func TestCatalogs(t *testing.T) {
+	for lang, catalog := range CATALOGS {
+		for key, message := range catalog {
+			english, ok := EN[key]
+			if !ok {
+				t.Errorf("%s has %q, which English doesn't", lang, key)
+				continue
+			}
+			// The same arguments go into every language's message.
+			if got, want := VERB.FindAllString(message, -1), VERB.FindAllString(english, -1); !slices.Equal(got, want) {
+				t.Errorf("%s's %q has %q, English's %q", lang, key, got, want)
+			}
+		}
+		if lang == "en" {
+			continue
+		}
+		for key := range EN {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("%s has no %q", lang, key)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	t.Cleanup(func() { Set("en") })
+	EN["test.english-only"] = "only in English"
+	t.Cleanup(func() { delete(EN, "test.english-only") })
+
+	tests := []struct {
+		lang, key string
+		args      []any
+		want      string
+	}{
+		{"en", "readability.before", nil, "Before Grade 1"},
+		{"en", "readability.grade", []any{7}, "Grade 7"},
+		{"en", "substitution.alpha", nil, "Key must only contain alphabetic characters. "},
+		{"th", "readability.grade", []any{7}, "ระดับชั้นปีที่ 7"},
+		{"th", "scrabble.wins", []any{2}, "ผู้เล่น 2 ชนะ!"},
+		{"th", "test.english-only", nil, "only in English"},
+		{"th", "no.such.key", nil, "no.such.key"},
+	}
+	for _, test := range tests {
+		if err := Set(test.lang); err != nil {
+			t.Fatal(err)
+		}
+		if got := T(test.key, test.args...); got != test.want {
+			t.Errorf("%s: T(%q, %v) = %q, want %q", test.lang, test.key, test.args, got, test.want)
+		}
+	}
+	if err := Set("fr"); err == nil || Lang() != "th" {
+		t.Errorf("Set(fr) = %v, and the language is %s", err, Lang())
+	}
+}
+
+func TestArgs(t *testing.T) {
+	t.Cleanup(func() { Set("en") })
+	tests := []struct {
+		env  string
+		args []string
+		rest []string
+		lang string
+		err  bool
+	}{
+		{"", []string{"mario"}, []string{"mario"}, "en", false},
+		{"th", []string{"mario"}, []string{"mario"}, "th", false},
+		{"", []string{"scrabble", "--lang", "th", "-game"}, []string{"scrabble", "-game"}, "th", false},
+		{"", []string{"cash", "-lang=th", "-currency", "thb"}, []string{"cash", "-currency", "thb"}, "th", false},
+		{"th", []string{"cash", "--lang=en"}, []string{"cash"}, "en", false},
+		{"", []string{"no-vowels", "--", "--lang"}, []string{"no-vowels", "--", "--lang"}, "en", false},
+		{"", []string{"no-vowels", "--lang"}, nil, "en", true},
+		{"", []string{"no-vowels", "--lang", "fr"}, nil, "en", true},
+		{"fr", []string{"no-vowels"}, nil, "en", true},
+	}
+	for _, test := range tests {
+		t.Setenv(LANG_ENV, test.env)
+		Set("en")
+		rest, err := Args(test.args)
+		name := fmt.Sprintf("$%s=%q, Args(%q)", LANG_ENV, test.env, test.args)
+		switch {
+		case test.err != (err != nil):
+			t.Errorf("%s: error %v", name, err)
+		case !test.err && !slices.Equal(rest, test.rest):
+			t.Errorf("%s = %q, want %q", name, rest, test.rest)
+		case Lang() != test.lang:
+			t.Errorf("%s: the language is %s, want %s", name, Lang(), test.lang)
+		}
+	}
+}
+
+func TestSetenv(t *testing.T) {
+	t.Setenv(LANG_ENV, "")
+	if err := Setenv("de"); err == nil {
+		t.Error("Setenv(de) = nil, want an error")
+	}
+	if err := Setenv("th"); err != nil || os.Getenv(LANG_ENV) != "th" {
+		t.Errorf("Setenv(th) = %v, $%s = %q", err, LANG_ENV, os.Getenv(LANG_ENV))
+	}
+	// What's set already wins, as --lang does over it.
+	if err := Setenv("en"); err != nil || os.Getenv(LANG_ENV) != "th" {
+		t.Errorf("Setenv(en) = %v, $%s = %q", err, LANG_ENV, os.Getenv(LANG_ENV))
+	}
+}
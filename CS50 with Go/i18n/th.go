@@ -0,0 +1,32 @@
+package i18n
+
+// TH is the messages in Thai. The card networks, AMEX, MASTERCARD and VISA,
+// and INVALID stay as the spec has them, so they aren't here.
+var TH = map[string]string{
+	"mario.prompt": "ความสูง= ",
+
+	"cash.prompt":           "เงินทอนที่ต้องจ่าย: %s",
+	"cash.unknown_currency": "ไม่รู้จักสกุลเงิน %q (ต้องเป็นหนึ่งใน %s)",
+	"cash.too_small":        "(เหลือ %d ซึ่งน้อยกว่าเหรียญที่เล็กที่สุด)",
+
+	"credit.prompt":   "หมายเลขบัตร: ",
+	"credit.checksum": "เย้! เป็นหมายเลขบัตรเครดิตแน่นอน ขอดูก่อนว่าเป็นบัตรอะไร ^_^ รอสักครู่นะ",
+
+	"no-vowels.usage": "วิธีใช้: ./no-vowels คำ",
+
+	"readability.prompt": "ข้อความ : ",
+	"readability.before": "ต่ำกว่าชั้น ป.1",
+	"readability.grade":  "ระดับชั้นปีที่ %d",
+	"readability.16plus": "ระดับชั้นปีที่ 16 ขึ้นไป",
+
+	"substitution.usage":      "วิธีใช้: ./substitution คีย์",
+	"substitution.length":     "คีย์ต้องมี 26 ตัวอักษร",
+	"substitution.alpha":      "คีย์ต้องเป็นตัวอักษรภาษาอังกฤษเท่านั้น",
+	"substitution.repeated":   "คีย์ต้องไม่มีตัวอักษรซ้ำกัน",
+	"substitution.plaintext":  "ข้อความต้นฉบับ: ",
+	"substitution.ciphertext": "ข้อความเข้ารหัส: ",
+
+	"scrabble.player": "ผู้เล่น %d: ",
+	"scrabble.wins":   "ผู้เล่น %d ชนะ!",
+	"scrabble.tie":    "เสมอ!",
+}
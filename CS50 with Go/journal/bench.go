@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"config"
+	"exercises"
+)
+
+const BENCH_USAGE = "Usage: journal bench WEEK/PROBLEM [-n N] [-json]"
+
+// benchFile is what to time a problem on, from testdata/NAME.bench.json.
+type benchFile struct {
+	C      string       `json:"c,omitempty"` // the C version, from the problem's directory; NAME.c if left out
+	Inputs []benchInput `json:"inputs"`
+}
+
+// benchInput is one input both versions run on. Each run is in a fresh
+// directory, so the files a program writes don't pile up: {dir} in Args
+// is the problem's directory, for the files it reads.
+type benchInput struct {
+	Name  string   `json:"name"`
+	Args  []string `json:"args,omitempty"`
+	Input []string `json:"input,omitempty"` // lines on standard input, which then ends
+}
+
+// benchResult is how one version did on one input.
+type benchResult struct {
+	Input       string  `json:"input"`
+	Version     string  `json:"version"` // C or Go
+	Runs        int     `json:"runs"`
+	MeanSeconds float64 `json:"mean_seconds"`
+	MinSeconds  float64 `json:"min_seconds"`
+	MaxRSS      int64   `json:"max_rss_bytes,omitempty"` // the most memory any run used; 0 where it can't be told
+}
+
+// benchReport is everything bench measured, as -json prints it.
+type benchReport struct {
+	Problem string        `json:"problem"`
+	C       string        `json:"c,omitempty"` // the C file, or "" when there's only Go
+	Results []benchResult `json:"results"`
+}
+
+func benchCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(w)
+	runs := flags.Int("n", 10, "runs of each version on each input, after one to warm up")
+	asJSON := flags.Bool("json", config.Get().Format == "json", "print the results as JSON")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *runs < 1 {
+		fmt.Fprintln(w, BENCH_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	dir := e.Path(root)
+	path := filepath.Join(dir, "testdata", e.Name()+".bench.json")
+	bench, err := loadBench(path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(w, "No inputs to time it on yet: add them to %s\n", path)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	report := benchReport{Problem: e.ID()}
+	versions := map[string]string{}
+	if versions["Go"], err = e.Build(root); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	source := cmp.Or(bench.C, e.Name()+".c")
+	if _, err := os.Stat(filepath.Join(dir, source)); err == nil {
+		tmp, err := os.MkdirTemp("", "journal-bench-")
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		defer os.RemoveAll(tmp)
+		if versions["C"], err = buildC(filepath.Join(dir, source), tmp); err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		report.C = source
+	}
+
+	for _, in := range bench.Inputs {
+		for _, version := range []string{"C", "Go"} {
+			bin, ok := versions[version]
+			if !ok {
+				continue
+			}
+			result, err := benchmark(bin, dir, in, *runs)
+			if err != nil {
+				fmt.Fprintf(w, "%s on %s: %v\n", version, in.Name, err)
+				return 2
+			}
+			result.Version = version
+			report.Results = append(report.Results, result)
+		}
+	}
+
+	if *asJSON {
+		data, _ := json.MarshalIndent(report, "", "    ")
+		fmt.Fprintf(w, "%s\n", data)
+		return 0
+	}
+	printBench(w, report, *runs)
+	return 0
+}
+
+// loadBench reads a bench file and makes sure every input has a name.
+func loadBench(path string) (benchFile, error) {
+	var bench benchFile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return bench, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&bench); err != nil {
+		return bench, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(bench.Inputs) == 0 {
+		return bench, fmt.Errorf("%s: no inputs", path)
+	}
+	for i, in := range bench.Inputs {
+		if in.Name == "" {
+			return bench, fmt.Errorf("%s: input %d has no name", path, i+1)
+		}
+	}
+	return bench, nil
+}
+
+// buildC compiles a C file into dir the way the course does, optimised,
+// with $CC or cc.
+func buildC(source, dir string) (string, error) {
+	compiler := cmp.Or(os.Getenv("CC"), "cc")
+	bin := filepath.Join(dir, strings.TrimSuffix(filepath.Base(source), ".c"))
+	cmd := exec.Command(compiler, "-O2", "-o", bin, source, "-lm")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("building %s: %v\n%s", filepath.Base(source), err, out)
+	}
+	return bin, nil
+}
+
+// benchmark runs bin on in once to warm the caches up and then runs times,
+// and returns how long they took and the most memory any of them used.
+func benchmark(bin, dir string, in benchInput, runs int) (benchResult, error) {
+	result := benchResult{Input: in.Name, Runs: runs}
+	var total time.Duration
+	for i := range runs + 1 {
+		wall, rss, err := runTimed(bin, dir, in)
+		if err != nil {
+			return result, err
+		}
+		if i == 0 {
+			continue
+		}
+		total += wall
+		if i == 1 || wall.Seconds() < result.MinSeconds {
+			result.MinSeconds = wall.Seconds()
+		}
+		result.MaxRSS = max(result.MaxRSS, rss)
+	}
+	result.MeanSeconds = total.Seconds() / float64(runs)
+	return result, nil
+}
+
+// runTimed runs bin on in, in a directory of its own, and returns how long
+// it took and its peak resident memory. A run that fails is an error: a
+// time for a crash means nothing.
+func runTimed(bin, dir string, in benchInput) (time.Duration, int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	tmp, err := os.MkdirTemp("", "journal-bench-run-")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.RemoveAll(tmp)
+	args := make([]string, len(in.Args))
+	for i, arg := range in.Args {
+		args[i] = strings.ReplaceAll(arg, "{dir}", dir)
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = tmp
+	if len(in.Input) > 0 {
+		cmd.Stdin = strings.NewReader(strings.Join(in.Input, "\n") + "\n")
+	}
+	var out limitedBuffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	start := time.Now()
+	err = cmd.Run()
+	wall := time.Since(start)
+	switch {
+	case ctx.Err() != nil:
+		return 0, 0, fmt.Errorf("still running after %v", TIMEOUT)
+	case err != nil:
+		return 0, 0, fmt.Errorf("%v: %s", err, shorten(out.String()))
+	}
+	return wall, maxRSS(cmd.ProcessState), nil
+}
+
+// printBench writes the results as a table, with how Go compares to C
+// under each input that has both.
+func printBench(w io.Writer, report benchReport, runs int) {
+	if report.C == "" {
+		fmt.Fprintf(w, "%s, with no C version to compare: %d runs on each input.\n\n", report.Problem, runs)
+	} else {
+		fmt.Fprintf(w, "%s against %s: %d runs of each on each input.\n\n", report.Problem, report.C, runs)
+	}
+	fmt.Fprintf(w, "%-16s %-4s %10s %10s %10s\n", "input", "", "mean", "fastest", "memory")
+	for i, r := range report.Results {
+		fmt.Fprintf(w, "%-16s %-4s %10s %10s %10s\n", r.Input, r.Version,
+			formatSeconds(r.MeanSeconds), formatSeconds(r.MinSeconds), formatBytes(r.MaxRSS))
+		if i == 0 || r.Version != "Go" || report.Results[i-1].Version != "C" {
+			continue
+		}
+		c := report.Results[i-1]
+		line := fmt.Sprintf("Go takes %.2fx C's time", r.MeanSeconds/c.MeanSeconds)
+		if r.MaxRSS > 0 && c.MaxRSS > 0 {
+			line += fmt.Sprintf(" and %.1fx its memory", float64(r.MaxRSS)/float64(c.MaxRSS))
+		}
+		fmt.Fprintf(w, "%-21s %s\n", "", line)
+	}
+	if slices.ContainsFunc(report.Results, func(r benchResult) bool { return r.MaxRSS == 0 }) {
+		fmt.Fprintln(w, "\nA ? is less memory than journal's own, which it can't measure below.")
+	}
+}
+
+// formatSeconds is a run's time to three significant figures or so.
+func formatSeconds(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+// formatBytes is a size in KB or MB, or "?" when it's not known.
+func formatBytes(n int64) string {
+	switch {
+	case n <= 0:
+		return "?"
+	case n < 1<<20:
+		return fmt.Sprintf("%d KB", n>>10)
+	}
+	return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// maxRSS is 0 where the peak memory of a process can't be read.
+func maxRSS(state *os.ProcessState) int64 {
+	return 0
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// maxRSS is the most memory a finished process had resident, in bytes, or
+// 0 when it can't be told. A child's peak starts at its parent's when it
+// starts, before exec, so one no bigger than journal's own could be
+// journal's rather than the program's.
+func maxRSS(state *os.ProcessState) int64 {
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	var self syscall.Rusage
+	if !ok || syscall.Getrusage(syscall.RUSAGE_SELF, &self) != nil || usage.Maxrss <= self.Maxrss {
+		return 0
+	}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "ios" {
+		return int64(usage.Maxrss) // bytes there, kilobytes everywhere else
+	}
+	return int64(usage.Maxrss) << 10
+}
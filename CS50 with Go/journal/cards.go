@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cs50"
+)
+
+const CARDS_USAGE = `Usage:
+  journal cards                      how many cards there are and how many are due
+  journal cards add QUESTION ANSWER
+  journal cards add -from PATH...    every ;;Q and ;;A pair in the notes under PATH
+  journal cards review [-n N]        quiz yourself on the cards due today`
+
+// CARDS_FILE is where the flashcards are kept, under the data directory
+// like the progress.
+const CARDS_FILE = "journal/cards.json"
+
+// A card starts with SM-2's ease of 2.5, which never drops below 1.3 or a
+// hard card would come back every day forever.
+const (
+	EASE     = 2.5
+	MIN_EASE = 1.3
+)
+
+// NOTE_EXTENSIONS are the files cards add -from looks in for markers:
+// notes, and comments in code.
+var NOTE_EXTENSIONS = []string{".md", ".txt", ".go"}
+
+// card is one flashcard and where it is in its SuperMemo 2 schedule.
+type card struct {
+	Question string    `json:"question"`
+	Answer   string    `json:"answer"`
+	Source   string    `json:"source,omitempty"` // the file:line it came from
+	Ease     float64   `json:"ease"`
+	Interval int       `json:"interval"` // days between the last review and the next
+	Reps     int       `json:"reps"`     // reviews in a row remembered
+	Due      time.Time `json:"due"`
+}
+
+// deck is every card, in the order they were added.
+type deck []*card
+
+// loadCards reads the cards file; there's none before the first card.
+func loadCards() (deck, error) {
+	path, err := dataPath(CARDS_FILE)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var d deck
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return d, nil
+}
+
+// save writes the cards file.
+func (d deck) save() error {
+	path, err := dataPath(CARDS_FILE)
+	if err != nil {
+		return err
+	}
+	return saveJSON(path, d)
+}
+
+// day is midnight at the start of t's day, which is when cards fall due.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// add puts c in the deck due today, or updates the answer of the card with
+// its question, keeping that card's schedule. It says which it did.
+func (d *deck) add(c card, now time.Time) (added bool, changed bool) {
+	for _, old := range *d {
+		if old.Question == c.Question {
+			changed = old.Answer != c.Answer || old.Source != c.Source
+			old.Answer, old.Source = c.Answer, c.Source
+			return false, changed
+		}
+	}
+	c.Ease, c.Interval, c.Reps, c.Due = EASE, 0, 0, day(now)
+	*d = append(*d, &c)
+	return true, true
+}
+
+// due is the cards to review by now, the longest overdue first.
+func (d deck) due(now time.Time) []*card {
+	var due []*card
+	for _, c := range d {
+		if !c.Due.After(now) {
+			due = append(due, c)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b *card) int { return a.Due.Compare(b.Due) })
+	return due
+}
+
+// grade schedules c after a review, by SM-2: quality is 0 for a blank, up
+// to 5 for a perfect answer. Below 3 it's forgotten and starts over a day
+// later; otherwise the wait grows by the ease, which the quality nudges.
+func (c *card) grade(quality int, now time.Time) {
+	if quality < 3 {
+		c.Reps, c.Interval = 0, 1
+	} else {
+		c.Reps++
+		switch c.Reps {
+		case 1:
+			c.Interval = 1
+		case 2:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.Ease))
+		}
+	}
+	miss := float64(5 - quality)
+	c.Ease = max(MIN_EASE, c.Ease+0.1-miss*(0.08+miss*0.02))
+	c.Due = day(now).AddDate(0, 0, c.Interval)
+}
+
+func cardsCommand(args []string, w io.Writer) int {
+	if len(args) == 0 {
+		d, err := loadCards()
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		printCards(w, d, time.Now())
+		return 0
+	}
+	switch args[0] {
+	case "add":
+		return addCardsCommand(args[1:], w)
+	case "review":
+		return reviewCommand(args[1:], w)
+	}
+	fmt.Fprintln(w, CARDS_USAGE)
+	return 1
+}
+
+// printCards says how many cards there are and when to next review.
+func printCards(w io.Writer, d deck, now time.Time) {
+	if len(d) == 0 {
+		fmt.Fprintln(w, "No cards yet: journal cards add, or ;;Q and ;;A in your notes.")
+		return
+	}
+	due := d.due(now)
+	fmt.Fprintf(w, "%d cards, %d due today.\n", len(d), len(due))
+	if len(due) == 0 {
+		next := slices.MinFunc(d, func(a, b *card) int { return a.Due.Compare(b.Due) })
+		fmt.Fprintf(w, "The next is due on %s.\n", next.Due.Format("Mon 2 Jan"))
+	}
+}
+
+func addCardsCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("cards add", flag.ContinueOnError)
+	flags.SetOutput(w)
+	from := flags.Bool("from", false, "extract the cards from the notes under the paths")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	var cards []card
+	switch {
+	case *from && len(positional) > 0:
+		for _, path := range positional {
+			found, err := extractCards(path)
+			if err != nil {
+				fmt.Fprintln(w, err)
+				return 2
+			}
+			cards = append(cards, found...)
+		}
+	case !*from && len(positional) == 2 && positional[0] != "" && positional[1] != "":
+		cards = []card{{Question: positional[0], Answer: positional[1]}}
+	default:
+		fmt.Fprintln(w, CARDS_USAGE)
+		return 1
+	}
+
+	d, err := loadCards()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	added, updated := 0, 0
+	now := time.Now()
+	for _, c := range cards {
+		switch isNew, changed := d.add(c, now); {
+		case isNew:
+			added++
+		case changed:
+			updated++
+		}
+	}
+	if err := d.save(); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Added %d cards, updated %d, %d unchanged.\n", added, updated, len(cards)-added-updated)
+	return 0
+}
+
+// extractCards finds the cards in the notes at path, a file or a folder
+// of them. A card is a line with ;;Q and the question after it, then lines
+// with ;;A and the answer, anywhere in a line so they can sit in comments:
+//
+//	<!-- ;;Q What does malloc return when it runs out of memory? -->
+//	<!-- ;;A NULL, so check before using it. -->
+//
+// More ;;Q or ;;A lines in a row carry on the question or the answer.
+func extractCards(path string) ([]card, error) {
+	var cards []card
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if p != path && !slices.Contains(NOTE_EXTENSIONS, filepath.Ext(p)) {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		found, err := scanCards(f, p)
+		cards = append(cards, found...)
+		return err
+	})
+	return cards, err
+}
+
+// scanCards reads the cards in one file, name.
+func scanCards(r io.Reader, name string) ([]card, error) {
+	var cards []card
+	var c *card
+	flush := func() {
+		if c != nil && c.Answer != "" {
+			cards = append(cards, *c)
+		}
+		c = nil
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if _, question, ok := strings.Cut(scanner.Text(), ";;Q"); ok {
+			if c != nil && c.Answer != "" {
+				flush()
+			}
+			if c == nil {
+				c = &card{Source: fmt.Sprintf("%s:%d", name, line)}
+			}
+			c.Question = joinLine(c.Question, question)
+			continue
+		}
+		if _, answer, ok := strings.Cut(scanner.Text(), ";;A"); ok && c != nil {
+			c.Answer = joinLine(c.Answer, answer)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return cards, scanner.Err()
+}
+
+// joinLine adds the text after a marker to what came before it, without
+// the end of the comment it's in.
+func joinLine(before, text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(text, "-->"), "*/"))
+	if before == "" {
+		return text
+	}
+	return before + "\n" + text
+}
+
+func reviewCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("cards review", flag.ContinueOnError)
+	flags.SetOutput(w)
+	limit := flags.Int("n", 20, "review at most this many cards")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 0 || *limit < 1 {
+		fmt.Fprintln(w, CARDS_USAGE)
+		return 1
+	}
+	d, err := loadCards()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if err := review(w, d, time.Now(), *limit, cs50.GetString); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return 0
+}
+
+// review quizzes on up to limit cards due by now, asking with ask, and
+// saves each grade as it's given so quitting halfway loses nothing.
+// Forgotten cards come round again at the end, without being graded twice.
+func review(w io.Writer, d deck, now time.Time, limit int, ask func(prompt string) string) error {
+	queue := d.due(now)
+	if len(queue) == 0 {
+		printCards(w, d, now)
+		return nil
+	}
+	queue = queue[:min(limit, len(queue))]
+	graded := map[*card]bool{}
+	reviewed, remembered := 0, 0
+	for i := 0; i < len(queue); i++ {
+		c := queue[i]
+		fmt.Fprintf(w, "\n%s\n", c.Question)
+		if strings.EqualFold(ask("Enter to see the answer, q to stop: "), "q") {
+			break
+		}
+		fmt.Fprintf(w, "%s\n", c.Answer)
+		quality := -1
+		for quality < 0 {
+			answer := ask("How well did you know it? 0 not at all, 3 just, 5 easily: ")
+			if strings.EqualFold(answer, "q") {
+				break
+			}
+			if n, err := strconv.Atoi(answer); err == nil && n >= 0 && n <= 5 {
+				quality = n
+			} else {
+				fmt.Fprintln(w, "Please enter a number from 0 to 5.")
+			}
+		}
+		if quality < 0 {
+			break
+		}
+		if quality < 3 {
+			queue = append(queue, c)
+		}
+		if graded[c] {
+			continue
+		}
+		graded[c] = true
+		reviewed++
+		if quality >= 3 {
+			remembered++
+		}
+		c.grade(quality, now)
+		if err := d.save(); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(w, "\nReviewed %d cards, remembered %d. %d still due today.\n", reviewed, remembered, len(d.due(now)))
+	return nil
+}
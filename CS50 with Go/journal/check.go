@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"exercises"
+	"i18n"
+)
+
+const CHECK_USAGE = "Usage: journal check WEEK/PROBLEM|all [-v]"
+
+// TIMEOUT is how long a case may run before it's failed, unless it says
+// otherwise; REJECT_WAIT is how long a program has to still be waiting
+// for input, after the last line, to count as rejecting it.
+const (
+	TIMEOUT     = 10 * time.Second
+	REJECT_WAIT = time.Second
+)
+
+// MAX_OUTPUT is as much output as a case keeps: enough to compare, not so
+// much that a runaway loop fills memory.
+const MAX_OUTPUT = 1 << 20
+
+// checkCase is one test of a problem, like one of check50's smileys. The
+// cases live next to the problem, in testdata/NAME.checks.json.
+type checkCase struct {
+	Name  string   `json:"name"`
+	Args  []string `json:"args,omitempty"`  // {tmp} is a fresh directory for output files
+	Input []string `json:"input,omitempty"` // lines typed at the prompts, in order
+	// Stdout is all the expected output, prompts included; * matches
+	// anything, across lines too. Left out, the output isn't checked.
+	Stdout  *string `json:"stdout,omitempty"`
+	Exit    int     `json:"exit,omitempty"`
+	Reject  bool    `json:"reject,omitempty"`  // the program asks again instead of finishing
+	Timeout float64 `json:"timeout,omitempty"` // seconds, for slow cases
+}
+
+// checksPath is where e's cases are.
+func checksPath(root string, e exercises.Program) string {
+	return filepath.Join(e.Path(root), "testdata", e.Name()+".checks.json")
+}
+
+// loadCases reads a checks file and makes sure every case can be run.
+func loadCases(path string) ([]checkCase, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cases []checkCase
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cases); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, c := range cases {
+		switch {
+		case c.Name == "":
+			return nil, fmt.Errorf("%s: case %d has no name", path, i+1)
+		case c.Reject && c.Exit != 0:
+			return nil, fmt.Errorf("%s: %q can't both reject its input and exit %d", path, c.Name, c.Exit)
+		}
+	}
+	return cases, nil
+}
+
+func checkCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(w)
+	verbose := flags.Bool("v", false, "show the whole output of failed cases")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, CHECK_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	var chosen []exercises.Exercise
+	if positional[0] == "all" {
+		// Every program with cases, and every other exercise, since
+		// those check themselves.
+		for _, e := range exercises.All() {
+			if p, ok := e.(exercises.Program); ok {
+				if _, err := os.Stat(checksPath(root, p)); err != nil {
+					continue
+				}
+			}
+			chosen = append(chosen, e)
+		}
+	} else {
+		e, ok := exercises.Lookup(positional[0])
+		if !ok {
+			fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+			return 1
+		}
+		chosen = append(chosen, e)
+	}
+
+	status := 0
+	for i, e := range chosen {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		result, summary := check(root, e, w, *verbose)
+		status = max(status, result)
+		if summary != "" {
+			if err := recordCheck(e, result == 0, summary); err != nil {
+				fmt.Fprintln(w, "Couldn't save your progress:", err)
+			}
+		}
+	}
+	return status
+}
+
+// check runs e's cases and prints a line for each, returning 1 when any
+// failed and 2 when they couldn't run, and how it went in a few words
+// ("13 of 14 passed"), or "" when they couldn't. An exercise that isn't a
+// program has no cases: its own Check is the one line.
+func check(root string, ex exercises.Exercise, w io.Writer, verbose bool) (int, string) {
+	fmt.Fprintf(w, "Results for %s\n", exercises.ID(ex))
+	e, ok := ex.(exercises.Program)
+	if !ok {
+		ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+		defer cancel()
+		if err := ex.Check(ctx); err != nil {
+			fmt.Fprintf(w, ":( %s passes its check\n    %v\n", ex.Name(), err)
+			return 1, "its check failed"
+		}
+		fmt.Fprintf(w, ":) %s passes its check\n", ex.Name())
+		return 0, "its check passed"
+	}
+	cases, err := loadCases(checksPath(root, e))
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(w, "No checks yet: add them to %s\n", checksPath(root, e))
+		return 2, ""
+	}
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2, ""
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		fmt.Fprintf(w, ":( %s compiles\n    %v\n", e.File, err)
+		return 1, "doesn't compile"
+	}
+	fmt.Fprintf(w, ":) %s compiles\n", e.File)
+
+	failed := 0
+	for _, c := range cases {
+		problem, output := runCase(bin, e.Path(root), c)
+		if problem == "" {
+			fmt.Fprintf(w, ":) %s\n", c.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, ":( %s\n    %s\n", c.Name, problem)
+		if verbose {
+			fmt.Fprintf(w, "    output: %q\n", output)
+		}
+	}
+	summary := fmt.Sprintf("%d of %d passed", len(cases)-failed, len(cases))
+	fmt.Fprintf(w, "%s.\n", summary)
+	if failed > 0 {
+		return 1, summary
+	}
+	return 0, summary
+}
+
+// runCase runs bin in dir the way c says, and returns what's wrong, or ""
+// when it passed, and what the program printed.
+func runCase(bin, dir string, c checkCase) (string, string) {
+	timeout := TIMEOUT
+	if c.Timeout > 0 {
+		timeout = time.Duration(c.Timeout * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tmp, err := os.MkdirTemp("", "journal-check-")
+	if err != nil {
+		return err.Error(), ""
+	}
+	defer os.RemoveAll(tmp)
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = strings.ReplaceAll(arg, "{tmp}", tmp)
+	}
+
+	// Standard input stays open after the last line, so a program that
+	// asks again waits for an answer, like at a terminal, instead of
+	// reading end-of-file.
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = englishEnv()
+	var out limitedBuffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err.Error(), ""
+	}
+	defer stdin.Close()
+	if err := cmd.Start(); err != nil {
+		return err.Error(), ""
+	}
+	go func() {
+		for _, line := range c.Input {
+			if _, err := io.WriteString(stdin, line+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	if c.Reject {
+		select {
+		case <-done:
+			return "expected the input to be rejected, but the program exited", out.String()
+		case <-time.After(REJECT_WAIT):
+			cmd.Process.Kill()
+			<-done
+			return compare(c, out.String()), out.String()
+		}
+	}
+
+	err = <-done
+	output := out.String()
+	var exit *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return "timed out while waiting for program to exit", output
+	case errors.As(err, &exit):
+		if exit.ExitCode() != c.Exit {
+			return fmt.Sprintf("expected exit code %d, not %d", c.Exit, exit.ExitCode()), output
+		}
+	case err != nil:
+		return err.Error(), output
+	case c.Exit != 0:
+		return fmt.Sprintf("expected exit code %d, not 0", c.Exit), output
+	}
+	return compare(c, output), output
+}
+
+// compare checks the output against c.Stdout.
+func compare(c checkCase, output string) string {
+	if c.Stdout == nil || match(*c.Stdout, output) {
+		return ""
+	}
+	return fmt.Sprintf("expected %s, not %s", shorten(*c.Stdout), shorten(output))
+}
+
+// match reports whether text is pattern, with every * in it standing for
+// any text at all.
+func match(pattern, text string) bool {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return text == pattern
+	}
+	if !strings.HasPrefix(text, parts[0]) {
+		return false
+	}
+	text = text[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(text, part)
+		if i < 0 {
+			return false
+		}
+		text = text[i+len(part):]
+	}
+	return len(text) >= len(last) && strings.HasSuffix(text, last)
+}
+
+// shorten quotes s for a report line, keeping its end, which is usually
+// where the answer is.
+func shorten(s string) string {
+	const MAX = 60
+	if r := []rune(s); len(r) > MAX {
+		return "\"…" + strings.Trim(fmt.Sprintf("%q", string(r[len(r)-MAX:])), `"`) + "\""
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+// limitedBuffer keeps the first MAX_OUTPUT bytes written to it and drops
+// the rest, still reporting them written so the program carries on.
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := MAX_OUTPUT - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// ReadFrom hides bytes.Buffer's, which io.Copy would otherwise use to read
+// the program's output without any limit.
+func (b *limitedBuffer) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(struct{ io.Writer }{b}, r)
+}
+
+// englishEnv is journal's environment with the psets' messages in English,
+// whatever the config's language: what checks, fixtures and casts expect is
+// written in it.
+func englishEnv() []string {
+	return append(os.Environ(), i18n.LANG_ENV+"=en")
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"exercises"
+)
+
+const DIFF_USAGE = "Usage: journal diff WEEK/PROBLEM|DIR [-sync] [-color]"
+
+// Annotations in a finished file say what its starter has instead, so the
+// starter can be written again from it. A block starts with any of them
+// and runs to END; the code in it is the solution, which the starter
+// leaves out:
+//
+//	//todo: Count them.                       → // TODO: Count them.
+//	//hint: len() works on slices.            → // HINT: len() works on slices.
+//	//starter: return 0 // Change this.       → return 0 // Change this.
+//	return len(q.items)                       (left out)
+//	//end
+//
+// More //todo: or //hint: lines in a row carry on the comment, and an
+// empty //starter: is a blank line.
+const (
+	TODO    = "//todo:"
+	HINT    = "//hint:"
+	STARTER = "//starter:"
+	END     = "//end"
+)
+
+// starterPair is a starter and the finished version of it.
+type starterPair struct {
+	Starter, Finished string
+}
+
+func diffCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(w)
+	sync := flags.Bool("sync", false, "write the starter again from the finished version's annotations")
+	color := flags.Bool("color", isTerminal(w), "colour the diffs")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, DIFF_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	pairs, err := findPairs(root, positional[0])
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+
+	status := 0
+	for i, pair := range pairs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		relStarter, _ := filepath.Rel(root, pair.Starter)
+		relFinished, _ := filepath.Rel(root, pair.Finished)
+		if *sync {
+			if err := syncStarter(w, pair, relStarter); err != nil {
+				fmt.Fprintf(w, "%s: %v\n", relFinished, err)
+				status = 1
+			}
+			continue
+		}
+		starter, err := os.ReadFile(pair.Starter)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		finished, err := os.ReadFile(pair.Finished)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		fmt.Fprintf(w, "%s → %s\n", filepath.ToSlash(relStarter), filepath.ToSlash(relFinished))
+		compareDecls(w, string(starter), string(finished), *color)
+	}
+	return status
+}
+
+// findPairs finds the starters and finished versions for what, a problem
+// or a folder, in any of the layouts the journal has:
+//
+//   - NAME.go next to NAME-finished.go, as journal new makes them;
+//   - the starters in a starter/ folder, like lru's and sorts';
+//   - week 5's recipe.go, with finished-distributionCode/recipe-finished.go.
+func findPairs(root, what string) ([]starterPair, error) {
+	var dirs []string
+	name := filepath.Base(what)
+	week := ""
+	if e, ok := exercises.Find(what); ok {
+		name = e.Name()
+		dirs = append(dirs, e.Path(root))
+		week = weekDir(root, e.Week())
+	} else if _, id, ok := strings.Cut(what, "/"); ok && strings.HasPrefix(what, "week") && !strings.Contains(id, "/") {
+		var n int
+		if _, err := fmt.Sscanf(what, "week%d/", &n); err == nil {
+			name, week = id, weekDir(root, n)
+			dirs = append(dirs, filepath.Join(week, id))
+		}
+	}
+	for _, dir := range []string{what, filepath.Join(root, what)} {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, dir := range dirs {
+		var pairs []starterPair
+		finished, _ := filepath.Glob(filepath.Join(dir, "*-finished.go"))
+		for _, f := range finished {
+			starter := strings.TrimSuffix(f, "-finished.go") + ".go"
+			pairs = append(pairs, starterPair{starter, f})
+		}
+		starters, _ := filepath.Glob(filepath.Join(dir, "starter", "*.go"))
+		for _, s := range starters {
+			if f := filepath.Join(dir, filepath.Base(s)); fileExists(f) {
+				pairs = append(pairs, starterPair{s, f})
+			}
+		}
+		if len(pairs) > 0 {
+			return pairs, nil
+		}
+	}
+	// The distribution code layout: the starter at the top of the week, the
+	// finished version in a folder of its own.
+	if week != "" {
+		finished, _ := filepath.Glob(filepath.Join(week, "*", name+"-finished.go"))
+		if starter := filepath.Join(week, name+".go"); len(finished) == 1 && fileExists(starter) {
+			return []starterPair{{starter, finished[0]}}, nil
+		}
+	}
+	return nil, fmt.Errorf("No starter and finished version of %s: NAME.go and NAME-finished.go, or a starter/ folder.", what)
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// decl is one top-level declaration: a function or method, a type, or a
+// group of constants, variables or imports.
+type decl struct {
+	Name  string
+	Line  int    // where it starts, doc comment and all
+	Text  string // as written
+	Code  string // printed without comments, to tell code changes from comments
+	TODOs int
+}
+
+// parseDecls lists src's declarations, in order.
+func parseDecls(src string) ([]decl, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var decls []decl
+	for _, d := range file.Decls {
+		start := d.Pos()
+		var bare ast.Decl
+		var name string
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+			name = d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				var recv bytes.Buffer
+				printer.Fprint(&recv, fset, d.Recv.List[0].Type)
+				name = "(" + recv.String() + ")." + name
+			}
+			copied := *d
+			copied.Doc = nil
+			bare = &copied
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+			name = d.Tok.String()
+			switch spec := d.Specs[0].(type) {
+			case *ast.TypeSpec:
+				name = spec.Name.Name
+			case *ast.ValueSpec:
+				name = spec.Names[0].Name
+			}
+			copied := *d
+			copied.Doc = nil
+			bare = &copied
+		}
+		text := src[fset.Position(start).Offset:fset.Position(d.End()).Offset]
+		var code bytes.Buffer
+		printer.Fprint(&code, fset, bare)
+		decls = append(decls, decl{
+			Name:  name,
+			Line:  fset.Position(start).Line,
+			Text:  text,
+			Code:  code.String(),
+			TODOs: strings.Count(text, "TODO"),
+		})
+	}
+	return decls, nil
+}
+
+// compareDecls writes which declarations the starter and the finished
+// version have in common and how they differ, then the diffs of those
+// whose code differs. A file that doesn't parse is diffed whole.
+func compareDecls(w io.Writer, starter, finished string, color bool) {
+	before, err1 := parseDecls(starter)
+	after, err2 := parseDecls(finished)
+	if err := errors.Join(err1, err2); err != nil {
+		fmt.Fprintf(w, "Doesn't parse, so the whole files: %v\n", err)
+		printDiff(w, unifiedFrom("--- starter\n+++ finished\n", diffLines(splitLines(starter), splitLines(finished)), 1, 1), color)
+		return
+	}
+
+	find := func(decls []decl, name string) *decl {
+		for i := range decls {
+			if decls[i].Name == name {
+				return &decls[i]
+			}
+		}
+		return nil
+	}
+	var diffs []string
+	todos := 0
+	for _, s := range before {
+		todos += s.TODOs
+		f := find(after, s.Name)
+		mark, note := "=", ""
+		switch {
+		case f == nil:
+			mark, note = "-", "only in the starter"
+		case s.Code != f.Code:
+			mark = "~"
+			edits := diffLines(splitLines(s.Text), splitLines(f.Text))
+			removed, added := 0, 0
+			for _, e := range edits {
+				switch e.Op {
+				case '-':
+					removed++
+				case '+':
+					added++
+				}
+			}
+			note = fmt.Sprintf("+%d -%d", added, removed)
+			diffs = append(diffs, unifiedFrom(fmt.Sprintf("--- starter %s\n+++ finished %s\n", s.Name, s.Name), edits, s.Line, f.Line))
+		case s.Text != f.Text:
+			mark, note = "#", "comments only"
+		}
+		if s.TODOs > 0 {
+			note = strings.TrimSpace(fmt.Sprintf("%d TODO%s  %s", s.TODOs, plural(s.TODOs), note))
+		}
+		fmt.Fprintf(w, "  %s %-28s %s\n", mark, s.Name, note)
+	}
+	for _, f := range after {
+		if find(before, f.Name) == nil {
+			fmt.Fprintf(w, "  + %-28s only in the finished version\n", f.Name)
+		}
+	}
+	fmt.Fprintf(w, "%d of the starter's %d declarations differ in code, with %d TODO%s.\n", len(diffs), len(before), todos, plural(todos))
+	for _, diff := range diffs {
+		fmt.Fprintln(w)
+		printDiff(w, diff, color)
+	}
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// syncStarter writes the starter of pair again from the annotations in its
+// finished version, and says whether that changed it.
+func syncStarter(w io.Writer, pair starterPair, name string) error {
+	finished, err := os.ReadFile(pair.Finished)
+	if err != nil {
+		return err
+	}
+	old, err := os.ReadFile(pair.Starter)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	starter, todos, err := starterFrom(string(finished), string(old))
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(starter, old) {
+		fmt.Fprintf(w, "%s is up to date, with %d TODO%s.\n", filepath.ToSlash(name), todos, plural(todos))
+		return nil
+	}
+	if err := os.WriteFile(pair.Starter, starter, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Wrote %s, with %d TODO%s.\n", filepath.ToSlash(name), todos, plural(todos))
+	if len(old) > 0 {
+		printDiff(w, unified(filepath.ToSlash(name), diffLines(splitLines(string(old)), splitLines(string(starter)))), false)
+	}
+	return nil
+}
+
+// starterFrom is the starter for finished, from its annotations, and how
+// many TODOs it has. What's above the package clause, the build
+// constraint and the file's comment, is kept from the old starter; without
+// one, it's the finished version's, built without the finished tag.
+func starterFrom(finished, old string) ([]byte, int, error) {
+	lines := splitLines(strings.ReplaceAll(finished, "\r\n", "\n"))
+	body := 0
+	for body < len(lines) && !strings.HasPrefix(lines[body], "package ") {
+		body++
+	}
+	if body == len(lines) {
+		return nil, 0, errors.New("no package clause")
+	}
+	var out []string
+	if header := packageHeader(old); header != nil {
+		out = header
+	} else {
+		for _, line := range lines[:body] {
+			if line == "//go:build finished" {
+				line = "//go:build !finished"
+			}
+			out = append(out, line)
+		}
+	}
+
+	todos, blockStart, last := 0, 0, ""
+	for i, line := range lines[body:] {
+		trimmed := strings.TrimSpace(line)
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		kind := ""
+		for _, k := range []string{TODO, HINT, STARTER} {
+			if strings.HasPrefix(trimmed, k) {
+				kind = k
+			}
+		}
+		switch {
+		case kind != "":
+			text := strings.TrimPrefix(trimmed, kind)
+			switch {
+			case kind == STARTER && strings.TrimSpace(text) == "":
+				out = append(out, "")
+			case kind == STARTER:
+				out = append(out, indent+strings.TrimPrefix(text, " "))
+			case kind == last:
+				out = append(out, indent+"// "+strings.TrimSpace(text))
+			case kind == TODO:
+				todos++
+				out = append(out, indent+"// TODO: "+strings.TrimSpace(text))
+			default:
+				out = append(out, indent+"// HINT: "+strings.TrimSpace(text))
+			}
+			if blockStart == 0 {
+				blockStart = body + i + 1
+			}
+		case trimmed == END:
+			if blockStart == 0 {
+				return nil, 0, fmt.Errorf("line %d: %s without a block to end", body+i+1, END)
+			}
+			blockStart = 0
+		case blockStart == 0:
+			out = append(out, line)
+		}
+		last = kind
+	}
+	if blockStart != 0 {
+		return nil, 0, fmt.Errorf("line %d: the block has no %s", blockStart, END)
+	}
+	if todos == 0 && !strings.Contains(finished, STARTER) {
+		return nil, 0, fmt.Errorf("no %s annotations to write the starter from", TODO)
+	}
+	src, err := format.Source([]byte(strings.Join(out, "\n") + "\n"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("the starter doesn't parse: %v", err)
+	}
+	return src, todos, nil
+}
+
+// packageHeader is what's above the package clause in src, or nil when
+// there's nothing, or no package clause.
+func packageHeader(src string) []string {
+	lines := splitLines(strings.ReplaceAll(src, "\r\n", "\n"))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "package ") {
+			return lines[:i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,526 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"exercises"
+)
+
+const EXPORT_USAGE = "Usage: journal export DIR [-serve] [-port N]"
+
+// SOURCE_LANGUAGES are the files that get a page of their own, by
+// extension, with the language they're highlighted as ("" for none).
+var SOURCE_LANGUAGES = map[string]string{
+	".go": "go", ".c": "c", ".h": "c", ".py": "python", ".sql": "sql", ".js": "javascript",
+	".sh": "sh", ".html": "", ".css": "", ".txt": "", ".csv": "", ".mmd": "", ".mod": "",
+}
+
+// ASSET_EXTENSIONS are copied as they are, for the pictures in the notes
+// and the sessions journal record made, for asciinema's player.
+var ASSET_EXTENSIONS = []string{".svg", ".png", ".jpg", ".jpeg", ".gif", ".webp", ".cast"}
+
+// MAX_SOURCE is the biggest file that gets a page; bigger ones are data,
+// like the dictionaries and DNA sequences, not something to read.
+const MAX_SOURCE = 256 << 10
+
+// SEARCH_INDEX is the search page's data: every page's title and text.
+const SEARCH_INDEX = "search.json"
+
+// sitePage is one page of the site.
+type sitePage struct {
+	Title  string
+	Path   string // in the site, with forward slashes
+	Root   string // the way back up to the site's root, "../../"
+	Crumbs []crumb
+	Weeks  []crumb
+	Body   template.HTML
+}
+
+type crumb struct {
+	Name, URL string
+}
+
+// searchEntry is a page as the search page sees it.
+type searchEntry struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	Text  string `json:"text"`
+}
+
+// site is the repo laid out as pages: each file's page (or copy) in the
+// site, by its path in the repo, and the folders' indexes.
+type site struct {
+	root  string
+	pages map[string]string // repo path → site path, both with forward slashes
+	dirs  map[string][]string
+}
+
+func exportCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(w)
+	serve := flags.Bool("serve", false, "preview it with week 8's serve afterwards")
+	port := flags.Int("port", 8080, "the port to preview it on")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, EXPORT_USAGE)
+		return 1
+	}
+	out, err := filepath.Abs(positional[0])
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	s, err := newSite(root, out)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	pages, err := s.export(out)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Exported %d pages to %s.\n", pages, positional[0])
+	if !*serve {
+		fmt.Fprintf(w, "Preview it with journal export %s -serve, or publish the folder to GitHub Pages as it is.\n", positional[0])
+		return 0
+	}
+
+	// The preview is week 8's serve itself, run from the workspace.
+	cmd := exec.Command("go", "run", "./week8-HTML-CSS-JS/serve", "-port", strconv.Itoa(*port), out)
+	cmd.Dir, cmd.Stdin, cmd.Stdout, cmd.Stderr = root, os.Stdin, w, w
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return 0
+}
+
+// WEEK_PATTERN is how the folders at the top of the repo that are weeks,
+// which are what the site is made of, start.
+var WEEK_PATTERN = regexp.MustCompile(`^week(\d+)`)
+
+// newSite finds what goes in the site: the README at the top and
+// everything readable in the weeks, apart from out if it's in there.
+func newSite(root, out string) (*site, error) {
+	s := &site{root: root, pages: map[string]string{}, dirs: map[string][]string{}}
+	if _, err := os.Stat(filepath.Join(root, "README.md")); err == nil {
+		s.pages["README.md"] = "index.html"
+	}
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if (!strings.Contains(rel, "/") && !WEEK_PATTERN.MatchString(name)) || strings.HasPrefix(name, ".") ||
+				name == "testdata" || name == "node_modules" || p == out {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		page, ok := sitePath(rel)
+		if !ok || !strings.Contains(rel, "/") {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > MAX_SOURCE && !isAsset(rel) {
+			return err
+		}
+		if binary, err := isBinary(p); err != nil || binary {
+			return err
+		}
+		s.pages[rel] = page
+		dir := path.Dir(rel)
+		s.dirs[dir] = append(s.dirs[dir], rel)
+		// The folders above it get indexes too, if they haven't yet.
+		for ; dir != "."; dir = path.Dir(dir) {
+			if parent := path.Dir(dir); !slices.Contains(s.dirs[parent], dir+"/") {
+				s.dirs[parent] = append(s.dirs[parent], dir+"/")
+			}
+		}
+		return nil
+	})
+	return s, err
+}
+
+// sitePath is where the file at rel goes in the site: a folder's README
+// is its index.html, other notes and source files get pages named after
+// them, and pictures are copied. It's false for anything else.
+func sitePath(rel string) (string, bool) {
+	base, ext := path.Base(rel), strings.ToLower(path.Ext(rel))
+	switch {
+	case strings.EqualFold(base, "README.md"):
+		return path.Join(path.Dir(rel), "index.html"), true
+	case ext == ".md":
+		return strings.TrimSuffix(rel, path.Ext(rel)) + ".html", true
+	case isAsset(rel):
+		return rel, true
+	}
+	if _, ok := SOURCE_LANGUAGES[ext]; ok {
+		return rel + ".html", true
+	}
+	return "", false
+}
+
+func isAsset(rel string) bool {
+	return slices.Contains(ASSET_EXTENSIONS, strings.ToLower(path.Ext(rel)))
+}
+
+// link rewrites an href in the page for from (a repo path) to the page it
+// points to in the site. Links out of the site are left alone.
+func (s *site) link(from, href string) string {
+	if href == "" || strings.HasPrefix(href, "#") || strings.Contains(href, "://") || strings.HasPrefix(href, "/") || strings.HasPrefix(href, "mailto:") {
+		return href
+	}
+	target, fragment, _ := strings.Cut(href, "#")
+	target = path.Join(path.Dir(from), target)
+	page, ok := s.pages[target]
+	if !ok {
+		if _, isDir := s.dirs[target]; !isDir {
+			return href
+		}
+		page = path.Join(target, "index.html")
+	}
+	rel := relativeURL(path.Dir(s.pages[from]), page)
+	if fragment != "" {
+		rel += "#" + fragment
+	}
+	return rel
+}
+
+// relativeURL is the way from a folder of the site to a page in it.
+func relativeURL(fromDir, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(fromDir), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+	return filepath.ToSlash(rel)
+}
+
+// export writes the site into out, which is either new or a site exported
+// before: it's emptied first so pages of deleted files don't linger. It
+// returns how many pages it wrote.
+func (s *site) export(out string) (int, error) {
+	if entries, err := os.ReadDir(out); err == nil && len(entries) > 0 {
+		if _, err := os.Stat(filepath.Join(out, SEARCH_INDEX)); err != nil {
+			return 0, fmt.Errorf("%s isn't empty, or an export to replace", out)
+		}
+		if err := os.RemoveAll(out); err != nil {
+			return 0, err
+		}
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	var weeks []crumb
+	for _, rel := range s.dirs["."] {
+		if dir := strings.TrimSuffix(rel, "/"); rel != dir {
+			weeks = append(weeks, crumb{dir, dir + "/index.html"})
+		}
+	}
+	slices.SortFunc(weeks, func(a, b crumb) int { return weekOrder(a.Name) - weekOrder(b.Name) })
+
+	var index []searchEntry
+	write := func(p sitePage, text string) error {
+		p.Root = strings.Repeat("../", strings.Count(p.Path, "/"))
+		p.Weeks = weeks
+		p.Crumbs = append([]crumb{{"CS50 with Go", "index.html"}}, p.Crumbs...)
+		index = append(index, searchEntry{p.Title, p.Path, text})
+		file := filepath.Join(out, filepath.FromSlash(p.Path))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		if err := pageTemplate.Execute(f, p); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	// The folders first, each with its README and what's in it, then a page
+	// for each file.
+	dirs := []string{"."}
+	for dir := range s.dirs {
+		if dir != "." {
+			dirs = append(dirs, dir)
+		}
+	}
+	slices.Sort(dirs[1:])
+	for _, dir := range dirs {
+		readme := s.readme(dir)
+		var body strings.Builder
+		text := ""
+		title := path.Base(dir)
+		if readme != "" {
+			src, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(readme)))
+			if err != nil {
+				return 0, err
+			}
+			text = string(src)
+			body.WriteString(renderMarkdown(text, func(href string) string { return s.link(readme, href) }))
+			title = headingOr(text, title)
+		} else {
+			fmt.Fprintf(&body, "<h1>%s</h1>\n", template.HTMLEscapeString(title))
+		}
+		if dir == "." {
+			title = "CS50 with Go"
+			body.WriteString("<h2>Weeks</h2>\n<ul>\n")
+			for _, week := range weeks {
+				fmt.Fprintf(&body, "<li><a href=\"%s\">%s</a></li>\n", week.URL, week.Name)
+			}
+			body.WriteString("</ul>\n")
+		} else {
+			s.listFiles(&body, dir, readme)
+		}
+		if err := write(sitePage{Title: title, Path: path.Join(dir, "index.html"), Crumbs: crumbs(dir), Body: template.HTML(body.String())}, text); err != nil {
+			return 0, err
+		}
+	}
+
+	pages := len(dirs)
+	for rel, page := range s.pages {
+		if path.Base(page) == "index.html" && strings.EqualFold(path.Base(rel), "README.md") {
+			continue // the folder's page
+		}
+		src, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(rel)))
+		if err != nil {
+			return 0, err
+		}
+		if isAsset(rel) {
+			file := filepath.Join(out, filepath.FromSlash(page))
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return 0, err
+			}
+			if err := os.WriteFile(file, src, 0644); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		p := sitePage{Title: path.Base(rel), Path: page, Crumbs: crumbs(rel)}
+		if strings.EqualFold(path.Ext(rel), ".md") {
+			p.Title = headingOr(string(src), p.Title)
+			p.Body = template.HTML(renderMarkdown(string(src), func(href string) string { return s.link(rel, href) }))
+		} else {
+			p.Body = template.HTML(fmt.Sprintf("<h1>%s</h1>\n<pre class=\"source\"><code>%s</code></pre>\n",
+				template.HTMLEscapeString(path.Base(rel)), highlight(string(src), SOURCE_LANGUAGES[strings.ToLower(path.Ext(rel))])))
+		}
+		if err := write(p, string(src)); err != nil {
+			return 0, err
+		}
+		pages++
+	}
+
+	if err := write(sitePage{Title: "Search", Path: "search.html", Body: SEARCH_BODY}, ""); err != nil {
+		return 0, err
+	}
+	index = slices.DeleteFunc(index, func(e searchEntry) bool { return e.URL == "search.html" })
+	slices.SortFunc(index, func(a, b searchEntry) int { return strings.Compare(a.URL, b.URL) })
+	data, err := json.Marshal(index)
+	if err != nil {
+		return 0, err
+	}
+	for name, content := range map[string][]byte{
+		SEARCH_INDEX: data,
+		"style.css":  []byte(STYLESHEET),
+		".nojekyll":  nil, // GitHub Pages serves the files as they are
+	} {
+		if err := os.WriteFile(filepath.Join(out, name), content, 0644); err != nil {
+			return 0, err
+		}
+	}
+	return pages + 1, nil
+}
+
+// readme is the README in dir, "" if it hasn't one.
+func (s *site) readme(dir string) string {
+	if dir == "." {
+		if _, ok := s.pages["README.md"]; ok {
+			return "README.md"
+		}
+		return ""
+	}
+	for _, rel := range s.dirs[dir] {
+		if strings.EqualFold(path.Base(rel), "README.md") {
+			return rel
+		}
+	}
+	return ""
+}
+
+// listFiles writes the list of what's in dir, folders first, leaving out
+// its README, which is already the page.
+func (s *site) listFiles(b *strings.Builder, dir, readme string) {
+	entries := slices.Clone(s.dirs[dir])
+	isFile := func(entry string) int {
+		if strings.HasSuffix(entry, "/") {
+			return 0
+		}
+		return 1
+	}
+	slices.SortFunc(entries, func(a, b string) int {
+		return cmp.Or(cmp.Compare(isFile(a), isFile(b)), strings.Compare(a, b))
+	})
+	b.WriteString("<h2 class=\"files\">Files</h2>\n<ul class=\"files\">\n")
+	for _, entry := range entries {
+		if entry == readme {
+			continue
+		}
+		name := path.Base(entry)
+		url := relativeURL(dir, s.pages[entry])
+		if strings.HasSuffix(entry, "/") {
+			name += "/"
+			url = path.Base(entry) + "/index.html"
+		}
+		fmt.Fprintf(b, "<li><a href=\"%s\">%s</a></li>\n", template.HTMLEscapeString(url), template.HTMLEscapeString(name))
+	}
+	b.WriteString("</ul>\n")
+}
+
+// crumbs is the trail of folders down to rel, each linking to its index.
+func crumbs(rel string) []crumb {
+	if rel == "." {
+		return nil
+	}
+	var trail []crumb
+	parts := strings.Split(rel, "/")
+	for i, part := range parts {
+		trail = append(trail, crumb{part, strings.Join(parts[:i+1], "/") + "/index.html"})
+	}
+	trail[len(trail)-1].URL = "" // the page itself
+	return trail
+}
+
+// headingOr is the first heading in markdown, or name without one.
+func headingOr(markdown, name string) string {
+	for _, line := range splitLines(markdown) {
+		if m := headingPattern.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			return strings.Trim(m[2], "# `")
+		}
+	}
+	return name
+}
+
+// weekOrder sorts week10 after week9.
+func weekOrder(name string) int {
+	n, _ := strconv.Atoi(WEEK_PATTERN.FindStringSubmatch(name)[1])
+	return n
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · CS50 with Go</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<nav>
+<a class="home" href="{{.Root}}index.html">CS50 with Go</a>
+<ul>{{range .Weeks}}
+<li><a href="{{$.Root}}{{.URL}}">{{.Name}}</a></li>{{end}}
+</ul>
+<a href="{{.Root}}search.html">Search</a>
+</nav>
+<main>
+<p class="crumbs">{{range $i, $c := .Crumbs}}{{if $i}} / {{end}}{{if $c.URL}}<a href="{{$.Root}}{{$c.URL}}">{{$c.Name}}</a>{{else}}{{$c.Name}}{{end}}{{end}}</p>
+{{.Body}}
+</main>
+</body>
+</html>
+`))
+
+// SEARCH_BODY is the search page: it loads the index and shows the pages
+// with every word typed, all in the browser, so it works on GitHub Pages.
+const SEARCH_BODY template.HTML = `<h1>Search</h1>
+<input id="query" type="search" placeholder="malloc, linked list, SELECT…" autofocus>
+<ul id="results"></ul>
+<script>
+const query = document.getElementById("query");
+const results = document.getElementById("results");
+fetch("search.json").then(response => response.json()).then(pages => {
+    const search = () => {
+        const words = query.value.toLowerCase().split(/\s+/).filter(Boolean);
+        results.replaceChildren();
+        if (words.length === 0) {
+            return;
+        }
+        for (const page of pages) {
+            const text = page.text.toLowerCase();
+            if (!words.every(word => text.includes(word) || page.title.toLowerCase().includes(word))) {
+                continue;
+            }
+            const at = Math.max(0, text.indexOf(words[0]) - 60);
+            const li = document.createElement("li");
+            const a = document.createElement("a");
+            a.href = page.url;
+            a.textContent = page.title + " (" + page.url + ")";
+            const snippet = document.createElement("p");
+            snippet.textContent = "…" + page.text.slice(at, at + 160) + "…";
+            li.append(a, snippet);
+            results.append(li);
+        }
+        if (!results.firstChild) {
+            results.textContent = "Nothing found.";
+        }
+    };
+    query.addEventListener("input", search);
+    query.value = new URLSearchParams(location.search).get("q") || query.value;
+    search();
+});
+</script>
+`
+
+const STYLESHEET = `body { margin: 0; display: flex; font: 16px/1.5 system-ui, sans-serif; color: #24292f; }
+nav { flex: 0 0 14em; padding: 1em; background: #f6f8fa; min-height: 100vh; box-sizing: border-box; }
+nav ul { list-style: none; padding: 0; }
+nav .home { font-weight: bold; }
+main { flex: 1; padding: 1em 2em; max-width: 56em; min-width: 0; }
+a { color: #0969da; }
+.crumbs { color: #57606a; font-size: 0.9em; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; line-height: 1.4; }
+code { font: 0.9em ui-monospace, monospace; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: 0.3em 0.7em; text-align: left; }
+blockquote { margin: 0; padding-left: 1em; border-left: 3px solid #d0d7de; color: #57606a; }
+img { max-width: 100%; }
+input[type=search] { font-size: 1.1em; padding: 0.3em; width: 100%; box-sizing: border-box; }
+.c { color: #6e7781; font-style: italic; }
+.s { color: #0a3069; }
+.n { color: #0550ae; }
+.k { color: #cf222e; }
+.b { color: #8250df; }
+@media (max-width: 40em) { body { display: block; } nav { min-height: 0; } }
+`
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"math/rand"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cipher"
+	"exercises"
+	"rngutil"
+	"runctx"
+)
+
+const FUZZ_USAGE = "Usage: journal fuzz WEEK/PROBLEM [-runs N] [-seed N] [-timeout D]"
+
+// MAX_FAILURES is how many failed runs fuzz shows before it stops: by then
+// the bug is clear, and each hang costs a whole timeout.
+const MAX_FAILURES = 10
+
+// fuzzCase is one random run of a problem, with the answer worked out
+// when it was made.
+type fuzzCase struct {
+	Args  []string
+	Input []string // lines typed at the prompts; the last is always one the program should take
+	Want  string   // what the output ends with; "" checks only the exit code
+	Exit  int
+}
+
+// FUZZ_TARGETS make random cases for the problems that read input, by
+// name: some right, most of them wrong in the ways people type wrong.
+var FUZZ_TARGETS = map[string]func(r *rand.Rand) fuzzCase{
+	"credit":       fuzzCredit,
+	"mario":        fuzzMario,
+	"cash":         fuzzCash,
+	"substitution": fuzzSubstitution,
+}
+
+func fuzzCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("fuzz", flag.ContinueOnError)
+	flags.SetOutput(w)
+	runs := flags.Int("runs", 200, "how many random cases to run")
+	seed := flags.Int64("seed", 0, "the random seed, to run the same cases again (0 = random)")
+	timeout := flags.Duration("timeout", 2*time.Second, "how long a run may take before it's a hang")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *runs < 1 || *timeout <= 0 {
+		fmt.Fprintln(w, FUZZ_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	generate, ok := FUZZ_TARGETS[e.Name()]
+	if !ok {
+		names := slices.Sorted(maps.Keys(FUZZ_TARGETS))
+		fmt.Fprintf(w, "No fuzzer for %s yet; there are ones for %s.\n", e.Name(), strings.Join(names, ", "))
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return fuzz(runctx.Context(), w, bin, e.Path(root), generate, *runs, *seed, *timeout)
+}
+
+// fuzz runs bin on random cases from generate and prints the ones that
+// go wrong, returning 1 when any did. Stopped by ctx, with Ctrl-C, it
+// still prints the summary of the runs it finished, and their seed.
+func fuzz(ctx context.Context, w io.Writer, bin, dir string, generate func(*rand.Rand) fuzzCase, runs int, seed int64, timeout time.Duration) int {
+	r := rngutil.New(&seed)
+	fmt.Fprintf(w, "Seed %d\n", seed)
+	failures := map[string]int{} // by kind, for the summary
+	failed, ran := 0, 0
+	for ; ran < runs && failed < MAX_FAILURES; ran++ {
+		c := generate(r)
+		problem := runFuzzCase(ctx, bin, dir, c, timeout)
+		if ctx.Err() != nil {
+			// The run was killed, not wrong.
+			fmt.Fprintf(w, "Interrupted after %d runs.\n", ran)
+			break
+		}
+		if problem == "" {
+			continue
+		}
+		failed++
+		kind, _, _ := strings.Cut(problem, ":")
+		failures[kind]++
+		fmt.Fprintf(w, ":( run %d: %s\n", ran+1, problem)
+		if len(c.Args) > 0 {
+			fmt.Fprintf(w, "    args:  %q\n", c.Args)
+		}
+		if len(c.Input) > 0 {
+			fmt.Fprintf(w, "    input: %q\n", c.Input)
+		}
+	}
+	if failed == 0 {
+		fmt.Fprintf(w, "%d runs, no crashes, hangs or wrong answers.\n", ran)
+		return 0
+	}
+	if failed == MAX_FAILURES && ran < runs {
+		fmt.Fprintf(w, "Stopped after %d failures.\n", failed)
+	}
+	kinds := make([]string, 0, len(failures))
+	for kind, n := range failures {
+		kinds = append(kinds, fmt.Sprintf("%d %s", n, kind))
+	}
+	slices.Sort(kinds)
+	fmt.Fprintf(w, "%d of %d runs failed: %s. -seed %d runs them again.\n", failed, ran, strings.Join(kinds, ", "), seed)
+	return 1
+}
+
+// runFuzzCase runs bin on c, the way check runs a case, and says what went
+// wrong, starting with the kind of problem: crashed, hung, exit or wrong.
+// Standard input stays open, so a program asking again for input it should
+// have taken hangs rather than reading end-of-file.
+func runFuzzCase(ctx context.Context, bin, dir string, c fuzzCase, timeout time.Duration) string {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, c.Args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = time.Second // for whatever it started that still has the output open
+	var out limitedBuffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err.Error()
+	}
+	defer stdin.Close()
+	if err := cmd.Start(); err != nil {
+		return err.Error()
+	}
+	go func() {
+		for _, line := range c.Input {
+			if _, err := io.WriteString(stdin, line+"\n"); err != nil {
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+	output := strings.ReplaceAll(out.String(), "\r\n", "\n")
+
+	var exit *exec.ExitError
+	switch {
+	case strings.Contains(output, "panic: ") || strings.Contains(output, "fatal error: "):
+		for _, line := range splitLines(output) {
+			if strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ") {
+				return "crashed: " + line
+			}
+		}
+		return "crashed"
+	case out.Len() >= MAX_OUTPUT:
+		return fmt.Sprintf("hung: printed over %d KB, in a loop", MAX_OUTPUT>>10)
+	case ctx.Err() != nil:
+		return fmt.Sprintf("hung: still running after %v, asking again or in a loop; it ended %s", timeout, shorten(output))
+	case errors.As(err, &exit):
+		if exit.ExitCode() != c.Exit {
+			return fmt.Sprintf("exit: expected exit code %d, not %d", c.Exit, exit.ExitCode())
+		}
+	case err != nil:
+		return err.Error()
+	case c.Exit != 0:
+		return fmt.Sprintf("exit: expected exit code %d, not 0", c.Exit)
+	}
+	if !strings.HasSuffix(output, c.Want) {
+		return fmt.Sprintf("wrong: expected it to end %s, not %s", shorten(c.Want), shorten(output))
+	}
+	return ""
+}
+
+// pick is one of choices, at random.
+func pick[T any](r *rand.Rand, choices ...T) T {
+	return choices[r.Intn(len(choices))]
+}
+
+// digits is n random digits.
+func digits(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + r.Intn(10))
+	}
+	return string(b)
+}
+
+// rejected adds a few lines the program should ask again after, then the
+// answer it should take.
+func rejected(r *rand.Rand, wrong func() string, answer string) []string {
+	var lines []string
+	for range r.Intn(4) {
+		lines = append(lines, wrong())
+	}
+	return append(lines, answer)
+}
+
+// JUNK is typed input that isn't a number at all.
+var JUNK = []string{"", " ", "abc", "four", "1e3", "0x10", "3.", "1,000", "１２", "٣", "NaN", "-", "--1", "1 2", "\t"}
+
+// luhn reports whether number's check digit is right.
+func luhn(number string) bool {
+	sum := 0
+	for i := range len(number) {
+		d := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// CARD_PREFIXES are the starts and lengths of the cards credit knows.
+var CARD_PREFIXES = []struct {
+	brand   string
+	prefix  []string
+	lengths []int
+}{
+	{"AMEX", []string{"34", "37"}, []int{15}},
+	{"MASTERCARD", []string{"51", "52", "53", "54", "55"}, []int{16}},
+	{"VISA", []string{"4"}, []int{13, 16}},
+}
+
+// cardBrand is what credit should say about a number it accepted.
+func cardBrand(number int64) string {
+	s := strconv.FormatInt(number, 10)
+	if number <= 0 || !luhn(s) {
+		return "INVALID"
+	}
+	for _, card := range CARD_PREFIXES {
+		if slices.Contains(card.lengths, len(s)) && slices.ContainsFunc(card.prefix, func(p string) bool { return strings.HasPrefix(s, p) }) {
+			return card.brand
+		}
+	}
+	return "INVALID"
+}
+
+// fuzzCredit types card numbers: real ones of each brand, ones a digit
+// off, random digits, and numbers too long for an int64, which have to be
+// asked for again rather than wrap around.
+func fuzzCredit(r *rand.Rand) fuzzCase {
+	var number string
+	switch r.Intn(4) {
+	case 0, 1:
+		card := pick(r, CARD_PREFIXES...)
+		prefix, length := pick(r, card.prefix...), pick(r, card.lengths...)
+		number = prefix + digits(r, length-len(prefix)-1)
+		for d := range 10 {
+			if luhn(number + strconv.Itoa(d)) {
+				number += strconv.Itoa(d)
+				break
+			}
+		}
+		if r.Intn(2) == 0 {
+			i := r.Intn(len(number)-1) + 1
+			number = number[:i] + strconv.Itoa((int(number[i]-'0')+1+r.Intn(9))%10) + number[i+1:]
+		}
+	case 2:
+		number = strconv.FormatInt(r.Int63n(1e12)-1e6, 10)
+	case 3:
+		number = digits(r, 1+r.Intn(18))
+	}
+	n, _ := strconv.ParseInt(number, 10, 64)
+	wrong := func() string {
+		return pick(r, pick(r, JUNK...), "9"+digits(r, 19+r.Intn(5)), "4003-6000-0000-0014")
+	}
+	return fuzzCase{Input: rejected(r, wrong, number), Want: cardBrand(n) + "\n"}
+}
+
+// fuzzMario types heights: in range, out of it, and not numbers.
+func fuzzMario(r *rand.Rand) fuzzCase {
+	height := 1 + r.Intn(8)
+	wrong := func() string {
+		return pick(r, pick(r, JUNK...), strconv.Itoa(-r.Intn(10)), strconv.Itoa(9+r.Intn(100)), "99999999999999999999", "2.5")
+	}
+	answer := strconv.Itoa(height)
+	if r.Intn(5) == 0 {
+		answer = pick(r, " "+answer+" ", "0"+answer, "+"+answer)
+	}
+	var want strings.Builder
+	for i := 1; i <= height; i++ {
+		want.WriteString(strings.Repeat(" ", height-i) + strings.Repeat("#", i) + "\n")
+	}
+	return fuzzCase{Input: rejected(r, wrong, answer), Want: want.String()}
+}
+
+// fuzzCash types amounts of change in the ways GetCurrency takes them,
+// now and then one so big that its cents don't fit in an int.
+func fuzzCash(r *rand.Rand) fuzzCase {
+	units := big.NewInt(r.Int63n(100))
+	switch r.Intn(10) {
+	case 0:
+		units.SetInt64(r.Int63n(1e9))
+	case 1:
+		units.SetInt64(1e17 + r.Int63n(9e18-1e17))
+	}
+	cents := int64(r.Intn(100))
+	answer := pick(r, fmt.Sprintf("%d.%02d", units, cents), fmt.Sprintf("$%d.%02d", units, cents))
+	if cents == 0 {
+		answer = pick(r, answer, units.String())
+	}
+	if units.Sign() == 0 && r.Intn(2) == 0 {
+		answer = fmt.Sprintf(".%02d", cents)
+	}
+	wrong := func() string {
+		return pick(r, pick(r, JUNK...), "-1.00", "-0.01", "1.234", "$", "4.2.0", "$-5")
+	}
+
+	// The fewest US coins, counted as big numbers so the answer is right
+	// even where the program's int isn't.
+	amount := new(big.Int).Add(new(big.Int).Mul(units, big.NewInt(100)), big.NewInt(cents))
+	coins := new(big.Int)
+	for _, coin := range []int64{25, 10, 5, 1} {
+		n, m := new(big.Int).DivMod(amount, big.NewInt(coin), new(big.Int))
+		coins.Add(coins, n)
+		amount = m
+	}
+	return fuzzCase{Input: rejected(r, wrong, answer), Want: "$" + coins.String() + "\n"}
+}
+
+// fuzzSubstitution tries keys, good and bad, on random plaintext. A bad
+// key, or no key, exits 1 without asking for any.
+func fuzzSubstitution(r *rand.Rand) fuzzCase {
+	key := []byte(cipher.RandomKey(r))
+	for i := range key {
+		if r.Intn(3) == 0 {
+			key[i] += 'a' - 'A'
+		}
+	}
+	switch r.Intn(6) {
+	case 0:
+		return fuzzCase{Args: pick(r, []string{}, []string{string(key), string(key)}), Exit: 1}
+	case 1:
+		bad := slices.Clone(key)
+		switch r.Intn(4) {
+		case 0:
+			bad = bad[:r.Intn(26)]
+		case 1:
+			bad = append(bad, 'A'+byte(r.Intn(26)))
+		case 2:
+			bad[r.Intn(26)] = pick[byte](r, '1', '.', ' ', '-')
+		case 3:
+			i := r.Intn(25)
+			bad[i+1] = bad[i]
+		}
+		return fuzzCase{Args: []string{string(bad)}, Exit: 1}
+	}
+
+	plain := make([]byte, r.Intn(40))
+	for i := range plain {
+		plain[i] = pick(r, byte('a'+r.Intn(26)), byte('A'+r.Intn(26)), pick[byte](r, ' ', ',', '!', '1'))
+	}
+	ciphertext := make([]byte, len(plain))
+	for i, c := range plain {
+		switch {
+		case c >= 'a' && c <= 'z':
+			ciphertext[i] = key[c-'a'] | 0x20
+		case c >= 'A' && c <= 'Z':
+			ciphertext[i] = key[c-'A'] &^ 0x20
+		default:
+			ciphertext[i] = c
+		}
+	}
+	return fuzzCase{Args: []string{string(key)}, Input: []string{string(plain)}, Want: "ciphertext: " + string(ciphertext) + "\n"}
+}
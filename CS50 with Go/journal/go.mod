@@ -0,0 +1,60 @@
+module journal
+
+go 1.25.0
+
+require (
+	bst v0.0.0
+	chart v0.0.0
+	cipher v0.0.0
+	config v0.0.0
+	cs50 v0.0.0
+	exercises v0.0.0
+	github.com/charmbracelet/bubbletea v1.3.10
+	github.com/charmbracelet/lipgloss v1.1.0
+	golang.org/x/net v0.56.0
+	i18n v0.0.0
+	linkedlist v0.0.0
+	profiling v0.0.0
+	rngutil v0.0.0
+	runctx v0.0.0
+	speller v0.0.0
+)
+
+require (
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/colorprofile v0.2.3-0.20250311203215-f60798e515dc // indirect
+	github.com/charmbracelet/x/ansi v0.10.1 // indirect
+	github.com/charmbracelet/x/cellbuf v0.0.13-0.20250311204145-2c3ea96c31dd // indirect
+	github.com/charmbracelet/x/term v0.2.1 // indirect
+	github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.24 // indirect
+	github.com/mattn/go-localereader v0.0.1 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
+	github.com/muesli/cancelreader v0.2.2 // indirect
+	github.com/muesli/termenv v0.16.0 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	bst => ../week5-Data-Strucutes/bst
+	chart => ../week3-Algorithms/chart
+	cipher => ../week2-Array/cipher
+	config => ../config
+	cs50 => ../cs50
+	exercises => ../exercises
+	i18n => ../i18n
+	letters => ../week2-Array/letters
+	linkedlist => ../week5-Data-Strucutes/linkedlist
+	memstats => ../week5-Data-Strucutes/memstats
+	profiling => ../profiling
+	rngutil => ../rngutil
+	runctx => ../runctx
+	set => ../week5-Data-Strucutes/set
+	speller => ../week5-Data-Strucutes/speller
+)
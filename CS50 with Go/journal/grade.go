@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"exercises"
+)
+
+const GRADE_USAGE = "Usage: journal grade WEEK/PROBLEM [-trim] [-tolerance X] [-v]"
+
+// gradeOptions say how to run a problem on its fixtures, from
+// testdata/NAME.grade.json. Without the file each fixture is standard input
+// and the output is standard output, compared exactly.
+type gradeOptions struct {
+	// Args are the program's arguments: {in} is the fixture's .in file,
+	// which is then not standard input too, and {tmp} a fresh directory.
+	Args []string `json:"args,omitempty"`
+	// Output is the file to compare, like "{tmp}/out.bmp", when the
+	// program writes its answer to a file instead of standard output.
+	Output    string  `json:"output,omitempty"`
+	Trim      bool    `json:"trim,omitempty"`      // ignore whitespace at the ends of lines and the output
+	Tolerance float64 `json:"tolerance,omitempty"` // how far apart numbers can be and still match
+}
+
+// fixture is one graded input: testdata/NAME.in and NAME.expected.
+type fixture struct {
+	Name            string
+	Input, Expected string // paths
+}
+
+func gradeCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("grade", flag.ContinueOnError)
+	flags.SetOutput(w)
+	trim := flags.Bool("trim", false, "ignore whitespace at the ends of lines")
+	tolerance := flags.Float64("tolerance", 0, "let numbers differ by this much")
+	verbose := flags.Bool("v", false, "show the whole diff of failed fixtures")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *tolerance < 0 {
+		fmt.Fprintln(w, GRADE_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	dir := e.Path(root)
+	options, err := loadGradeOptions(filepath.Join(dir, "testdata", e.Name()+".grade.json"))
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	options.Trim = options.Trim || *trim
+	options.Tolerance = max(options.Tolerance, *tolerance)
+	fixtures, err := findFixtures(filepath.Join(dir, "testdata"))
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if len(fixtures) == 0 {
+		fmt.Fprintf(w, "No fixtures yet: add NAME.in and NAME.expected to %s\n", filepath.Join(dir, "testdata"))
+		return 2
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return grade(w, bin, dir, fixtures, options, *verbose)
+}
+
+// loadGradeOptions reads a problem's grade file, or the defaults without one.
+func loadGradeOptions(path string) (gradeOptions, error) {
+	var options gradeOptions
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return options, nil
+	}
+	if err != nil {
+		return options, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&options); err != nil {
+		return options, fmt.Errorf("%s: %w", path, err)
+	}
+	if options.Tolerance < 0 {
+		return options, fmt.Errorf("%s: the tolerance can't be negative", path)
+	}
+	return options, nil
+}
+
+// findFixtures lists the .in files in dir that have an .expected file,
+// by name. An .in file without one is an error, not a fixture skipped.
+func findFixtures(dir string) ([]fixture, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.in"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(inputs)
+	var fixtures []fixture
+	for _, input := range inputs {
+		name := strings.TrimSuffix(filepath.Base(input), ".in")
+		expected := strings.TrimSuffix(input, ".in") + ".expected"
+		if _, err := os.Stat(expected); err != nil {
+			return nil, fmt.Errorf("%s has no %s", filepath.Base(input), filepath.Base(expected))
+		}
+		fixtures = append(fixtures, fixture{name, input, expected})
+	}
+	return fixtures, nil
+}
+
+// grade runs bin in dir on every fixture and prints a line for each and
+// the score, returning 1 when any failed.
+func grade(w io.Writer, bin, dir string, fixtures []fixture, options gradeOptions, verbose bool) int {
+	passed := 0
+	for _, f := range fixtures {
+		got, err := runFixture(bin, dir, f, options)
+		if err != nil {
+			fmt.Fprintf(w, ":( %s\n    %v\n", f.Name, err)
+			continue
+		}
+		want, err := os.ReadFile(f.Expected)
+		if err != nil {
+			fmt.Fprintf(w, ":( %s\n    %v\n", f.Name, err)
+			continue
+		}
+		problem := compareOutput(string(want), string(got), options)
+		if problem == "" {
+			passed++
+			fmt.Fprintf(w, ":) %s\n", f.Name)
+			continue
+		}
+		fmt.Fprintf(w, ":( %s\n    %s\n", f.Name, problem)
+		if verbose && !looksBinary(want) && !looksBinary(got) {
+			edits := diffLines(splitLines(string(want)), splitLines(string(got)))
+			printDiff(w, unified(f.Name+".expected", edits), false)
+		}
+	}
+	fmt.Fprintf(w, "Score: %d of %d (%d%%).\n", passed, len(fixtures), passed*100/len(fixtures))
+	if passed < len(fixtures) {
+		return 1
+	}
+	return 0
+}
+
+// runFixture runs bin on f and returns what it wrote: to standard output,
+// or to options.Output. Its exit code doesn't matter, only the output.
+func runFixture(bin, dir string, f fixture, options gradeOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	tmp, err := os.MkdirTemp("", "journal-grade-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	expand := strings.NewReplacer("{in}", f.Input, "{tmp}", tmp).Replace
+
+	args := make([]string, len(options.Args))
+	usesInput := false
+	for i, arg := range options.Args {
+		usesInput = usesInput || strings.Contains(arg, "{in}")
+		args[i] = expand(arg)
+	}
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = englishEnv()
+	if !usesInput {
+		in, err := os.Open(f.Input)
+		if err != nil {
+			return nil, err
+		}
+		defer in.Close()
+		cmd.Stdin = in
+	}
+	var out, stderr limitedBuffer
+	cmd.Stdout, cmd.Stderr = &out, &stderr
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil, errors.New("timed out while waiting for program to exit")
+	}
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		return nil, err
+	}
+	if options.Output == "" {
+		return out.Bytes(), nil
+	}
+	data, err := os.ReadFile(expand(options.Output))
+	if err != nil {
+		return nil, fmt.Errorf("no output file: %v\n    %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return data, nil
+}
+
+// NUMBER_PATTERN matches the numbers in a line, for the tolerance.
+var NUMBER_PATTERN = regexp.MustCompile(`[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?`)
+
+// compareOutput says how got differs from want, or "" when it doesn't.
+// Text is compared a line at a time, normalised the way options say;
+// anything with a zero byte in it, like an image, has to be the same.
+func compareOutput(want, got string, options gradeOptions) string {
+	if looksBinary([]byte(want)) || looksBinary([]byte(got)) {
+		if want != got {
+			return fmt.Sprintf("expected the same %d bytes, not %d different ones", len(want), len(got))
+		}
+		return ""
+	}
+	wantLines, gotLines := normalize(want, options.Trim), normalize(got, options.Trim)
+	for i := range max(len(wantLines), len(gotLines)) {
+		if i >= len(wantLines) {
+			return fmt.Sprintf("line %d: expected the end, not %s", i+1, shorten(gotLines[i]))
+		}
+		if i >= len(gotLines) {
+			return fmt.Sprintf("line %d: expected %s, not the end", i+1, shorten(wantLines[i]))
+		}
+		if !sameLine(wantLines[i], gotLines[i], options.Tolerance) {
+			return fmt.Sprintf("line %d: expected %s, not %s", i+1, shorten(wantLines[i]), shorten(gotLines[i]))
+		}
+	}
+	return ""
+}
+
+// normalize splits text into lines, with no \r, and with trim no
+// whitespace at their ends or blank lines at the end.
+func normalize(text string, trim bool) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if trim {
+		text = strings.TrimRight(text, " \t\n")
+	}
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if trim {
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+	}
+	return lines
+}
+
+// sameLine reports whether got is want, with each number in it no more
+// than tolerance away from want's.
+func sameLine(want, got string, tolerance float64) bool {
+	if want == got {
+		return true
+	}
+	if tolerance == 0 {
+		return false
+	}
+	wantNumbers, gotNumbers := NUMBER_PATTERN.FindAllString(want, -1), NUMBER_PATTERN.FindAllString(got, -1)
+	if len(wantNumbers) != len(gotNumbers) ||
+		NUMBER_PATTERN.ReplaceAllString(want, "#") != NUMBER_PATTERN.ReplaceAllString(got, "#") {
+		return false
+	}
+	for i := range wantNumbers {
+		a, errA := strconv.ParseFloat(wantNumbers[i], 64)
+		b, errB := strconv.ParseFloat(gotNumbers[i], 64)
+		if errA != nil || errB != nil || math.Abs(a-b) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// looksBinary reports whether data looks like anything but text.
+func looksBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"go/scanner"
+	"go/token"
+	"html"
+	"slices"
+	"strings"
+)
+
+// language is what highlight needs to know to colour a language other
+// than Go: its comments and keywords.
+type language struct {
+	lineComment  []string
+	blockComment [2]string
+	keywords     []string
+	ignoreCase   bool // SQL's SELECT and select
+}
+
+// LANGUAGES are the other languages in the notes, by the names fenced code
+// blocks use and by file extension.
+var LANGUAGES = map[string]*language{
+	"c": {
+		lineComment:  []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		keywords: []string{"break", "case", "char", "const", "continue", "default", "do", "double", "else", "enum",
+			"extern", "float", "for", "if", "int", "long", "return", "short", "signed", "sizeof", "static",
+			"struct", "switch", "typedef", "union", "unsigned", "void", "while", "bool", "string", "NULL",
+			"true", "false", "#include", "#define"},
+	},
+	"python": {
+		lineComment: []string{"#"},
+		keywords: []string{"and", "as", "assert", "break", "class", "continue", "def", "del", "elif", "else",
+			"except", "False", "finally", "for", "from", "if", "import", "in", "is", "lambda", "None", "not",
+			"or", "pass", "raise", "return", "True", "try", "while", "with", "yield"},
+	},
+	"sql": {
+		lineComment:  []string{"--"},
+		blockComment: [2]string{"/*", "*/"},
+		keywords: []string{"and", "as", "asc", "by", "create", "delete", "desc", "distinct", "from", "group",
+			"having", "in", "index", "inner", "insert", "integer", "into", "is", "join", "key", "like", "limit",
+			"not", "null", "on", "or", "order", "primary", "references", "select", "set", "table", "text",
+			"update", "values", "where"},
+		ignoreCase: true,
+	},
+	"javascript": {
+		lineComment:  []string{"//"},
+		blockComment: [2]string{"/*", "*/"},
+		keywords: []string{"async", "await", "break", "case", "catch", "class", "const", "continue", "default",
+			"else", "export", "false", "for", "function", "if", "import", "let", "new", "null", "of", "return",
+			"switch", "this", "throw", "true", "try", "undefined", "var", "while"},
+	},
+	"sh": {
+		lineComment: []string{"#"},
+		keywords:    []string{"case", "do", "done", "elif", "else", "esac", "fi", "for", "if", "in", "then", "while"},
+	},
+}
+
+func init() {
+	for alias, name := range map[string]string{
+		"h": "c", "py": "python", "js": "javascript", "mjs": "javascript",
+		"bash": "sh", "shell": "sh", "console": "sh", "sqlite": "sql",
+	} {
+		LANGUAGES[alias] = LANGUAGES[name]
+	}
+}
+
+// highlight escapes code for a <pre> and wraps its comments, strings,
+// numbers and keywords in spans the stylesheet colours: c, s, n and k.
+// Go's predeclared names are b. A language it doesn't know is just escaped.
+func highlight(code, lang string) string {
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	lang = strings.ToLower(lang)
+	if lang == "go" {
+		return highlightGo(code)
+	}
+	if l, ok := LANGUAGES[lang]; ok {
+		return l.highlight(code)
+	}
+	return html.EscapeString(code)
+}
+
+// span writes text in a span of class, or just escaped without one.
+func span(b *strings.Builder, class, text string) {
+	if class == "" {
+		b.WriteString(html.EscapeString(text))
+		return
+	}
+	b.WriteString(`<span class="` + class + `">` + html.EscapeString(text) + "</span>")
+}
+
+// PREDECLARED are Go's built-in types, constants and functions.
+var PREDECLARED = strings.Fields(`any append bool byte cap clear close complex complex64 complex128 copy
+	delete error false float32 float64 imag int int8 int16 int32 int64 iota len make max min new nil
+	panic print println real recover rune string true uint uint8 uint16 uint32 uint64 uintptr`)
+
+// highlightGo colours Go with the standard library's scanner, so it's
+// right about raw strings, runes and the rest. Code that doesn't scan, like
+// a snippet in the notes, is coloured as far as it can be.
+func highlightGo(code string) string {
+	fset := token.NewFileSet()
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", fset.Base(), len(code)), []byte(code), nil, scanner.ScanComments)
+	var b strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // inserted, not in the code
+		}
+		start := fset.Position(pos).Offset
+		text := lit
+		if text == "" {
+			text = tok.String() // an operator
+		}
+		end := min(len(code), start+len(text))
+		b.WriteString(html.EscapeString(code[last:start]))
+		class := ""
+		switch {
+		case tok == token.COMMENT:
+			class = "c"
+		case tok == token.STRING || tok == token.CHAR:
+			class = "s"
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			class = "n"
+		case tok.IsKeyword():
+			class = "k"
+		case tok == token.IDENT && slices.Contains(PREDECLARED, lit):
+			class = "b"
+		}
+		span(&b, class, code[start:end])
+		last = end
+	}
+	b.WriteString(html.EscapeString(code[last:]))
+	return b.String()
+}
+
+// highlight colours code in l, a word at a time: good enough for snippets
+// in notes, without a real lexer for every language.
+func (l *language) highlight(code string) string {
+	var b strings.Builder
+	isWord := func(c byte) bool {
+		return c == '_' || c == '#' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	}
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		switch {
+		case slices.ContainsFunc(l.lineComment, func(p string) bool { return strings.HasPrefix(rest, p) }):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			span(&b, "c", rest[:end])
+			i += end
+		case l.blockComment[0] != "" && strings.HasPrefix(rest, l.blockComment[0]):
+			end := strings.Index(rest[len(l.blockComment[0]):], l.blockComment[1])
+			if end < 0 {
+				end = len(rest)
+			} else {
+				end += len(l.blockComment[0]) + len(l.blockComment[1])
+			}
+			span(&b, "c", rest[:end])
+			i += end
+		case rest[0] == '"' || rest[0] == '\'' || rest[0] == '`':
+			end := 1
+			for end < len(rest) && rest[end] != rest[0] && rest[end] != '\n' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(len(rest), end+1)
+			span(&b, "s", rest[:end])
+			i += end
+		case isWord(rest[0]) && (i == 0 || !isWord(code[i-1])):
+			end := 1
+			for end < len(rest) && isWord(rest[end]) {
+				end++
+			}
+			word, class := rest[:end], ""
+			switch {
+			case word[0] >= '0' && word[0] <= '9':
+				class = "n"
+			case slices.ContainsFunc(l.keywords, func(k string) bool {
+				return k == word || l.ignoreCase && strings.EqualFold(k, word)
+			}):
+				class = "k"
+			}
+			span(&b, class, word)
+			i += end
+		default:
+			span(&b, "", rest[:1])
+			i++
+		}
+	}
+	return b.String()
+}
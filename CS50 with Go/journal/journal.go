@@ -0,0 +1,142 @@
+// journal: chores around the learning journal, starting with new projects.
+//
+//	./journal scaffold webapp guestbook    a web app like birthdays, in ./guestbook
+//	./journal scaffold cli wordcount       a command like csvdb
+//	./journal scaffold api shorten -dir ~/final
+//	./journal check week1/credit           run credit's checks, like check50
+//	./journal style week1-C                gofmt's diffs and a score, like style50
+//	./journal package week1/credit         credit.zip to hand in, like submit50
+//	./journal progress                     what's started, checked and completed
+//	./journal ui                           all of it in a dashboard
+//	./journal new week5/queue              a starter, its finished version and tests
+//	./journal start week1/credit           time it, until ./journal stop
+//	./journal report                       where the time went, by day, week and problem
+//	./journal cards review                 flashcards due today, from ;;Q and ;;A in notes
+//	./journal export ./site -serve         the notes and code as a static site, previewed
+//	./journal search linked list           ranked file:line matches across the weeks
+//	./journal diff week5/recipe            the starter against the finished version
+//	./journal grade week5/speller          score it on testdata's .in and .expected files
+//	./journal fuzz week1/credit -runs 1000 random input, looking for crashes, hangs and wrong answers
+//	./journal bench week4/recover          time the Go version against the C one
+//	./journal stats                        Go written per week, tests, checks and streaks
+//	./journal spec week5/speller           the spec as Markdown and the distribution code, fetched
+//	./journal play bst                     insert, delete and find, drawn after every step
+//	./journal record week1/mario -o demo.cast  a run, typing and all, to replay
+//	./journal replay demo.cast -check      play it back, or run it again as a test
+//	./journal run week2/readability -stdin book.txt  once, on a file, diffed with -expect
+//	./journal profile week5/speller -args dictionaries/small texts/cat.txt  where the time goes
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"config"
+	"exercises"
+	"i18n"
+	"runctx"
+)
+
+const USAGE = `Usage:
+  journal scaffold webapp|cli|api NAME [-dir DIR]
+  journal check WEEK/PROBLEM|all [-v]
+  journal style PATH... [-fix] [-color]
+  journal package WEEK/PROBLEM [-o FILE.zip|FILE.tar.gz]
+  journal progress [WEEK] | start|done|reset WEEK/PROBLEM
+  journal ui
+  journal new WEEK/NAME [-template datastructure|function]
+  journal start WEEK/PROBLEM | stop
+  journal report [-days N]
+  journal cards [add QUESTION ANSWER | add -from PATH... | review [-n N]]
+  journal export DIR [-serve] [-port N]
+  journal search WORDS... [-n N] [-color] [-rebuild]
+  journal diff WEEK/PROBLEM|DIR [-sync] [-color]
+  journal grade WEEK/PROBLEM [-trim] [-tolerance X] [-v]
+  journal fuzz WEEK/PROBLEM [-runs N] [-seed N] [-timeout D]
+  journal bench WEEK/PROBLEM [-n N] [-json]
+  journal stats [-weeks N]
+  journal spec WEEK/PROBLEM [-year N] [-url URL] [-refresh]
+  journal play list|bst|hash|trie
+  journal record WEEK/PROBLEM [ARGS...] [-o FILE.cast]
+  journal replay FILE.cast [-speed X] [-idle D] [-check]
+  journal run WEEK/PROBLEM [-stdin FILE] [-expect FILE] [-trim] [-o FILE] [-args ARGS...]
+  journal profile WEEK/PROBLEM [-mem | -trace] [-stdin FILE] [-o FILE] [-top N] [-http ADDR] [-timeout D] [-args ARGS...]`
+
+func main() {
+	// Outside the repo, scaffolding say, there's only the user's config.
+	root, _ := exercises.Root()
+	c, err := config.Load(root)
+	if err == nil {
+		err = i18n.Setenv(c.Language)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	// Ctrl-C stops fuzz with its summary so far and puts the terminal back
+	// the way it was, as ui may not have; journal then exits 130.
+	runctx.Main(func(context.Context) int { return run(os.Args[1:], os.Stdout) })
+}
+
+// run handles one subcommand and returns the exit code.
+func run(args []string, w io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+	switch args[0] {
+	case "scaffold":
+		return scaffoldCommand(args[1:], w)
+	case "check":
+		return checkCommand(args[1:], w)
+	case "style":
+		return styleCommand(args[1:], w)
+	case "package":
+		return packageCommand(args[1:], w)
+	case "progress":
+		return progressCommand(args[1:], w)
+	case "ui":
+		return uiCommand(args[1:], w)
+	case "new":
+		return newCommand(args[1:], w)
+	case "start":
+		return startCommand(args[1:], w)
+	case "stop":
+		return stopCommand(args[1:], w)
+	case "report":
+		return reportCommand(args[1:], w)
+	case "cards":
+		return cardsCommand(args[1:], w)
+	case "export":
+		return exportCommand(args[1:], w)
+	case "search":
+		return searchCommand(args[1:], w)
+	case "diff":
+		return diffCommand(args[1:], w)
+	case "grade":
+		return gradeCommand(args[1:], w)
+	case "fuzz":
+		return fuzzCommand(args[1:], w)
+	case "bench":
+		return benchCommand(args[1:], w)
+	case "stats":
+		return statsCommand(args[1:], w)
+	case "spec":
+		return specCommand(args[1:], w)
+	case "play":
+		return playCommand(args[1:], w)
+	case "record":
+		return recordCommand(args[1:], w)
+	case "replay":
+		return replayCommand(args[1:], w)
+	case "run":
+		return runCommand(args[1:], w)
+	case "profile":
+		return profileCommand(args[1:], w)
+	}
+	fmt.Fprintln(w, USAGE)
+	return 1
+}
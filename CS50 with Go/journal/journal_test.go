@@ -0,0 +1,1967 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"maps"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/net/html"
+
+	"config"
+	"exercises"
+	"i18n"
+	"profiling"
+)
+
+func TestScaffold(t *testing.T) {
+	tests := []struct {
+		kind  string
+		files []string
+	}{
+		{"cli", []string{".gitignore", "README.md", "generate.go", "go.mod", "word-count.go", "word-count_test.go"}},
+		{"api", []string{".gitignore", "README.md", "generate.go", "go.mod", "handlers.go", "word-count.go", "word-count_test.go"}},
+		{"webapp", []string{".gitignore", "README.md", "generate.go", "go.mod", "handlers.go", "templates/index.html",
+			"templates/layout.html", "templates/styles.css", "word-count.go", "word-count_test.go"}},
+	}
+	for _, tt := range tests {
+		root := filepath.Join(t.TempDir(), "word-count")
+		files, err := scaffold(tt.kind, "word-count", root)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.kind, err)
+		}
+		slices.Sort(files)
+		if !slices.Equal(files, tt.files) {
+			t.Errorf("%s: files %q, want %q", tt.kind, files, tt.files)
+		}
+
+		for _, file := range files {
+			content, err := os.ReadFile(filepath.Join(root, file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(content, []byte("[[")) {
+				t.Errorf("%s/%s: placeholder left in\n%s", tt.kind, file, content)
+			}
+			if strings.HasSuffix(file, ".go") {
+				if _, err := parser.ParseFile(token.NewFileSet(), file, content, 0); err != nil {
+					t.Errorf("%s/%s: %v", tt.kind, file, err)
+				}
+			}
+		}
+		gomod, _ := os.ReadFile(filepath.Join(root, "go.mod"))
+		if !strings.HasPrefix(string(gomod), "module word-count\n\ngo "+GO_VERSION) {
+			t.Errorf("%s: go.mod = %q", tt.kind, gomod)
+		}
+	}
+
+	// The web app's own templates are left for html/template
+	root := filepath.Join(t.TempDir(), "guestbook")
+	if _, err := scaffold("webapp", "guestbook", root); err != nil {
+		t.Fatal(err)
+	}
+	layout, _ := os.ReadFile(filepath.Join(root, "templates", "layout.html"))
+	if !strings.Contains(string(layout), `{{block "main" .}}`) || !strings.Contains(string(layout), "<title>Guestbook</title>") {
+		t.Errorf("layout.html =\n%s", layout)
+	}
+}
+
+func TestScaffoldErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "taken"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		kind, name string
+		want       string
+	}{
+		{"gui", "app", `unknown kind "gui"`},
+		{"cli", "App", `invalid name "App"`},
+		{"cli", "my_app", `invalid name "my_app"`},
+		{"cli", "9lives", `invalid name "9lives"`},
+		{"cli", "../up", `invalid name "../up"`},
+		{"cli", "taken", "already exists"},
+	}
+	for _, tt := range tests {
+		_, err := scaffold(tt.kind, tt.name, filepath.Join(dir, tt.name))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("scaffold(%s, %s) error = %v, want %q", tt.kind, tt.name, err, tt.want)
+		}
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("failed scaffolds left %d entries behind", len(entries)-1)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		args   []string
+		status int
+		want   string
+	}{
+		{nil, 1, USAGE},
+		{[]string{"publish"}, 1, USAGE},
+		{[]string{"scaffold", "cli"}, 1, USAGE},
+		{[]string{"scaffold", "cli", "wc", "-dir", dir}, 0, "Created cli " + filepath.Join(dir, "wc")},
+		{[]string{"scaffold", "-dir", dir, "cli", "wc"}, 1, "already exists"},
+		{[]string{"check"}, 1, CHECK_USAGE},
+		{[]string{"style"}, 1, STYLE_USAGE},
+		{[]string{"package"}, 1, PACKAGE_USAGE},
+		{[]string{"new", "queue"}, 1, NEW_USAGE},
+		{[]string{"new", "week5/Queue"}, 1, `invalid name "Queue"`},
+		{[]string{"package", "credit", "-o", "credit.rar"}, 1, "a package is a .zip or a .tar.gz"},
+		{[]string{"check", "week1/nope"}, 1, `No problem "week1/nope"`},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if status := run(tt.args, &out); status != tt.status || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("run(%q) = %d %q, want %d with %q", tt.args, status, out.String(), tt.status, tt.want)
+		}
+	}
+}
+
+// A project made inside a workspace, like this repo, is added to its go.work.
+func TestJoinWorkspace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("no go command")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.work"), []byte("go "+GO_VERSION+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Join(dir, "week10", "guestbook")
+	if _, err := scaffold("cli", "guestbook", root); err != nil {
+		t.Fatal(err)
+	}
+	work, err := joinWorkspace(root)
+	if err != nil || work != filepath.Join(dir, "go.work") {
+		t.Fatalf("joinWorkspace = %q, %v", work, err)
+	}
+	if content, _ := os.ReadFile(work); !strings.Contains(string(content), "./week10/guestbook") {
+		t.Errorf("go.work doesn't use the project:\n%s", content)
+	}
+
+	// Outside a workspace there's nothing to do (the temp directory isn't
+	// in one, or TestScaffold would be adding to it).
+	if work, err := joinWorkspace(filepath.Join(t.TempDir(), "alone")); work != "" || err != nil {
+		t.Errorf("joinWorkspace outside a workspace = %q, %v", work, err)
+	}
+}
+
+// Every skeleton is ready to run: it vets and its tests pass.
+func TestSkeletonsBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command")
+	}
+	for _, kind := range KINDS {
+		root := filepath.Join(t.TempDir(), "check-"+kind)
+		if _, err := scaffold(kind, "check-"+kind, root); err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range [][]string{{"vet", "."}, {"test", "."}} {
+			cmd := exec.Command(goCmd, args...)
+			cmd.Dir = root
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Errorf("%s: go %s: %v\n%s", kind, strings.Join(args, " "), err, out)
+			}
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		want          bool
+	}{
+		{"h3ll0\n", "h3ll0\n", true},
+		{"h3ll0\n", "h3ll0", false},
+		{"*AMEX\n", "Number: AMEX\n", true},
+		{"*AMEX\n", "AMEX\n", true},
+		{"*AMEX\n", "AMEX\nINVALID\n", false},
+		{"*\n4\n", "Change owed: $quarters   1\n4\n", true},
+		{"*\n4\n", "Change owed: $14\n", false},
+		{"Usage: *", "Usage: ./dna data.csv sequence.txt\n", true},
+		{"*Invalid vote.\n*Bob\n", "Vote: Invalid vote.\nVote: Bob\n", true},
+		{"*ab*ba", "aba", false},
+		{"*", "", true},
+		{"*Grade 5\n", "Grade 5\r\n", true},
+	}
+	for _, tt := range tests {
+		if got := match(tt.pattern, tt.text); got != tt.want {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLoadCasesErrors(t *testing.T) {
+	tests := []struct {
+		json, want string
+	}{
+		{`[{"input": ["1"]}]`, "case 1 has no name"},
+		{`[{"name": "ok"}, {"name": "both", "reject": true, "exit": 1}]`, `"both" can't both reject`},
+		{`[{"name": "typo", "stdin": ["1"]}]`, `unknown field "stdin"`},
+		{`{"name": "not a list"}`, "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "x.checks.json")
+		if err := os.WriteFile(path, []byte(tt.json), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadCases(path); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("loadCases(%s) error = %v, want %q", tt.json, err, tt.want)
+		}
+	}
+}
+
+// Every checks file in the repo can be run.
+func TestChecksFiles(t *testing.T) {
+	root, err := exercises.Root()
+	if err != nil {
+		t.Skip(err)
+	}
+	found := 0
+	for _, e := range exercises.PROGRAMS {
+		cases, err := loadCases(checksPath(root, e))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(cases) == 0 {
+			t.Errorf("%s has no cases", e.ID())
+		}
+		found++
+	}
+	if found == 0 {
+		t.Error("no checks files")
+	}
+
+	// So can the ones waiting for their program, like caesar's.
+	filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err == nil && strings.HasSuffix(path, ".checks.json") && filepath.Base(filepath.Dir(path)) == "testdata" {
+			if _, err := loadCases(path); err != nil {
+				t.Error(err)
+			}
+		}
+		return nil
+	})
+}
+
+// runCase is tried on sh, which every case can be made of.
+func TestRunCase(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		c    checkCase
+		want string
+	}{
+		{checkCase{Args: []string{"-c", "read name; echo hello, $name"}, Input: []string{"Emma"}, Stdout: str("hello, Emma\n")}, ""},
+		{checkCase{Args: []string{"-c", "echo hi"}, Stdout: str("bye\n")}, `expected "bye\n", not "hi\n"`},
+		{checkCase{Args: []string{"-c", "exit 3"}, Exit: 3}, ""},
+		{checkCase{Args: []string{"-c", "exit 3"}}, "expected exit code 0, not 3"},
+		{checkCase{Args: []string{"-c", "true"}, Exit: 1}, "expected exit code 1, not 0"},
+		{checkCase{Args: []string{"-c", "read a; read b"}, Input: []string{"-1"}, Reject: true}, ""},
+		{checkCase{Args: []string{"-c", "read a"}, Input: []string{"1"}, Reject: true}, "expected the input to be rejected"},
+		{checkCase{Args: []string{"-c", "sleep 5"}, Timeout: 0.1}, "timed out"},
+		{checkCase{Args: []string{"-c", "touch {tmp}/out && test -f {tmp}/out"}}, ""},
+	}
+	for _, tt := range tests {
+		if got, _ := runCase(sh, t.TempDir(), tt.c); !strings.Contains(got, tt.want) || (tt.want == "") != (got == "") {
+			t.Errorf("runCase(%q) = %q, want %q", tt.c.Args, got, tt.want)
+		}
+	}
+}
+
+// check builds a real exercise and runs its cases.
+func TestCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds an exercise")
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		t.Skip(err)
+	}
+	// Checks are in English, whatever the messages are in.
+	t.Setenv(i18n.LANG_ENV, "th")
+	e, _ := exercises.Find("no-vowels")
+	var out bytes.Buffer
+	if status, summary := check(root, e, &out, false); status != 0 || summary != "5 of 5 passed" || !strings.Contains(out.String(), ":) converts \"hello\" to \"h3ll0\"") {
+		t.Errorf("check(no-vowels) = %d, %q\n%s", status, summary, out.String())
+	}
+}
+
+// quiz is an exercise that isn't a program, which checks itself.
+type quiz struct{ err error }
+
+func (q quiz) Name() string                            { return "quiz" }
+func (q quiz) Week() int                               { return 2 }
+func (q quiz) Summary() string                         { return "ten questions on arrays" }
+func (q quiz) Run(context.Context, exercises.IO) error { return nil }
+func (q quiz) Check(context.Context) error             { return q.err }
+
+func TestCheckExercise(t *testing.T) {
+	for _, test := range []struct {
+		err     error
+		status  int
+		summary string
+		want    string
+	}{
+		{nil, 0, "its check passed", "Results for week2/quiz\n:) quiz passes its check\n"},
+		{errors.New("question 3 is wrong"), 1, "its check failed", "Results for week2/quiz\n:( quiz passes its check\n    question 3 is wrong\n"},
+	} {
+		var out bytes.Buffer
+		status, summary := check("..", quiz{test.err}, &out, false)
+		if status != test.status || summary != test.summary || out.String() != test.want {
+			t.Errorf("check(quiz{%v}) = %d, %q\n%s", test.err, status, summary, out.String())
+		}
+	}
+}
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    string // the edits' ops
+		changed int
+	}{
+		{"a\nb\nc", "a\nb\nc", "   ", 0},
+		{"a\nb\nc", "a\nB\nc", " -+ ", 1},
+		{"a\nc", "a\nb\nc", " + ", 1},
+		{"a\nb\n\n\nc", "a\nb\n\nc", "   - ", 1},
+		{"x\ny", "z", "--+", 2},
+		{"", "package main", "+", 1},
+	}
+	for _, tt := range tests {
+		edits := diffLines(splitLines(tt.a), splitLines(tt.b))
+		var ops []byte
+		for _, e := range edits {
+			ops = append(ops, e.Op)
+		}
+		if string(ops) != tt.want || changedLines(edits) != tt.changed {
+			t.Errorf("diffLines(%q, %q) = %q changing %d, want %q changing %d", tt.a, tt.b, ops, changedLines(edits), tt.want, tt.changed)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		a = append(a, fmt.Sprint(i))
+		b = append(b, fmt.Sprint(i))
+	}
+	b[1], b[17] = "two", "eighteen"
+	want := `--- f.go
++++ f.go (gofmt)
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -15,6 +15,6 @@
+ 15
+ 16
+ 17
+-18
++eighteen
+ 19
+ 20
+`
+	if got := unified("f.go", diffLines(a, b)); got != want {
+		t.Errorf("unified =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestStyle(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.go":          "package main\n\nfunc main() {}\n",
+		"bad.go":           "package main\nfunc  f (){\n}\n",
+		"crlf.go":          "package main\r\n\r\nvar x = 1\r\n",
+		"testdata/skip.go": "package  main",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if status := styleCommand([]string{dir, "-color=false"}, &out); status != 1 {
+		t.Errorf("style = %d, want 1", status)
+	}
+	for _, want := range []string{
+		":) " + filepath.Join(dir, "good.go"),
+		":( " + filepath.Join(dir, "bad.go") + ", 2 of 3 lines",
+		"-func  f (){\n    +\n    +func f() {\n",
+		":( " + filepath.Join(dir, "crlf.go") + ", CRLF line endings",
+		"1 of 3 files formatted. Score: 0.78",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("style output doesn't have %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "skip.go") || strings.Contains(out.String(), "\033[") {
+		t.Errorf("style looked in testdata or coloured its output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if status := styleCommand([]string{"-fix", dir}, &out); status != 0 {
+		t.Errorf("style -fix = %d\n%s", status, out.String())
+	}
+	if fixed, _ := os.ReadFile(filepath.Join(dir, "bad.go")); string(fixed) != "package main\n\nfunc f() {\n}\n" {
+		t.Errorf("bad.go after -fix = %q", fixed)
+	}
+	out.Reset()
+	if status := styleCommand([]string{dir}, &out); status != 0 {
+		t.Errorf("style after -fix = %d\n%s", status, out.String())
+	}
+
+	os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package main\nfunc {"), 0644)
+	out.Reset()
+	if status := styleCommand([]string{dir}, &out); status != 2 || !strings.Contains(out.String(), "broken.go doesn't parse") {
+		t.Errorf("style with a broken file = %d\n%s", status, out.String())
+	}
+}
+
+func TestPackageFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"credit.go":                   "package main",
+		"credit_test.go":              "package main",
+		"go.mod":                      "module credit",
+		"README.md":                   "# credit",
+		"credit":                      "\x7fELF\x02\x01",
+		"credit.exe":                  "MZ\x90\x00",
+		"credit.zip":                  "PK",
+		".gitignore":                  "credit",
+		"luhn/luhn.go":                "package luhn",
+		"testdata/credit.checks.json": "[]",
+		"testdata/out.bmp":            "BM",
+		"other/go.mod":                "module other",
+		"other/other.go":              "package main",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := packageFiles(dir, "credit", "")
+	want := []string{"README.md", "credit.go", "credit_test.go", "go.mod", "luhn/luhn.go", "testdata/credit.checks.json"}
+	if err != nil || !slices.Equal(files, want) {
+		t.Errorf("packageFiles = %q, %v, want %q", files, err, want)
+	}
+	files, err = packageFiles(dir, "credit", "credit.go")
+	want = []string{"credit.go", "credit_test.go", "testdata/credit.checks.json"}
+	if err != nil || !slices.Equal(files, want) {
+		t.Errorf("packageFiles of a loose file = %q, %v, want %q", files, err, want)
+	}
+}
+
+// A package opens back up into its files under the problem's name, with a
+// manifest, whichever kind of archive it is.
+func TestWriteArchive(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "hello.go"), []byte("package main\n"), 0644)
+	m := manifest{Problem: "week1/hello", Created: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC), Checks: "2 of 2 passed", Files: []string{"hello.go"}}
+
+	for _, name := range []string{"hello.zip", "hello.tar.gz"} {
+		output := filepath.Join(t.TempDir(), name)
+		if err := writeArchive(output, "hello", dir, m); err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]string{}
+		if strings.HasSuffix(name, ".zip") {
+			zr, err := zip.OpenReader(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range zr.File {
+				r, _ := f.Open()
+				content, _ := io.ReadAll(r)
+				got[f.Name] = string(content)
+			}
+			zr.Close()
+		} else {
+			f, _ := os.Open(output)
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tr := tar.NewReader(gz)
+			for {
+				header, err := tr.Next()
+				if err != nil {
+					break
+				}
+				content, _ := io.ReadAll(tr)
+				got[header.Name] = string(content)
+			}
+			f.Close()
+		}
+		if got["hello/hello.go"] != "package main\n" || !strings.Contains(got["hello/manifest.json"], `"problem": "week1/hello"`) || len(got) != 2 {
+			t.Errorf("%s holds %q", name, got)
+		}
+	}
+
+	if err := writeArchive(filepath.Join(t.TempDir(), "hello.rar"), "hello", dir, m); err == nil {
+		t.Error("writeArchive made a .rar")
+	}
+}
+
+// Code that fails its checks isn't packaged; code that passes is.
+func TestPackage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds exercises")
+	}
+	if _, err := exercises.Root(); err != nil {
+		t.Skip(err)
+	}
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	real, _ := exercises.Root()
+
+	// A no-vowels that prints its word back, in a repo of its own.
+	t.Setenv(exercises.ROOT_ENV, brokenRoot(t, real, "no-vowels", "week2-Array/no-vowels", "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() { fmt.Println(os.Args[1]) }\n"))
+	output := filepath.Join(t.TempDir(), "no-vowels.zip")
+	var out bytes.Buffer
+	if status := packageCommand([]string{"no-vowels", "-o", output}, &out); status != 1 || !strings.Contains(out.String(), "Not packaging") {
+		t.Errorf("package a failing no-vowels = %d\n%s", status, out.String())
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Error("failing code was packaged")
+	}
+
+	t.Setenv(exercises.ROOT_ENV, real)
+	output = filepath.Join(t.TempDir(), "no-vowels.tar.gz")
+	out.Reset()
+	if status := packageCommand([]string{"no-vowels", "-o", output}, &out); status != 0 || !strings.Contains(out.String(), "checks: 5 of 5 passed") {
+		t.Errorf("package no-vowels = %d\n%s", status, out.String())
+	}
+	if p, err := loadProgress(); err != nil || p["week2/no-vowels"] == nil || p["week2/no-vowels"].Status != COMPLETED {
+		t.Errorf("no-vowels isn't completed after packaging: %v", err)
+	}
+}
+
+// brokenRoot makes a repo with one problem in it, name in dir, whose code
+// is source, with the real one's checks from the repo at root, if it has
+// any.
+func brokenRoot(t *testing.T, root, name, dir, source string) string {
+	t.Helper()
+	fake := t.TempDir()
+	files := map[string]string{
+		"go.work":                "go 1.24.4\n\nuse ./" + dir + "\n",
+		"week1-C/.keep":          "",
+		dir + "/go.mod":          "module " + name + "\n\ngo 1.24.4\n",
+		dir + "/" + name + ".go": source,
+	}
+	if checks, err := os.ReadFile(filepath.Join(root, dir, "testdata", name+".checks.json")); err == nil {
+		files[dir+"/testdata/"+name+".checks.json"] = string(checks)
+	}
+	for file, content := range files {
+		path := filepath.Join(fake, filepath.FromSlash(file))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fake
+}
+
+// The config's data_dir and color win over the environment's.
+func TestConfig(t *testing.T) {
+	t.Cleanup(func() { config.Set(config.DEFAULTS) })
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	dir := t.TempDir()
+	config.Set(config.Config{Color: "always", DataDir: dir})
+	if path, err := dataPath(PROGRESS_FILE); err != nil || path != filepath.Join(dir, "journal", "progress.json") {
+		t.Errorf("dataPath with data_dir = %q, %v", path, err)
+	}
+	if !isTerminal(&bytes.Buffer{}) {
+		t.Error("color: always doesn't colour a buffer")
+	}
+	config.Set(config.Config{Color: "never"})
+	if path, _ := dataPath(PROGRESS_FILE); strings.HasPrefix(path, dir) {
+		t.Errorf("dataPath without data_dir = %q", path)
+	}
+	if isTerminal(os.Stdout) {
+		t.Error("color: never colours standard output")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	credit, _ := exercises.Find("credit")
+	cash, _ := exercises.Find("cash")
+	steps := []struct {
+		do     func() error
+		id     string
+		status string
+	}{
+		{func() error { return recordCheck(credit, false, "3 of 14 passed") }, "week1/credit", STARTED},
+		{func() error { return recordCheck(credit, true, "14 of 14 passed") }, "week1/credit", CHECKED},
+		{func() error { return recordCheck(credit, false, "13 of 14 passed") }, "week1/credit", STARTED},
+		{func() error { return recordPackage(cash, "9 of 9 passed") }, "week1/cash", COMPLETED},
+		// Completed stays completed, with the latest checks.
+		{func() error { return recordCheck(cash, false, "8 of 9 passed") }, "week1/cash", COMPLETED},
+	}
+	for i, step := range steps {
+		if err := step.do(); err != nil {
+			t.Fatal(err)
+		}
+		p, err := loadProgress()
+		if err != nil || p[step.id] == nil || p[step.id].Status != step.status {
+			t.Fatalf("step %d: %s = %+v, %v, want %s", i, step.id, p[step.id], err, step.status)
+		}
+	}
+
+	var out bytes.Buffer
+	if status := progressCommand([]string{"1"}, &out); status != 0 {
+		t.Fatalf("progress 1 = %d\n%s", status, out.String())
+	}
+	for _, want := range []string{
+		"Week 1: 1 of 9 completed\n",
+		"  cash                completed  ",
+		", 8 of 9 passed\n",
+		"  credit              started    ",
+		"  hello               -\n",
+		"1 of 9 completed (11%)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("progress 1 doesn't have %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Week 2") {
+		t.Errorf("progress 1 shows week 2:\n%s", out.String())
+	}
+
+	tests := []struct {
+		args   []string
+		status int
+		want   string
+	}{
+		{[]string{"done", "week1/credit"}, 0, "week1/credit: completed"},
+		{[]string{"reset", "cash"}, 0, "week1/cash: not started"},
+		{[]string{"start", "dna"}, 0, "week6/dna: started"},
+		{[]string{"done", "nope"}, 1, `No problem "nope"`},
+		{[]string{"42"}, 1, "No week 42."},
+		{[]string{"week1"}, 1, PROGRESS_USAGE},
+		{nil, 0, "\n1 of "},
+	}
+	for _, tt := range tests {
+		out.Reset()
+		if status := progressCommand(tt.args, &out); status != tt.status || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("progress %q = %d\n%s\nwant %d with %q", tt.args, status, out.String(), tt.status, tt.want)
+		}
+	}
+}
+
+// The dashboard's model, driven by keys the way Bubble Tea would.
+func TestUI(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	credit, _ := exercises.Find("credit")
+	if err := recordCheck(credit, true, "14 of 14 passed"); err != nil {
+		t.Fatal(err)
+	}
+	m, err := newUIModel("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	press := func(keys ...string) {
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			switch key {
+			case "down", "right", "left", "enter", "backspace", "esc":
+				msg = tea.KeyMsg{Type: map[string]tea.KeyType{"down": tea.KeyDown, "right": tea.KeyRight, "left": tea.KeyLeft, "enter": tea.KeyEnter, "backspace": tea.KeyBackspace, "esc": tea.KeyEsc}[key]}
+			}
+			model, _ := m.Update(msg)
+			m = model.(uiModel)
+		}
+	}
+
+	press("down", "j", "j")
+	if got := m.selected().Name(); got != "credit" {
+		t.Fatalf("three down from hello is %s", got)
+	}
+	view := m.View()
+	for _, want := range []string{"Week 1", "Week 2", BADGES[CHECKED], "week1/credit", "checked since", "last checks 14 of 14 passed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view doesn't have %q:\n%s", want, view)
+		}
+	}
+
+	press("right")
+	if e := m.selected(); e.Week() != 2 || e.Name() != "bulbs" {
+		t.Errorf("right goes to %s, want week 2's first", exercises.ID(e))
+	}
+	press("left", "left")
+	if e := m.selected(); e.Name() != "hello" {
+		t.Errorf("left twice from bulbs goes to %s, want hello", exercises.ID(e))
+	}
+
+	press("a", "c", "a", "r", "d", ".", "r", "a", "w", "x", "backspace")
+	if m.args == nil || *m.args != "card.raw" || !strings.Contains(m.View(), "run hello with: card.raw") {
+		t.Errorf("typed arguments = %v", m.args)
+	}
+	press("esc")
+	if m.args != nil {
+		t.Error("esc doesn't cancel the arguments")
+	}
+
+	model, _ := m.Update(checkedMsg{"week1/hello", "Results for week1/hello\n2 of 2 passed.\n"})
+	m = model.(uiModel)
+	if m.checking || !strings.Contains(m.View(), "2 of 2 passed.") {
+		t.Errorf("a check's output isn't shown:\n%s", m.View())
+	}
+}
+
+// Every exercise template makes a starter that builds but fails its tests,
+// and a finished version that passes them.
+func TestNewExercise(t *testing.T) {
+	if _, err := newExercise("graph", "queue", 5, filepath.Join(t.TempDir(), "queue")); err == nil || !strings.Contains(err.Error(), `unknown template "graph"`) {
+		t.Errorf("newExercise with an unknown template: %v", err)
+	}
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command")
+	}
+	for _, template := range TEMPLATES {
+		dir := filepath.Join(t.TempDir(), "priority-queue")
+		files, err := newExercise(template, "priority-queue", 5, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"priority-queue.go", "priority-queue-finished.go", "priority-queue_test.go", "problem.md"} {
+			if !slices.Contains(files, want) {
+				t.Errorf("%s: no %s in %q", template, want, files)
+			}
+		}
+		if spec, _ := os.ReadFile(filepath.Join(dir, "problem.md")); !strings.Contains(string(spec), "Week 5") || !strings.Contains(string(spec), "PriorityQueue") {
+			t.Errorf("%s: problem.md =\n%s", template, spec)
+		}
+		for _, step := range []struct {
+			args []string
+			pass bool
+		}{
+			{[]string{"vet", "."}, true},
+			{[]string{"vet", "-tags", "finished", "."}, true},
+			{[]string{"test", "-tags", "finished", "."}, true},
+			{[]string{"test", "."}, false}, // the TODOs aren't done
+		} {
+			cmd := exec.Command(goCmd, step.args...)
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); (err == nil) != step.pass {
+				t.Errorf("%s: go %s: %v, want it to pass: %v\n%s", template, strings.Join(step.args, " "), err, step.pass, out)
+			}
+		}
+	}
+}
+
+func TestWeekDir(t *testing.T) {
+	root := t.TempDir()
+	os.Mkdir(filepath.Join(root, "week5-Data-Strucutes"), 0755)
+	os.WriteFile(filepath.Join(root, "week6-notes.md"), nil, 0644)
+	for week, want := range map[int]string{5: "week5-Data-Strucutes", 6: "week6", 10: "week10"} {
+		if got := weekDir(root, week); got != filepath.Join(root, want) {
+			t.Errorf("weekDir(%d) = %s, want %s", week, got, want)
+		}
+	}
+}
+
+func TestTimer(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	credit, _ := exercises.Find("credit")
+	if err := recordCheck(credit, true, "14 of 14 passed"); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args   []string
+		status int
+		want   string
+	}{
+		{[]string{"start", "credit"}, 0, "Timing week1/credit."},
+		{[]string{"start", "cash"}, 0, "Stopped week1/credit after 0m.\nTiming week1/cash."},
+		{[]string{"stop"}, 0, "Stopped week1/cash after 0m."},
+		{[]string{"stop"}, 1, "Nothing is being timed."},
+		{[]string{"start", "nope"}, 1, `No problem "nope"`},
+		{[]string{"start"}, 1, TIMER_USAGE},
+		{[]string{"report", "-days", "0"}, 1, TIMER_USAGE},
+		{[]string{"report"}, 0, "  week1/cash                     0m  1 session\n"},
+	}
+	var out bytes.Buffer
+	for _, tt := range tests {
+		out.Reset()
+		if status := run(tt.args, &out); status != tt.status || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("journal %q = %d\n%s\nwant %d with %q", tt.args, status, out.String(), tt.status, tt.want)
+		}
+	}
+	p, err := loadProgress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Timing a problem starts it, but doesn't undo its checks.
+	if p["week1/credit"].Status != CHECKED || p["week1/cash"].Status != STARTED {
+		t.Errorf("credit %s, cash %s, want %s and %s", p["week1/credit"].Status, p["week1/cash"].Status, CHECKED, STARTED)
+	}
+
+	// A session over midnight counts on both days, and the running one up
+	// to now.
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 10, day, hour, minute, 0, 0, time.Local) }
+	p = progress{
+		"week1/cash":   {Sessions: []session{{at(12, 23, 30), at(13, 0, 30)}}},
+		"week1/credit": {Sessions: []session{{at(13, 8, 0), at(13, 9, 30)}}},
+		"week6/dna":    {Sessions: []session{{at(14, 9, 0), time.Time{}}}},
+	}
+	out.Reset()
+	report(&out, p, at(14, 10, 0), 3)
+	for _, want := range []string{
+		"By day\n  Mon 2026-10-12  30m\n  Tue 2026-10-13  2h00m\n  Wed 2026-10-14  1h00m\n",
+		"  from Mon 2026-10-12  3h30m\n",
+		"By problem\n  week1/credit                1h30m  1 session\n  week1/cash                  1h00m  1 session\n  week6/dna                   1h00m  1 session\n",
+		"By topic\n  Week 1                      2h30m  2 sessions\n  Week 6                      1h00m  1 session\n",
+		"Timing week6/dna, for 1h00m so far.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report doesn't have %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestScanCards(t *testing.T) {
+	notes := `# Memory
+
+<!-- ;;Q What does malloc return when it runs out of memory? -->
+<!-- ;;A NULL, so check before using it. -->
+
+// ;;Q Which two lines
+// ;;Q make a question?
+// ;;A Both of them,
+// ;;A and two of an answer.
+;;Q A question with no answer is left out.
+
+;;A Nor is an answer without a question.
+;;Q Straight after the last answer
+;;A is a new card.
+`
+	cards, err := scanCards(strings.NewReader(notes), "notes.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []card{
+		{Question: "What does malloc return when it runs out of memory?", Answer: "NULL, so check before using it.", Source: "notes.md:3"},
+		{Question: "Which two lines\nmake a question?", Answer: "Both of them,\nand two of an answer.", Source: "notes.md:6"},
+		{Question: "Straight after the last answer", Answer: "is a new card.", Source: "notes.md:13"},
+	}
+	if !slices.Equal(cards, want) {
+		t.Errorf("scanCards =\n%+v\nwant\n%+v", cards, want)
+	}
+}
+
+// The intervals and eases from the SM-2 paper.
+func TestGrade(t *testing.T) {
+	now := time.Date(2026, 10, 16, 20, 0, 0, 0, time.Local)
+	c := card{Ease: EASE}
+	steps := []struct {
+		quality  int
+		interval int
+		ease     float64
+	}{
+		{5, 1, 2.6},
+		{4, 6, 2.6},
+		{3, 16, 2.46},
+		{0, 1, 1.66},
+		{1, 1, 1.3}, // no easier than MIN_EASE
+		{4, 1, 1.3},
+		{4, 6, 1.3},
+		{5, 8, 1.4},
+	}
+	for i, step := range steps {
+		c.grade(step.quality, now)
+		if c.Interval != step.interval || math.Abs(c.Ease-step.ease) > 1e-9 {
+			t.Fatalf("step %d: grade(%d) = interval %d, ease %v, want %d, %v", i, step.quality, c.Interval, c.Ease, step.interval, step.ease)
+		}
+		if want := time.Date(2026, 10, 16+step.interval, 0, 0, 0, 0, time.Local); !c.Due.Equal(want) {
+			t.Fatalf("step %d: due %s, want %s", i, c.Due, want)
+		}
+	}
+}
+
+func TestCards(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	notes := filepath.Join(t.TempDir(), "notes")
+	os.Mkdir(notes, 0755)
+	os.WriteFile(filepath.Join(notes, "week4.md"), []byte(";;Q What's *p?\n;;A What p points to.\n"), 0644)
+	os.WriteFile(filepath.Join(notes, "card.raw"), []byte(";;Q Not a note\n;;A so not a card\n"), 0644)
+
+	tests := []struct {
+		args   []string
+		status int
+		want   string
+	}{
+		{[]string{"cards"}, 0, "No cards yet"},
+		{[]string{"cards", "add", "What's 0x10?", "16"}, 0, "Added 1 cards, updated 0, 0 unchanged."},
+		{[]string{"cards", "add", "-from", notes}, 0, "Added 1 cards, updated 0, 0 unchanged."},
+		{[]string{"cards", "add", "What's 0x10?", "16, in decimal"}, 0, "Added 0 cards, updated 1, 0 unchanged."},
+		{[]string{"cards", "add", "-from", notes}, 0, "Added 0 cards, updated 0, 1 unchanged."},
+		{[]string{"cards", "add", "only a question"}, 1, CARDS_USAGE},
+		{[]string{"cards", "add", "-from", filepath.Join(notes, "nope")}, 2, "no such file"},
+		{[]string{"cards", "review", "-n", "0"}, 1, CARDS_USAGE},
+		{[]string{"cards", "shuffle"}, 1, CARDS_USAGE},
+		{[]string{"cards"}, 0, "2 cards, 2 due today."},
+	}
+	var out bytes.Buffer
+	for _, tt := range tests {
+		out.Reset()
+		if status := run(tt.args, &out); status != tt.status || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("journal %q = %d\n%s\nwant %d with %q", tt.args, status, out.String(), tt.status, tt.want)
+		}
+	}
+
+	// Forgetting the first card brings it back at the end, without grading
+	// it again; the second is remembered.
+	d, err := loadCards()
+	if err != nil || len(d) != 2 {
+		t.Fatalf("loadCards = %d cards, %v", len(d), err)
+	}
+	answers := []string{"", "1", "", "4", "", "7", "5"}
+	ask := func(prompt string) string {
+		fmt.Fprintln(&out, prompt)
+		if len(answers) == 0 {
+			t.Fatalf("asked %q after the last answer", prompt)
+		}
+		answer := answers[0]
+		answers = answers[1:]
+		return answer
+	}
+	out.Reset()
+	now := time.Now()
+	if err := review(&out, d, now, 10, ask); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Please enter a number from 0 to 5.") ||
+		!strings.Contains(out.String(), "Reviewed 2 cards, remembered 1. 0 still due today.") {
+		t.Errorf("review:\n%s", out.String())
+	}
+	d, _ = loadCards()
+	if d[0].Interval != 1 || d[0].Reps != 0 || d[1].Interval != 1 || d[1].Reps != 1 {
+		t.Errorf("after review: %+v, %+v", *d[0], *d[1])
+	}
+	out.Reset()
+	printCards(&out, d, now)
+	if want := "2 cards, 0 due today.\nThe next is due on " + now.AddDate(0, 0, 1).Format("Mon 2 Jan"); !strings.Contains(out.String(), want) {
+		t.Errorf("cards = %q, want %q", out.String(), want)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	link := func(href string) string { return strings.ReplaceAll(href, ".md", ".html") }
+	tests := []struct {
+		markdown, want string
+	}{
+		{"# credit\n\nLuhn's *checksum*,\nin **Go**.", "<h1 id=\"credit\">credit</h1>\n<p>Luhn&#39;s <em>checksum</em>,\nin <strong>Go</strong>.</p>\n"},
+		{"## Run `go test`", "<h2 id=\"run-go-test\">Run <code>go test</code></h2>\n"},
+		{"See [the notes](notes.md#malloc) and <https://cs50.harvard.edu>.", `<p>See <a href="notes.html#malloc">the notes</a> and <a href="https://cs50.harvard.edu">https://cs50.harvard.edu</a>.</p>` + "\n"},
+		{"![flowchart](chart.svg)", `<p><img src="chart.svg" alt="flowchart"></p>` + "\n"},
+		{"<b>raw</b> & <!-- ;;Q hidden -->", "<p>&lt;b&gt;raw&lt;/b&gt; &amp; &lt;!-- ;;Q hidden --&gt;</p>\n"},
+		{"<!-- ;;Q hidden\n;;A too -->\nshown", "<p>shown</p>\n"},
+		{"```go\nx := 1 // one\n```", "<pre><code>x := <span class=\"n\">1</span> <span class=\"c\">// one</span>\n</code></pre>\n"},
+		{"- one\n- two\n  continued\n  - nested\n\n- three\n\n1. first", "<ul>\n<li>one</li>\n<li>two\ncontinued<ul>\n<li>nested</li>\n</ul>\n</li>\n<li>three</li>\n</ul>\n<ol>\n<li>first</li>\n</ol>\n"},
+		{"> quoted\n> *twice*", "<blockquote>\n<p>quoted\n<em>twice</em></p>\n</blockquote>\n"},
+		{"| a | `b|c` |\n| - | :-: |\n| 1 | 2 |", "<table>\n<thead><tr><th>a</th><th><code>b|c</code></th></tr></thead>\n<tbody>\n<tr><td>1</td><td>2</td></tr>\n</tbody>\n</table>\n"},
+		{"above\n\n---\n\nsnake_case_name", "<p>above</p>\n<hr>\n<p>snake_case_name</p>\n"},
+	}
+	for _, tt := range tests {
+		if got := renderMarkdown(tt.markdown, link); got != tt.want {
+			t.Errorf("renderMarkdown(%q) =\n%s\nwant\n%s", tt.markdown, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		code, lang, want string
+	}{
+		{"if n < 0 {\n\treturn \"no\"\n}", "go", `<span class="k">if</span> n &lt; <span class="n">0</span> {` + "\n\t" + `<span class="k">return</span> <span class="s">&#34;no&#34;</span>` + "\n}"},
+		{"s := `raw\n// not a comment`", "Go", "s := <span class=\"s\">`raw\n// not a comment`</span>"},
+		{"var err error = nil", "go", `<span class="k">var</span> err <span class="b">error</span> = <span class="b">nil</span>`},
+		{"int x = 5; /* five */ printf(\"%i\\n\", x);", "c", `<span class="k">int</span> x = <span class="n">5</span>; <span class="c">/* five */</span> printf(<span class="s">&#34;%i\n&#34;</span>, x);`},
+		{"SELECT name FROM people; -- all", "sql", `<span class="k">SELECT</span> name <span class="k">FROM</span> people; <span class="c">-- all</span>`},
+		{"def f(): # why", "py", `<span class="k">def</span> f(): <span class="c"># why</span>`},
+		{"<p>hi</p>", "html", "&lt;p&gt;hi&lt;/p&gt;"},
+	}
+	for _, tt := range tests {
+		if got := highlight(tt.code, tt.lang); got != tt.want {
+			t.Errorf("highlight(%q, %s) =\n%s\nwant\n%s", tt.code, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		"README.md":                     "# Top\n\nStart with [week 1](week1-C) or [mario](week1-C/mario/mario.go).",
+		"cs50/cs50.go":                  "package cs50",
+		"week1-C/README.md":             "# Week 1\n\n- [credit's notes](credit/notes.md#luhn)\n- [missing](nope.md)",
+		"week1-C/credit/credit.go":      "package main\n\nfunc main() {}\n",
+		"week1-C/credit/notes.md":       "# Luhn\n\n![chart](chart.svg)",
+		"week1-C/credit/chart.svg":      "<svg/>",
+		"week1-C/credit/credit":         "\x7fELF binary",
+		"week1-C/credit/testdata/x.txt": "skipped",
+		"week1-C/mario/mario.go":        "package main\n",
+		"week10-Final/app.py":           "print('hi')\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	out := filepath.Join(root, "week1-C", "site") // inside, and left out
+	s, err := newSite(root, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, err := s.export(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The top, week1-C, credit, mario and week10-Final, 4 files and search.
+	if pages != 10 {
+		t.Errorf("exported %d pages, want 10", pages)
+	}
+	read := func(file string) string {
+		data, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(file)))
+		if err != nil {
+			t.Error(err)
+		}
+		return string(data)
+	}
+	for file, wants := range map[string][]string{
+		"index.html": {
+			`<a href="week1-C/index.html">week 1</a>`, `<a href="week1-C/mario/mario.go.html">mario</a>`,
+			"<li><a href=\"week1-C/index.html\">week1-C</a></li>\n<li><a href=\"week10-Final/index.html\">week10-Final</a></li>",
+		},
+		"week1-C/index.html": {
+			"<title>Week 1 · CS50 with Go</title>", `href="../style.css"`,
+			`<a href="credit/notes.html#luhn">credit&#39;s notes</a>`, `<a href="nope.md">missing</a>`,
+			`<li><a href="credit/index.html">credit/</a></li>`,
+		},
+		"week1-C/credit/index.html":     {`<a href="../../index.html">CS50 with Go</a> / <a href="../../week1-C/index.html">week1-C</a> / credit`, `<a href="notes.html">notes.md</a>`},
+		"week1-C/credit/notes.html":     {`<img src="chart.svg" alt="chart">`},
+		"week1-C/credit/credit.go.html": {`<span class="k">func</span> main`},
+		"week1-C/credit/chart.svg":      {"<svg/>"},
+		"search.json":                   {`"title":"Luhn","url":"week1-C/credit/notes.html","text":"# Luhn`},
+		".nojekyll":                     {""},
+	} {
+		for _, want := range wants {
+			if got := read(file); !strings.Contains(got, want) {
+				t.Errorf("%s doesn't have %q:\n%s", file, want, got)
+			}
+		}
+	}
+	for _, file := range []string{"cs50/cs50.go.html", "week1-C/credit/credit.html", "week1-C/credit/testdata/x.txt.html"} {
+		if _, err := os.Stat(filepath.Join(out, file)); err == nil {
+			t.Errorf("exported %s", file)
+		}
+	}
+
+	// Exporting again replaces it, but not a folder that isn't an export.
+	if _, err := s.export(out); err != nil {
+		t.Errorf("exporting again: %v", err)
+	}
+	if _, err := s.export(filepath.Join(root, "week1-C")); err == nil {
+		t.Error("exported over week1-C")
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := map[string][]string{
+		"A linked list, of nodes.":  {"linked", "list", "of", "nodes"},
+		"InsertHead(list *List)":    {"insert", "head", "inserthead", "list", "list"},
+		"parseHTTPRequest x":        {"parse", "http", "request", "parsehttprequest"},
+		"linked_list node_count2":   {"linked", "list", "linkedlist", "node", "count2", "nodecount2"},
+		"ภาษาไทย and Go's malloc()": {"ภาษาไทย", "and", "go", "malloc"},
+	}
+	for text, want := range tests {
+		if got := tokenize(text); !slices.Equal(got, want) {
+			t.Errorf("tokenize(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestSearchIndex(t *testing.T) {
+	root := t.TempDir()
+	write := func(file, content string) {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("week5/list/README.md", "# list\n\nA singly linked list.\n\nEach node points to the next in the list.\n")
+	write("week5/list/list.go", "package list\n\n// Node is one node of a LinkedList.\ntype Node struct{}\n")
+	write("week5/queue/queue.go", "package queue\n\n// A queue, linked\n// like a list.\n")
+	write("week5/list/testdata/list.txt", "linked list linked list")
+	write("week5/list/list.exe", "linked list")
+
+	index := &searchIndex{}
+	if changed, err := index.update(root); err != nil || changed != 3 {
+		t.Fatalf("update = %d, %v, want 3 files", changed, err)
+	}
+	results := index.search(tokenize("linked list"))
+	var got []string
+	for _, r := range results {
+		got = append(got, fmt.Sprintf("%s %v", r.File, r.Lines))
+	}
+	// Together on one line beats apart, and only the lines with both are
+	// shown when there are any.
+	want := []string{"week5/list/README.md [3]", "week5/list/list.go [3]", "week5/queue/queue.go [3 4]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("search = %q, want %q", got, want)
+	}
+	if results := index.search(tokenize("queue node")); len(results) != 0 {
+		t.Errorf("search for words in different files = %+v", results)
+	}
+
+	// Only what changed is read again, and deleted files go.
+	if changed, _ := index.update(root); changed != 0 {
+		t.Errorf("update with nothing changed = %d", changed)
+	}
+	write("week5/queue/queue.go", "package queue\n")
+	os.Remove(filepath.Join(root, "week5", "list", "list.go"))
+	if changed, _ := index.update(root); changed != 2 {
+		t.Errorf("update = %d, want 2", changed)
+	}
+	if results := index.search([]string{"linked"}); len(results) != 1 || index.Terms["node"] == nil || index.Terms["linkedlist"] != nil {
+		t.Errorf("after the changes: %+v, terms %v", results, slices.Sorted(maps.Keys(index.Terms)))
+	}
+
+	var out bytes.Buffer
+	if status := searchCommand([]string{"-n", "3", "?!"}, &out); status != 1 || !strings.Contains(out.String(), SEARCH_USAGE) {
+		t.Errorf("search for no words = %d\n%s", status, out.String())
+	}
+}
+
+// Every template's finished version writes its starter exactly, so the
+// two can't drift apart.
+func TestSyncTemplates(t *testing.T) {
+	for _, template := range TEMPLATES {
+		dir := filepath.Join(t.TempDir(), "queue")
+		if _, err := newExercise(template, "queue", 5, dir); err != nil {
+			t.Fatal(err)
+		}
+		finished, _ := os.ReadFile(filepath.Join(dir, "queue-finished.go"))
+		starter, _ := os.ReadFile(filepath.Join(dir, "queue.go"))
+		got, todos, err := starterFrom(string(finished), string(starter))
+		if err != nil || string(got) != string(starter) {
+			t.Errorf("%s: starterFrom = %v\n%s", template, err, unified("queue.go", diffLines(splitLines(string(starter)), splitLines(string(got)))))
+		}
+		if want := strings.Count(string(starter), "// TODO:"); todos != want {
+			t.Errorf("%s: %d TODOs, want %d", template, todos, want)
+		}
+		// Without a starter, the header is the finished version's.
+		if got, _, _ := starterFrom(string(finished), ""); !strings.HasPrefix(string(got), "//go:build !finished\n") {
+			t.Errorf("%s: a new starter starts\n%s", template, got[:100])
+		}
+	}
+}
+
+func TestStarterFrom(t *testing.T) {
+	tests := []struct {
+		finished, want, err string
+	}{
+		{"package p\n\nfunc f() int {\n\t//todo: Count them,\n\t//todo: all of them.\n\t//hint: len()\n\t//starter: return 0\n\treturn 1\n\t//end\n}\n",
+			"package p\n\nfunc f() int {\n\t// TODO: Count them,\n\t// all of them.\n\t// HINT: len()\n\treturn 0\n}\n", ""},
+		{"package p\n\nfunc f() {\n\t//todo: a\n}\n", "", "line 4: the block has no //end"},
+		{"package p\n\n//end\n", "", "line 3: //end without a block to end"},
+		{"package p\n\nfunc f() {}\n", "", "no //todo: annotations"},
+		{"package p\n\nfunc f() {\n\t//todo: a\n\t//starter: }}\n\t//end\n}\n", "", "the starter doesn't parse"},
+	}
+	for _, tt := range tests {
+		got, _, err := starterFrom(tt.finished, "")
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("starterFrom(%q) = %v, want %q", tt.finished, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || string(got) != tt.want {
+			t.Errorf("starterFrom(%q) =\n%s%v\nwant\n%s", tt.finished, got, err, tt.want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	starter := "package main\n\n// Count counts.\nfunc Count(s []int) int {\n\t// TODO: Count them.\n\treturn 0\n}\n\n// Same stays.\nfunc Same() {}\n\n// Noted.\nfunc Noted() {}\n\nfunc gone() {}\n"
+	finished := "package main\n\n// Count counts.\nfunc Count(s []int) int {\n\treturn len(s)\n}\n\n// Same stays.\nfunc Same() {}\n\n// Noted, differently.\nfunc Noted() {}\n\nfunc (q *Queue) New() {}\n"
+	var out bytes.Buffer
+	compareDecls(&out, starter, finished, false)
+	for _, want := range []string{
+		"  ~ Count                        1 TODO  +1 -2\n",
+		"  = Same                         \n",
+		"  # Noted                        comments only\n",
+		"  - gone                         only in the starter\n",
+		"  + (*Queue).New                 only in the finished version\n",
+		"1 of the starter's 4 declarations differ in code, with 1 TODO.\n",
+		"    --- starter Count\n    +++ finished Count\n    @@ -3,5 +3,4 @@\n",
+		"    -\t// TODO: Count them.\n    -\treturn 0\n    +\treturn len(s)\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("compareDecls doesn't have %q:\n%s", want, out.String())
+		}
+	}
+
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		"week5-Data/queue/queue.go":              "package main\n",
+		"week5-Data/queue/queue-finished.go":     "package main\n",
+		"week5-Data/lru/lru.go":                  "package lru\n",
+		"week5-Data/lru/starter/lru.go":          "package lru\n",
+		"week5-Data/recipe.go":                   "package main\n",
+		"week5-Data/finished/recipe-finished.go": "package main\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	week := filepath.Join(root, "week5-Data")
+	for what, want := range map[string]starterPair{
+		"week5/queue":    {filepath.Join(week, "queue", "queue.go"), filepath.Join(week, "queue", "queue-finished.go")},
+		"week5-Data/lru": {filepath.Join(week, "lru", "starter", "lru.go"), filepath.Join(week, "lru", "lru.go")},
+		"week5/recipe":   {filepath.Join(week, "recipe.go"), filepath.Join(week, "finished", "recipe-finished.go")},
+	} {
+		if pairs, err := findPairs(root, what); err != nil || len(pairs) != 1 || pairs[0] != want {
+			t.Errorf("findPairs(%s) = %v, %v, want %v", what, pairs, err, want)
+		}
+	}
+	if _, err := findPairs(root, "week5/nope"); err == nil {
+		t.Error("findPairs found a pair for week5/nope")
+	}
+}
+
+func TestCompareOutput(t *testing.T) {
+	tests := []struct {
+		want, got string
+		options   gradeOptions
+		problem   string
+	}{
+		{"a\nb\n", "a\nb\n", gradeOptions{}, ""},
+		{"a\nb\n", "a\r\nb\r\n", gradeOptions{}, ""},
+		{"a\nb\n", "a \nb\n", gradeOptions{}, `line 1: expected "a", not "a "`},
+		{"a\nb\n", "a \nb\n\n\n", gradeOptions{Trim: true}, ""},
+		{"a\nb\n", "a\n", gradeOptions{}, `line 2: expected "b", not the end`},
+		{"a\n", "a\nb\n", gradeOptions{}, `line 2: expected the end, not "b"`},
+		{"TIME: 0.00\n", "TIME: 0.03\n", gradeOptions{}, `line 1: expected "TIME: 0.00", not "TIME: 0.03"`},
+		{"TIME: 0.00\n", "TIME: 0.03\n", gradeOptions{Tolerance: 0.05}, ""},
+		{"TIME: 0.00\n", "TIME: 0.3\n", gradeOptions{Tolerance: 0.05}, "line 1"},
+		{"x = 1e3, y = -2\n", "x = 1000.0001, y = -2.0\n", gradeOptions{Tolerance: 0.001}, ""},
+		{"TIME: 0.00\n", "TOTAL: 0.00\n", gradeOptions{Tolerance: 1}, "line 1"},
+		{"1 2\n", "1\n", gradeOptions{Tolerance: 1}, "line 1"},
+		{"BM\x00\x01", "BM\x00\x01", gradeOptions{Trim: true}, ""},
+		{"BM\x00\x01", "BM\x00\x02", gradeOptions{Tolerance: 1}, "expected the same 4 bytes"},
+	}
+	for _, tt := range tests {
+		if got := compareOutput(tt.want, tt.got, tt.options); !strings.Contains(got, tt.problem) || (tt.problem == "") != (got == "") {
+			t.Errorf("compareOutput(%q, %q, %+v) = %q, want %q", tt.want, tt.got, tt.options, got, tt.problem)
+		}
+	}
+}
+
+// grade is tried on sh, as runCase is, with fixtures in a temp dir.
+func TestGradeFixtures(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		"upper.in": "hello\n", "upper.expected": "HELLO\n",
+		"lower.in": "BYE\n", "lower.expected": "BYE\n",
+		"wrong.in": "hi\n", "wrong.expected": "hi!\n",
+	} {
+		os.WriteFile(filepath.Join(dir, file), []byte(content), 0644)
+	}
+	fixtures, err := findFixtures(dir)
+	if err != nil || len(fixtures) != 3 || fixtures[0].Name != "lower" {
+		t.Fatalf("findFixtures = %v, %v", fixtures, err)
+	}
+	var out bytes.Buffer
+	status := grade(&out, sh, dir, fixtures, gradeOptions{Args: []string{"-c", "tr a-z A-Z"}}, true)
+	want := ":) lower\n:) upper\n:( wrong\n    line 1: expected \"hi!\", not \"HI\"\n"
+	if status != 1 || !strings.HasPrefix(out.String(), want) || !strings.HasSuffix(out.String(), "Score: 2 of 3 (66%).\n") {
+		t.Errorf("grade = %d\n%s\nwant\n%s", status, out.String(), want)
+	}
+
+	// With {in} in the arguments, the fixture is a file, not standard input;
+	// output is a file the program writes.
+	options := gradeOptions{Args: []string{"-c", `tr a-z A-Z < "$0" > "$1"`, "{in}", "{tmp}/out"}, Output: "{tmp}/out"}
+	out.Reset()
+	if status := grade(&out, sh, dir, fixtures[1:2], options, false); status != 0 {
+		t.Errorf("grade with {in} and an output file = %d\n%s", status, out.String())
+	}
+
+	os.WriteFile(filepath.Join(dir, "alone.in"), nil, 0644)
+	if _, err := findFixtures(dir); err == nil || err.Error() != "alone.in has no alone.expected" {
+		t.Errorf("findFixtures with an .in alone = %v", err)
+	}
+}
+
+// Every grade file in the repo parses and has fixtures to grade.
+func TestGradeFiles(t *testing.T) {
+	root, err := exercises.Root()
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, e := range exercises.PROGRAMS {
+		testdata := filepath.Join(e.Path(root), "testdata")
+		if _, err := os.Stat(filepath.Join(testdata, e.Name()+".grade.json")); err != nil {
+			continue
+		}
+		if _, err := loadGradeOptions(filepath.Join(testdata, e.Name()+".grade.json")); err != nil {
+			t.Error(err)
+		}
+		if fixtures, err := findFixtures(testdata); err != nil || len(fixtures) == 0 {
+			t.Errorf("%s: fixtures %v, %v", e.ID(), fixtures, err)
+		}
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	for number, want := range map[int64]string{
+		378282246310005:  "AMEX",
+		371449635398431:  "AMEX",
+		5555555555554444: "MASTERCARD",
+		5105105105105100: "MASTERCARD",
+		4111111111111111: "VISA",
+		4222222222222:    "VISA",
+		1234567890:       "INVALID",
+		4111111111111113: "INVALID",
+		6176292929:       "INVALID",
+		0:                "INVALID",
+		-378282246310005: "INVALID",
+	} {
+		if got := cardBrand(number); got != want {
+			t.Errorf("cardBrand(%d) = %s, want %s", number, got, want)
+		}
+	}
+}
+
+// The fuzzers' cases hold together: the same seed makes the same ones, and
+// every case has an answer to check.
+func TestFuzzTargets(t *testing.T) {
+	for name, generate := range FUZZ_TARGETS {
+		if _, ok := exercises.Find(name); !ok {
+			t.Errorf("a fuzzer for %s, which isn't an exercise", name)
+		}
+		a, b := rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1))
+		for range 200 {
+			c := generate(a)
+			if again := generate(b); !slices.Equal(c.Input, again.Input) || c.Want != again.Want {
+				t.Fatalf("%s: %v and then %v from the same seed", name, c, again)
+			}
+			if c.Want == "" && c.Exit == 0 {
+				t.Errorf("%s: %v checks nothing", name, c)
+			}
+		}
+	}
+}
+
+// fuzz is tried on sh, with cases that pass, crash, hang and go wrong.
+func TestFuzz(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	cases := []struct {
+		c       fuzzCase
+		problem string
+	}{
+		{fuzzCase{Args: []string{"-c", "read a; read b; echo $b"}, Input: []string{"x", "y"}, Want: "y\n"}, ""},
+		{fuzzCase{Args: []string{"-c", "exit 1"}, Exit: 1}, ""},
+		{fuzzCase{Args: []string{"-c", "echo 'panic: runtime error: index out of range'; exit 2"}}, "crashed: panic: runtime error: index out of range"},
+		{fuzzCase{Args: []string{"-c", "read a; read b"}, Input: []string{"x"}}, "hung: still running after 100ms"},
+		{fuzzCase{Args: []string{"-c", "head -c 2000000 /dev/zero | tr '\\0' x"}}, "hung: printed over 1024 KB"},
+		{fuzzCase{Args: []string{"-c", "echo 3"}, Want: "4\n"}, `wrong: expected it to end "4\n", not "3\n"`},
+		{fuzzCase{Args: []string{"-c", "true"}, Exit: 1}, "exit: expected exit code 1, not 0"},
+	}
+	for _, tt := range cases {
+		if got := runFuzzCase(context.Background(), sh, t.TempDir(), tt.c, 100*time.Millisecond); !strings.HasPrefix(got, tt.problem) || (tt.problem == "") != (got == "") {
+			t.Errorf("runFuzzCase(%q) = %q, want %q", tt.c.Args, got, tt.problem)
+		}
+	}
+
+	var out bytes.Buffer
+	wrong := func(r *rand.Rand) fuzzCase {
+		return fuzzCase{Args: []string{"-c", "echo $0", strconv.Itoa(r.Intn(3))}, Want: "1\n"}
+	}
+	if status := fuzz(context.Background(), &out, sh, t.TempDir(), wrong, 100, 7, time.Second); status != 1 ||
+		!strings.Contains(out.String(), "Stopped after 10 failures.\n10 of ") || !strings.Contains(out.String(), "runs failed: 10 wrong. -seed 7 runs them again.\n") {
+		t.Errorf("fuzz = %d\n%s", status, out.String())
+	}
+
+	// Interrupted, the run it was on doesn't count as a hang.
+	out.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := fuzz(ctx, &out, sh, t.TempDir(), wrong, 100, 7, time.Second); status != 0 || out.String() != "Seed 7\nInterrupted after 0 runs.\n0 runs, no crashes, hangs or wrong answers.\n" {
+		t.Errorf("fuzz, interrupted = %d\n%s", status, out.String())
+	}
+}
+
+func TestLoadBench(t *testing.T) {
+	dir := t.TempDir()
+	for content, want := range map[string]string{
+		`{"inputs": [{"name": "card.raw", "args": ["{dir}/card.raw"]}]}`: "",
+		`{"inputs": []}`:                         "no inputs",
+		`{"inputs": [{"args": ["x"]}]}`:          "input 1 has no name",
+		`{"inputs": [{"name": "x"}], "go": "x"}`: "unknown field",
+	} {
+		path := filepath.Join(dir, "x.bench.json")
+		os.WriteFile(path, []byte(content), 0644)
+		_, err := loadBench(path)
+		if (want == "") != (err == nil) || err != nil && !strings.Contains(err.Error(), want) {
+			t.Errorf("loadBench(%s) = %v, want %q", content, err, want)
+		}
+	}
+}
+
+// benchmark is tried on sh, which runs in a directory of its own.
+func TestBenchmark(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "in.txt"), []byte("hello\n"), 0644)
+	in := benchInput{Name: "in.txt", Args: []string{"-c", `read a && cat "$0" > out.txt && test "$a" = hi`, "{dir}/in.txt"}, Input: []string{"hi"}}
+	result, err := benchmark(sh, dir, in, 3)
+	if err != nil || result.Runs != 3 || result.MinSeconds <= 0 || result.MinSeconds > result.MeanSeconds {
+		t.Errorf("benchmark = %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "out.txt")); err == nil {
+		t.Error("a run wrote to the problem's directory")
+	}
+	if _, err := benchmark(sh, dir, benchInput{Name: "fails", Args: []string{"-c", "echo oops; exit 3"}}, 1); err == nil || err.Error() != `exit status 3: "oops\n"` {
+		t.Errorf("benchmark of a failing run = %v", err)
+	}
+}
+
+func TestPrintBench(t *testing.T) {
+	report := benchReport{Problem: "week4/recover", C: "recover.c", Results: []benchResult{
+		{"card.raw", "C", 5, 0.004, 0.0031234, 2 << 20},
+		{"card.raw", "Go", 5, 0.006, 0.005, 5 << 20},
+		{"empty.raw", "C", 5, 0.0005, 0.0004, 0},
+		{"empty.raw", "Go", 5, 1.5, 1.25, 900 << 10},
+	}}
+	var out bytes.Buffer
+	printBench(&out, report, 5)
+	want := `week4/recover against recover.c: 5 runs of each on each input.
+
+input                       mean    fastest     memory
+card.raw         C           4ms     3.12ms     2.0 MB
+card.raw         Go          6ms        5ms     5.0 MB
+                      Go takes 1.50x C's time and 2.5x its memory
+empty.raw        C         500µs      400µs          ?
+empty.raw        Go         1.5s      1.25s     900 KB
+                      Go takes 3000.00x C's time
+
+A ? is less memory than journal's own, which it can't measure below.
+`
+	if out.String() != want {
+		t.Errorf("printBench =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		days             []string
+		current, longest int
+		from             string
+	}{
+		{nil, 0, 0, "0001-01-01"},
+		{[]string{"2026-03-10"}, 1, 1, "2026-03-10"},
+		{[]string{"2026-03-08", "2026-03-09"}, 2, 2, "2026-03-08"},
+		{[]string{"2026-03-07", "2026-03-08"}, 0, 2, "2026-03-07"},
+		{[]string{"2026-02-27", "2026-02-28", "2026-03-01", "2026-03-02", "2026-03-09", "2026-03-10"}, 2, 4, "2026-02-27"},
+	}
+	for _, tt := range tests {
+		days := map[string]bool{}
+		for _, day := range tt.days {
+			days[day] = true
+		}
+		current, longest, from := streaks(days, now)
+		if current != tt.current || longest != tt.longest || from.Format(time.DateOnly) != tt.from {
+			t.Errorf("streaks(%v) = %d, %d, %s, want %d, %d, %s", tt.days, current, longest, from.Format(time.DateOnly), tt.current, tt.longest, tt.from)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	root := t.TempDir()
+	for file, content := range map[string]string{
+		"week1-C/hello/hello.go":      "package main\n\nfunc main() {}\n",
+		"week1-C/hello/hello_test.go": "package main\n\nfunc TestHello(t *testing.T) {}\nfunc Testing() {}\nfunc BenchmarkHello(b *testing.B) {}\n",
+		"week1/notes.go":              "package notes\n",
+		"week1/testdata/big.go":       "package big\n\n\n\n",
+		"week2-Array/a.go":            "package a\n\n",
+		"README.md":                   "# CS50\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte(content), 0644)
+	}
+	code, err := countCode(root)
+	want := []weekCode{{Week: 1, Lines: 9, TestLines: 5, Tests: 2}, {Week: 2, Lines: 2}}
+	if err != nil || !slices.Equal(code, want) {
+		t.Errorf("countCode = %v, %v, want %v", code, err, want)
+	}
+
+	now := time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC) // a Wednesday
+	history := activity{
+		Added: map[string]int{"2026-03-09": 30, "2026-03-02": 10, "2026-03-08": 5},
+		Days:  map[string]bool{"2026-03-02": true, "2026-03-08": true, "2026-03-09": true},
+	}
+	p := progress{
+		"week1/hello": {Status: CHECKED, Checked: 3, Updated: time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC),
+			Sessions: []session{{Start: time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 10, 20, 30, 0, 0, time.UTC)}}},
+		"week2/bulbs": {Status: STARTED, Checked: 1, Updated: time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)},
+	}
+	var out bytes.Buffer
+	printStats(&out, history, code, p, now, 3, 30)
+	wantOut := fmt.Sprintf(`Go written, by week: lines added, from git
+Feb 23  0
+Mar 2  15 ██████████
+Mar 9  30 ████████████████████
+
+Go in the repo, by CS50 week: lines, and tests
+week 1 9 ███████████ 2 tests in 5 lines
+week 2 2 ██▍ 0 tests in 0 lines
+11 lines of Go, 2 tests.
+
+Checks run: 4, with 1 of %d problems passing or done.
+Time tracked: 1h30m in 1 session.
+Streak: 3 days, the longest yet.
+`, len(exercises.All()))
+	if out.String() != wantOut {
+		t.Errorf("printStats =\n%s\nwant\n%s", out.String(), wantOut)
+	}
+}
+
+// gitActivity is tried on a repo of its own, made with git.
+func TestGitActivity(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("no git")
+	}
+	dir := t.TempDir()
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git(nil, "init", "-q")
+	for i, day := range []string{"2026-03-01T10:00:00+00:00", "2026-03-03T10:00:00+00:00"} {
+		os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.go", i)), []byte(strings.Repeat("x\n", i+2)), 0644)
+		os.WriteFile(filepath.Join(dir, "notes.md"), []byte(strings.Repeat("y\n", 10*(i+1))), 0644)
+		git(nil, "add", ".")
+		git([]string{"GIT_AUTHOR_DATE=" + day, "GIT_COMMITTER_DATE=" + day, "GIT_AUTHOR_NAME=a", "GIT_AUTHOR_EMAIL=a@b",
+			"GIT_COMMITTER_NAME=a", "GIT_COMMITTER_EMAIL=a@b"}, "commit", "-q", "-m", "work")
+	}
+	history, err := gitActivity(dir)
+	if err != nil || !maps.Equal(history.Added, map[string]int{"2026-03-01": 2, "2026-03-03": 3}) || len(history.Days) != 2 {
+		t.Errorf("gitActivity = %v, %v", history, err)
+	}
+	if _, err := gitActivity(t.TempDir()); err == nil {
+		t.Error("gitActivity outside a repo worked")
+	}
+}
+
+func TestHTMLToMarkdown(t *testing.T) {
+	base, _ := url.Parse("https://cs50.harvard.edu/x/2026/psets/5/speller/")
+	for _, test := range []struct{ html, want string }{
+		{"<h1>Speller</h1><p>Spell-check   a\n <em>text</em>.</p>", "# Speller\n\nSpell-check a *text*.\n"},
+		{"<nav>Menu</nav><main><h2 id=x>Background</h2><p>In <code>dictionary.c</code>, <strong>hash </strong>words.</p></main><footer>c</footer>",
+			"## Background\n\nIn `dictionary.c`, **hash** words.\n"},
+		{"<p>See <a href='../../4/filter/'>filter</a>, <a href='#top'>up</a> and <img src='a.png' alt='A'>.</p>",
+			"See [filter](https://cs50.harvard.edu/x/2026/psets/4/filter/), up and ![A](https://cs50.harvard.edu/x/2026/psets/5/speller/a.png).\n"},
+		{"<ul><li>one</li><li>two<ol start=3><li>three</li></ol></li></ul>", "- one\n- two\n  3. three\n"},
+		{`<div class="language-c highlighter-rouge"><div class="highlight"><pre class="highlight"><code><span>int</span> main(void)
+{
+}
+</code></pre></div></div>`, "```c\nint main(void)\n{\n}\n```\n"},
+		{"<blockquote><p>a</p><p>b</p></blockquote>", "> a\n>\n> b\n"},
+		{"<table><thead><tr><th>a</th><th>b|c</th></tr></thead><tbody><tr><td>1</td></tr></tbody></table>",
+			"| a | b\\|c |\n| --- | --- |\n| 1 |  |\n"},
+		{"<details><summary>Hint</summary><p>Use a <kbd>hash</kbd> table.</p></details>", "**Hint**\n\nUse a `hash` table.\n"},
+		{"<p>a<br>b</p><hr><script>x()</script><iframe src='https://www.youtube.com/embed/x'></iframe>",
+			"a\\\nb\n\n---\n\n<https://www.youtube.com/embed/x>\n"},
+	} {
+		doc, err := html.Parse(strings.NewReader(test.html))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := htmlToMarkdown(doc, base); got != test.want {
+			t.Errorf("htmlToMarkdown(%q) =\n%s\nwant\n%s", test.html, got, test.want)
+		}
+	}
+}
+
+func TestUnzipInto(t *testing.T) {
+	zipOf := func(files ...string) string {
+		path := filepath.Join(t.TempDir(), "dist.zip")
+		f, _ := os.Create(path)
+		zw := zip.NewWriter(f)
+		for _, name := range files {
+			w, _ := zw.Create(name)
+			io.WriteString(w, "from "+name)
+		}
+		zw.Close()
+		f.Close()
+		return path
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "dictionary.c"), []byte("mine"), 0644)
+	written, kept, err := unzipInto(zipOf("speller/dictionary.c", "speller/speller.c", "speller/texts/cat.txt", "__MACOSX/speller/._speller.c"), dir)
+	if err != nil || !slices.Equal(written, []string{"speller.c", "texts/cat.txt"}) || !slices.Equal(kept, []string{"dictionary.c"}) {
+		t.Errorf("unzipInto = %v, %v, %v", written, kept, err)
+	}
+	for file, want := range map[string]string{"dictionary.c": "mine", "speller.c": "from speller/speller.c", "texts/cat.txt": "from speller/texts/cat.txt"} {
+		if got, _ := os.ReadFile(filepath.Join(dir, file)); string(got) != want {
+			t.Errorf("%s = %q, want %q", file, got, want)
+		}
+	}
+
+	// Without one folder around it all, nothing's taken off.
+	dir = t.TempDir()
+	if written, _, err := unzipInto(zipOf("a/x.c", "y.c"), dir); err != nil || !slices.Equal(written, []string{"a/x.c", "y.c"}) {
+		t.Errorf("unzipInto = %v, %v", written, err)
+	}
+	if _, _, err := unzipInto(zipOf("../evil.c"), t.TempDir()); err == nil {
+		t.Error("unzipInto wrote outside the folder")
+	}
+}
+
+// spec is tried on a server of its own, with the distribution code on it too.
+func TestSpec(t *testing.T) {
+	var server *httptest.Server
+	fetched := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		switch r.URL.Path {
+		case "/x/psets/9/newthing/":
+			fmt.Fprintf(w, "<html><body><main><h1>New Thing</h1><pre><code>wget %s/newthing.zip\n</code></pre></main></body></html>", server.URL)
+		case "/newthing.zip":
+			zw := zip.NewWriter(w)
+			f, _ := zw.Create("newthing/newthing.c")
+			io.WriteString(f, "int main(void) {}\n")
+			zw.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	root := t.TempDir()
+	t.Setenv(exercises.ROOT_ENV, root)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	os.Mkdir(filepath.Join(root, "week9-Flask"), 0755)
+
+	var out bytes.Buffer
+	if code := run([]string{"spec", "week9/newthing", "-url", server.URL + "/x/psets/9/newthing/"}, &out); code != 0 {
+		t.Fatalf("spec = %d\n%s", code, out.String())
+	}
+	dir := filepath.Join(root, "week9-Flask", "newthing")
+	spec, _ := os.ReadFile(filepath.Join(dir, "problem.md"))
+	code, _ := os.ReadFile(filepath.Join(dir, "newthing.c"))
+	if !strings.HasPrefix(string(spec), "# New Thing\n\n```\nwget ") || string(code) != "int main(void) {}\n" || fetched != 2 {
+		t.Errorf("spec wrote problem.md\n%s\nand newthing.c %q, in %d fetches:\n%s", spec, code, fetched, out.String())
+	}
+
+	// The second time, it's all from the cache, and what's there is kept.
+	out.Reset()
+	if code := run([]string{"spec", "week9/newthing", "-url", server.URL + "/x/psets/9/newthing/"}, &out); code != 0 || fetched != 2 ||
+		!strings.Contains(out.String(), "Kept your week9-Flask/newthing/problem.md") || !strings.Contains(out.String(), "kept your newthing.c") {
+		t.Errorf("spec again = %d, in %d fetches:\n%s", code, fetched, out.String())
+	}
+	out.Reset()
+	if code := run([]string{"spec", "week9/missing", "-url", server.URL + "/x/psets/9/missing/"}, &out); code != 2 || !strings.Contains(out.String(), "404") {
+		t.Errorf("spec of a missing page = %d:\n%s", code, out.String())
+	}
+	if got := specURL(2026, 1, "mario"); got != "https://cs50.harvard.edu/x/2026/psets/1/mario/less/" {
+		t.Errorf("specURL = %s", got)
+	}
+}
+
+func TestDrawChain(t *testing.T) {
+	var out bytes.Buffer
+	drawChain(&out, "head ", []string{"1", "22"})
+	drawChain(&out, "head ", nil)
+	want := `     ┌───┐   ┌────┐
+head │ 1 │──▶│ 22 │──▶ NULL
+     └───┘   └────┘
+head ──▶ NULL
+`
+	if out.String() != want {
+		t.Errorf("drawChain =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	for _, test := range []struct {
+		kind, script string
+		want         []string
+	}{
+		{"list", "insert 1 2\ndelete 7\nfind 1 3\n", []string{"head │ 2 │──▶│ 1 │──▶ NULL", "No 7 to delete.", "Found 1, node 2 of 2.", "No 3, after all 2 nodes."}},
+		// A lone child is drawn on its side, the missing one as a ·.
+		{"bst", "insert 5 3 8 4\nfind 4 9\n", []string{"5\n├── 3\n│   ├── ·\n│   └── 4\n└── 8\n4 keys, 3 high.", "Found 4: 5 → 3 → 4.", "No 9: 5 → 8 → NULL."}},
+		{"bst", "insert 5 3\ndelete 5\nundo\nundo\nundo\n", []string{"3\n1 key, 1 high.", "Undid delete 5.\n5\n├── 3\n└── ·\n", "Undid insert 5 3.\nroot ──▶ NULL", "Nothing to undo."}},
+		// Half a step is undone at once.
+		{"bst", "insert 1\ninsert 2 x\nshow\n", []string{`"x" isn't a number`, "1\n1 key, 1 high."}},
+		{"trie", "insert cat car\ndelete car\nfind ca cab dog\n", []string{"root\n└── c\n    └── a\n        ├── r ● car\n        └── t ● cat\n2 words, 5 nodes.",
+			"root\n└── c\n    └── a\n        └── t ● cat\n1 word, 4 nodes.", "No ca: ca is there, but isn't a word.", "No cab: c → a, then no b.", "No dog: nothing starts with d."}},
+		{"trie", "insert c4t\n", []string{`invalid character '4' in "c4t"`}},
+		{"hash", "insert Cat\nfind CAT\ndelete cat\nfind cat\n", []string{"│ cat │──▶ NULL", "1 word in 1 of 65536 buckets.", "Found CAT in bucket", "0 words in 0 of 65536 buckets.", "No cat: bucket"}},
+		{"hash", "frobnicate\nquit\ninsert x\n", []string{"No frobnicate: insert, delete, find"}},
+	} {
+		var out bytes.Buffer
+		play(strings.NewReader(test.script), &out, test.kind, "")
+		for _, want := range test.want {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("play %s on %q =\n%s\nwant it to have\n%s", test.kind, test.script, out.String(), want)
+			}
+		}
+		if strings.Contains(test.script, "quit") && strings.Contains(out.String(), "│ x │") {
+			t.Errorf("play %s went on after quit:\n%s", test.kind, out.String())
+		}
+	}
+}
+
+func TestLoadCast(t *testing.T) {
+	dir := t.TempDir()
+	for _, test := range []struct{ cast, err string }{
+		{`{"version": 2, "width": 80, "height": 24, "title": "week1/mario", "args": ["3"]}` + "\n" + `[0.5, "i", "3\n"]` + "\n" + `[0.5, "o", "3\r\n"]` + "\n", ""},
+		{"", "is empty"},
+		{`{"version": 1}`, "not asciicast 2"},
+		{`{"version": 2}` + "\n" + `[0.5, "o"]`, "an event is [time, kind, data]"},
+		{`{"version": 2}` + "\n" + `["soon", "o", "x"]`, "cannot unmarshal"},
+	} {
+		path := filepath.Join(dir, "demo.cast")
+		os.WriteFile(path, []byte(test.cast), 0644)
+		header, events, err := loadCast(path)
+		switch {
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("loadCast(%q) = %v, want %q", test.cast, err, test.err)
+		case test.err == "" && (err != nil || header.Title != "week1/mario" || !slices.Equal(header.Args, []string{"3"}) ||
+			!slices.Equal(events, []castEvent{{0.5, "i", "3\n"}, {0.5, "o", "3\r\n"}})):
+			t.Errorf("loadCast(%q) = %+v, %v, %v", test.cast, header, events, err)
+		}
+	}
+}
+
+// record and replay are tried on a shell script, asking a question.
+func TestRecordReplay(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	script := `printf 'Name: '; read name; echo "hello, $name"; echo oops >&2`
+	var cast, out bytes.Buffer
+	header := castHeader{Version: 2, Width: 80, Height: 24, Title: "week1/hello", Args: []string{"-c", script}}
+	rec, code, err := record(&cast, header, sh, t.TempDir(), strings.NewReader("David\n"), &out)
+	if err != nil || code != 0 || out.String() != "Name: hello, David\noops\n" {
+		t.Fatalf("record = %d, %v, showing %q", code, err, out.String())
+	}
+	path := filepath.Join(t.TempDir(), "demo.cast")
+	os.WriteFile(path, cast.Bytes(), 0644)
+	loaded, events, err := loadCast(path)
+	if err != nil || !slices.Equal(loaded.Args, header.Args) || len(events) != rec.events {
+		t.Fatalf("loadCast of what record wrote = %+v, %d events, %v\n%s", loaded, len(events), err, cast.String())
+	}
+	input, output := castSession(events)
+	if input != "David\n" || output != "Name: hello, David\noops\n" {
+		t.Errorf("castSession = %q, %q", input, output)
+	}
+
+	// Played back, it's what the terminal showed, echo and all, with the
+	// pauses capped.
+	var played bytes.Buffer
+	var pauses []time.Duration
+	replay(&played, []castEvent{{0, "o", "Name: "}, {5, "i", "David\n"}, {5, "o", "David\r\n"}, {5.5, "o", "hello\r\n"}}, 2, time.Second,
+		func(d time.Duration) { pauses = append(pauses, d) })
+	if played.String() != "Name: David\r\nhello\r\n" || !slices.Equal(pauses, []time.Duration{0, time.Second, 250 * time.Millisecond}) {
+		t.Errorf("replay = %q, pausing %v", played.String(), pauses)
+	}
+
+	// Checked, the same script passes and a changed one shows the diff.
+	var checked bytes.Buffer
+	if code := checkCast(&checked, loaded, events, sh, t.TempDir()); code != 0 || !strings.HasPrefix(checked.String(), ":)") {
+		t.Errorf("checkCast = %d:\n%s", code, checked.String())
+	}
+	checked.Reset()
+	loaded.Args = []string{"-c", strings.Replace(script, "hello", "goodbye", 1)}
+	if code := checkCast(&checked, loaded, events, sh, t.TempDir()); code != 1 || !strings.Contains(checked.String(), "-Name: hello, David\n    +Name: goodbye, David") {
+		t.Errorf("checkCast of a changed program = %d:\n%s", code, checked.String())
+	}
+}
+
+func TestFixturePath(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "book.txt"), nil, 0o644)
+	here := filepath.Join(t.TempDir(), "here.txt")
+	os.WriteFile(here, nil, 0o644)
+	for _, test := range []struct{ path, dir, want string }{
+		{here, dir, here},
+		{"book.txt", dir, filepath.Join(dir, "book.txt")},
+		{"missing.txt", dir, filepath.Join(dir, "missing.txt")},
+		{"book.txt", "", "book.txt"},
+	} {
+		if got := fixturePath(test.path, test.dir); got != test.want {
+			t.Errorf("fixturePath(%q, %q) = %q, want %q", test.path, test.dir, got, test.want)
+		}
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds exercises")
+	}
+	if _, err := exercises.Root(); err != nil {
+		t.Skip(err)
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"word.txt":     "pseudocode\n",
+		"right.txt":    "ps3ud0c0d3\n",
+		"wrong.txt":    "pseudocode\n",
+		"trailing.txt": "ps3ud0c0d3  \n\n",
+	}
+	for name, content := range files {
+		os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+	tests := []struct {
+		args   []string
+		status int
+		want   []string
+	}{
+		{[]string{"no-vowels", "-args", "pseudocode"}, 0, []string{"ps3ud0c0d3\n[exit 0]\n"}},
+		{[]string{"no-vowels"}, 0, []string{"Usage: ./no-vowels word\n[exit 1]\n"}},
+		{[]string{"no-vowels", "-expect", path("right.txt"), "-args", "pseudocode"}, 0, []string{":) the output is " + path("right.txt")}},
+		{[]string{"no-vowels", "-expect", path("wrong.txt"), "-args", "pseudocode"}, 1, []string{":( the output isn't", "-pseudocode\n    +ps3ud0c0d3"}},
+		{[]string{"no-vowels", "-expect", path("trailing.txt"), "-args", "pseudocode"}, 1, []string{":("}},
+		{[]string{"no-vowels", "-expect", path("trailing.txt"), "-trim", "-args", "pseudocode"}, 0, []string{":)"}},
+		{[]string{"no-vowels", "-o", path("saved.txt"), "--args", "-o"}, 0, []string{"Saved the output to"}},
+		{[]string{"week1/mario", "-stdin", path("word.txt")}, 0, []string{"[still running after 1s, so stopped]"}},
+		{[]string{"no-vowels", "-stdin", path("missing.txt")}, 2, []string{"no such file"}},
+		{[]string{"tetris"}, 1, []string{`No problem "tetris"`}},
+		{[]string{}, 1, []string{RUN_USAGE}},
+	}
+	for _, test := range tests {
+		args := test.args
+		if len(args) > 0 && args[0] == "week1/mario" {
+			args = append(args, "-timeout", "1s")
+		}
+		var out bytes.Buffer
+		status := runCommand(args, &out)
+		for _, want := range test.want {
+			if status != test.status || !strings.Contains(out.String(), want) {
+				t.Errorf("run %q = %d\n%s\nwant %d and %q", args, status, out.String(), test.status, want)
+			}
+		}
+	}
+	if saved, err := os.ReadFile(path("saved.txt")); err != nil || string(saved) != "-0\n" {
+		t.Errorf("-o saved %q, %v", saved, err)
+	}
+}
+
+func TestProfileCommand(t *testing.T) {
+	for _, args := range [][]string{{}, {"week5/speller", "-mem", "-trace"}, {"week5/speller", "-top", "0"}} {
+		var out bytes.Buffer
+		if status := profileCommand(args, &out); status != 1 || !strings.Contains(out.String(), PROFILE_USAGE) {
+			t.Errorf("profile %q = %d\n%s\nwant 1 and the usage", args, status, out.String())
+		}
+	}
+	var out bytes.Buffer
+	if status := profileCommand([]string{"tetris"}, &out); status != 1 || !strings.Contains(out.String(), `No problem "tetris"`) {
+		t.Errorf("profile tetris = %d\n%s", status, out.String())
+	}
+
+	if testing.Short() {
+		t.Skip("builds exercises")
+	}
+	if _, err := exercises.Root(); err != nil {
+		t.Skip(err)
+	}
+	path := filepath.Join(t.TempDir(), "speller.mem.pprof")
+	out.Reset()
+	status := profileCommand([]string{"week5/speller", "-mem", "-o", path, "-top", "5", "-args", "dictionaries/small", "texts/cat.txt"}, &out)
+	for _, want := range []string{"WORDS MISSPELLED:", "Memory profile in " + path, "Type: alloc_space", "Showing nodes"} {
+		if status != 0 || !strings.Contains(out.String(), want) {
+			t.Errorf("profile week5/speller -mem = %d\n%s\nwant 0 and %q", status, out.String(), want)
+		}
+	}
+	out.Reset()
+	if status := profileCommand([]string{"no-vowels", "-args", "pseudocode"}, &out); status != 2 || !strings.Contains(out.String(), "profiling.Start") {
+		t.Errorf("profile no-vowels = %d\n%s\nwant 2: it writes no profile", status, out.String())
+	}
+}
+
+func TestPprofArgs(t *testing.T) {
+	tests := []struct {
+		written profiling.Settings
+		addr    string
+		want    []string
+	}{
+		{profiling.Settings{CPUProfile: "a.pprof"}, "", []string{"tool", "pprof", "-top", "-nodecount=10", "a.pprof"}},
+		{profiling.Settings{MemProfile: "a.pprof"}, "", []string{"tool", "pprof", "-sample_index=alloc_space", "-top", "-nodecount=10", "a.pprof"}},
+		{profiling.Settings{CPUProfile: "a.pprof"}, ":8080", []string{"tool", "pprof", "-http=:8080", "a.pprof"}},
+		{profiling.Settings{Trace: "a.trace"}, ":8080", []string{"tool", "trace", "-http=:8080", "a.trace"}},
+		{profiling.Settings{Trace: "a.trace"}, "", nil},
+	}
+	for _, test := range tests {
+		if got := pprofArgs(test.written, 10, test.addr); !slices.Equal(got, test.want) {
+			t.Errorf("pprofArgs(%+v, 10, %q) = %q, want %q", test.written, test.addr, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// renderMarkdown turns the notes' Markdown into HTML: headings, paragraphs,
+// lists, quotes, tables, fenced code (highlighted by its language) and the
+// usual inline marks. It's the part of CommonMark the notes use, not all
+// of it; raw HTML is escaped, and comments are dropped so ;;Q cards don't
+// show. link rewrites each link and image, for pages that moved.
+func renderMarkdown(src string, link func(string) string) string {
+	lines := splitLines(strings.ReplaceAll(src, "\r\n", "\n"))
+	var b strings.Builder
+	var paragraph []string
+	endParagraph := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(&b, "<p>%s</p>\n", inline(strings.Join(paragraph, "\n"), link))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			endParagraph()
+
+		case strings.HasPrefix(trimmed, "<!--"):
+			endParagraph()
+			for !strings.Contains(lines[i], "-->") && i+1 < len(lines) {
+				i++
+			}
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			endParagraph()
+			fence := trimmed[:3]
+			lang := strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1]))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			fmt.Fprintf(&b, "<pre><code>%s</code></pre>\n", highlight(strings.Join(code, "\n")+"\n", lang))
+
+		case headingPattern.MatchString(trimmed):
+			endParagraph()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			text := strings.TrimRight(m[2], "# ")
+			fmt.Fprintf(&b, "<h%d id=\"%s\">%s</h%d>\n", len(m[1]), slug(text), inline(text, link), len(m[1]))
+
+		case len(paragraph) == 0 && rulePattern.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			endParagraph()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			i--
+			fmt.Fprintf(&b, "<blockquote>\n%s</blockquote>\n", renderMarkdown(strings.Join(quote, "\n"), link))
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableRulePattern.MatchString(strings.TrimSpace(lines[i+1])):
+			endParagraph()
+			b.WriteString("<table>\n<thead><tr>")
+			for _, cell := range tableCells(trimmed) {
+				fmt.Fprintf(&b, "<th>%s</th>", inline(cell, link))
+			}
+			b.WriteString("</tr></thead>\n<tbody>\n")
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				b.WriteString("<tr>")
+				for _, cell := range tableCells(strings.TrimSpace(lines[i])) {
+					fmt.Fprintf(&b, "<td>%s</td>", inline(cell, link))
+				}
+				b.WriteString("</tr>\n")
+			}
+			i--
+			b.WriteString("</tbody>\n</table>\n")
+
+		case listPattern.MatchString(line) && (len(paragraph) == 0 || !strings.HasPrefix(line, " ")):
+			endParagraph()
+			i = renderList(&b, lines, i, link) - 1
+
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	endParagraph()
+	return b.String()
+}
+
+var (
+	headingPattern   = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	rulePattern      = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	tableRulePattern = regexp.MustCompile(`^\|?(\s*:?-+:?\s*\|)+\s*(:?-+:?\s*)?$`)
+	listPattern      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+`)
+)
+
+// renderList writes the list starting at lines[start], with any lists
+// indented under its items, and returns where it ends.
+func renderList(b *strings.Builder, lines []string, start int, link func(string) string) int {
+	m := listPattern.FindStringSubmatch(lines[start])
+	indent, ordered := len(m[1]), isOrdered(m[2])
+	// item matches another item of this list, with the same indent and
+	// the same kind of marker.
+	item := func(line string) []string {
+		if m := listPattern.FindStringSubmatch(line); m != nil && len(m[1]) == indent && isOrdered(m[2]) == ordered {
+			return m
+		}
+		return nil
+	}
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	fmt.Fprintf(b, "<%s>\n", tag)
+	i := start
+	for i < len(lines) {
+		m := item(lines[i])
+		if m == nil {
+			break
+		}
+		text := []string{strings.TrimSpace(lines[i][len(m[0]):])}
+		var nested strings.Builder
+		for i++; i < len(lines); i++ {
+			line, trimmed := lines[i], strings.TrimSpace(lines[i])
+			if trimmed == "" {
+				if i+1 < len(lines) && strings.HasPrefix(lines[i+1], strings.Repeat(" ", indent+1)) {
+					continue
+				}
+				break
+			}
+			if next := listPattern.FindStringSubmatch(line); next != nil {
+				if len(next[1]) <= indent {
+					break
+				}
+				i = renderList(&nested, lines, i, link) - 1
+				continue
+			}
+			if !strings.HasPrefix(line, " ") {
+				break
+			}
+			text = append(text, trimmed)
+		}
+		fmt.Fprintf(b, "<li>%s%s</li>\n", inline(strings.Join(text, "\n"), link), nested.String())
+		// Blank lines between items keep the list going.
+		next := i
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next > i {
+			if next == len(lines) || item(lines[next]) == nil {
+				break
+			}
+			i = next
+		}
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+	return i
+}
+
+// isOrdered reports whether a list marker is a number, "1." or "1)".
+func isOrdered(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+// tableCells splits a table row into its cells, leaving pipes in code be.
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	inCode := false
+	for i := 0; i < len(row); i++ {
+		switch c := row[i]; {
+		case c == '`':
+			inCode = !inCode
+			cell.WriteByte(c)
+		case c == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '|' && !inCode:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+var inlinePattern = regexp.MustCompile("`+[^`]+`+|!?\\[[^\\]]*\\]\\([^)\\s]*\\)|\\*\\*[^*]+\\*\\*|\\*[^*\\s][^*]*\\*|\\b_[^_\\s][^_]*_\\b|<https?://[^>]+>")
+
+// inline renders the marks within a block: code, links and images, bold
+// and italics, and <autolinks>. Everything else is escaped.
+func inline(text string, link func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, at := range inlinePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:at[0]]))
+		last = at[1]
+		m := text[at[0]:at[1]]
+		switch {
+		case m[0] == '`':
+			ticks := len(m) - len(strings.TrimLeft(m, "`"))
+			fmt.Fprintf(&b, "<code>%s</code>", html.EscapeString(strings.TrimSpace(m[ticks:len(m)-ticks])))
+		case m[0] == '[' || m[0] == '!':
+			label, href, _ := strings.Cut(strings.TrimPrefix(m, "!"), "](")
+			label, href = label[1:], link(strings.TrimSuffix(href, ")"))
+			if m[0] == '!' {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`, html.EscapeString(href), html.EscapeString(label))
+			} else {
+				fmt.Fprintf(&b, `<a href="%s">%s</a>`, html.EscapeString(href), inline(label, link))
+			}
+		case strings.HasPrefix(m, "**"):
+			fmt.Fprintf(&b, "<strong>%s</strong>", inline(m[2:len(m)-2], link))
+		case m[0] == '*' || m[0] == '_':
+			fmt.Fprintf(&b, "<em>%s</em>", inline(m[1:len(m)-1], link))
+		case m[0] == '<':
+			url := html.EscapeString(m[1 : len(m)-1])
+			fmt.Fprintf(&b, `<a href="%s">%s</a>`, url, url)
+		}
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// slug is a heading's id, the way GitHub makes them: lower case, spaces
+// to dashes, punctuation dropped.
+func slug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ' || r == '-':
+			b.WriteRune('-')
+		case r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r > 127:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
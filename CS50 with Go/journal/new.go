@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"exercises"
+)
+
+const NEW_USAGE = "Usage: journal new WEEK/NAME [-template datastructure|function]"
+
+// TEMPLATES are the exercises new can start, one directory each under
+// skeletons/new/. Each has a starter full of TODOs, NAME.go, a finished
+// version, NAME-finished.go, like week 5's recipe.go and recipe-finished.go,
+// and tests for both: the finished file is behind the finished build tag,
+// so the two can share a folder and a package.
+var TEMPLATES = []string{"datastructure", "function"}
+
+func newCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	flags.SetOutput(w)
+	template := flags.String("template", "datastructure", "what to start: "+strings.Join(TEMPLATES, ", "))
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	var week int
+	var name string
+	if len(positional) != 1 {
+		fmt.Fprintln(w, NEW_USAGE)
+		return 1
+	}
+	if n, _ := fmt.Sscanf(strings.Replace(positional[0], "/", " ", 1), "week%d %s", &week, &name); n != 2 || week < 0 {
+		fmt.Fprintln(w, NEW_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	dir := filepath.Join(weekDir(root, week), name)
+	files, err := newExercise(*template, name, week, dir)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+	fmt.Fprintf(w, "Created %s %s:\n", *template, dir)
+	for _, file := range files {
+		fmt.Fprintf(w, "    %s\n", file)
+	}
+	switch work, err := joinWorkspace(dir); {
+	case err != nil:
+		fmt.Fprintf(w, "Couldn't add it to the workspace, run go work use yourself: %v\n", err)
+	case work != "":
+		fmt.Fprintf(w, "Added it to %s.\n", work)
+	}
+	rel, _ := filepath.Rel(root, dir)
+	fmt.Fprintf(w, "\nNext:\n    cd %s\n    go test .                  # fails until the TODOs are done\n    go test -tags finished .   # the finished version passes\n", dir)
+	fmt.Fprintf(w, "\nand add it to PROGRAMS in exercises/exercises.go, for cs50go and journal:\n    {%q, %d, %q, %q, \"...\"},\n", name, week, filepath.ToSlash(rel), name+".go")
+	return 0
+}
+
+// newExercise writes the template's starter, finished version, tests and
+// problem.md for an exercise called name into dir, which mustn't exist
+// yet, and returns the files it wrote.
+func newExercise(template, name string, week int, dir string) ([]string, error) {
+	if !slices.Contains(TEMPLATES, template) {
+		return nil, fmt.Errorf("unknown template %q (want %s)", template, strings.Join(TEMPLATES, ", "))
+	}
+	if err := checkNew(name, dir); err != nil {
+		return nil, err
+	}
+	p := newProject(name)
+	p.Week = week
+	return writeSkeleton(path.Join("skeletons", "new", template), p, dir)
+}
+
+// weekDir is the week's folder under root, whatever follows its number
+// (week5-Data-Strucutes), or a new weekN when there isn't one.
+func weekDir(root string, week int) string {
+	matches, _ := filepath.Glob(filepath.Join(root, fmt.Sprintf("week%d-*", week)))
+	for _, match := range append(matches, filepath.Join(root, fmt.Sprintf("week%d", week))) {
+		if info, err := os.Stat(match); err == nil && info.IsDir() {
+			return match
+		}
+	}
+	return filepath.Join(root, fmt.Sprintf("week%d", week))
+}
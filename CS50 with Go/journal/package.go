@@ -0,0 +1,282 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"exercises"
+)
+
+const PACKAGE_USAGE = "Usage: journal package WEEK/PROBLEM [-o FILE.zip|FILE.tar.gz]"
+
+// MANIFEST is the file a package describes itself in, next to the code.
+const MANIFEST = "manifest.json"
+
+// manifest says what a package is, when it was made and from which
+// commit, so whoever gets it can find the same code in the repo.
+type manifest struct {
+	Problem string    `json:"problem"`
+	Created time.Time `json:"created"`
+	Commit  string    `json:"commit,omitempty"`
+	Dirty   bool      `json:"dirty,omitempty"` // there were uncommitted changes
+	Checks  string    `json:"checks"`
+	Files   []string  `json:"files"`
+}
+
+// BINARY_MAGIC are how executables start: ELF, Windows' MZ and the Mach-O
+// magic numbers, both byte orders.
+var BINARY_MAGIC = [][]byte{
+	[]byte("\x7fELF"),
+	[]byte("MZ"),
+	{0xfe, 0xed, 0xfa, 0xcf}, {0xcf, 0xfa, 0xed, 0xfe},
+	{0xfe, 0xed, 0xfa, 0xce}, {0xce, 0xfa, 0xed, 0xfe},
+}
+
+func packageCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("package", flag.ContinueOnError)
+	flags.SetOutput(w)
+	output := flags.String("o", "", "the archive to write, .zip or .tar.gz (default NAME.zip)")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, PACKAGE_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	if *output == "" {
+		*output = e.Name() + ".zip"
+	}
+	if !isArchive(*output) {
+		fmt.Fprintf(w, "%s: a package is a .zip or a .tar.gz\n", *output)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	// Only code that passes its checks goes out, like submit50 after
+	// check50. A problem without checks can't be held to any.
+	var report bytes.Buffer
+	status, summary := check(root, e, &report, false)
+	_, err = os.Stat(checksPath(root, e))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		summary = "none"
+	case status != 0:
+		fmt.Fprintf(w, "%s\nNot packaging %s until its checks pass.\n", report.String(), e.ID())
+		return 1
+	}
+
+	dir := e.Path(root)
+	only := ""
+	if e.Loose(root) {
+		only = e.File
+	}
+	files, err := packageFiles(dir, e.Name(), only)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	m := manifest{Problem: e.ID(), Created: time.Now().UTC().Truncate(time.Second), Checks: summary, Files: files}
+	m.Commit, m.Dirty = gitCommit(dir)
+	if err := writeArchive(*output, e.Name(), dir, m); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Packaged %s (checks: %s) in %s:\n", e.ID(), summary, *output)
+	for _, file := range files {
+		fmt.Fprintf(w, "    %s\n", file)
+	}
+	if err := recordPackage(e, summary); err != nil {
+		fmt.Fprintln(w, "Couldn't save your progress:", err)
+	}
+	return 0
+}
+
+// packageFiles lists what goes in a package of the problem in dir, with
+// forward slashes: everything but binaries, earlier packages, hidden files
+// and what tests leave in testdata, apart from the problem's checks. Subfolders that are
+// modules of their own are someone else's. A loose program is only its
+// file and its tests, since its neighbours are other programs.
+func packageFiles(dir, name, only string) ([]string, error) {
+	checks := "testdata/" + name + ".checks.json"
+	if only != "" {
+		var files []string
+		for _, file := range []string{only, strings.TrimSuffix(only, ".go") + "_test.go", checks} {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err == nil {
+				files = append(files, file)
+			}
+		}
+		return files, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if (strings.HasPrefix(rel, "testdata/") && rel != checks) || rel == MANIFEST || isArchive(rel) {
+			return nil
+		}
+		if binary, err := isBinary(path); err != nil || binary {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// isArchive reports whether name is a package journal can write.
+func isArchive(name string) bool {
+	return strings.HasSuffix(name, ".zip") || strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+// isBinary reports whether path is an executable, by its first bytes:
+// built programs get left next to the code, with or without .exe.
+func isBinary(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	head := make([]byte, 4)
+	n, _ := io.ReadFull(f, head)
+	return slices.ContainsFunc(BINARY_MAGIC, func(magic []byte) bool {
+		return bytes.HasPrefix(head[:n], magic)
+	}), nil
+}
+
+// gitCommit is the commit dir is checked out at, and whether there are
+// changes under it that aren't committed. Outside git it's "".
+func gitCommit(dir string) (string, bool) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	status := exec.Command("git", "status", "--porcelain", "--", ".")
+	status.Dir = dir
+	changes, err := status.Output()
+	return strings.TrimSpace(string(out)), err == nil && len(changes) > 0
+}
+
+// writeArchive writes m.Files from dir and the manifest into a zip or a
+// gzipped tar, by output's extension, all under a folder called prefix.
+func writeArchive(output, prefix, dir string, m manifest) error {
+	manifestJSON, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return err
+	}
+	manifestJSON = append(manifestJSON, '\n')
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	var add func(name string, mode fs.FileMode, content []byte) error
+	var finish func() error
+	switch {
+	case strings.HasSuffix(output, ".zip"):
+		zw := zip.NewWriter(f)
+		add = func(name string, mode fs.FileMode, content []byte) error {
+			header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: m.Created}
+			header.SetMode(mode)
+			fw, err := zw.CreateHeader(header)
+			if err != nil {
+				return err
+			}
+			_, err = fw.Write(content)
+			return err
+		}
+		finish = zw.Close
+	case strings.HasSuffix(output, ".tar.gz"), strings.HasSuffix(output, ".tgz"):
+		gz := gzip.NewWriter(f)
+		tw := tar.NewWriter(gz)
+		add = func(name string, mode fs.FileMode, content []byte) error {
+			header := &tar.Header{Name: name, Mode: int64(mode.Perm()), Size: int64(len(content)), ModTime: m.Created}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			_, err := tw.Write(content)
+			return err
+		}
+		finish = func() error {
+			if err := tw.Close(); err != nil {
+				return err
+			}
+			return gz.Close()
+		}
+	default:
+		f.Close()
+		os.Remove(output)
+		return fmt.Errorf("%s: not a .zip or a .tar.gz", output)
+	}
+
+	err = add(prefix+"/"+MANIFEST, 0644, manifestJSON)
+	for _, file := range m.Files {
+		if err != nil {
+			break
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		var content []byte
+		var info fs.FileInfo
+		if content, err = os.ReadFile(path); err != nil {
+			break
+		}
+		if info, err = os.Stat(path); err != nil {
+			break
+		}
+		err = add(prefix+"/"+file, info.Mode(), content)
+	}
+	if err == nil {
+		err = finish()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(output)
+	}
+	return err
+}
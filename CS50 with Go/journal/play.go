@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"bst"
+	"linkedlist"
+	"speller/hashtable"
+	"speller/trie"
+)
+
+const PLAY_USAGE = "Usage: journal play list|bst|hash|trie"
+
+const PLAY_HELP = `insert X...   put X in; several at once are one step
+delete X...   take X out
+find X...     where X is, or how far looking for it got
+undo          take back the last step
+show          draw it again
+help          this
+quit          or Ctrl-D`
+
+// playground is one of week 5's structures, driven by what's typed: numbers
+// for the list and the tree, words for the hash table and the trie.
+type playground interface {
+	insert(key string) error
+	delete(key string) (bool, error)
+	find(key string) (string, error) // a line saying where key is, or isn't
+	draw(w io.Writer)
+}
+
+// PLAYGROUNDS are the structures play knows, each an empty one.
+var PLAYGROUNDS = map[string]func() playground{
+	"list": func() playground { return &listPlayground{linkedlist.New()} },
+	"bst":  func() playground { return &bstPlayground{bst.New[int, struct{}]()} },
+	"hash": func() playground { return &hashPlayground{hashtable.New()} },
+	"trie": func() playground { return &triePlayground{trie.New()} },
+}
+
+func playCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("play", flag.ContinueOnError)
+	flags.SetOutput(w)
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || PLAYGROUNDS[positional[0]] == nil {
+		fmt.Fprintln(w, PLAY_USAGE)
+		return 1
+	}
+	// Prompts are for someone typing, not for a script piped in.
+	prompt := ""
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		prompt = positional[0] + "> "
+	}
+	play(os.Stdin, w, positional[0], prompt)
+	return 0
+}
+
+// play reads operations from r until it ends or says quit, prompting for
+// each, drawing the structure after each one that changes it. Undo starts again from empty
+// and does every step but the last: no structure has to know how to undo.
+func play(r io.Reader, w io.Writer, kind, prompt string) {
+	p := PLAYGROUNDS[kind]()
+	var steps [][]string
+	replay := func() {
+		p = PLAYGROUNDS[kind]()
+		for _, step := range steps {
+			apply(io.Discard, p, step)
+		}
+	}
+
+	fmt.Fprintf(w, "An empty %s. help lists what to type.\n", kind)
+	scanner := bufio.NewScanner(r)
+	for fmt.Fprint(w, prompt); scanner.Scan(); fmt.Fprint(w, prompt) {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch op := fields[0]; op {
+		case "quit", "exit":
+			return
+		case "help":
+			fmt.Fprintln(w, PLAY_HELP)
+		case "show":
+			p.draw(w)
+		case "find":
+			for _, key := range fields[1:] {
+				line, err := p.find(key)
+				if err != nil {
+					line = err.Error()
+				}
+				fmt.Fprintln(w, line)
+			}
+		case "undo":
+			if len(steps) == 0 {
+				fmt.Fprintln(w, "Nothing to undo.")
+				continue
+			}
+			fmt.Fprintf(w, "Undid %s.\n", strings.Join(steps[len(steps)-1], " "))
+			steps = steps[:len(steps)-1]
+			replay()
+			p.draw(w)
+		case "insert", "delete":
+			if len(fields) == 1 {
+				fmt.Fprintf(w, "%s what?\n", op)
+				continue
+			}
+			changed, err := apply(w, p, fields)
+			if err != nil {
+				// Half a step is no step: back to how it was before it.
+				fmt.Fprintln(w, err)
+				replay()
+				continue
+			}
+			if changed {
+				steps = append(steps, fields)
+				p.draw(w)
+			}
+		default:
+			fmt.Fprintf(w, "No %s: insert, delete, find, undo, show, help or quit.\n", op)
+		}
+	}
+	if prompt != "" {
+		// Ctrl-D leaves the cursor after the prompt.
+		fmt.Fprintln(w)
+	}
+}
+
+// apply does one insert or delete step to p, for each of its keys, and
+// reports whether anything changed.
+func apply(w io.Writer, p playground, step []string) (bool, error) {
+	changed := false
+	for _, key := range step[1:] {
+		if step[0] == "insert" {
+			if err := p.insert(key); err != nil {
+				return false, err
+			}
+			changed = true
+			continue
+		}
+		deleted, err := p.delete(key)
+		if err != nil {
+			return false, err
+		}
+		if !deleted {
+			fmt.Fprintf(w, "No %s to delete.\n", key)
+		}
+		changed = changed || deleted
+	}
+	return changed, nil
+}
+
+// number is a key typed for the list or the tree.
+func number(key string) (int, error) {
+	n, err := strconv.Atoi(key)
+	if err != nil {
+		return 0, fmt.Errorf("%q isn't a number", key)
+	}
+	return n, nil
+}
+
+// listPlayground is week 5's linked list, inserting at the head as the
+// lecture does.
+type listPlayground struct{ list *linkedlist.List }
+
+func (p *listPlayground) insert(key string) error {
+	n, err := number(key)
+	if err == nil {
+		p.list.InsertHead(n)
+	}
+	return err
+}
+
+func (p *listPlayground) delete(key string) (bool, error) {
+	n, err := number(key)
+	return err == nil && p.list.Delete(n), err
+}
+
+func (p *listPlayground) find(key string) (string, error) {
+	n, err := number(key)
+	if err != nil {
+		return "", err
+	}
+	values := p.list.Values()
+	if i := slices.Index(values, n); i >= 0 {
+		return fmt.Sprintf("Found %d, node %d of %d.", n, i+1, len(values)), nil
+	}
+	return fmt.Sprintf("No %d, after all %d node%s.", n, len(values), plural(len(values))), nil
+}
+
+func (p *listPlayground) draw(w io.Writer) {
+	labels := []string{}
+	for _, n := range p.list.Values() {
+		labels = append(labels, strconv.Itoa(n))
+	}
+	drawChain(w, "head ", labels)
+}
+
+// bstPlayground is week 5's binary search tree, with keys and no values.
+type bstPlayground struct{ tree *bst.Tree[int, struct{}] }
+
+func (p *bstPlayground) insert(key string) error {
+	n, err := number(key)
+	if err == nil {
+		p.tree.Insert(n, struct{}{})
+	}
+	return err
+}
+
+func (p *bstPlayground) delete(key string) (bool, error) {
+	n, err := number(key)
+	return err == nil && p.tree.Delete(n), err
+}
+
+func (p *bstPlayground) find(key string) (string, error) {
+	n, err := number(key)
+	if err != nil {
+		return "", err
+	}
+	var path []string
+	for s := p.tree.Shape(); s != nil; {
+		path = append(path, strconv.Itoa(s.Key))
+		switch {
+		case n < s.Key:
+			s = s.Left
+		case n > s.Key:
+			s = s.Right
+		default:
+			return fmt.Sprintf("Found %d: %s.", n, strings.Join(path, " → ")), nil
+		}
+	}
+	return fmt.Sprintf("No %d: %s.", n, strings.Join(append(path, "NULL"), " → ")), nil
+}
+
+func (p *bstPlayground) draw(w io.Writer) {
+	if p.tree.Len() == 0 {
+		fmt.Fprintln(w, "root ──▶ NULL")
+		return
+	}
+	var convert func(*bst.Shape[int]) *drawNode
+	convert = func(s *bst.Shape[int]) *drawNode {
+		if s == nil {
+			return nil
+		}
+		n := &drawNode{Label: strconv.Itoa(s.Key)}
+		if s.Left != nil || s.Right != nil {
+			// Both, even when one's missing, or a lone child could be either.
+			n.Children = []*drawNode{convert(s.Left), convert(s.Right)}
+		}
+		return n
+	}
+	drawTree(w, convert(p.tree.Shape()))
+	fmt.Fprintf(w, "%d key%s, %d high.\n", p.tree.Len(), plural(p.tree.Len()), p.tree.Height())
+}
+
+// hashPlayground is speller's hash table, showing just the buckets in use.
+type hashPlayground struct{ table *hashtable.Table }
+
+func (p *hashPlayground) insert(key string) error {
+	return p.table.Load(strings.NewReader(key))
+}
+
+func (p *hashPlayground) delete(key string) (bool, error) {
+	return p.table.Delete(key), nil
+}
+
+func (p *hashPlayground) find(key string) (string, error) {
+	bucket := hashtable.Bucket(key)
+	var chain []string
+	p.table.Buckets(func(index int, words []string) {
+		if index == bucket {
+			chain = words
+		}
+	})
+	if i := slices.Index(chain, strings.ToLower(key)); i >= 0 {
+		return fmt.Sprintf("Found %s in bucket %d, word %d of %d there.", key, bucket, i+1, len(chain)), nil
+	}
+	if len(chain) == 0 {
+		return fmt.Sprintf("No %s: bucket %d is empty.", key, bucket), nil
+	}
+	return fmt.Sprintf("No %s in bucket %d's %d word%s.", key, bucket, len(chain), plural(len(chain))), nil
+}
+
+func (p *hashPlayground) draw(w io.Writer) {
+	used := 0
+	p.table.Buckets(func(index int, words []string) {
+		drawChain(w, fmt.Sprintf("%5d ", index), words)
+		used++
+	})
+	fmt.Fprintf(w, "%d word%s in %d of %d buckets.\n", p.table.Size(), plural(p.table.Size()), used, hashtable.N)
+}
+
+// triePlayground is speller's trie, a ● on each letter that ends a word.
+type triePlayground struct{ trie *trie.Trie }
+
+func (p *triePlayground) insert(key string) error {
+	return p.trie.Load(strings.NewReader(key))
+}
+
+func (p *triePlayground) delete(key string) (bool, error) {
+	return p.trie.Delete(key), nil
+}
+
+func (p *triePlayground) find(key string) (string, error) {
+	key = strings.ToLower(key)
+	prefixes := map[string]bool{}
+	p.trie.Walk(func(prefix string, isWord bool) { prefixes[prefix] = isWord })
+	if prefixes[key] {
+		return fmt.Sprintf("Found %s: %s ●.", key, strings.Join(strings.Split(key, ""), " → ")), nil
+	}
+	got := 0
+	for got < len(key) {
+		if _, ok := prefixes[key[:got+1]]; !ok {
+			break
+		}
+		got++
+	}
+	switch {
+	case got == len(key):
+		return fmt.Sprintf("No %s: %s is there, but isn't a word.", key, key), nil
+	case got == 0:
+		return fmt.Sprintf("No %s: nothing starts with %s.", key, key[:1]), nil
+	}
+	return fmt.Sprintf("No %s: %s, then no %s.", key, strings.Join(strings.Split(key[:got], ""), " → "), key[got:got+1]), nil
+}
+
+func (p *triePlayground) draw(w io.Writer) {
+	root := &drawNode{Label: "root"}
+	nodes := map[string]*drawNode{"": root}
+	p.trie.Walk(func(prefix string, isWord bool) {
+		n := &drawNode{Label: prefix[len(prefix)-1:]}
+		if isWord {
+			n.Label += " ● " + prefix
+		}
+		parent := nodes[prefix[:len(prefix)-1]]
+		parent.Children = append(parent.Children, n)
+		nodes[prefix] = n
+	})
+	drawTree(w, root)
+	fmt.Fprintf(w, "%d word%s, %d node%s.\n", p.trie.Size(), plural(p.trie.Size()), len(nodes), plural(len(nodes)))
+}
+
+// drawNode is one node of a tree to draw: a nil child is drawn as a ·.
+type drawNode struct {
+	Label    string
+	Children []*drawNode
+}
+
+// drawTree draws a tree sideways, the way tree(1) draws folders.
+func drawTree(w io.Writer, root *drawNode) {
+	fmt.Fprintln(w, root.Label)
+	drawChildren(w, root.Children, "")
+}
+
+func drawChildren(w io.Writer, children []*drawNode, indent string) {
+	for i, child := range children {
+		branch, under := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, under = "└── ", "    "
+		}
+		if child == nil {
+			fmt.Fprintf(w, "%s%s·\n", indent, branch)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s%s\n", indent, branch, child.Label)
+		drawChildren(w, child.Children, indent+under)
+	}
+}
+
+// drawChain draws labels as boxes pointing one to the next, after label,
+// the way the lecture draws a linked list:
+//
+//	     ┌───┐   ┌───┐
+//	head │ 1 │──▶│ 2 │──▶ NULL
+//	     └───┘   └───┘
+func drawChain(w io.Writer, label string, labels []string) {
+	if len(labels) == 0 {
+		fmt.Fprintf(w, "%s──▶ NULL\n", label)
+		return
+	}
+	var top, middle, bottom strings.Builder
+	indent := strings.Repeat(" ", utf8.RuneCountInString(label))
+	top.WriteString(indent)
+	middle.WriteString(label)
+	bottom.WriteString(indent)
+	for i, l := range labels {
+		if i > 0 {
+			top.WriteString("   ")
+			middle.WriteString("──▶")
+			bottom.WriteString("   ")
+		}
+		line := strings.Repeat("─", utf8.RuneCountInString(l)+2)
+		top.WriteString("┌" + line + "┐")
+		middle.WriteString("│ " + l + " │")
+		bottom.WriteString("└" + line + "┘")
+	}
+	middle.WriteString("──▶ NULL")
+	fmt.Fprintf(w, "%s\n%s\n%s\n", top.String(), middle.String(), bottom.String())
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"exercises"
+	"profiling"
+)
+
+const PROFILE_USAGE = "Usage: journal profile WEEK/PROBLEM [-mem | -trace] [-stdin FILE] [-o FILE] [-top N] [-http ADDR] [-timeout D] [-args ARGS...]"
+
+func profileCommand(args []string, w io.Writer) int {
+	// Everything after -args is the exercise's, as for run.
+	var exerciseArgs []string
+	if i := slices.IndexFunc(args, func(arg string) bool { return arg == "-args" || arg == "--args" }); i >= 0 {
+		args, exerciseArgs = args[:i], args[i+1:]
+	}
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	flags.SetOutput(w)
+	mem := flags.Bool("mem", false, "profile the heap as it ends, instead of the CPU")
+	traced := flags.Bool("trace", false, "write an execution trace instead, for go tool trace")
+	stdin := flags.String("stdin", "", "a file to pipe in as standard input, instead of nothing")
+	out := flags.String("o", "", "the file to write (default NAME.cpu.pprof, NAME.mem.pprof or NAME.trace)")
+	top := flags.Int("top", 20, "how many functions the report lists")
+	addr := flags.String("http", "", "open the report in a browser instead, served on this address, like localhost:8080")
+	timeout := flags.Duration("timeout", time.Minute, "how long it may run; stopped, it writes no profile")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || (*mem && *traced) || *top < 1 || *timeout <= 0 {
+		fmt.Fprintln(w, PROFILE_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	var prof profiling.Settings
+	switch {
+	case *mem:
+		prof.MemProfile = cmp.Or(*out, e.Name()+".mem.pprof")
+	case *traced:
+		prof.Trace = cmp.Or(*out, e.Name()+".trace")
+	default:
+		prof.CPUProfile = cmp.Or(*out, e.Name()+".cpu.pprof")
+	}
+	env, err := prof.Env()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	var input io.Reader = strings.NewReader("")
+	if *stdin != "" {
+		f, err := os.Open(fixturePath(*stdin, e.Path(root)))
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		defer f.Close()
+		input = f
+	}
+
+	// It runs in its folder, like run and its checks, and says what it
+	// says as it goes: it may take a while, which is why it's profiled.
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	start := time.Now()
+	err = e.Run(ctx, exercises.IO{Args: exerciseArgs, Dir: e.Path(root), Env: env, Stdin: input, Stdout: w, Stderr: w})
+	var exit *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		fmt.Fprintf(w, "[still running after %v, so stopped]\n", *timeout)
+		return 1
+	case errors.As(err, &exit):
+		fmt.Fprintf(w, "[exit %d]\n", exit.ExitCode())
+	case err != nil:
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	written := prof.Written(start)
+	if !written.Any() {
+		fmt.Fprintf(w, "%s didn't write a profile: a program has to call profiling.Start, as recover, speller, filter and volume do.\n", e.ID())
+		return 2
+	}
+	for _, line := range written.Describe() {
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintln(w)
+
+	report := pprofArgs(written, *top, *addr)
+	if report == nil {
+		return 0
+	}
+	cmd := exec.Command("go", report...)
+	cmd.Stdout, cmd.Stderr = w, w
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return 0
+}
+
+// pprofArgs is the go command that reports on what was written: pprof's
+// top functions, or its pages on addr, or go tool trace's. A trace has no
+// report without a browser, so there's nothing to run then. The heap is
+// written as a program ends, when it has freed most of it, so it's what
+// was allocated that's shown.
+func pprofArgs(written profiling.Settings, top int, addr string) []string {
+	if written.Trace != "" {
+		if addr == "" {
+			return nil
+		}
+		return []string{"tool", "trace", "-http=" + addr, written.Trace}
+	}
+	args := []string{"tool", "pprof"}
+	if written.MemProfile != "" {
+		args = append(args, "-sample_index=alloc_space")
+	}
+	if addr != "" {
+		args = append(args, "-http="+addr)
+	} else {
+		args = append(args, "-top", "-nodecount="+strconv.Itoa(top))
+	}
+	return append(args, cmp.Or(written.CPUProfile, written.MemProfile))
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"config"
+	"exercises"
+)
+
+const PROGRESS_USAGE = `Usage:
+  journal progress [WEEK]                  how far you are, e.g. journal progress 2
+  journal progress start|done|reset WEEK/PROBLEM`
+
+// A problem goes from STARTED, when it's first checked or you say so, to
+// CHECKED when every check passes, and is COMPLETED once it's packaged to
+// hand in or marked done. Checks failing later move a checked problem back
+// to started, but leave a completed one completed.
+const (
+	STARTED   = "started"
+	CHECKED   = "checked"
+	COMPLETED = "completed"
+)
+
+// PROGRESS_FILE is where progress is kept under the data directory, which
+// is the config's data_dir, or $XDG_DATA_HOME, or ~/.local/share without
+// either, so it survives checkouts and branches of the repo.
+const PROGRESS_FILE = "journal/progress.json"
+
+// problemProgress is how far one problem has got.
+type problemProgress struct {
+	Status  string    `json:"status"`
+	Started time.Time `json:"started"`
+	Updated time.Time `json:"updated"`
+	Checks  string    `json:"checks,omitempty"`  // the last check, "13 of 14 passed"
+	Checked int       `json:"checked,omitempty"` // how many checks there have been
+	// Sessions is the time spent on it, from journal start and stop.
+	Sessions []session `json:"sessions,omitempty"`
+}
+
+// progress is every problem worked on, by ID ("week1/credit").
+type progress map[string]*problemProgress
+
+// dataPath is where file is kept under the data directory.
+func dataPath(file string) (string, error) {
+	data := cmp.Or(config.Get().DataDir, os.Getenv("XDG_DATA_HOME"))
+	if data == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		data = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(data, filepath.FromSlash(file)), nil
+}
+
+// loadProgress reads the progress file; there's none before the first
+// check, which is no progress yet.
+func loadProgress() (progress, error) {
+	path, err := dataPath(PROGRESS_FILE)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := progress{}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// save writes the progress file.
+func (p progress) save() error {
+	path, err := dataPath(PROGRESS_FILE)
+	if err != nil {
+		return err
+	}
+	return saveJSON(path, p)
+}
+
+// saveJSON writes v to path, through a temporary file so a crash halfway
+// doesn't lose it all.
+func saveJSON(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// set moves id to status, keeping when it was started.
+func (p progress) set(id, status string, now time.Time) *problemProgress {
+	now = now.Truncate(time.Second)
+	pp, ok := p[id]
+	if !ok {
+		pp = &problemProgress{Started: now}
+		p[id] = pp
+	}
+	pp.Status, pp.Updated = status, now
+	return pp
+}
+
+// updateProgress loads the progress, changes it and saves it again.
+func updateProgress(change func(progress)) error {
+	p, err := loadProgress()
+	if err != nil {
+		return err
+	}
+	change(p)
+	return p.save()
+}
+
+// recordCheck notes a check of e, passed or not.
+func recordCheck(e exercises.Exercise, passed bool, summary string) error {
+	id := exercises.ID(e)
+	return updateProgress(func(p progress) {
+		status := STARTED
+		if passed {
+			status = CHECKED
+		}
+		if pp, ok := p[id]; ok && pp.Status == COMPLETED {
+			status = COMPLETED
+		}
+		pp := p.set(id, status, time.Now())
+		pp.Checks = summary
+		pp.Checked++
+	})
+}
+
+// recordPackage notes e was packaged, with every check passing.
+func recordPackage(e exercises.Exercise, summary string) error {
+	return updateProgress(func(p progress) {
+		p.set(exercises.ID(e), COMPLETED, time.Now()).Checks = summary
+	})
+}
+
+func progressCommand(args []string, w io.Writer) int {
+	switch {
+	case len(args) == 2 && slices.Contains([]string{"start", "done", "reset"}, args[0]):
+		e, ok := exercises.Lookup(args[1])
+		if !ok {
+			fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", args[1])
+			return 1
+		}
+		err := updateProgress(func(p progress) {
+			switch args[0] {
+			case "start":
+				p.set(exercises.ID(e), STARTED, time.Now())
+			case "done":
+				p.set(exercises.ID(e), COMPLETED, time.Now())
+			case "reset":
+				delete(p, exercises.ID(e))
+			}
+		})
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		fmt.Fprintf(w, "%s: %s\n", exercises.ID(e), map[string]string{"start": STARTED, "done": COMPLETED, "reset": "not started"}[args[0]])
+		return 0
+	case len(args) > 1:
+		fmt.Fprintln(w, PROGRESS_USAGE)
+		return 1
+	}
+
+	week := 0
+	if len(args) == 1 {
+		if _, err := fmt.Sscan(args[0], &week); err != nil {
+			fmt.Fprintln(w, PROGRESS_USAGE)
+			return 1
+		}
+	}
+	p, err := loadProgress()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if !printProgress(w, p, week) {
+		fmt.Fprintf(w, "No week %d.\n", week)
+		return 1
+	}
+	return 0
+}
+
+// printProgress shows every problem under its week, or only week's when it
+// isn't 0, with a count of those completed, then the overall percentage.
+// It reports false when there's nothing in that week.
+func printProgress(w io.Writer, p progress, week int) bool {
+	total, done, current := 0, 0, 0
+	var lines []string
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "Week %d: %d of %d completed\n", current, done, total)
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+		}
+		lines = nil
+	}
+
+	allTotal, allDone := 0, 0
+	for _, e := range exercises.All() {
+		if week != 0 && e.Week() != week {
+			continue
+		}
+		if e.Week() != current {
+			flush()
+			current, total, done = e.Week(), 0, 0
+		}
+		total++
+		allTotal++
+		line := fmt.Sprintf("  %-20s", e.Name())
+		if pp, ok := p[exercises.ID(e)]; ok {
+			if pp.Status == COMPLETED {
+				done++
+				allDone++
+			}
+			line += fmt.Sprintf("%-10s %s", pp.Status, pp.Updated.Format(time.DateOnly))
+			if pp.Checks != "" {
+				line += ", " + pp.Checks
+			}
+		} else {
+			line += "-"
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	if allTotal == 0 {
+		return false
+	}
+	fmt.Fprintf(w, "\n%d of %d completed (%d%%)\n", allDone, allTotal, allDone*100/allTotal)
+	return true
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"chart"
+	"exercises"
+	"i18n"
+)
+
+const RECORD_USAGE = "Usage: journal record WEEK/PROBLEM [ARGS...] [-o FILE.cast]"
+
+const REPLAY_USAGE = "Usage: journal replay FILE.cast [-speed X] [-idle D] [-check]"
+
+// castHeader is the first line of an asciicast v2 file, the format
+// asciinema records and its player plays on a web page. Args aren't in
+// the format; players ignore what they don't know. Env has the language
+// the messages were in, to check it in the same one.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"` // the problem, "week1/mario"
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// castEvent is every line after the header, [seconds, "o", "text"]: "o"
+// is output and "i" input. What was typed is output too, right after its
+// "i" at the same time, since a terminal echoes it.
+type castEvent struct {
+	Time float64
+	Kind string
+	Data string
+}
+
+func (e castEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time, e.Kind, e.Data})
+}
+
+func (e *castEvent) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) != 3 {
+		return fmt.Errorf("an event is [time, kind, data], not %s", data)
+	}
+	if err := json.Unmarshal(fields[0], &e.Time); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &e.Kind); err != nil {
+		return err
+	}
+	return json.Unmarshal(fields[2], &e.Data)
+}
+
+// recorder writes a cast as it happens; input and output come from
+// goroutines of their own.
+type recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	start  time.Time
+	events int
+	err    error
+}
+
+// event adds events, all at the same time from the start. Newlines are
+// written the way a terminal gets them, as \r\n.
+func (r *recorder) event(kinds []string, data string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Since(r.start).Round(time.Microsecond).Seconds()
+	for _, kind := range kinds {
+		if kind == "o" {
+			data = strings.ReplaceAll(data, "\n", "\r\n")
+		}
+		line, _ := json.Marshal(castEvent{now, kind, data})
+		if _, err := fmt.Fprintf(r.w, "%s\n", line); err != nil && r.err == nil {
+			r.err = err
+		}
+		r.events++
+	}
+}
+
+// castOutput is the program's output, shown as it comes and recorded.
+type castOutput struct {
+	w   io.Writer
+	rec *recorder
+}
+
+func (o castOutput) Write(p []byte) (int, error) {
+	o.rec.event([]string{"o"}, string(p))
+	return o.w.Write(p)
+}
+
+func recordCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("record", flag.ContinueOnError)
+	flags.SetOutput(w)
+	out := flags.String("o", "", "the file to record to (default NAME.cast)")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) < 1 {
+		fmt.Fprintln(w, RECORD_USAGE)
+		return 1
+	}
+	e, ok := exercises.Find(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	path := cmp.Or(*out, e.Name()+".cast")
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer f.Close()
+
+	header := castHeader{Version: 2, Width: chart.Width(), Height: 24, Timestamp: time.Now().Unix(), Title: e.ID(), Args: positional[1:],
+		Env: map[string]string{i18n.LANG_ENV: cmp.Or(os.Getenv(i18n.LANG_ENV), "en")}}
+	rec, code, err := record(f, header, bin, e.Path(root), os.Stdin, w)
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Recorded %d events, exit code %d, to %s. journal replay %s plays it.\n", rec.events, code, path, path)
+	return 0
+}
+
+// record runs bin in dir, passing in on to it a line at a time and showing
+// its output on w, and writes it all to cast as it goes. It returns the
+// recorder and the program's exit code.
+func record(cast io.Writer, header castHeader, bin, dir string, in io.Reader, w io.Writer) (*recorder, int, error) {
+	data, _ := json.Marshal(header)
+	if _, err := fmt.Fprintf(cast, "%s\n", data); err != nil {
+		return nil, 0, err
+	}
+	rec := &recorder{w: cast, start: time.Now()}
+	cmd := exec.Command(bin, header.Args...)
+	cmd.Dir = dir
+	cmd.Stdout = castOutput{w, rec}
+	cmd.Stderr = cmd.Stdout
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, 0, err
+	}
+	go func() {
+		// Still waiting on the terminal when the program ends; journal
+		// exits soon after.
+		defer stdin.Close()
+		lines := bufio.NewReader(in)
+		for {
+			line, err := lines.ReadString('\n')
+			if line != "" {
+				rec.event([]string{"i", "o"}, line)
+				if _, err := io.WriteString(stdin, line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		return rec, exit.ExitCode(), rec.err
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return rec, 0, rec.err
+}
+
+func replayCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("replay", flag.ContinueOnError)
+	flags.SetOutput(w)
+	speed := flags.Float64("speed", 1, "play it this many times faster")
+	idle := flags.Duration("idle", 2*time.Second, "the longest pause, however long it was")
+	check := flags.Bool("check", false, "run the problem on the same input and compare its output")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *speed <= 0 {
+		fmt.Fprintln(w, REPLAY_USAGE)
+		return 1
+	}
+	header, events, err := loadCast(positional[0])
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if !*check {
+		replay(w, events, *speed, *idle, time.Sleep)
+		return 0
+	}
+
+	e, ok := exercises.Find(header.Title)
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", header.Title)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	bin, err := e.Build(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return checkCast(w, header, events, bin, e.Path(root))
+}
+
+// loadCast reads an asciicast v2 file.
+func loadCast(path string) (castHeader, []castEvent, error) {
+	var header castHeader
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return header, nil, err
+	}
+	lines := splitLines(string(data))
+	if len(lines) == 0 {
+		return header, nil, fmt.Errorf("%s is empty", path)
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		return header, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if header.Version != 2 {
+		return header, nil, fmt.Errorf("%s: version %d, not asciicast 2", path, header.Version)
+	}
+	var events []castEvent
+	for i, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var e castEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return header, nil, fmt.Errorf("%s:%d: %w", path, i+2, err)
+		}
+		events = append(events, e)
+	}
+	return header, events, nil
+}
+
+// replay writes the output events to w at the pace they were recorded,
+// speed times faster, and with no pause longer than idle.
+func replay(w io.Writer, events []castEvent, speed float64, idle time.Duration, sleep func(time.Duration)) {
+	last := 0.0
+	for _, e := range events {
+		if e.Kind != "o" {
+			continue
+		}
+		pause := time.Duration((e.Time - last) / speed * float64(time.Second))
+		sleep(min(max(pause, 0), idle))
+		last = e.Time
+		fmt.Fprint(w, e.Data)
+	}
+}
+
+// castSession is what a cast says went in and came out, without the echo
+// of what was typed.
+func castSession(events []castEvent) (input, output string) {
+	var in, out strings.Builder
+	for i, e := range events {
+		switch {
+		case e.Kind == "i":
+			in.WriteString(e.Data)
+		case e.Kind == "o" && i > 0 && events[i-1].Kind == "i" && events[i-1].Time == e.Time:
+		case e.Kind == "o":
+			out.WriteString(e.Data)
+		}
+	}
+	return in.String(), strings.ReplaceAll(out.String(), "\r\n", "\n")
+}
+
+// checkCast runs bin on the input a cast recorded, all at once, and
+// compares what it prints now with what it printed then: an old session
+// as a test. It returns 1 if they differ.
+func checkCast(w io.Writer, header castHeader, events []castEvent, bin, dir string) int {
+	input, want := castSession(events)
+	ctx, cancel := context.WithTimeout(context.Background(), TIMEOUT)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin, header.Args...)
+	cmd.Dir = dir
+	// Casts from before there was a language were in English.
+	cmd.Env = append(os.Environ(), i18n.LANG_ENV+"="+cmp.Or(header.Env[i18n.LANG_ENV], "en"))
+	cmd.Stdin = strings.NewReader(input)
+	var out limitedBuffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	err := cmd.Run()
+	var exit *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		fmt.Fprintf(w, ":( %s still running after %v\n", header.Title, TIMEOUT)
+		return 1
+	case err != nil && !errors.As(err, &exit):
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	got := out.String()
+	if got == want {
+		fmt.Fprintf(w, ":) %s prints what it did when it was recorded.\n", header.Title)
+		return 0
+	}
+	fmt.Fprintf(w, ":( %s prints something else now:\n", header.Title)
+	edits := diffLines(splitLines(want), splitLines(got))
+	printDiff(w, unifiedFrom("--- recorded\n+++ now\n", edits, 1, 1), isTerminal(w))
+	if strings.TrimSpace(want) == strings.TrimSpace(got) {
+		fmt.Fprintln(w, "Only the whitespace at the ends differs.")
+	}
+	return 1
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"exercises"
+)
+
+const RUN_USAGE = "Usage: journal run WEEK/PROBLEM [-stdin FILE] [-expect FILE] [-trim] [-o FILE] [-timeout D] [-args ARGS...]"
+
+// runResult is what a run printed, to standard output and error both, and
+// how it ended.
+type runResult struct {
+	Output   string
+	Code     int
+	TimedOut bool
+}
+
+func runCommand(args []string, w io.Writer) int {
+	// Everything after -args is the exercise's, flags too.
+	var exerciseArgs []string
+	if i := slices.IndexFunc(args, func(arg string) bool { return arg == "-args" || arg == "--args" }); i >= 0 {
+		args, exerciseArgs = args[:i], args[i+1:]
+	}
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(w)
+	stdin := flags.String("stdin", "", "a file to pipe in as standard input, instead of nothing")
+	expect := flags.String("expect", "", "a file the output should be, with a diff when it isn't")
+	trim := flags.Bool("trim", false, "ignore whitespace at the ends of lines, and blank lines at the end, for -expect")
+	out := flags.String("o", "", "save the output to this file, to start an expected one from")
+	timeout := flags.Duration("timeout", TIMEOUT, "how long it may run")
+	color := flags.Bool("color", isTerminal(w), "colour the diff")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *timeout <= 0 {
+		fmt.Fprintln(w, RUN_USAGE)
+		return 1
+	}
+	e, ok := exercises.Lookup(positional[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", positional[0])
+		return 1
+	}
+	// A program runs in its folder, like its checks, and the files named
+	// here can be from there too: -stdin testdata/book.txt.
+	dir := ""
+	if p, ok := e.(exercises.Program); ok {
+		root, err := exercises.Root()
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		dir = p.Path(root)
+	}
+
+	var input io.Reader = strings.NewReader("")
+	if *stdin != "" {
+		f, err := os.Open(fixturePath(*stdin, dir))
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		defer f.Close()
+		input = f
+	}
+	var want []byte
+	if *expect != "" {
+		if want, err = os.ReadFile(fixturePath(*expect, dir)); err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+	}
+
+	result, err := runExercise(e, dir, exerciseArgs, input, *timeout)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprint(w, result.Output)
+	if result.Output != "" && !strings.HasSuffix(result.Output, "\n") {
+		fmt.Fprintln(w)
+	}
+	if result.TimedOut {
+		fmt.Fprintf(w, "[still running after %v, so stopped]\n", *timeout)
+	} else {
+		fmt.Fprintf(w, "[exit %d]\n", result.Code)
+	}
+	if *out != "" {
+		if err := os.WriteFile(*out, []byte(result.Output), 0o644); err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		fmt.Fprintf(w, "Saved the output to %s.\n", *out)
+	}
+	if *expect == "" {
+		return 0
+	}
+	return expectOutput(w, *expect, string(want), result.Output, *trim, *color)
+}
+
+// fixturePath is path as given when it's there, or else from dir, the
+// problem's folder.
+func fixturePath(path, dir string) string {
+	if _, err := os.Stat(path); err == nil || dir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// runExercise runs e in dir, when it's a program, with input, and keeps
+// what it prints. An exit code is a result, not an error; an error is
+// that it couldn't run, like a program that doesn't build.
+func runExercise(e exercises.Exercise, dir string, args []string, input io.Reader, timeout time.Duration) (runResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var out limitedBuffer
+	err := e.Run(ctx, exercises.IO{Args: args, Dir: dir, Stdin: input, Stdout: &out, Stderr: &out})
+	result := runResult{Output: out.String()}
+	var exit interface{ ExitCode() int }
+	switch {
+	case ctx.Err() != nil:
+		result.TimedOut = true
+	case errors.As(err, &exit):
+		result.Code = exit.ExitCode()
+	case err != nil:
+		return result, err
+	}
+	return result, nil
+}
+
+// expectOutput compares got with want, the file expected's, the way grade
+// does, and shows a diff when they differ, returning 1.
+func expectOutput(w io.Writer, expected, want, got string, trim, color bool) int {
+	problem := compareOutput(want, got, gradeOptions{Trim: trim})
+	if problem == "" {
+		fmt.Fprintf(w, ":) the output is %s\n", expected)
+		return 0
+	}
+	fmt.Fprintf(w, ":( the output isn't %s\n    %s\n", expected, problem)
+	if !looksBinary([]byte(want)) && !looksBinary([]byte(got)) {
+		edits := diffLines(normalize(want, trim), normalize(got, trim))
+		printDiff(w, unifiedFrom("--- "+expected+"\n+++ output\n", edits, 1, 1), color)
+	}
+	return 1
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// KINDS are the skeletons scaffold can make, one directory each under
+// skeletons/.
+var KINDS = []string{"webapp", "cli", "api"}
+
+// GO_VERSION goes in the new go.mod, the version the psets use.
+const GO_VERSION = "1.24.4"
+
+// NAME_PLACEHOLDER in a skeleton's file name becomes the project's name.
+const NAME_PLACEHOLDER = "__name__"
+
+// Skeleton files end in .tmpl so the Go files among them aren't compiled
+// into journal, and their go.mod doesn't cut them out of this module. all:
+// keeps the .gitignore files.
+//
+//go:embed all:skeletons
+var skeletons embed.FS
+
+// validName is a name that works as a directory, a module path and a
+// command: lower case letters, digits and dashes.
+var validName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// project is what skeleton templates see. They use [[ ]] instead of {{ }}
+// so the web app's own html/templates pass through untouched.
+type project struct {
+	Name      string // priority-queue
+	Title     string // Priority Queue
+	Type      string // PriorityQueue, for Go names
+	Receiver  string // p
+	GoVersion string
+	Week      int // for exercises
+}
+
+func newProject(name string) project {
+	typ := strings.ReplaceAll(title(name), " ", "")
+	return project{Name: name, Title: title(name), Type: typ, Receiver: strings.ToLower(typ[:1]), GoVersion: GO_VERSION}
+}
+
+func scaffoldCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	flags.SetOutput(w)
+	dir := flags.String("dir", ".", "directory to create the project in")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 2 {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+
+	kind, name := positional[0], positional[1]
+	root := filepath.Join(*dir, name)
+	files, err := scaffold(kind, name, root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+	fmt.Fprintf(w, "Created %s %s:\n", kind, root)
+	for _, file := range files {
+		fmt.Fprintf(w, "    %s\n", file)
+	}
+	switch work, err := joinWorkspace(root); {
+	case err != nil:
+		fmt.Fprintf(w, "Couldn't add it to the workspace, run go work use yourself: %v\n", err)
+	case work != "":
+		fmt.Fprintf(w, "Added it to %s.\n", work)
+	}
+	fmt.Fprintf(w, "\nNext:\n    cd %s\n    go generate && go test . && go run .\n", root)
+	return 0
+}
+
+// joinWorkspace adds the module at root to the go.work above it, if there
+// is one, and returns that go.work: inside a workspace, a module it
+// doesn't list can't even build.
+func joinWorkspace(root string) (string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	for dir := filepath.Dir(root); ; dir = filepath.Dir(dir) {
+		work := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(work); err == nil {
+			rel, err := filepath.Rel(dir, root)
+			if err != nil {
+				return "", err
+			}
+			cmd := exec.Command("go", "work", "use", rel)
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return "", fmt.Errorf("%v: %s", err, bytes.TrimSpace(out))
+			}
+			return work, nil
+		}
+		if filepath.Dir(dir) == dir {
+			return "", nil
+		}
+	}
+}
+
+// scaffold writes the kind skeleton for a project called name into root,
+// which mustn't exist yet, and returns the files it wrote.
+func scaffold(kind, name, root string) ([]string, error) {
+	if !slices.Contains(KINDS, kind) {
+		return nil, fmt.Errorf("unknown kind %q (want %s)", kind, strings.Join(KINDS, ", "))
+	}
+	if err := checkNew(name, root); err != nil {
+		return nil, err
+	}
+	return writeSkeleton(path.Join("skeletons", kind), newProject(name), root)
+}
+
+// checkNew makes sure name is a valid name and root is free for it.
+func checkNew(name, root string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid name %q: use lower case letters, digits and dashes, starting with a letter", name)
+	}
+	if _, err := os.Stat(root); err == nil {
+		return fmt.Errorf("%s already exists", root)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// writeSkeleton renders every file in skeleton for p into root, and
+// returns the files it wrote.
+func writeSkeleton(skeleton string, p project, root string) ([]string, error) {
+	var files []string
+	err := fs.WalkDir(skeletons, skeleton, func(src string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel := strings.TrimSuffix(strings.TrimPrefix(src, skeleton+"/"), ".tmpl")
+		rel = strings.ReplaceAll(rel, NAME_PLACEHOLDER, p.Name)
+		content, err := render(src, p)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, content, 0644); err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		os.RemoveAll(root) // don't leave half a project behind
+		return nil, err
+	}
+	return files, nil
+}
+
+// render executes one skeleton file.
+func render(src string, p project) ([]byte, error) {
+	text, err := fs.ReadFile(skeletons, src)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(src).Delims("[[", "]]").Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// title turns "word-count" into "Word Count".
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// parseInterspersed lets flags come after the name too ("scaffold cli
+// wc -dir ~/final"); the flag package stops at the first argument that
+// isn't a flag.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"exercises"
+)
+
+const SEARCH_USAGE = "Usage: journal search WORDS... [-n N] [-color] [-rebuild]"
+
+// INDEX_FILE is where the search index is kept, under the data directory.
+const INDEX_FILE = "journal/search-index.json"
+
+// INDEXED_EXTENSIONS are the files searched: the solutions and the notes.
+var INDEXED_EXTENSIONS = []string{".go", ".md", ".txt"}
+
+// LINES_PER_FILE is how many matching lines are shown under each file.
+const LINES_PER_FILE = 3
+
+// searchIndex is an inverted index of the repo: for every word, the files
+// it's in and the lines it's on. It's kept on disk and brought up to date
+// before each search, reading again only the files that changed.
+type searchIndex struct {
+	Root  string                      `json:"root"`
+	Files map[string]indexedFile      `json:"files"` // by path, with forward slashes
+	Terms map[string]map[string][]int `json:"terms"` // word → file → line numbers, once per use
+}
+
+// indexedFile is what the index knows about a file: enough to tell it's
+// changed, and its length in words for ranking.
+type indexedFile struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Words   int       `json:"words"`
+}
+
+// searchResult is a file that matches, and its best lines.
+type searchResult struct {
+	File  string
+	Score float64
+	Lines []int
+}
+
+func searchCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(w)
+	limit := flags.Int("n", 10, "show at most this many files")
+	color := flags.Bool("color", isTerminal(w), "highlight the words")
+	rebuild := flags.Bool("rebuild", false, "index every file again")
+	words, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	query := tokenize(strings.Join(words, " "))
+	if len(query) == 0 || *limit < 1 {
+		fmt.Fprintln(w, SEARCH_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+
+	index := &searchIndex{}
+	if !*rebuild {
+		if index, err = loadIndex(); err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+	}
+	changed, err := index.update(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if changed > 0 {
+		if err := index.save(); err != nil {
+			fmt.Fprintln(w, "Couldn't save the index:", err)
+		}
+	}
+
+	results := index.search(query)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "Nothing has all of %s.\n", strings.Join(query, ", "))
+		return 1
+	}
+	highlighter := termPattern(query)
+	for i, r := range results[:min(*limit, len(results))] {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		lines, err := readLines(filepath.Join(root, filepath.FromSlash(r.File)))
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		for _, n := range r.Lines {
+			if n > len(lines) {
+				continue // changed since it was indexed
+			}
+			text := strings.TrimSpace(lines[n-1])
+			if *color {
+				text = highlighter.ReplaceAllString(text, RED+"$0"+RESET)
+			}
+			fmt.Fprintf(w, "%s:%d: %s\n", r.File, n, text)
+		}
+	}
+	if len(results) > *limit {
+		fmt.Fprintf(w, "\n%d more files; -n %d for them all.\n", len(results)-*limit, len(results))
+	}
+	return 0
+}
+
+// loadIndex reads the index file, or starts an empty index without one.
+func loadIndex() (*searchIndex, error) {
+	index := &searchIndex{}
+	path, err := dataPath(INDEX_FILE)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		// It's only a cache: a broken one is built again.
+		return &searchIndex{}, nil
+	}
+	return index, nil
+}
+
+// save writes the index file.
+func (index *searchIndex) save() error {
+	path, err := dataPath(INDEX_FILE)
+	if err != nil {
+		return err
+	}
+	return saveJSON(path, index)
+}
+
+// update brings the index up to date with the files under root, and says
+// how many it had to add, read again or drop.
+func (index *searchIndex) update(root string) (int, error) {
+	if index.Root != root || index.Files == nil {
+		*index = searchIndex{Root: root, Files: map[string]indexedFile{}, Terms: map[string]map[string][]int{}}
+	}
+	seen := map[string]bool{}
+	changed := 0
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(name, ".") || name == "testdata" || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !slices.Contains(INDEXED_EXTENSIONS, filepath.Ext(name)) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > MAX_SOURCE {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		seen[rel] = true
+		if old, ok := index.Files[rel]; ok && old.ModTime.Equal(info.ModTime()) && old.Size == info.Size() {
+			return nil
+		}
+		lines, err := readLines(p)
+		if err != nil {
+			return err
+		}
+		index.remove(rel)
+		index.add(rel, lines, indexedFile{ModTime: info.ModTime(), Size: info.Size()})
+		changed++
+		return nil
+	})
+	for file := range index.Files {
+		if !seen[file] {
+			index.remove(file)
+			changed++
+		}
+	}
+	return changed, err
+}
+
+// add indexes the lines of file.
+func (index *searchIndex) add(file string, lines []string, f indexedFile) {
+	for i, line := range lines {
+		for _, term := range tokenize(line) {
+			if index.Terms[term] == nil {
+				index.Terms[term] = map[string][]int{}
+			}
+			index.Terms[term][file] = append(index.Terms[term][file], i+1)
+			f.Words++
+		}
+	}
+	index.Files[file] = f
+}
+
+// remove takes file out of the index.
+func (index *searchIndex) remove(file string) {
+	if _, ok := index.Files[file]; !ok {
+		return
+	}
+	delete(index.Files, file)
+	for term, files := range index.Terms {
+		delete(files, file)
+		if len(files) == 0 {
+			delete(index.Terms, term)
+		}
+	}
+}
+
+// search ranks the files with every term in query by tf-idf: a word counts
+// for more the more often a file uses it, for less the more files use it,
+// and a long file needs more uses than a short one. A file with all the
+// words on one line counts double, since that's usually the explanation
+// being looked for. Each result has the first lines with the most of the
+// words.
+func (index *searchIndex) search(query []string) []searchResult {
+	query = slices.Compact(slices.Sorted(slices.Values(query)))
+	var results []searchResult
+	for file, f := range index.Files {
+		score := 0.0
+		hits := map[int]int{} // line → how many of the words are on it
+		for _, term := range query {
+			lines := index.Terms[term][file]
+			if len(lines) == 0 {
+				score = -1
+				break
+			}
+			idf := math.Log(1 + float64(len(index.Files))/float64(len(index.Terms[term])))
+			tf := 1 + math.Log(float64(len(lines)))
+			score += tf * idf / math.Sqrt(float64(max(f.Words, 1)))
+			for _, line := range slices.Compact(slices.Clone(lines)) {
+				hits[line]++
+			}
+		}
+		if score < 0 {
+			continue
+		}
+		best := slices.SortedFunc(maps.Keys(hits), func(a, b int) int { return cmp.Or(cmp.Compare(hits[b], hits[a]), cmp.Compare(a, b)) })
+		if len(query) > 1 && hits[best[0]] == len(query) {
+			score *= 2
+		}
+		// The lines to show are those with as many of the words as any.
+		most := hits[best[0]]
+		best = slices.DeleteFunc(best, func(line int) bool { return hits[line] < most })
+		best = best[:min(LINES_PER_FILE, len(best))]
+		slices.Sort(best)
+		results = append(results, searchResult{file, score, best})
+	}
+	slices.SortFunc(results, func(a, b searchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), strings.Compare(a.File, b.File))
+	})
+	return results
+}
+
+// tokenize splits text into the words the index is made of: lower case
+// runs of letters and digits, two or more long. Code's camelCase and
+// snake_case names are their parts as well as the whole, so "linked list"
+// finds LinkedList and linked_list.
+func tokenize(text string) []string {
+	var terms []string
+	isWordRune := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' }
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !isWordRune(r) }) {
+		parts := splitIdentifier(word)
+		if len(parts) > 1 {
+			parts = append(parts, strings.ReplaceAll(word, "_", ""))
+		}
+		for _, part := range parts {
+			if part = strings.ToLower(part); len([]rune(part)) >= 2 {
+				terms = append(terms, part)
+			}
+		}
+	}
+	return terms
+}
+
+// splitIdentifier splits camelCase and snake_case names into their words:
+// "parseHTTPRequest" is parse, HTTP and Request.
+func splitIdentifier(word string) []string {
+	var parts []string
+	for _, piece := range strings.Split(word, "_") {
+		runes := []rune(piece)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			lowerToUpper := unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i])
+			acronymEnd := i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i+1])
+			if lowerToUpper || acronymEnd {
+				parts = append(parts, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < len(runes) {
+			parts = append(parts, string(runes[start:]))
+		}
+	}
+	return parts
+}
+
+// termPattern matches the query's words in a line, to highlight them.
+func termPattern(query []string) *regexp.Regexp {
+	quoted := make([]string, len(query))
+	for i, term := range query {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	slices.SortFunc(quoted, func(a, b string) int { return len(b) - len(a) })
+	return regexp.MustCompile(`(?i)` + strings.Join(quoted, "|"))
+}
+
+// readLines reads a file's lines.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, MAX_SOURCE)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,582 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"exercises"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const SPEC_USAGE = "Usage: journal spec WEEK/PROBLEM [-year N] [-url URL] [-refresh]"
+
+// SPEC_URL is where CS50x keeps a problem's spec: the year, the week and
+// the problem.
+const SPEC_URL = "https://cs50.harvard.edu/x/%d/psets/%d/%s/"
+
+// SPEC_SLUGS are the problems whose spec isn't at their own name, the ones
+// that come in a less and a more comfortable version.
+var SPEC_SLUGS = map[string]string{
+	"mario":  "mario/less",
+	"filter": "filter/less",
+}
+
+// ZIP_PATTERN finds the distribution code in a spec, which links it or
+// wgets it in a code block.
+var ZIP_PATTERN = regexp.MustCompile(`https?://[^\s"'<>()]+\.zip\b`)
+
+// MAX_DOWNLOAD is the most spec fetches or unzips into one file; speller's
+// texts are the biggest, at a few MB.
+const MAX_DOWNLOAD = 100 << 20
+
+func specCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("spec", flag.ContinueOnError)
+	flags.SetOutput(w)
+	year := flags.Int("year", time.Now().Year(), "the year of CS50x whose spec to fetch")
+	from := flags.String("url", "", "fetch the spec from here instead")
+	refresh := flags.Bool("refresh", false, "fetch it all again instead of using the cached copies")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, SPEC_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	// A new pset isn't one of the PROGRAMS yet: it goes where new would put it.
+	var week int
+	var name, dir string
+	if e, ok := exercises.Find(positional[0]); ok {
+		week, name, dir = e.Week(), e.Name(), e.Path(root)
+	} else if n, _ := fmt.Sscanf(strings.Replace(positional[0], "/", " ", 1), "week%d %s", &week, &name); n == 2 && week >= 0 {
+		dir = filepath.Join(weekDir(root, week), name)
+	} else {
+		fmt.Fprintln(w, SPEC_USAGE)
+		return 1
+	}
+
+	page := cmp.Or(*from, specURL(*year, week, name))
+	base, err := url.Parse(page)
+	if err != nil || base.Host == "" {
+		fmt.Fprintf(w, "%q isn't a URL.\n", page)
+		return 1
+	}
+	cache, err := dataPath(path.Join("journal", "specs", strings.ReplaceAll(base.Host, ":", "_"), base.Path))
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	client := &http.Client{Timeout: time.Minute}
+	data, err := fetchCached(w, client, page, filepath.Join(cache, "index.html"), *refresh)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintf(w, "%s: %v\n", page, err)
+		return 2
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	rel, _ := filepath.Rel(root, dir)
+
+	spec := filepath.Join(dir, "problem.md")
+	markdown := htmlToMarkdown(doc, base)
+	if _, err := os.Stat(spec); err == nil {
+		fmt.Fprintf(w, "Kept your %s; the spec is in %s\n", filepath.Join(rel, "problem.md"), filepath.Join(cache, "index.html"))
+	} else if err := os.WriteFile(spec, []byte(markdown), 0644); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	} else {
+		lines := strings.Count(markdown, "\n")
+		fmt.Fprintf(w, "Wrote %s, %d line%s.\n", filepath.Join(rel, "problem.md"), lines, plural(lines))
+	}
+
+	for _, link := range distributionLinks(data) {
+		archive := filepath.Join(cache, path.Base(link))
+		if _, err := fetchCached(w, client, link, archive, *refresh); err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		written, kept, err := unzipInto(archive, dir)
+		if err != nil {
+			fmt.Fprintf(w, "%s: %v\n", path.Base(link), err)
+			return 2
+		}
+		fmt.Fprintf(w, "Unzipped %d file%s from %s into %s", len(written), plural(len(written)), path.Base(link), rel)
+		if len(kept) > 0 {
+			fmt.Fprintf(w, "; kept your %s", strings.Join(kept, ", "))
+		}
+		fmt.Fprintln(w, ".")
+	}
+	return 0
+}
+
+// specURL is where CS50x keeps the spec for a problem in a year.
+func specURL(year, week int, name string) string {
+	return fmt.Sprintf(SPEC_URL, year, week, cmp.Or(SPEC_SLUGS[name], name))
+}
+
+// fetchCached returns what's at link, from path when it's been fetched
+// before and refresh isn't set, and otherwise fetched and kept at path.
+func fetchCached(w io.Writer, client *http.Client, link, path string, refresh bool) ([]byte, error) {
+	if !refresh {
+		if info, err := os.Stat(path); err == nil {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				fmt.Fprintf(w, "Using %s, fetched %s (-refresh fetches it again).\n", link, info.ModTime().Format("Jan 2"))
+			}
+			return data, err
+		}
+	}
+	response, err := client.Get(link)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", link, response.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, MAX_DOWNLOAD+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", link, err)
+	}
+	if len(data) > MAX_DOWNLOAD {
+		return nil, fmt.Errorf("%s: more than %d MB", link, MAX_DOWNLOAD>>20)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(w, "Fetched %s\n", link)
+	return data, os.Rename(tmp, path)
+}
+
+// distributionLinks are the zip files a spec links to or downloads, each once.
+func distributionLinks(page []byte) []string {
+	var links []string
+	for _, link := range ZIP_PATTERN.FindAllString(html.UnescapeString(string(page)), -1) {
+		if !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// unzipInto unzips archive into dir, without the folder the whole archive
+// is in (speller.zip's speller/), and returns the files it wrote and the
+// ones it didn't because dir already has them: an existing file is never
+// overwritten.
+func unzipInto(archive, dir string) (written, kept []string, err error) {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	var files []*zip.File
+	for _, f := range r.File {
+		if !f.FileInfo().IsDir() && !strings.HasPrefix(f.Name, "__MACOSX/") {
+			files = append(files, f)
+		}
+	}
+	top := ""
+	if len(files) > 0 {
+		if first, _, ok := strings.Cut(files[0].Name, "/"); ok {
+			top = first + "/"
+		}
+		for _, f := range files {
+			if !strings.HasPrefix(f.Name, top) {
+				top = ""
+				break
+			}
+		}
+	}
+
+	for _, f := range files {
+		name := strings.TrimPrefix(f.Name, top)
+		if !filepath.IsLocal(f.Name) || !filepath.IsLocal(name) {
+			return written, kept, fmt.Errorf("%q is outside the folder", f.Name)
+		}
+		dest := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(dest); err == nil {
+			kept = append(kept, name)
+			continue
+		}
+		if err := unzipFile(f, dest); err != nil {
+			return written, kept, err
+		}
+		written = append(written, name)
+	}
+	return written, kept, nil
+}
+
+// unzipFile writes one file from a zip to dest.
+func unzipFile(f *zip.File, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	in, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, f.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(out, io.LimitReader(in, MAX_DOWNLOAD+1))
+	if err == nil && n > MAX_DOWNLOAD {
+		err = fmt.Errorf("%s: more than %d MB", f.Name, MAX_DOWNLOAD>>20)
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dest)
+	}
+	return err
+}
+
+// htmlToMarkdown turns a spec page into Markdown: the headings, paragraphs,
+// lists, tables, quotes and code of its <main>, or the whole body without
+// one, with links made absolute against base. It's the HTML CS50's specs
+// use, the other way round from renderMarkdown.
+func htmlToMarkdown(doc *html.Node, base *url.URL) string {
+	content := findElement(doc, atom.Main)
+	if content == nil {
+		content = cmp.Or(findElement(doc, atom.Body), doc)
+	}
+	return strings.Join(markdownBlocks(content, base), "\n\n") + "\n"
+}
+
+// findElement is the first element of kind a in n, depth first.
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// markdownBlocks converts n's children to Markdown blocks, gathering runs
+// of inline content into paragraphs.
+func markdownBlocks(n *html.Node, base *url.URL) []string {
+	var blocks []string
+	var paragraph strings.Builder
+	add := func(block string) {
+		if block = strings.TrimSpace(block); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	endParagraph := func() {
+		add(tidyInline(paragraph.String()))
+		paragraph.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			appendInline(&paragraph, markdownInline(c, base))
+			continue
+		}
+		switch c.DataAtom {
+		case atom.Script, atom.Style, atom.Nav, atom.Footer, atom.Noscript, atom.Template, atom.Button, atom.Form:
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			endParagraph()
+			level, _ := strconv.Atoi(c.Data[1:])
+			if heading := tidyInline(markdownChildren(c, base)); heading != "" {
+				add(strings.Repeat("#", level) + " " + heading)
+			}
+		case atom.P:
+			endParagraph()
+			add(tidyInline(markdownChildren(c, base)))
+		case atom.Summary:
+			endParagraph()
+			add(wrap(tidyInline(markdownChildren(c, base)), "**", "**"))
+		case atom.Ul, atom.Ol:
+			endParagraph()
+			add(markdownList(c, base))
+		case atom.Pre:
+			endParagraph()
+			add("```" + codeLanguage(c) + "\n" + strings.Trim(textContent(c), "\n") + "\n```")
+		case atom.Blockquote:
+			endParagraph()
+			quote := strings.Split(strings.Join(markdownBlocks(c, base), "\n\n"), "\n")
+			for i, line := range quote {
+				quote[i] = strings.TrimRight("> "+line, " ")
+			}
+			add(strings.Join(quote, "\n"))
+		case atom.Hr:
+			endParagraph()
+			add("---")
+		case atom.Table:
+			endParagraph()
+			add(markdownTable(c, base))
+		case atom.Iframe:
+			// The videos: a link to them is all Markdown can do.
+			endParagraph()
+			if src := attribute(c, "src"); src != "" {
+				add("<" + resolve(base, src) + ">")
+			}
+		case atom.Div, atom.Section, atom.Article, atom.Main, atom.Header, atom.Details, atom.Figure, atom.Aside, atom.Li, atom.Dl, atom.Dd, atom.Dt:
+			endParagraph()
+			blocks = append(blocks, markdownBlocks(c, base)...)
+		default:
+			appendInline(&paragraph, markdownInline(c, base))
+		}
+	}
+	endParagraph()
+	return blocks
+}
+
+// markdownInline converts text and inline elements, with whitespace
+// collapsed the way a browser shows it.
+func markdownInline(n *html.Node, base *url.URL) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseSpace(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+	inner := func() string { return markdownChildren(n, base) }
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Button:
+		return ""
+	case atom.Br:
+		return "\\\n"
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		code := strings.TrimSpace(collapseSpace(textContent(n)))
+		if code == "" {
+			return ""
+		}
+		fence := "`"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			code = " " + code + " "
+		}
+		return fence + code + fence
+	case atom.Strong, atom.B:
+		return wrap(inner(), "**", "**")
+	case atom.Em, atom.I:
+		return wrap(inner(), "*", "*")
+	case atom.A:
+		text, href := inner(), attribute(n, "href")
+		if href == "" || strings.HasPrefix(href, "#") {
+			return text
+		}
+		if strings.TrimSpace(text) == "" {
+			return "<" + resolve(base, href) + ">"
+		}
+		return wrap(text, "[", "]("+resolve(base, href)+")")
+	case atom.Img:
+		return "![" + attribute(n, "alt") + "](" + resolve(base, attribute(n, "src")) + ")"
+	}
+	return inner()
+}
+
+// markdownChildren is the inline Markdown of all of n's children.
+func markdownChildren(n *html.Node, base *url.URL) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		appendInline(&b, markdownInline(c, base))
+	}
+	return b.String()
+}
+
+// appendInline adds an inline piece to b without doubling the space
+// between them, as a browser would show them.
+func appendInline(b *strings.Builder, piece string) {
+	if strings.HasPrefix(piece, " ") && (b.Len() == 0 || strings.HasSuffix(b.String(), " ") || strings.HasSuffix(b.String(), "\n")) {
+		piece = piece[1:]
+	}
+	b.WriteString(piece)
+}
+
+// collapseSpace turns each run of whitespace in text into one space.
+func collapseSpace(text string) string {
+	collapsed := strings.Join(strings.FieldsFunc(text, isSpace), " ")
+	if text != "" && isSpace(rune(text[0])) {
+		collapsed = " " + collapsed
+	}
+	if collapsed != " " && text != "" && isSpace(rune(text[len(text)-1])) {
+		collapsed += " "
+	}
+	return collapsed
+}
+
+// wrap puts open and close around text, outside any space at its ends,
+// since "** bold **" isn't bold.
+func wrap(text, open, close string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	i := strings.Index(text, trimmed)
+	return text[:i] + open + trimmed + close + text[i+len(trimmed):]
+}
+
+// tidyInline trims the spaces at the ends of each line and the whole.
+func tidyInline(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// markdownList converts a <ul> or <ol>, with what's inside each item
+// indented under its marker, nested lists too.
+func markdownList(n *html.Node, base *url.URL) string {
+	var items []string
+	number, _ := strconv.Atoi(cmp.Or(attribute(n, "start"), "1"))
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		lines := strings.Split(strings.Join(markdownBlocks(c, base), "\n"), "\n")
+		for i, line := range lines {
+			if i > 0 && line != "" {
+				lines[i] = strings.Repeat(" ", len(marker)) + line
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+// markdownTable converts a table to a pipe table, its first row the header.
+func markdownTable(n *html.Node, base *url.URL) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom != atom.Tr {
+				walk(c)
+				continue
+			}
+			var row []string
+			for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.DataAtom == atom.Td || cell.DataAtom == atom.Th {
+					text := strings.ReplaceAll(tidyInline(markdownChildren(cell, base)), "\\\n", " ")
+					row = append(row, strings.ReplaceAll(text, "|", `\|`))
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(row, " | "))
+		if i == 0 {
+			fmt.Fprintf(&b, "|%s\n", strings.Repeat(" --- |", columns))
+		}
+	}
+	return b.String()
+}
+
+// codeLanguage is the language of a <pre>, from a language-X class on it,
+// its <code> or the <div> Jekyll wraps it in.
+func codeLanguage(pre *html.Node) string {
+	nodes := []*html.Node{pre}
+	if code := findElement(pre, atom.Code); code != nil {
+		nodes = append(nodes, code)
+	}
+	for p := pre.Parent; p != nil && p.DataAtom == atom.Div; p = p.Parent {
+		nodes = append(nodes, p)
+	}
+	for _, n := range nodes {
+		for _, class := range strings.Fields(attribute(n, "class")) {
+			if language, ok := strings.CutPrefix(class, "language-"); ok {
+				return language
+			}
+		}
+	}
+	return ""
+}
+
+// textContent is all the text in n, as it is.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+// attribute is the value of n's attribute key, or "".
+func attribute(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// resolve makes a link absolute against base, leaving it be if it's not a URL.
+func resolve(base *url.URL, link string) string {
+	u, err := base.Parse(link)
+	if err != nil {
+		return link
+	}
+	return u.String()
+}
+
+// isSpace is HTML's whitespace.
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f'
+}
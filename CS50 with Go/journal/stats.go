@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"chart"
+	"exercises"
+)
+
+const STATS_USAGE = "Usage: journal stats [-weeks N]"
+
+// TEST_PATTERN is how a test, fuzz test or benchmark starts in a _test.go file.
+var TEST_PATTERN = regexp.MustCompile(`^func (Test|Fuzz|Benchmark)[A-Z0-9_]\w*\(`)
+
+// weekCode is how much Go is in one of the course's weeks now.
+type weekCode struct {
+	Week      int
+	Lines     int // in every .go file, tests too
+	TestLines int
+	Tests     int
+}
+
+// activity is what git knows about the work: the Go lines added each day,
+// and the days with any commit at all.
+type activity struct {
+	Added map[string]int // by day, "2006-01-02"
+	Days  map[string]bool
+}
+
+func statsCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	flags.SetOutput(w)
+	weeks := flags.Int("weeks", 8, "how many calendar weeks of history to chart")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 0 || *weeks < 1 {
+		fmt.Fprintln(w, STATS_USAGE)
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	p, err := loadProgress()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	code, err := countCode(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	history, err := gitActivity(root)
+	if err != nil {
+		// Stats without git are still stats: the code and the progress.
+		fmt.Fprintf(w, "No git history (%v); only what's in the tree and the progress.\n\n", err)
+	}
+	printStats(w, history, code, p, time.Now(), *weeks, chart.Width())
+	return 0
+}
+
+// gitActivity reads the log of the repo root is in, without merges.
+func gitActivity(root string) (activity, error) {
+	history := activity{Added: map[string]int{}, Days: map[string]bool{}}
+	cmd := exec.Command("git", "log", "--no-merges", "--numstat", "--format=@%aI", "--", ".")
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && len(exit.Stderr) > 0 {
+			return history, fmt.Errorf("%s", bytes.TrimSpace(exit.Stderr))
+		}
+		return history, err
+	}
+	day := ""
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if date, ok := strings.CutPrefix(line, "@"); ok {
+			t, err := time.Parse(time.RFC3339, date)
+			if err != nil {
+				return history, fmt.Errorf("git log: %q isn't a date", date)
+			}
+			day = t.Format(time.DateOnly)
+			history.Days[day] = true
+			continue
+		}
+		// added, deleted and the path; binary files have - for both.
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || !strings.HasSuffix(strings.TrimRight(fields[2], "}"), ".go") {
+			continue
+		}
+		if added, err := strconv.Atoi(fields[0]); err == nil {
+			history.Added[day] += added
+		}
+	}
+	return history, scanner.Err()
+}
+
+// countCode counts the Go in each week of the course as it is now.
+func countCode(root string) ([]weekCode, error) {
+	var weeks []weekCode
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		m := WEEK_PATTERN.FindStringSubmatch(entry.Name())
+		if m == nil || !entry.IsDir() {
+			continue
+		}
+		week, _ := strconv.Atoi(m[1])
+		code := weekCode{Week: week}
+		err := filepath.WalkDir(filepath.Join(root, entry.Name()), func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if name := d.Name(); strings.HasPrefix(name, ".") || name == "testdata" || name == "vendor" || name == "node_modules" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(p) != ".go" {
+				return nil
+			}
+			lines, err := readLines(p)
+			if err != nil {
+				return err
+			}
+			code.Lines += len(lines)
+			if strings.HasSuffix(p, "_test.go") {
+				code.TestLines += len(lines)
+				for _, line := range lines {
+					if TEST_PATTERN.MatchString(line) {
+						code.Tests++
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Two folders can both be a week, like week1-C and week1: count them once.
+		if i := slices.IndexFunc(weeks, func(c weekCode) bool { return c.Week == week }); i >= 0 {
+			weeks[i].Lines += code.Lines
+			weeks[i].TestLines += code.TestLines
+			weeks[i].Tests += code.Tests
+			continue
+		}
+		weeks = append(weeks, code)
+	}
+	slices.SortFunc(weeks, func(a, b weekCode) int { return a.Week - b.Week })
+	return weeks, nil
+}
+
+// printStats writes the charts and the totals, as of now.
+func printStats(w io.Writer, history activity, code []weekCode, p progress, now time.Time, weeks, width int) {
+	if len(history.Days) > 0 {
+		fmt.Fprintf(w, "Go written, by week: lines added, from git\n")
+		monday := now.AddDate(0, 0, -(int(now.Weekday())+6)%7)
+		var bars []chart.Bar
+		for i := weeks - 1; i >= 0; i-- {
+			start := monday.AddDate(0, 0, -7*i)
+			added := 0
+			for d := range 7 {
+				added += history.Added[start.AddDate(0, 0, d).Format(time.DateOnly)]
+			}
+			bars = append(bars, chart.Bar{Label: start.Format("Jan 2"), Value: added})
+		}
+		chart.Fprint(w, bars, width)
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "Go in the repo, by CS50 week: lines, and tests")
+	var bars []chart.Bar
+	lines, tests := 0, 0
+	for _, c := range code {
+		bars = append(bars, chart.Bar{Label: fmt.Sprintf("week %d", c.Week), Value: c.Lines,
+			Note: fmt.Sprintf("%d test%s in %d lines", c.Tests, plural(c.Tests), c.TestLines)})
+		lines += c.Lines
+		tests += c.Tests
+	}
+	chart.Fprint(w, bars, width-10)
+	fmt.Fprintf(w, "%d lines of Go, %d tests.\n\n", lines, tests)
+
+	checks, done, sessions := 0, 0, 0
+	var spent time.Duration
+	days := map[string]bool{}
+	for day := range history.Days {
+		days[day] = true
+	}
+	for _, pp := range p {
+		checks += pp.Checked
+		if pp.Status == CHECKED || pp.Status == COMPLETED {
+			done++
+		}
+		for _, s := range pp.Sessions {
+			spent += s.length(now)
+			days[s.Start.Format(time.DateOnly)] = true
+		}
+		sessions += len(pp.Sessions)
+		if !pp.Updated.IsZero() {
+			days[pp.Updated.Format(time.DateOnly)] = true
+		}
+	}
+	fmt.Fprintf(w, "Checks run: %d, with %d of %d problems passing or done.\n", checks, done, len(exercises.All()))
+	fmt.Fprintf(w, "Time tracked: %s in %d session%s.\n", formatDuration(spent), sessions, plural(sessions))
+	current, longest, from := streaks(days, now)
+	switch {
+	case longest == 0:
+		fmt.Fprintln(w, "Streak: no days yet.")
+	case current == longest:
+		fmt.Fprintf(w, "Streak: %d day%s, the longest yet.\n", current, plural(current))
+	default:
+		fmt.Fprintf(w, "Streak: %d day%s; the longest was %d, from %s.\n", current, plural(current), longest, from.Format("Jan 2, 2006"))
+	}
+}
+
+// streaks finds the runs of days in a row with some work in them: the one
+// going on now, which is 0 if neither today nor yesterday had any, and the
+// longest, with the day it started.
+func streaks(days map[string]bool, now time.Time) (current, longest int, from time.Time) {
+	sorted := slices.Sorted(maps.Keys(days))
+	run := 0
+	var start, last time.Time
+	for _, day := range sorted {
+		t, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			continue
+		}
+		if run > 0 && t.Equal(last.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run, start = 1, t
+		}
+		last = t
+		if run > longest {
+			longest, from = run, start
+		}
+	}
+	today, _ := time.Parse(time.DateOnly, now.Format(time.DateOnly))
+	if !last.IsZero() && !last.Before(today.AddDate(0, 0, -1)) {
+		current = run
+	}
+	return current, longest, from
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"config"
+)
+
+const STYLE_USAGE = "Usage: journal style PATH... [-fix] [-color]"
+
+// CONTEXT is how many unchanged lines a diff shows around each change, as
+// diff -u does.
+const CONTEXT = 3
+
+// ANSI colours for the diffs, like style50's.
+const (
+	RED   = "\033[31m"
+	GREEN = "\033[32m"
+	CYAN  = "\033[36m"
+	RESET = "\033[0m"
+)
+
+// styleReport is what style found wrong with one file.
+type styleReport struct {
+	Lines   int    // in the file as it is
+	Changed int    // lines gofmt would add or rewrite
+	CRLF    bool   // Windows line endings, which gofmt drops
+	Diff    string // unified, empty when the file is fine
+	Fixed   []byte // the file as gofmt wants it
+}
+
+func (r styleReport) ok() bool {
+	return r.Changed == 0 && !r.CRLF
+}
+
+func styleCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("style", flag.ContinueOnError)
+	flags.SetOutput(w)
+	fix := flags.Bool("fix", false, "rewrite the files gofmt's way")
+	color := flags.Bool("color", isTerminal(w), "colour the diffs")
+	paths, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(w, STYLE_USAGE)
+		return 1
+	}
+
+	var files []string
+	for _, path := range paths {
+		found, err := goFiles(path)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		files = append(files, found...)
+	}
+	if len(files) == 0 {
+		fmt.Fprintln(w, "No Go files.")
+		return 1
+	}
+
+	status, lines, changed, good := 0, 0, 0, 0
+	for _, file := range files {
+		r, err := styleFile(file)
+		if err != nil {
+			// Code that doesn't parse can't be formatted, so it scores 0.
+			fmt.Fprintf(w, ":( %s doesn't parse\n    %v\n", file, err)
+			status = 2
+			continue
+		}
+		lines += r.Lines
+		changed += r.Changed
+		if r.ok() {
+			good++
+			fmt.Fprintf(w, ":) %s\n", file)
+			continue
+		}
+		if *fix {
+			if err := writeKeepingMode(file, r.Fixed); err != nil {
+				fmt.Fprintln(w, err)
+				status = 2
+				continue
+			}
+			good++
+			fmt.Fprintf(w, ":) %s, fixed %s\n", file, r.summary())
+			continue
+		}
+		status = max(status, 1)
+		fmt.Fprintf(w, ":( %s, %s\n", file, r.summary())
+		printDiff(w, r.Diff, *color)
+	}
+
+	// The score is the share of lines gofmt leaves alone, like style50's.
+	score := 1.0
+	if lines > 0 {
+		score = max(0, 1-float64(changed)/float64(lines))
+	}
+	fmt.Fprintf(w, "\n%d of %d files formatted. Score: %.2f\n", good, len(files), score)
+	if status == 1 {
+		fmt.Fprintln(w, "journal style -fix formats them for you.")
+	}
+	return status
+}
+
+// summary says what's wrong with the file in a few words.
+func (r styleReport) summary() string {
+	var parts []string
+	if r.Changed > 0 {
+		parts = append(parts, fmt.Sprintf("%d of %d lines", r.Changed, r.Lines))
+	}
+	if r.CRLF {
+		parts = append(parts, "CRLF line endings")
+	}
+	return strings.Join(parts, " and ")
+}
+
+// goFiles is path if it's a file, or the .go files under it, skipping
+// testdata and hidden directories like the go command does.
+func goFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != path && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(name, ".go") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// styleFile formats file the way gofmt does and diffs the two. Line
+// endings are compared apart from the rest, or every line of a file saved
+// on Windows would show up as changed.
+func styleFile(file string) (styleReport, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return styleReport{}, err
+	}
+	fixed, err := format.Source(src)
+	if err != nil {
+		return styleReport{}, err
+	}
+	crlf := bytes.Contains(src, []byte("\r\n"))
+	before := splitLines(strings.ReplaceAll(string(src), "\r\n", "\n"))
+	after := splitLines(string(fixed))
+	edits := diffLines(before, after)
+	r := styleReport{Lines: len(before), CRLF: crlf, Fixed: fixed}
+	r.Changed = changedLines(edits)
+	if r.Changed > 0 {
+		r.Diff = unified(file, edits)
+	}
+	return r, nil
+}
+
+// splitLines splits text into lines without their newlines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// edit is one line of a diff: kept (' '), removed ('-') or added ('+').
+type edit struct {
+	Op   byte
+	Line string
+}
+
+// diffLines turns a into b with as few removed and added lines as it can,
+// the longest common subsequence way. gofmt's changes are small, so the
+// common start and end are skipped before the quadratic part.
+func diffLines(a, b []string) []edit {
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		start++
+	}
+	end := 0
+	for end < len(a)-start && end < len(b)-start && a[len(a)-1-end] == b[len(b)-1-end] {
+		end++
+	}
+	var edits []edit
+	for _, line := range a[:start] {
+		edits = append(edits, edit{' ', line})
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	x, y := a[start:len(a)-end], b[start:len(b)-end]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			edits = append(edits, edit{' ', x[i]})
+			i, j = i+1, j+1
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', x[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', y[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-end:] {
+		edits = append(edits, edit{' ', line})
+	}
+	return edits
+}
+
+// changedLines counts the lines a diff touches: a rewritten line is one
+// removed and one added, so it's whichever there are more of, per change.
+func changedLines(edits []edit) int {
+	changed, removed, added := 0, 0, 0
+	for _, e := range append(edits, edit{' ', ""}) {
+		switch e.Op {
+		case '-':
+			removed++
+		case '+':
+			added++
+		default:
+			changed += max(removed, added)
+			removed, added = 0, 0
+		}
+	}
+	return changed
+}
+
+// unified writes edits as a diff -u of name, with CONTEXT lines around
+// each change.
+func unified(name string, edits []edit) string {
+	return unifiedFrom(fmt.Sprintf("--- %s\n+++ %s (gofmt)\n", name, name), edits, 1, 1)
+}
+
+// unifiedFrom writes edits as a diff -u under header, numbering the lines
+// from oldStart and newStart, for a diff of part of a file.
+func unifiedFrom(header string, edits []edit, oldStart, newStart int) string {
+	var b strings.Builder
+	b.WriteString(header)
+	for start := 0; start < len(edits); {
+		// Find the next change, and the end of the hunk around it: where
+		// more than 2*CONTEXT unchanged lines follow a change.
+		first := start
+		for first < len(edits) && edits[first].Op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		last, kept := first, 0
+		for i := first; i < len(edits) && kept <= 2*CONTEXT; i++ {
+			if edits[i].Op == ' ' {
+				kept++
+			} else {
+				last, kept = i, 0
+			}
+		}
+		from, to := max(start, first-CONTEXT), min(len(edits), last+CONTEXT+1)
+
+		// Line numbers in a and b where the hunk starts.
+		oldLine, newLine := oldStart, newStart
+		for _, e := range edits[:from] {
+			if e.Op != '+' {
+				oldLine++
+			}
+			if e.Op != '-' {
+				newLine++
+			}
+		}
+		oldCount, newCount := 0, 0
+		for _, e := range edits[from:to] {
+			if e.Op != '+' {
+				oldCount++
+			}
+			if e.Op != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+		for _, e := range edits[from:to] {
+			fmt.Fprintf(&b, "%c%s\n", e.Op, e.Line)
+		}
+		start = to
+	}
+	return b.String()
+}
+
+// printDiff writes diff indented under its file's line, in colour if
+// asked.
+func printDiff(w io.Writer, diff string, color bool) {
+	for _, line := range splitLines(diff) {
+		if color {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				line = CYAN + line + RESET
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			case strings.HasPrefix(line, "-"):
+				line = RED + line + RESET
+			case strings.HasPrefix(line, "+"):
+				line = GREEN + line + RESET
+			}
+		}
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
+
+// writeKeepingMode replaces file's content, leaving its permissions be.
+func writeKeepingMode(file string, content []byte) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, content, info.Mode().Perm())
+}
+
+// isTerminal reports whether w is a terminal, where colours show up as
+// colours, and NO_COLOR isn't set, unless the config's color says always
+// or never.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return config.Get().UseColor(false)
+	}
+	info, err := f.Stat()
+	return config.Get().UseColor(err == nil && info.Mode()&os.ModeCharDevice != 0)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"exercises"
+)
+
+const TIMER_USAGE = `Usage:
+  journal start WEEK/PROBLEM   start timing it, stopping whatever was running
+  journal stop
+  journal report [-days N]     time by day, week, problem and topic`
+
+// session is one stretch of work on a problem. End is zero while it's
+// still going; only one is at a time.
+type session struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitzero"`
+}
+
+// length is how long s lasted, or has lasted by now.
+func (s session) length(now time.Time) time.Duration {
+	if s.End.IsZero() {
+		return now.Sub(s.Start)
+	}
+	return s.End.Sub(s.Start)
+}
+
+// running finds the session that hasn't been stopped, if there is one.
+func (p progress) running() (string, *session) {
+	for id, pp := range p {
+		if n := len(pp.Sessions); n > 0 && pp.Sessions[n-1].End.IsZero() {
+			return id, &pp.Sessions[n-1]
+		}
+	}
+	return "", nil
+}
+
+// stop ends the running session at now and says which it was.
+func (p progress) stop(now time.Time) (string, time.Duration, bool) {
+	id, s := p.running()
+	if s == nil {
+		return "", 0, false
+	}
+	s.End = now.Truncate(time.Second)
+	return id, s.length(now), true
+}
+
+// start stops the running session and starts one on id. A problem timed
+// before it was ever checked counts as started.
+func (p progress) start(id string, now time.Time) {
+	now = now.Truncate(time.Second)
+	if _, ok := p[id]; !ok {
+		p.set(id, STARTED, now)
+	}
+	p[id].Sessions = append(p[id].Sessions, session{Start: now})
+}
+
+func startCommand(args []string, w io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(w, TIMER_USAGE)
+		return 1
+	}
+	e, ok := exercises.Lookup(args[0])
+	if !ok {
+		fmt.Fprintf(w, "No problem %q. cs50go lists them.\n", args[0])
+		return 1
+	}
+	now := time.Now()
+	err := updateProgress(func(p progress) {
+		if id, spent, ok := p.stop(now); ok {
+			fmt.Fprintf(w, "Stopped %s after %s.\n", id, formatDuration(spent))
+		}
+		p.start(exercises.ID(e), now)
+	})
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Timing %s. journal stop when you take a break.\n", exercises.ID(e))
+	return 0
+}
+
+func stopCommand(args []string, w io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintln(w, TIMER_USAGE)
+		return 1
+	}
+	stopped := false
+	err := updateProgress(func(p progress) {
+		var id string
+		var spent time.Duration
+		if id, spent, stopped = p.stop(time.Now()); stopped {
+			fmt.Fprintf(w, "Stopped %s after %s.\n", id, formatDuration(spent))
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if !stopped {
+		fmt.Fprintln(w, "Nothing is being timed.")
+		return 1
+	}
+	return 0
+}
+
+func reportCommand(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	flags.SetOutput(w)
+	days := flags.Int("days", 7, "how many days back to list day by day")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if flags.NArg() != 0 || *days < 1 {
+		fmt.Fprintln(w, TIMER_USAGE)
+		return 1
+	}
+	p, err := loadProgress()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	report(w, p, time.Now(), *days)
+	return 0
+}
+
+// WEEKS_BACK is how many calendar weeks the report totals.
+const WEEKS_BACK = 4
+
+// report prints the time spent in the last days, day by day, then the
+// last few weeks (Monday to Sunday), then every problem ever timed and
+// every CS50 week's topic, longest first.
+func report(w io.Writer, p progress, now time.Time, days int) {
+	// spent is the time in [from, to) across every session.
+	spent := func(from, to time.Time) time.Duration {
+		var total time.Duration
+		for _, pp := range p {
+			for _, s := range pp.Sessions {
+				start, end := s.Start, s.End
+				if end.IsZero() {
+					end = now
+				}
+				if start.Before(from) {
+					start = from
+				}
+				if end.After(to) {
+					end = to
+				}
+				if end.After(start) {
+					total += end.Sub(start)
+				}
+			}
+		}
+		return total
+	}
+	today := day(now)
+
+	fmt.Fprintln(w, "By day")
+	for i := days - 1; i >= 0; i-- {
+		from := today.AddDate(0, 0, -i)
+		fmt.Fprintf(w, "  %s  %s\n", from.Format("Mon 2006-01-02"), formatDuration(spent(from, from.AddDate(0, 0, 1))))
+	}
+
+	fmt.Fprintln(w, "\nBy week")
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	for i := WEEKS_BACK - 1; i >= 0; i-- {
+		from := monday.AddDate(0, 0, -7*i)
+		fmt.Fprintf(w, "  from %s  %s\n", from.Format("Mon 2006-01-02"), formatDuration(spent(from, from.AddDate(0, 0, 7))))
+	}
+
+	type total struct {
+		name     string
+		spent    time.Duration
+		sessions int
+	}
+	var problems []total
+	topics := map[int]*total{}
+	for _, e := range exercises.All() {
+		pp, ok := p[exercises.ID(e)]
+		if !ok || len(pp.Sessions) == 0 {
+			continue
+		}
+		t := total{name: exercises.ID(e), sessions: len(pp.Sessions)}
+		for _, s := range pp.Sessions {
+			t.spent += s.length(now)
+		}
+		problems = append(problems, t)
+		if topics[e.Week()] == nil {
+			topics[e.Week()] = &total{name: fmt.Sprintf("Week %d", e.Week())}
+		}
+		topics[e.Week()].spent += t.spent
+		topics[e.Week()].sessions += t.sessions
+	}
+	if len(problems) == 0 {
+		fmt.Fprintln(w, "\nNothing timed yet: journal start WEEK/PROBLEM.")
+		return
+	}
+	longest := func(a, b total) int { return cmp.Compare(b.spent, a.spent) }
+
+	fmt.Fprintln(w, "\nBy problem")
+	slices.SortStableFunc(problems, longest)
+	for _, t := range problems {
+		fmt.Fprintf(w, "  %-24s %8s  %s\n", t.name, formatDuration(t.spent), sessions(t.sessions))
+	}
+	fmt.Fprintln(w, "\nBy topic")
+	var weeks []total
+	for _, t := range topics {
+		weeks = append(weeks, *t)
+	}
+	slices.SortStableFunc(weeks, func(a, b total) int {
+		return cmp.Or(longest(a, b), cmp.Compare(a.name, b.name))
+	})
+	for _, t := range weeks {
+		fmt.Fprintf(w, "  %-24s %8s  %s\n", t.name, formatDuration(t.spent), sessions(t.sessions))
+	}
+
+	if id, s := p.running(); s != nil {
+		fmt.Fprintf(w, "\nTiming %s, for %s so far.\n", id, formatDuration(s.length(now)))
+	}
+}
+
+// sessions is "1 session" or "n sessions".
+func sessions(n int) string {
+	if n == 1 {
+		return "1 session"
+	}
+	return fmt.Sprintf("%d sessions", n)
+}
+
+// formatDuration rounds d to minutes, "2h05m", "25m", or "0m".
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
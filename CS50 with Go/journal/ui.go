@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"config"
+	"exercises"
+)
+
+const UI_HELP = "↑/↓ move · ←/→ week · enter run · a run with arguments · c check · n notes · q quit"
+
+// Badges show a problem's progress in the list, coloured like check50's
+// smileys.
+var (
+	BADGES = map[string]string{
+		"":        lipgloss.NewStyle().Faint(true).Render("·"),
+		STARTED:   lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Render("◐"),
+		CHECKED:   lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render("✓"),
+		COMPLETED: lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true).Render("★"),
+	}
+	weekStyle     = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("5"))
+)
+
+// uiModel is the dashboard: the exercises by week, with a cursor, and the
+// output of the last thing done to one of them.
+type uiModel struct {
+	root     string
+	progress progress
+	all      []exercises.Exercise
+	cursor   int // into all
+	height   int
+	message  string // what the last check, run or error said
+	checking bool
+	args     *string // arguments being typed for a run, nil when not
+}
+
+// Messages from the commands the dashboard starts.
+type (
+	checkedMsg struct {
+		id     string
+		output string
+	}
+	ranMsg struct {
+		id  string
+		err error
+	}
+	editedMsg struct{ err error }
+)
+
+func uiCommand(args []string, w io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintln(w, "Usage: journal ui")
+		return 1
+	}
+	root, err := exercises.Root()
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	m, err := newUIModel(root)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return 0
+}
+
+func newUIModel(root string) (uiModel, error) {
+	p, err := loadProgress()
+	return uiModel{root: root, progress: p, all: exercises.All(), height: 24}, err
+}
+
+func (m uiModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m uiModel) selected() exercises.Exercise {
+	return m.all[m.cursor]
+}
+
+func (m uiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+	case checkedMsg:
+		m.checking = false
+		m.message = msg.output
+		m.reload()
+	case ranMsg:
+		m.message = ""
+		if msg.err != nil {
+			m.message = fmt.Sprintf("%s: %v", msg.id, msg.err)
+		}
+	case editedMsg:
+		m.message = ""
+		if msg.err != nil {
+			m.message = msg.err.Error()
+		}
+	case tea.KeyMsg:
+		if m.args != nil {
+			return m.typeArgs(msg)
+		}
+		return m.key(msg)
+	}
+	return m, nil
+}
+
+// reload picks up progress saved since the dashboard started, by a check
+// here or a journal command in another terminal.
+func (m *uiModel) reload() {
+	if p, err := loadProgress(); err == nil {
+		m.progress = p
+	} else {
+		m.message = err.Error()
+	}
+}
+
+func (m uiModel) key(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.all) - 1
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(0, m.cursor-1)
+	case "down", "j":
+		m.cursor = min(last, m.cursor+1)
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = last
+	case "left", "h":
+		// The first problem of this week, or of the week before when
+		// already there.
+		week := m.selected().Week()
+		if m.cursor > 0 && m.all[m.cursor-1].Week() != week {
+			week = m.all[m.cursor-1].Week()
+		}
+		for m.cursor > 0 && m.all[m.cursor-1].Week() == week {
+			m.cursor--
+		}
+	case "right", "l":
+		week := m.selected().Week()
+		for m.cursor < last && m.all[m.cursor].Week() == week {
+			m.cursor++
+		}
+	case "enter":
+		return m, m.run(nil)
+	case "a":
+		m.args = new(string)
+	case "c":
+		if !m.checking {
+			m.checking = true
+			m.message = ""
+			return m, m.check()
+		}
+	case "n":
+		return m, m.editNotes()
+	case "r":
+		m.reload()
+	}
+	return m, nil
+}
+
+// typeArgs edits the arguments for a run, which starts on enter.
+func (m uiModel) typeArgs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		args := strings.Fields(*m.args)
+		m.args = nil
+		return m, m.run(args)
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.args = nil
+	case tea.KeyBackspace:
+		if r := []rune(*m.args); len(r) > 0 {
+			*m.args = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		*m.args += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// check runs the selected problem's checks in the background and records
+// them, like journal check.
+func (m uiModel) check() tea.Cmd {
+	e, root := m.selected(), m.root
+	return func() tea.Msg {
+		var out bytes.Buffer
+		status, summary := check(root, e, &out, false)
+		if summary != "" {
+			if err := recordCheck(e, status == 0, summary); err != nil {
+				fmt.Fprintln(&out, "Couldn't save your progress:", err)
+			}
+		}
+		return checkedMsg{exercises.ID(e), out.String()}
+	}
+}
+
+// run hands the terminal to the selected exercise, a program in its own
+// folder so its default files are found, until it exits and enter is
+// pressed.
+func (m uiModel) run(args []string) tea.Cmd {
+	e := m.selected()
+	c := &pausedCommand{exercise: e, stdio: exercises.IO{Args: args}}
+	if p, ok := e.(exercises.Program); ok {
+		c.stdio.Dir = p.Path(m.root)
+	}
+	return tea.Exec(c, func(err error) tea.Msg {
+		return ranMsg{exercises.ID(e), err}
+	})
+}
+
+// editNotes opens the selected problem's notes in the config's editor,
+// or $EDITOR, or vi without either.
+func (m uiModel) editNotes() tea.Cmd {
+	p, ok := m.selected().(exercises.Program)
+	if !ok {
+		return func() tea.Msg { return editedMsg{fmt.Errorf("%s has no notes in the repo", m.selected().Name())} }
+	}
+	fields := config.Get().EditorCommand()
+	cmd := exec.Command(fields[0], append(fields[1:], p.Notes(m.root))...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg { return editedMsg{err} })
+}
+
+// pausedCommand runs an exercise and then waits for enter, so its output
+// can be read before the dashboard comes back over it. One that can't run,
+// like a program that doesn't build, goes straight back, to show why.
+type pausedCommand struct {
+	exercise exercises.Exercise
+	stdio    exercises.IO
+}
+
+func (c *pausedCommand) SetStdin(r io.Reader)  { c.stdio.Stdin = r }
+func (c *pausedCommand) SetStdout(w io.Writer) { c.stdio.Stdout = w }
+func (c *pausedCommand) SetStderr(w io.Writer) { c.stdio.Stderr = w }
+
+func (c *pausedCommand) Run() error {
+	err := c.exercise.Run(context.Background(), c.stdio)
+	var exit interface{ ExitCode() int }
+	code := 0
+	switch {
+	case errors.As(err, &exit):
+		code = exit.ExitCode()
+	case err != nil:
+		return err
+	}
+	fmt.Fprintf(c.stdio.Stdout, "\n[exit %d] Press enter to go back.", code)
+	bufio.NewReader(c.stdio.Stdin).ReadString('\n')
+	return nil
+}
+
+func (m uiModel) View() string {
+	var b strings.Builder
+	done := 0
+	for _, pp := range m.progress {
+		if pp.Status == COMPLETED {
+			done++
+		}
+	}
+	total := len(m.all)
+	fmt.Fprintf(&b, "%s  %d of %d completed (%d%%)\n\n", titleStyle.Render("CS50 with Go"), done, total, done*100/total)
+
+	// The list scrolls to keep the cursor in view, leaving room for the
+	// details underneath.
+	var lines []string
+	at := 0
+	for i, e := range m.all {
+		if i == 0 || m.all[i-1].Week() != e.Week() {
+			lines = append(lines, weekStyle.Render(fmt.Sprintf("Week %d", e.Week())))
+		}
+		status := ""
+		if pp, ok := m.progress[exercises.ID(e)]; ok {
+			status = pp.Status
+		}
+		line := fmt.Sprintf(" %s %-20s %s", BADGES[status], e.Name(), faintStyle.Render(e.Summary()))
+		if i == m.cursor {
+			at = len(lines)
+			line = selectedStyle.Render(fmt.Sprintf(" %s %-20s", BADGES[status], e.Name())) + " " + e.Summary()
+		}
+		lines = append(lines, line)
+	}
+	room := max(5, m.height-12)
+	start := min(max(0, at-room/2), max(0, len(lines)-room))
+	for _, line := range lines[start:min(len(lines), start+room)] {
+		b.WriteString(line + "\n")
+	}
+
+	e := m.selected()
+	if p, ok := e.(exercises.Program); ok {
+		fmt.Fprintf(&b, "\n%s  %s/%s\n", titleStyle.Render(p.ID()), p.Dir, p.File)
+	} else {
+		fmt.Fprintf(&b, "\n%s\n", titleStyle.Render(exercises.ID(e)))
+	}
+	if pp, ok := m.progress[exercises.ID(e)]; ok {
+		fmt.Fprintf(&b, "%s since %s", pp.Status, pp.Started.Format("2 Jan"))
+		if pp.Checks != "" {
+			fmt.Fprintf(&b, ", last checks %s", pp.Checks)
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString("not started\n")
+	}
+	switch {
+	case m.checking:
+		b.WriteString("checking…\n")
+	case m.message != "":
+		b.WriteString(lastLines(m.message, 6) + "\n")
+	}
+
+	b.WriteString("\n")
+	if m.args != nil {
+		fmt.Fprintf(&b, "run %s with: %s█  (enter to run, esc to cancel)", e.Name(), *m.args)
+	} else {
+		b.WriteString(faintStyle.Render(UI_HELP))
+	}
+	return b.String()
+}
+
+// lastLines is the end of s, at most n lines of it, since a check's
+// failures and summary are at the bottom.
+func lastLines(s string, n int) string {
+	lines := splitLines(s)
+	return strings.Join(lines[max(0, len(lines)-n):], "\n")
+}
@@ -0,0 +1,198 @@
+// Package logging is the programs' log: log/slog on standard error, as
+// text or as JSON lines, at the level -v and -vv ask for. What a program
+// used to fmt.Println to see what it was doing, like credit's digits, is
+// a debug log now, there with -v and out of the output otherwise.
+//
+//	func main() {
+//		logging.Init() // takes -v, -vv and --log-format json off os.Args
+//		slog.Debug("checksum", "sum", sum)
+//	}
+//
+// Without the flags it's $CS50GO_LOG and $CS50GO_LOG_FORMAT, which cs50go
+// sets from its own -v, -vv and --log-format for the program it runs.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+)
+
+// The variables Settings come from.
+const (
+	LEVEL_ENV  = "CS50GO_LOG"        // trace, debug, info, warn or error
+	FORMAT_ENV = "CS50GO_LOG_FORMAT" // text or json
+)
+
+// LevelTrace is -vv's: below debug, for what's too much even there, like
+// the headers of every request.
+const LevelTrace = slog.LevelDebug - 4
+
+// LEVELS are the levels by the names $CS50GO_LOG takes.
+var LEVELS = map[string]slog.Level{
+	"trace": LevelTrace,
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// FORMATS are the formats there are, text first, the default.
+var FORMATS = []string{"text", "json"}
+
+// Settings are what to log and how.
+type Settings struct {
+	Level  slog.Level
+	Format string // "text" or "json"
+}
+
+// DEFAULTS are the settings with no flags and nothing in the environment:
+// what a web app says it's doing, and nothing more.
+var DEFAULTS = Settings{Level: slog.LevelInfo, Format: "text"}
+
+// Init takes -v, -vv and --log-format FORMAT off os.Args, before any
+// flag.Parse, and makes the default logger the one they ask for, or the
+// environment does. A format or level there isn't is a usage error, so it
+// exits with 2, as flag.Parse does.
+func Init() {
+	s, args, err := Args(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Args = args
+	slog.SetDefault(New(os.Stderr, s))
+}
+
+// FromEnv is the Settings the environment has, DEFAULTS where it has none.
+func FromEnv() (Settings, error) {
+	s := DEFAULTS
+	if name := os.Getenv(LEVEL_ENV); name != "" {
+		level, ok := LEVELS[strings.ToLower(name)]
+		if !ok {
+			return s, fmt.Errorf("%s is %q, not one of %s", LEVEL_ENV, name, strings.Join(levelNames(), ", "))
+		}
+		s.Level = level
+	}
+	if format := os.Getenv(FORMAT_ENV); format != "" {
+		s.Format = format
+	}
+	return s, s.check()
+}
+
+// Args returns the settings args and the environment ask for, with the
+// flags taken out of args: -v is debug and -vv, or -v twice, trace;
+// --log-format, or -log-format, takes its value after = or as the next
+// argument. Nothing after -- is looked at.
+func Args(args []string) (Settings, []string, error) {
+	s, err := FromEnv()
+	if err != nil {
+		return s, nil, err
+	}
+	verbosity := 0
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			rest = append(rest, arg)
+		case arg == "-v" || arg == "--v":
+			verbosity++
+		case arg == "-vv" || arg == "--vv":
+			verbosity += 2
+		case name == "log-format":
+			if !hasValue {
+				if i+1 == len(args) {
+					return s, nil, fmt.Errorf("--log-format needs a format: %s", strings.Join(FORMATS, " or "))
+				}
+				i++
+				value = args[i]
+			}
+			s.Format = value
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	if verbosity > 0 {
+		s.Level = Verbosity(verbosity)
+	}
+	return s, rest, s.check()
+}
+
+// Verbosity is the level for a count of -v: info, then debug, then trace.
+func Verbosity(n int) slog.Level {
+	switch {
+	case n <= 0:
+		return slog.LevelInfo
+	case n == 1:
+		return slog.LevelDebug
+	}
+	return LevelTrace
+}
+
+func (s Settings) check() error {
+	if !slices.Contains(FORMATS, s.Format) {
+		return fmt.Errorf("no log format %q: the formats are %s", s.Format, strings.Join(FORMATS, " and "))
+	}
+	return nil
+}
+
+// Env is s as the environment, for a program to run with; the defaults
+// are left out, so the program's own flags still count.
+func (s Settings) Env() []string {
+	var env []string
+	if s.Level != DEFAULTS.Level {
+		env = append(env, LEVEL_ENV+"="+LevelName(s.Level))
+	}
+	if s.Format != DEFAULTS.Format {
+		env = append(env, FORMAT_ENV+"="+s.Format)
+	}
+	return env
+}
+
+// New is a logger writing to w the way s says: slog's text or JSON, with
+// LevelTrace called TRACE rather than DEBUG-4.
+func New(w io.Writer, s Settings) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level: s.Level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if level, ok := a.Value.Any().(slog.Level); ok && a.Key == slog.LevelKey && len(groups) == 0 {
+				a.Value = slog.StringValue(strings.ToUpper(LevelName(level)))
+			}
+			return a
+		},
+	}
+	if s.Format == "json" {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// LevelName is level's name in $CS50GO_LOG, "trace" or "debug", or slog's
+// for one between them.
+func LevelName(level slog.Level) string {
+	for name, l := range LEVELS {
+		if l == level {
+			return name
+		}
+	}
+	return level.String()
+}
+
+// levelNames are LEVELS' names, from the most said to the least.
+func levelNames() []string {
+	names := make([]string, 0, len(LEVELS))
+	for name := range LEVELS {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int { return int(LEVELS[a] - LEVELS[b]) })
+	return names
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		level, format string // the environment
+		args          []string
+		rest          []string
+		want          Settings
+		err           bool
+	}{
+		{"", "", []string{"credit"}, []string{"credit"}, DEFAULTS, false},
+		{"", "", []string{"credit", "-v"}, []string{"credit"}, Settings{slog.LevelDebug, "text"}, false},
+		{"", "", []string{"credit", "-vv"}, []string{"credit"}, Settings{LevelTrace, "text"}, false},
+		{"", "", []string{"credit", "-v", "--v"}, []string{"credit"}, Settings{LevelTrace, "text"}, false},
+		{"", "", []string{"api", "--log-format", "json", "-port", "80"}, []string{"api", "-port", "80"}, Settings{slog.LevelInfo, "json"}, false},
+		{"", "", []string{"api", "-log-format=json", "-v"}, []string{"api"}, Settings{slog.LevelDebug, "json"}, false},
+		{"debug", "json", []string{"api"}, []string{"api"}, Settings{slog.LevelDebug, "json"}, false},
+		{"WARN", "", []string{"api", "-vv"}, []string{"api"}, Settings{LevelTrace, "text"}, false},
+		{"", "json", []string{"api", "--log-format", "text"}, []string{"api"}, DEFAULTS, false},
+		{"", "", []string{"no-vowels", "--", "-v"}, []string{"no-vowels", "--", "-v"}, DEFAULTS, false},
+		{"", "", []string{"no-vowels", "-verbose"}, []string{"no-vowels", "-verbose"}, DEFAULTS, false},
+		{"", "", []string{"api", "--log-format"}, nil, DEFAULTS, true},
+		{"", "", []string{"api", "--log-format", "xml"}, nil, DEFAULTS, true},
+		{"loud", "", []string{"api"}, nil, DEFAULTS, true},
+	}
+	for _, test := range tests {
+		t.Setenv(LEVEL_ENV, test.level)
+		t.Setenv(FORMAT_ENV, test.format)
+		s, rest, err := Args(test.args)
+		name := fmt.Sprintf("$%s=%q $%s=%q, Args(%q)", LEVEL_ENV, test.level, FORMAT_ENV, test.format, test.args)
+		switch {
+		case test.err != (err != nil):
+			t.Errorf("%s: error %v", name, err)
+		case test.err:
+		case !slices.Equal(rest, test.rest):
+			t.Errorf("%s = %q, want %q", name, rest, test.rest)
+		case s != test.want:
+			t.Errorf("%s = %+v, want %+v", name, s, test.want)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		s    Settings
+		want []string
+	}{
+		{DEFAULTS, nil},
+		{Settings{slog.LevelDebug, "text"}, []string{"CS50GO_LOG=debug"}},
+		{Settings{LevelTrace, "json"}, []string{"CS50GO_LOG=trace", "CS50GO_LOG_FORMAT=json"}},
+	}
+	for _, test := range tests {
+		if got := test.s.Env(); !slices.Equal(got, test.want) {
+			t.Errorf("%+v.Env() = %q, want %q", test.s, got, test.want)
+		}
+		// What a program gets is what was given.
+		for _, kv := range test.want {
+			name, value, _ := strings.Cut(kv, "=")
+			t.Setenv(name, value)
+		}
+		if s, err := FromEnv(); err != nil || s != test.s {
+			t.Errorf("FromEnv() after %q = %+v, %v, want %+v", test.want, s, err, test.s)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, Settings{slog.LevelDebug, "text"})
+	logger.Log(t.Context(), LevelTrace, "too much")
+	logger.Debug("digit", "position", 2, "value", 4)
+	if got := out.String(); strings.Contains(got, "too much") || !strings.Contains(got, `level=DEBUG msg=digit position=2 value=4`) {
+		t.Errorf("debug, text = %q", got)
+	}
+
+	out.Reset()
+	logger = New(&out, Settings{LevelTrace, "json"})
+	logger.Log(t.Context(), LevelTrace, "headers", "accept", "text/html")
+	var line map[string]any
+	if err := json.Unmarshal(out.Bytes(), &line); err != nil || line["level"] != "TRACE" || line["msg"] != "headers" || line["accept"] != "text/html" {
+		t.Errorf("trace, json = %s, %v", out.String(), err)
+	}
+}
+
+func TestVerbosity(t *testing.T) {
+	for n, want := range []slog.Level{slog.LevelInfo, slog.LevelDebug, LevelTrace, LevelTrace} {
+		if got := Verbosity(n); got != want {
+			t.Errorf("Verbosity(%d) = %v, want %v", n, got, want)
+		}
+	}
+	if got := LevelName(LevelTrace); got != "trace" {
+		t.Errorf("LevelName(LevelTrace) = %q", got)
+	}
+}
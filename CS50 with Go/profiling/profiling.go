@@ -0,0 +1,218 @@
+// Package profiling is the programs' -cpuprofile, -memprofile and -trace,
+// without flags of their own: what to write, and where, comes from the
+// environment, which cs50go's flags set for the program it runs.
+//
+//	func main() {
+//		defer profiling.Start()()
+//		...
+//	}
+//
+//	cs50go -cpuprofile cpu.pprof speller texts/holmes.txt
+//	CS50GO_CPUPROFILE=cpu.pprof go run .   // the same, without cs50go
+//
+// CS50GO_PPROF=localhost:6060 serves net/http/pprof's pages on that
+// address while the program runs, which is the way into a web app: it
+// doesn't end until it's stopped.
+package profiling
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	rpprof "runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"runctx"
+)
+
+// The variables Settings come from.
+const (
+	CPUPROFILE_ENV = "CS50GO_CPUPROFILE"
+	MEMPROFILE_ENV = "CS50GO_MEMPROFILE"
+	TRACE_ENV      = "CS50GO_TRACE"
+	PPROF_ENV      = "CS50GO_PPROF"
+)
+
+// Settings are what to profile: a file for each profile, "" for none, and
+// an address to serve pprof on.
+type Settings struct {
+	CPUProfile string // go tool pprof reads it
+	MemProfile string // the heap, when the program ends
+	Trace      string // go tool trace reads it
+	HTTP       string // like localhost:6060, for /debug/pprof/
+}
+
+// FromEnv is the Settings the environment has.
+func FromEnv() Settings {
+	return Settings{
+		CPUProfile: os.Getenv(CPUPROFILE_ENV),
+		MemProfile: os.Getenv(MEMPROFILE_ENV),
+		Trace:      os.Getenv(TRACE_ENV),
+		HTTP:       os.Getenv(PPROF_ENV),
+	}
+}
+
+// Any reports whether there's anything to profile.
+func (s Settings) Any() bool {
+	return s != Settings{}
+}
+
+// Env is s as the environment, for a program to run with, the files made
+// absolute: the program may run somewhere else.
+func (s Settings) Env() ([]string, error) {
+	var env []string
+	for _, v := range []struct{ name, path string }{
+		{CPUPROFILE_ENV, s.CPUProfile},
+		{MEMPROFILE_ENV, s.MemProfile},
+		{TRACE_ENV, s.Trace},
+	} {
+		if v.path == "" {
+			continue
+		}
+		abs, err := filepath.Abs(v.path)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, v.name+"="+abs)
+	}
+	if s.HTTP != "" {
+		env = append(env, PPROF_ENV+"="+s.HTTP)
+	}
+	return env, nil
+}
+
+// Start starts what the environment asks for and returns what stops it,
+// for main to defer. A profile it can't start is logged as an error, and
+// the program goes on without it: profiling is never why it fails.
+func Start() (stop func()) {
+	stop, err := FromEnv().Start()
+	if err != nil {
+		slog.Error("profiling", "err", err)
+	}
+	return stop
+}
+
+// Start starts the CPU profile, the trace and the pprof server s asks for
+// and returns what writes the memory profile and stops the rest. Stop is
+// a runctx cleanup too, so Ctrl-C on a server still leaves its profiles
+// before it exits 130. Stop always works, even with an error.
+func (s Settings) Start() (stop func(), err error) {
+	var stops []func() error
+	stop = func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			if err := stops[i](); err != nil {
+				slog.Error("profiling", "err", err)
+			}
+		}
+		stops = nil
+	}
+	if !s.Any() {
+		return stop, nil
+	}
+
+	if s.CPUProfile != "" {
+		f, err := os.Create(s.CPUProfile)
+		if err != nil {
+			return stop, err
+		}
+		if err := rpprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return stop, err
+		}
+		stops = append(stops, func() error {
+			rpprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+	if s.Trace != "" {
+		f, err := os.Create(s.Trace)
+		if err != nil {
+			return stop, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return stop, err
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+	if s.MemProfile != "" {
+		stops = append(stops, func() error { return writeHeap(s.MemProfile) })
+	}
+	if s.HTTP != "" {
+		l, err := net.Listen("tcp", s.HTTP)
+		if err != nil {
+			return stop, err
+		}
+		slog.Info("pprof", "url", fmt.Sprintf("http://%s/debug/pprof/", l.Addr()))
+		go http.Serve(l, Handler())
+		stops = append(stops, l.Close)
+	}
+
+	remove := runctx.Defer(stop)
+	stops = append(stops, func() error {
+		remove()
+		return nil
+	})
+	return stop, nil
+}
+
+// writeHeap writes the heap profile to path, after a collection so it's
+// up to date.
+func writeHeap(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	return errors.Join(rpprof.WriteHeapProfile(f), f.Close())
+}
+
+// Handler is net/http/pprof's pages, under /debug/pprof/, on a mux of
+// their own rather than http.DefaultServeMux, which a web app may use.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// Written is the files of s a program wrote since start: a program that
+// doesn't call Start writes none, whatever it's asked.
+func (s Settings) Written(since time.Time) Settings {
+	wrote := func(path string) string {
+		// Some filesystems only keep the second.
+		if info, err := os.Stat(path); path == "" || err != nil || info.ModTime().Before(since.Truncate(time.Second)) {
+			return ""
+		}
+		return path
+	}
+	return Settings{CPUProfile: wrote(s.CPUProfile), MemProfile: wrote(s.MemProfile), Trace: wrote(s.Trace)}
+}
+
+// Describe says where s's files are and how to read them, a line each.
+func (s Settings) Describe() []string {
+	var lines []string
+	if s.CPUProfile != "" {
+		lines = append(lines, fmt.Sprintf("CPU profile in %s: go tool pprof %s", s.CPUProfile, s.CPUProfile))
+	}
+	if s.MemProfile != "" {
+		lines = append(lines, fmt.Sprintf("Memory profile in %s: go tool pprof %s", s.MemProfile, s.MemProfile))
+	}
+	if s.Trace != "" {
+		lines = append(lines, fmt.Sprintf("Trace in %s: go tool trace %s", s.Trace, s.Trace))
+	}
+	return lines
+}
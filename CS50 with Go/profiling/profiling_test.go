@@ -0,0 +1,93 @@
+package profiling
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnv(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir, _ := os.Getwd()
+	s := Settings{CPUProfile: "cpu.pprof", Trace: "/tmp/trace.out", HTTP: "localhost:6060"}
+	env, err := s.Env()
+	want := []string{
+		CPUPROFILE_ENV + "=" + filepath.Join(dir, "cpu.pprof"),
+		TRACE_ENV + "=/tmp/trace.out",
+		PPROF_ENV + "=localhost:6060",
+	}
+	if err != nil || !slices.Equal(env, want) {
+		t.Errorf("Env() = %q, %v, want %q", env, err, want)
+	}
+	if env, _ := (Settings{}).Env(); env != nil || (Settings{}).Any() {
+		t.Errorf("nothing's Env() = %q", env)
+	}
+
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		t.Setenv(name, value)
+	}
+	t.Setenv(MEMPROFILE_ENV, "")
+	if got := FromEnv(); got.CPUProfile != filepath.Join(dir, "cpu.pprof") || got.HTTP != s.HTTP || got.MemProfile != "" {
+		t.Errorf("FromEnv() = %+v", got)
+	}
+}
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+	s := Settings{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		MemProfile: filepath.Join(dir, "mem.pprof"),
+		Trace:      filepath.Join(dir, "trace.out"),
+	}
+	start := time.Now()
+	stop, err := s.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	work := 0
+	for i := range 1_000_000 {
+		work += i % 7
+	}
+	stop()
+	stop() // twice is once
+	for _, path := range []string{s.CPUProfile, s.MemProfile, s.Trace} {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("%s: %v, %v", filepath.Base(path), info, err)
+		}
+	}
+	if len(s.Describe()) != 3 {
+		t.Errorf("Describe() = %q", s.Describe())
+	}
+	if got := s.Written(start); got != s {
+		t.Errorf("Written = %+v, want all of %+v", got, s)
+	}
+	if got := s.Written(start.Add(time.Hour)); got.Any() {
+		t.Errorf("Written an hour from now = %+v, want none", got)
+	}
+
+	// A profile it can't write is an error, and stop still stops the rest.
+	bad := Settings{Trace: filepath.Join(dir, "trace2.out"), CPUProfile: filepath.Join(dir, "no", "cpu.pprof")}
+	stop, err = bad.Start()
+	if err == nil {
+		t.Error("Start() with no such folder = nil, want an error")
+	}
+	stop()
+	if stop, err := (Settings{}).Start(); err != nil {
+		t.Errorf("Start() with nothing = %v", err)
+	} else {
+		stop()
+	}
+}
+
+func TestHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/debug/pprof/", nil))
+	if w.Code != 200 || !strings.Contains(w.Body.String(), "heap") {
+		t.Errorf("/debug/pprof/ = %d\n%s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,224 @@
+// Package render prints a program's results as something else can read
+// them: an aligned table, JSON or CSV, all from the same structs. Without
+// --format a program prints what it always has, the text check50 and
+// journal check compare.
+//
+//	type verdict struct {
+//		Number int64  `json:"number"`
+//		Card   string `json:"card"`
+//	}
+//
+//	func main() {
+//		render.Init() // takes --format table|json|csv off os.Args
+//		...
+//		if render.Text() {
+//			fmt.Println(card)
+//			return
+//		}
+//		render.Print(verdict{number, card})
+//	}
+//
+// The names are the fields' json tags, for the table's and the CSV's
+// headers too. Without the flag it's $CS50GO_FORMAT.
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// FORMAT_ENV is the format when there's no --format.
+const FORMAT_ENV = "CS50GO_FORMAT"
+
+// FORMATS are the formats there are, text first, the default: the
+// program's own output.
+var FORMATS = []string{"text", "table", "json", "csv"}
+
+// chosen is the format Init found.
+var chosen = "text"
+
+// Init takes --format FORMAT off os.Args, before any flag.Parse, and
+// makes it, or the environment's, the one Print uses. A format there
+// isn't is a usage error, so it exits with 2, as flag.Parse does.
+func Init() {
+	f, args, err := Args(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Args, chosen = args, f
+}
+
+// Format is the format Init found, "text" before it's called.
+func Format() string {
+	return chosen
+}
+
+// Text reports whether the program prints its own text, as it did before
+// there were formats.
+func Text() bool {
+	return chosen == "text"
+}
+
+// Prompt is prompt for the text, and "" for the other formats: cs50's
+// prompts are on standard output, where they'd come before the JSON.
+func Prompt(prompt string) string {
+	if Text() {
+		return prompt
+	}
+	return ""
+}
+
+// Args returns the format args and the environment ask for, with the
+// flag taken out of args: --format, or -format, takes its value after =
+// or as the next argument. Nothing after -- is looked at.
+func Args(args []string) (string, []string, error) {
+	f := os.Getenv(FORMAT_ENV)
+	if f == "" {
+		f = FORMATS[0]
+	}
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "format" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return f, nil, fmt.Errorf("--format needs a format: %s", strings.Join(FORMATS, ", "))
+			}
+			i++
+			value = args[i]
+		}
+		f = value
+	}
+	if !slices.Contains(FORMATS, f) {
+		return f, nil, fmt.Errorf("no format %q: the formats are %s", f, strings.Join(FORMATS, ", "))
+	}
+	return f, rest, nil
+}
+
+// Print writes v to standard output in the format Init found.
+func Print(v any) error {
+	return Fprint(os.Stdout, chosen, v)
+}
+
+// Fprint writes v, a struct or a slice of them, to w as a table, JSON or
+// CSV. A table of one struct is a field to a line; of a slice, a row to
+// each. There's no text: that's the program's to print.
+func Fprint(w io.Writer, format string, v any) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	value := reflect.Indirect(reflect.ValueOf(v))
+	one := value.Kind() == reflect.Struct
+	if !one && (value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Struct) {
+		return fmt.Errorf("render: %T isn't a struct or a slice of them", v)
+	}
+	var names []string
+	var rows [][]string
+	if one {
+		names, rows = fields(value.Type()), [][]string{cells(value)}
+	} else {
+		names = fields(value.Type().Elem())
+		for i := range value.Len() {
+			rows = append(rows, cells(value.Index(i)))
+		}
+	}
+
+	switch format {
+	case "csv":
+		out := csv.NewWriter(w)
+		out.Write(names)
+		out.WriteAll(rows)
+		return out.Error()
+	case "table":
+		out := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		if one {
+			for i, name := range names {
+				fmt.Fprintf(out, "%s\t%s\n", name, rows[0][i])
+			}
+		} else {
+			fmt.Fprintln(out, strings.Join(names, "\t"))
+			for _, row := range rows {
+				fmt.Fprintln(out, strings.Join(row, "\t"))
+			}
+		}
+		return out.Flush()
+	}
+	return fmt.Errorf("render: no format %q", format)
+}
+
+// fields are the names of t's exported fields, from their json tags, the
+// way encoding/json names them; "-" leaves one out.
+func fields(t reflect.Type) []string {
+	var names []string
+	for _, f := range reflect.VisibleFields(t) {
+		if name, ok := fieldName(f); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() || f.Anonymous {
+		return "", false
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	}
+	return name, true
+}
+
+// cells are v's fields as text, in fields' order. A slice is its elements
+// with spaces between them.
+func cells(v reflect.Value) []string {
+	var row []string
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if _, ok := fieldName(f); ok {
+			row = append(row, cell(v.FieldByIndex(f.Index)))
+		}
+	}
+	return row
+}
+
+func cell(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = cell(v.Index(i))
+		}
+		return strings.Join(parts, " ")
+	}
+	return fmt.Sprint(v.Interface())
+}
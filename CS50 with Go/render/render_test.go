@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	tests := []struct {
+		env  string
+		args []string
+		rest []string
+		want string
+		err  bool
+	}{
+		{"", []string{"credit"}, []string{"credit"}, "text", false},
+		{"", []string{"credit", "--format", "json"}, []string{"credit"}, "json", false},
+		{"", []string{"speller", "-format=csv", "bench", "texts/la.txt"}, []string{"speller", "bench", "texts/la.txt"}, "csv", false},
+		{"table", []string{"election", "Alice"}, []string{"election", "Alice"}, "table", false},
+		{"json", []string{"election", "--format", "text"}, []string{"election"}, "text", false},
+		{"", []string{"recover", "--", "--format", "json"}, []string{"recover", "--", "--format", "json"}, "text", false},
+		{"", []string{"recover", "-formats"}, []string{"recover", "-formats"}, "text", false},
+		{"", []string{"credit", "--format"}, nil, "", true},
+		{"", []string{"credit", "--format", "xml"}, nil, "", true},
+		{"yaml", []string{"credit"}, nil, "", true},
+	}
+	for _, test := range tests {
+		t.Setenv(FORMAT_ENV, test.env)
+		f, rest, err := Args(test.args)
+		name := fmt.Sprintf("$%s=%q, Args(%q)", FORMAT_ENV, test.env, test.args)
+		switch {
+		case test.err != (err != nil):
+			t.Errorf("%s: error %v", name, err)
+		case test.err:
+		case !slices.Equal(rest, test.rest):
+			t.Errorf("%s = %q, want %q", name, rest, test.rest)
+		case f != test.want:
+			t.Errorf("%s = %q, want %q", name, f, test.want)
+		}
+	}
+}
+
+type tally struct {
+	Method    string   `json:"method"`
+	Candidate string   `json:"candidate"`
+	Votes     int      `json:"votes"`
+	Share     float64  `json:"share"`
+	Winners   []string `json:"winners,omitempty"`
+	note      string
+	Skipped   bool `json:"-"`
+}
+
+func TestFprint(t *testing.T) {
+	rows := []tally{
+		{"plurality", "Alice", 2, 0.5, []string{"Alice", "Bob"}, "", false},
+		{"plurality", "Bob, Jr.", 2, 0.5, nil, "", true},
+	}
+	tests := []struct {
+		format string
+		v      any
+		want   string
+	}{
+		{"table", rows, "method     candidate  votes  share  winners\n" +
+			"plurality  Alice      2      0.5    Alice Bob\n" +
+			"plurality  Bob, Jr.   2      0.5    \n"},
+		{"csv", rows, "method,candidate,votes,share,winners\n" +
+			"plurality,Alice,2,0.5,Alice Bob\n" +
+			"plurality,\"Bob, Jr.\",2,0.5,\n"},
+		{"table", rows[0], "method     plurality\ncandidate  Alice\nvotes      2\nshare      0.5\nwinners    Alice Bob\n"},
+		{"csv", &rows[0], "method,candidate,votes,share,winners\nplurality,Alice,2,0.5,Alice Bob\n"},
+		{"json", rows[1:], "[\n  {\n    \"method\": \"plurality\",\n    \"candidate\": \"Bob, Jr.\",\n    \"votes\": 2,\n    \"share\": 0.5\n  }\n]\n"},
+	}
+	for _, test := range tests {
+		var out bytes.Buffer
+		if err := Fprint(&out, test.format, test.v); err != nil {
+			t.Errorf("Fprint(%s, %T): %v", test.format, test.v, err)
+		} else if out.String() != test.want {
+			t.Errorf("Fprint(%s, %T) =\n%s\nwant\n%s", test.format, test.v, out.String(), test.want)
+		}
+	}
+
+	for _, bad := range []struct {
+		format string
+		v      any
+	}{{"table", 3}, {"csv", []int{1}}, {"text", rows}, {"xml", rows}} {
+		if err := Fprint(&bytes.Buffer{}, bad.format, bad.v); err == nil {
+			t.Errorf("Fprint(%s, %T) didn't fail", bad.format, bad.v)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module rngutil
+
+go 1.24.4
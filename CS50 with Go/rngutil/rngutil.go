@@ -0,0 +1,48 @@
+// Package rngutil is where the programs get their randomness: a *rand.Rand
+// from a -seed flag, so a run can be had again, or from crypto/rand when
+// there isn't one. Nothing uses math/rand's global functions, whose seed
+// can't be printed or given back.
+//
+//	seed := flag.Int64("seed", 0, rngutil.SEED_USAGE)
+//	flag.Parse()
+//	rng := rngutil.New(seed) // *seed is the one used now, to print
+package rngutil
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
+
+// SEED_USAGE is the -seed flag's usage, for the programs without a word
+// of their own for it.
+const SEED_USAGE = "random seed, to get the same run again (0 = random)"
+
+// New returns a generator seeded with *seed. A seed of 0 is a random one,
+// which New puts in *seed, so the program can print it and the run can be
+// had again with -seed.
+func New(seed *int64) *rand.Rand {
+	if *seed == 0 {
+		*seed = Seed()
+	}
+	return rand.New(rand.NewSource(*seed))
+}
+
+// Random returns a generator with a random seed, for the packages whose
+// callers may not pass one.
+func Random() *rand.Rand {
+	return rand.New(rand.NewSource(Seed()))
+}
+
+// Seed is a random seed from crypto/rand, never 0, which is "random" to
+// the -seed flags. It's positive, so it reads as a flag does.
+func Seed() int64 {
+	var b [8]byte
+	for {
+		// crypto/rand.Read doesn't fail; it crashes the program first.
+		crand.Read(b[:])
+		if seed := int64(binary.LittleEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package rngutil
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	seed := int64(50)
+	a, b := New(&seed), New(&seed)
+	for range 10 {
+		if x, y := a.Int63(), b.Int63(); x != y {
+			t.Fatalf("seed 50 gave %d and %d", x, y)
+		}
+	}
+	if seed != 50 {
+		t.Errorf("New changed seed 50 to %d", seed)
+	}
+
+	// 0 is a random seed, told back so it can be given again.
+	zero := int64(0)
+	first := New(&zero).Int63()
+	if zero <= 0 {
+		t.Fatalf("New(0) used seed %d", zero)
+	}
+	if again := New(&zero).Int63(); again != first {
+		t.Errorf("seed %d gave %d, then %d", zero, first, again)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	seen := map[int64]bool{}
+	for range 100 {
+		s := Seed()
+		if s <= 0 || seen[s] {
+			t.Fatalf("Seed() = %d, after %d others", s, len(seen))
+		}
+		seen[s] = true
+	}
+}
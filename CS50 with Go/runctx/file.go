@@ -0,0 +1,43 @@
+package runctx
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+// File is an output file that's removed again if the program is stopped
+// before it's closed: half an image or a JPEG cut off is worse than none.
+type File struct {
+	*os.File
+	remove func()
+	once   sync.Once
+}
+
+// Create creates path, like os.Create, and registers its removal with
+// Defer until it's closed.
+func Create(path string) (*File, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	out := &File{File: f}
+	out.remove = Defer(func() {
+		f.Close()
+		os.Remove(path)
+	})
+	return out, nil
+}
+
+// Close closes the file, which keeps it.
+func (f *File) Close() error {
+	f.once.Do(f.remove)
+	return f.File.Close()
+}
+
+// Discard closes the file and removes it, for an output that failed
+// half-way.
+func (f *File) Discard() error {
+	f.once.Do(f.remove)
+	return errors.Join(f.File.Close(), os.Remove(f.Name()))
+}
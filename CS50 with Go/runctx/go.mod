@@ -0,0 +1,3 @@
+module runctx
+
+go 1.24.4
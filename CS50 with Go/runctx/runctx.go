@@ -0,0 +1,205 @@
+// Package runctx is how a program stops when it's asked to. Ctrl-C
+// (SIGINT) or kill (SIGTERM) cancels its context, the cleanups it
+// registered run, the terminal is put back the way it was, and it exits
+// 130 or 143, as a shell expects. Without it a signal kills a program
+// where it stands: half a JPEG left on disk, echo still off after a
+// password prompt.
+//
+//	func main() {
+//		runctx.Main(func(ctx context.Context) int {
+//			out, err := runctx.Create("000.jpg") // gone again if interrupted before Close
+//			...
+//			return 0
+//		})
+//	}
+//
+// A program whose work doesn't look at a context calls Install instead: a
+// signal runs the cleanups and exits straight away.
+package runctx
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// The exit codes for a signal, 128 and its number, as a shell reports it.
+const (
+	EXIT_INTERRUPTED = 130 // SIGINT, Ctrl-C
+	EXIT_TERMINATED  = 143 // SIGTERM, kill
+)
+
+// GRACE is how long Main's run gets to return, once its context is
+// canceled, before the cleanups run and the program exits anyway. A
+// second signal doesn't wait.
+var GRACE = time.Second
+
+// Interrupted is the cause of the context when a signal canceled it.
+type Interrupted struct {
+	Signal os.Signal
+}
+
+func (e Interrupted) Error() string {
+	return "interrupted: " + e.Signal.String()
+}
+
+// Code is the exit code for the signal.
+func (e Interrupted) Code() int {
+	if e.Signal == syscall.SIGTERM {
+		return EXIT_TERMINATED
+	}
+	return EXIT_INTERRUPTED
+}
+
+var (
+	installed sync.Once
+	ctx       = context.Background()
+	cancel    context.CancelCauseFunc
+	signals   chan os.Signal
+	terminal  string // stty -g, when standard input is a terminal
+
+	mu       sync.Mutex
+	cleanups []*func()
+	watched  chan struct{} // while Main's run runs; closed when it returns
+
+	exiting sync.Mutex // held for good by the first Exit
+)
+
+// Install starts handling SIGINT and SIGTERM, once: the first cancels the
+// context and, unless Main is waiting on its run, runs the cleanups and
+// exits.
+func Install() {
+	installed.Do(func() {
+		ctx, cancel = context.WithCancelCause(context.Background())
+		terminal = sttyState()
+		signals = make(chan os.Signal, 2)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go handle()
+	})
+}
+
+// Context is the program's context, canceled by the first signal with an
+// Interrupted cause. It installs the handling, if nothing has.
+func Context() context.Context {
+	Install()
+	return ctx
+}
+
+// Main runs run with the program's context and exits with its code, after
+// the cleanups. When a signal canceled the context the code is the
+// signal's, whatever run returned: it was stopped, not done.
+func Main(run func(ctx context.Context) int) {
+	Install()
+	done := make(chan struct{})
+	mu.Lock()
+	watched = done
+	mu.Unlock()
+	code := run(ctx)
+	close(done)
+	if cause, ok := InterruptedBy(ctx); ok {
+		code = cause.Code()
+	}
+	Exit(code)
+}
+
+// InterruptedBy reports whether a signal canceled ctx, and which.
+func InterruptedBy(ctx context.Context) (Interrupted, bool) {
+	var cause Interrupted
+	ok := errors.As(context.Cause(ctx), &cause)
+	return cause, ok
+}
+
+// handle waits for a signal and stops the program: at once, or after Main's
+// run has had GRACE to see its context is done.
+func handle() {
+	sig := <-signals
+	cause := Interrupted{sig}
+	cancel(cause)
+	mu.Lock()
+	done := watched
+	mu.Unlock()
+	if done != nil {
+		select {
+		case <-done:
+			return // Main exits
+		case <-signals:
+		case <-time.After(GRACE):
+		}
+	}
+	Exit(cause.Code())
+}
+
+// Defer registers cleanup to run when the program exits through Exit, Main
+// or a signal: the last registered first, each once. The program calls
+// remove when it's cleaned up itself, or doesn't need it any more. Defer
+// installs the handling, if nothing has.
+func Defer(cleanup func()) (remove func()) {
+	Install()
+	f := &cleanup
+	mu.Lock()
+	cleanups = append(cleanups, f)
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for i, g := range cleanups {
+			if g == f {
+				cleanups = append(cleanups[:i], cleanups[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Exit runs the cleanups that are still registered, puts the terminal back
+// if a signal came, and exits with code, in place of os.Exit, which runs
+// nothing. A second Exit, from another goroutine, waits on the first.
+func Exit(code int) {
+	exiting.Lock()
+	Cleanup()
+	if _, ok := InterruptedBy(ctx); ok && terminal != "" {
+		stty(terminal)
+	}
+	os.Exit(code)
+}
+
+// Cleanup runs the cleanups that are registered, the last first, and
+// forgets them.
+func Cleanup() {
+	mu.Lock()
+	pending := cleanups
+	cleanups = nil
+	mu.Unlock()
+	for i := len(pending) - 1; i >= 0; i-- {
+		(*pending[i])()
+	}
+}
+
+// sttyState is the terminal's settings, as stty -g gives them to set back,
+// or "" when standard input isn't a terminal.
+func sttyState() string {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return ""
+	}
+	cmd := exec.Command("stty", "-g")
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// stty sets the terminal behind standard input to settings.
+func stty(settings string) error {
+	cmd := exec.Command("stty", settings)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
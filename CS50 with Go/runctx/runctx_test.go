@@ -0,0 +1,141 @@
+package runctx
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"syscall"
+	"testing"
+)
+
+func TestDefer(t *testing.T) {
+	var ran []string
+	Defer(func() { ran = append(ran, "first") })
+	remove := Defer(func() { ran = append(ran, "removed") })
+	Defer(func() { ran = append(ran, "last") })
+	remove()
+	remove() // twice is fine
+	Cleanup()
+	Cleanup() // and they run once
+	if want := []string{"last", "first"}; !slices.Equal(ran, want) {
+		t.Errorf("cleanups ran %q, want %q", ran, want)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	kept, gone, failed := filepath.Join(dir, "000.jpg"), filepath.Join(dir, "001.jpg"), filepath.Join(dir, "out.bmp")
+	for _, path := range []string{kept, gone, failed} {
+		f, err := Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.WriteString("\xff\xd8\xff\xe0")
+		switch path {
+		case kept:
+			if err := f.Close(); err != nil {
+				t.Fatal(err)
+			}
+		case failed:
+			if err := f.Discard(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	Cleanup() // as a signal would, with 001.jpg still open
+	for path, want := range map[string]bool{kept: true, gone: false, failed: false} {
+		if _, err := os.Stat(path); (err == nil) != want {
+			t.Errorf("%s there: %v, want %v", filepath.Base(path), err == nil, want)
+		}
+	}
+}
+
+func TestInterruptedBy(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	if _, ok := InterruptedBy(ctx); ok {
+		t.Error("interrupted before it was canceled")
+	}
+	cancel(Interrupted{syscall.SIGTERM})
+	if cause, ok := InterruptedBy(ctx); !ok || cause.Code() != EXIT_TERMINATED {
+		t.Errorf("InterruptedBy = %v, %v, want SIGTERM and %d", cause, ok, EXIT_TERMINATED)
+	}
+	if code := (Interrupted{os.Interrupt}).Code(); code != EXIT_INTERRUPTED {
+		t.Errorf("SIGINT's code = %d, want %d", code, EXIT_INTERRUPTED)
+	}
+}
+
+// TestSignal runs TestHelper in a process of its own and signals it once
+// it has an output open: whether Main's run returns or not, the output
+// goes and the exit code is the signal's.
+func TestSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no signals to send")
+	}
+	tests := []struct {
+		mode   string
+		signal os.Signal
+		code   int
+	}{
+		{"main", os.Interrupt, EXIT_INTERRUPTED},
+		{"install", syscall.SIGTERM, EXIT_TERMINATED},
+		{"stuck", os.Interrupt, EXIT_INTERRUPTED}, // Main's run never returns
+	}
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "partial.out")
+		cmd := exec.Command(os.Args[0], "-test.run=^TestHelper$")
+		cmd.Env = append(os.Environ(), "RUNCTX_HELPER="+test.mode, "RUNCTX_OUTPUT="+path)
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		if line, err := bufio.NewReader(out).ReadString('\n'); line != "ready\n" {
+			t.Fatalf("%s: helper said %q, %v", test.mode, line, err)
+		}
+		cmd.Process.Signal(test.signal)
+		err = cmd.Wait()
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) || exit.ExitCode() != test.code {
+			t.Errorf("%s: %v after %v, want exit %d", test.mode, err, test.signal, test.code)
+		}
+		if _, err := os.Stat(path); err == nil {
+			t.Errorf("%s: the partial output is still there", test.mode)
+		}
+	}
+}
+
+// TestHelper is the program TestSignal interrupts.
+func TestHelper(t *testing.T) {
+	mode := os.Getenv("RUNCTX_HELPER")
+	if mode == "" {
+		t.Skip("run by TestSignal")
+	}
+	start := func() {
+		if _, err := Create(os.Getenv("RUNCTX_OUTPUT")); err != nil {
+			os.Exit(2)
+		}
+		os.Stdout.WriteString("ready\n")
+	}
+	switch mode {
+	case "install":
+		Install()
+		start()
+		select {}
+	case "main", "stuck":
+		Main(func(ctx context.Context) int {
+			start()
+			if mode == "stuck" {
+				select {}
+			}
+			<-ctx.Done()
+			return 0
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"i18n"
+	"os"
+	"strings"
+
+	"cash/coins"
+)
+
+func main() {
+	i18n.Init()
+	currencyName := flag.String("currency", "usd", "coin set to use: "+strings.Join(coins.Names(), ", "))
+	breakdown := flag.Bool("coins", false, "say how many of each coin, before the total")
+	flag.Parse()
+
+	currency, ok := coins.Currencies[strings.ToLower(*currencyName)]
+	if !ok {
+		fmt.Println(i18n.T("cash.unknown_currency", *currencyName, strings.Join(coins.Names(), ", ")))
+		os.Exit(1)
+	}
+
+	// prompt until the change owed isn't negative (do-while in C)
+	var owed int
+	for {
+		owed = cs50.GetCurrency(i18n.T("cash.prompt", currency.Symbol))
+		if owed >= 0 {
+			break
+		}
+	}
+
+	// greedy: biggest coin first
+	change := coins.Change(owed, currency)
+	if *breakdown {
+		// not in the spec, which prints the total alone
+		fmt.Println()
+		for _, c := range change.Counts {
+			fmt.Printf("%-10s %d\n", c.Coin.Name, c.N)
+		}
+	}
+	if change.Remainder > 0 {
+		fmt.Println(i18n.T("cash.too_small", change.Remainder))
+	}
+	fmt.Println(change.Total())
+}
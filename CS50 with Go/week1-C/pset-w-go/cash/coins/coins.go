@@ -0,0 +1,125 @@
+// Package coins makes change greedily: always take the largest coin that
+// still fits. That's optimal for "canonical" coin systems like US cents or
+// Thai baht, which is every real-world set this package ships.
+package coins
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Coin is one denomination, valued in the currency's smallest unit.
+type Coin struct {
+	Name  string
+	Value int
+}
+
+// Currency is a set of coins, largest first.
+type Currency struct {
+	Name   string
+	Symbol string
+	Coins  []Coin
+}
+
+// USD is the cash pset's original set: quarters, dimes, nickels and pennies.
+var USD = Currency{
+	Name:   "usd",
+	Symbol: "$",
+	Coins: []Coin{
+		{"quarters", 25},
+		{"dimes", 10},
+		{"nickels", 5},
+		{"pennies", 1},
+	},
+}
+
+// THB is Thai baht coins in satang (100 satang = 1 baht). There is no coin
+// below 25 satang, so some amounts leave a remainder.
+var THB = Currency{
+	Name:   "thb",
+	Symbol: "฿",
+	Coins: []Coin{
+		{"10 baht", 1000},
+		{"5 baht", 500},
+		{"2 baht", 200},
+		{"1 baht", 100},
+		{"50 satang", 50},
+		{"25 satang", 25},
+	},
+}
+
+// Currencies is every known currency by name.
+var Currencies = map[string]Currency{
+	USD.Name: USD,
+	THB.Name: THB,
+}
+
+// Names returns the known currency names, sorted.
+func Names() []string {
+	names := make([]string, 0, len(Currencies))
+	for name := range Currencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Count is how many of one coin to give.
+type Count struct {
+	Coin Coin
+	N    int
+}
+
+// Breakdown is the change for one amount: a count per coin (largest first)
+// and whatever was too small for the smallest coin.
+type Breakdown struct {
+	Counts    []Count
+	Remainder int
+}
+
+// Total returns the number of coins.
+func (b Breakdown) Total() int {
+	total := 0
+	for _, c := range b.Counts {
+		total += c.N
+	}
+	return total
+}
+
+// String lists the non-zero counts, e.g. "1 quarters, 1 dimes, 1 nickels".
+func (b Breakdown) String() string {
+	var parts []string
+	for _, c := range b.Counts {
+		if c.N > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.N, c.Coin.Name))
+		}
+	}
+	if len(parts) == 0 {
+		return "no coins"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Coins returns the change for cents in US coins.
+func Coins(cents int) Breakdown {
+	return Change(cents, USD)
+}
+
+// Change returns the change for amount (in the currency's smallest unit)
+// using the greedy algorithm. Negative amounts give no coins.
+func Change(amount int, currency Currency) Breakdown {
+	b := Breakdown{Counts: make([]Count, len(currency.Coins))}
+	for i, coin := range currency.Coins {
+		b.Counts[i] = Count{Coin: coin}
+		if amount <= 0 {
+			continue
+		}
+		b.Counts[i].N = amount / coin.Value
+		amount %= coin.Value
+	}
+	if amount > 0 {
+		b.Remainder = amount
+	}
+	return b
+}
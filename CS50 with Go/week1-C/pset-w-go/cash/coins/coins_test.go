@@ -0,0 +1,62 @@
+package coins
+
+import "testing"
+
+func TestCoins(t *testing.T) {
+	tests := []struct {
+		cents                             int
+		quarters, dimes, nickels, pennies int
+		total                             int
+	}{
+		{0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 1, 1},
+		{15, 0, 1, 1, 0, 2},
+		{41, 1, 1, 1, 1, 4},
+		{70, 2, 2, 0, 0, 4},
+		{160, 6, 1, 0, 0, 7},
+		{2300, 92, 0, 0, 0, 92},
+		{-5, 0, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		b := Coins(tt.cents)
+		got := [4]int{b.Counts[0].N, b.Counts[1].N, b.Counts[2].N, b.Counts[3].N}
+		want := [4]int{tt.quarters, tt.dimes, tt.nickels, tt.pennies}
+		if got != want || b.Total() != tt.total || b.Remainder != 0 {
+			t.Errorf("Coins(%d) = %v (total %d, remainder %d), want %v (total %d)",
+				tt.cents, got, b.Total(), b.Remainder, want, tt.total)
+		}
+	}
+}
+
+func TestChangeTHB(t *testing.T) {
+	tests := []struct {
+		satang    int
+		want      string
+		total     int
+		remainder int
+	}{
+		{1875, "1 10 baht, 1 5 baht, 1 2 baht, 1 1 baht, 1 50 satang, 1 25 satang", 6, 0},
+		{4000, "4 10 baht", 4, 0},
+		{130, "1 1 baht, 1 25 satang", 2, 5},
+		{10, "no coins", 0, 10},
+	}
+	for _, tt := range tests {
+		b := Change(tt.satang, THB)
+		if b.String() != tt.want || b.Total() != tt.total || b.Remainder != tt.remainder {
+			t.Errorf("Change(%d, THB) = %q (total %d, remainder %d), want %q (total %d, remainder %d)",
+				tt.satang, b, b.Total(), b.Remainder, tt.want, tt.total, tt.remainder)
+		}
+	}
+}
+
+// Every shipped currency is listed largest coin first, or greedy breaks.
+func TestCurrenciesSorted(t *testing.T) {
+	for _, name := range Names() {
+		c := Currencies[name]
+		for i := 1; i < len(c.Coins); i++ {
+			if c.Coins[i].Value >= c.Coins[i-1].Value {
+				t.Errorf("%s: %s listed after %s", name, c.Coins[i].Name, c.Coins[i-1].Name)
+			}
+		}
+	}
+}
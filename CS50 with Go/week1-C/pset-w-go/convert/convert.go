@@ -0,0 +1,80 @@
+// Unit converter lab: temperature, length and weight.
+//
+//	./convert -from C -to F 100       100 °C = 212 °F
+//	./convert -from mi -to km 26.2
+//	./convert                         menus to pick the units
+//	./convert -list                   every unit it knows
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"convert/units"
+)
+
+func main() {
+	from := flag.String("from", "", "unit to convert from (symbol or name)")
+	to := flag.String("to", "", "unit to convert to")
+	list := flag.Bool("list", false, "list every unit")
+	flag.Parse()
+
+	if *list {
+		printUnits()
+		return
+	}
+
+	// Flags for scripts, menus for everyone else
+	var value float64
+	if *from != "" || *to != "" {
+		if *from == "" || *to == "" || flag.NArg() != 1 {
+			fmt.Println("Usage: ./convert -from unit -to unit value")
+			os.Exit(1)
+		}
+		v, err := strconv.ParseFloat(flag.Arg(0), 64)
+		if err != nil {
+			fmt.Println("Usage: ./convert -from unit -to unit value")
+			os.Exit(1)
+		}
+		value = v
+	} else {
+		quantities := units.Quantities()
+		quantity := quantities[cs50.GetChoice("Convert: ", quantities)]
+		options := units.Units(quantity)
+		names := make([]string, len(options))
+		for i, u := range options {
+			names[i] = fmt.Sprintf("%s (%s)", u.Name, u.Symbol)
+		}
+		*from = options[cs50.GetChoice("From: ", names)].Symbol
+		*to = options[cs50.GetChoice("To: ", names)].Symbol
+		value = cs50.GetDouble("Value: ")
+	}
+
+	result, err := units.Convert(value, *from, *to)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fromUnit, _ := units.Lookup(*from)
+	toUnit, _ := units.Lookup(*to)
+	fmt.Printf("%s %s = %s %s\n", format(value), fromUnit.Symbol, format(result), toUnit.Symbol)
+}
+
+// format prints up to 6 significant digits without trailing zeros.
+func format(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// printUnits lists the units of every quantity.
+func printUnits() {
+	for _, quantity := range units.Quantities() {
+		fmt.Printf("%s:\n", quantity)
+		for _, u := range units.Units(quantity) {
+			fmt.Printf("  %-6s %s\n", u.Symbol, u.Name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package units
+
+// The built-in units. Each table is in base units of its quantity: kelvin,
+// metres and kilograms. Adding a row is all it takes to add a unit.
+
+var TEMPERATURES = []Unit{
+	Affine("degrees Celsius", "°C", 1, 273.15, "C", "celsius"),
+	Affine("degrees Fahrenheit", "°F", 5.0/9, 273.15-32*5.0/9, "F", "fahrenheit"),
+	Affine("kelvin", "K", 1, 0, "kelvins"),
+}
+
+var LENGTHS = []Unit{
+	Linear("millimetres", "mm", 0.001, "millimeters"),
+	Linear("centimetres", "cm", 0.01, "centimeters"),
+	Linear("metres", "m", 1, "meters", "metre", "meter"),
+	Linear("kilometres", "km", 1000, "kilometers"),
+	Linear("inches", "in", 0.0254, "inch"),
+	Linear("feet", "ft", 0.3048, "foot"),
+	Linear("yards", "yd", 0.9144, "yard"),
+	Linear("miles", "mi", 1609.344, "mile"),
+	Linear("wah", "wa", 2, "วา"), // Thai unit, exactly 2 m
+}
+
+var WEIGHTS = []Unit{
+	Linear("grams", "g", 0.001, "gram"),
+	Linear("kilograms", "kg", 1, "kilogram", "kilo"),
+	Linear("tonnes", "t", 1000, "tonne"),
+	Linear("ounces", "oz", 0.028349523125, "ounce"),
+	Linear("pounds", "lb", 0.45359237, "pound", "lbs"),
+	Linear("stone", "st", 6.35029318),
+	Linear("baht weight", "baht", 0.015244, "บาท"), // for gold
+}
+
+func init() {
+	for quantity, table := range map[string][]Unit{
+		"temperature": TEMPERATURES,
+		"length":      LENGTHS,
+		"weight":      WEIGHTS,
+	} {
+		for _, u := range table {
+			MustRegister(quantity, u)
+		}
+	}
+}
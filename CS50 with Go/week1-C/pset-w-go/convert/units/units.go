@@ -0,0 +1,115 @@
+// Package units converts between units of the same quantity (temperature,
+// length, weight ...). Every unit knows how to get to and from its
+// quantity's base unit, so any two units convert through the base and a new
+// unit only has to be registered once.
+package units
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Unit is one unit of a quantity.
+type Unit struct {
+	Name     string // "degrees Celsius"
+	Symbol   string // "°C"
+	Aliases  []string
+	Quantity string
+
+	// ToBase and FromBase convert a value in this unit to the base unit
+	// and back.
+	ToBase, FromBase func(float64) float64
+}
+
+// Linear is a unit that is factor base units, like 1 ft = 0.3048 m.
+func Linear(name, symbol string, factor float64, aliases ...string) Unit {
+	return Unit{
+		Name: name, Symbol: symbol, Aliases: aliases,
+		ToBase:   func(v float64) float64 { return v * factor },
+		FromBase: func(v float64) float64 { return v / factor },
+	}
+}
+
+// Affine is a unit with its own zero: base = v*scale + offset, like
+// K = °C + 273.15.
+func Affine(name, symbol string, scale, offset float64, aliases ...string) Unit {
+	return Unit{
+		Name: name, Symbol: symbol, Aliases: aliases,
+		ToBase:   func(v float64) float64 { return v*scale + offset },
+		FromBase: func(v float64) float64 { return (v - offset) / scale },
+	}
+}
+
+// registry maps a quantity to its units in registration order, and every
+// lowercase symbol, name and alias to its unit.
+var (
+	quantities = map[string][]*Unit{}
+	lookup     = map[string]*Unit{}
+)
+
+// Register adds u to quantity. Symbols, names and aliases have to be unique
+// across all quantities, ignoring case.
+func Register(quantity string, u Unit) error {
+	if u.ToBase == nil || u.FromBase == nil {
+		return fmt.Errorf("units: %s has no conversion", u.Name)
+	}
+	keys := append([]string{u.Symbol, u.Name}, u.Aliases...)
+	for _, key := range keys {
+		if existing, ok := lookup[strings.ToLower(key)]; ok {
+			return fmt.Errorf("units: %q is already %s", key, existing.Name)
+		}
+	}
+
+	u.Quantity = quantity
+	unit := &u
+	quantities[quantity] = append(quantities[quantity], unit)
+	for _, key := range keys {
+		lookup[strings.ToLower(key)] = unit
+	}
+	return nil
+}
+
+// MustRegister is Register for the built-in tables, which can't clash.
+func MustRegister(quantity string, u Unit) {
+	if err := Register(quantity, u); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup finds a unit by symbol, name or alias, ignoring case.
+func Lookup(s string) (*Unit, bool) {
+	u, ok := lookup[strings.ToLower(strings.TrimSpace(s))]
+	return u, ok
+}
+
+// Quantities returns every quantity name, sorted.
+func Quantities() []string {
+	names := make([]string, 0, len(quantities))
+	for name := range quantities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Units returns the units of quantity in the order they were registered.
+func Units(quantity string) []*Unit {
+	return append([]*Unit(nil), quantities[quantity]...)
+}
+
+// Convert converts value from one unit to another of the same quantity.
+func Convert(value float64, from, to string) (float64, error) {
+	fromUnit, ok := Lookup(from)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	toUnit, ok := Lookup(to)
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if fromUnit.Quantity != toUnit.Quantity {
+		return 0, fmt.Errorf("can't convert %s (%s) to %s (%s)", fromUnit.Symbol, fromUnit.Quantity, toUnit.Symbol, toUnit.Quantity)
+	}
+	return toUnit.FromBase(fromUnit.ToBase(value)), nil
+}
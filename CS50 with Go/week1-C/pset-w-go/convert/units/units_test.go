@@ -0,0 +1,85 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{100, "C", "F", 212},
+		{32, "°F", "celsius", 0},
+		{-40, "F", "C", -40},
+		{0, "K", "C", -273.15},
+		{98.6, "F", "K", 310.15},
+		{1, "mi", "km", 1.609344},
+		{12, "in", "ft", 1},
+		{3, "ft", "yd", 1},
+		{1, "wa", "m", 2},
+		{1, "lb", "oz", 16},
+		{14, "lb", "st", 1},
+		{1, "t", "kg", 1000},
+		{2, "baht", "g", 30.488},
+		{5, "Kilometres", "METRES", 5000},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.value, tt.from, tt.to)
+		if err != nil || !near(got, tt.want) {
+			t.Errorf("Convert(%v, %s, %s) = %v, %v; want %v", tt.value, tt.from, tt.to, got, err, tt.want)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, tt := range []struct{ from, to string }{
+		{"kg", "m"},     // different quantities
+		{"C", "lb"},     // different quantities
+		{"parsec", "m"}, // unknown
+		{"m", ""},
+	} {
+		if _, err := Convert(1, tt.from, tt.to); err == nil {
+			t.Errorf("Convert(1, %q, %q): want error", tt.from, tt.to)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Every pair of units of a quantity, there and back
+	for _, quantity := range Quantities() {
+		for _, a := range Units(quantity) {
+			for _, b := range Units(quantity) {
+				for _, v := range []float64{-40, 0, 1, 37.5, 1234.5678} {
+					there, err1 := Convert(v, a.Symbol, b.Symbol)
+					back, err2 := Convert(there, b.Symbol, a.Symbol)
+					if err1 != nil || err2 != nil || !near(back, v) {
+						t.Errorf("%v %s -> %s -> %s = %v", v, a.Symbol, b.Symbol, a.Symbol, back)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	// New units plug into Convert without touching it
+	if err := Register("length", Linear("light-nanoseconds", "lns", 0.299792458)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := Convert(1, "lns", "cm"); !near(got, 29.9792458) {
+		t.Errorf("1 lns = %v cm", got)
+	}
+	if err := Register("length", Linear("metres again", "M", 1)); err == nil {
+		t.Error("registering m twice: want error")
+	}
+	if err := Register("length", Unit{Name: "broken", Symbol: "xx"}); err == nil {
+		t.Error("unit without conversion: want error")
+	}
+}
@@ -1,70 +1,58 @@
 package main
 
 import (
+	"context"
 	"cs50"
+	"exitcode"
 	"fmt"
+	"i18n"
+	"log/slog"
+	"logging"
+	"luhn"
+	"render"
+	"strconv"
 )
 
+// verdict is what --format prints: the card, and the sums that told it.
+type verdict struct {
+	Number   int64  `json:"number"`
+	Digits   int    `json:"digits"`
+	Checksum int    `json:"checksum"`
+	Valid    bool   `json:"valid"` // the checksum ends in 0
+	Card     string `json:"card"`  // AMEX, MASTERCARD, VISA or INVALID
+}
+
 func main (){
-	
+	i18n.Init()
+	logging.Init() // -v shows the number and its checksum
+	render.Init()
+	exitcode.Main(run)
+}
+
+// run is the pset, with an error where it can't go on; exitcode.Main
+// exits with its code.
+func run(ctx context.Context, args []string) error {
 	// prompt for input
-	creditNumber := cs50.GetLong("creditnumber: ")
-	fmt.Printf("Credit Number = %d \n", creditNumber)
+	creditNumber := cs50.GetLong(render.Prompt(i18n.T("credit.prompt")))
+	slog.Debug("credit number", "number", creditNumber)
 	
-	// calculate checksum
-	sumCheck := 0
-	workingNumber := creditNumber
-	digits := 0
-	position := 1
-
-	// loop umtil working_number > 0
-	for workingNumber > 0 {
-		lastDigit := int(workingNumber % 10)
-
-		if position%2 == 0 {
-			product := lastDigit * 2
-			sumOfDigits := (product / 10) + (product % 10)
-			fmt.Printf("Position %d (from right) has value: %d | product =%d \n | sum of digit = %d -> Adding to sum\n", position, lastDigit, product, sumOfDigits)
-			sumCheck += sumOfDigits
-		} else {
-			fmt.Printf("Position %d | value: %d -> Adding to sum\n", position, lastDigit)
-			sumCheck += lastDigit
-		}
-
-        workingNumber = workingNumber / 10
-        digits++
-        position++
-    }
-    fmt.Println("endloop---")
-    fmt.Printf("Total digits = %d\n", digits)
-    fmt.Printf("Final Checksum = %d\n\n", sumCheck)
-
-    // Check invalid
-    if sumCheck%10 == 0 {
-        fmt.Println("Yee! that's credit card for sure now let me see what is your card ^_^, Pls wait a second.")
-
-        // ----Define start 2 digit----
-        startDigitsDivisor := int64(1)
-        for i := 0; i < digits-2; i++ {
-            startDigitsDivisor *= 10
+	// calculate checksum and work out the card, with the luhn package
+	card, err := luhn.Check(strconv.FormatInt(creditNumber, 10))
+	if err != nil {
+		// a negative number: no card's
+		card = luhn.Card{Brand: "INVALID"}
+	}
+	slog.Debug("checksum", "digits", len(card.Number), "sum", card.Checksum)
+
+    if render.Text() {
+        if card.Valid {
+            fmt.Println(i18n.T("credit.checksum"))
         }
-        startDigits := int(creditNumber / startDigitsDivisor)
-
-        // ----start check card----
-        // AMEX : 15 digits, start 34 || 37
-        if digits == 15 && (startDigits == 34 || startDigits == 37) {
-            fmt.Println("AMEX")
-        } else if digits == 16 && (startDigits >= 51 && startDigits <= 55) {
-            // MasterCard: 16 digits, start 51-55
-            fmt.Println("MASTERCARD")
-        } else if (digits == 13 || digits == 16) && (startDigits/10 == 4) {
-            // Visa: 13 || 16 digit, start 4
-            fmt.Println("VISA")
-        } else {
-            // not match any, should be another card, say "INVALID"
-            fmt.Println("INVALID")
-        }
-    } else {
-        fmt.Println("INVALID")
+        fmt.Println(card.Brand)
+        return nil
+    }
+    if err := render.Print(verdict{creditNumber, len(card.Number), card.Checksum, card.Valid, card.Brand}); err != nil {
+        return exitcode.Internal("can't print the verdict", err)
     }
+    return nil
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"exitcode"
+)
+
+// RUN_ENV makes the test binary run's process instead: cs50 reads the
+// number from os.Stdin, which a test can't swap once the package is loaded.
+const RUN_ENV = "CREDIT_TEST_RUN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(RUN_ENV) != "" {
+		os.Exit(exitcode.Handle(os.Stderr, run(context.Background(), nil)))
+	}
+	os.Exit(m.Run())
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"378282246310005\n", "AMEX\n"},
+		{"5105105105105100\n", "MASTERCARD\n"},
+		{"4111111111111111\n", "VISA\n"},
+		{"4222222222222\n", "VISA\n"},
+		{"1234567890\n", "INVALID\n"},
+		{"6011111111111117\n", "INVALID\n"}, // the checksum's right, but Discover isn't one of them
+		{"hello\n4003600000000014\n", "VISA\n"},
+	}
+	for _, test := range tests {
+		cmd := exec.Command(os.Args[0])
+		cmd.Env = append(os.Environ(), RUN_ENV+"=1")
+		cmd.Stdin = strings.NewReader(test.input)
+		out, err := cmd.Output()
+		if err != nil || !strings.HasSuffix(string(out), test.want) {
+			t.Errorf("run with %q = %q, %v, want it to end %q", test.input, out, err, test.want)
+		}
+	}
+}
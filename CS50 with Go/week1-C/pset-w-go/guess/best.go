@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// loadBest reads the fewest attempts per level name. A missing file means no
+// games yet.
+func loadBest(path string) (map[string]int, error) {
+	best := map[string]int{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return best, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &best); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return best, nil
+}
+
+// saveBest writes best to path, replacing the file in one step so a crash
+// can't leave it half written.
+func saveBest(path string, best map[string]int) error {
+	data, err := json.MarshalIndent(best, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
@@ -0,0 +1,13 @@
+module guess
+
+go 1.24.4
+
+require (
+	cs50 v0.0.0
+	rngutil v0.0.0
+)
+
+replace (
+	cs50 => ../../../cs50
+	rngutil => ../../../rngutil
+)
@@ -0,0 +1,121 @@
+// Guessing game: the computer picks a number, you guess it with higher/lower
+// hints. The fewest attempts per difficulty are kept in a best-score file.
+//
+//	./guess                 asks for the difficulty
+//	./guess -level hard     1 to 1000
+//	./guess -seed 50        the same number every time (for testing)
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"rngutil"
+)
+
+// Level is a difficulty: the secret is between 1 and Max.
+type Level struct {
+	Name string
+	Max  int
+}
+
+// LEVELS from easiest to hardest.
+var LEVELS = []Level{
+	{"easy", 10},
+	{"medium", 100},
+	{"hard", 1000},
+}
+
+func main() {
+	levelName := flag.String("level", "", "easy, medium or hard (asked when not given)")
+	seed := flag.Int64("seed", 0, "pick the secret with this seed (0 = random)")
+	bestPath := flag.String("best", "best.json", "file keeping the best score per level")
+	flag.Parse()
+
+	// Choose the difficulty
+	level, ok := findLevel(*levelName)
+	for !ok {
+		if *levelName != "" {
+			fmt.Printf("Unknown level %q.\n", *levelName)
+		}
+		*levelName = cs50.GetString("Difficulty (easy, medium, hard): ")
+		level, ok = findLevel(*levelName)
+	}
+
+	// Seed the random number generator
+	rng := rngutil.New(seed)
+	secret := rng.Intn(level.Max) + 1
+
+	fmt.Printf("I'm thinking of a number between 1 and %d.\n", level.Max)
+	attempts := play(secret, func() int {
+		for {
+			guess := cs50.GetInt("Guess: ")
+			if guess >= 1 && guess <= level.Max {
+				return guess
+			}
+			fmt.Printf("Between 1 and %d, please.\n", level.Max)
+		}
+	}, os.Stdout)
+
+	// Keep the best score
+	best, err := loadBest(*bestPath)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	previous, played := best[level.Name]
+	switch {
+	case !played:
+		fmt.Printf("First %s game: %d attempts to beat next time.\n", level.Name, attempts)
+	case attempts < previous:
+		fmt.Printf("New best for %s! (was %d)\n", level.Name, previous)
+	default:
+		fmt.Printf("Best for %s is still %d.\n", level.Name, previous)
+	}
+	if !played || attempts < previous {
+		best[level.Name] = attempts
+		if err := saveBest(*bestPath, best); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+}
+
+// findLevel looks a level up by name or first letter.
+func findLevel(name string) (Level, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, level := range LEVELS {
+		if name != "" && strings.HasPrefix(level.Name, name) {
+			return level, true
+		}
+	}
+	return Level{}, false
+}
+
+// play asks next for guesses until one is secret, printing a hint after
+// each miss, and returns the number of guesses.
+func play(secret int, next func() int, w io.Writer) int {
+	for attempts := 1; ; attempts++ {
+		switch guess := next(); {
+		case guess < secret:
+			fmt.Fprintln(w, "Higher!")
+		case guess > secret:
+			fmt.Fprintln(w, "Lower!")
+		default:
+			fmt.Fprintf(w, "Got it in %d %s!\n", attempts, plural(attempts, "attempt"))
+			return attempts
+		}
+	}
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
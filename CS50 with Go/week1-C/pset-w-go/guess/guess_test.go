@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlay(t *testing.T) {
+	// A binary search finds any number from 1 to 100 in at most 7 guesses
+	for secret := 1; secret <= 100; secret++ {
+		lo, hi, guess := 1, 100, 0
+		var out bytes.Buffer
+		attempts := play(secret, func() int {
+			// Narrow the range with the hint from the last guess
+			switch {
+			case bytes.HasSuffix(out.Bytes(), []byte("Higher!\n")):
+				lo = guess + 1
+			case bytes.HasSuffix(out.Bytes(), []byte("Lower!\n")):
+				hi = guess - 1
+			}
+			guess = (lo + hi) / 2
+			return guess
+		}, &out)
+		if attempts > 7 {
+			t.Errorf("secret %d took %d attempts:\n%s", secret, attempts, out.String())
+		}
+	}
+}
+
+func TestPlayHints(t *testing.T) {
+	guesses := []int{50, 25, 37, 42}
+	var out bytes.Buffer
+	attempts := play(42, func() int {
+		g := guesses[0]
+		guesses = guesses[1:]
+		return g
+	}, &out)
+	want := "Lower!\nHigher!\nHigher!\nGot it in 4 attempts!\n"
+	if attempts != 4 || out.String() != want {
+		t.Errorf("play = %d, printed %q", attempts, out.String())
+	}
+}
+
+func TestFindLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		max  int
+		ok   bool
+	}{
+		{"easy", 10, true},
+		{"M", 100, true},
+		{" hard ", 1000, true},
+		{"", 0, false},
+		{"expert", 0, false},
+	}
+	for _, tt := range tests {
+		level, ok := findLevel(tt.name)
+		if ok != tt.ok || level.Max != tt.max {
+			t.Errorf("findLevel(%q) = %+v, %v", tt.name, level, ok)
+		}
+	}
+}
+
+func TestBest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "best.json")
+	best, err := loadBest(path)
+	if err != nil || len(best) != 0 {
+		t.Fatalf("missing file: %v, %v", best, err)
+	}
+
+	best["easy"] = 3
+	best["hard"] = 9
+	if err := saveBest(path, best); err != nil {
+		t.Fatal(err)
+	}
+	back, err := loadBest(path)
+	if err != nil || back["easy"] != 3 || back["hard"] != 9 || len(back) != 2 {
+		t.Errorf("round trip: %v, %v", back, err)
+	}
+
+	os.WriteFile(path, []byte("not json"), 0o644)
+	if _, err := loadBest(path); err == nil {
+		t.Error("corrupt file: want error")
+	}
+}
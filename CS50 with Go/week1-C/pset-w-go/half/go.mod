@@ -0,0 +1,7 @@
+module half
+
+go 1.24.4
+
+require cs50 v0.0.0
+
+replace cs50 => ../../../cs50
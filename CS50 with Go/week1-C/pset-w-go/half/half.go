@@ -0,0 +1,35 @@
+// Half lab: split a restaurant bill with a friend after tax and tip.
+//
+//	Bill before tax and tip: 50
+//	Sale Tax Percent: 10
+//	Tip percent: 20
+//	You will owe $33.00 each!
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+	"math"
+)
+
+func main() {
+	bill := cs50.GetCurrency("Bill before tax and tip: ")
+	tax := cs50.GetFloat("Sale Tax Percent: ")
+	tip := cs50.GetInt("Tip percent: ")
+
+	fmt.Printf("You will owe %s each!\n", dollars(half(bill, float64(tax), tip)))
+}
+
+// half adds tax to the bill, then tip on top of that, and returns half the
+// total in cents, rounded to the nearest cent.
+func half(bill int, tax float64, tip int) int {
+	total := float64(bill) * (1 + tax/100)
+	total *= 1 + float64(tip)/100
+	return int(math.Round(total / 2))
+}
+
+// dollars formats cents as "$33.00".
+func dollars(cents int) string {
+	return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestHalf(t *testing.T) {
+	// The first two are check50's
+	tests := []struct {
+		bill int
+		tax  float64
+		tip  int
+		want string
+	}{
+		{5000, 10, 20, "$33.00"},
+		{10000, 6.25, 18, "$62.69"},
+		{12000, 8.875, 15, "$75.12"},
+		{0, 10, 20, "$0.00"},
+		{1999, 0, 0, "$10.00"}, // 9.995 rounds up
+		{4250, 7, 15, "$26.15"},
+	}
+	for _, tt := range tests {
+		if got := dollars(half(tt.bill, tt.tax, tt.tip)); got != tt.want {
+			t.Errorf("half(%d, %v, %d) = %s, want %s", tt.bill, tt.tax, tt.tip, got, tt.want)
+		}
+	}
+}
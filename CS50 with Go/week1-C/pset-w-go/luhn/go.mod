@@ -0,0 +1,3 @@
+module luhn
+
+go 1.24.4
@@ -0,0 +1,80 @@
+// Package luhn is the credit pset's check without the prompts: Luhn's
+// checksum and which card (AMEX, MASTERCARD, VISA) a number belongs to.
+package luhn
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotDigits is returned for a number with anything but digits in it,
+// once spaces and dashes are taken out.
+var ErrNotDigits = errors.New("luhn: number must only contain digits")
+
+// Card is the credit pset's answer for a number.
+type Card struct {
+	Number   string // digits only
+	Checksum int    // the Luhn sum; valid when it ends in 0
+	Valid    bool
+	Brand    string // AMEX, MASTERCARD, VISA or INVALID
+}
+
+// Check reads number ("4003 6000 0000 0014" is fine) and works out its
+// checksum and brand.
+func Check(number string) (Card, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if digits == "" {
+		return Card{}, ErrNotDigits
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Card{}, ErrNotDigits
+		}
+	}
+
+	card := Card{Number: digits, Checksum: Checksum(digits)}
+	card.Valid = card.Checksum%10 == 0
+	card.Brand = "INVALID"
+	if card.Valid {
+		card.Brand = Brand(digits)
+	}
+	return card, nil
+}
+
+// Checksum returns Luhn's sum of digits: from the right, every second
+// digit is doubled and the digits of the products are added to the rest.
+func Checksum(digits string) int {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			product := digit * 2
+			digit = product/10 + product%10
+		}
+		sum += digit
+	}
+	return sum
+}
+
+// Brand names the card by its length and first digits, ignoring the
+// checksum:
+//
+//	AMEX        15 digits, starting 34 or 37
+//	MASTERCARD  16 digits, starting 51 to 55
+//	VISA        13 or 16 digits, starting 4
+func Brand(digits string) string {
+	n := len(digits)
+	if n < 2 {
+		return "INVALID"
+	}
+	start := int(digits[0]-'0')*10 + int(digits[1]-'0')
+	switch {
+	case n == 15 && (start == 34 || start == 37):
+		return "AMEX"
+	case n == 16 && start >= 51 && start <= 55:
+		return "MASTERCARD"
+	case (n == 13 || n == 16) && start/10 == 4:
+		return "VISA"
+	}
+	return "INVALID"
+}
@@ -0,0 +1,46 @@
+package luhn
+
+import (
+	"errors"
+	"testing"
+)
+
+// The credit pset's check50 numbers.
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		number string
+		brand  string
+	}{
+		{"378282246310005", "AMEX"},
+		{"371449635398431", "AMEX"},
+		{"5555555555554444", "MASTERCARD"},
+		{"5105105105105100", "MASTERCARD"},
+		{"4111111111111111", "VISA"},
+		{"4012888888881881", "VISA"},
+		{"4222222222222", "VISA"},
+		{"4003-6000-0000-0014", "VISA"},
+		{"4003 6000 0000 0014", "VISA"},
+		{"1234567890", "INVALID"},
+		{"369421438430814", "INVALID"}, // valid checksum, unknown start
+		{"4062901840", "INVALID"},      // VISA start, wrong length
+		{"5673598276138003", "INVALID"},
+		{"4111111111111113", "INVALID"}, // checksum off
+		{"0", "INVALID"},
+	}
+	for _, tt := range tests {
+		card, err := Check(tt.number)
+		if err != nil || card.Brand != tt.brand {
+			t.Errorf("Check(%q) = %+v, %v; want %s", tt.number, card, err, tt.brand)
+		}
+	}
+
+	card, _ := Check("4003600000000014")
+	if card.Checksum != 20 || !card.Valid || card.Number != "4003600000000014" {
+		t.Errorf("Check(4003600000000014) = %+v, want checksum 20", card)
+	}
+	for _, bad := range []string{"", " - ", "4003x600", "٤٠٠٣"} {
+		if _, err := Check(bad); !errors.Is(err, ErrNotDigits) {
+			t.Errorf("Check(%q) error = %v, want ErrNotDigits", bad, err)
+		}
+	}
+}
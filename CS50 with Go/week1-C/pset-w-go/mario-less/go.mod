@@ -1,3 +1,15 @@
 module mario-less
 
 go 1.24.4
+
+require (
+	cs50 v0.0.0
+	i18n v0.0.0
+	pyramid v0.0.0
+)
+
+replace (
+	cs50 => ../../../cs50
+	i18n => ../../../i18n
+	pyramid => ../pyramid
+)
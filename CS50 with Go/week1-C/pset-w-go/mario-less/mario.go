@@ -3,33 +3,27 @@ package main
 import (
 	"cs50"
 	"fmt"
+	"i18n"
+
+	"pyramid"
 )
 
 var h int
 
 func main (){
-	// get pyramid's actual height (loop until the number is positive integer)
+	i18n.Init()
+
+	// get pyramid's actual height (loop until it is between 1 and 8)
 	//do while loop in C
 	for {
-		h = cs50.GetInt("Actual Height= ")
-		if h >= 1 {
+		h = cs50.GetInt(i18n.T("mario.prompt"))
+		if h >= 1 && h <= 8 {
 			break
 		}
 	}
 
 	//print rows
-	for r := 0; r < h; r++ {
-		printRow(r + 1)
-	}
-}
-
-// print clumns
-func printRow(coll int) {
-	for sp := h; sp > coll; sp-- {
-		fmt.Print(" ")
-	}
-	for bri := 0; bri < coll; bri++ {
-		fmt.Print("#")
+	for _, row := range pyramid.Left(h) {
+		fmt.Println(row)
 	}
-	fmt.Println()
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+// Population growth lab: how many years until a llama population grows from
+// a start size to an end size, when n/3 are born and n/4 pass away each year?
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+)
+
+// MIN_START is the smallest population that grows: below 9, n/3 - n/4
+// rounds down to 0 every year.
+const MIN_START = 9
+
+func main() {
+	// Prompt for start size
+	var start int
+	for {
+		start = cs50.GetInt("Start size: ")
+		if start >= MIN_START {
+			break
+		}
+	}
+
+	// Prompt for end size
+	var end int
+	for {
+		end = cs50.GetInt("End size: ")
+		if end >= start {
+			break
+		}
+	}
+
+	// Calculate number of years until we reach threshold
+	years, _ := Years(start, end)
+
+	// Print number of years
+	fmt.Printf("Years: %d\n", years)
+}
+
+// Grow returns the population a year after n, with integer division like C.
+func Grow(n int) int {
+	return n + n/3 - n/4
+}
+
+// Years returns how many years it takes for start to reach at least end.
+// ok is false when it never gets there (start below MIN_START but end
+// above it).
+func Years(start, end int) (years int, ok bool) {
+	for n := start; n < end; years++ {
+		next := Grow(n)
+		if next == n {
+			return 0, false
+		}
+		n = next
+	}
+	return years, true
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestYears(t *testing.T) {
+	// The first five are check50's
+	tests := []struct {
+		start, end int
+		years      int
+		ok         bool
+	}{
+		{1200, 1300, 1, true},
+		{9, 9, 0, true},
+		{9, 18, 8, true},
+		{20, 100, 20, true},
+		{100, 1000000, 115, true},
+		{9, 10, 1, true},
+		{50, 10, 0, true}, // already there
+		{8, 100, 0, false},
+		{1, 2, 0, false},
+	}
+	for _, tt := range tests {
+		years, ok := Years(tt.start, tt.end)
+		if years != tt.years || ok != tt.ok {
+			t.Errorf("Years(%d, %d) = %d, %v, want %d, %v", tt.start, tt.end, years, ok, tt.years, tt.ok)
+		}
+	}
+}
+
+func TestGrow(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{9, 10},  // +3 born, -2 die
+		{12, 13}, // +4, -3
+		{100, 108},
+		{8, 8}, // +2, -2
+	}
+	for _, tt := range tests {
+		if got := Grow(tt.n); got != tt.want {
+			t.Errorf("Grow(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
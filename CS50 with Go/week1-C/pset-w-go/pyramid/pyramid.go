@@ -0,0 +1,32 @@
+// Package pyramid builds Mario's pyramids as lines of text, so mario-less
+// can print them and other programs can send them elsewhere.
+package pyramid
+
+import "strings"
+
+// MAX_HEIGHT is the tallest pyramid the pset asks for.
+const MAX_HEIGHT = 8
+
+// Left returns the rows of mario-less's right-aligned pyramid, top first:
+//
+//	   #
+//	  ##
+//	 ###
+//	####
+func Left(height int) []string {
+	rows := make([]string, 0, max(height, 0))
+	for r := 1; r <= height; r++ {
+		rows = append(rows, strings.Repeat(" ", height-r)+strings.Repeat("#", r))
+	}
+	return rows
+}
+
+// Double returns the rows of mario-more's pair of pyramids with a gap of
+// two between them.
+func Double(height int) []string {
+	rows := Left(height)
+	for i, row := range rows {
+		rows[i] = row + "  " + strings.Repeat("#", i+1)
+	}
+	return rows
+}
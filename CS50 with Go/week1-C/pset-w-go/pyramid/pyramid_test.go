@@ -0,0 +1,27 @@
+package pyramid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPyramids(t *testing.T) {
+	tests := []struct {
+		height int
+		left   []string
+		double []string
+	}{
+		{1, []string{"#"}, []string{"#  #"}},
+		{3, []string{"  #", " ##", "###"}, []string{"  #  #", " ##  ##", "###  ###"}},
+		{0, []string{}, []string{}},
+		{-1, []string{}, []string{}},
+	}
+	for _, tt := range tests {
+		if got := Left(tt.height); !slices.Equal(got, tt.left) {
+			t.Errorf("Left(%d) = %q, want %q", tt.height, got, tt.left)
+		}
+		if got := Double(tt.height); !slices.Equal(got, tt.double) {
+			t.Errorf("Double(%d) = %q, want %q", tt.height, got, tt.double)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Tip calculator, from the Scratch project to code: add a tip to the meal
+// and split the total between everyone at the table.
+//
+//	Meal cost: $84.50
+//	Tip percentage: 18%
+//	People: 3
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Split is what a meal comes to. Each has one entry per person and adds up
+// to Total exactly: the leftover cents go to the first people.
+type Split struct {
+	Tip, Total int
+	Each       []int
+}
+
+func main() {
+	meal := cs50.GetCurrency("Meal cost: $")
+
+	// Keep asking until the percentage makes sense
+	var percent float64
+	for {
+		p, err := parsePercent(cs50.GetString("Tip percentage: "))
+		if err == nil {
+			percent = p
+			break
+		}
+		fmt.Println(err)
+	}
+
+	var people int
+	for {
+		people = cs50.GetInt("People: ")
+		if people >= 1 {
+			break
+		}
+	}
+
+	split := tip(meal, percent, people)
+	fmt.Printf("Tip: %s\n", dollars(split.Tip))
+	fmt.Printf("Total: %s\n", dollars(split.Total))
+	if split.Each[0] == split.Each[people-1] {
+		fmt.Printf("Each person pays %s\n", dollars(split.Each[0]))
+		return
+	}
+	for i, amount := range split.Each {
+		fmt.Printf("Person %d pays %s\n", i+1, dollars(amount))
+	}
+}
+
+// tip works out the tip on meal (in cents) rounded to the nearest cent, and
+// splits meal plus tip between people.
+func tip(meal int, percent float64, people int) Split {
+	t := int(math.Round(float64(meal) * percent / 100))
+	split := Split{Tip: t, Total: meal + t, Each: make([]int, people)}
+	for i := range split.Each {
+		split.Each[i] = split.Total / people
+		if i < split.Total%people {
+			split.Each[i]++
+		}
+	}
+	return split
+}
+
+// parsePercent reads "15", "15%", "12.5 %" or "0.15" as it's typed into the
+// Scratch project. A fraction below 1 is taken as a fraction of the meal, so
+// "0.15" is 15% too.
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%"))
+	percent, err := strconv.ParseFloat(s, 64)
+	if err != nil || percent < 0 || math.IsInf(percent, 0) || math.IsNaN(percent) {
+		return 0, fmt.Errorf("Invalid percentage %q. Please enter something like 15%%.", s)
+	}
+	if percent > 0 && percent < 1 {
+		percent *= 100
+	}
+	return percent, nil
+}
+
+// dollars formats cents as "$28.50".
+func dollars(cents int) string {
+	return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTip(t *testing.T) {
+	tests := []struct {
+		meal    int
+		percent float64
+		people  int
+		tip     int
+		each    []int
+	}{
+		{8450, 18, 3, 1521, []int{3324, 3324, 3323}},
+		{10000, 15, 4, 1500, []int{2875, 2875, 2875, 2875}},
+		{1000, 12.5, 1, 125, []int{1125}},
+		{999, 20, 2, 200, []int{600, 599}},
+		{0, 20, 3, 0, []int{0, 0, 0}},
+		{333, 0, 5, 0, []int{67, 67, 67, 66, 66}},
+	}
+	for _, tt := range tests {
+		split := tip(tt.meal, tt.percent, tt.people)
+		if split.Tip != tt.tip || split.Total != tt.meal+tt.tip || !slices.Equal(split.Each, tt.each) {
+			t.Errorf("tip(%d, %v, %d) = %+v", tt.meal, tt.percent, tt.people, split)
+		}
+		sum := 0
+		for _, e := range split.Each {
+			sum += e
+		}
+		if sum != split.Total {
+			t.Errorf("tip(%d, %v, %d): shares add up to %d, not %d", tt.meal, tt.percent, tt.people, sum, split.Total)
+		}
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		s    string
+		want float64
+		ok   bool
+	}{
+		{"15", 15, true},
+		{"15%", 15, true},
+		{" 12.5 % ", 12.5, true},
+		{"0.15", 15, true},
+		{"0", 0, true},
+		{"100%", 100, true},
+		{"", 0, false},
+		{"-5%", 0, false},
+		{"fifteen", 0, false},
+		{"15%%", 0, false},
+		{"Inf", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parsePercent(tt.s)
+		if (err == nil) != tt.ok || tt.ok && got != tt.want {
+			t.Errorf("parsePercent(%q) = %v, %v", tt.s, got, err)
+		}
+	}
+}
@@ -2,9 +2,14 @@ module world
 
 go 1.24.4
 
-require rsc.io/quote v1.5.2
+require (
+	cs50 v0.0.0
+	rsc.io/quote v1.5.2
+)
 
 require (
 	golang.org/x/text v0.0.0-20170915032832-14c0d48ead0c // indirect
 	rsc.io/sampler v1.3.0 // indirect
 )
+
+replace cs50 => ../../../cs50
@@ -0,0 +1,79 @@
+// Package bits turns bytes into their 8 binary digits and back, most
+// significant bit first, the way the bulbs lab lights them up.
+package bits
+
+import "fmt"
+
+// BITS_IN_BYTE is how many bits (bulbs) each byte gets.
+const BITS_IN_BYTE = 8
+
+// Byte is the bits of one byte, most significant first: 'H' (72) is
+// {0, 1, 0, 0, 1, 0, 0, 0}.
+type Byte [BITS_IN_BYTE]uint8
+
+// FromByte splits b into its bits.
+func FromByte(b byte) Byte {
+	var bits Byte
+	for i := BITS_IN_BYTE - 1; i >= 0; i-- {
+		bits[i] = b % 2
+		b /= 2
+	}
+	return bits
+}
+
+// ToByte puts bits back together. Anything other than 0 counts as 1.
+func ToByte(bits Byte) byte {
+	var b byte
+	for _, bit := range bits {
+		b <<= 1
+		if bit != 0 {
+			b |= 1
+		}
+	}
+	return b
+}
+
+// Encode returns the bits of every byte of message.
+func Encode(message string) []Byte {
+	out := make([]Byte, len(message))
+	for i := 0; i < len(message); i++ {
+		out[i] = FromByte(message[i])
+	}
+	return out
+}
+
+// Decode is the reverse of Encode.
+func Decode(bytes []Byte) string {
+	out := make([]byte, len(bytes))
+	for i, bits := range bytes {
+		out[i] = ToByte(bits)
+	}
+	return string(out)
+}
+
+// String returns the bits as digits, e.g. "01001000".
+func (bits Byte) String() string {
+	var s [BITS_IN_BYTE]byte
+	for i, bit := range bits {
+		s[i] = '0' + bit
+	}
+	return string(s[:])
+}
+
+// Parse reads 8 binary digits written by String.
+func Parse(s string) (Byte, error) {
+	var bits Byte
+	if len(s) != BITS_IN_BYTE {
+		return bits, fmt.Errorf("bits: want %d digits, got %q", BITS_IN_BYTE, s)
+	}
+	for i := 0; i < BITS_IN_BYTE; i++ {
+		switch s[i] {
+		case '0':
+		case '1':
+			bits[i] = 1
+		default:
+			return bits, fmt.Errorf("bits: %q is not binary", s)
+		}
+	}
+	return bits, nil
+}
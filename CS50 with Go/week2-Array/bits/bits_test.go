@@ -0,0 +1,50 @@
+package bits
+
+import "testing"
+
+func TestFromByte(t *testing.T) {
+	tests := []struct {
+		b    byte
+		want string
+	}{
+		{0, "00000000"},
+		{1, "00000001"},
+		{'H', "01001000"},
+		{'I', "01001001"},
+		{'!', "00100001"},
+		{255, "11111111"},
+	}
+	for _, tt := range tests {
+		bits := FromByte(tt.b)
+		if bits.String() != tt.want {
+			t.Errorf("FromByte(%d) = %s, want %s", tt.b, bits, tt.want)
+		}
+		if ToByte(bits) != tt.b {
+			t.Errorf("ToByte(%s) = %d, want %d", bits, ToByte(bits), tt.b)
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for _, message := range []string{"", "HI!", "Hello, world", "สวัสดี"} {
+		if got := Decode(Encode(message)); got != message {
+			t.Errorf("Decode(Encode(%q)) = %q", message, got)
+		}
+	}
+	// One Byte per byte, so UTF-8 characters take several
+	if n := len(Encode("é")); n != 2 {
+		t.Errorf("Encode(\"é\") has %d bytes, want 2", n)
+	}
+}
+
+func TestParse(t *testing.T) {
+	bits, err := Parse("01001000")
+	if err != nil || ToByte(bits) != 'H' {
+		t.Errorf("Parse(01001000) = %s, %v", bits, err)
+	}
+	for _, bad := range []string{"", "0100100", "010010001", "0100100x"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q): want error", bad)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module bits
+
+go 1.24.4
@@ -0,0 +1,132 @@
+// Bulbs lab: turn a message into rows of light bulbs, one row of 8 per
+// character, on for 1 and off for 0. -d reads bulbs back into the message.
+//
+//	./bulbs            Message: HI!
+//	./bulbs -color     the same with coloured dots for terminals without emoji
+//	./bulbs | ./bulbs -d
+
+package main
+
+import (
+	"bufio"
+	"cs50"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bits"
+)
+
+// Each style is how a bulb that is off and on look.
+var STYLES = map[string][2]string{
+	"emoji": {"\U000026AB", "\U0001F7E1"},             // ⚫ 🟡
+	"color": {"\033[90m○\033[0m", "\033[93m●\033[0m"}, // grey, bright yellow
+	"bits":  {"0", "1"},
+}
+
+// PROMPT asks for the message.
+const PROMPT = "Message: "
+
+// OFF and ON are every character the decoder accepts as a bulb.
+const (
+	OFF = "\U000026AB○0"
+	ON  = "\U0001F7E1●1"
+)
+
+func main() {
+	decode := flag.Bool("d", false, "decode bulbs from standard input back into text")
+	color := flag.Bool("color", false, "draw bulbs as coloured dots instead of emoji")
+	digits := flag.Bool("bits", false, "draw bulbs as 0 and 1")
+	flag.Parse()
+
+	if *decode {
+		message, err := decodeBulbs(os.Stdin)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(message)
+		return
+	}
+
+	style := STYLES["emoji"]
+	switch {
+	case *color:
+		style = STYLES["color"]
+	case *digits:
+		style = STYLES["bits"]
+	}
+
+	message := cs50.GetString(PROMPT)
+	for _, b := range bits.Encode(message) {
+		printBulbs(os.Stdout, b, style)
+	}
+}
+
+// printBulbs prints one row of bulbs for the bits of one byte.
+func printBulbs(w io.Writer, b bits.Byte, style [2]string) {
+	var row strings.Builder
+	for _, bit := range b {
+		row.WriteString(style[bit])
+	}
+	fmt.Fprintln(w, row.String())
+}
+
+// decodeBulbs reads rows of 8 bulbs in any style and returns the message.
+// Blank lines are skipped, so pasted output with extra newlines still works,
+// and so is the prompt, so ./bulbs | ./bulbs -d works.
+func decodeBulbs(r io.Reader) (string, error) {
+	var bytes []bits.Byte
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		row := strings.TrimPrefix(stripEscapes(scanner.Text()), PROMPT)
+		if strings.TrimSpace(row) == "" {
+			continue
+		}
+
+		var b bits.Byte
+		n := 0
+		for _, c := range row {
+			var bit uint8
+			switch {
+			case c == ' ' || c == '\t' || c == '\uFE0F': // spaces, emoji variation selector
+				continue
+			case strings.ContainsRune(OFF, c):
+				bit = 0
+			case strings.ContainsRune(ON, c):
+				bit = 1
+			default:
+				return "", fmt.Errorf("line %d: %q is not a bulb", line, c)
+			}
+			if n == bits.BITS_IN_BYTE {
+				return "", fmt.Errorf("line %d: more than %d bulbs", line, bits.BITS_IN_BYTE)
+			}
+			b[n] = bit
+			n++
+		}
+		if n != bits.BITS_IN_BYTE {
+			return "", fmt.Errorf("line %d: %d bulbs, want %d", line, n, bits.BITS_IN_BYTE)
+		}
+		bytes = append(bytes, b)
+	}
+	return bits.Decode(bytes), scanner.Err()
+}
+
+// stripEscapes removes ANSI colour codes like "\033[93m".
+func stripEscapes(s string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\033' && i+1 < len(s) && s[i+1] == '[' {
+			// Skip to the final letter of the sequence
+			i += 2
+			for i < len(s) && !(s[i] >= '@' && s[i] <= '~') {
+				i++
+			}
+			continue
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String()
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"bits"
+)
+
+func TestPrintBulbs(t *testing.T) {
+	var out bytes.Buffer
+	for _, b := range bits.Encode("HI!") {
+		printBulbs(&out, b, STYLES["emoji"])
+	}
+	// From the lab's spec
+	want := "⚫🟡⚫⚫🟡⚫⚫⚫\n" +
+		"⚫🟡⚫⚫🟡⚫⚫🟡\n" +
+		"⚫⚫🟡⚫⚫⚫⚫🟡\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for name, style := range STYLES {
+		for _, message := range []string{"HI!", "This is CS50", "ภาษาไทย"} {
+			var out bytes.Buffer
+			for _, b := range bits.Encode(message) {
+				printBulbs(&out, b, style)
+			}
+			got, err := decodeBulbs(&out)
+			if err != nil || got != message {
+				t.Errorf("%s: %q came back as %q, %v", name, message, got, err)
+			}
+		}
+	}
+}
+
+func TestDecodeBulbs(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		err   bool
+	}{
+		{"01001000\n\n0100 1001\n", "HI", false},       // blank lines and spaces are fine
+		{"⚫️🟡️⚫️⚫️🟡️⚫️⚫️⚫️\n", "H", false},             // emoji with variation selectors
+		{"Message: 01001000\n01001001\n", "HI", false}, // piped with the prompt
+		{"0100100\n", "", true},                        // 7 bulbs
+		{"010010001\n", "", true},                      // 9 bulbs
+		{"0100100x\n", "", true},                       // not a bulb
+		{"\033[93m●\033[0m○○○○○○●", "\x81", false},     // colour codes
+	}
+	for _, tt := range tests {
+		got, err := decodeBulbs(strings.NewReader(tt.input))
+		if (err != nil) != tt.err || got != tt.want && !tt.err {
+			t.Errorf("decodeBulbs(%q) = %q, %v", tt.input, got, err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+module bulbs
+
+go 1.24.4
+
+require (
+	bits v0.0.0
+	cs50 v0.0.0
+)
+
+replace (
+	bits => ../bits
+	cs50 => ../../cs50
+)
@@ -0,0 +1,84 @@
+// Package cipher is the week 2 ciphers without the prompts: substitution,
+// which swaps every letter for the one in the same place of a 26-letter
+// key, and caesar, which shifts every letter by the same amount. Case is
+// kept and anything that isn't a letter passes through.
+package cipher
+
+import (
+	"errors"
+	"math/rand"
+
+	"letters"
+)
+
+// Why a substitution key was refused, worded like the pset's messages.
+var (
+	ErrKeyLength   = errors.New("key must contain 26 characters")
+	ErrKeyLetters  = errors.New("key must only contain alphabetic characters")
+	ErrKeyRepeated = errors.New("key must not contain repeated characters")
+)
+
+// ValidKey checks key is 26 letters with none repeated, upper and lower
+// case being the same letter.
+func ValidKey(key string) error {
+	if len(key) != 26 {
+		return ErrKeyLength
+	}
+	var seen [26]bool
+	for _, c := range key {
+		i, ok := letters.Index(c)
+		if !ok {
+			return ErrKeyLetters
+		}
+		if seen[i] {
+			return ErrKeyRepeated
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+// RandomKey is a substitution key, the alphabet shuffled by rng, in
+// capitals. The same seed is the same key.
+func RandomKey(rng *rand.Rand) string {
+	key := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	rng.Shuffle(len(key), func(i, j int) { key[i], key[j] = key[j], key[i] })
+	return string(key)
+}
+
+// Substitute enciphers plaintext with key: A becomes key[0], B key[1], ...
+func Substitute(key, plaintext string) (string, error) {
+	if err := ValidKey(key); err != nil {
+		return "", err
+	}
+	return mapLetters(plaintext, func(i int) int {
+		to, _ := letters.Index(rune(key[i]))
+		return to
+	}), nil
+}
+
+// Caesar shifts every letter of plaintext k places along the alphabet,
+// wrapping around after Z. A negative k shifts back, so Caesar(-k)
+// deciphers Caesar(k).
+func Caesar(k int, plaintext string) string {
+	k = (k%26 + 26) % 26
+	return mapLetters(plaintext, func(i int) int { return (i + k) % 26 })
+}
+
+// mapLetters replaces each letter of s, numbered 0-25, with the letter
+// shift returns, in the same case.
+func mapLetters(s string, shift func(int) int) string {
+	out := []rune(s)
+	for i, c := range out {
+		index, ok := letters.Index(c)
+		if !ok {
+			continue
+		}
+		if c >= 'a' {
+			out[i] = rune('a' + shift(index))
+		} else {
+			out[i] = rune('A' + shift(index))
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,68 @@
+package cipher
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// KEY is the pset's example key.
+const KEY = "NQXPOMAFTRHLZGECYJIUWSKDVB"
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		key, plaintext, want string
+		err                  error
+	}{
+		{"ZYXWVUTSRQPONMLKJIHGFEDCBA", "A", "Z", nil},
+		{"zyxwvutsrqponmlkjihgfedcba", "a", "z", nil},
+		{KEY, "HELLO", "FOLLE", nil},
+		{"nqxpomaftrhlzgecyjiuwskdvb", "Hello, world!", "Folle, kejlp!", nil},
+		{"VcHpRzGjNtLsKfBdQwAxEuYmOi", "hello, world", "jrssb, ybwsp", nil},
+		{KEY, "héllo 123", "félle 123", nil},
+		{"ABC", "x", "", ErrKeyLength},
+		{"1BCDEFGHIJKLMNOPQRSTUVWXYZ", "x", "", ErrKeyLetters},
+		{"AACDEFGHIJKLMNOPQRSTUVWXYZ", "x", "", ErrKeyRepeated},
+		{"aBCDEFGHIJKLMNOPQRSTUVWXYA", "x", "", ErrKeyRepeated},
+	}
+	for _, tt := range tests {
+		got, err := Substitute(tt.key, tt.plaintext)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Substitute(%q, %q) = %q, %v; want %q, %v", tt.key, tt.plaintext, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCaesar(t *testing.T) {
+	tests := []struct {
+		k               int
+		plaintext, want string
+	}{
+		{1, "HELLO", "IFMMP"},
+		{13, "hello, world", "uryyb, jbeyq"},
+		{13, "be sure to drink your Ovaltine", "or fher gb qevax lbhe Binygvar"},
+		{26, "World", "World"},
+		{27, "Zz", "Aa"},
+		{-1, "Aa", "Zz"},
+	}
+	for _, tt := range tests {
+		if got := Caesar(tt.k, tt.plaintext); got != tt.want {
+			t.Errorf("Caesar(%d, %q) = %q, want %q", tt.k, tt.plaintext, got, tt.want)
+		}
+		if back := Caesar(-tt.k, tt.want); back != tt.plaintext {
+			t.Errorf("Caesar(%d, %q) = %q, want it deciphered", -tt.k, tt.want, back)
+		}
+	}
+}
+
+func TestRandomKey(t *testing.T) {
+	for seed := range int64(20) {
+		key := RandomKey(rand.New(rand.NewSource(seed)))
+		if err := ValidKey(key); err != nil {
+			t.Errorf("seed %d: RandomKey = %q: %v", seed, key, err)
+		}
+		if again := RandomKey(rand.New(rand.NewSource(seed))); again != key {
+			t.Errorf("seed %d: RandomKey = %q, then %q", seed, key, again)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+module cipher
+
+go 1.24.4
+
+require letters v0.0.0
+
+replace letters => ../letters
@@ -0,0 +1,13 @@
+module hours
+
+go 1.24.4
+
+require (
+	cs50 v0.0.0
+	timeparse v0.0.0
+)
+
+replace (
+	cs50 => ../../cs50
+	timeparse => ../timeparse
+)
@@ -0,0 +1,98 @@
+// Hours lab: enter the days and hours you work (or study) and get the week
+// printed as a schedule, in 12-hour time or with -24 in 24-hour time.
+//
+//	Days: mon-fri
+//	Hours: 9am-5pm
+//	Days: sat
+//	Hours: 10-2pm
+//	Days:
+//	Monday     9:00 AM - 5:00 PM    8h
+//	...
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"timeparse"
+)
+
+// schedule holds each day's hour ranges.
+type schedule map[time.Weekday][]timeparse.Range
+
+func main() {
+	h24 := flag.Bool("24", false, "print times in 24-hour format")
+	flag.Parse()
+
+	fmt.Println("Enter days (mon-fri, sat, weekends ...) and hours (9am-5pm, 13:00-17:30 ...). Leave days blank to finish.")
+	week := schedule{}
+	for {
+		input := cs50.GetString("Days: ")
+		if input == "" {
+			break
+		}
+		days, err := timeparse.ParseDays(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		// Keep asking for hours until they make sense
+		for {
+			r, err := timeparse.ParseRange(cs50.GetString("Hours: "))
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			week.add(days, r)
+			break
+		}
+	}
+
+	week.print(os.Stdout, *h24)
+}
+
+// add puts r on every one of days, keeping each day sorted by start time.
+func (s schedule) add(days []time.Weekday, r timeparse.Range) {
+	for _, day := range days {
+		s[day] = append(s[day], r)
+		slices.SortFunc(s[day], func(a, b timeparse.Range) int { return int(a.Start - b.Start) })
+	}
+}
+
+// total returns the hours of the whole week.
+func (s schedule) total() time.Duration {
+	var total time.Duration
+	for _, ranges := range s {
+		for _, r := range ranges {
+			total += r.Duration()
+		}
+	}
+	return total
+}
+
+// print writes one line per range, Monday first, with the day name only on
+// its first line.
+func (s schedule) print(w io.Writer, h24 bool) {
+	for _, day := range timeparse.WEEK {
+		ranges := s[day]
+		if len(ranges) == 0 {
+			fmt.Fprintf(w, "%-10s off\n", day)
+			continue
+		}
+		for i, r := range ranges {
+			name := ""
+			if i == 0 {
+				name = day.String()
+			}
+			fmt.Fprintf(w, "%-10s %-20s %6s\n", name, r.Format(h24), timeparse.FormatDuration(r.Duration()))
+		}
+	}
+	fmt.Fprintf(w, "Total: %s\n", timeparse.FormatDuration(s.total()))
+}
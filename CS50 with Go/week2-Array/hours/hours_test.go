@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"timeparse"
+)
+
+func TestPrint(t *testing.T) {
+	week := schedule{}
+	weekdays, _ := timeparse.ParseDays("mon-fri")
+	morning, _ := timeparse.ParseRange("9am-12pm")
+	afternoon, _ := timeparse.ParseRange("1-5pm")
+	night, _ := timeparse.ParseRange("22:00-01:30")
+
+	// Added out of order, printed in order
+	week.add(weekdays, afternoon)
+	week.add(weekdays, morning)
+	week.add([]time.Weekday{time.Saturday}, night)
+
+	var out12 bytes.Buffer
+	week.print(&out12, false)
+	want12 := "" +
+		"Monday     9:00 AM - 12:00 PM       3h\n" +
+		"           1:00 PM - 5:00 PM        4h\n" +
+		"Tuesday    9:00 AM - 12:00 PM       3h\n" +
+		"           1:00 PM - 5:00 PM        4h\n" +
+		"Wednesday  9:00 AM - 12:00 PM       3h\n" +
+		"           1:00 PM - 5:00 PM        4h\n" +
+		"Thursday   9:00 AM - 12:00 PM       3h\n" +
+		"           1:00 PM - 5:00 PM        4h\n" +
+		"Friday     9:00 AM - 12:00 PM       3h\n" +
+		"           1:00 PM - 5:00 PM        4h\n" +
+		"Saturday   10:00 PM - 1:30 AM    3h30m\n" +
+		"Sunday     off\n" +
+		"Total: 38h30m\n"
+	if out12.String() != want12 {
+		t.Errorf("12-hour schedule:\n%s\nwant\n%s", out12.String(), want12)
+	}
+
+	var out24 bytes.Buffer
+	week.print(&out24, true)
+	if !bytes.Contains(out24.Bytes(), []byte("Saturday   22:00 - 01:30         3h30m\n")) {
+		t.Errorf("24-hour schedule:\n%s", out24.String())
+	}
+}
@@ -0,0 +1,59 @@
+// Package letters is the A-Z handling shared by the week 2 word games:
+// scrabble scores words with it and wordle compares guesses with it.
+// Anything that isn't an ASCII letter is ignored, like isalpha in C.
+package letters
+
+// POINTS is the Scrabble value of each letter, A through Z.
+var POINTS = [26]int{1, 3, 3, 2, 1, 4, 2, 4, 1, 8, 5, 1, 3, 1, 1, 3, 10, 1, 1, 1, 1, 4, 4, 8, 4, 10}
+
+// Index returns 0 for 'a' or 'A' through 25 for 'z' or 'Z'. ok is false
+// for anything else.
+func Index(c rune) (i int, ok bool) {
+	switch {
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	}
+	return 0, false
+}
+
+// IsLetter reports whether c is an ASCII letter.
+func IsLetter(c rune) bool {
+	_, ok := Index(c)
+	return ok
+}
+
+// Upper returns word in upper case with every non-letter dropped.
+func Upper(word string) string {
+	out := make([]byte, 0, len(word))
+	for _, c := range word {
+		if i, ok := Index(c); ok {
+			out = append(out, byte('A'+i))
+		}
+	}
+	return string(out)
+}
+
+// Count returns how many times each letter appears in word.
+func Count(word string) [26]int {
+	var counts [26]int
+	for _, c := range word {
+		if i, ok := Index(c); ok {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// Score returns the Scrabble score of word. Case doesn't matter and
+// non-letters are worth 0.
+func Score(word string) int {
+	score := 0
+	for _, c := range word {
+		if i, ok := Index(c); ok {
+			score += POINTS[i]
+		}
+	}
+	return score
+}
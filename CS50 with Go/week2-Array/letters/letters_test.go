@@ -0,0 +1,38 @@
+package letters
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"", 0},
+		{"Question?", 17},
+		{"Question!", 17},
+		{"red", 4},
+		{"wheelbarrow", 22},
+		{"COMPUTER", 14},
+		{"computer", 14},
+		{"Oh,", 5},
+		{"hai!", 6},
+		{"Scrabble", 14},
+		{"1234", 0},
+		{"zebra café", 24},
+	}
+	for _, tt := range tests {
+		if got := Score(tt.word); got != tt.want {
+			t.Errorf("Score(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestUpperAndCount(t *testing.T) {
+	if got := Upper("it's a Hat!"); got != "ITSAHAT" {
+		t.Errorf("Upper = %q, want ITSAHAT", got)
+	}
+	counts := Count("Banana")
+	if counts[0] != 3 || counts['n'-'a'] != 2 || counts['b'-'a'] != 1 {
+		t.Errorf("Count(Banana) = %v", counts)
+	}
+}
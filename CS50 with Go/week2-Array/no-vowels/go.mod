@@ -0,0 +1,16 @@
+module no-vowels
+
+go 1.24.4
+
+require (
+	i18n v0.0.0
+	textutil v0.0.0
+)
+
+require cs50 v0.0.0 // indirect
+
+replace (
+	cs50 => ../../cs50
+	i18n => ../../i18n
+	textutil => ../textutil
+)
@@ -0,0 +1,38 @@
+// No vowels lab: write a word in l33t, replacing vowels with numbers.
+//
+//	./no-vowels pseudocode    ->  ps3ud0c0d3
+
+package main
+
+import (
+	"fmt"
+	"i18n"
+	"io"
+	"os"
+
+	"textutil"
+)
+
+func main() {
+	i18n.Init()
+	os.Exit(run(os.Args, os.Stdout))
+}
+
+// run is int main(int argc, string argv[]) from C: it returns the exit code.
+func run(argv []string, stdout io.Writer) int {
+	argc := len(argv)
+
+	// Accept a single command-line argument
+	if argc != 2 {
+		fmt.Fprintln(stdout, i18n.T("no-vowels.usage"))
+		return 1
+	}
+
+	fmt.Fprintln(stdout, replace(argv[1]))
+	return 0
+}
+
+// replace changes a to 6, e to 3, i to 1 and o to 0.
+func replace(word string) string {
+	return textutil.LEET.Apply(word)
+}
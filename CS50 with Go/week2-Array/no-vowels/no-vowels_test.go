@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		argv   []string
+		output string
+		code   int
+	}{
+		{[]string{"./no-vowels", "hello"}, "h3ll0\n", 0},
+		{[]string{"./no-vowels", "pseudocode"}, "ps3ud0c0d3\n", 0},
+		{[]string{"./no-vowels"}, "Usage: ./no-vowels word\n", 1},
+		{[]string{"./no-vowels", "two", "words"}, "Usage: ./no-vowels word\n", 1},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		code := run(tt.argv, &out)
+		if out.String() != tt.output || code != tt.code {
+			t.Errorf("run(%q) printed %q and returned %d, want %q and %d", tt.argv, out.String(), code, tt.output, tt.code)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+module password
+
+go 1.24.4
+
+require (
+	cs50 v0.0.0
+	runctx v0.0.0
+	textutil v0.0.0
+)
+
+replace (
+	cs50 => ../../cs50
+	runctx => ../../runctx
+	textutil => ../textutil
+)
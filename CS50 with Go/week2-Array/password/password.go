@@ -0,0 +1,49 @@
+// Password lab: check that a password has a lowercase letter, an uppercase
+// letter, a number and a symbol, then go further and estimate how hard it
+// would be to guess.
+//
+//	Enter your password: (hidden)
+//	Your password is valid!
+//	Length: 12, 4 character classes (lowercase, uppercase, digits, symbols), not a common password
+//	Entropy: ~79 bits
+//	Strength: strong
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+	"strings"
+
+	"runctx"
+)
+
+func main() {
+	// Ctrl-C at the prompt mustn't leave the terminal without echo.
+	runctx.Install()
+	password := cs50.GetPassword("Enter your password: ")
+	r := analyze(password)
+
+	if r.valid() {
+		fmt.Println("Your password is valid!")
+	} else {
+		fmt.Println("Your password needs at least one uppercase letter, lowercase letter, number and symbol")
+	}
+
+	var used []string
+	for class, ok := range r.classes {
+		if ok {
+			used = append(used, CLASS_NAMES[class])
+		}
+	}
+	commonness := "not a common password"
+	if r.common {
+		commonness = "a very common password"
+	}
+	fmt.Printf("Length: %d, %d character classes (%s), %s\n", r.length, r.classCount(), strings.Join(used, ", "), commonness)
+	fmt.Printf("Entropy: ~%.0f bits\n", r.entropy)
+	fmt.Printf("Strength: %s\n", STRENGTH_NAMES[r.strength])
+	if r.length < MIN_LENGTH {
+		fmt.Printf("Use at least %d characters.\n", MIN_LENGTH)
+	}
+}
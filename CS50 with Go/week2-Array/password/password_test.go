@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+		common   bool
+		strength int
+	}{
+		// The lab's examples
+		{"hello", false, true, VERY_WEAK},
+		{"Hello1!", true, true, VERY_WEAK}, // hello with 1! on the end
+		{"Tapir1!", true, false, WEAK},     // too short to be more
+		{"Zebra1!", true, true, VERY_WEAK}, // zebra is on the list
+		{"Pa$$w0rd", true, true, VERY_WEAK},
+		{"P@ssw0rd123!", true, true, VERY_WEAK},
+		{"qwerty", false, true, VERY_WEAK},
+		{"Dragon2024", false, true, VERY_WEAK},
+		{"eyphed", false, true, VERY_WEAK},   // the list's last entry
+		{"correcthorse", false, false, FAIR}, // 12 * log2(26) = 56.4 bits
+		{"xk4Tq9!mZp2#", true, false, STRONG},
+		{"Tr0ub4dor&3xyzzy-K9q!mB7@wLp2$vN", true, false, VERY_STRONG},
+		{"", false, false, VERY_WEAK},
+		{"รหัสผ่านยาวมาก", false, false, STRONG}, // 14 * log2(100)
+	}
+	for _, tt := range tests {
+		r := analyze(tt.password)
+		if r.valid() != tt.valid || r.common != tt.common || r.strength != tt.strength {
+			t.Errorf("analyze(%q): valid %v, common %v, %s (%.1f bits); want %v, %v, %s",
+				tt.password, r.valid(), r.common, STRENGTH_NAMES[r.strength], r.entropy,
+				tt.valid, tt.common, STRENGTH_NAMES[tt.strength])
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	// 8 lowercase letters: 8 * log2(26) = 37.6 bits
+	if r := analyze("zqxjvkwp"); r.entropy < 37.6 || r.entropy > 37.7 {
+		t.Errorf("8 lowercase letters: %.2f bits", r.entropy)
+	}
+	// Adding a class grows the pool for every character
+	if analyze("zqxjvkw9").entropy <= analyze("zqxjvkwp").entropy {
+		t.Error("a digit didn't add entropy")
+	}
+}
+
+func TestCommonList(t *testing.T) {
+	if len(commonSet) < 200 || !commonSet["123456"] || !commonSet["password"] {
+		t.Errorf("embedded list has %d passwords", len(commonSet))
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+
+	"textutil"
+)
+
+// MIN_LENGTH is the shortest password that can be better than weak.
+const MIN_LENGTH = 8
+
+// Character classes and how many characters each one has, which is what an
+// attacker guessing blindly has to try for every position.
+const (
+	LOWER = iota
+	UPPER
+	DIGIT
+	SYMBOL
+	OTHER // anything outside ASCII
+	CLASSES
+)
+
+var CLASS_NAMES = [CLASSES]string{"lowercase", "uppercase", "digits", "symbols", "other"}
+var POOL_SIZES = [CLASSES]int{26, 26, 10, 33, 100}
+
+// Strength verdicts, weakest first, with the entropy (bits) each needs.
+const (
+	VERY_WEAK = iota
+	WEAK
+	FAIR
+	STRONG
+	VERY_STRONG
+)
+
+var STRENGTH_NAMES = []string{"very weak", "weak", "fair", "strong", "very strong"}
+var STRENGTH_BITS = []float64{0, 28, 36, 60, 128}
+
+// common is the embedded list of passwords people use the most, one per
+// line, most common first: zxcvbn's copy of Mark Burnett's top 10,000
+// (https://xato.net/passwords/more-top-worst-passwords), as shipped in
+// github.com/nbutton23/zxcvbn-go's data/Passwords.json under the MIT licence
+// in common.LICENSE. zxcvbn trimmed it to 7,141 entries, dropping the ones
+// its other dictionaries (names, English words) rank higher, so names like
+// "michael" aren't on it.
+//
+//go:embed common.txt
+var common string
+
+var commonSet = func() map[string]bool {
+	set := map[string]bool{}
+	for _, line := range strings.Split(common, "\n") {
+		if line != "" {
+			set[line] = true
+		}
+	}
+	return set
+}()
+
+// UNLEET undoes the substitutions people make to dress up a common
+// password: p@ssw0rd is still password.
+var UNLEET = textutil.RuneMap{
+	'@': 'a', '4': 'a',
+	'3': 'e',
+	'1': 'i', '!': 'i',
+	'0': 'o',
+	'$': 's', '5': 's',
+	'7': 't',
+}
+
+// report is everything the checker found out about a password.
+type report struct {
+	length   int
+	classes  [CLASSES]bool
+	common   bool
+	entropy  float64
+	strength int
+}
+
+// analyze measures password. Entropy is length * log2(pool), where the pool
+// is every character of the classes used; a common password has (almost)
+// none, since it's one of the first guesses.
+func analyze(password string) report {
+	var r report
+	for _, c := range password {
+		r.length++
+		r.classes[classOf(c)] = true
+	}
+
+	pool := 0
+	for class, used := range r.classes {
+		if used {
+			pool += POOL_SIZES[class]
+		}
+	}
+	if pool > 0 {
+		r.entropy = float64(r.length) * math.Log2(float64(pool))
+	}
+
+	r.common = isCommon(password)
+	if r.common {
+		r.entropy = 0
+	}
+
+	for r.strength+1 < len(STRENGTH_BITS) && r.entropy >= STRENGTH_BITS[r.strength+1] {
+		r.strength++
+	}
+	if r.length < MIN_LENGTH {
+		r.strength = min(r.strength, WEAK)
+	}
+	return r
+}
+
+// valid is the CS50 lab's rule: at least one lowercase letter, uppercase
+// letter, number and symbol.
+func (r report) valid() bool {
+	return r.classes[LOWER] && r.classes[UPPER] && r.classes[DIGIT] && r.classes[SYMBOL]
+}
+
+// classCount returns how many classes the password uses.
+func (r report) classCount() int {
+	n := 0
+	for _, used := range r.classes {
+		if used {
+			n++
+		}
+	}
+	return n
+}
+
+func classOf(c rune) int {
+	switch {
+	case c > unicode.MaxASCII:
+		return OTHER
+	case c >= 'a' && c <= 'z':
+		return LOWER
+	case c >= 'A' && c <= 'Z':
+		return UPPER
+	case c >= '0' && c <= '9':
+		return DIGIT
+	}
+	return SYMBOL
+}
+
+// isCommon reports whether password is on the list, ignoring case, leet
+// substitutions and a few digits or symbols tacked on the end.
+func isCommon(password string) bool {
+	lower := strings.ToLower(password)
+	for _, candidate := range []string{
+		lower,
+		UNLEET.Apply(lower),
+		strings.TrimRight(lower, "0123456789!?.*#$@"),
+		UNLEET.Apply(strings.TrimRight(lower, "0123456789!?.*#$@")),
+	} {
+		if candidate != "" && commonSet[candidate] {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"cs50"
 	"fmt"
+	"log/slog"
+	"logging"
 	"math"
 	"unicode"
 )
@@ -10,13 +12,15 @@ import (
 ////////////////////////////////////////////////////////////
 
 func main() {
+	logging.Init()
+
 	///----Display----///
 	/// (Input) ask text to user and hold it.
 	text := cs50.GetString("Text: ")
 
 	/// (ProCess) hold colmanIndex Call func name text_counter(text) round them
 	colemanIndex := int(math.Round(text_counter(text)))
-	fmt.Printf("colmanIndex = %d\n", colemanIndex)
+	slog.Debug("Coleman-Liau index", "index", colemanIndex)
 
 
 	/// (outuT)Print The grade level // use colenamIndex make condition and print grade
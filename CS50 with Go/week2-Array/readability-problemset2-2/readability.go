@@ -3,11 +3,20 @@ package main
 import (
 	"cs50"
 	"fmt"
+	"i18n"
+	"log/slog"
+	"logging"
 	"math"
-	"unicode"
+	"os"
+	"readability"
+	"render"
 )
 
 func main() {
+	i18n.Init()
+	logging.Init()
+	render.Init()
+
 	//// ------- Greeting ----------
 	// fmt.Println("hello, world")
 	// name := cs50.GetString("Name : ")
@@ -15,43 +24,49 @@ func main() {
     // fmt.Println()
 
 	///// -----Readability Display----
-	text := cs50.GetString("Book Detail : ")
+	text := cs50.GetString(render.Prompt(i18n.T("readability.prompt")))
 
-    colemanIndex := int(math.Round(textCounter(text)))
-    fmt.Printf("colemanIndex = %d\n", colemanIndex)
+    m := textCounter(text)
+    colemanIndex := int(math.Round(m.Index))
+    slog.Debug("Coleman-Liau index", "index", colemanIndex)
 	
+    if !render.Text() {
+        m.Index = math.Round(m.Index*100) / 100 // 4.72, not 4.719999999999999
+        m.Grade = colemanIndex
+        if err := render.Print(m); err != nil {
+            fmt.Println(err)
+            os.Exit(1)
+        }
+        return
+    }
     if colemanIndex < 1 {
-        fmt.Println("Before Grade 1")
+        fmt.Println(i18n.T("readability.before"))
     } else if colemanIndex >= 16 {
-        fmt.Println("Grade 16+")
+        fmt.Println(i18n.T("readability.16plus"))
     } else {
-        fmt.Printf("Grade %d\n", colemanIndex)
+        fmt.Println(i18n.T("readability.grade", colemanIndex))
     }
 }
 
 
 
-// ---Tool- Count the number of letters, words, and sentences in the text
-func textCounter(text string) float64 {
-    letterCount := 0
-    wordCount := 1
-    sentenceCount := 0
-
-    for _, ch := range text {
-        if unicode.IsLetter(ch) {
-            letterCount++
-        } else if unicode.IsSpace(ch) {
-            wordCount++
-        } else if ch == '.' || ch == '!' || ch == '?' {
-            sentenceCount++
-        }
-    }
-    return computeColeman(letterCount, wordCount, sentenceCount)
+// metrics is what --format prints: the counts, and the grade they make.
+type metrics struct {
+	Letters   int     `json:"letters"`
+	Words     int     `json:"words"`
+	Sentences int     `json:"sentences"`
+	Index     float64 `json:"index"` // Coleman-Liau, before it's rounded
+	Grade     int     `json:"grade"` // below 1 is before grade 1, 16 and up is 16+
 }
 
-// Compute Coleman-Liau index
-func computeColeman(letterCount, wordCount, sentenceCount int) float64 {
-    l := float64(letterCount) / float64(wordCount) * 100.0
-    s := float64(sentenceCount) / float64(wordCount) * 100.0
-    return 0.0588*l - 0.296*s - 15.8
+// ---Tool- Count the number of letters, words, and sentences in the text
+// with the readability package, and compute the Coleman-Liau index
+func textCounter(text string) metrics {
+    c := readability.Count(text)
+    return metrics{
+        Letters:   c.Letters,
+        Words:     c.Words,
+        Sentences: c.Sentences,
+        Index:     c.Index(),
+    }
 }
\ No newline at end of file
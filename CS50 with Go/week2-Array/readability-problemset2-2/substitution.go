@@ -3,68 +3,63 @@
 package main
 
 import (
+	"cipher"
+	"context"
 	"cs50"
+	"errors"
+	"exitcode"
 	"fmt"
-	"os"
+	"i18n"
+	"log/slog"
+	"logging"
 )
 
 func main() {
-	// implement int main(int argc, string argv[]) from C
-	argc := len(os.Args)
-	argv := os.Args
-	
-	
-	fmt.Println("hello, world")
+	i18n.Init()
+	logging.Init()
+	exitcode.Main(run)
+}
+
+// run is int main(int argc, string argv[]) from C, with args argv after
+// the program's name: an error of a kind is its return 1, and exitcode.Main
+// exits with it.
+func run(ctx context.Context, args []string) error {
+	// fmt.Println("hello, world")
 	// name := cs50.GetString("Name: ")
 	// fmt.Printf("hello, %s", name)
 	
-	if argc != 2 {
-		fmt.Println("Usage: ./substitution key")
-		os.Exit(1) // return 1; in C that mean exite with status code 1
+	if len(args) != 1 {
+		return exitcode.Usage(i18n.T("substitution.usage"), nil) // return 1; in C that mean exite with status code 1
 	}
 
 	// Argument report. 
-	fmt.Println("argc:", argc)
-	fmt.Printf("arv[0]: %s | argv[1]: %s \n", argv[0], argv[1])
+	slog.Debug("arguments", "key", args[0])
 
-	if !validate_key(argv[1]) {
-		// Error messages for invalid key print inside validate_key
-		os.Exit(1)
+	if err := validate_key(args[0]); err != nil {
+		// A bad key is a usage error too: it says what's wrong with it
+		return err
 	}
 
 	// validate pass
-	plaintext := cs50.GetString("plaintext: ");
-	fmt.Println("text = ", plaintext)
+	plaintext := cs50.GetString(i18n.T("substitution.plaintext"));
+	slog.Debug("plaintext", "text", plaintext)
 
+	// the key is valid, so there's no error to have
+	ciphertext, _ := cipher.Substitute(args[0], plaintext)
+	fmt.Println(i18n.T("substitution.ciphertext") + ciphertext)
+	return nil
 }
 
 // --component-- validate key
-func validate_key(key string) bool {
-	// check 1: lenght must be 26
-	if len(key) != 26 {
-		fmt.Println("Key must contain 26 characters.")
-		return false
-	}
-
-	// Frequency array to check for duplicates
-	freq := make([]int, 26)
-
-	for i := 0; i < 26; i++ {
-		c := key[i]
-
-		// check 2: all must be alphabetic
-		if ( c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
-			fmt.Println("Key must only contain alphabetic characters. ")
-			return false
-		}
-
-		// check 3: No duplicate characters (case-insensitive)
-		index := int((c | 0x20) - 'a') // convert to lowercase
-		if freq[index] > 0 {
-			fmt.Println("Key must not contain repeated characters.")
-			return false
-		}
-		freq[index]++
+// validate_key is cipher.ValidKey with the pset's messages.
+func validate_key(key string) error {
+	switch err := cipher.ValidKey(key); {
+	case errors.Is(err, cipher.ErrKeyLength):
+		return exitcode.Usage(i18n.T("substitution.length"), nil)
+	case errors.Is(err, cipher.ErrKeyLetters):
+		return exitcode.Usage(i18n.T("substitution.alpha"), nil)
+	case errors.Is(err, cipher.ErrKeyRepeated):
+		return exitcode.Usage(i18n.T("substitution.repeated"), nil)
 	}
-	return true
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"exitcode"
+	"i18n"
+)
+
+// TestRunRejects is run's part before the plaintext: whatever's wrong with
+// the arguments is a usage error, with the pset's message, and nothing is
+// asked for.
+func TestRunRejects(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "substitution.usage"},
+		{[]string{"NQXPOMAFTRHLZGECYJIUWSKDVB", "extra"}, "substitution.usage"},
+		{[]string{"ABC"}, "substitution.length"},
+		{[]string{""}, "substitution.length"},
+		{[]string{"NQXPOMAFTRHLZGECYJIUWSKDV1"}, "substitution.alpha"},
+		{[]string{"NQXPOMAFTRHLZGECYJIUWSKD.B"}, "substitution.alpha"},
+		{[]string{"NQXPOMAFTRHLZGECYJIUWSKDVV"}, "substitution.repeated"},
+		{[]string{"NQXPOMAFTRHLZGECYJIUWSKDVn"}, "substitution.repeated"}, // n is N
+	}
+	for _, test := range tests {
+		err := run(context.Background(), test.args)
+		if !errors.Is(err, exitcode.ErrUsage) || exitcode.Code(err) != exitcode.USAGE || err.Error() != i18n.T(test.want) {
+			t.Errorf("run(%q) = %v, want the usage error %q", test.args, err, i18n.T(test.want))
+		}
+	}
+}
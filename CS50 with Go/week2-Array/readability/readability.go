@@ -0,0 +1,63 @@
+// Package readability grades text with the Coleman-Liau index, the
+// readability pset without the prompt.
+package readability
+
+import (
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// Counts is what the index is computed from.
+type Counts struct {
+	Letters   int
+	Words     int // runs of non-spaces
+	Sentences int // '.', '!' and '?'
+}
+
+// Count counts text's letters, words and sentences.
+func Count(text string) Counts {
+	var c Counts
+	inWord := false
+	for _, ch := range text {
+		switch {
+		case unicode.IsSpace(ch):
+			inWord = false
+			continue
+		case unicode.IsLetter(ch):
+			c.Letters++
+		case ch == '.' || ch == '!' || ch == '?':
+			c.Sentences++
+		}
+		if !inWord {
+			c.Words++
+			inWord = true
+		}
+	}
+	return c
+}
+
+// Index returns the Coleman-Liau index, 0.0588 * L - 0.296 * S - 15.8,
+// where L is letters and S sentences per 100 words. Text without words
+// scores 0.
+func (c Counts) Index() float64 {
+	if c.Words == 0 {
+		return 0
+	}
+	l := float64(c.Letters) / float64(c.Words) * 100
+	s := float64(c.Sentences) / float64(c.Words) * 100
+	return 0.0588*l - 0.296*s - 15.8
+}
+
+// Grade returns the index rounded to a school grade, as the pset prints
+// it: "Before Grade 1", "Grade 1" to "Grade 15", or "Grade 16+".
+func Grade(index float64) string {
+	grade := int(math.Round(index))
+	switch {
+	case grade < 1:
+		return "Before Grade 1"
+	case grade >= 16:
+		return "Grade 16+"
+	}
+	return "Grade " + strconv.Itoa(grade)
+}
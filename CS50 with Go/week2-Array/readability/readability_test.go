@@ -0,0 +1,45 @@
+package readability
+
+import "testing"
+
+// The readability pset's check50 texts.
+func TestGrade(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"One fish. Two fish. Red fish. Blue fish.", "Before Grade 1"},
+		{"Would you like them here or there? I would not like them here or there. I would not like them anywhere.", "Grade 2"},
+		{"Congratulations! Today is your day. You're off to Great Places! You're off and away!", "Grade 3"},
+		{"Harry Potter was a highly unusual boy in many ways. For one thing, he hated the summer holidays more than any other time of year. For another, he really wanted to do his homework, but was forced to do it in secret, in the dead of the night. And he also happened to be a wizard.", "Grade 5"},
+		{"In my younger and more vulnerable years my father gave me some advice that I've been turning over in my mind ever since.", "Grade 7"},
+		{`Alice was beginning to get very tired of sitting by her sister on the bank, and of having nothing to do: once or twice she had peeped into the book her sister was reading, but it had no pictures or conversations in it, "and what is the use of a book," thought Alice "without pictures or conversation?"`, "Grade 8"},
+		{"When he was nearly thirteen, my brother Jem got his arm badly broken at the elbow. When it healed, and Jem's fears of never being able to play football were assuaged, he was seldom self-conscious about his injury. His left arm was somewhat shorter than his right; when he stood or walked, the back of his hand was at right angles to his body, his thumb parallel to his thigh.", "Grade 8"},
+		{"There are more things in Heaven and Earth, Horatio, than are dreamt of in your philosophy.", "Grade 9"},
+		{"It was a bright cold day in April, and the clocks were striking thirteen. Winston Smith, his chin nuzzled into his breast in an effort to escape the vile wind, slipped quickly through the glass doors of Victory Mansions, though not quickly enough to prevent a swirl of gritty dust from entering along with him.", "Grade 10"},
+		{"A large class of computational problems involve the determination of properties of graphs, digraphs, integers, arrays of integers, finite families of finite sets, boolean formulas and elements of other countable domains.", "Grade 16+"},
+		{"", "Before Grade 1"},
+	}
+	for _, tt := range tests {
+		if got := Grade(Count(tt.text).Index()); got != tt.want {
+			t.Errorf("Grade(%.40q...) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		text string
+		want Counts
+	}{
+		{"Hello, world!", Counts{10, 2, 1}},
+		{"  two   spaces.  ", Counts{9, 2, 1}},
+		{"Wait... what?!", Counts{8, 2, 5}},
+		{"", Counts{}},
+	}
+	for _, tt := range tests {
+		if got := Count(tt.text); got != tt.want {
+			t.Errorf("Count(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
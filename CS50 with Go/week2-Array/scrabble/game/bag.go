@@ -0,0 +1,50 @@
+package game
+
+import "math/rand"
+
+// DISTRIBUTION is how many tiles of each letter, A through Z, start in the
+// bag: the English set minus the two blanks.
+var DISTRIBUTION = [26]int{9, 2, 2, 4, 12, 2, 3, 2, 9, 1, 1, 4, 2, 6, 8, 2, 1, 6, 4, 6, 4, 2, 2, 1, 2, 1}
+
+// Bag is the tiles nobody has drawn yet.
+type Bag struct {
+	tiles []byte
+	rng   *rand.Rand
+}
+
+// NewBag returns a full bag shuffled with rng.
+func NewBag(rng *rand.Rand) *Bag {
+	bag := &Bag{rng: rng}
+	for i, n := range DISTRIBUTION {
+		for range n {
+			bag.tiles = append(bag.tiles, byte('A'+i))
+		}
+	}
+	rng.Shuffle(len(bag.tiles), func(i, j int) {
+		bag.tiles[i], bag.tiles[j] = bag.tiles[j], bag.tiles[i]
+	})
+	return bag
+}
+
+// Len returns how many tiles are left.
+func (b *Bag) Len() int {
+	return len(b.tiles)
+}
+
+// Draw takes up to n tiles, fewer when the bag runs out.
+func (b *Bag) Draw(n int) []byte {
+	n = min(n, len(b.tiles))
+	drawn := append([]byte(nil), b.tiles[len(b.tiles)-n:]...)
+	b.tiles = b.tiles[:len(b.tiles)-n]
+	return drawn
+}
+
+// Return puts tiles back in random places.
+func (b *Bag) Return(tiles []byte) {
+	for _, tile := range tiles {
+		b.tiles = append(b.tiles, tile)
+		i := b.rng.Intn(len(b.tiles))
+		last := len(b.tiles) - 1
+		b.tiles[i], b.tiles[last] = b.tiles[last], b.tiles[i]
+	}
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SIZE is the width and height of the board.
+const SIZE = 15
+
+// Premium squares multiply the letter or the whole word placed on them.
+const (
+	PLAIN = iota
+	DOUBLE_LETTER
+	TRIPLE_LETTER
+	DOUBLE_WORD
+	TRIPLE_WORD
+)
+
+// LAYOUT is the standard board: T triple word, D double word, t triple
+// letter, d double letter. The centre is a double word square too.
+var LAYOUT = [SIZE]string{
+	"T..d...T...d..T",
+	".D...t...t...D.",
+	"..D...d.d...D..",
+	"d..D...d...D..d",
+	"....D.....D....",
+	".t...t...t...t.",
+	"..d...d.d...d..",
+	"T..d...D...d..T",
+	"..d...d.d...d..",
+	".t...t...t...t.",
+	"....D.....D....",
+	"d..D...d...D..d",
+	"..D...d.d...D..",
+	".D...t...t...D.",
+	"T..d...T...d..T",
+}
+
+// CENTER is the square the first word has to cover.
+const CENTER = SIZE / 2
+
+// Board holds the placed tiles, 'A' to 'Z', or 0 for an empty square.
+type Board [SIZE][SIZE]byte
+
+// Premium returns what kind of square row, col is.
+func Premium(row, col int) int {
+	switch LAYOUT[row][col] {
+	case 'd':
+		return DOUBLE_LETTER
+	case 't':
+		return TRIPLE_LETTER
+	case 'D':
+		return DOUBLE_WORD
+	case 'T':
+		return TRIPLE_WORD
+	}
+	return PLAIN
+}
+
+// Empty reports whether no tile has been placed yet.
+func (b *Board) Empty() bool {
+	return *b == Board{}
+}
+
+// at returns the tile at row, col, or 0 when it's empty or off the board.
+func (b *Board) at(row, col int) byte {
+	if row < 0 || row >= SIZE || col < 0 || col >= SIZE {
+		return 0
+	}
+	return b[row][col]
+}
+
+// ParsePosition reads a square like "H8": column A-O, then row 1-15.
+func ParsePosition(s string) (row, col int, err error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	var column byte
+	if _, err := fmt.Sscanf(s, "%c%d", &column, &row); err != nil || column < 'A' || column >= 'A'+SIZE || row < 1 || row > SIZE {
+		return 0, 0, fmt.Errorf("%q is not a square, try H8", s)
+	}
+	return row - 1, int(column - 'A'), nil
+}
+
+// Position is the reverse of ParsePosition.
+func Position(row, col int) string {
+	return fmt.Sprintf("%c%d", 'A'+col, row+1)
+}
+
+// SYMBOLS is how String draws each kind of empty square.
+var SYMBOLS = [...]byte{
+	PLAIN:         '.',
+	DOUBLE_LETTER: '\'',
+	TRIPLE_LETTER: '"',
+	DOUBLE_WORD:   '-',
+	TRIPLE_WORD:   '=',
+}
+
+// String draws the board with column letters and row numbers. The empty
+// centre square shows as *.
+func (b *Board) String() string {
+	var out strings.Builder
+	out.WriteString("   ")
+	for col := range SIZE {
+		fmt.Fprintf(&out, " %c", 'A'+col)
+	}
+	out.WriteByte('\n')
+
+	for row := range SIZE {
+		fmt.Fprintf(&out, "%2d ", row+1)
+		for col := range SIZE {
+			square := SYMBOLS[Premium(row, col)]
+			switch {
+			case b[row][col] != 0:
+				square = b[row][col]
+			case row == CENTER && col == CENTER:
+				square = '*'
+			}
+			fmt.Fprintf(&out, " %c", square)
+		}
+		out.WriteByte('\n')
+	}
+	return out.String()
+}
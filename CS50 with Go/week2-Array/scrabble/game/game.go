@@ -0,0 +1,311 @@
+// Package game is a two-player game of Scrabble: a bag of tiles, a rack of 7
+// for each player, and a board where every word formed has to be in a
+// speller dictionary. There are no blank tiles.
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"letters"
+	"speller/dictionary"
+)
+
+const (
+	// RACK_SIZE is how many tiles a player holds.
+	RACK_SIZE = 7
+
+	// BINGO is the bonus for using all 7 tiles in one move.
+	BINGO = 50
+
+	// MAX_SCORELESS ends the game after this many passes and exchanges in
+	// a row.
+	MAX_SCORELESS = 6
+)
+
+// Player is one of the two players.
+type Player struct {
+	Name  string
+	Rack  []byte
+	Score int
+}
+
+// Move places Word with its first letter on Row, Col, going right when
+// Across and down otherwise. Letters already on the board are part of Word.
+type Move struct {
+	Word     string
+	Row, Col int
+	Across   bool
+}
+
+// Game is the state of a game in progress.
+type Game struct {
+	Board   Board
+	Bag     *Bag
+	Players []*Player
+	Turn    int // index of the player to move
+
+	dict      dictionary.Dictionary
+	scoreless int
+}
+
+// New deals a rack to each player from a fresh bag.
+func New(dict dictionary.Dictionary, names []string, rng *rand.Rand) *Game {
+	g := &Game{Bag: NewBag(rng), dict: dict}
+	for _, name := range names {
+		g.Players = append(g.Players, &Player{Name: name, Rack: g.Bag.Draw(RACK_SIZE)})
+	}
+	return g
+}
+
+// Current returns the player to move.
+func (g *Game) Current() *Player {
+	return g.Players[g.Turn]
+}
+
+// Play validates move for the current player and, if it's legal, places the
+// tiles, scores every word formed and refills the rack. words lists the
+// words formed, the main one first. The game doesn't change on error.
+func (g *Game) Play(move Move) (score int, words []string, err error) {
+	word := letters.Upper(move.Word)
+	if len(word) < 2 || len(word) != len(strings.TrimSpace(move.Word)) {
+		return 0, nil, errors.New("a word is at least 2 letters and only letters")
+	}
+	dRow, dCol := 0, 1
+	if !move.Across {
+		dRow, dCol = 1, 0
+	}
+	endRow, endCol := move.Row+dRow*(len(word)-1), move.Col+dCol*(len(word)-1)
+	if move.Row < 0 || move.Col < 0 || endRow >= SIZE || endCol >= SIZE {
+		return 0, nil, fmt.Errorf("%s doesn't fit on the board", word)
+	}
+
+	// Work on a copy so nothing changes if the move is rejected
+	board := g.Board
+	var placed [][2]int
+	var used []byte
+	touches := false
+	for i := range len(word) {
+		row, col := move.Row+dRow*i, move.Col+dCol*i
+		switch board[row][col] {
+		case word[i]:
+			touches = true
+		case 0:
+			board[row][col] = word[i]
+			placed = append(placed, [2]int{row, col})
+			used = append(used, word[i])
+		default:
+			return 0, nil, fmt.Errorf("%s already has %c on it", Position(row, col), board[row][col])
+		}
+	}
+	if len(placed) == 0 {
+		return 0, nil, errors.New("place at least one tile")
+	}
+	if !hasTiles(g.Current().Rack, used) {
+		return 0, nil, fmt.Errorf("you don't have the tiles for %s", used)
+	}
+
+	// The word can't run into tiles before or after it
+	if board.at(move.Row-dRow, move.Col-dCol) != 0 || board.at(endRow+dRow, endCol+dCol) != 0 {
+		full, _, _ := run(&board, move.Row, move.Col, dRow, dCol)
+		return 0, nil, fmt.Errorf("that makes %s, not %s", full, word)
+	}
+
+	if g.Board.Empty() {
+		if !covers(placed, CENTER, CENTER) {
+			return 0, nil, fmt.Errorf("the first word has to cover %s", Position(CENTER, CENTER))
+		}
+	} else if !touches && !adjacent(&g.Board, placed) {
+		return 0, nil, errors.New("the word has to join the tiles already on the board")
+	}
+
+	// Score the main word and every cross-word a new tile made
+	score = scoreWord(&board, placed, move.Row, move.Col, dRow, dCol)
+	words = append(words, word)
+	for _, p := range placed {
+		cross, start, length := run(&board, p[0], p[1], dCol, dRow)
+		if length < 2 {
+			continue
+		}
+		words = append(words, cross)
+		score += scoreWord(&board, placed, start[0], start[1], dCol, dRow)
+	}
+	for _, w := range words {
+		if !g.dict.Check(w) {
+			return 0, nil, fmt.Errorf("%s is not in the dictionary", w)
+		}
+	}
+	if len(placed) == RACK_SIZE {
+		score += BINGO
+	}
+
+	// Commit the move
+	player := g.Current()
+	g.Board = board
+	player.Rack = removeTiles(player.Rack, used)
+	player.Rack = append(player.Rack, g.Bag.Draw(RACK_SIZE-len(player.Rack))...)
+	player.Score += score
+	g.scoreless = 0
+	g.next()
+	return score, words, nil
+}
+
+// Pass ends the current player's turn without scoring.
+func (g *Game) Pass() {
+	g.scoreless++
+	g.next()
+}
+
+// Exchange swaps tiles from the current player's rack with the bag, which
+// uses up the turn. The bag needs at least a full rack left.
+func (g *Game) Exchange(tiles string) error {
+	swap := []byte(letters.Upper(tiles))
+	player := g.Current()
+	if len(swap) == 0 || !hasTiles(player.Rack, swap) {
+		return fmt.Errorf("you don't have %s to exchange", strings.ToUpper(tiles))
+	}
+	if g.Bag.Len() < RACK_SIZE {
+		return fmt.Errorf("only %d tiles left in the bag, exchanging needs %d", g.Bag.Len(), RACK_SIZE)
+	}
+	player.Rack = append(removeTiles(player.Rack, swap), g.Bag.Draw(len(swap))...)
+	g.Bag.Return(swap)
+	g.scoreless++
+	g.next()
+	return nil
+}
+
+// Over reports whether the game has ended: someone used their last tile
+// with the bag empty, or there were too many scoreless turns in a row.
+func (g *Game) Over() bool {
+	if g.scoreless >= MAX_SCORELESS {
+		return true
+	}
+	for _, p := range g.Players {
+		if len(p.Rack) == 0 && g.Bag.Len() == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Finish applies the end-of-game adjustments: everyone loses the value of
+// the tiles left on their rack, and a player who went out gains all of it.
+// It returns the winners, more than one on a tie.
+func (g *Game) Finish() []*Player {
+	left := 0
+	var out *Player
+	for _, p := range g.Players {
+		value := letters.Score(string(p.Rack))
+		p.Score -= value
+		left += value
+		if len(p.Rack) == 0 {
+			out = p
+		}
+	}
+	if out != nil {
+		out.Score += left
+	}
+
+	var winners []*Player
+	for _, p := range g.Players {
+		switch {
+		case len(winners) == 0 || p.Score > winners[0].Score:
+			winners = []*Player{p}
+		case p.Score == winners[0].Score:
+			winners = append(winners, p)
+		}
+	}
+	return winners
+}
+
+func (g *Game) next() {
+	g.Turn = (g.Turn + 1) % len(g.Players)
+}
+
+// run returns the word through row, col in direction dRow, dCol: every
+// tile touching in a line, where it starts and how long it is.
+func run(b *Board, row, col, dRow, dCol int) (string, [2]int, int) {
+	for b.at(row-dRow, col-dCol) != 0 {
+		row, col = row-dRow, col-dCol
+	}
+	start := [2]int{row, col}
+	var word []byte
+	for b.at(row, col) != 0 {
+		word = append(word, b[row][col])
+		row, col = row+dRow, col+dCol
+	}
+	return string(word), start, len(word)
+}
+
+// scoreWord adds up the word starting at row, col. Premium squares only
+// count for tiles placed this turn.
+func scoreWord(b *Board, placed [][2]int, row, col, dRow, dCol int) int {
+	sum, multiplier := 0, 1
+	for ; b.at(row, col) != 0; row, col = row+dRow, col+dCol {
+		i, _ := letters.Index(rune(b[row][col]))
+		value := letters.POINTS[i]
+		if covers(placed, row, col) {
+			switch Premium(row, col) {
+			case DOUBLE_LETTER:
+				value *= 2
+			case TRIPLE_LETTER:
+				value *= 3
+			case DOUBLE_WORD:
+				multiplier *= 2
+			case TRIPLE_WORD:
+				multiplier *= 3
+			}
+		}
+		sum += value
+	}
+	return sum * multiplier
+}
+
+// covers reports whether row, col is one of squares.
+func covers(squares [][2]int, row, col int) bool {
+	for _, s := range squares {
+		if s == [2]int{row, col} {
+			return true
+		}
+	}
+	return false
+}
+
+// adjacent reports whether any placed square is next to a tile on b.
+func adjacent(b *Board, placed [][2]int) bool {
+	for _, p := range placed {
+		if b.at(p[0]-1, p[1]) != 0 || b.at(p[0]+1, p[1]) != 0 || b.at(p[0], p[1]-1) != 0 || b.at(p[0], p[1]+1) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTiles reports whether rack holds every tile in want, counting repeats.
+func hasTiles(rack, want []byte) bool {
+	have := letters.Count(string(rack))
+	for _, tile := range want {
+		i, ok := letters.Index(rune(tile))
+		if !ok || have[i] == 0 {
+			return false
+		}
+		have[i]--
+	}
+	return true
+}
+
+// removeTiles returns rack without one of each tile in used.
+func removeTiles(rack, used []byte) []byte {
+	rack = append([]byte(nil), rack...)
+	for _, tile := range used {
+		for i, t := range rack {
+			if t == tile {
+				rack = append(rack[:i], rack[i+1:]...)
+				break
+			}
+		}
+	}
+	return rack
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"speller/mapdict"
+)
+
+// newGame returns a game with fixed racks and a tiny dictionary.
+func newGame(t *testing.T, racks ...string) *Game {
+	t.Helper()
+	dict := mapdict.New()
+	if err := dict.Load(strings.NewReader("cat\ncats\nat\nta\nto\nax\nox\nzax\nquiz\nact\nretains\n")); err != nil {
+		t.Fatal(err)
+	}
+	g := New(dict, []string{"alice", "bob"}, rand.New(rand.NewSource(1)))
+	for i, rack := range racks {
+		g.Players[i].Rack = []byte(rack)
+	}
+	return g
+}
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		s        string
+		row, col int
+		ok       bool
+	}{
+		{"H8", 7, 7, true},
+		{"a1", 0, 0, true},
+		{" O15 ", 14, 14, true},
+		{"P1", 0, 0, false},
+		{"A0", 0, 0, false},
+		{"A16", 0, 0, false},
+		{"8H", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		row, col, err := ParsePosition(tt.s)
+		if (err == nil) != tt.ok || tt.ok && (row != tt.row || col != tt.col) {
+			t.Errorf("ParsePosition(%q) = %d, %d, %v", tt.s, row, col, err)
+		}
+		if tt.ok && Position(row, col) != strings.ToUpper(strings.TrimSpace(tt.s)) {
+			t.Errorf("Position(%d, %d) = %s", row, col, Position(row, col))
+		}
+	}
+}
+
+func TestBag(t *testing.T) {
+	bag := NewBag(rand.New(rand.NewSource(1)))
+	if bag.Len() != 98 {
+		t.Fatalf("%d tiles, want 98", bag.Len())
+	}
+	drawn := bag.Draw(7)
+	if len(drawn) != 7 || bag.Len() != 91 {
+		t.Errorf("drew %d, %d left", len(drawn), bag.Len())
+	}
+	bag.Return(drawn)
+	if bag.Len() != 98 {
+		t.Errorf("%d tiles after returning", bag.Len())
+	}
+	if n := len(bag.Draw(200)); n != 98 || bag.Len() != 0 {
+		t.Errorf("drew %d of 98", n)
+	}
+}
+
+func TestFirstMove(t *testing.T) {
+	g := newGame(t, "CATSXYZ", "OXQUIZE")
+
+	// Has to cover the centre
+	if _, _, err := g.Play(Move{Word: "cat", Row: 0, Col: 0, Across: true}); err == nil {
+		t.Error("first move off centre: want error")
+	}
+	// Has to be a word
+	if _, _, err := g.Play(Move{Word: "tac", Row: 7, Col: 7, Across: true}); err == nil {
+		t.Error("tac: want error")
+	}
+	// Has to be on the rack
+	if _, _, err := g.Play(Move{Word: "to", Row: 7, Col: 7, Across: true}); err == nil {
+		t.Error("to without an O: want error")
+	}
+	// Has to fit
+	if _, _, err := g.Play(Move{Word: "cat", Row: 7, Col: 13, Across: true}); err == nil {
+		t.Error("cat off the edge: want error")
+	}
+	if !g.Board.Empty() || g.Turn != 0 {
+		t.Fatal("a rejected move changed the game")
+	}
+
+	// C3 A1 T1 with the centre doubling the word
+	score, words, err := g.Play(Move{Word: "CAT", Row: 7, Col: 7, Across: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 10 || !slices.Equal(words, []string{"CAT"}) {
+		t.Errorf("CAT = %d %v, want 10", score, words)
+	}
+	if g.Players[0].Score != 10 || len(g.Players[0].Rack) != RACK_SIZE || g.Turn != 1 {
+		t.Errorf("after CAT: %+v, turn %d", g.Players[0], g.Turn)
+	}
+	if g.Board[7][7] != 'C' || g.Board[7][9] != 'T' {
+		t.Errorf("board row 8 = %q", g.Board[7][:])
+	}
+}
+
+func TestLaterMoves(t *testing.T) {
+	g := newGame(t, "CATQUIZ", "OXSAXYZ")
+	if _, _, err := g.Play(Move{Word: "CAT", Row: 7, Col: 7, Across: true}); err != nil {
+		t.Fatal(err)
+	}
+	g.Players[0].Rack = []byte("QUIZTTT")
+
+	tests := []struct {
+		name  string
+		move  Move
+		score int
+		words []string
+		err   bool
+	}{
+		{"not joined", Move{Word: "ox", Row: 0, Col: 0, Across: true}, 0, nil, true},
+		{"clash", Move{Word: "ox", Row: 7, Col: 8, Across: false}, 0, nil, true},
+		{"runs into CAT", Move{Word: "ax", Row: 7, Col: 5, Across: true}, 0, nil, true},
+		{"no new tile", Move{Word: "cat", Row: 7, Col: 7, Across: true}, 0, nil, true},
+		// S on K8 makes CATS: 3+1+1+1, no premium
+		{"extend", Move{Word: "cats", Row: 7, Col: 7, Across: true}, 6, []string{"CATS"}, false},
+	}
+	for _, tt := range tests {
+		g.Turn = 1
+		score, words, err := g.Play(tt.move)
+		if (err != nil) != tt.err || score != tt.score || !slices.Equal(words, tt.words) {
+			t.Errorf("%s: %d %v %v", tt.name, score, words, err)
+		}
+	}
+
+	// AX down from the A on I8: X lands on I9, a double letter, for 1+8*2
+	g.Turn = 1
+	g.Players[1].Rack = []byte("XOOOOOO")
+	score, words, err := g.Play(Move{Word: "ax", Row: 7, Col: 8, Across: false})
+	if err != nil || score != 17 || !slices.Equal(words, []string{"AX"}) {
+		t.Errorf("AX = %d %v %v, want 17", score, words, err)
+	}
+
+	// ZAX across row 9 ending on that X: the A on H9 sits under the C of
+	// CATS and makes CA, which isn't a word
+	g.Players[0].Rack = []byte("ZAAAAAA")
+	g.Turn = 0
+	if _, _, err := g.Play(Move{Word: "zax", Row: 8, Col: 6, Across: true}); err == nil || !strings.Contains(err.Error(), "dictionary") {
+		t.Errorf("ZAX under CAT: err = %v", err)
+	}
+}
+
+func TestCrossWords(t *testing.T) {
+	g := newGame(t, "ATXXXXX", "OOOOOOO")
+	if _, _, err := g.Play(Move{Word: "at", Row: 7, Col: 7, Across: true}); err != nil {
+		t.Fatal(err)
+	}
+	// TO down from I8 uses the T on the board: O lands on I9 (double
+	// letter) for T1 + O1*2 = 3
+	score, words, err := g.Play(Move{Word: "to", Row: 7, Col: 8, Across: false})
+	if err != nil || score != 3 || !slices.Equal(words, []string{"TO"}) {
+		t.Fatalf("TO = %d %v %v", score, words, err)
+	}
+
+	// OX across row 9 starting on that O: X on J9 makes OX and no cross-word
+	g.Players[0].Rack = []byte("XAAAAAA")
+	score, words, err = g.Play(Move{Word: "ox", Row: 8, Col: 8, Across: true})
+	if err != nil || score != 9 || !slices.Equal(words, []string{"OX"}) {
+		t.Fatalf("OX = %d %v %v", score, words, err)
+	}
+
+	// A on H9 under the A of AT: across it makes AOX (not a word)
+	g.Players[1].Rack = []byte("AOOOOOO")
+	if _, _, err := g.Play(Move{Word: "aa", Row: 7, Col: 7, Across: false}); err == nil {
+		t.Error("AA making AOX: want error")
+	}
+}
+
+func TestBingoAndEnd(t *testing.T) {
+	g := newGame(t, "RETAINS", "QUIZXXX")
+	g.Bag.Draw(100)
+
+	// RETAINS across from H8: 7 one-point letters, the I on the L8 double
+	// letter, the centre doubling the word, plus the bingo: 8*2 + 50
+	score, _, err := g.Play(Move{Word: "retains", Row: 7, Col: 7, Across: true})
+	if err != nil || score != 66 {
+		t.Fatalf("RETAINS = %d %v, want 66", score, err)
+	}
+	if !g.Over() {
+		t.Fatal("alice went out with an empty bag: want game over")
+	}
+
+	// bob is left with QUIZXXX: 10+1+1+10+8+8+8 = 46
+	winners := g.Finish()
+	if len(winners) != 1 || winners[0].Name != "alice" || g.Players[0].Score != 66+46 || g.Players[1].Score != -46 {
+		t.Errorf("scores %d, %d", g.Players[0].Score, g.Players[1].Score)
+	}
+}
+
+func TestScoreless(t *testing.T) {
+	g := newGame(t, "ABCDEFG")
+	if err := g.Exchange("zz"); err == nil {
+		t.Error("exchanging tiles not on the rack: want error")
+	}
+	for range MAX_SCORELESS - 1 {
+		g.Pass()
+	}
+	if g.Over() {
+		t.Fatal("over too early")
+	}
+	rack := string(g.Current().Rack)
+	if err := g.Exchange(rack[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Over() {
+		t.Error("6 scoreless turns: want game over")
+	}
+	if g.Bag.Len() != 98-2*RACK_SIZE {
+		t.Errorf("%d tiles in the bag after exchanging", g.Bag.Len())
+	}
+}
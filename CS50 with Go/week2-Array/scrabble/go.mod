@@ -0,0 +1,27 @@
+module scrabble
+
+go 1.24.4
+
+require (
+	cs50 v0.0.0
+	i18n v0.0.0
+	letters v0.0.0
+	rngutil v0.0.0
+	speller v0.0.0
+)
+
+require (
+	memstats v0.0.0 // indirect
+	set v0.0.0 // indirect
+)
+
+replace (
+	cs50 => ../../cs50
+	i18n => ../../i18n
+	letters => ../letters
+	memstats => ../../week5-Data-Strucutes/memstats
+	profiling => ../../profiling
+	rngutil => ../../rngutil
+	set => ../../week5-Data-Strucutes/set
+	speller => ../../week5-Data-Strucutes/speller
+)
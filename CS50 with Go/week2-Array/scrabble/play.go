@@ -0,0 +1,107 @@
+package main
+
+import (
+	"cs50"
+	"fmt"
+	"os"
+	"strings"
+
+	"rngutil"
+	"scrabble/game"
+	"speller/dictionary"
+	"speller/hashtable"
+)
+
+// play runs a game between two players at the same keyboard.
+func play(dictPath string, seed int64) {
+	// Load the dictionary every word is checked against
+	dict := hashtable.New()
+	if err := dictionary.LoadFile(dict, dictPath); err != nil {
+		fmt.Printf("Could not load %s.\n", dictPath)
+		os.Exit(1)
+	}
+	defer dict.Unload()
+
+	names := []string{cs50.GetString("Player 1: "), cs50.GetString("Player 2: ")}
+	for i, name := range names {
+		if strings.TrimSpace(name) == "" {
+			names[i] = fmt.Sprintf("Player %d", i+1)
+		}
+	}
+	g := game.New(dict, names, rngutil.New(&seed))
+
+	fmt.Println("Type a word to play it, or /pass, /swap LETTERS or /quit.")
+	for !g.Over() {
+		player := g.Current()
+		fmt.Println()
+		fmt.Print(g.Board.String())
+		printScores(g)
+		fmt.Printf("%s's rack: %s\n", player.Name, spaced(player.Rack))
+
+		input := strings.TrimSpace(cs50.GetString("Word: "))
+		command, argument, _ := strings.Cut(input, " ")
+		switch strings.ToLower(command) {
+		case "":
+			continue
+		case "/quit":
+			return
+		case "/pass":
+			g.Pass()
+			continue
+		case "/swap":
+			if err := g.Exchange(argument); err != nil {
+				fmt.Println(err)
+			}
+			continue
+		}
+
+		move, err := askMove(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		score, words, err := g.Play(move)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Printf("%s plays %s for %d points.\n", player.Name, strings.Join(words, ", "), score)
+	}
+
+	// Game over: tiles left on racks count against their owners
+	winners := g.Finish()
+	fmt.Println()
+	fmt.Print(g.Board.String())
+	printScores(g)
+	if len(winners) > 1 {
+		fmt.Println("Tie!")
+	} else {
+		fmt.Printf("%s wins!\n", winners[0].Name)
+	}
+}
+
+// askMove asks where word goes.
+func askMove(word string) (game.Move, error) {
+	row, col, err := game.ParsePosition(cs50.GetString("Start square (e.g. H8): "))
+	if err != nil {
+		return game.Move{}, err
+	}
+	direction := cs50.GetChar("Across or down (a/d): ")
+	if direction != 'a' && direction != 'A' && direction != 'd' && direction != 'D' {
+		return game.Move{}, fmt.Errorf("%q is not a or d", direction)
+	}
+	return game.Move{Word: word, Row: row, Col: col, Across: direction == 'a' || direction == 'A'}, nil
+}
+
+// printScores prints every player's score and what's left in the bag.
+func printScores(g *game.Game) {
+	for _, p := range g.Players {
+		fmt.Printf("%s: %d  ", p.Name, p.Score)
+	}
+	fmt.Printf("(%d tiles in the bag)\n", g.Bag.Len())
+}
+
+// spaced returns tiles as "A B C".
+func spaced(tiles []byte) string {
+	return strings.Join(strings.Split(string(tiles), ""), " ")
+}
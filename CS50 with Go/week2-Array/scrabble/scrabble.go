@@ -0,0 +1,46 @@
+// Scrabble lab: two players enter a word each, the higher score wins.
+//
+//	./scrabble          the lab
+//	./scrabble -game    a full game on a board, see play.go
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"i18n"
+
+	"letters"
+)
+
+func main() {
+	i18n.Init()
+	game := flag.Bool("game", false, "play a full two-player game on a board")
+	dictionary := flag.String("dictionary", "dictionaries/words", "words allowed in -game, one per line")
+	seed := flag.Int64("seed", 0, "shuffle the bag with this seed (0 = random)")
+	flag.Parse()
+
+	if *game {
+		play(*dictionary, *seed)
+		return
+	}
+
+	// Get input words from both players
+	word1 := cs50.GetString(i18n.T("scrabble.player", 1))
+	word2 := cs50.GetString(i18n.T("scrabble.player", 2))
+
+	// Score both words
+	score1 := letters.Score(word1)
+	score2 := letters.Score(word2)
+
+	// Print the winner
+	switch {
+	case score1 > score2:
+		fmt.Println(i18n.T("scrabble.wins", 1))
+	case score1 < score2:
+		fmt.Println(i18n.T("scrabble.wins", 2))
+	default:
+		fmt.Println(i18n.T("scrabble.tie"))
+	}
+}
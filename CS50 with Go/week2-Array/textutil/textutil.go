@@ -0,0 +1,31 @@
+// Package textutil holds the small character-by-character text transforms
+// of the week 2 labs, built on rune mappings that strings.Map can apply.
+package textutil
+
+import "strings"
+
+// RuneMap replaces each key rune with its value. Runes without an entry are
+// left alone.
+type RuneMap map[rune]rune
+
+// LEET replaces vowels with the digits they look like, upper or lower case.
+// U stays a U: no digit looks like it.
+var LEET = RuneMap{
+	'a': '6', 'A': '6',
+	'e': '3', 'E': '3',
+	'i': '1', 'I': '1',
+	'o': '0', 'O': '0',
+}
+
+// Rune returns what m replaces r with.
+func (m RuneMap) Rune(r rune) rune {
+	if to, ok := m[r]; ok {
+		return to
+	}
+	return r
+}
+
+// Apply replaces every rune of s.
+func (m RuneMap) Apply(s string) string {
+	return strings.Map(m.Rune, s)
+}
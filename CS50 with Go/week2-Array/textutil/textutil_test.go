@@ -0,0 +1,21 @@
+package textutil
+
+import "testing"
+
+func TestLeet(t *testing.T) {
+	tests := []struct{ in, want string }{
+		// check50's words
+		{"hello", "h3ll0"},
+		{"pseudocode", "ps3ud0c0d3"},
+		{"computer", "c0mput3r"},
+		{"cs50", "cs50"},
+		{"CS50 Is Awesome", "CS50 1s 6w3s0m3"},
+		{"", ""},
+		{"ภาษา", "ภาษา"},
+	}
+	for _, tt := range tests {
+		if got := LEET.Apply(tt.in); got != tt.want {
+			t.Errorf("LEET.Apply(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
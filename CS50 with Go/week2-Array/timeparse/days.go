@@ -0,0 +1,74 @@
+package timeparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WEEK is the days in the order a schedule prints them, Monday first.
+var WEEK = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// ParseDay reads a day name or any prefix of at least 2 letters: "mon",
+// "Tu", "thursday".
+func ParseDay(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) >= 2 {
+		for _, day := range WEEK {
+			if strings.HasPrefix(strings.ToLower(day.String()), s) {
+				return day, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("timeparse: %q is not a day", s)
+}
+
+// ParseDays reads a list of days separated by commas or spaces, where each
+// item is a day, a range that wraps around the week ("mon-fri", "fri-mon"),
+// "weekdays", "weekends" or "daily". The days come back in WEEK order
+// without repeats.
+func ParseDays(s string) ([]time.Weekday, error) {
+	var chosen [7]bool
+	items := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ',' || r == ' ' })
+	if len(items) == 0 {
+		return nil, fmt.Errorf("timeparse: no days in %q", s)
+	}
+	for _, item := range items {
+		switch item {
+		case "weekdays":
+			item = "mon-fri"
+		case "weekends", "weekend":
+			item = "sat-sun"
+		case "daily", "everyday", "all":
+			item = "mon-sun"
+		}
+
+		from, to, isRange := strings.Cut(item, "-")
+		first, err := ParseDay(from)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if isRange {
+			if last, err = ParseDay(to); err != nil {
+				return nil, err
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			chosen[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+
+	var days []time.Weekday
+	for _, day := range WEEK {
+		if chosen[day] {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,3 @@
+module timeparse
+
+go 1.24.4
@@ -0,0 +1,200 @@
+// Package timeparse reads the times, hour ranges, days and durations people
+// type ("9am-5pm", "mon-fri", "1h30m") and formats them back in 12 or 24
+// hour style.
+package timeparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MINUTES_PER_DAY is one full turn of the clock.
+const MINUTES_PER_DAY = 24 * 60
+
+// Clock is a time of day in minutes after midnight, 0 to 1439.
+type Clock int
+
+// ParseClock reads "9", "9am", "9:30 pm", "21:30", "0930", "noon" or
+// "midnight". A bare hour without am/pm is taken as 24-hour time.
+func ParseClock(s string) (Clock, error) {
+	original := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "noon":
+		return 12 * 60, nil
+	case "midnight":
+		return 0, nil
+	}
+
+	// Split off am/pm (also "a.m." and "a")
+	meridiem := ""
+	for _, suffix := range []string{"a.m.", "p.m.", "am", "pm", "a", "p"} {
+		if strings.HasSuffix(s, suffix) {
+			meridiem = suffix[:1]
+			s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+			break
+		}
+	}
+
+	hourText, minuteText, hasColon := strings.Cut(s, ":")
+	if !hasColon {
+		hourText, minuteText = s, "0"
+		if len(s) == 4 { // 0930
+			hourText, minuteText = s[:2], s[2:]
+		}
+	}
+	hour, err1 := strconv.Atoi(hourText)
+	minute, err2 := strconv.Atoi(minuteText)
+	if err1 != nil || err2 != nil || minute < 0 || minute > 59 || hasColon && len(minuteText) != 2 {
+		return 0, fmt.Errorf("timeparse: %q is not a time", original)
+	}
+
+	switch meridiem {
+	case "":
+		if hour < 0 || hour > 24 || hour == 24 && minute != 0 {
+			return 0, fmt.Errorf("timeparse: %q is not a time", original)
+		}
+		hour %= 24
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, fmt.Errorf("timeparse: %q is not a 12-hour time", original)
+		}
+		hour %= 12 // 12am is 0:00, 12pm is 12:00
+		if meridiem == "p" {
+			hour += 12
+		}
+	}
+	return Clock(hour*60 + minute), nil
+}
+
+// Hour and Minute split c up.
+func (c Clock) Hour() int   { return int(c) / 60 }
+func (c Clock) Minute() int { return int(c) % 60 }
+
+// Format24 returns c as "21:30".
+func (c Clock) Format24() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour(), c.Minute())
+}
+
+// Format12 returns c as "9:30 PM".
+func (c Clock) Format12() string {
+	hour, meridiem := c.Hour()%12, "AM"
+	if c.Hour() >= 12 {
+		meridiem = "PM"
+	}
+	if hour == 0 {
+		hour = 12
+	}
+	return fmt.Sprintf("%d:%02d %s", hour, c.Minute(), meridiem)
+}
+
+// Format returns c in 24-hour style when h24 is true, 12-hour otherwise.
+func (c Clock) Format(h24 bool) string {
+	if h24 {
+		return c.Format24()
+	}
+	return c.Format12()
+}
+
+// Range is a stretch of the day from Start to End. End before Start means
+// it runs past midnight, like a night shift.
+type Range struct {
+	Start, End Clock
+}
+
+// ParseRange reads two times joined by "-", "–" or "to": "9am-5pm",
+// "09:00 to 17:30", "22-6". If only the end has am/pm, the start gets the
+// one that makes the shorter range: "9-11am" is 9 AM to 11 AM.
+func ParseRange(s string) (Range, error) {
+	var start, end string
+	ok := false
+	for _, sep := range []string{" to ", "–", "-"} {
+		if start, end, ok = strings.Cut(s, sep); ok {
+			break
+		}
+	}
+	if !ok {
+		return Range{}, fmt.Errorf("timeparse: %q is not a range like 9am-5pm", s)
+	}
+
+	endClock, err := ParseClock(end)
+	if err != nil {
+		return Range{}, err
+	}
+	startClock, err := ParseClock(start)
+	if err != nil {
+		return Range{}, err
+	}
+	if meridiem := suffix(end); meridiem != "" && suffix(start) == "" {
+		// "11-1pm" is 11 AM to 1 PM, so switch when the same one would put
+		// the start after the end
+		if c, err := ParseClock(start + meridiem); err == nil {
+			startClock = c
+			if c > endClock {
+				startClock, _ = ParseClock(start + map[string]string{"am": "pm", "pm": "am"}[meridiem])
+			}
+		}
+	}
+	if startClock == endClock {
+		return Range{}, fmt.Errorf("timeparse: %q starts and ends at the same time", s)
+	}
+	return Range{startClock, endClock}, nil
+}
+
+// suffix returns "am" or "pm" if s ends with one.
+func suffix(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, m := range []string{"am", "pm"} {
+		if strings.HasSuffix(s, m) {
+			return m
+		}
+	}
+	return ""
+}
+
+// Duration returns how long the range lasts.
+func (r Range) Duration() time.Duration {
+	minutes := (int(r.End) - int(r.Start) + MINUTES_PER_DAY) % MINUTES_PER_DAY
+	return time.Duration(minutes) * time.Minute
+}
+
+// Format returns the range as "9:00 AM - 5:00 PM" or "09:00 - 17:00".
+func (r Range) Format(h24 bool) string {
+	return r.Start.Format(h24) + " - " + r.End.Format(h24)
+}
+
+// ParseDuration reads "1h30m", "90m", "1.5h", "1:30" or a bare number of
+// minutes.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if hours, minutes, ok := strings.Cut(s, ":"); ok {
+		h, err1 := strconv.Atoi(hours)
+		m, err2 := strconv.Atoi(minutes)
+		if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 {
+			return 0, fmt.Errorf("timeparse: %q is not a duration", s)
+		}
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+	}
+	if minutes, err := strconv.Atoi(s); err == nil && minutes >= 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("timeparse: %q is not a duration", s)
+	}
+	return d, nil
+}
+
+// FormatDuration returns d as "8h", "45m" or "1h30m", rounded to the minute.
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,122 @@
+package timeparse
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		s      string
+		want24 string
+		want12 string
+	}{
+		{"9", "09:00", "9:00 AM"},
+		{"9am", "09:00", "9:00 AM"},
+		{"9:30 pm", "21:30", "9:30 PM"},
+		{"9:30 P.M.", "21:30", "9:30 PM"},
+		{"21:30", "21:30", "9:30 PM"},
+		{"0930", "09:30", "9:30 AM"},
+		{"12am", "00:00", "12:00 AM"},
+		{"12pm", "12:00", "12:00 PM"},
+		{"noon", "12:00", "12:00 PM"},
+		{"midnight", "00:00", "12:00 AM"},
+		{"24", "00:00", "12:00 AM"},
+		{"0:05", "00:05", "12:05 AM"},
+	}
+	for _, tt := range tests {
+		c, err := ParseClock(tt.s)
+		if err != nil || c.Format24() != tt.want24 || c.Format12() != tt.want12 {
+			t.Errorf("ParseClock(%q) = %s / %s, %v; want %s / %s", tt.s, c.Format24(), c.Format12(), err, tt.want24, tt.want12)
+		}
+	}
+
+	for _, bad := range []string{"", "25", "13pm", "0am", "9:5", "9:60", "nine", "24:30", "-1"} {
+		if c, err := ParseClock(bad); err == nil {
+			t.Errorf("ParseClock(%q) = %s, want error", bad, c.Format24())
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		s        string
+		want     string
+		duration time.Duration
+	}{
+		{"9am-5pm", "09:00 - 17:00", 8 * time.Hour},
+		{"09:00 to 17:30", "09:00 - 17:30", 8*time.Hour + 30*time.Minute},
+		{"9-11am", "09:00 - 11:00", 2 * time.Hour},
+		{"1-3pm", "13:00 - 15:00", 2 * time.Hour},
+		{"11-1pm", "11:00 - 13:00", 2 * time.Hour},
+		{"22-6", "22:00 - 06:00", 8 * time.Hour}, // overnight
+		{"8:15–9:45", "08:15 - 09:45", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.s)
+		if err != nil || r.Format(true) != tt.want || r.Duration() != tt.duration {
+			t.Errorf("ParseRange(%q) = %s (%v), %v; want %s (%v)", tt.s, r.Format(true), r.Duration(), err, tt.want, tt.duration)
+		}
+	}
+
+	for _, bad := range []string{"9am", "9-9", "x-5", "9-y", ""} {
+		if _, err := ParseRange(bad); err == nil {
+			t.Errorf("ParseRange(%q): want error", bad)
+		}
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []time.Weekday
+	}{
+		{"mon", []time.Weekday{time.Monday}},
+		{"Mon-Fri", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}},
+		{"weekends", []time.Weekday{time.Saturday, time.Sunday}},
+		{"fri-mon", []time.Weekday{time.Monday, time.Friday, time.Saturday, time.Sunday}},
+		{"tu, th", []time.Weekday{time.Tuesday, time.Thursday}},
+		{"sun wed sun", []time.Weekday{time.Wednesday, time.Sunday}},
+		{"daily", WEEK},
+	}
+	for _, tt := range tests {
+		got, err := ParseDays(tt.s)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("ParseDays(%q) = %v, %v; want %v", tt.s, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "m", "t", "funday", "mon-xyz"} {
+		if _, err := ParseDays(bad); err == nil {
+			t.Errorf("ParseDays(%q): want error", bad)
+		}
+	}
+}
+
+func TestDurations(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+		text string
+	}{
+		{"1h30m", 90 * time.Minute, "1h30m"},
+		{"90m", 90 * time.Minute, "1h30m"},
+		{"1.5h", 90 * time.Minute, "1h30m"},
+		{"1:30", 90 * time.Minute, "1h30m"},
+		{"45", 45 * time.Minute, "45m"},
+		{"8h", 8 * time.Hour, "8h"},
+		{"2h 5m", 125 * time.Minute, "2h05m"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDuration(tt.s)
+		if err != nil || d != tt.want || FormatDuration(d) != tt.text {
+			t.Errorf("ParseDuration(%q) = %v (%s), %v", tt.s, d, FormatDuration(d), err)
+		}
+	}
+	for _, bad := range []string{"", "-5", "1:75", "soon"} {
+		if _, err := ParseDuration(bad); err == nil {
+			t.Errorf("ParseDuration(%q): want error", bad)
+		}
+	}
+}
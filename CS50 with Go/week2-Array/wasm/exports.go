@@ -0,0 +1,44 @@
+// wasm: readability and substitution for the browser. Built with
+// GOOS=js GOARCH=wasm it gives JavaScript two functions,
+//
+//	AnalyzeText(text)                   {letters, words, sentences, index, grade}
+//	EncryptSubstitution(key, plaintext) {ciphertext} or {error}
+//
+// and built normally it's the two psets with their usual prompts, on the
+// same code.
+//
+//	./wasm readability
+//	./wasm substitution NQXPOMAFTRHLZGECYJIUWSKDVB
+
+package main
+
+import (
+	"math"
+
+	"cipher"
+	"readability"
+)
+
+// analyzeText is AnalyzeText: the Coleman-Liau grade of text and what it
+// was worked out from. The index is rounded to 2 decimal places.
+func analyzeText(text string) map[string]any {
+	c := readability.Count(text)
+	index := c.Index()
+	return map[string]any{
+		"letters":   c.Letters,
+		"words":     c.Words,
+		"sentences": c.Sentences,
+		"index":     math.Round(index*100) / 100,
+		"grade":     readability.Grade(index),
+	}
+}
+
+// encryptSubstitution is EncryptSubstitution. A bad key is an error
+// message rather than an exception, so the page can just show it.
+func encryptSubstitution(key, plaintext string) map[string]any {
+	ciphertext, err := cipher.Substitute(key, plaintext)
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{"ciphertext": ciphertext}
+}
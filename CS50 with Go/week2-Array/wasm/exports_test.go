@@ -0,0 +1,56 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeText(t *testing.T) {
+	got := analyzeText("Congratulations! Today is your day. You're off to Great Places! You're off and away!")
+	want := map[string]any{"letters": 65, "words": 14, "sentences": 4, "index": 3.04, "grade": "Grade 3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("analyzeText = %v, want %v", got, want)
+	}
+}
+
+func TestEncryptSubstitution(t *testing.T) {
+	tests := []struct {
+		key, plaintext string
+		want           map[string]any
+	}{
+		{"NQXPOMAFTRHLZGECYJIUWSKDVB", "Hello, world", map[string]any{"ciphertext": "Folle, kejlp"}},
+		{"ABC", "x", map[string]any{"error": "key must contain 26 characters"}},
+	}
+	for _, tt := range tests {
+		if got := encryptSubstitution(tt.key, tt.plaintext); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("encryptSubstitution(%q, %q) = %v, want %v", tt.key, tt.plaintext, got, tt.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	answer := func(text string) func(string) string {
+		return func(string) string { return text }
+	}
+	tests := []struct {
+		args   []string
+		input  string
+		status int
+		want   string
+	}{
+		{[]string{"readability"}, "One fish. Two fish. Red fish. Blue fish.", 0, "Before Grade 1\n"},
+		{[]string{"substitution", "VCHPRZGJNTLSKFBDQWAXEUYMOI"}, "hello, world", 0, "ciphertext: jrssb, ybwsp\n"},
+		{[]string{"substitution", "ABC"}, "", 1, "key must contain 26 characters\n"},
+		{[]string{"substitution"}, "", 1, "Usage: ./wasm readability | ./wasm substitution key\n"},
+		{nil, "", 1, "Usage: ./wasm readability | ./wasm substitution key\n"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if status := run(tt.args, &out, answer(tt.input)); status != tt.status || out.String() != tt.want {
+			t.Errorf("run(%q) = %d %q, want %d %q", tt.args, status, out.String(), tt.status, tt.want)
+		}
+	}
+}
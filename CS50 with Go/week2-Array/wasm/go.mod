@@ -0,0 +1,18 @@
+module wasm
+
+go 1.24.4
+
+require (
+	cipher v0.0.0
+	cs50 v0.0.0
+	readability v0.0.0
+)
+
+require letters v0.0.0 // indirect
+
+replace (
+	cipher => ../cipher
+	cs50 => ../../cs50
+	letters => ../letters
+	readability => ../readability
+)
@@ -0,0 +1,36 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+	"io"
+	"os"
+
+	"cipher"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, cs50.GetString))
+}
+
+// run is the psets' command-line side, asking for input with ask the way
+// the C versions use get_string.
+func run(args []string, w io.Writer, ask func(prompt string) string) int {
+	switch {
+	case len(args) == 1 && args[0] == "readability":
+		fmt.Fprintln(w, analyzeText(ask("Text: "))["grade"])
+		return 0
+	case len(args) == 2 && args[0] == "substitution":
+		if err := cipher.ValidKey(args[1]); err != nil {
+			fmt.Fprintln(w, err)
+			return 1
+		}
+		result := encryptSubstitution(args[1], ask("plaintext:  "))
+		fmt.Fprintf(w, "ciphertext: %s\n", result["ciphertext"])
+		return 0
+	}
+	fmt.Fprintln(w, "Usage: ./wasm readability | ./wasm substitution key")
+	return 1
+}
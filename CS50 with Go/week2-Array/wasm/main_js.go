@@ -0,0 +1,32 @@
+//go:build js && wasm
+
+package main
+
+import "syscall/js"
+
+func main() {
+	export()
+
+	// Tell the page the functions are there, then keep them alive: Go's
+	// exports go away when main returns.
+	if ready := js.Global().Get("onWasmReady"); ready.Type() == js.TypeFunction {
+		ready.Invoke()
+	}
+	select {}
+}
+
+// export puts AnalyzeText and EncryptSubstitution on the global object.
+func export() {
+	js.Global().Set("AnalyzeText", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 1 || args[0].Type() != js.TypeString {
+			return map[string]any{"error": "usage: AnalyzeText(text)"}
+		}
+		return analyzeText(args[0].String())
+	}))
+	js.Global().Set("EncryptSubstitution", js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) != 2 || args[0].Type() != js.TypeString || args[1].Type() != js.TypeString {
+			return map[string]any{"error": "usage: EncryptSubstitution(key, plaintext)"}
+		}
+		return encryptSubstitution(args[0].String(), args[1].String())
+	}))
+}
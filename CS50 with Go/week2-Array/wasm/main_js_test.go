@@ -0,0 +1,39 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+// Run with GOOS=js GOARCH=wasm go test -exec "$(go env GOROOT)/lib/wasm/go_js_wasm_exec" .
+// (needs Node.js).
+func TestExports(t *testing.T) {
+	export()
+	global := js.Global()
+
+	grade := global.Call("AnalyzeText", "There are more things in Heaven and Earth, Horatio, than are dreamt of in your philosophy.")
+	if got := grade.Get("grade").String(); got != "Grade 9" || grade.Get("words").Int() != 16 {
+		t.Errorf("AnalyzeText grade = %q, words = %v", got, grade.Get("words"))
+	}
+
+	tests := []struct {
+		args  []any
+		field string
+		want  string
+	}{
+		{[]any{"NQXPOMAFTRHLZGECYJIUWSKDVB", "Hello, world"}, "ciphertext", "Folle, kejlp"},
+		{[]any{"NQXPOMAFTRHLZGECYJIUWSKDVN", "x"}, "error", "key must not contain repeated characters"},
+		{[]any{"NQXPOMAFTRHLZGECYJIUWSKDVB"}, "error", "usage: EncryptSubstitution(key, plaintext)"},
+		{[]any{26, "x"}, "error", "usage: EncryptSubstitution(key, plaintext)"},
+	}
+	for _, tt := range tests {
+		if got := global.Call("EncryptSubstitution", tt.args...).Get(tt.field).String(); got != tt.want {
+			t.Errorf("EncryptSubstitution%v.%s = %q, want %q", tt.args, tt.field, got, tt.want)
+		}
+	}
+	if got := global.Call("AnalyzeText").Get("error").String(); got != "usage: AnalyzeText(text)" {
+		t.Errorf("AnalyzeText() error = %q", got)
+	}
+}
@@ -0,0 +1,15 @@
+module wordle
+
+go 1.24.4
+
+require (
+	cs50 v0.0.0
+	letters v0.0.0
+	rngutil v0.0.0
+)
+
+replace (
+	cs50 => ../../cs50
+	letters => ../letters
+	rngutil => ../../rngutil
+)
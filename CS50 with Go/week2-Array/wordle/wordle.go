@@ -0,0 +1,154 @@
+// Wordle50 lab: guess a random 5-8 letter word in six tries. Each guess is
+// coloured green (right letter, right spot), yellow (in the word somewhere
+// else) or red (not in the word).
+
+package main
+
+import (
+	"cs50"
+	"embed"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"letters"
+	"rngutil"
+)
+
+// each of our text files contains 400+ words
+//
+//go:embed words/*.txt
+var wordFiles embed.FS
+
+// GUESSES is how many tries the player gets.
+const GUESSES = 6
+
+// Feedback for one letter of a guess.
+const (
+	WRONG = iota // not in the word (or already used up by other matches)
+	CLOSE        // in the word, different position
+	EXACT        // right letter, right position
+)
+
+// ANSI colours used by the C version.
+const (
+	GREEN  = "\033[38;2;255;255;255;1m\033[48;2;106;170;100;1m"
+	YELLOW = "\033[38;2;255;255;255;1m\033[48;2;201;180;88;1m"
+	RED    = "\033[38;2;255;255;255;1m\033[48;2;220;20;60;1m"
+	RESET  = "\033[0;39m"
+)
+
+func main() {
+	seed := flag.Int64("seed", 0, "random seed to pick the word with (0 = random)")
+	flag.Parse()
+
+	// ensure proper usage
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: ./wordle [-seed N] wordsize")
+		os.Exit(1)
+	}
+	wordsize, err := strconv.Atoi(flag.Arg(0))
+	if err != nil || wordsize < 5 || wordsize > 8 {
+		fmt.Println("Error: wordsize must be either 5, 6, 7, or 8")
+		os.Exit(1)
+	}
+
+	// load the word list for that size and pick one
+	options, err := loadWords(wordsize)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	choice := options[rngutil.New(seed).Intn(len(options))]
+
+	// print greeting, using ANSI color codes to demonstrate
+	fmt.Printf("%sThis is WORDLE50%s\n", GREEN, RESET)
+	fmt.Printf("You have %d tries to guess the %d-letter word I'm thinking of\n", GUESSES, wordsize)
+
+	won := false
+	for i := 0; i < GUESSES && !won; i++ {
+		guess := getGuess(wordsize)
+		status := check(guess, choice)
+		fmt.Printf("Guess %d: ", i+1)
+		printWord(guess, status)
+
+		won = true
+		for _, s := range status {
+			if s != EXACT {
+				won = false
+			}
+		}
+	}
+
+	if won {
+		fmt.Println("You won!")
+	} else {
+		fmt.Printf("The word was %s\n", choice)
+	}
+}
+
+// loadWords returns the embedded words of one length.
+func loadWords(wordsize int) ([]string, error) {
+	data, err := wordFiles.ReadFile(fmt.Sprintf("words/%d.txt", wordsize))
+	if err != nil {
+		return nil, fmt.Errorf("error opening word list: %w", err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// getGuess keeps asking until the guess is wordsize letters.
+func getGuess(wordsize int) string {
+	for {
+		guess := strings.ToLower(cs50.GetString(fmt.Sprintf("Input a %d-letter word: ", wordsize)))
+		if len(guess) == wordsize && len(letters.Upper(guess)) == wordsize {
+			return guess
+		}
+	}
+}
+
+// check scores every letter of guess against choice. Exact matches are found
+// first, then each remaining letter of choice can turn at most one guessed
+// letter yellow. "eerie" against "there" has a green e at the end and two
+// more e's, but "there" has only one e left for them: one is yellow, the
+// other grey. "geese" against "those" has no yellow e at all, because the
+// only e in "those" is already green.
+func check(guess, choice string) []int {
+	status := make([]int, len(guess))
+	var unmatched [26]int
+	for i := range choice {
+		if guess[i] == choice[i] {
+			status[i] = EXACT
+		} else if c, ok := letters.Index(rune(choice[i])); ok {
+			unmatched[c]++
+		}
+	}
+
+	for i := range guess {
+		if status[i] == EXACT {
+			continue
+		}
+		if c, ok := letters.Index(rune(guess[i])); ok && unmatched[c] > 0 {
+			status[i] = CLOSE
+			unmatched[c]--
+		}
+	}
+	return status
+}
+
+// printWord prints guess one coloured letter at a time.
+func printWord(guess string, status []int) {
+	for i := range guess {
+		switch status[i] {
+		case EXACT:
+			fmt.Print(GREEN)
+		case CLOSE:
+			fmt.Print(YELLOW)
+		default:
+			fmt.Print(RED)
+		}
+		fmt.Printf("%c", guess[i])
+	}
+	fmt.Println(RESET)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		guess, choice string
+		want          []int
+	}{
+		{"hello", "hello", []int{EXACT, EXACT, EXACT, EXACT, EXACT}},
+		{"spine", "pines", []int{CLOSE, CLOSE, CLOSE, CLOSE, CLOSE}},
+		// those's only e is green, so geese's other two e's are grey
+		{"geese", "those", []int{WRONG, WRONG, WRONG, EXACT, EXACT}},
+		// there's second e is green, its first turns one of the other two yellow
+		{"eerie", "there", []int{CLOSE, WRONG, CLOSE, WRONG, EXACT}},
+		{"llama", "hello", []int{CLOSE, CLOSE, WRONG, WRONG, WRONG}},
+		{"brick", "table", []int{CLOSE, WRONG, WRONG, WRONG, WRONG}},
+	}
+	for _, tt := range tests {
+		if got := check(tt.guess, tt.choice); !slices.Equal(got, tt.want) {
+			t.Errorf("check(%q, %q) = %v, want %v", tt.guess, tt.choice, got, tt.want)
+		}
+	}
+}
+
+func TestWordLists(t *testing.T) {
+	for size := 5; size <= 8; size++ {
+		words, err := loadWords(size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(words) < 100 {
+			t.Errorf("only %d %d-letter words", len(words), size)
+		}
+		for _, w := range words {
+			if len(w) != size {
+				t.Errorf("%q in %d.txt", w, size)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package chart draws horizontal bar charts in the terminal, e.g. vote
+// counts per candidate:
+//
+//	Alice    4 ████████████████████
+//	Bob      3 ███████████████
+//	Charlie  2 ██████████
+//
+// Bars are scaled so the biggest value fills the width and are drawn with
+// eighth blocks, so small differences still show.
+package chart
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DEFAULT_WIDTH is used when the terminal width can't be found out.
+const DEFAULT_WIDTH = 80
+
+// MIN_BAR is the fewest columns a bar gets, however narrow the terminal.
+const MIN_BAR = 10
+
+// EIGHTHS are the partial blocks from 1/8 to 7/8 of a column.
+var EIGHTHS = []rune("▏▎▍▌▋▊▉")
+
+// FULL is a whole column of bar.
+const FULL = '█'
+
+// Bar is one row of a chart.
+type Bar struct {
+	Label string
+	Value int
+	Note  string // printed after the bar, e.g. "(eliminated)"
+}
+
+// Width returns the number of columns of the terminal: $COLUMNS if set,
+// else what stty reports for standard input, else DEFAULT_WIDTH.
+func Width() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	cmd := exec.Command("stty", "size")
+	cmd.Stdin = os.Stdin
+	if out, err := cmd.Output(); err == nil {
+		// "rows columns"
+		if fields := strings.Fields(string(out)); len(fields) == 2 {
+			if columns, err := strconv.Atoi(fields[1]); err == nil && columns > 0 {
+				return columns
+			}
+		}
+	}
+	return DEFAULT_WIDTH
+}
+
+// Fprint writes bars to w in at most width columns (notes may run over),
+// the biggest value filling the line.
+func Fprint(w io.Writer, bars []Bar, width int) {
+	top := 0
+	for _, b := range bars {
+		top = max(top, b.Value)
+	}
+	FprintScaled(w, bars, top, width)
+}
+
+// FprintScaled is Fprint with the value that fills the line given as top, so
+// that several charts (e.g. runoff rounds out of the same number of ballots)
+// can be compared bar for bar.
+func FprintScaled(w io.Writer, bars []Bar, top, width int) {
+	labelWidth, valueWidth := 0, 1
+	for _, b := range bars {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(b.Label))
+		valueWidth = max(valueWidth, len(strconv.Itoa(b.Value)))
+	}
+
+	// label, space, value, space, bar
+	barWidth := max(width-labelWidth-valueWidth-2, MIN_BAR)
+	for _, b := range bars {
+		padding := strings.Repeat(" ", labelWidth-utf8.RuneCountInString(b.Label))
+		line := fmt.Sprintf("%s%s %*d", b.Label, padding, valueWidth, b.Value)
+		for _, part := range []string{Draw(b.Value, top, barWidth), b.Note} {
+			if part != "" {
+				line += " " + part
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// Draw returns a bar for value out of max that is width columns long when
+// value == max. Negative values get no bar.
+func Draw(value, max, width int) string {
+	if value <= 0 || max <= 0 {
+		return ""
+	}
+	eighths := value * width * 8 / max
+	bar := strings.Repeat(string(FULL), eighths/8)
+	if eighths%8 > 0 {
+		bar += string(EIGHTHS[eighths%8-1])
+	}
+	return bar
+}
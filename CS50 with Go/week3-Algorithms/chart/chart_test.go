@@ -0,0 +1,67 @@
+package chart
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDraw(t *testing.T) {
+	tests := []struct {
+		value, max, width int
+		want              string
+	}{
+		{4, 4, 4, "████"},
+		{2, 4, 4, "██"},
+		{1, 4, 3, "▊"},  // 6/8 of a column
+		{3, 8, 3, "█▏"}, // 9/8
+		{0, 4, 4, ""},
+		{-1, 4, 4, ""},
+		{1, 0, 4, ""},
+	}
+	for _, tt := range tests {
+		if got := Draw(tt.value, tt.max, tt.width); got != tt.want {
+			t.Errorf("Draw(%d, %d, %d) = %q, want %q", tt.value, tt.max, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestFprint(t *testing.T) {
+	bars := []Bar{
+		{Label: "Alice", Value: 10},
+		{Label: "Bob", Value: 5},
+		{Label: "Charlie", Value: 0, Note: "(eliminated)"},
+	}
+	var out bytes.Buffer
+	Fprint(&out, bars, 21) // 7 label + 2 value + 2 spaces = 10 columns of bar
+
+	want := "Alice   10 ██████████\n" +
+		"Bob      5 █████\n" +
+		"Charlie  0 (eliminated)\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+
+	// A narrow terminal still gets MIN_BAR columns
+	out.Reset()
+	Fprint(&out, bars[:1], 5)
+	if want := "Alice 10 ██████████\n"; out.String() != want {
+		t.Errorf("narrow: got %q, want %q", out.String(), want)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "123")
+	if got := Width(); got != 123 {
+		t.Errorf("Width() = %d with COLUMNS=123", got)
+	}
+}
+
+func TestFprintScaled(t *testing.T) {
+	var out bytes.Buffer
+	FprintScaled(&out, []Bar{{Label: "A", Value: 5}, {Label: "B", Value: 2}}, 10, 14)
+	want := "A 5 █████\n" +
+		"B 2 ██\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
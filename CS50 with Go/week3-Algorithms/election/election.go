@@ -0,0 +1,174 @@
+// One program for every voting method: collect ranked ballots once, then
+// count them with plurality, Borda, approval, instant runoff or ranked pairs
+// (or all of them) to see how the choice of method changes the winner.
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"chart"
+	"elections"
+	"render"
+)
+
+// count is a row of what --format prints: one candidate's count under one
+// method.
+type count struct {
+	Method     string `json:"method"`
+	Candidate  string `json:"candidate"`
+	Votes      int    `json:"votes"`
+	Eliminated bool   `json:"eliminated"`
+	Winner     bool   `json:"winner"`
+}
+
+func main() {
+	render.Init()
+	method := flag.String("method", "all", "voting method: all, "+strings.Join(elections.MethodNames(), ", "))
+	ballotsPath := flag.String("ballots", "", "read ranked ballots from a CSV file instead of prompting")
+	showChart := flag.Bool("chart", false, "draw the counts as bars (every round for irv)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: election [-method NAME] [-ballots votes.csv] [-chart] [candidate ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Check for invalid usage
+	if flag.NArg() < 1 && *ballotsPath == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	methods := elections.METHODS
+	if *method != "all" {
+		m, err := elections.Method(*method)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		methods = []elections.VotingMethod{m}
+	}
+
+	var names []string
+	var ballots []elections.Ballot
+	var err error
+	if *ballotsPath != "" {
+		names, ballots, err = elections.LoadBallots(*ballotsPath, flag.Args())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+	} else {
+		names = flag.Args()
+		if _, err := elections.NewTally(names); err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		ballots, err = readBallots(names)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(3)
+		}
+	}
+
+	// Same ballots, fresh tally for every method
+	var counts []count
+	for _, m := range methods {
+		tally, _ := elections.NewTally(names)
+		winners := m.Elect(tally, ballots)
+		if !render.Text() {
+			for _, c := range tally.Candidates {
+				counts = append(counts, count{m.Name(), c.Name, c.Votes, c.Eliminated, slices.Contains(winners, c.Name)})
+			}
+		} else if *showChart {
+			chartResult(m, names, ballots, tally, winners)
+		} else {
+			printResult(m, tally, winners)
+		}
+	}
+	if !render.Text() {
+		if err := render.Print(counts); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+}
+
+// readBallots prompts for every voter's ranking. A blank rank ends that
+// voter's ballot early; approval counts only the ranked candidates.
+func readBallots(names []string) ([]elections.Ballot, error) {
+	tally, _ := elections.NewTally(names)
+	var voterCount int
+	for {
+		voterCount = cs50.GetInt(render.Prompt("Number of voters: "))
+		if voterCount >= 0 {
+			break
+		}
+	}
+
+	ballots := make([]elections.Ballot, 0, voterCount)
+	for i := 0; i < voterCount; i++ {
+		var ballot elections.Ballot
+		ranked := map[int]bool{}
+		for j := range names {
+			name := cs50.GetString(render.Prompt(fmt.Sprintf("Rank %d: ", j+1)))
+			if name == "" && j > 0 {
+				break
+			}
+
+			c, ok := tally.Index(name)
+			if !ok || ranked[c] {
+				return nil, fmt.Errorf("Invalid vote.")
+			}
+			ranked[c] = true
+			ballot = append(ballot, c)
+		}
+		ballots = append(ballots, ballot)
+		if render.Text() {
+			fmt.Println()
+		}
+	}
+	return ballots, nil
+}
+
+// printResult prints the winner(s) of one method and every candidate's count.
+func printResult(m elections.VotingMethod, tally *elections.Tally, winners []string) {
+	fmt.Printf("%s: %s\n", m.Name(), strings.Join(winners, ", "))
+	for _, c := range tally.Candidates {
+		note := ""
+		if c.Eliminated {
+			note = " (eliminated)"
+		}
+		fmt.Printf("    %-12s %4d%s\n", c.Name, c.Votes, note)
+	}
+}
+
+// chartResult is printResult with bars. Instant runoff draws every round,
+// each out of all the ballots, so you can watch votes move.
+func chartResult(m elections.VotingMethod, names []string, ballots []elections.Ballot, tally *elections.Tally, winners []string) {
+	fmt.Printf("%s: %s\n", m.Name(), strings.Join(winners, ", "))
+	width := chart.Width() - 4
+	var bars strings.Builder
+	if _, ok := m.(elections.InstantRunoff); !ok {
+		chart.Fprint(&bars, elections.Bars(tally.Candidates), width)
+	} else {
+		// Elect only keeps the last round, so run it again round by round
+		tally, _ := elections.NewTally(names)
+		_, rounds := tally.RunoffRounds(ballots, elections.RunoffOptions{})
+		for i, round := range rounds {
+			fmt.Fprintf(&bars, "round %d\n", i+1)
+			chart.FprintScaled(&bars, round.Bars(), len(ballots), width)
+		}
+	}
+
+	// Indent like printResult's counts
+	for _, line := range strings.SplitAfter(bars.String(), "\n") {
+		if line != "" {
+			fmt.Print("    " + line)
+		}
+	}
+}
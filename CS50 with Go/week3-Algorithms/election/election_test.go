@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// RUN_ENV makes the test binary run main instead, with the arguments after
+// "--": cs50 reads from os.Stdin, which a test can't swap once the package
+// is loaded.
+const RUN_ENV = "ELECTION_TEST_RUN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(RUN_ENV) != "" {
+		for i, arg := range os.Args {
+			if arg == "--" {
+				os.Args = append([]string{"election"}, os.Args[i+1:]...)
+				break
+			}
+		}
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// A negative number of voters is asked again rather than reaching make.
+func TestNegativeVoters(t *testing.T) {
+	tests := []struct {
+		input   string
+		prompts int
+		want    string
+	}{
+		{"-1\n2\nAlice\nBob\nAlice\nBob\n", 2, "plurality: Alice\n"},
+		{"-1\n-5\n0\n", 3, "plurality: Alice, Bob\n"},
+	}
+	for _, test := range tests {
+		cmd := exec.Command(os.Args[0], "--", "-method", "plurality", "Alice", "Bob")
+		cmd.Env = append(os.Environ(), RUN_ENV+"=1")
+		cmd.Stdin = strings.NewReader(test.input)
+		out, err := cmd.CombinedOutput()
+		if err != nil || !strings.Contains(string(out), test.want) || strings.Count(string(out), "Number of voters: ") != test.prompts {
+			t.Errorf("election with %q = %q, %v, want %q after %d prompts", test.input, out, err, test.want, test.prompts)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package elections
+
+import (
+	"slices"
+
+	"chart"
+)
+
+// Bars turns candidates into chart rows, noting who has been eliminated.
+func Bars(candidates []Candidate) []chart.Bar {
+	bars := make([]chart.Bar, len(candidates))
+	for i, c := range candidates {
+		bars[i] = chart.Bar{Label: c.Name, Value: c.Votes}
+		if c.Eliminated {
+			bars[i].Note = "(eliminated)"
+		}
+	}
+	return bars
+}
+
+// Bars are the candidates still running in the round, with the ones who go
+// out at the end of it noted.
+func (r Round) Bars() []chart.Bar {
+	var bars []chart.Bar
+	for _, c := range r.Candidates {
+		if c.Eliminated {
+			continue
+		}
+		bar := chart.Bar{Label: c.Name, Value: c.Votes}
+		if slices.Contains(r.Eliminated, c.Name) {
+			bar.Note = "(eliminated)"
+		}
+		bars = append(bars, bar)
+	}
+	return bars
+}
@@ -0,0 +1,132 @@
+package elections
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ReadBallots reads ranked ballots from CSV. The header row names the
+// candidates, and every other row is one voter giving each candidate a rank:
+//
+//	Alice,Bob,Charlie
+//	1,2,3
+//	2,,1
+//
+// 1 is the first choice; a blank cell leaves that candidate unranked. When
+// names is empty the header decides the candidates, otherwise the header
+// must list exactly those names (in any order). Errors name the row, counting
+// the header as row 1 like a spreadsheet does.
+func ReadBallots(r io.Reader, names []string) ([]string, []Ballot, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("row 1: missing header of candidate names")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	if len(names) == 0 {
+		names = header
+	}
+	tally, err := NewTally(names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("row 1: %w", err)
+	}
+
+	// column i of the CSV is candidate columns[i]
+	columns := make([]int, len(header))
+	seen := map[int]bool{}
+	for i, name := range header {
+		c, ok := tally.Index(name)
+		if !ok {
+			return nil, nil, fmt.Errorf("row 1: %q is not a candidate", name)
+		}
+		if seen[c] {
+			return nil, nil, fmt.Errorf("row 1: duplicate column %q", name)
+		}
+		seen[c] = true
+		columns[i] = c
+	}
+	if len(seen) != len(names) {
+		return nil, nil, fmt.Errorf("row 1: want a column for each of %s", strings.Join(names, ", "))
+	}
+
+	var ballots []Ballot
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		row, _ := reader.FieldPos(0)
+
+		ballot, err := parseBallot(record, columns)
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		ballots = append(ballots, ballot)
+	}
+	return names, ballots, nil
+}
+
+// parseBallot turns one row of ranks into candidates in order of preference.
+// Ranks must be 1..k without gaps or repeats.
+func parseBallot(record []string, columns []int) (Ballot, error) {
+	ballot := make(Ballot, len(columns))
+	filled := 0
+	for i, cell := range record {
+		cell = strings.TrimSpace(cell)
+		if cell == "" {
+			continue
+		}
+		rank, err := strconv.Atoi(cell)
+		if err != nil || rank < 1 || rank > len(columns) {
+			return nil, fmt.Errorf("rank %q must be a number from 1 to %d", cell, len(columns))
+		}
+		if filled&(1<<rank) != 0 {
+			return nil, fmt.Errorf("rank %d used twice", rank)
+		}
+		ballot[rank-1] = columns[i]
+		filled |= 1 << rank
+	}
+
+	// Ranks 1..k must all be there, then nothing after k
+	k := 0
+	for k < len(columns) && filled&(1<<(k+1)) != 0 {
+		k++
+	}
+	if k == 0 {
+		return nil, fmt.Errorf("no candidate ranked")
+	}
+	if filled != (1<<(k+1))-2 {
+		return nil, fmt.Errorf("ranks skip from %d", k)
+	}
+	return ballot[:k], nil
+}
+
+// LoadBallots is ReadBallots on a file, with the file name in errors.
+func LoadBallots(path string, names []string) ([]string, []Ballot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	names, ballots, err := ReadBallots(file, names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return names, ballots, nil
+}
@@ -0,0 +1,58 @@
+package elections
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadBallots(t *testing.T) {
+	input := "Alice, Bob, Charlie\n1,2,3\n2,,1\n,1,\n3,2,1\n"
+	names, ballots, err := ReadBallots(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(names, []string{"Alice", "Bob", "Charlie"}) {
+		t.Errorf("names = %v", names)
+	}
+	want := []Ballot{{0, 1, 2}, {2, 0}, {1}, {2, 1, 0}}
+	if !slices.EqualFunc(ballots, want, slices.Equal) {
+		t.Errorf("ballots = %v, want %v", ballots, want)
+	}
+}
+
+func TestReadBallotsColumnOrder(t *testing.T) {
+	// Columns in a different order from argv still map to the right candidate
+	_, ballots, err := ReadBallots(strings.NewReader("Charlie,Alice,Bob\n1,3,2\n"), []string{"Alice", "Bob", "Charlie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ballots[0], Ballot{2, 1, 0}) {
+		t.Errorf("ballot = %v, want [2 1 0]", ballots[0])
+	}
+}
+
+func TestReadBallotsErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		names []string
+		want  string
+	}{
+		{"", nil, "row 1: missing header"},
+		{"Alice,Bob,Alice\n", nil, "row 1: duplicate candidate"},
+		{"Alice,Bob\n", []string{"Alice", "Bob", "Charlie"}, "row 1: want a column for each"},
+		{"Alice,Dave\n", []string{"Alice", "Bob"}, `row 1: "Dave" is not a candidate`},
+		{"Alice,Bob\n1,2\n2,x\n", nil, `row 3: rank "x" must be a number from 1 to 2`},
+		{"Alice,Bob\n1,2\n1,2\n3,1\n", nil, "row 4: rank \"3\""},
+		{"Alice,Bob\n1,1\n", nil, "row 2: rank 1 used twice"},
+		{"Alice,Bob,Charlie\n1,,3\n", nil, "row 2: ranks skip from 1"},
+		{"Alice,Bob,Charlie\n,2,3\n", nil, "row 2: no candidate ranked"},
+		{"Alice,Bob\n,\n", nil, "row 2: no candidate ranked"},
+	}
+	for _, tt := range tests {
+		_, _, err := ReadBallots(strings.NewReader(tt.input), tt.names)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ReadBallots(%q) error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package elections holds what the week 3 voting psets share: the list of
+// candidates with their vote counts, and counting votes by name.
+// plurality, runoff and tideman are all built on top of it.
+package elections
+
+import "fmt"
+
+// MAX is the maximum number of candidates, as in the C distribution code.
+const MAX = 9
+
+// Candidate is one name on the ballot.
+type Candidate struct {
+	Name       string
+	Votes      int
+	Eliminated bool
+}
+
+// Tally is the candidates of one election in argv order.
+type Tally struct {
+	Candidates []Candidate
+	index      map[string]int
+}
+
+// NewTally returns a tally with zero votes for each name. It fails when
+// there are no names, more than MAX, or the same name twice.
+func NewTally(names []string) (*Tally, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no candidates")
+	}
+	if len(names) > MAX {
+		return nil, fmt.Errorf("Maximum number of candidates is %d", MAX)
+	}
+
+	t := &Tally{Candidates: make([]Candidate, len(names)), index: make(map[string]int, len(names))}
+	for i, name := range names {
+		if _, dup := t.index[name]; dup {
+			return nil, fmt.Errorf("duplicate candidate %q", name)
+		}
+		t.Candidates[i] = Candidate{Name: name}
+		t.index[name] = i
+	}
+	return t, nil
+}
+
+// Index returns the position of a candidate by name.
+func (t *Tally) Index(name string) (int, bool) {
+	i, ok := t.index[name]
+	return i, ok
+}
+
+// Names returns every candidate's name in order.
+func (t *Tally) Names() []string {
+	names := make([]string, len(t.Candidates))
+	for i, c := range t.Candidates {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Vote adds one vote for name. It returns false (and counts nothing) when
+// name isn't a candidate.
+func (t *Tally) Vote(name string) bool {
+	i, ok := t.index[name]
+	if !ok {
+		return false
+	}
+	t.Candidates[i].Votes++
+	return true
+}
+
+// Winners returns every remaining candidate with the most votes; more than
+// one name means a tie.
+func (t *Tally) Winners() []string {
+	most := -1
+	for _, c := range t.Candidates {
+		if !c.Eliminated && c.Votes > most {
+			most = c.Votes
+		}
+	}
+
+	var winners []string
+	for _, c := range t.Candidates {
+		if !c.Eliminated && c.Votes == most {
+			winners = append(winners, c.Name)
+		}
+	}
+	return winners
+}
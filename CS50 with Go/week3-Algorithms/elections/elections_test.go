@@ -0,0 +1,58 @@
+package elections
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewTally(t *testing.T) {
+	if _, err := NewTally(nil); err == nil {
+		t.Error("no candidates: want error")
+	}
+	if _, err := NewTally([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}); err == nil {
+		t.Error("10 candidates: want error")
+	}
+	if _, err := NewTally([]string{"Alice", "Bob", "Alice"}); err == nil {
+		t.Error("duplicate: want error")
+	}
+
+	tally, err := NewTally([]string{"Alice", "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i, ok := tally.Index("Bob"); !ok || i != 1 {
+		t.Errorf("Index(Bob) = %d, %v", i, ok)
+	}
+	if !slices.Equal(tally.Names(), []string{"Alice", "Bob"}) {
+		t.Errorf("Names() = %v", tally.Names())
+	}
+}
+
+func TestPlurality(t *testing.T) {
+	tests := []struct {
+		votes   []string
+		invalid int
+		winners []string
+	}{
+		{[]string{"Alice", "Bob", "Alice"}, 0, []string{"Alice"}},
+		{[]string{"Alice", "Bob", "Charlie", "Bob"}, 0, []string{"Bob"}},
+		{[]string{"Alice", "Bob"}, 0, []string{"Alice", "Bob"}},
+		{[]string{"alice", "Dave", "Charlie"}, 2, []string{"Charlie"}},
+		{nil, 0, []string{"Alice", "Bob", "Charlie"}},
+	}
+	for _, tt := range tests {
+		tally, _ := NewTally([]string{"Alice", "Bob", "Charlie"})
+		invalid := 0
+		for _, v := range tt.votes {
+			if !tally.Vote(v) {
+				invalid++
+			}
+		}
+		if invalid != tt.invalid {
+			t.Errorf("%v: %d invalid votes, want %d", tt.votes, invalid, tt.invalid)
+		}
+		if got := tally.Winners(); !slices.Equal(got, tt.winners) {
+			t.Errorf("%v: Winners() = %v, want %v", tt.votes, got, tt.winners)
+		}
+	}
+}
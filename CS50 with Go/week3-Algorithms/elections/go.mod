@@ -0,0 +1,16 @@
+module elections
+
+go 1.24.4
+
+require (
+	chart v0.0.0
+	graph v0.0.0
+)
+
+require cs50 v0.0.0 // indirect
+
+replace (
+	chart => ../chart
+	cs50 => ../../cs50
+	graph => ../../week5-Data-Strucutes/graph
+)
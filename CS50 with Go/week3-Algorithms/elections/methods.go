@@ -0,0 +1,121 @@
+package elections
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VotingMethod turns the same ranked ballots into a result. A ballot may rank
+// fewer than all candidates; how unranked candidates count is up to the method.
+type VotingMethod interface {
+	// Name is what `election -method` accepts.
+	Name() string
+
+	// Elect counts ballots into t and returns the winner(s). What ends up in
+	// each candidate's Votes depends on the method (see each type).
+	Elect(t *Tally, ballots []Ballot) []string
+}
+
+// Plurality counts first choices only. Votes: first choices.
+type Plurality struct{}
+
+func (Plurality) Name() string { return "plurality" }
+
+func (Plurality) Elect(t *Tally, ballots []Ballot) []string {
+	for _, ballot := range ballots {
+		if len(ballot) > 0 {
+			t.Candidates[ballot[0]].Votes++
+		}
+	}
+	return t.Winners()
+}
+
+// Borda gives n-1 points for a first choice, n-2 for a second, down to 0 for
+// last place or unranked. Votes: points.
+type Borda struct{}
+
+func (Borda) Name() string { return "borda" }
+
+func (Borda) Elect(t *Tally, ballots []Ballot) []string {
+	n := len(t.Candidates)
+	for _, ballot := range ballots {
+		for rank, c := range ballot {
+			t.Candidates[c].Votes += n - 1 - rank
+		}
+	}
+	return t.Winners()
+}
+
+// Approval treats every candidate a voter ranked as approved, regardless of
+// order, and the most approved wins. Votes: approvals.
+type Approval struct{}
+
+func (Approval) Name() string { return "approval" }
+
+func (Approval) Elect(t *Tally, ballots []Ballot) []string {
+	for _, ballot := range ballots {
+		for _, c := range ballot {
+			t.Candidates[c].Votes++
+		}
+	}
+	return t.Winners()
+}
+
+// InstantRunoff is the runoff pset. Votes: the final round's count, with the
+// eliminated candidates marked.
+type InstantRunoff struct{}
+
+func (InstantRunoff) Name() string { return "irv" }
+
+func (InstantRunoff) Elect(t *Tally, ballots []Ballot) []string {
+	return t.Runoff(ballots)
+}
+
+// RankedPairs is the tideman pset. Votes: head-to-head victories.
+type RankedPairs struct{}
+
+func (RankedPairs) Name() string { return "ranked-pairs" }
+
+func (RankedPairs) Elect(t *Tally, ballots []Ballot) []string {
+	for _, p := range Pairs(Preferences(len(t.Candidates), ballots)) {
+		t.Candidates[p.Winner].Votes++
+	}
+	return t.Tideman(ballots)
+}
+
+// METHODS lists every voting method in the order `-method all` runs them.
+var METHODS = []VotingMethod{Plurality{}, Borda{}, Approval{}, InstantRunoff{}, RankedPairs{}}
+
+// aliases are the pset names for methods that have a more general one.
+var aliases = map[string]string{
+	"runoff":  "irv",
+	"tideman": "ranked-pairs",
+}
+
+// MethodNames returns the accepted method names, sorted.
+func MethodNames() []string {
+	var names []string
+	for _, m := range METHODS {
+		names = append(names, m.Name())
+	}
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Method looks up a voting method by name or pset alias, ignoring case.
+func Method(name string) (VotingMethod, error) {
+	name = strings.ToLower(name)
+	if real, ok := aliases[name]; ok {
+		name = real
+	}
+	for _, m := range METHODS {
+		if m.Name() == name {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown method %q (want one of %s)", name, strings.Join(MethodNames(), ", "))
+}
@@ -0,0 +1,85 @@
+package elections
+
+import (
+	"slices"
+	"testing"
+)
+
+// The classic example where plurality, instant runoff and the pairwise
+// methods each pick someone else: 9 voters, 3 candidates. Borda agrees with
+// ranked pairs on Bob, and approval with full rankings ties everyone.
+//
+//	4: Alice > Bob > Charlie
+//	3: Charlie > Bob > Alice
+//	2: Bob > Charlie > Alice
+var disagree = ballots(
+	4, Ballot{0, 1, 2},
+	3, Ballot{2, 1, 0},
+	2, Ballot{1, 2, 0},
+)
+
+func elect(t *testing.T, m VotingMethod, b []Ballot) ([]string, []int) {
+	t.Helper()
+	tally, err := NewTally([]string{"Alice", "Bob", "Charlie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	winners := m.Elect(tally, b)
+	return winners, votes(tally)
+}
+
+func TestMethods(t *testing.T) {
+	tests := []struct {
+		method  VotingMethod
+		ballots []Ballot
+		winners []string
+		votes   []int
+	}{
+		{Plurality{}, disagree, []string{"Alice"}, []int{4, 2, 3}},
+		// Alice 4*2, Bob 4+3+2*2, Charlie 3*2+2
+		{Borda{}, disagree, []string{"Bob"}, []int{8, 11, 8}},
+		// IRV drops Bob first, his voters go to Charlie
+		{InstantRunoff{}, disagree, []string{"Charlie"}, []int{4, 0, 5}},
+		// Bob beats both head to head
+		{RankedPairs{}, disagree, []string{"Bob"}, []int{0, 2, 1}},
+		// Full rankings approve everyone
+		{Approval{}, disagree, []string{"Alice", "Bob", "Charlie"}, []int{9, 9, 9}},
+		{Approval{}, []Ballot{{0}, {1, 0}, {2}}, []string{"Alice"}, []int{2, 1, 1}},
+		// Unranked candidates get 0 points
+		{Borda{}, []Ballot{{2}, {1, 0}}, []string{"Bob", "Charlie"}, []int{1, 2, 2}},
+	}
+	for _, tt := range tests {
+		winners, got := elect(t, tt.method, tt.ballots)
+		if !slices.Equal(winners, tt.winners) {
+			t.Errorf("%s: winners %v, want %v", tt.method.Name(), winners, tt.winners)
+		}
+		if tt.votes != nil && !slices.Equal(got, tt.votes) {
+			t.Errorf("%s: votes %v, want %v", tt.method.Name(), got, tt.votes)
+		}
+	}
+}
+
+func TestPartialBallotPreferences(t *testing.T) {
+	// Ranking only Bob puts him above both others, who stay tied
+	got := Preferences(3, []Ballot{{1}})
+	want := [][]int{{0, 0, 0}, {1, 0, 1}, {0, 0, 0}}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Preferences = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMethod(t *testing.T) {
+	for _, name := range MethodNames() {
+		if _, err := Method(name); err != nil {
+			t.Errorf("Method(%q): %v", name, err)
+		}
+	}
+	if m, _ := Method("Tideman"); m.Name() != "ranked-pairs" {
+		t.Errorf("Method(Tideman) = %s", m.Name())
+	}
+	if _, err := Method("coin-toss"); err == nil {
+		t.Error("Method(coin-toss): want error")
+	}
+}
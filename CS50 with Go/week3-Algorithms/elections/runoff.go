@@ -0,0 +1,83 @@
+package elections
+
+// MAX_VOTERS is the maximum number of voters, as in runoff.c.
+const MAX_VOTERS = 100
+
+// Ballot is one voter's ranking: Ballot[0] is the index of their first
+// choice, Ballot[1] their second, and so on.
+type Ballot []int
+
+// Tabulate recounts the votes: every ballot counts for its highest ranked
+// candidate that is still in the race.
+func (t *Tally) Tabulate(ballots []Ballot) {
+	for i := range t.Candidates {
+		t.Candidates[i].Votes = 0
+	}
+	for _, ballot := range ballots {
+		for _, c := range ballot {
+			if !t.Candidates[c].Eliminated {
+				t.Candidates[c].Votes++
+				break
+			}
+		}
+	}
+}
+
+// Majority returns the candidate with more than half of voters votes, if any.
+func (t *Tally) Majority(voters int) (string, bool) {
+	for _, c := range t.Candidates {
+		if !c.Eliminated && c.Votes > voters/2 {
+			return c.Name, true
+		}
+	}
+	return "", false
+}
+
+// FindMin returns the fewest votes any remaining candidate has.
+func (t *Tally) FindMin() int {
+	min := -1
+	for _, c := range t.Candidates {
+		if !c.Eliminated && (min == -1 || c.Votes < min) {
+			min = c.Votes
+		}
+	}
+	return min
+}
+
+// IsTie reports whether every remaining candidate has exactly min votes.
+func (t *Tally) IsTie(min int) bool {
+	for _, c := range t.Candidates {
+		if !c.Eliminated && c.Votes != min {
+			return false
+		}
+	}
+	return true
+}
+
+// Eliminate knocks out every remaining candidate with min votes.
+func (t *Tally) Eliminate(min int) {
+	for i, c := range t.Candidates {
+		if !c.Eliminated && c.Votes == min {
+			t.Candidates[i].Eliminated = true
+		}
+	}
+}
+
+// Remaining returns the names of the candidates still in the race.
+func (t *Tally) Remaining() []string {
+	var names []string
+	for _, c := range t.Candidates {
+		if !c.Eliminated {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// Runoff runs instant-runoff rounds until someone has a majority or the
+// remaining candidates are all tied, eliminating everyone tied for last
+// place. It returns the winner(s).
+func (t *Tally) Runoff(ballots []Ballot) []string {
+	winners, _ := t.RunoffRounds(ballots, RunoffOptions{})
+	return winners
+}
@@ -0,0 +1,166 @@
+package elections
+
+import (
+	"slices"
+	"testing"
+)
+
+// newRunoff returns a tally of Alice, Bob and Charlie with some eliminated.
+func newRunoff(t *testing.T, eliminated ...string) *Tally {
+	t.Helper()
+	tally, err := NewTally([]string{"Alice", "Bob", "Charlie"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range eliminated {
+		i, _ := tally.Index(name)
+		tally.Candidates[i].Eliminated = true
+	}
+	return tally
+}
+
+func votes(tally *Tally) []int {
+	v := make([]int, len(tally.Candidates))
+	for i, c := range tally.Candidates {
+		v[i] = c.Votes
+	}
+	return v
+}
+
+func TestTabulate(t *testing.T) {
+	ballots := []Ballot{
+		{0, 1, 2},
+		{0, 2, 1},
+		{1, 2, 0},
+		{2, 0, 1},
+		{2, 1, 0},
+	}
+	tests := []struct {
+		eliminated []string
+		want       []int
+	}{
+		{nil, []int{2, 1, 2}},
+		{[]string{"Bob"}, []int{2, 0, 3}},
+		{[]string{"Alice"}, []int{0, 2, 3}},
+		{[]string{"Alice", "Charlie"}, []int{0, 5, 0}},
+	}
+	for _, tt := range tests {
+		tally := newRunoff(t, tt.eliminated...)
+		tally.Tabulate(ballots)
+		if got := votes(tally); !slices.Equal(got, tt.want) {
+			t.Errorf("eliminated %v: votes %v, want %v", tt.eliminated, got, tt.want)
+		}
+
+		// Tabulating twice recounts from zero
+		tally.Tabulate(ballots)
+		if got := votes(tally); !slices.Equal(got, tt.want) {
+			t.Errorf("eliminated %v, second Tabulate: votes %v, want %v", tt.eliminated, got, tt.want)
+		}
+	}
+}
+
+func TestMajority(t *testing.T) {
+	tally := newRunoff(t)
+	tally.Candidates[0].Votes = 2
+	tally.Candidates[1].Votes = 2
+	if _, ok := tally.Majority(4); ok {
+		t.Error("2 of 4 is not a majority")
+	}
+	tally.Candidates[1].Votes = 3
+	if name, ok := tally.Majority(5); !ok || name != "Bob" {
+		t.Errorf("3 of 5: Majority = %q, %v, want Bob", name, ok)
+	}
+}
+
+func TestFindMin(t *testing.T) {
+	tests := []struct {
+		votes      []int
+		eliminated []string
+		want       int
+	}{
+		{[]int{1, 2, 3}, nil, 1},
+		{[]int{4, 2, 3}, nil, 2},
+		{[]int{0, 2, 3}, []string{"Alice"}, 2},
+		{[]int{5, 5, 5}, nil, 5},
+		{[]int{0, 0, 7}, []string{"Alice", "Bob"}, 7},
+	}
+	for _, tt := range tests {
+		tally := newRunoff(t, tt.eliminated...)
+		for i, v := range tt.votes {
+			tally.Candidates[i].Votes = v
+		}
+		if got := tally.FindMin(); got != tt.want {
+			t.Errorf("votes %v eliminated %v: FindMin = %d, want %d", tt.votes, tt.eliminated, got, tt.want)
+		}
+	}
+}
+
+func TestIsTie(t *testing.T) {
+	tests := []struct {
+		votes      []int
+		eliminated []string
+		min        int
+		want       bool
+	}{
+		{[]int{3, 3, 3}, nil, 3, true},
+		{[]int{3, 3, 4}, nil, 3, false},
+		{[]int{1, 3, 3}, []string{"Alice"}, 3, true},
+		{[]int{2, 2, 5}, nil, 2, false},
+		{[]int{0, 0, 6}, []string{"Alice", "Bob"}, 6, true},
+	}
+	for _, tt := range tests {
+		tally := newRunoff(t, tt.eliminated...)
+		for i, v := range tt.votes {
+			tally.Candidates[i].Votes = v
+		}
+		if got := tally.IsTie(tt.min); got != tt.want {
+			t.Errorf("votes %v eliminated %v: IsTie(%d) = %v, want %v", tt.votes, tt.eliminated, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestEliminate(t *testing.T) {
+	tests := []struct {
+		votes      []int
+		eliminated []string
+		min        int
+		want       []string
+	}{
+		{[]int{1, 2, 3}, nil, 1, []string{"Bob", "Charlie"}},
+		{[]int{1, 1, 3}, nil, 1, []string{"Charlie"}},
+		// Already eliminated candidates stay out, even with more votes
+		{[]int{0, 2, 3}, []string{"Alice"}, 2, []string{"Charlie"}},
+		{[]int{4, 2, 3}, nil, 1, []string{"Alice", "Bob", "Charlie"}},
+	}
+	for _, tt := range tests {
+		tally := newRunoff(t, tt.eliminated...)
+		for i, v := range tt.votes {
+			tally.Candidates[i].Votes = v
+		}
+		tally.Eliminate(tt.min)
+		if got := tally.Remaining(); !slices.Equal(got, tt.want) {
+			t.Errorf("votes %v: Eliminate(%d) leaves %v, want %v", tt.votes, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestRunoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		ballots []Ballot
+		want    []string
+	}{
+		{"first round majority", []Ballot{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}}, []string{"Alice"}},
+		// Charlie is out first, his voter's second choice (Bob) decides it
+		{"second round", []Ballot{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 1, 0}}, []string{"Bob"}},
+		{"three-way tie", []Ballot{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}}, []string{"Alice", "Bob", "Charlie"}},
+		// Bob and Charlie tie for last and are eliminated together
+		{"tied last place", []Ballot{{0, 1, 2}, {0, 2, 1}, {1, 2, 0}, {2, 1, 0}, {0, 1, 2}, {1, 0, 2}, {2, 0, 1}}, []string{"Alice"}},
+	}
+	for _, tt := range tests {
+		tally := newRunoff(t)
+		if got := tally.Runoff(tt.ballots); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Runoff = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
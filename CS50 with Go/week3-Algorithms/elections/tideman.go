@@ -0,0 +1,113 @@
+package elections
+
+import (
+	"sort"
+
+	"graph"
+)
+
+// Pair is one head-to-head result: more voters ranked Winner above Loser
+// than the other way around. Both are candidate indexes.
+type Pair struct {
+	Winner int
+	Loser  int
+}
+
+// Preferences counts, for every i and j, how many voters ranked candidate i
+// above candidate j (preferences[i][j] in tideman.c). A ballot that doesn't
+// rank everyone puts every ranked candidate above the unranked ones.
+func Preferences(candidates int, ballots []Ballot) [][]int {
+	preferences := make([][]int, candidates)
+	for i := range preferences {
+		preferences[i] = make([]int, candidates)
+	}
+	ranked := make([]bool, candidates)
+	for _, ranks := range ballots {
+		clear(ranked)
+		for _, c := range ranks {
+			ranked[c] = true
+		}
+		for i, above := range ranks {
+			for _, below := range ranks[i+1:] {
+				preferences[above][below]++
+			}
+			for below, r := range ranked {
+				if !r {
+					preferences[above][below]++
+				}
+			}
+		}
+	}
+	return preferences
+}
+
+// Pairs returns every pair with a winner (ties are left out), strongest
+// victory first. Strength is how many voters prefer the winner; equally
+// strong pairs keep the order they were found in.
+func Pairs(preferences [][]int) []Pair {
+	var pairs []Pair
+	for i := range preferences {
+		for j := i + 1; j < len(preferences); j++ {
+			switch {
+			case preferences[i][j] > preferences[j][i]:
+				pairs = append(pairs, Pair{i, j})
+			case preferences[j][i] > preferences[i][j]:
+				pairs = append(pairs, Pair{j, i})
+			}
+		}
+	}
+
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return preferences[pairs[a].Winner][pairs[a].Loser] > preferences[pairs[b].Winner][pairs[b].Loser]
+	})
+	return pairs
+}
+
+// Lock adds the pairs to a directed graph (winner -> loser) in order,
+// skipping any pair that would close a cycle. Nodes are candidate indexes
+// carrying the candidate's name.
+func Lock(names []string, pairs []Pair) *graph.Graph[int, string] {
+	locked := graph.NewDirected[int, string]()
+	for i, name := range names {
+		locked.AddNode(i, name)
+	}
+	for _, p := range pairs {
+		if !locked.WouldCreateCycle(p.Winner, p.Loser) {
+			locked.AddEdge(p.Winner, p.Loser)
+		}
+	}
+	return locked
+}
+
+// Sources returns the candidates no locked edge points at. Locking never
+// makes a cycle, so there is always at least one; there's more than one only
+// when some head-to-heads were exact ties.
+func Sources(locked *graph.Graph[int, string]) []int {
+	beaten := map[int]bool{}
+	for _, id := range locked.Nodes() {
+		for _, loser := range locked.Neighbors(id) {
+			beaten[loser] = true
+		}
+	}
+
+	var sources []int
+	for _, id := range locked.Nodes() {
+		if !beaten[id] {
+			sources = append(sources, id)
+		}
+	}
+	return sources
+}
+
+// Tideman runs a ranked pairs election and returns the winner(s).
+func (t *Tally) Tideman(ballots []Ballot) []string {
+	names := t.Names()
+	preferences := Preferences(len(names), ballots)
+	locked := Lock(names, Pairs(preferences))
+
+	var winners []string
+	for _, id := range Sources(locked) {
+		winners = append(winners, names[id])
+	}
+	return winners
+}
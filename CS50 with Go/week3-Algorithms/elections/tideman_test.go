@@ -0,0 +1,134 @@
+package elections
+
+import (
+	"slices"
+	"testing"
+)
+
+// ballots repeats each ranking count times.
+func ballots(rankings ...any) []Ballot {
+	var out []Ballot
+	for i := 0; i < len(rankings); i += 2 {
+		for n := 0; n < rankings[i].(int); n++ {
+			out = append(out, rankings[i+1].(Ballot))
+		}
+	}
+	return out
+}
+
+func TestPreferences(t *testing.T) {
+	got := Preferences(3, []Ballot{{0, 1, 2}, {1, 2, 0}, {0, 2, 1}})
+	want := [][]int{
+		{0, 2, 2},
+		{1, 0, 2},
+		{1, 1, 0},
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Preferences = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPairs(t *testing.T) {
+	preferences := [][]int{
+		{0, 5, 3, 6},
+		{4, 0, 7, 4},
+		{6, 2, 0, 9},
+		{3, 4, 0, 0},
+	}
+	// 0>1 by 5-4, 2>0 by 6-3, 0>3 by 6-3, 1>2 by 7-2, 1 vs 3 tied, 2>3 by 9-0
+	want := []Pair{{2, 3}, {1, 2}, {2, 0}, {0, 3}, {0, 1}}
+	if got := Pairs(preferences); !slices.Equal(got, want) {
+		t.Errorf("Pairs = %v, want %v", got, want)
+	}
+}
+
+func TestPairsTiesLeftOut(t *testing.T) {
+	preferences := [][]int{
+		{0, 2},
+		{2, 0},
+	}
+	if got := Pairs(preferences); len(got) != 0 {
+		t.Errorf("Pairs of a tie = %v, want none", got)
+	}
+}
+
+func TestLockSkipsCycles(t *testing.T) {
+	names := []string{"Alice", "Bob", "Charlie"}
+	locked := Lock(names, []Pair{{0, 1}, {1, 2}, {2, 0}})
+
+	for _, p := range []Pair{{0, 1}, {1, 2}} {
+		if !locked.HasEdge(p.Winner, p.Loser) {
+			t.Errorf("pair %v not locked", p)
+		}
+	}
+	if locked.HasEdge(2, 0) {
+		t.Error("locked Charlie -> Alice, which closes a cycle")
+	}
+	if locked.HasCycle() {
+		t.Error("locked graph has a cycle")
+	}
+	if name, _ := locked.Node(1); name != "Bob" {
+		t.Errorf("node 1 = %q, want Bob", name)
+	}
+}
+
+func TestLockLongerCycle(t *testing.T) {
+	// 0->1->2->3 locks; 3->0 would close a four-candidate loop, 1->3 is fine
+	locked := Lock([]string{"a", "b", "c", "d"}, []Pair{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {1, 3}})
+	if locked.HasEdge(3, 0) {
+		t.Error("locked 3 -> 0")
+	}
+	if !locked.HasEdge(1, 3) {
+		t.Error("1 -> 3 should lock")
+	}
+	if got := Sources(locked); !slices.Equal(got, []int{0}) {
+		t.Errorf("Sources = %v, want [0]", got)
+	}
+}
+
+func TestTideman(t *testing.T) {
+	names := []string{"Alice", "Bob", "Charlie"}
+	tests := []struct {
+		name    string
+		ballots []Ballot
+		want    []string
+	}{
+		{"condorcet winner", ballots(
+			3, Ballot{0, 1, 2},
+			2, Ballot{1, 2, 0},
+			1, Ballot{2, 0, 1},
+		), []string{"Alice"}},
+		// Rock-paper-scissors: A>B, B>C, C>A all 2-1. The last pair found
+		// (B>C) would close the cycle, so Charlie stays unbeaten.
+		{"cycle", ballots(
+			1, Ballot{0, 1, 2},
+			1, Ballot{1, 2, 0},
+			1, Ballot{2, 0, 1},
+		), []string{"Charlie"}},
+		// The CS50 walkthrough: A>B 7-2, C>A 6-3, B>C 5-4. Locking the two
+		// strongest makes C the source, B>C is skipped.
+		{"cs50 cycle", ballots(
+			3, Ballot{0, 1, 2},
+			2, Ballot{1, 2, 0},
+			2, Ballot{2, 0, 1},
+			2, Ballot{2, 0, 1},
+		), []string{"Charlie"}},
+		{"plurality loser can win", ballots(
+			4, Ballot{0, 1, 2},
+			3, Ballot{2, 1, 0},
+			2, Ballot{1, 2, 0},
+		), []string{"Bob"}},
+		{"no votes", nil, []string{"Alice", "Bob", "Charlie"}},
+	}
+	for _, tt := range tests {
+		tally, err := NewTally(names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tally.Tideman(tt.ballots); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Tideman = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package elections
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TieBreak decides who is eliminated when several candidates share last
+// place in a runoff round.
+type TieBreak int
+
+const (
+	// TIEBREAK_ALL eliminates every candidate tied for last, the CS50 rule.
+	TIEBREAK_ALL TieBreak = iota
+	// TIEBREAK_RANDOM eliminates one of them, chosen at random.
+	TIEBREAK_RANDOM
+	// TIEBREAK_BALLOT_ORDER eliminates the one listed last on the ballot
+	// (command line or CSV header order).
+	TIEBREAK_BALLOT_ORDER
+)
+
+var tieBreakNames = map[TieBreak]string{
+	TIEBREAK_ALL:          "all",
+	TIEBREAK_RANDOM:       "random",
+	TIEBREAK_BALLOT_ORDER: "ballot-order",
+}
+
+// TIEBREAK_DESCRIPTIONS say in a sentence what each policy does, for the
+// summary a runoff prints.
+var TIEBREAK_DESCRIPTIONS = map[TieBreak]string{
+	TIEBREAK_ALL:          "Every candidate tied for last place is eliminated at once (the CS50 rule), which can knock out several candidates whose votes would have decided the race",
+	TIEBREAK_RANDOM:       "One candidate tied for last place is eliminated at random, so the same ballots can elect someone else with another seed",
+	TIEBREAK_BALLOT_ORDER: "The candidate tied for last place who is listed last on the ballot is eliminated, so ballot position can decide close races",
+}
+
+func (tb TieBreak) String() string {
+	return tieBreakNames[tb]
+}
+
+// TieBreakNames returns the accepted policy names, sorted.
+func TieBreakNames() []string {
+	var names []string
+	for _, name := range tieBreakNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTieBreak looks a policy up by name, ignoring case.
+func ParseTieBreak(name string) (TieBreak, error) {
+	for tb, n := range tieBreakNames {
+		if strings.EqualFold(n, name) {
+			return tb, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tie-break %q (want one of %s)", name, strings.Join(TieBreakNames(), ", "))
+}
+
+// RunoffOptions configure RunoffRounds. The zero value is the CS50 runoff.
+type RunoffOptions struct {
+	TieBreak TieBreak
+	Rand     *rand.Rand // for TIEBREAK_RANDOM; nil uses a fixed seed
+}
+
+// Round is one round of counting in a runoff.
+type Round struct {
+	// Candidates is the count after tabulating, eliminated ones included.
+	Candidates []Candidate
+	// Tied is who shared last place when more than one did.
+	Tied []string
+	// Eliminated is who was knocked out at the end of the round.
+	Eliminated []string
+}
+
+// RunoffRounds runs instant-runoff rounds like Runoff, breaking ties for
+// last place with opts.TieBreak, and returns the winner(s) and every round.
+// When all remaining candidates are tied they all win, whatever the policy.
+func (t *Tally) RunoffRounds(ballots []Ballot, opts RunoffOptions) ([]string, []Round) {
+	rng := opts.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+
+	var rounds []Round
+	for {
+		// Calculate votes given remaining candidates
+		t.Tabulate(ballots)
+		round := Round{Candidates: slices.Clone(t.Candidates)}
+
+		// Check if election has been won
+		if winner, ok := t.Majority(len(ballots)); ok {
+			return []string{winner}, append(rounds, round)
+		}
+
+		// If tie, everyone left wins
+		min := t.FindMin()
+		if t.IsTie(min) {
+			return t.Remaining(), append(rounds, round)
+		}
+
+		// Who is in last place
+		var last []int
+		for i, c := range t.Candidates {
+			if !c.Eliminated && c.Votes == min {
+				last = append(last, i)
+			}
+		}
+		if len(last) > 1 {
+			for _, i := range last {
+				round.Tied = append(round.Tied, t.Candidates[i].Name)
+			}
+		}
+
+		// Break the tie
+		switch opts.TieBreak {
+		case TIEBREAK_RANDOM:
+			last = []int{last[rng.Intn(len(last))]}
+		case TIEBREAK_BALLOT_ORDER:
+			last = last[len(last)-1:]
+		}
+		for _, i := range last {
+			t.Candidates[i].Eliminated = true
+			round.Eliminated = append(round.Eliminated, t.Candidates[i].Name)
+		}
+		rounds = append(rounds, round)
+	}
+}
@@ -0,0 +1,117 @@
+package elections
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// tieBallots has Charlie and David tied for last in round 1. Eliminating both
+// hands the race to Bob; eliminating only David lets Charlie win.
+func tieBallots(t *testing.T) (*Tally, []Ballot) {
+	t.Helper()
+	tally, err := NewTally([]string{"Alice", "Bob", "Charlie", "David"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ballots []Ballot
+	add := func(n int, ballot Ballot) {
+		for range n {
+			ballots = append(ballots, ballot)
+		}
+	}
+	add(4, Ballot{0, 1, 2, 3})
+	add(3, Ballot{1, 2, 0, 3})
+	add(2, Ballot{2, 1, 0, 3})
+	add(2, Ballot{3, 2, 1, 0})
+	return tally, ballots
+}
+
+func TestTieBreaks(t *testing.T) {
+	tests := []struct {
+		tieBreak   TieBreak
+		winners    []string
+		rounds     int
+		eliminated []string // in round 1
+	}{
+		{TIEBREAK_ALL, []string{"Bob"}, 2, []string{"Charlie", "David"}},
+		{TIEBREAK_BALLOT_ORDER, []string{"Charlie"}, 3, []string{"David"}},
+	}
+	for _, tt := range tests {
+		tally, ballots := tieBallots(t)
+		winners, rounds := tally.RunoffRounds(ballots, RunoffOptions{TieBreak: tt.tieBreak})
+		if !slices.Equal(winners, tt.winners) || len(rounds) != tt.rounds {
+			t.Errorf("%s: winners %v after %d rounds, want %v after %d", tt.tieBreak, winners, len(rounds), tt.winners, tt.rounds)
+			continue
+		}
+		if !slices.Equal(rounds[0].Tied, []string{"Charlie", "David"}) || !slices.Equal(rounds[0].Eliminated, tt.eliminated) {
+			t.Errorf("%s: round 1 tied %v, eliminated %v", tt.tieBreak, rounds[0].Tied, rounds[0].Eliminated)
+		}
+		if got := votes(&Tally{Candidates: rounds[0].Candidates}); !slices.Equal(got, []int{4, 3, 2, 2}) {
+			t.Errorf("%s: round 1 votes %v", tt.tieBreak, got)
+		}
+	}
+
+	// The CS50 Runoff is the "all" policy
+	tally, ballots := tieBallots(t)
+	if got := tally.Runoff(ballots); !slices.Equal(got, []string{"Bob"}) {
+		t.Errorf("Runoff = %v, want Bob", got)
+	}
+}
+
+func TestRandomTieBreak(t *testing.T) {
+	// Either Charlie or David goes, and the same seed always picks the same
+	seen := map[string]bool{}
+	for seed := int64(1); seed <= 20; seed++ {
+		tally, ballots := tieBallots(t)
+		winners, rounds := tally.RunoffRounds(ballots, RunoffOptions{TieBreak: TIEBREAK_RANDOM, Rand: rand.New(rand.NewSource(seed))})
+		if len(rounds[0].Eliminated) != 1 {
+			t.Fatalf("seed %d: eliminated %v in round 1", seed, rounds[0].Eliminated)
+		}
+		seen[winners[0]] = true
+
+		again, _ := tieBallots(t)
+		if w, _ := again.RunoffRounds(ballots, RunoffOptions{TieBreak: TIEBREAK_RANDOM, Rand: rand.New(rand.NewSource(seed))}); !slices.Equal(w, winners) {
+			t.Errorf("seed %d: %v then %v", seed, winners, w)
+		}
+	}
+	if !seen["Bob"] || !seen["Charlie"] {
+		t.Errorf("20 seeds only elected %v", seen)
+	}
+}
+
+func TestParseTieBreak(t *testing.T) {
+	for _, name := range TieBreakNames() {
+		tb, err := ParseTieBreak(name)
+		if err != nil || tb.String() != name || TIEBREAK_DESCRIPTIONS[tb] == "" {
+			t.Errorf("ParseTieBreak(%q) = %v, %v", name, tb, err)
+		}
+	}
+	if tb, err := ParseTieBreak("Ballot-Order"); err != nil || tb != TIEBREAK_BALLOT_ORDER {
+		t.Errorf("ParseTieBreak(Ballot-Order) = %v, %v", tb, err)
+	}
+	if _, err := ParseTieBreak("coin"); err == nil {
+		t.Error("ParseTieBreak(coin): want error")
+	}
+}
+
+func TestRoundBars(t *testing.T) {
+	tally, ballots := tieBallots(t)
+	_, rounds := tally.RunoffRounds(ballots, RunoffOptions{TieBreak: TIEBREAK_BALLOT_ORDER})
+
+	// Round 2: David is gone, Bob goes out
+	var got []string
+	for _, bar := range rounds[1].Bars() {
+		got = append(got, fmt.Sprintf("%s %d %s", bar.Label, bar.Value, bar.Note))
+	}
+	want := []string{"Alice 4 ", "Bob 3 (eliminated)", "Charlie 4 "}
+	if !slices.Equal(got, want) {
+		t.Errorf("round 2 bars = %q, want %q", got, want)
+	}
+
+	// Bars keeps everyone
+	if bars := Bars(tally.Candidates); len(bars) != 4 || bars[3].Note != "(eliminated)" || bars[2].Note != "" {
+		t.Errorf("Bars = %+v", bars)
+	}
+}
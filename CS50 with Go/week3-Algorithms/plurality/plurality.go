@@ -0,0 +1,70 @@
+// Plurality pset: everyone votes for one candidate, most votes wins.
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"os"
+
+	"chart"
+	"elections"
+)
+
+func main() {
+	ballotsPath := flag.String("ballots", "", "read votes from a CSV file instead of prompting (each voter's rank 1 is their vote)")
+	showChart := flag.Bool("chart", false, "draw everyone's votes as bars after the winner")
+	flag.Parse()
+
+	// Check for invalid usage
+	if flag.NArg() < 1 && *ballotsPath == "" {
+		fmt.Println("Usage: plurality [-ballots votes.csv] [-chart] [candidate ...]")
+		os.Exit(1)
+	}
+
+	if *ballotsPath != "" {
+		names, ballots, err := elections.LoadBallots(*ballotsPath, flag.Args())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		tally, _ := elections.NewTally(names)
+		for _, name := range (elections.Plurality{}).Elect(tally, ballots) {
+			fmt.Println(name)
+		}
+		if *showChart {
+			fmt.Println()
+			chart.Fprint(os.Stdout, elections.Bars(tally.Candidates), chart.Width())
+		}
+		return
+	}
+
+	// Populate array of candidates
+	tally, err := elections.NewTally(flag.Args())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	voterCount := cs50.GetInt("Number of voters: ")
+
+	// Loop over all voters
+	for i := 0; i < voterCount; i++ {
+		name := cs50.GetString("Vote: ")
+
+		// Check for invalid vote
+		if !tally.Vote(name) {
+			fmt.Println("Invalid vote.")
+		}
+	}
+
+	// Display winner of election
+	for _, name := range tally.Winners() {
+		fmt.Println(name)
+	}
+	if *showChart {
+		fmt.Println()
+		chart.Fprint(os.Stdout, elections.Bars(tally.Candidates), chart.Width())
+	}
+}
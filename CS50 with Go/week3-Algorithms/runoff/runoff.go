@@ -0,0 +1,150 @@
+// Runoff pset: voters rank every candidate. Whoever has a majority of first
+// choices wins; otherwise the last place candidate is eliminated and their
+// voters move on to their next choice, round after round.
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"chart"
+	"elections"
+	"rngutil"
+)
+
+// report is what to print besides the winners.
+type report struct {
+	summary bool  // -tiebreak given: the policy and every round's counts
+	chart   bool  // -chart: every round as bars
+	seed    int64 // for -tiebreak random
+}
+
+func main() {
+	ballotsPath := flag.String("ballots", "", "read ranked ballots from a CSV file instead of prompting")
+	tieBreakName := flag.String("tiebreak", "", "who to eliminate when several tie for last: "+strings.Join(elections.TieBreakNames(), ", ")+" (default all, and print a round-by-round summary)")
+	seed := flag.Int64("seed", 0, "random seed for -tiebreak random (0 = random)")
+	showChart := flag.Bool("chart", false, "draw every round's votes as bars")
+	flag.Parse()
+
+	// Check for invalid usage
+	if flag.NArg() < 1 && *ballotsPath == "" {
+		fmt.Println("Usage: runoff [-ballots votes.csv] [-tiebreak policy] [-seed N] [-chart] [candidate ...]")
+		os.Exit(1)
+	}
+	var opts elections.RunoffOptions
+	if *tieBreakName != "" {
+		tb, err := elections.ParseTieBreak(*tieBreakName)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		opts = elections.RunoffOptions{TieBreak: tb, Rand: rngutil.New(seed)}
+	}
+	rep := report{summary: *tieBreakName != "", chart: *showChart, seed: *seed}
+
+	if *ballotsPath != "" {
+		names, ballots, err := elections.LoadBallots(*ballotsPath, flag.Args())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		tally, _ := elections.NewTally(names)
+		runoff(os.Stdout, tally, ballots, opts, rep)
+		return
+	}
+
+	// Populate array of candidates
+	tally, err := elections.NewTally(flag.Args())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	candidateCount := len(tally.Candidates)
+
+	var voterCount int
+	for {
+		voterCount = cs50.GetInt("Number of voters: ")
+		if voterCount >= 0 {
+			break
+		}
+	}
+	if voterCount > elections.MAX_VOTERS {
+		fmt.Printf("Maximum number of voters is %d\n", elections.MAX_VOTERS)
+		os.Exit(3)
+	}
+
+	// Keep querying for votes
+	ballots := make([]elections.Ballot, voterCount)
+	for i := range ballots {
+		ballots[i] = make(elections.Ballot, candidateCount)
+
+		// Query for each rank
+		for j := 0; j < candidateCount; j++ {
+			name := cs50.GetString(fmt.Sprintf("Rank %d: ", j+1))
+
+			// Record vote, unless it's invalid
+			c, ok := tally.Index(name)
+			if !ok {
+				fmt.Println("Invalid vote.")
+				os.Exit(4)
+			}
+			ballots[i][j] = c
+		}
+		fmt.Println()
+	}
+
+	// Keep holding runoffs until winner exists
+	runoff(os.Stdout, tally, ballots, opts, rep)
+}
+
+// runoff prints the winner(s) like the pset, then what rep asks for: how
+// every round went (as counts or bars) and what the tie-break policy did.
+func runoff(w io.Writer, tally *elections.Tally, ballots []elections.Ballot, opts elections.RunoffOptions, rep report) {
+	winners, rounds := tally.RunoffRounds(ballots, opts)
+	for _, name := range winners {
+		fmt.Fprintln(w, name)
+	}
+	if !rep.summary && !rep.chart {
+		return
+	}
+
+	if rep.summary {
+		fmt.Fprintf(w, "\nTie-break: %s", opts.TieBreak)
+		if opts.TieBreak == elections.TIEBREAK_RANDOM {
+			fmt.Fprintf(w, " (seed %d)", rep.seed)
+		}
+		fmt.Fprintf(w, "\n%s.\n", elections.TIEBREAK_DESCRIPTIONS[opts.TieBreak])
+	}
+
+	if rep.chart {
+		fmt.Fprintf(w, "\nA full bar is all %d ballots; a majority is more than half.\n", len(ballots))
+	}
+	tied := 0
+	for i, round := range rounds {
+		fmt.Fprintf(w, "\nRound %d\n", i+1)
+		if rep.chart {
+			chart.FprintScaled(w, round.Bars(), len(ballots), chart.Width())
+		} else {
+			for _, c := range round.Candidates {
+				if !c.Eliminated {
+					fmt.Fprintf(w, "  %-12s %d\n", c.Name, c.Votes)
+				}
+			}
+		}
+		if len(round.Tied) > 0 {
+			tied++
+			fmt.Fprintf(w, "  tied for last: %s\n", strings.Join(round.Tied, ", "))
+		}
+		if len(round.Eliminated) > 0 && !rep.chart {
+			fmt.Fprintf(w, "  eliminated: %s\n", strings.Join(round.Eliminated, ", "))
+		}
+	}
+	if rep.summary {
+		fmt.Fprintf(w, "\n%d round(s), %d decided by the tie-break.\n", len(rounds), tied)
+	}
+}
@@ -0,0 +1,116 @@
+// bsearch: binary search on a sorted slice, printing lo/mid/hi before every
+// probe so you can watch the range halve. Compare the probe count with
+// log2(n) and with what linear search would have needed.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"rngutil"
+	"search"
+)
+
+// BAR_WIDTH is how many columns the range bar uses for big slices.
+const BAR_WIDTH = 64
+
+func main() {
+	n := flag.Int("n", 16, "number of sorted random values to search")
+	target := flag.Int("target", -1, "value to look for (default: a random element)")
+	seed := flag.Int64("seed", 0, "random seed (0 = random)")
+	animate := flag.Bool("animate", false, "redraw the screen for every step instead of scrolling")
+	delay := flag.Duration("delay", 800*time.Millisecond, "pause between steps when animating")
+	flag.Parse()
+
+	if *n < 1 {
+		fmt.Println("Usage: ./bsearch [-n N] [-target X] [-seed N] [-animate] [-delay 800ms]")
+		os.Exit(1)
+	}
+	rng := rngutil.New(seed)
+
+	// Sorted values with gaps, so missing targets are possible too
+	a := make([]int, *n)
+	for i := range a {
+		a[i] = rng.Intn(*n * 4)
+	}
+	slices.Sort(a)
+	if *target < 0 {
+		*target = a[rng.Intn(len(a))]
+	}
+
+	fmt.Printf("Searching %d values for %d\n", len(a), *target)
+	step := 0
+	index, probes := search.BinarySearchSteps(a, *target, func(s search.Step) {
+		if *animate {
+			// ANSI: cursor home + clear screen
+			fmt.Print("\033[H\033[2J")
+		}
+		step++
+		verdict := "found it"
+		switch {
+		case a[s.Mid] < *target:
+			verdict = "too small, go right"
+		case a[s.Mid] > *target:
+			verdict = "too big, go left"
+		}
+		fmt.Printf("%2d. lo=%-4d mid=%-4d hi=%-4d a[mid]=%-4d %s\n", step, s.Lo, s.Mid, s.Hi, a[s.Mid], verdict)
+		fmt.Println("    " + draw(a, s))
+		if *animate {
+			time.Sleep(*delay)
+		}
+	})
+
+	if index >= 0 {
+		fmt.Printf("Found %d at index %d", *target, index)
+	} else {
+		fmt.Printf("%d is not there", *target)
+	}
+	_, linear := search.Linear(a, *target)
+	fmt.Printf(" after %d probes (log2 %d = %.1f, linear search: %d)\n",
+		probes, len(a), math.Log2(float64(len(a))), linear)
+}
+
+// draw shows the range still in play. Small slices print their values with
+// the range in brackets and mid in parentheses; big ones a scaled bar:
+// '.' ruled out, '=' still possible, '|' mid.
+func draw(a []int, s search.Step) string {
+	if len(a) <= 16 {
+		var b strings.Builder
+		for i, v := range a {
+			cell := fmt.Sprint(v)
+			switch {
+			case i == s.Mid:
+				cell = "(" + cell + ")"
+			case i < s.Lo || i > s.Hi:
+				cell = strings.Repeat(".", len(cell))
+			}
+			if i == s.Lo {
+				b.WriteString("[")
+			}
+			b.WriteString(cell)
+			if i == s.Hi {
+				b.WriteString("]")
+			}
+			b.WriteString(" ")
+		}
+		return b.String()
+	}
+
+	bar := make([]byte, BAR_WIDTH)
+	for col := range bar {
+		i := col * len(a) / BAR_WIDTH
+		if i >= s.Lo && i <= s.Hi {
+			bar[col] = '='
+		} else {
+			bar[col] = '.'
+		}
+	}
+	bar[s.Mid*BAR_WIDTH/len(a)] = '|'
+	return string(bar)
+}
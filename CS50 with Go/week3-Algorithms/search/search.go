@@ -0,0 +1,54 @@
+// Package search is linear and binary search, counting probes (elements
+// looked at) so the difference between n and log n shows up as numbers.
+package search
+
+import "cmp"
+
+// Step is the state of a binary search just before it probes a[Mid]:
+// the target, if present, is somewhere in a[Lo..Hi].
+type Step struct {
+	Lo, Mid, Hi int
+}
+
+// Linear looks at every element in order. It returns the index of target
+// (-1 if missing) and how many elements it probed.
+func Linear[E comparable](a []E, target E) (index, probes int) {
+	for i, v := range a {
+		probes++
+		if v == target {
+			return i, probes
+		}
+	}
+	return -1, probes
+}
+
+// BinarySearch finds target in sorted a by halving the range at every probe,
+// so it needs at most floor(log2 n) + 1 probes. It returns the index of
+// target (-1 if missing) and the number of probes.
+func BinarySearch[E cmp.Ordered](a []E, target E) (index, probes int) {
+	return BinarySearchSteps(a, target, nil)
+}
+
+// BinarySearchSteps is BinarySearch calling step (if not nil) before every
+// probe, for visualizers.
+func BinarySearchSteps[E cmp.Ordered](a []E, target E, step func(Step)) (index, probes int) {
+	lo, hi := 0, len(a)-1
+	for lo <= hi {
+		// lo + (hi-lo)/2, not (lo+hi)/2: the sum can overflow on huge slices
+		mid := lo + (hi-lo)/2
+		if step != nil {
+			step(Step{lo, mid, hi})
+		}
+		probes++
+
+		switch {
+		case a[mid] == target:
+			return mid, probes
+		case a[mid] < target:
+			lo = mid + 1 // target is in the right half
+		default:
+			hi = mid - 1 // target is in the left half
+		}
+	}
+	return -1, probes
+}
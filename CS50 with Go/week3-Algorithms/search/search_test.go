@@ -0,0 +1,95 @@
+package search
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestBinarySearch(t *testing.T) {
+	a := []int{1, 3, 5, 7, 9, 11, 13}
+	tests := []struct {
+		target, index, probes int
+	}{
+		{7, 3, 1},
+		{3, 1, 2},
+		{11, 5, 2},
+		{1, 0, 3},
+		{13, 6, 3},
+		{0, -1, 3},
+		{8, -1, 3},
+		{14, -1, 3},
+	}
+	for _, tt := range tests {
+		index, probes := BinarySearch(a, tt.target)
+		if index != tt.index || probes != tt.probes {
+			t.Errorf("BinarySearch(%d) = %d, %d probes; want %d, %d probes", tt.target, index, probes, tt.index, tt.probes)
+		}
+	}
+
+	if index, probes := BinarySearch([]int{}, 1); index != -1 || probes != 0 {
+		t.Errorf("empty: %d, %d", index, probes)
+	}
+}
+
+// Every element of every size is found, never with more than log2(n)+1 probes.
+func TestBinarySearchBound(t *testing.T) {
+	for n := 1; n <= 1024; n++ {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = 2 * i
+		}
+		bound := bits.Len(uint(n))
+		for i, v := range a {
+			index, probes := BinarySearch(a, v)
+			if index != i || probes > bound {
+				t.Fatalf("n=%d: BinarySearch(%d) = %d in %d probes, bound %d", n, v, index, probes, bound)
+			}
+			if _, probes := BinarySearch(a, v+1); probes > bound {
+				t.Fatalf("n=%d: missing %d took %d probes, bound %d", n, v+1, probes, bound)
+			}
+		}
+	}
+}
+
+func TestBinarySearchSteps(t *testing.T) {
+	var steps []Step
+	BinarySearchSteps([]string{"a", "b", "c", "d", "e", "f", "g", "h"}, "g", func(s Step) {
+		steps = append(steps, s)
+	})
+	want := []Step{{0, 3, 7}, {4, 5, 7}, {6, 6, 7}}
+	if len(steps) != len(want) {
+		t.Fatalf("steps = %v, want %v", steps, want)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("step %d = %v, want %v", i, steps[i], want[i])
+		}
+	}
+}
+
+func TestLinear(t *testing.T) {
+	a := []string{"Bill", "Charlie", "Fred", "George", "Ginny", "Percy", "Ron"}
+	if index, probes := Linear(a, "Ron"); index != 6 || probes != 7 {
+		t.Errorf("Linear(Ron) = %d, %d", index, probes)
+	}
+	if index, probes := Linear(a, "Harry"); index != -1 || probes != 7 {
+		t.Errorf("Linear(Harry) = %d, %d", index, probes)
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	a := make([]int, 1_000_000)
+	for i := range a {
+		a[i] = i
+	}
+	b.Run("linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Linear(a, i%len(a))
+		}
+	})
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BinarySearch(a, i%len(a))
+		}
+	})
+}
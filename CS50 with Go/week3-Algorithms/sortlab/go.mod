@@ -0,0 +1,7 @@
+module sortlab
+
+go 1.24.4
+
+require rngutil v0.0.0
+
+replace rngutil => ../../rngutil
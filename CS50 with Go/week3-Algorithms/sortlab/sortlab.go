@@ -0,0 +1,204 @@
+// Sort lab (CS50 week 3 "sort"): time three mystery sorts on random,
+// reversed, sorted and nearly-sorted inputs and deduce which algorithm each
+// one is. Bubble sort with early exit flies through sorted input, selection
+// sort doesn't care about order at all, merge sort is fast everywhere.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"rngutil"
+)
+
+// ORDERS are the input shapes, in table order.
+var ORDERS = []string{"random", "reversed", "sorted", "nearly"}
+
+func main() {
+	gen := flag.Bool("gen", false, "generate the input files instead of timing")
+	dir := flag.String("dir", "data", "directory with the input files")
+	sizes := flag.String("sizes", "5000,10000,50000", "comma-separated sizes for -gen")
+	seed := flag.Int64("seed", 1, "random seed for -gen")
+	runs := flag.Int("runs", 1, "time each sort this many times and keep the fastest")
+	flag.Parse()
+
+	if *gen {
+		n, err := generate(*dir, *sizes, *seed)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %d files to %s\n", n, *dir)
+		return
+	}
+
+	files, err := inputFiles(*dir)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Printf("No input files in %s, run: sortlab -gen\n", *dir)
+		os.Exit(1)
+	}
+
+	// Table: one row per file, one column per sort
+	fmt.Printf("%-20s", "file")
+	for _, s := range SORTS {
+		fmt.Printf("%12s", s.name)
+	}
+	fmt.Println()
+
+	for _, path := range files {
+		numbers, err := readNumbers(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("%-20s", filepath.Base(path))
+		for _, s := range SORTS {
+			fmt.Printf("%11.4fs", timeSort(s.sort, numbers, *runs).Seconds())
+		}
+		fmt.Println()
+	}
+}
+
+// timeSort returns the fastest of runs sorts of a fresh copy of numbers, and
+// exits if the result isn't actually sorted.
+func timeSort(sort func([]int), numbers []int, runs int) time.Duration {
+	best := time.Duration(0)
+	for i := 0; i < max(runs, 1); i++ {
+		a := slices.Clone(numbers)
+		start := time.Now()
+		sort(a)
+		elapsed := time.Since(start)
+		if !slices.IsSorted(a) {
+			fmt.Println("sort returned unsorted output")
+			os.Exit(1)
+		}
+		if best == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+	return best
+}
+
+// generate writes <order><size>.txt for every order and size, one number per
+// line like the CS50 files.
+func generate(dir, sizes string, seed int64) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	rng := rngutil.New(&seed)
+
+	written := 0
+	for _, field := range strings.Split(sizes, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size < 1 {
+			return written, fmt.Errorf("bad size %q", field)
+		}
+		for _, order := range ORDERS {
+			path := filepath.Join(dir, fmt.Sprintf("%s%d.txt", order, size))
+			if err := writeNumbers(path, numbers(order, size, rng)); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+	return written, nil
+}
+
+// numbers returns size numbers 1..size arranged in the given order.
+func numbers(order string, size int, rng *rand.Rand) []int {
+	a := make([]int, size)
+	for i := range a {
+		a[i] = i + 1
+	}
+	switch order {
+	case "random":
+		rng.Shuffle(size, func(i, j int) { a[i], a[j] = a[j], a[i] })
+	case "reversed":
+		slices.Reverse(a)
+	case "nearly":
+		// 1% of the elements swapped with a neighbour
+		for n := 0; n < size/100+1; n++ {
+			i := rng.Intn(size)
+			j := min(i+1, size-1)
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	return a
+}
+
+func writeNumbers(path string, numbers []int) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, n := range numbers {
+		fmt.Fprintln(writer, n)
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func readNumbers(path string) ([]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var numbers []int
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		n, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, scanner.Err()
+}
+
+// inputFiles returns the .txt files in dir grouped by order, then by size.
+func inputFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	key := func(path string) (int, int) {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		for i, order := range ORDERS {
+			if size, err := strconv.Atoi(strings.TrimPrefix(name, order)); err == nil && strings.HasPrefix(name, order) {
+				return i, size
+			}
+		}
+		return len(ORDERS), 0
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		oa, sa := key(a)
+		ob, sb := key(b)
+		if oa != ob {
+			return oa - ob
+		}
+		if sa != sb {
+			return sa - sb
+		}
+		return strings.Compare(a, b)
+	})
+	return paths, nil
+}
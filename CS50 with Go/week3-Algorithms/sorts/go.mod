@@ -0,0 +1,3 @@
+module sorts
+
+go 1.24.4
@@ -0,0 +1,185 @@
+// Package sorts has the five classic sorting algorithms of week 3, each one
+// counting the work it does so you can compare them by more than wall time.
+//
+//	algorithm   best        average     worst       extra memory
+//	Bubble      n           n²          n²          1
+//	Selection   n²          n²          n²          1
+//	Insertion   n           n²          n²          1
+//	Merge       n log n     n log n     n log n     n
+//	Quick       n log n     n log n     n²          log n
+package sorts
+
+import "cmp"
+
+// Stats counts the work of one sort.
+type Stats struct {
+	Comparisons int // a[i] vs a[j]
+	Swaps       int // exchanging two elements
+	Moves       int // writing one element (merge sort copies, it never swaps)
+}
+
+// Func is the shape every sort in this package has.
+type Func[E cmp.Ordered] func([]E) Stats
+
+// Algorithm is a sort with its name, for tables and benchmarks.
+type Algorithm[E cmp.Ordered] struct {
+	Name string
+	Sort Func[E]
+}
+
+// All returns every sort in this package, slowest family first.
+func All[E cmp.Ordered]() []Algorithm[E] {
+	return []Algorithm[E]{
+		{"bubble", Bubble[E]},
+		{"selection", Selection[E]},
+		{"insertion", Insertion[E]},
+		{"merge", Merge[E]},
+		{"quick", Quick[E]},
+	}
+}
+
+// Bubble sort: repeatedly swap neighbours that are out of order. Each pass
+// bubbles the largest remaining element to the end; a pass without swaps
+// means we're done.
+func Bubble[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	for n := len(a); n > 1; n-- {
+		swapped := false
+		for i := 1; i < n; i++ {
+			s.Comparisons++
+			if a[i] < a[i-1] {
+				a[i-1], a[i] = a[i], a[i-1]
+				s.Swaps++
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+	return s
+}
+
+// Selection sort: find the smallest of the unsorted part and swap it to the
+// front of that part.
+func Selection[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	for i := range a {
+		min := i
+		for j := i + 1; j < len(a); j++ {
+			s.Comparisons++
+			if a[j] < a[min] {
+				min = j
+			}
+		}
+		if min != i {
+			a[i], a[min] = a[min], a[i]
+			s.Swaps++
+		}
+	}
+	return s
+}
+
+// Insertion sort: grow a sorted prefix, swapping each new element left until
+// it's in place. Fast on input that's almost sorted already.
+func Insertion[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	for i := 1; i < len(a); i++ {
+		for j := i; j > 0; j-- {
+			s.Comparisons++
+			if !(a[j] < a[j-1]) {
+				break
+			}
+			a[j-1], a[j] = a[j], a[j-1]
+			s.Swaps++
+		}
+	}
+	return s
+}
+
+// Merge sort: sort the left half, sort the right half, merge the two sorted
+// halves. Stable, always n log n, but needs a second array.
+func Merge[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	if len(a) > 1 {
+		mergeSort(a, make([]E, len(a)), &s)
+	}
+	return s
+}
+
+func mergeSort[E cmp.Ordered](a, tmp []E, s *Stats) {
+	if len(a) < 2 {
+		return
+	}
+	mid := len(a) / 2
+	mergeSort(a[:mid], tmp[:mid], s)
+	mergeSort(a[mid:], tmp[mid:], s)
+
+	// Merge into tmp, taking from the left on ties to stay stable
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(a) {
+		s.Comparisons++
+		if a[j] < a[i] {
+			tmp[k] = a[j]
+			j++
+		} else {
+			tmp[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(tmp[k:], a[i:mid])
+	copy(tmp[k:], a[j:])
+
+	copy(a, tmp[:len(a)])
+	s.Moves += 2 * len(a) // into tmp and back
+}
+
+// Quick sort: pick a pivot, move everything smaller to its left and the rest
+// to its right, recurse on both sides. The middle element is the pivot so
+// sorted input doesn't hit the n² worst case.
+func Quick[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	quickSort(a, &s)
+	return s
+}
+
+func quickSort[E cmp.Ordered](a []E, s *Stats) {
+	for len(a) > 1 {
+		p := partition(a, s)
+
+		// Recurse into the smaller side, loop on the bigger one: log n stack
+		if p < len(a)-p-1 {
+			quickSort(a[:p], s)
+			a = a[p+1:]
+		} else {
+			quickSort(a[p+1:], s)
+			a = a[:p]
+		}
+	}
+}
+
+// partition is Lomuto's scheme with the middle element moved to the end as
+// pivot. It returns the pivot's final index.
+func partition[E cmp.Ordered](a []E, s *Stats) int {
+	last := len(a) - 1
+	mid := len(a) / 2
+	a[mid], a[last] = a[last], a[mid]
+	s.Swaps++
+	pivot := a[last]
+
+	store := 0
+	for i := 0; i < last; i++ {
+		s.Comparisons++
+		if a[i] < pivot {
+			if i != store {
+				a[i], a[store] = a[store], a[i]
+				s.Swaps++
+			}
+			store++
+		}
+	}
+	a[store], a[last] = a[last], a[store]
+	s.Swaps++
+	return store
+}
@@ -0,0 +1,133 @@
+package sorts
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func inputs() map[string][]int {
+	rng := rand.New(rand.NewSource(3))
+	random := make([]int, 500)
+	for i := range random {
+		random[i] = rng.Intn(100) // plenty of duplicates
+	}
+	sorted := make([]int, 500)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+
+	return map[string][]int{
+		"empty":    {},
+		"one":      {42},
+		"two":      {2, 1},
+		"equal":    {7, 7, 7, 7},
+		"random":   random,
+		"sorted":   sorted,
+		"reversed": reversed,
+	}
+}
+
+func TestSorts(t *testing.T) {
+	for _, alg := range All[int]() {
+		for name, input := range inputs() {
+			a := slices.Clone(input)
+			alg.Sort(a)
+			want := slices.Clone(input)
+			slices.Sort(want)
+			if !slices.Equal(a, want) {
+				t.Errorf("%s on %s: got %v", alg.Name, name, a)
+			}
+		}
+	}
+}
+
+func TestStrings(t *testing.T) {
+	for _, alg := range All[string]() {
+		a := []string{"pear", "apple", "fig", "banana", "apple"}
+		alg.Sort(a)
+		if !slices.IsSorted(a) {
+			t.Errorf("%s: %v", alg.Name, a)
+		}
+	}
+}
+
+// The counters should show each algorithm's best and worst cases.
+func TestStats(t *testing.T) {
+	const n = 100
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	reversed := slices.Clone(sorted)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name  string
+		sort  Func[int]
+		input []int
+		want  Stats
+	}{
+		// One pass, no swaps: bubble and insertion are linear on sorted input
+		{"bubble sorted", Bubble[int], sorted, Stats{Comparisons: n - 1}},
+		{"insertion sorted", Insertion[int], sorted, Stats{Comparisons: n - 1}},
+		// Selection compares everything with everything no matter what
+		{"selection sorted", Selection[int], sorted, Stats{Comparisons: n * (n - 1) / 2}},
+		// Reversed: every pair is an inversion, each one swapped once
+		{"bubble reversed", Bubble[int], reversed, Stats{Comparisons: n * (n - 1) / 2, Swaps: n * (n - 1) / 2}},
+		{"insertion reversed", Insertion[int], reversed, Stats{Comparisons: n * (n - 1) / 2, Swaps: n * (n - 1) / 2}},
+		{"selection reversed", Selection[int], reversed, Stats{Comparisons: n * (n - 1) / 2, Swaps: n / 2}},
+	}
+	for _, tt := range tests {
+		if got := tt.sort(slices.Clone(tt.input)); got != tt.want {
+			t.Errorf("%s: %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	// n log n: 100 elements is under 700 comparisons, n² would be 4,950
+	for _, alg := range All[int]()[3:] {
+		for name, input := range map[string][]int{"sorted": sorted, "reversed": reversed} {
+			if s := alg.Sort(slices.Clone(input)); s.Comparisons > 700 {
+				t.Errorf("%s on %s: %d comparisons", alg.Name, name, s.Comparisons)
+			}
+		}
+	}
+}
+
+func BenchmarkSorts(b *testing.B) {
+	for _, n := range []int{100, 1_000, 10_000} {
+		rng := rand.New(rand.NewSource(1))
+		input := rng.Perm(n)
+
+		for _, alg := range All[int]() {
+			if n > 1_000 && alg.Name != "merge" && alg.Name != "quick" {
+				continue // n² at 10,000 drowns out the rest
+			}
+			b.Run(fmt.Sprintf("%s/%d", alg.Name, n), func(b *testing.B) {
+				a := make([]int, n)
+				for i := 0; i < b.N; i++ {
+					copy(a, input)
+					alg.Sort(a)
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("sort.Slice/%d", n), func(b *testing.B) {
+			a := make([]int, n)
+			for i := 0; i < b.N; i++ {
+				copy(a, input)
+				sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+			}
+		})
+		b.Run(fmt.Sprintf("slices.Sort/%d", n), func(b *testing.B) {
+			a := make([]int, n)
+			for i := 0; i < b.N; i++ {
+				copy(a, input)
+				slices.Sort(a)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package sorts has the five classic sorting algorithms of week 3, each one
+// counting the work it does so you can compare them by more than wall time.
+//
+// Starter version: fill in the TODOs, then run the tests from
+// ../sorts_test.go against it (copy the test file next to this one).
+package sorts
+
+import "cmp"
+
+// Stats counts the work of one sort.
+type Stats struct {
+	Comparisons int // a[i] vs a[j]
+	Swaps       int // exchanging two elements
+	Moves       int // writing one element (merge sort copies, it never swaps)
+}
+
+// Func is the shape every sort in this package has.
+type Func[E cmp.Ordered] func([]E) Stats
+
+// Algorithm is a sort with its name, for tables and benchmarks.
+type Algorithm[E cmp.Ordered] struct {
+	Name string
+	Sort Func[E]
+}
+
+// All returns every sort in this package, slowest family first.
+func All[E cmp.Ordered]() []Algorithm[E] {
+	return []Algorithm[E]{
+		{"bubble", Bubble[E]},
+		{"selection", Selection[E]},
+		{"insertion", Insertion[E]},
+		{"merge", Merge[E]},
+		{"quick", Quick[E]},
+	}
+}
+
+// Bubble sort: repeatedly swap neighbours that are out of order.
+func Bubble[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	// TODO: Make passes over a, swapping a[i-1] and a[i] when they're out of order.
+	// HINT: After each pass the largest remaining element is at the end, so the
+	// next pass can stop one earlier. A pass with no swaps means you're done.
+	// Count every comparison in s.Comparisons and every swap in s.Swaps.
+
+	return s
+}
+
+// Selection sort: find the smallest of the unsorted part and swap it to the
+// front of that part.
+func Selection[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	// TODO: For each i, find the index of the smallest element in a[i:],
+	// then swap it into a[i]. Only count a swap when it actually moves something.
+
+	return s
+}
+
+// Insertion sort: grow a sorted prefix, swapping each new element left until
+// it's in place.
+func Insertion[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	// TODO: For each i from 1, swap a[j] with a[j-1] while it's smaller.
+	// HINT: Stop at the first comparison that says it's in place; that's what
+	// makes insertion sort fast on sorted input.
+
+	return s
+}
+
+// Merge sort: sort the left half, sort the right half, merge the two sorted
+// halves.
+func Merge[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	if len(a) > 1 {
+		mergeSort(a, make([]E, len(a)), &s)
+	}
+	return s
+}
+
+func mergeSort[E cmp.Ordered](a, tmp []E, s *Stats) {
+	if len(a) < 2 {
+		return
+	}
+	// TODO: Recursively sort a[:mid] and a[mid:] (with matching halves of tmp).
+
+	// TODO: Merge the two halves into tmp, always taking the smaller front
+	// element (take from the left on ties so the sort stays stable), then
+	// copy tmp back into a. Add every element written to s.Moves.
+}
+
+// Quick sort: pick a pivot, move everything smaller to its left and the rest
+// to its right, recurse on both sides.
+func Quick[E cmp.Ordered](a []E) Stats {
+	var s Stats
+	quickSort(a, &s)
+	return s
+}
+
+func quickSort[E cmp.Ordered](a []E, s *Stats) {
+	if len(a) < 2 {
+		return
+	}
+	// TODO: p := partition(a, s), then quickSort both sides of index p.
+}
+
+// partition puts the pivot in its final place and returns its index.
+func partition[E cmp.Ordered](a []E, s *Stats) int {
+	// TODO: Swap the middle element to the end and use it as the pivot.
+	// HINT: Walk i over a[:last], swapping every element smaller than the
+	// pivot to a[store] and incrementing store. Finally swap the pivot into
+	// a[store] and return store.
+
+	return 0 // This needs to be changed.
+}
@@ -0,0 +1,18 @@
+module tideman
+
+go 1.24.4
+
+require (
+	chart v0.0.0
+	cs50 v0.0.0
+	elections v0.0.0
+)
+
+require graph v0.0.0 // indirect
+
+replace (
+	chart => ../chart
+	cs50 => ../../cs50
+	elections => ../elections
+	graph => ../../week5-Data-Strucutes/graph
+)
@@ -0,0 +1,90 @@
+// Tideman (ranked pairs) pset: compare every two candidates head to head,
+// lock the strongest victories first without ever creating a cycle, and the
+// candidate nobody beats in the locked graph wins.
+
+package main
+
+import (
+	"cs50"
+	"flag"
+	"fmt"
+	"os"
+
+	"chart"
+	"elections"
+)
+
+func main() {
+	ballotsPath := flag.String("ballots", "", "read ranked ballots from a CSV file instead of prompting")
+	showChart := flag.Bool("chart", false, "draw everyone's head-to-head victories as bars after the winner")
+	flag.Parse()
+
+	// Check for invalid usage
+	if flag.NArg() < 1 && *ballotsPath == "" {
+		fmt.Println("Usage: tideman [-ballots votes.csv] [-chart] [candidate ...]")
+		os.Exit(1)
+	}
+
+	if *ballotsPath != "" {
+		names, ballots, err := elections.LoadBallots(*ballotsPath, flag.Args())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		tally, _ := elections.NewTally(names)
+		tideman(tally, ballots, *showChart)
+		return
+	}
+
+	// Populate array of candidates
+	tally, err := elections.NewTally(flag.Args())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	candidateCount := len(tally.Candidates)
+
+	var voterCount int
+	for {
+		voterCount = cs50.GetInt("Number of voters: ")
+		if voterCount >= 0 {
+			break
+		}
+	}
+
+	// Query for votes
+	ballots := make([]elections.Ballot, voterCount)
+	for i := range ballots {
+		// ranks[i] is voter's ith preference
+		ranks := make(elections.Ballot, candidateCount)
+
+		// Query for each rank
+		for j := range ranks {
+			name := cs50.GetString(fmt.Sprintf("Rank %d: ", j+1))
+
+			c, ok := tally.Index(name)
+			if !ok {
+				fmt.Println("Invalid vote.")
+				os.Exit(3)
+			}
+			ranks[j] = c
+		}
+		ballots[i] = ranks
+		fmt.Println()
+	}
+
+	// Record preferences, sort the pairs, lock them, print the source
+	tideman(tally, ballots, *showChart)
+}
+
+// tideman prints the winner(s) and, with showChart, how many head-to-head
+// matchups each candidate won.
+func tideman(tally *elections.Tally, ballots []elections.Ballot, showChart bool) {
+	for _, name := range (elections.RankedPairs{}).Elect(tally, ballots) {
+		fmt.Println(name)
+	}
+	if showChart {
+		fmt.Println("\nHead-to-head victories")
+		chart.Fprint(os.Stdout, elections.Bars(tally.Candidates), chart.Width())
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"wav"
+)
+
+// run applies fx to a stereo file made of samples and decodes the result.
+func run(t *testing.T, samples []int16, fx effects) *wav.Audio {
+	t.Helper()
+	var input, output bytes.Buffer
+	audio := &wav.Audio{
+		Format:  wav.Format{Channels: 2, SampleRate: 4, BitsPerSample: 16},
+		Samples: samples,
+		Extra:   []wav.Chunk{{ID: "LIST", Data: []byte("INFOINAM\x02\x00\x00\x00Hi")}},
+	}
+	if err := wav.Encode(&input, audio); err != nil {
+		t.Fatal(err)
+	}
+	if fx.speed == 0 {
+		fx.speed = 1
+	}
+	if err := audiofx(bytes.NewReader(input.Bytes()), &output, fx); err != nil {
+		t.Fatal(err)
+	}
+	got, err := wav.Decode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Channels != 2 || got.SampleRate != 4 || got.Info["INAM"] != "Hi" {
+		t.Errorf("format %+v, info %q", got.Format, got.Info)
+	}
+	return got
+}
+
+func TestEffects(t *testing.T) {
+	// Frames (left, right): (0, 0) (100, -100) (200, -200) ...
+	samples := make([]int16, 2*9)
+	for f := range 9 {
+		samples[2*f], samples[2*f+1] = int16(100*f), int16(-100*f)
+	}
+
+	tests := []struct {
+		name string
+		fx   effects
+		want []int16
+	}{
+		{"none", effects{}, samples},
+		{"reverse", effects{reverse: true}, []int16{
+			800, -800, 700, -700, 600, -600, 500, -500, 400, -400, 300, -300, 200, -200, 100, -100, 0, 0,
+		}},
+		{"double speed", effects{speed: 2}, []int16{
+			0, 0, 200, -200, 400, -400, 600, -600, 800, -800,
+		}},
+		{"half speed", effects{speed: 0.5}, []int16{
+			0, 0, 50, -50, 100, -100, 150, -150, 200, -200, 250, -250, 300, -300, 350, -350,
+			400, -400, 450, -450, 500, -500, 550, -550, 600, -600, 650, -650, 700, -700, 750, -750, 800, -800,
+		}},
+		// 4 Hz, so 1 second is 4 frames
+		{"fade in", effects{fadeIn: 1}, []int16{
+			0, 0, 25, -25, 100, -100, 225, -225, 400, -400, 500, -500, 600, -600, 700, -700, 800, -800,
+		}},
+		{"fade out", effects{fadeOut: 1}, []int16{
+			0, 0, 100, -100, 200, -200, 300, -300, 400, -400, 375, -375, 300, -300, 175, -175, 0, 0,
+		}},
+		{"reverse fast fade", effects{reverse: true, speed: 4, fadeIn: 0.5}, []int16{
+			0, 0, 200, -200, 0, 0,
+		}},
+	}
+	for _, tt := range tests {
+		got := run(t, samples, tt.fx)
+		if !slices.Equal(got.Samples, tt.want) {
+			t.Errorf("%s:\n got %v\nwant %v", tt.name, got.Samples, tt.want)
+		}
+	}
+}
+
+func TestLongFile(t *testing.T) {
+	// More than one block, so effects have to carry state across reads
+	frames := 3*BLOCK_FRAMES + 17
+	samples := make([]int16, 2*frames)
+	for i := range samples {
+		samples[i] = int16(i % 1000)
+	}
+
+	got := run(t, samples, effects{reverse: true})
+	for f := range frames {
+		back := frames - 1 - f
+		if got.Samples[2*f] != samples[2*back] || got.Samples[2*f+1] != samples[2*back+1] {
+			t.Fatalf("frame %d = %v, want %v", f, got.Samples[2*f:2*f+2], samples[2*back:2*back+2])
+		}
+	}
+
+	got = run(t, samples, effects{speed: 3})
+	if want := (frames-1)/3 + 1; got.Frames() != want {
+		t.Fatalf("%d frames, want %d", got.Frames(), want)
+	}
+	for f := range got.Frames() {
+		if got.Samples[2*f] != samples[2*3*f] {
+			t.Fatalf("frame %d = %d, want %d", f, got.Samples[2*f], samples[2*3*f])
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"wav"
+)
+
+// BLOCK_FRAMES is how many frames each effect reads at a time, so memory use
+// doesn't grow with the file.
+const BLOCK_FRAMES = 4096
+
+// source is a stream of interleaved samples whose length is known up front
+// (the WAV header needs it), like *wav.Reader. Effects are sources that wrap
+// another source.
+type source interface {
+	Read(samples []int16) (int, error)
+	Len() int
+}
+
+// reverse reads the data chunk backwards, one block at a time from the end,
+// keeping the channels of each frame in order.
+type reverse struct {
+	file     io.ReaderAt
+	offset   int64 // of the first sample
+	channels int
+	samples  int
+	done     int
+	buffer   []byte
+}
+
+func newReverse(file io.ReaderAt, stream *wav.Reader) *reverse {
+	return &reverse{
+		file:     file,
+		offset:   stream.DataOffset,
+		channels: stream.Channels,
+		samples:  stream.Len() / stream.Channels * stream.Channels,
+	}
+}
+
+func (r *reverse) Len() int {
+	return r.samples
+}
+
+func (r *reverse) Read(samples []int16) (int, error) {
+	n := min(len(samples)/r.channels*r.channels, r.samples-r.done)
+	if n == 0 {
+		return 0, io.EOF
+	}
+
+	// The n samples that end where the last block started
+	end := r.samples - r.done
+	start := end - n
+	if cap(r.buffer) < 2*n {
+		r.buffer = make([]byte, 2*n)
+	}
+	buffer := r.buffer[:2*n]
+	if _, err := r.file.ReadAt(buffer, r.offset+2*int64(start)); err != nil {
+		return 0, &wav.FormatError{Reason: "data chunk ends early"}
+	}
+
+	frames := n / r.channels
+	for f := range frames {
+		from := (frames - 1 - f) * r.channels
+		for c := range r.channels {
+			i := 2 * (from + c)
+			samples[f*r.channels+c] = int16(uint16(buffer[i]) | uint16(buffer[i+1])<<8)
+		}
+	}
+	r.done += n
+	return n, nil
+}
+
+// frameReader hands out a source one frame at a time.
+type frameReader struct {
+	src      source
+	channels int
+	buffer   []int16
+	pos, n   int
+	err      error
+}
+
+func newFrameReader(src source, channels int) *frameReader {
+	return &frameReader{src: src, channels: channels, buffer: make([]int16, BLOCK_FRAMES*channels)}
+}
+
+// next copies the next frame into frame, or returns false at the end.
+func (f *frameReader) next(frame []int16) bool {
+	if f.n-f.pos < f.channels {
+		n, err := f.src.Read(f.buffer)
+		if err != nil && !errors.Is(err, io.EOF) {
+			f.err = err
+		}
+		f.pos, f.n = 0, n
+		if n < f.channels {
+			return false
+		}
+	}
+	copy(frame, f.buffer[f.pos:f.pos+f.channels])
+	f.pos += f.channels
+	return true
+}
+
+// speed plays the source factor times faster by resampling: output frame j
+// comes from input position j*factor, interpolating linearly between the two
+// frames around it. Like speeding up a tape, the pitch changes too.
+type speed struct {
+	frames    *frameReader
+	channels  int
+	factor    float64
+	outFrames int
+	done      int     // output frames so far
+	index     int     // input frame held in a
+	a, b      []int16 // input frames index and index+1
+}
+
+func newSpeed(src source, channels int, factor float64) *speed {
+	s := &speed{
+		frames:   newFrameReader(src, channels),
+		channels: channels,
+		factor:   factor,
+		a:        make([]int16, channels),
+		b:        make([]int16, channels),
+	}
+	if inFrames := src.Len() / channels; inFrames > 0 {
+		s.outFrames = int(float64(inFrames-1)/factor) + 1
+	}
+	s.frames.next(s.a)
+	if !s.frames.next(s.b) {
+		copy(s.b, s.a)
+	}
+	return s
+}
+
+func (s *speed) Len() int {
+	return s.outFrames * s.channels
+}
+
+func (s *speed) Read(samples []int16) (int, error) {
+	n := 0
+	for n+s.channels <= len(samples) && s.done < s.outFrames {
+		position := float64(s.done) * s.factor
+		i := int(position)
+		fraction := position - float64(i)
+
+		// Slide the two-frame window up to position
+		for s.index < i {
+			s.a, s.b = s.b, s.a
+			if !s.frames.next(s.b) {
+				copy(s.b, s.a)
+			}
+			s.index++
+		}
+		if s.frames.err != nil {
+			return n, s.frames.err
+		}
+
+		for c := range s.channels {
+			v := float64(s.a[c])*(1-fraction) + float64(s.b[c])*fraction
+			samples[n+c] = int16(math.Round(v))
+		}
+		n += s.channels
+		s.done++
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// fade ramps the volume linearly up from silence over the first in frames and
+// down to silence over the last out frames.
+type fade struct {
+	src      source
+	channels int
+	frames   int
+	in, out  int
+	done     int // samples so far
+}
+
+func newFade(src source, channels, in, out int) *fade {
+	return &fade{src: src, channels: channels, frames: src.Len() / channels, in: in, out: out}
+}
+
+func (f *fade) Len() int {
+	return f.src.Len()
+}
+
+func (f *fade) Read(samples []int16) (int, error) {
+	n, err := f.src.Read(samples)
+	for i := range n {
+		samples[i] = wav.Scale(samples[i], f.gain((f.done+i)/f.channels))
+	}
+	f.done += n
+	return n, err
+}
+
+// gain is the volume factor of frame: 0 at the very start and end.
+func (f *fade) gain(frame int) float64 {
+	gain := 1.0
+	if frame < f.in {
+		gain = float64(frame) / float64(f.in)
+	}
+	if left := f.frames - 1 - frame; left < f.out {
+		gain = min(gain, float64(left)/float64(f.out))
+	}
+	return gain
+}
@@ -0,0 +1,7 @@
+module audiofx
+
+go 1.24.4
+
+require wav v0.0.0
+
+replace wav => ../wav
@@ -0,0 +1,291 @@
+// Package bmp reads and writes 24-bit uncompressed BMP files with a
+// BITMAPINFOHEADER, the format of CS50's bmp.h (filter, and anything else
+// that wants to write pictures without a dependency). Headers are kept
+// exactly as read, so a file written back after filtering differs from the
+// input only in its pixels.
+package bmp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// MAX_PIXELS guards against headers that claim absurd sizes: 2^28 pixels is
+// a 16384 x 16384 photo, 768 MiB of RGB.
+const MAX_PIXELS = 1 << 28
+
+// Errors returned by Decode wrap one of these, so callers can tell "valid
+// BMP we can't handle" from "not a BMP at all" with errors.Is.
+var (
+	ErrUnsupported = errors.New("bmp: unsupported format")
+	ErrMalformed   = errors.New("bmp: malformed file")
+)
+
+// UnsupportedError is a valid BMP using a feature this package doesn't
+// implement (another bit depth, compression, header version).
+type UnsupportedError struct {
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string { return "bmp: unsupported " + e.Reason }
+func (e *UnsupportedError) Unwrap() error { return ErrUnsupported }
+
+// FormatError is a file that isn't a well-formed BMP.
+type FormatError struct {
+	Reason string
+}
+
+func (e *FormatError) Error() string { return "bmp: invalid format: " + e.Reason }
+func (e *FormatError) Unwrap() error { return ErrMalformed }
+
+// COMPRESSIONS names the biCompression values, for error messages.
+var COMPRESSIONS = map[uint32]string{
+	1: "BI_RLE8",
+	2: "BI_RLE4",
+	3: "BI_BITFIELDS",
+	4: "BI_JPEG",
+	5: "BI_PNG",
+	6: "BI_ALPHABITFIELDS",
+}
+
+// FileHeader is BITMAPFILEHEADER: 14 bytes, little-endian.
+type FileHeader struct {
+	Type      uint16 // "BM" = 0x4d42
+	Size      uint32 // whole file in bytes
+	Reserved1 uint16
+	Reserved2 uint16
+	OffBits   uint32 // where the pixels start
+}
+
+// InfoHeader is BITMAPINFOHEADER: 40 bytes, little-endian.
+type InfoHeader struct {
+	Size          uint32
+	Width         int32
+	Height        int32 // negative: rows stored top-down, positive: bottom-up
+	Planes        uint16
+	BitCount      uint16
+	Compression   uint32
+	SizeImage     uint32
+	XPelsPerMeter int32
+	YPelsPerMeter int32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// RGBTriple is one pixel. The file stores it blue first, as in bmp.h.
+type RGBTriple struct {
+	Blue  byte
+	Green byte
+	Red   byte
+}
+
+// Image is a decoded BMP. Pixels[row][column] always runs top to bottom,
+// whatever the file's row order was.
+type Image struct {
+	File   FileHeader
+	Info   InfoHeader
+	Pixels [][]RGBTriple
+}
+
+// Width returns the image width in pixels.
+func (img *Image) Width() int {
+	return int(img.Info.Width)
+}
+
+// Height returns the image height in pixels (always positive).
+func (img *Image) Height() int {
+	return len(img.Pixels)
+}
+
+// Padding returns how many zero bytes end each row, since rows are stored
+// in multiples of 4 bytes.
+func Padding(width int) int {
+	return (4 - (width*3)%4) % 4
+}
+
+// Decode reads a 24-bit BMP. Errors wrap ErrUnsupported or ErrMalformed,
+// or are io errors for a file that ends too early.
+func Decode(r io.Reader) (*Image, error) {
+	reader := bufio.NewReader(r)
+	img := &Image{}
+	if err := readHeaders(reader, img); err != nil {
+		return nil, err
+	}
+
+	width := int(img.Info.Width)
+	height := int(img.Info.Height)
+	if height < 0 {
+		height = -height
+	}
+
+	// Read each row, skipping the padding at its end
+	row := make([]byte, width*3+Padding(width))
+	img.Pixels = make([][]RGBTriple, height)
+	for i := range img.Pixels {
+		if _, err := io.ReadFull(reader, row); err != nil {
+			return nil, &FormatError{fmt.Sprintf("pixel data ends in row %d of %d", i, height)}
+		}
+		pixels := make([]RGBTriple, width)
+		for j := range pixels {
+			pixels[j] = RGBTriple{Blue: row[3*j], Green: row[3*j+1], Red: row[3*j+2]}
+		}
+		img.Pixels[i] = pixels
+	}
+
+	// Bottom-up file: flip so Pixels[0] is the top row
+	if img.Info.Height > 0 {
+		flip(img.Pixels)
+	}
+	return img, nil
+}
+
+// readHeaders reads and validates both headers into img.
+func readHeaders(r io.Reader, img *Image) error {
+	if err := binary.Read(r, binary.LittleEndian, &img.File); err != nil {
+		return headerError("file header", err)
+	}
+	if img.File.Type != 0x4d42 {
+		return &FormatError{"missing \"BM\" signature"}
+	}
+	if err := binary.Read(r, binary.LittleEndian, &img.Info); err != nil {
+		return headerError("info header", err)
+	}
+	return img.validate()
+}
+
+// validate checks the headers, from "is this a BMP" to "can we read it".
+func (img *Image) validate() error {
+	info := img.Info
+	switch info.Size {
+	case 40:
+	case 12, 16, 52, 56, 64, 108, 124:
+		return &UnsupportedError{fmt.Sprintf("info header of %d bytes (only the 40-byte BITMAPINFOHEADER)", info.Size)}
+	default:
+		return &FormatError{fmt.Sprintf("info header size %d", info.Size)}
+	}
+	if info.Planes != 1 {
+		return &FormatError{fmt.Sprintf("%d color planes, must be 1", info.Planes)}
+	}
+	switch info.BitCount {
+	case 24:
+	case 1, 4, 8, 16, 32:
+		return &UnsupportedError{fmt.Sprintf("%d-bit color (only 24-bit)", info.BitCount)}
+	default:
+		return &FormatError{fmt.Sprintf("bit depth %d", info.BitCount)}
+	}
+	if info.Compression != 0 {
+		name, ok := COMPRESSIONS[info.Compression]
+		if !ok {
+			return &FormatError{fmt.Sprintf("compression type %d", info.Compression)}
+		}
+		return &UnsupportedError{name + " compression (only uncompressed BI_RGB)"}
+	}
+	if info.Width <= 0 || info.Height == 0 {
+		return &FormatError{fmt.Sprintf("dimensions %d x %d", info.Width, info.Height)}
+	}
+	height := int64(info.Height)
+	if height < 0 {
+		height = -height
+	}
+	if int64(info.Width)*height > MAX_PIXELS {
+		return &FormatError{fmt.Sprintf("%d x %d is too large", info.Width, height)}
+	}
+	if img.File.OffBits != 54 {
+		return &UnsupportedError{fmt.Sprintf("pixel data at offset %d (only right after the headers, 54)", img.File.OffBits)}
+	}
+	return nil
+}
+
+func headerError(which string, err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return &FormatError{"file too short for the " + which}
+	}
+	return err
+}
+
+// Encode writes img with its original headers and row order.
+func Encode(w io.Writer, img *Image) error {
+	writer := bufio.NewWriter(w)
+	if err := binary.Write(writer, binary.LittleEndian, img.File); err != nil {
+		return err
+	}
+	if err := binary.Write(writer, binary.LittleEndian, img.Info); err != nil {
+		return err
+	}
+
+	rows := img.Pixels
+	if img.Info.Height > 0 {
+		rows = make([][]RGBTriple, len(img.Pixels))
+		copy(rows, img.Pixels)
+		flip(rows)
+	}
+
+	width := img.Width()
+	row := make([]byte, width*3+Padding(width)) // padding stays zero
+	for _, pixels := range rows {
+		for j, p := range pixels {
+			row[3*j], row[3*j+1], row[3*j+2] = p.Blue, p.Green, p.Red
+		}
+		if _, err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// New returns a black width x height image with fresh headers, stored
+// top-down like CS50's sample images.
+func New(width, height int) *Image {
+	size := (width*3 + Padding(width)) * height
+	img := &Image{
+		File: FileHeader{Type: 0x4d42, Size: uint32(54 + size), OffBits: 54},
+		Info: InfoHeader{
+			Size:          40,
+			Width:         int32(width),
+			Height:        -int32(height),
+			Planes:        1,
+			BitCount:      24,
+			SizeImage:     uint32(size),
+			XPelsPerMeter: 2835, // 72 DPI
+			YPelsPerMeter: 2835,
+		},
+		Pixels: make([][]RGBTriple, height),
+	}
+	for i := range img.Pixels {
+		img.Pixels[i] = make([]RGBTriple, width)
+	}
+	return img
+}
+
+// Load decodes the BMP at path.
+func Load(path string) (*Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Decode(file)
+}
+
+// Save encodes img to path.
+func Save(path string, img *Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func flip(rows [][]RGBTriple) {
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+}
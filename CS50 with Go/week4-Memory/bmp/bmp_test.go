@@ -0,0 +1,124 @@
+package bmp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPadding(t *testing.T) {
+	for width, want := range map[int]int{1: 1, 2: 2, 3: 3, 4: 0, 5: 1, 600: 0} {
+		if got := Padding(width); got != want {
+			t.Errorf("Padding(%d) = %d, want %d", width, got, want)
+		}
+	}
+}
+
+// Every width needs a different amount of padding; both row orders must
+// survive a round trip byte for byte.
+func TestRoundTrip(t *testing.T) {
+	for width := 1; width <= 5; width++ {
+		for _, bottomUp := range []bool{false, true} {
+			img := New(width, 3)
+			if bottomUp {
+				img.Info.Height = -img.Info.Height
+			}
+			for i, row := range img.Pixels {
+				for j := range row {
+					row[j] = RGBTriple{Blue: byte(i), Green: byte(j), Red: byte(10*i + j)}
+				}
+			}
+
+			var first bytes.Buffer
+			if err := Encode(&first, img); err != nil {
+				t.Fatal(err)
+			}
+			if first.Len() != int(img.File.Size) {
+				t.Errorf("width %d: wrote %d bytes, header says %d", width, first.Len(), img.File.Size)
+			}
+
+			decoded, err := Decode(bytes.NewReader(first.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Pixels[2][width-1] != img.Pixels[2][width-1] {
+				t.Errorf("width %d bottomUp %v: pixel %v, want %v", width, bottomUp, decoded.Pixels[2][width-1], img.Pixels[2][width-1])
+			}
+
+			var second bytes.Buffer
+			if err := Encode(&second, decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Errorf("width %d bottomUp %v: round trip changed the bytes", width, bottomUp)
+			}
+		}
+	}
+}
+
+func TestBottomUpRowOrder(t *testing.T) {
+	img := New(1, 2)
+	img.Info.Height = 2 // bottom-up
+	img.Pixels[0][0] = RGBTriple{Red: 1}
+	img.Pixels[1][0] = RGBTriple{Red: 2}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	// 54 bytes of headers, then the bottom row first: B G R + 1 byte padding
+	if got := buf.Bytes()[54+2]; got != 2 {
+		t.Errorf("first stored row has red %d, want the bottom row (2)", got)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(img *Image)
+		is     error
+		reason string
+	}{
+		{"32-bit", func(img *Image) { img.Info.BitCount = 32 }, ErrUnsupported, "32-bit color"},
+		{"RLE8", func(img *Image) { img.Info.Compression = 1 }, ErrUnsupported, "BI_RLE8 compression"},
+		{"V5 header", func(img *Image) { img.Info.Size = 124 }, ErrUnsupported, "info header of 124 bytes"},
+		{"offset", func(img *Image) { img.File.OffBits = 138 }, ErrUnsupported, "offset 138"},
+		{"signature", func(img *Image) { img.File.Type = 0x4947 }, ErrMalformed, `missing "BM"`},
+		{"bit depth", func(img *Image) { img.Info.BitCount = 23 }, ErrMalformed, "bit depth 23"},
+		{"planes", func(img *Image) { img.Info.Planes = 0 }, ErrMalformed, "0 color planes"},
+		{"compression", func(img *Image) { img.Info.Compression = 99 }, ErrMalformed, "compression type 99"},
+		{"width", func(img *Image) { img.Info.Width = -2 }, ErrMalformed, "dimensions -2 x -2"},
+		{"huge", func(img *Image) { img.Info.Width, img.Info.Height = 1<<20, 1<<20 }, ErrMalformed, "too large"},
+	}
+	for _, tt := range tests {
+		// Valid pixels behind patched headers
+		var valid bytes.Buffer
+		Encode(&valid, New(2, 2))
+		img := New(2, 2)
+		tt.modify(img)
+		var data bytes.Buffer
+		binary.Write(&data, binary.LittleEndian, img.File)
+		binary.Write(&data, binary.LittleEndian, img.Info)
+		data.Write(valid.Bytes()[54:])
+
+		_, err := Decode(&data)
+		if !errors.Is(err, tt.is) || err == nil || !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("%s: err = %v, want %v containing %q", tt.name, err, tt.is, tt.reason)
+		}
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	Encode(&buf, New(4, 4))
+	data := buf.Bytes()
+
+	for _, n := range []int{0, 10, 30, 54 + 12*2 + 5} {
+		_, err := Decode(bytes.NewReader(data[:n]))
+		if !errors.Is(err, ErrMalformed) {
+			t.Errorf("%d bytes: err = %v, want ErrMalformed", n, err)
+		}
+	}
+}
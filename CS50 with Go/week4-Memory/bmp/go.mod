@@ -0,0 +1,3 @@
+module bmp
+
+go 1.24.4
@@ -0,0 +1,65 @@
+package bmp
+
+import (
+	"image"
+	"image/color"
+	"io"
+)
+
+// Register BMP with the image package, so image.Decode recognises it by its
+// "BM" signature next to PNG and JPEG. The decoded image.Image is an *Image.
+func init() {
+	image.RegisterFormat("bmp", "BM", decodeImage, DecodeConfig)
+}
+
+func decodeImage(r io.Reader) (image.Image, error) {
+	return Decode(r)
+}
+
+// DecodeConfig returns the size of a BMP without reading its pixels.
+func DecodeConfig(r io.Reader) (image.Config, error) {
+	img := &Image{}
+	if err := readHeaders(r, img); err != nil {
+		return image.Config{}, err
+	}
+	height := int(img.Info.Height)
+	if height < 0 {
+		height = -height
+	}
+	return image.Config{ColorModel: color.RGBAModel, Width: int(img.Info.Width), Height: height}, nil
+}
+
+// ColorModel, Bounds and At make *Image an image.Image, so it can go
+// straight into png.Encode or jpeg.Encode.
+func (img *Image) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (img *Image) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.Width(), img.Height())
+}
+
+func (img *Image) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(img.Bounds())) {
+		return color.RGBA{}
+	}
+	p := img.Pixels[y][x]
+	return color.RGBA{R: p.Red, G: p.Green, B: p.Blue, A: 0xff}
+}
+
+// FromImage converts any image to a new 24-bit BMP. Transparency is dropped
+// (composited onto black, like the alpha-premultiplied colours Go returns).
+func FromImage(m image.Image) *Image {
+	if img, ok := m.(*Image); ok {
+		return img
+	}
+	bounds := m.Bounds()
+	img := New(bounds.Dx(), bounds.Dy())
+	for i, row := range img.Pixels {
+		for j := range row {
+			r, g, b, _ := m.At(bounds.Min.X+j, bounds.Min.Y+i).RGBA()
+			row[j] = RGBTriple{Red: byte(r >> 8), Green: byte(g >> 8), Blue: byte(b >> 8)}
+		}
+	}
+	return img
+}
@@ -0,0 +1,58 @@
+package bmp
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestImageDecodeDetectsBMP(t *testing.T) {
+	img := New(3, 2)
+	img.Pixels[1][2] = RGBTriple{Red: 200, Green: 100, Blue: 50}
+	var buf bytes.Buffer
+	if err := Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(buf.Bytes()))
+	if err != nil || format != "bmp" || config.Width != 3 || config.Height != 2 {
+		t.Fatalf("DecodeConfig = %+v, %q, %v", config, format, err)
+	}
+
+	m, format, err := image.Decode(&buf)
+	if err != nil || format != "bmp" {
+		t.Fatalf("Decode: %q, %v", format, err)
+	}
+	if got := m.At(2, 1); got != (color.RGBA{200, 100, 50, 255}) {
+		t.Errorf("At(2, 1) = %v", got)
+	}
+}
+
+func TestFromImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(10, 10, 14, 13)) // bounds not at the origin
+	src.Set(13, 12, color.RGBA{1, 2, 3, 255})
+	src.Set(10, 10, color.RGBA{255, 0, 0, 255})
+
+	img := FromImage(src)
+	if img.Width() != 4 || img.Height() != 3 {
+		t.Fatalf("size %dx%d, want 4x3", img.Width(), img.Height())
+	}
+	if img.Pixels[2][3] != (RGBTriple{Red: 1, Green: 2, Blue: 3}) || img.Pixels[0][0].Red != 255 {
+		t.Errorf("pixels = %v", img.Pixels)
+	}
+
+	// An *Image can be encoded as PNG and comes back the same
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back := FromImage(decoded); back.Pixels[2][3] != img.Pixels[2][3] {
+		t.Errorf("PNG round trip: %v, want %v", back.Pixels[2][3], img.Pixels[2][3])
+	}
+}
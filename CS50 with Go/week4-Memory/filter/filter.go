@@ -0,0 +1,104 @@
+// Filter pset (less and more comfortable): apply one filter to an image.
+//
+//	./filter -g infile.bmp outfile.bmp
+//	./filter -e photo.jpg edges.png
+//
+// The input can be BMP, PNG, JPEG or GIF (detected from the content); the
+// output format follows the output file's extension.
+// -b blur, -e edges, -g grayscale, -r reflect, -s sepia.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"runtime"
+
+	"bmp"
+	"filter/helpers"
+	"profiling"
+	"runctx"
+)
+
+// FILTERS are the allowable filters, one flag each. The neighbourhood
+// filters also have a parallel version that -p hands the worker count to.
+var FILTERS = []struct {
+	flag     string
+	usage    string
+	apply    func([][]bmp.RGBTriple)
+	parallel func([][]bmp.RGBTriple, int)
+}{
+	{"b", "blur", helpers.Blur, helpers.BlurParallel},
+	{"e", "edges", helpers.Edges, helpers.EdgesParallel},
+	{"g", "grayscale", helpers.Grayscale, nil},
+	{"r", "reflect", helpers.Reflect, nil},
+	{"s", "sepia", helpers.Sepia, nil},
+}
+
+func main() {
+	runctx.Install()
+	defer profiling.Start()()
+	chosen := make([]*bool, len(FILTERS))
+	for i, f := range FILTERS {
+		chosen[i] = flag.Bool(f.flag, false, f.usage)
+	}
+	workers := flag.Int("p", runtime.NumCPU(), "worker goroutines for blur and edges (1 = sequential)")
+	flag.Usage = func() {
+		fmt.Println("Usage: ./filter [flag] [-p N] infile outfile")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Get filter flag and check validity
+	var apply func([][]bmp.RGBTriple)
+	for i, f := range FILTERS {
+		if !*chosen[i] {
+			continue
+		}
+		if apply != nil {
+			fmt.Println("Only one filter allowed.")
+			os.Exit(2)
+		}
+		apply = f.apply
+		if f.parallel != nil && *workers > 1 {
+			parallel := f.parallel
+			apply = func(image [][]bmp.RGBTriple) { parallel(image, *workers) }
+		}
+	}
+	if apply == nil {
+		fmt.Println("Invalid filter.")
+		os.Exit(1)
+	}
+
+	// Ensure proper usage
+	if flag.NArg() != 2 {
+		fmt.Println("Usage: ./filter [flag] [-p N] infile outfile")
+		os.Exit(3)
+	}
+	infile, outfile := flag.Arg(0), flag.Arg(1)
+
+	// Open input file: BMP, PNG, JPEG or GIF, whatever its name says
+	img, err := load(infile)
+	if errors.Is(err, bmp.ErrUnsupported) || errors.Is(err, bmp.ErrMalformed) || errors.Is(err, image.ErrFormat) {
+		fmt.Println("Unsupported file format.")
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(6)
+	}
+	if err != nil {
+		fmt.Printf("Could not open %s.\n", infile)
+		os.Exit(4)
+	}
+
+	// Filter image
+	apply(img.Pixels)
+
+	// Write outfile in the format of its extension
+	if err := save(outfile, img); err != nil {
+		fmt.Printf("Could not create %s.\n", outfile)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(5)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bmp"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata/")
+
+// Every filter applied to images/sample.bmp must match its golden image byte
+// for byte. After an intended change, regenerate with: go test -update .
+func TestGolden(t *testing.T) {
+	for _, f := range FILTERS {
+		img, err := bmp.Load("images/sample.bmp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.apply(img.Pixels)
+
+		var got bytes.Buffer
+		if err := bmp.Encode(&got, img); err != nil {
+			t.Fatal(err)
+		}
+
+		golden := filepath.Join("testdata", "sample-"+f.usage+".bmp")
+		if *update {
+			if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got.Bytes(), want) {
+			t.Errorf("-%s (%s) differs from %s", f.flag, f.usage, golden)
+		}
+	}
+}
+
+// The goldens aren't only whatever -update wrote: pixel [5][7] of each is
+// worked out by hand. Around it images/sample.bmp is a plain gradient, red
+// 24, 28, 32 left to right, green 231, 225, 219 top to bottom and blue 231,
+// 227, 223 left to right, so the arithmetic stays small.
+func TestGoldenByHand(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   bmp.RGBTriple
+	}{
+		// The 3x3 box averages to its middle, (28, 225, 227)
+		{"blur", bmp.RGBTriple{Red: 28, Green: 225, Blue: 227}},
+		// Gx = 4 * (right - left), Gy = 4 * (below - above): red (32, 0),
+		// green (0, -48), blue (-32, 0)
+		{"edges", bmp.RGBTriple{Red: 32, Green: 48, Blue: 32}},
+		// (28 + 225 + 227) / 3 = 160
+		{"grayscale", bmp.RGBTriple{Red: 160, Green: 160, Blue: 160}},
+		// The 60-pixel row backwards: [5][52]
+		{"reflect", bmp.RGBTriple{Red: 208, Green: 30, Blue: 47}},
+		// 226.932, 202.258, 157.503
+		{"sepia", bmp.RGBTriple{Red: 227, Green: 202, Blue: 158}},
+	}
+	for _, tt := range tests {
+		golden, err := bmp.Load(filepath.Join("testdata", "sample-"+tt.filter+".bmp"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := golden.Pixels[5][7]; got != tt.want {
+			t.Errorf("%s: pixel [5][7] = %+v, want %+v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+// A PNG goes in, a filtered JPEG/PNG/BMP comes out, chosen by extension.
+func TestFormats(t *testing.T) {
+	src, err := bmp.Load("images/sample.bmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	pngPath := filepath.Join(dir, "sample.png")
+	if err := save(pngPath, src); err != nil {
+		t.Fatal(err)
+	}
+
+	// Named .bmp but it's a PNG: content wins
+	disguised := filepath.Join(dir, "really-a-png.bmp")
+	data, _ := os.ReadFile(pngPath)
+	os.WriteFile(disguised, data, 0o644)
+
+	for _, in := range []string{pngPath, disguised} {
+		img, err := load(in)
+		if err != nil {
+			t.Fatalf("load %s: %v", in, err)
+		}
+		if img.Pixels[5][7] != src.Pixels[5][7] {
+			t.Errorf("%s: pixel %v, want %v", in, img.Pixels[5][7], src.Pixels[5][7])
+		}
+	}
+
+	for _, out := range []string{"out.bmp", "out.png", "out.JPG", "out.jpeg"} {
+		img, _ := load(pngPath)
+		FILTERS[2].apply(img.Pixels) // grayscale
+		path := filepath.Join(dir, out)
+		if err := save(path, img); err != nil {
+			t.Fatalf("save %s: %v", out, err)
+		}
+		back, err := load(path)
+		if err != nil {
+			t.Fatalf("reload %s: %v", out, err)
+		}
+		if back.Width() != src.Width() || back.Height() != src.Height() {
+			t.Errorf("%s: %dx%d", out, back.Width(), back.Height())
+		}
+	}
+
+	if err := save(filepath.Join(dir, "out.tiff"), src); err == nil {
+		t.Error("save .tiff: want error")
+	}
+}
@@ -0,0 +1,15 @@
+module filter
+
+go 1.24.4
+
+require (
+	bmp v0.0.0
+	profiling v0.0.0
+	runctx v0.0.0
+)
+
+replace (
+	bmp => ../bmp
+	profiling => ../../profiling
+	runctx => ../../runctx
+)
@@ -0,0 +1,183 @@
+// Package helpers is the pset's helpers.c: one function per filter, each
+// changing the image in place. image[row][column], top row first.
+package helpers
+
+import (
+	"math"
+	"sync"
+
+	"bmp"
+)
+
+// Grayscale sets every channel to the pixel's average, rounded.
+func Grayscale(image [][]bmp.RGBTriple) {
+	for _, row := range image {
+		for j, p := range row {
+			average := byte(math.Round((float64(p.Red) + float64(p.Green) + float64(p.Blue)) / 3))
+			row[j] = bmp.RGBTriple{Blue: average, Green: average, Red: average}
+		}
+	}
+}
+
+// Sepia applies the usual sepia matrix, rounding and capping at 255.
+func Sepia(image [][]bmp.RGBTriple) {
+	for _, row := range image {
+		for j, p := range row {
+			r, g, b := float64(p.Red), float64(p.Green), float64(p.Blue)
+			row[j] = bmp.RGBTriple{
+				Red:   cap255(.393*r + .769*g + .189*b),
+				Green: cap255(.349*r + .686*g + .168*b),
+				Blue:  cap255(.272*r + .534*g + .131*b),
+			}
+		}
+	}
+}
+
+// Reflect mirrors the image horizontally.
+func Reflect(image [][]bmp.RGBTriple) {
+	for _, row := range image {
+		for i, j := 0, len(row)-1; i < j; i, j = i+1, j-1 {
+			row[i], row[j] = row[j], row[i]
+		}
+	}
+}
+
+// Blur replaces each pixel with the average of the up to 3x3 box around it
+// (pixels past the edge don't count), rounded.
+func Blur(image [][]bmp.RGBTriple) {
+	BlurParallel(image, 1)
+}
+
+// BlurParallel is Blur split across workers goroutines; the result is the
+// same for any number of workers.
+func BlurParallel(image [][]bmp.RGBTriple, workers int) {
+	// Average from a copy, or already blurred neighbours would leak in
+	original := borrowCopy(image)
+	defer copies.Put(original)
+	parallelRows(len(image), workers, func(i int) {
+		blurRow(original.rows, image[i], i)
+	})
+}
+
+func blurRow(original [][]bmp.RGBTriple, row []bmp.RGBTriple, i int) {
+	height, width := len(original), len(row)
+	for j := range row {
+		var red, green, blue, count float64
+		for di := -1; di <= 1; di++ {
+			for dj := -1; dj <= 1; dj++ {
+				y, x := i+di, j+dj
+				if y < 0 || y >= height || x < 0 || x >= width {
+					continue
+				}
+				p := original[y][x]
+				red += float64(p.Red)
+				green += float64(p.Green)
+				blue += float64(p.Blue)
+				count++
+			}
+		}
+		row[j] = bmp.RGBTriple{
+			Red:   byte(math.Round(red / count)),
+			Green: byte(math.Round(green / count)),
+			Blue:  byte(math.Round(blue / count)),
+		}
+	}
+}
+
+// GX and GY are the Sobel kernels: GX finds vertical edges (left vs
+// right), GY horizontal ones (above vs below).
+var (
+	GX = [3][3]float64{
+		{-1, 0, 1},
+		{-2, 0, 2},
+		{-1, 0, 1},
+	}
+	GY = [3][3]float64{
+		{-1, -2, -1},
+		{0, 0, 0},
+		{1, 2, 1},
+	}
+)
+
+// Edges runs the Sobel operator on every channel: sqrt(Gx² + Gy²), rounded
+// and capped at 255. Pixels past the edge count as solid black.
+func Edges(image [][]bmp.RGBTriple) {
+	EdgesParallel(image, 1)
+}
+
+// EdgesParallel is Edges split across workers goroutines; the result is the
+// same for any number of workers.
+func EdgesParallel(image [][]bmp.RGBTriple, workers int) {
+	original := borrowCopy(image)
+	defer copies.Put(original)
+	parallelRows(len(image), workers, func(i int) {
+		edgesRow(original.rows, image[i], i)
+	})
+}
+
+func edgesRow(original [][]bmp.RGBTriple, row []bmp.RGBTriple, i int) {
+	height, width := len(original), len(row)
+	for j := range row {
+		var gx, gy [3]float64 // red, green, blue
+		for di := -1; di <= 1; di++ {
+			for dj := -1; dj <= 1; dj++ {
+				y, x := i+di, j+dj
+				if y < 0 || y >= height || x < 0 || x >= width {
+					continue // black: adds 0 to both sums
+				}
+				p := original[y][x]
+				channels := [3]float64{float64(p.Red), float64(p.Green), float64(p.Blue)}
+				for c, v := range channels {
+					gx[c] += GX[di+1][dj+1] * v
+					gy[c] += GY[di+1][dj+1] * v
+				}
+			}
+		}
+		row[j] = bmp.RGBTriple{
+			Red:   cap255(math.Sqrt(gx[0]*gx[0] + gy[0]*gy[0])),
+			Green: cap255(math.Sqrt(gx[1]*gx[1] + gy[1]*gy[1])),
+			Blue:  cap255(math.Sqrt(gx[2]*gx[2] + gy[2]*gy[2])),
+		}
+	}
+}
+
+// cap255 rounds v to the nearest byte value, capping at 255.
+func cap255(v float64) byte {
+	return byte(math.Min(math.Round(v), 255))
+}
+
+// imageCopy is a copy of an image for blur and edges to read from: its
+// rows are slices of one array of pixels.
+type imageCopy struct {
+	rows   [][]bmp.RGBTriple
+	pixels []bmp.RGBTriple
+}
+
+// copies keeps the copies blur and edges are done with, so filtering one
+// image after another, frames or a benchmark, doesn't allocate a whole
+// image each time.
+var copies = sync.Pool{New: func() any { return new(imageCopy) }}
+
+// borrowCopy copies image into a copy from the pool, grown if it's too
+// small. It goes back with copies.Put, once nothing reads it.
+func borrowCopy(image [][]bmp.RGBTriple) *imageCopy {
+	c := copies.Get().(*imageCopy)
+	n := 0
+	for _, row := range image {
+		n += len(row)
+	}
+	if cap(c.pixels) < n {
+		c.pixels = make([]bmp.RGBTriple, n)
+	}
+	if cap(c.rows) < len(image) {
+		c.rows = make([][]bmp.RGBTriple, len(image))
+	}
+	c.rows = c.rows[:len(image)]
+	pixels := c.pixels[:n]
+	for i, row := range image {
+		c.rows[i] = pixels[:len(row):len(row)]
+		copy(c.rows[i], row)
+		pixels = pixels[len(row):]
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"testing"
+
+	"bmp"
+)
+
+// rgb builds a pixel in the (red, green, blue) order check50 prints.
+func rgb(r, g, b byte) bmp.RGBTriple {
+	return bmp.RGBTriple{Red: r, Green: g, Blue: b}
+}
+
+func equal(t *testing.T, name string, got, want [][]bmp.RGBTriple) {
+	t.Helper()
+	for i := range want {
+		for j := range want[i] {
+			if got[i][j] != want[i][j] {
+				t.Errorf("%s: pixel [%d][%d] = %+v, want %+v", name, i, j, got[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+// The 3x3 image check50 uses for blur.
+func sample() [][]bmp.RGBTriple {
+	return [][]bmp.RGBTriple{
+		{rgb(10, 20, 30), rgb(40, 50, 60), rgb(70, 80, 90)},
+		{rgb(110, 130, 140), rgb(120, 140, 150), rgb(130, 150, 160)},
+		{rgb(200, 210, 220), rgb(220, 230, 240), rgb(240, 250, 255)},
+	}
+}
+
+func TestGrayscale(t *testing.T) {
+	image := [][]bmp.RGBTriple{
+		{rgb(20, 40, 90), rgb(27, 28, 28)}, // whole average, then one that rounds
+		{rgb(50, 50, 50), rgb(255, 255, 254)},
+	}
+	Grayscale(image)
+	equal(t, "grayscale", image, [][]bmp.RGBTriple{
+		{rgb(50, 50, 50), rgb(28, 28, 28)},
+		{rgb(50, 50, 50), rgb(255, 255, 255)},
+	})
+}
+
+func TestSepia(t *testing.T) {
+	image := [][]bmp.RGBTriple{
+		{rgb(20, 40, 90), rgb(255, 255, 255)},
+		{rgb(0, 0, 0), rgb(10, 20, 30)},
+	}
+	Sepia(image)
+	equal(t, "sepia", image, [][]bmp.RGBTriple{
+		// 55.63, 49.54, 38.59 / 344.505, 306.765 capped at 255, 238.935
+		{rgb(56, 50, 39), rgb(255, 255, 239)},
+		// 0, 0, 0 / 24.98, 22.25, 17.33
+		{rgb(0, 0, 0), rgb(25, 22, 17)},
+	})
+}
+
+func TestReflect(t *testing.T) {
+	image := [][]bmp.RGBTriple{
+		{rgb(255, 0, 0), rgb(0, 0, 255), rgb(0, 255, 0)},
+		{rgb(1, 1, 1), rgb(2, 2, 2)},
+		{rgb(9, 9, 9)},
+	}
+	Reflect(image)
+	equal(t, "reflect", image, [][]bmp.RGBTriple{
+		{rgb(0, 255, 0), rgb(0, 0, 255), rgb(255, 0, 0)},
+		{rgb(2, 2, 2), rgb(1, 1, 1)},
+		{rgb(9, 9, 9)},
+	})
+}
+
+func TestBlur(t *testing.T) {
+	image := sample()
+	Blur(image)
+	equal(t, "blur", image, [][]bmp.RGBTriple{
+		{rgb(70, 85, 95), rgb(80, 95, 105), rgb(90, 105, 115)},
+		{rgb(117, 130, 140), rgb(127, 140, 149), rgb(137, 150, 159)},
+		{rgb(163, 178, 188), rgb(170, 185, 194), rgb(178, 193, 201)},
+	})
+}
+
+func TestEdges(t *testing.T) {
+	// check50's edges image
+	image := [][]bmp.RGBTriple{
+		{rgb(0, 10, 25), rgb(0, 10, 30), rgb(40, 60, 80)},
+		{rgb(20, 30, 90), rgb(30, 40, 100), rgb(80, 70, 90)},
+		{rgb(20, 20, 40), rgb(30, 10, 30), rgb(50, 40, 10)},
+	}
+	Edges(image)
+	// Middle pixel: every neighbour is real
+	if got, want := image[1][1], rgb(210, 150, 60); got != want {
+		t.Errorf("edges middle = %+v, want %+v", got, want)
+	}
+	// Edge and corner pixels: the black border pushes them to the cap
+	if got, want := image[0][1], rgb(213, 228, 255); got != want {
+		t.Errorf("edges top edge = %+v, want %+v", got, want)
+	}
+	if got, want := image[0][0], rgb(76, 117, 255); got != want {
+		t.Errorf("edges corner = %+v, want %+v", got, want)
+	}
+
+	// A flat image has no edges inside, only at its black border
+	flat := [][]bmp.RGBTriple{
+		{rgb(9, 9, 9), rgb(9, 9, 9), rgb(9, 9, 9)},
+		{rgb(9, 9, 9), rgb(9, 9, 9), rgb(9, 9, 9)},
+		{rgb(9, 9, 9), rgb(9, 9, 9), rgb(9, 9, 9)},
+	}
+	Edges(flat)
+	if flat[1][1] != rgb(0, 0, 0) {
+		t.Errorf("flat middle = %+v, want black", flat[1][1])
+	}
+}
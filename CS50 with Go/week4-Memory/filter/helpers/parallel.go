@@ -0,0 +1,40 @@
+package helpers
+
+import "sync"
+
+// BAND_ROWS is how many rows one worker takes at a time. Bands instead of
+// single rows keep the channel traffic low; small enough that the last
+// band doesn't leave most workers idle.
+const BAND_ROWS = 16
+
+// parallelRows calls row(i) for every i in [0, height) using a pool of
+// workers goroutines pulling bands of rows off a channel. Each row is
+// written by exactly one worker and only read from a copy, so no locking is
+// needed and the output doesn't depend on the schedule.
+func parallelRows(height, workers int, row func(i int)) {
+	if workers <= 1 || height <= BAND_ROWS {
+		for i := 0; i < height; i++ {
+			row(i)
+		}
+		return
+	}
+
+	bands := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range bands {
+				for i := start; i < min(start+BAND_ROWS, height); i++ {
+					row(i)
+				}
+			}
+		}()
+	}
+	for start := 0; start < height; start += BAND_ROWS {
+		bands <- start
+	}
+	close(bands)
+	wg.Wait()
+}
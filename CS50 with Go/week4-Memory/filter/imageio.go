@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bmp"
+	"runctx"
+)
+
+// JPEG_QUALITY is used when the output file ends in .jpg or .jpeg.
+const JPEG_QUALITY = 90
+
+// load decodes any format the image package knows, detected from the file's
+// content rather than its name. BMPs keep their original headers.
+func load(path string) (*bmp.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	m, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	return bmp.FromImage(m), nil
+}
+
+// save encodes img in the format named by path's extension. An image that
+// fails to encode, or is stopped with Ctrl-C, isn't left half written.
+func save(path string, img *bmp.Image) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, ok := OUTPUT_FORMATS[ext]; !ok {
+		return fmt.Errorf("unknown output format %q (want .bmp, .png, .jpg or .jpeg)", ext)
+	}
+
+	file, err := runctx.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := OUTPUT_FORMATS[ext](file.File, img); err != nil {
+		file.Discard()
+		return err
+	}
+	return file.Close()
+}
+
+// OUTPUT_FORMATS maps an output extension to its encoder.
+var OUTPUT_FORMATS = map[string]func(*os.File, *bmp.Image) error{
+	".bmp": func(f *os.File, img *bmp.Image) error { return bmp.Encode(f, img) },
+	".png": func(f *os.File, img *bmp.Image) error { return png.Encode(f, img) },
+	".jpg": func(f *os.File, img *bmp.Image) error {
+		return jpeg.Encode(f, img, &jpeg.Options{Quality: JPEG_QUALITY})
+	},
+	".jpeg": func(f *os.File, img *bmp.Image) error {
+		return jpeg.Encode(f, img, &jpeg.Options{Quality: JPEG_QUALITY})
+	},
+}
@@ -1,35 +1,64 @@
 package main
 
 import (
+	"context"
+	"exitcode"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
+
+	"logging"
+	"profiling"
+	"render"
+	"runctx"
 )
 
+// jpeg is a line of the report --format prints: a JPEG found, where on
+// the card it starts, and how big it is.
+type jpeg struct {
+	File   string `json:"file"`
+	Offset int64  `json:"offset"`
+	Bytes  int64  `json:"bytes"`
+}
+
 func main() {
+	logging.Init()
+	render.Init() // --format table|json|csv lists the JPEGs
+	profiling.Start() // stopped by the cleanups, as exitcode.Main exits
+	exitcode.Main(run)
+}
+
+// run recovers the JPEGs, or returns why it can't go on. Ctrl-C cancels
+// ctx, and the JPEG it was halfway through is removed, not left broken.
+func run(ctx context.Context, args []string) error {
 //--|-- Gate keeper
-	if len(os.Args) != 2 {
-		log.Fatal("| Usage: go run recover.go card.raw |")
+	if len(args) != 1 {
+		return exitcode.Usage("| Usage: go run recover.go card.raw |", nil)
 	}
 //--> Get in
-	fmt.Println("hello, world")
+	slog.Debug("recovering", "image", args[0])
 
 	// appear checker
 	// ##Open file
-	cardFile, err := os.Open(os.Args[1])
+	cardFile, err := os.Open(args[0])
 	if err != nil {
-		log.Fatal(err)
+		// CS50 says 1, as for usage: the argument names no image to read
+		return exitcode.Usage("can't open the image", err)
 	}
 	defer cardFile.Close()
 	// ##close file
 	
 //-- Main Loop and Recovery Logic
+	// One block for the whole card, however many GB: the loop allocates
+	// nothing per block, only a name, a file and a report line per JPEG.
 	buffer := make([]byte, 512)
 	
 	// Prepare output file variables
 	fileCounter := 0
-	var outputFile *os.File = nil
+	var outputFile *runctx.File = nil
+	var report []jpeg
+	offset := int64(0)
 
 	for {
 		n, err := cardFile.Read(buffer)
@@ -37,11 +66,14 @@ func main() {
 			if err == io.EOF {
 				break
 			}
-			log.Fatal (err)
+			return exitcode.Data("can't read the image", err)
 		}
 		if n == 0 {
 			break
 		}
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
 
 		// Check for JPEG signature
 		if buffer[0] == 0xff && buffer[1] == 0xd8 && buffer[2] == 0xff && (buffer[3]&0xf0) == 0xe0 {
@@ -51,10 +83,12 @@ func main() {
 			}
 			// Create new fileman
 			filename := fmt.Sprintf("%03d.jpg", fileCounter)
-			outputFile, err = os.Create(filename)
+			outputFile, err = runctx.Create(filename)
 			if err != nil {
-				log.Fatal(err)
+				return exitcode.Data("can't create a JPEG", err)
 			}
+			slog.Debug("found a JPEG", "file", filename)
+			report = append(report, jpeg{File: filename, Offset: offset})
 			fileCounter++
 		}
 
@@ -62,16 +96,25 @@ func main() {
 		if outputFile != nil {
 			_, err := outputFile.Write(buffer[:n])
 			if err != nil {
-				log.Fatal(err)
+				return exitcode.Data("can't write a JPEG", err)
 			}
+			report[len(report)-1].Bytes += int64(n)
 		}
+		offset += int64(n)
 	}
 //--Final Cleanup
+	slog.Debug("recovered", "jpegs", fileCounter)
 	//## close the last file After loop.
 	if outputFile != nil {
 			outputFile.Close()
 		}
+	if !render.Text() {
+		if err := render.Print(report); err != nil {
+			return exitcode.Internal("can't print the report", err)
+		}
+	}
 // close the input File
 defer cardFile.Close()
+	return nil
 }
 //--> Out door
\ No newline at end of file
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"exitcode"
+)
+
+// card is a forensic image of blocks: a header starts a JPEG with that
+// byte after the signature, and 0 is a block of nothing, before any JPEG
+// or inside one.
+func card(blocks ...byte) []byte {
+	var image []byte
+	for _, b := range blocks {
+		block := make([]byte, 512)
+		if b != 0 {
+			copy(block, []byte{0xff, 0xd8, 0xff, 0xe0 | b&0x0f})
+		}
+		image = append(image, block...)
+	}
+	return image
+}
+
+func TestRun(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("card.raw", card(0, 0, 1, 0, 0, 2, 0xf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(context.Background(), []string{"card.raw"}); err != nil {
+		t.Fatalf("run(card.raw) = %v", err)
+	}
+	// What comes before the first JPEG is skipped, and each runs to the next.
+	for file, size := range map[string]int{"000.jpg": 3 * 512, "001.jpg": 512, "002.jpg": 512} {
+		info, err := os.Stat(file)
+		if err != nil || info.Size() != int64(size) {
+			t.Errorf("%s: %v, want %d bytes", file, err, size)
+		}
+	}
+	if found, _ := filepath.Glob("*.jpg"); len(found) != 3 {
+		t.Errorf("recovered %v, want 3 JPEGs", found)
+	}
+	first, _ := os.ReadFile("000.jpg")
+	if !bytes.HasPrefix(first, []byte{0xff, 0xd8, 0xff, 0xe1}) {
+		t.Errorf("000.jpg starts % x, not with the JPEG it found", first[:4])
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Chdir(t.TempDir())
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := os.WriteFile("card.raw", card(1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ctx  context.Context
+		args []string
+		code int
+		is   error
+	}{
+		{context.Background(), nil, exitcode.USAGE, exitcode.ErrUsage},
+		{context.Background(), []string{"card.raw", "more.raw"}, exitcode.USAGE, exitcode.ErrUsage},
+		{context.Background(), []string{"missing.raw"}, exitcode.USAGE, os.ErrNotExist},
+		{context.Background(), []string{"."}, exitcode.DATA, exitcode.ErrData}, // opens, but a folder can't be read
+		{canceled, []string{"card.raw"}, exitcode.INTERRUPTED, context.Canceled},
+	}
+	for _, test := range tests {
+		err := run(test.ctx, test.args)
+		if code := exitcode.Code(err); code != test.code || !errors.Is(err, test.is) {
+			t.Errorf("run(%q) = %v, code %d, want code %d and %v", test.args, err, code, test.code, test.is)
+		}
+	}
+	if found, _ := filepath.Glob("*.jpg"); len(found) != 0 {
+		t.Errorf("canceled before a block, it still wrote %v", found)
+	}
+}
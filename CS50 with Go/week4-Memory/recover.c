@@ -0,0 +1,62 @@
+// The C reference for recover, the way the pset asks for it, so that
+// journal bench can time play-recover.go against it:
+//
+//	cc -O2 -o recover recover.c && ./recover card.raw
+
+#include <stdint.h>
+#include <stdio.h>
+
+#define BLOCK_SIZE 512
+
+int main(int argc, char *argv[])
+{
+    if (argc != 2)
+    {
+        printf("Usage: ./recover card.raw\n");
+        return 1;
+    }
+
+    FILE *card = fopen(argv[1], "r");
+    if (card == NULL)
+    {
+        printf("Could not open %s.\n", argv[1]);
+        return 1;
+    }
+
+    uint8_t buffer[BLOCK_SIZE];
+    FILE *image = NULL;
+    int count = 0;
+    char filename[16];
+
+    // A JPEG starts on a block boundary with 0xff 0xd8 0xff 0xe?, and runs
+    // until the next one starts.
+    while (fread(buffer, 1, BLOCK_SIZE, card) == BLOCK_SIZE)
+    {
+        if (buffer[0] == 0xff && buffer[1] == 0xd8 && buffer[2] == 0xff && (buffer[3] & 0xf0) == 0xe0)
+        {
+            if (image != NULL)
+            {
+                fclose(image);
+            }
+            snprintf(filename, sizeof filename, "%03i.jpg", count++);
+            image = fopen(filename, "w");
+            if (image == NULL)
+            {
+                fclose(card);
+                printf("Could not create %s.\n", filename);
+                return 1;
+            }
+        }
+        if (image != NULL)
+        {
+            fwrite(buffer, 1, BLOCK_SIZE, image);
+        }
+    }
+
+    if (image != NULL)
+    {
+        fclose(image);
+    }
+    fclose(card);
+    return 0;
+}
@@ -0,0 +1,15 @@
+module volume
+
+go 1.24.4
+
+require (
+	profiling v0.0.0
+	runctx v0.0.0
+	wav v0.0.0
+)
+
+replace (
+	profiling => ../../profiling
+	runctx => ../../runctx
+	wav => ../wav
+)
@@ -0,0 +1,73 @@
+// Volume lab: modify the volume of an audio file by a factor.
+//
+//	./volume input.wav output.wav 2.0
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"profiling"
+	"runctx"
+	"wav"
+)
+
+func main() {
+	// Stopped halfway, or failing, it leaves no output.wav behind.
+	runctx.Install()
+	defer profiling.Start()()
+
+	// Check command-line arguments
+	if len(os.Args) != 4 {
+		fmt.Println("Usage: ./volume input.wav output.wav factor")
+		os.Exit(1)
+	}
+
+	// Open files and determine scaling factor
+	input, err := os.Open(os.Args[1])
+	if err != nil {
+		fmt.Println("Could not open file.")
+		os.Exit(1)
+	}
+	defer input.Close()
+
+	output, err := runctx.Create(os.Args[2])
+	if err != nil {
+		fmt.Println("Could not open file.")
+		os.Exit(1)
+	}
+
+	factor, err := strconv.ParseFloat(os.Args[3], 64)
+	if err != nil {
+		fmt.Println("Usage: ./volume input.wav output.wav factor")
+		runctx.Exit(1)
+	}
+
+	if err := volume(input, output, factor); err != nil {
+		fmt.Println(err)
+		runctx.Exit(1)
+	}
+	if err := output.Close(); err != nil {
+		fmt.Println(err)
+		runctx.Exit(1)
+	}
+}
+
+// volume decodes input, scales every sample by factor and encodes the result
+// to output. Chunks other than the samples (fmt, LIST, ...) are kept as they
+// were.
+func volume(input io.Reader, output io.Writer, factor float64) error {
+	audio, err := wav.Decode(input)
+	if err != nil {
+		return err
+	}
+
+	// Update every sample, whatever channel it belongs to
+	for i, sample := range audio.Samples {
+		audio.Samples[i] = wav.Scale(sample, factor)
+	}
+	return wav.Encode(output, audio)
+}
@@ -0,0 +1,3 @@
+module wav
+
+go 1.24.4
@@ -0,0 +1,45 @@
+// sinegen writes a sine wave WAV to play with when you don't have a
+// recording handy: go run ./sinegen -hz 440 -seconds 2 input.wav
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"wav"
+)
+
+func main() {
+	frequency := flag.Float64("hz", 440, "frequency in Hz")
+	seconds := flag.Float64("seconds", 2, "duration in seconds")
+	rate := flag.Int("rate", 44100, "samples per second")
+	amplitude := flag.Int("amplitude", 8000, "peak amplitude (max 32767)")
+	flag.Parse()
+
+	if flag.NArg() != 1 || *amplitude < 0 || *amplitude > 32767 {
+		fmt.Println("Usage: ./sinegen [-hz 440] [-seconds 2] [-rate 44100] [-amplitude 8000] output.wav")
+		os.Exit(1)
+	}
+
+	file, err := os.Create(flag.Arg(0))
+	if err != nil {
+		fmt.Println("Could not open file.")
+		os.Exit(1)
+	}
+	writer := bufio.NewWriter(file)
+	samples := wav.Sine(*frequency, *seconds, *rate, int16(*amplitude))
+	err = wav.Write(writer, *rate, 1, samples)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
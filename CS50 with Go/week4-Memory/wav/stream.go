@@ -0,0 +1,232 @@
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Reader streams the samples of a WAV file instead of loading them all.
+type Reader struct {
+	Format
+
+	// Info and Extra are from the chunks before the samples; chunks after
+	// them are never read.
+	Info  map[string]string
+	Extra []Chunk
+
+	// DataOffset is where the first sample starts in the file, so callers
+	// with an io.ReaderAt (like reverse in audiofx) can jump around.
+	DataOffset int64
+
+	reader    *bufio.Reader
+	samples   int
+	remaining int
+}
+
+// NewReader reads the chunks up to and including the data chunk header.
+func NewReader(r io.Reader) (*Reader, error) {
+	stream := &Reader{reader: bufio.NewReader(r), Info: map[string]string{}}
+
+	var riff struct {
+		ID   [4]byte
+		Size uint32
+		Form [4]byte
+	}
+	if err := binary.Read(stream.reader, binary.LittleEndian, &riff); err != nil {
+		return nil, &FormatError{"file too short for the RIFF header"}
+	}
+	if string(riff.ID[:]) != "RIFF" || string(riff.Form[:]) != "WAVE" {
+		return nil, &FormatError{"not a RIFF/WAVE file"}
+	}
+	stream.DataOffset = 12
+
+	haveFormat := false
+	for {
+		id, size, err := stream.chunkHeader()
+		if errors.Is(err, io.EOF) {
+			if !haveFormat {
+				return nil, &FormatError{"no fmt chunk"}
+			}
+			return nil, &FormatError{"no data chunk"}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Stop at the samples, the caller reads them with Read
+		if id == "data" {
+			if !haveFormat {
+				return nil, &FormatError{"data chunk before fmt chunk"}
+			}
+			if size%2 != 0 {
+				return nil, &FormatError{"odd number of bytes of 16-bit samples"}
+			}
+			stream.samples = int(size / 2)
+			stream.remaining = stream.samples
+			return stream, nil
+		}
+
+		data, err := stream.chunkData(id, size)
+		if err != nil {
+			return nil, err
+		}
+		switch id {
+		case "fmt ":
+			if stream.Format, err = parseFormat(data); err != nil {
+				return nil, err
+			}
+			haveFormat = true
+		default:
+			if id == "LIST" {
+				parseInfo(data, stream.Info)
+			}
+			stream.Extra = append(stream.Extra, Chunk{ID: id, Data: data})
+		}
+	}
+}
+
+// Len returns the number of samples in the data chunk (all channels).
+func (r *Reader) Len() int {
+	return r.samples
+}
+
+// Read reads up to len(samples) samples and returns how many it read, or
+// io.EOF once every sample has been read.
+func (r *Reader) Read(samples []int16) (int, error) {
+	n := min(len(samples), r.remaining)
+	if n == 0 {
+		return 0, io.EOF
+	}
+	if err := binary.Read(r.reader, binary.LittleEndian, samples[:n]); err != nil {
+		return 0, &FormatError{"data chunk ends early"}
+	}
+	r.remaining -= n
+	return n, nil
+}
+
+// chunkHeader reads the ID and size of the next chunk.
+func (r *Reader) chunkHeader() (string, uint32, error) {
+	var header struct {
+		ID   [4]byte
+		Size uint32
+	}
+	err := binary.Read(r.reader, binary.LittleEndian, &header)
+	if errors.Is(err, io.EOF) {
+		return "", 0, io.EOF
+	}
+	if err != nil {
+		return "", 0, &FormatError{"truncated chunk header"}
+	}
+	r.DataOffset += 8
+	return string(header.ID[:]), header.Size, nil
+}
+
+// chunkData reads the body of a chunk and its pad byte.
+func (r *Reader) chunkData(id string, size uint32) ([]byte, error) {
+	if size > MAX_CHUNK {
+		return nil, &FormatError{fmt.Sprintf("%q chunk of %d bytes is too large", id, size)}
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return nil, &FormatError{fmt.Sprintf("%q chunk ends early", id)}
+	}
+	// Chunks are padded to an even size
+	if size%2 != 0 {
+		r.reader.ReadByte()
+	}
+	r.DataOffset += int64(size + size%2)
+	return data, nil
+}
+
+// chunk reads the next whole chunk after the samples.
+func (r *Reader) chunk() (string, []byte, error) {
+	id, size, err := r.chunkHeader()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := r.chunkData(id, size)
+	return id, data, err
+}
+
+// Writer streams samples into a WAV file. The number of samples goes in the
+// header, so it has to be known up front.
+type Writer struct {
+	writer  *bufio.Writer
+	extra   []Chunk
+	samples int
+	written int
+}
+
+// NewWriter writes the RIFF header, fmt chunk, the extra chunks that aren't
+// AfterData, and the header of a data chunk holding samples samples.
+func NewWriter(w io.Writer, format Format, samples int, extra []Chunk) (*Writer, error) {
+	stream := &Writer{writer: bufio.NewWriter(w), extra: extra, samples: samples}
+
+	size := 4 + 8 + 16 + 8 + 2*samples
+	for _, c := range extra {
+		size += 8 + len(c.Data) + len(c.Data)%2
+	}
+
+	le := binary.LittleEndian
+	header := []byte("RIFF\x00\x00\x00\x00WAVE")
+	le.PutUint32(header[4:], uint32(size))
+	stream.writer.Write(header)
+
+	var fmtData [16]byte
+	le.PutUint16(fmtData[0:], FORMAT_PCM)
+	le.PutUint16(fmtData[2:], uint16(format.Channels))
+	le.PutUint32(fmtData[4:], uint32(format.SampleRate))
+	le.PutUint32(fmtData[8:], uint32(format.SampleRate*format.Channels*2))
+	le.PutUint16(fmtData[12:], uint16(format.Channels*2))
+	le.PutUint16(fmtData[14:], 16)
+	stream.writeChunk("fmt ", fmtData[:])
+
+	for _, c := range extra {
+		if !c.AfterData {
+			stream.writeChunk(c.ID, c.Data)
+		}
+	}
+
+	var dataHeader [8]byte
+	copy(dataHeader[:], "data")
+	le.PutUint32(dataHeader[4:], uint32(2*samples))
+	_, err := stream.writer.Write(dataHeader[:])
+	return stream, err
+}
+
+// Write appends samples to the data chunk.
+func (w *Writer) Write(samples []int16) error {
+	if w.written+len(samples) > w.samples {
+		return fmt.Errorf("wav: writing more than the %d samples in the header", w.samples)
+	}
+	w.written += len(samples)
+	return binary.Write(w.writer, binary.LittleEndian, samples)
+}
+
+// Close writes the AfterData chunks and flushes. It doesn't close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if w.written != w.samples {
+		return fmt.Errorf("wav: wrote %d of the %d samples in the header", w.written, w.samples)
+	}
+	for _, c := range w.extra {
+		if c.AfterData {
+			w.writeChunk(c.ID, c.Data)
+		}
+	}
+	return w.writer.Flush()
+}
+
+func (w *Writer) writeChunk(id string, data []byte) {
+	var header [8]byte
+	copy(header[:], id)
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(data)))
+	w.writer.Write(header[:])
+	w.writer.Write(data)
+	if len(data)%2 != 0 {
+		w.writer.WriteByte(0)
+	}
+}
@@ -0,0 +1,71 @@
+package wav
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	info := append([]byte("INFO"), chunk("INAM", []byte("Hum"))...)
+	extra := []Chunk{{ID: "LIST", Data: info}}
+	samples := Sine(440, 0.1, 8000, 10000)
+
+	// Write in uneven blocks
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Format{Channels: 1, SampleRate: 8000, BitsPerSample: 16}, len(samples), extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rest := samples; len(rest) > 0; {
+		n := min(len(rest), 333)
+		if err := w.Write(rest[:n]); err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != len(samples) || r.Info["INAM"] != "Hum" {
+		t.Errorf("len %d, info %q", r.Len(), r.Info)
+	}
+	if want := int64(HEADER_SIZE + 8 + len(info) + len(info)%2); r.DataOffset != want {
+		t.Errorf("data offset %d, want %d", r.DataOffset, want)
+	}
+
+	var got []int16
+	block := make([]int16, 256)
+	for {
+		n, err := r.Read(block)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, block[:n]...)
+	}
+	if !slices.Equal(got, samples) {
+		t.Error("samples changed")
+	}
+}
+
+func TestWriterCount(t *testing.T) {
+	format := Format{Channels: 1, SampleRate: 8000, BitsPerSample: 16}
+	w, _ := NewWriter(io.Discard, format, 2, nil)
+	if err := w.Write([]int16{1, 2, 3}); err == nil {
+		t.Error("writing 3 of 2 samples: want error")
+	}
+	w.Write([]int16{1})
+	if err := w.Close(); err == nil {
+		t.Error("closing after 1 of 2 samples: want error")
+	}
+}
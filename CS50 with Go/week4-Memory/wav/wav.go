@@ -0,0 +1,244 @@
+// Package wav reads and writes 16-bit PCM WAV files by walking their RIFF
+// chunks (fmt, data, LIST, ...) instead of assuming the canonical 44-byte
+// header. Chunks it doesn't need are kept and written back unchanged.
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// HEADER_SIZE is the number of bytes before the samples in a canonical WAV
+// (RIFF header, 16-byte fmt chunk, data chunk header), which is what Encode
+// writes when there are no extra chunks.
+const HEADER_SIZE = 44
+
+// MAX_SAMPLES guards Decode against data chunks that claim absurd sizes:
+// 2^28 samples is 512 MiB, 46 minutes of 48 kHz stereo. NewReader streams,
+// so it takes any size; the samples just have to be there when read.
+const MAX_SAMPLES = 1 << 28
+
+// MAX_CHUNK is the largest chunk other than data that's read into memory.
+// They're tags and cue points, a few KiB at most.
+const MAX_CHUNK = 1 << 24
+
+// Audio format codes from the fmt chunk.
+const (
+	FORMAT_PCM        = 1
+	FORMAT_FLOAT      = 3
+	FORMAT_ALAW       = 6
+	FORMAT_MULAW      = 7
+	FORMAT_EXTENSIBLE = 0xfffe
+)
+
+// Errors returned by Decode wrap one of these, like the bmp package.
+var (
+	ErrUnsupported = errors.New("wav: unsupported format")
+	ErrMalformed   = errors.New("wav: malformed file")
+)
+
+// UnsupportedError is a valid WAV this package can't handle (not 16-bit PCM).
+type UnsupportedError struct {
+	Reason string
+}
+
+func (e *UnsupportedError) Error() string { return "wav: unsupported " + e.Reason }
+func (e *UnsupportedError) Unwrap() error { return ErrUnsupported }
+
+// FormatError is a file that isn't a well-formed WAV.
+type FormatError struct {
+	Reason string
+}
+
+func (e *FormatError) Error() string { return "wav: invalid format: " + e.Reason }
+func (e *FormatError) Unwrap() error { return ErrMalformed }
+
+// Format is the decoded fmt chunk.
+type Format struct {
+	Channels      int
+	SampleRate    int
+	BitsPerSample int
+}
+
+// Chunk is a RIFF chunk this package doesn't interpret, e.g. LIST or cue.
+type Chunk struct {
+	ID        string
+	Data      []byte
+	AfterData bool // it came after the data chunk in the file
+}
+
+// Audio is a decoded WAV file.
+type Audio struct {
+	Format
+
+	// Samples are interleaved when there is more than one channel:
+	// left, right, left, right, ...
+	Samples []int16
+
+	// Info holds the LIST/INFO tags (INAM = title, IART = artist, ...).
+	// It's for reading only; Encode writes the LIST chunk as it was.
+	Info map[string]string
+
+	// Extra are the chunks other than fmt and data, in file order.
+	Extra []Chunk
+}
+
+// Frames returns the number of sample frames (one sample per channel).
+func (a *Audio) Frames() int {
+	if a.Channels == 0 {
+		return 0
+	}
+	return len(a.Samples) / a.Channels
+}
+
+// Duration returns how long the audio plays.
+func (a *Audio) Duration() time.Duration {
+	if a.SampleRate == 0 {
+		return 0
+	}
+	return time.Duration(a.Frames()) * time.Second / time.Duration(a.SampleRate)
+}
+
+// Decode reads a whole 16-bit PCM WAV file into memory. Use NewReader to
+// stream one that may not fit.
+func Decode(r io.Reader) (*Audio, error) {
+	stream, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if stream.Len() > MAX_SAMPLES {
+		return nil, &FormatError{fmt.Sprintf("%d samples is too many", stream.Len())}
+	}
+	a := &Audio{Format: stream.Format, Info: stream.Info, Extra: stream.Extra}
+	a.Samples = make([]int16, stream.Len())
+	if _, err := stream.Read(a.Samples); err != nil && len(a.Samples) > 0 {
+		return nil, err
+	}
+
+	// Chunks after the samples (cue points, a second LIST, ...)
+	for {
+		id, data, err := stream.chunk()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if id == "LIST" {
+			parseInfo(data, a.Info)
+		}
+		a.Extra = append(a.Extra, Chunk{ID: id, Data: data, AfterData: true})
+	}
+	return a, nil
+}
+
+// parseFormat checks the fmt chunk describes 16-bit PCM.
+func parseFormat(data []byte) (Format, error) {
+	var f Format
+	if len(data) < 16 {
+		return f, &FormatError{fmt.Sprintf("fmt chunk of %d bytes", len(data))}
+	}
+	code := binary.LittleEndian.Uint16(data[0:])
+	f.Channels = int(binary.LittleEndian.Uint16(data[2:]))
+	f.SampleRate = int(binary.LittleEndian.Uint32(data[4:]))
+	blockAlign := int(binary.LittleEndian.Uint16(data[12:]))
+	f.BitsPerSample = int(binary.LittleEndian.Uint16(data[14:]))
+
+	// WAVE_FORMAT_EXTENSIBLE keeps the real format in a sub-format GUID
+	// whose first two bytes are the old format code.
+	if code == FORMAT_EXTENSIBLE && len(data) >= 26 {
+		code = binary.LittleEndian.Uint16(data[24:])
+	}
+	switch code {
+	case FORMAT_PCM:
+	case FORMAT_FLOAT:
+		return f, &UnsupportedError{"IEEE float samples (only PCM)"}
+	case FORMAT_ALAW, FORMAT_MULAW:
+		return f, &UnsupportedError{"A-law/mu-law samples (only PCM)"}
+	default:
+		return f, &UnsupportedError{fmt.Sprintf("audio format %#x (only PCM)", code)}
+	}
+
+	if f.BitsPerSample != 16 {
+		return f, &UnsupportedError{fmt.Sprintf("%d-bit samples (only 16-bit)", f.BitsPerSample)}
+	}
+	if f.Channels < 1 || f.SampleRate < 1 {
+		return f, &FormatError{fmt.Sprintf("%d channels at %d Hz", f.Channels, f.SampleRate)}
+	}
+	if blockAlign != f.Channels*2 {
+		return f, &FormatError{fmt.Sprintf("block align %d for %d channels", blockAlign, f.Channels)}
+	}
+	return f, nil
+}
+
+// parseInfo reads the tags of a LIST chunk of type INFO.
+func parseInfo(data []byte, info map[string]string) {
+	if len(data) < 4 || string(data[:4]) != "INFO" {
+		return
+	}
+	for rest := data[4:]; len(rest) >= 8; {
+		id := string(rest[:4])
+		size := int(binary.LittleEndian.Uint32(rest[4:]))
+		if 8+size > len(rest) {
+			return
+		}
+		value := rest[8 : 8+size]
+		for len(value) > 0 && value[len(value)-1] == 0 {
+			value = value[:len(value)-1] // NUL-terminated
+		}
+		info[id] = string(value)
+		rest = rest[8+size+size%2:]
+	}
+}
+
+// Encode writes a as 16-bit PCM: RIFF header, fmt, the extra chunks that
+// came before the data, data, then the rest.
+func Encode(w io.Writer, a *Audio) error {
+	stream, err := NewWriter(w, a.Format, len(a.Samples), a.Extra)
+	if err != nil {
+		return err
+	}
+	if err := stream.Write(a.Samples); err != nil {
+		return err
+	}
+	return stream.Close()
+}
+
+// Write writes samples as a canonical 44-byte-header WAV. With more than one
+// channel, samples are interleaved (left, right, ...).
+func Write(w io.Writer, sampleRate, channels int, samples []int16) error {
+	return Encode(w, &Audio{
+		Format:  Format{Channels: channels, SampleRate: sampleRate, BitsPerSample: 16},
+		Samples: samples,
+	})
+}
+
+// Scale multiplies a sample by factor. Like the C version the result is
+// truncated toward zero, but it's clipped to the int16 range instead of
+// wrapping around (which turns loud into noise).
+func Scale(sample int16, factor float64) int16 {
+	v := float64(sample) * factor
+	switch {
+	case v > math.MaxInt16:
+		return math.MaxInt16
+	case v < math.MinInt16:
+		return math.MinInt16
+	}
+	return int16(v)
+}
+
+// Sine returns a mono sine wave: seconds of frequency Hz at sampleRate, with
+// peak amplitude. Test fixtures and demos use it instead of shipping audio.
+func Sine(frequency, seconds float64, sampleRate int, amplitude int16) []int16 {
+	samples := make([]int16, int(seconds*float64(sampleRate)))
+	for i := range samples {
+		t := float64(i) / float64(sampleRate)
+		samples[i] = int16(math.Round(float64(amplitude) * math.Sin(2*math.Pi*frequency*t)))
+	}
+	return samples
+}
@@ -0,0 +1,232 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		sample int16
+		factor float64
+		want   int16
+	}{
+		{1000, 2, 2000},
+		{1000, 0.5, 500},
+		{-1001, 0.5, -500}, // truncated toward zero, like C
+		{1001, 0.5, 500},
+		{20000, 2, math.MaxInt16},
+		{-20000, 2, math.MinInt16},
+		{math.MinInt16, -1, math.MaxInt16},
+		{123, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := Scale(tt.sample, tt.factor); got != tt.want {
+			t.Errorf("Scale(%d, %v) = %d, want %d", tt.sample, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestWriteAndDecode(t *testing.T) {
+	samples := Sine(440, 0.01, 44100, 10000)
+	if len(samples) != 441 {
+		t.Fatalf("%d samples, want 441", len(samples))
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, 44100, 1, samples); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != HEADER_SIZE+2*len(samples) {
+		t.Fatalf("wrote %d bytes", buf.Len())
+	}
+	header := buf.Bytes()[:HEADER_SIZE]
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" || string(header[36:40]) != "data" {
+		t.Errorf("header = %q", header)
+	}
+
+	a, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SampleRate != 44100 || a.Channels != 1 || a.BitsPerSample != 16 {
+		t.Errorf("format = %+v", a.Format)
+	}
+	if a.Duration() != 10*time.Millisecond {
+		t.Errorf("duration %v", a.Duration())
+	}
+	if !slices.Equal(a.Samples, samples) {
+		t.Error("samples changed")
+	}
+}
+
+// chunk builds one RIFF chunk, padded to an even size.
+func chunk(id string, data []byte) []byte {
+	out := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(data)))
+	out = append(out, data...)
+	if len(data)%2 != 0 {
+		out = append(out, 0)
+	}
+	return out
+}
+
+// riff wraps chunks in a RIFF/WAVE header.
+func riff(chunks ...[]byte) []byte {
+	body := []byte("WAVE")
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	return chunk("RIFF", body)
+}
+
+// fmtChunk is a fmt chunk with the given format code, channels and bit depth.
+func fmtChunk(code, channels, bits int) []byte {
+	data := make([]byte, 16)
+	binary.LittleEndian.PutUint16(data[0:], uint16(code))
+	binary.LittleEndian.PutUint16(data[2:], uint16(channels))
+	binary.LittleEndian.PutUint32(data[4:], 8000)
+	binary.LittleEndian.PutUint32(data[8:], uint32(8000*channels*bits/8))
+	binary.LittleEndian.PutUint16(data[12:], uint16(channels*bits/8))
+	binary.LittleEndian.PutUint16(data[14:], uint16(bits))
+	return chunk("fmt ", data)
+}
+
+func TestDecodeChunks(t *testing.T) {
+	// What editors like Audacity write: a LIST/INFO chunk (with an odd-sized
+	// tag that needs padding) between fmt and data, and a chunk after data.
+	info := append([]byte("INFO"), chunk("INAM", []byte("Beep\x00"))...)
+	info = append(info, chunk("IART", []byte("CS50\x00"))...)
+	file := riff(
+		fmtChunk(FORMAT_PCM, 2, 16),
+		chunk("LIST", info),
+		chunk("data", []byte{1, 0, 2, 0, 3, 0, 0xff, 0xff}),
+		chunk("cue ", []byte{9, 9, 9}),
+	)
+
+	a, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Channels != 2 || a.SampleRate != 8000 {
+		t.Errorf("format = %+v", a.Format)
+	}
+	if !slices.Equal(a.Samples, []int16{1, 2, 3, -1}) || a.Frames() != 2 {
+		t.Errorf("samples = %v, %d frames", a.Samples, a.Frames())
+	}
+	if a.Info["INAM"] != "Beep" || a.Info["IART"] != "CS50" {
+		t.Errorf("info = %q", a.Info)
+	}
+	if len(a.Extra) != 2 || a.Extra[0].ID != "LIST" || a.Extra[0].AfterData || a.Extra[1].ID != "cue " || !a.Extra[1].AfterData {
+		t.Errorf("extra = %+v", a.Extra)
+	}
+
+	// Encoding writes the same file back
+	var out bytes.Buffer
+	if err := Encode(&out, a); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), file) {
+		t.Errorf("round trip:\n got %q\nwant %q", out.Bytes(), file)
+	}
+}
+
+func TestDecodeExtensible(t *testing.T) {
+	// WAVE_FORMAT_EXTENSIBLE with the PCM sub-format
+	data := make([]byte, 40)
+	copy(data, fmtChunk(FORMAT_EXTENSIBLE, 1, 16)[8:])
+	binary.LittleEndian.PutUint16(data[16:], 22)
+	binary.LittleEndian.PutUint16(data[24:], FORMAT_PCM)
+	file := riff(chunk("fmt ", data), chunk("data", []byte{7, 0}))
+
+	a, err := Decode(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(a.Samples, []int16{7}) {
+		t.Errorf("samples = %v", a.Samples)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	samples := chunk("data", []byte{1, 0})
+	tests := []struct {
+		name string
+		file []byte
+		want error
+	}{
+		{"empty", nil, ErrMalformed},
+		{"not riff", append([]byte("RIFX\x00\x00\x00\x00WAVE"), samples...), ErrMalformed},
+		{"not wave", chunk("RIFF", []byte("AVI ")), ErrMalformed},
+		{"no fmt", riff(samples), ErrMalformed},
+		{"no data", riff(fmtChunk(FORMAT_PCM, 1, 16)), ErrMalformed},
+		{"short fmt", riff(chunk("fmt ", make([]byte, 8)), samples), ErrMalformed},
+		{"truncated data", riff(fmtChunk(FORMAT_PCM, 1, 16), chunk("data", make([]byte, 8)))[:40], ErrMalformed},
+		{"odd data", riff(fmtChunk(FORMAT_PCM, 1, 16), chunk("data", []byte{1, 2, 3})), ErrMalformed},
+		{"no channels", riff(fmtChunk(FORMAT_PCM, 0, 16), samples), ErrMalformed},
+		{"float", riff(fmtChunk(FORMAT_FLOAT, 1, 32), samples), ErrUnsupported},
+		{"mu-law", riff(fmtChunk(FORMAT_MULAW, 1, 8), samples), ErrUnsupported},
+		{"8-bit", riff(fmtChunk(FORMAT_PCM, 1, 8), samples), ErrUnsupported},
+		{"24-bit", riff(fmtChunk(FORMAT_PCM, 2, 24), samples), ErrUnsupported},
+	}
+	for _, tt := range tests {
+		_, err := Decode(bytes.NewReader(tt.file))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// header is a chunk header claiming size bytes, without them.
+func header(id string, size uint32) []byte {
+	out := append([]byte(id), 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(out[4:], size)
+	return out
+}
+
+// A 44-byte file can claim 4 GiB; it's refused before anything that size
+// is allocated.
+func TestDecodeTooLarge(t *testing.T) {
+	tests := []struct {
+		name string
+		file []byte
+	}{
+		{"huge data", riff(fmtChunk(FORMAT_PCM, 1, 16), header("data", 0xfffffffe))},
+		{"data just over", riff(fmtChunk(FORMAT_PCM, 1, 16), header("data", 2*MAX_SAMPLES+2))},
+		{"huge LIST", riff(fmtChunk(FORMAT_PCM, 1, 16), header("LIST", 0xffffffff))},
+		{"huge fmt", riff(header("fmt ", MAX_CHUNK+1))},
+	}
+	for _, tt := range tests {
+		_, err := Decode(bytes.NewReader(tt.file))
+		var formatErr *FormatError
+		if !errors.As(err, &formatErr) || !strings.Contains(err.Error(), "too") {
+			t.Errorf("%s: err = %v, want a FormatError for the size", tt.name, err)
+		}
+	}
+
+	// Streaming doesn't allocate the samples, so any size is fine until
+	// they turn out not to be there.
+	r, err := NewReader(bytes.NewReader(riff(fmtChunk(FORMAT_PCM, 1, 16), header("data", 0xfffffffe))))
+	if err != nil || r.Len() != 0x7fffffff {
+		t.Fatalf("NewReader = %v, %v", r, err)
+	}
+	if _, err := r.Read(make([]int16, 8)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Read past the end: err = %v", err)
+	}
+}
+
+func TestSinePeak(t *testing.T) {
+	peak := int16(0)
+	for _, s := range Sine(100, 0.1, 8000, 12000) {
+		peak = max(peak, s)
+	}
+	if peak != 12000 {
+		t.Errorf("peak %d, want 12000", peak)
+	}
+}
@@ -0,0 +1,165 @@
+// Package bst is the (unbalanced) binary search tree from the week 5
+// lecture: smaller keys go left, bigger keys go right. Nothing keeps it
+// balanced, so inserting sorted keys turns it into a linked list.
+package bst
+
+import "cmp"
+
+// node is one key/value pair and its two subtrees.
+type node[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	left  *node[K, V]
+	right *node[K, V]
+}
+
+// Tree maps keys to values.
+type Tree[K cmp.Ordered, V any] struct {
+	root *node[K, V]
+	size int
+}
+
+// New returns an empty tree.
+func New[K cmp.Ordered, V any]() *Tree[K, V] {
+	return &Tree[K, V]{}
+}
+
+// Insert stores value under key, replacing the old value if key exists.
+func (t *Tree[K, V]) Insert(key K, value V) {
+	// Walk down with a pointer to the link we may have to fill in, so the
+	// root needs no special case (and no recursion for deep trees).
+	link := &t.root
+	for *link != nil {
+		switch c := cmp.Compare(key, (*link).key); {
+		case c < 0:
+			link = &(*link).left
+		case c > 0:
+			link = &(*link).right
+		default:
+			(*link).value = value
+			return
+		}
+	}
+	*link = &node[K, V]{key: key, value: value}
+	t.size++
+}
+
+// Search returns the value stored under key.
+func (t *Tree[K, V]) Search(key K) (V, bool) {
+	cursor := t.root
+	for cursor != nil {
+		switch c := cmp.Compare(key, cursor.key); {
+		case c < 0:
+			cursor = cursor.left
+		case c > 0:
+			cursor = cursor.right
+		default:
+			return cursor.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was in the tree.
+func (t *Tree[K, V]) Delete(key K) bool {
+	link := &t.root
+	for *link != nil {
+		n := *link
+		switch c := cmp.Compare(key, n.key); {
+		case c < 0:
+			link = &n.left
+			continue
+		case c > 0:
+			link = &n.right
+			continue
+		}
+
+		switch {
+		case n.left == nil:
+			*link = n.right
+		case n.right == nil:
+			*link = n.left
+		default:
+			// Two children: replace n with its successor, the smallest key
+			// of the right subtree, and unlink the successor instead.
+			succLink := &n.right
+			for (*succLink).left != nil {
+				succLink = &(*succLink).left
+			}
+			succ := *succLink
+			*succLink = succ.right
+			succ.left, succ.right = n.left, n.right
+			*link = succ
+		}
+		t.size--
+		return true
+	}
+	return false
+}
+
+// Len returns the number of keys.
+func (t *Tree[K, V]) Len() int {
+	return t.size
+}
+
+// Height returns the number of nodes on the longest root-to-leaf path:
+// about log2(n) when balanced, n in the worst case.
+func (t *Tree[K, V]) Height() int {
+	// Level-order walk instead of recursion, a degenerate tree is n deep.
+	height := 0
+	level := []*node[K, V]{}
+	if t.root != nil {
+		level = append(level, t.root)
+	}
+	for len(level) > 0 {
+		height++
+		var next []*node[K, V]
+		for _, n := range level {
+			if n.left != nil {
+				next = append(next, n.left)
+			}
+			if n.right != nil {
+				next = append(next, n.right)
+			}
+		}
+		level = next
+	}
+	return height
+}
+
+// Keys returns every key in ascending order (in-order traversal).
+func (t *Tree[K, V]) Keys() []K {
+	keys := make([]K, 0, t.size)
+	var stack []*node[K, V]
+	cursor := t.root
+	for cursor != nil || len(stack) > 0 {
+		for cursor != nil {
+			stack = append(stack, cursor)
+			cursor = cursor.left
+		}
+		cursor = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		keys = append(keys, cursor.key)
+		cursor = cursor.right
+	}
+	return keys
+}
+
+// Shape is a tree's structure without its values, for drawing it.
+type Shape[K cmp.Ordered] struct {
+	Key         K
+	Left, Right *Shape[K]
+}
+
+// Shape returns the tree's structure, or nil when it's empty.
+func (t *Tree[K, V]) Shape() *Shape[K] {
+	return shapeOf(t.root)
+}
+
+func shapeOf[K cmp.Ordered, V any](n *node[K, V]) *Shape[K] {
+	if n == nil {
+		return nil
+	}
+	return &Shape[K]{Key: n.key, Left: shapeOf(n.left), Right: shapeOf(n.right)}
+}
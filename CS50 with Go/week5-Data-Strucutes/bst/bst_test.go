@@ -0,0 +1,127 @@
+package bst
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// draw writes a shape as "key(left right)", with "-" for a missing child.
+func draw(s *Shape[int]) string {
+	if s == nil {
+		return "-"
+	}
+	if s.Left == nil && s.Right == nil {
+		return fmt.Sprint(s.Key)
+	}
+	return fmt.Sprintf("%d(%s %s)", s.Key, draw(s.Left), draw(s.Right))
+}
+
+func build(keys ...int) *Tree[int, string] {
+	t := New[int, string]()
+	for _, k := range keys {
+		t.Insert(k, fmt.Sprint("v", k))
+	}
+	return t
+}
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		keys   []int
+		shape  string
+		height int
+	}{
+		{nil, "-", 0},
+		{[]int{5}, "5", 1},
+		{[]int{5, 3}, "5(3 -)", 2},
+		{[]int{5, 7}, "5(- 7)", 2},
+		{[]int{5, 3, 7, 1, 4}, "5(3(1 4) 7)", 3},
+		{[]int{5, 3, 5, 3}, "5(3 -)", 2},           // repeats replace, not add
+		{[]int{1, 2, 3, 4}, "1(- 2(- 3(- 4)))", 4}, // sorted input: a list
+	}
+	for _, tt := range tests {
+		tree := build(tt.keys...)
+		if got := draw(tree.Shape()); got != tt.shape {
+			t.Errorf("Insert(%v) shape = %s, want %s", tt.keys, got, tt.shape)
+		}
+		if tree.Height() != tt.height {
+			t.Errorf("Insert(%v) Height() = %d, want %d", tt.keys, tree.Height(), tt.height)
+		}
+	}
+
+	tree := build(5, 3)
+	tree.Insert(3, "new")
+	if v, ok := tree.Search(3); v != "new" || !ok || tree.Len() != 2 {
+		t.Errorf("Insert again: Search(3) = %q, %v with Len() %d", v, ok, tree.Len())
+	}
+	if v, ok := tree.Search(4); v != "" || ok {
+		t.Errorf("Search(4) = %q, %v", v, ok)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	// 5(3(1 4) 8(6(- 7) 9)): 1, 4, 7 and 9 are leaves, 6 has one child,
+	// 3, 8 and 5 have two.
+	keys := []int{5, 3, 8, 1, 4, 6, 9, 7}
+	tests := []struct {
+		delete int
+		found  bool
+		shape  string
+	}{
+		{1, true, "5(3(- 4) 8(6(- 7) 9))"},  // no children
+		{9, true, "5(3(1 4) 8(6(- 7) -))"},  // no children, on the right
+		{6, true, "5(3(1 4) 8(7 9))"},       // one child moves up
+		{3, true, "5(4(1 -) 8(6(- 7) 9))"},  // two children: successor 4 is a leaf
+		{8, true, "5(3(1 4) 9(6(- 7) -))"},  // two children: successor 9
+		{5, true, "6(3(1 4) 8(7 9))"},       // the root: successor 6 leaves 7 behind
+		{2, false, "5(3(1 4) 8(6(- 7) 9))"}, // not there
+	}
+	for _, tt := range tests {
+		tree := build(keys...)
+		if found := tree.Delete(tt.delete); found != tt.found {
+			t.Errorf("Delete(%d) = %v, want %v", tt.delete, found, tt.found)
+		}
+		if got := draw(tree.Shape()); got != tt.shape {
+			t.Errorf("Delete(%d) shape = %s, want %s", tt.delete, got, tt.shape)
+		}
+
+		want := slices.Clone(keys)
+		if tt.found {
+			want = slices.DeleteFunc(want, func(k int) bool { return k == tt.delete })
+		}
+		slices.Sort(want)
+		if got := tree.Keys(); !slices.Equal(got, want) || tree.Len() != len(want) {
+			t.Errorf("Delete(%d) Keys() = %v with Len() %d, want %v", tt.delete, got, tree.Len(), want)
+		}
+		for _, k := range want {
+			if v, ok := tree.Search(k); !ok || v != fmt.Sprint("v", k) {
+				t.Errorf("Delete(%d): Search(%d) = %q, %v", tt.delete, k, v, ok)
+			}
+		}
+	}
+
+	// The only node, then nothing
+	tree := build(1)
+	if !tree.Delete(1) || tree.Shape() != nil || tree.Len() != 0 || tree.Delete(1) {
+		t.Errorf("deleting the only key left %s", draw(tree.Shape()))
+	}
+}
+
+func TestKeys(t *testing.T) {
+	tests := [][]int{
+		nil,
+		{1},
+		{5, 3, 8, 1, 4, 6, 9, 7},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1}, // a list leaning left
+		{4, 2, 6, 2, 4, 6},
+	}
+	for _, keys := range tests {
+		want := slices.Compact(slices.Sorted(slices.Values(keys)))
+		if want == nil {
+			want = []int{}
+		}
+		if got := build(keys...).Keys(); !slices.Equal(got, want) {
+			t.Errorf("Keys() after Insert(%v) = %v, want %v", keys, got, want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module bst
+
+go 1.24.4
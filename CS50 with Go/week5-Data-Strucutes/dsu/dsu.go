@@ -0,0 +1,81 @@
+// Package dsu is a disjoint-set union (union-find): it tracks which of n
+// elements are in the same group, and merges groups, in almost O(1) each.
+//
+// Every group is a tree and its root is the group's representative. Two
+// tricks keep the trees flat:
+//   - union by rank: hang the shorter tree under the taller one
+//   - path compression: after Find, point every visited node at the root
+//
+// Together they make any sequence of m operations cost O(m α(n)), where the
+// inverse Ackermann function α(n) is below 5 for any n that fits in memory.
+package dsu
+
+// DSU holds elements 0 .. n-1.
+type DSU struct {
+	parent []int
+	rank   []int // upper bound on the height of the tree under each root
+	sets   int
+}
+
+// New returns n elements, each in a group of its own.
+func New(n int) *DSU {
+	d := &DSU{parent: make([]int, n), rank: make([]int, n), sets: n}
+	for i := range d.parent {
+		d.parent[i] = i
+	}
+	return d
+}
+
+// Find returns the representative (root) of x's group.
+func (d *DSU) Find(x int) int {
+	// First pass: walk up to the root
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+
+	// Second pass: path compression, point everything on the way at the root
+	for d.parent[x] != root {
+		next := d.parent[x]
+		d.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union merges the groups of a and b. It returns false when they already
+// were in the same group, which is exactly when an edge a-b would close a cycle.
+func (d *DSU) Union(a, b int) bool {
+	rootA, rootB := d.Find(a), d.Find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	// Union by rank: the shorter tree goes under the taller one
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	d.sets--
+	return true
+}
+
+// Connected reports whether a and b are in the same group.
+func (d *DSU) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// Sets returns the number of separate groups.
+func (d *DSU) Sets() int {
+	return d.sets
+}
+
+// Len returns the number of elements.
+func (d *DSU) Len() int {
+	return len(d.parent)
+}
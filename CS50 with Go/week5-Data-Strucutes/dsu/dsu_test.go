@@ -0,0 +1,122 @@
+package dsu
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	d := New(6)
+	steps := []struct {
+		a, b   int
+		merged bool
+		sets   int
+	}{
+		{0, 1, true, 5},
+		{2, 3, true, 4},
+		{1, 0, false, 4}, // already together: would be a cycle
+		{1, 3, true, 3},
+		{0, 2, false, 3},
+		{4, 4, false, 3},
+	}
+	for _, s := range steps {
+		if got := d.Union(s.a, s.b); got != s.merged {
+			t.Fatalf("Union(%d, %d) = %v, want %v", s.a, s.b, got, s.merged)
+		}
+		if d.Sets() != s.sets {
+			t.Fatalf("after Union(%d, %d) Sets() = %d, want %d", s.a, s.b, d.Sets(), s.sets)
+		}
+	}
+
+	if !d.Connected(0, 3) || d.Connected(0, 4) || d.Connected(4, 5) {
+		t.Fatal("Connected gives the wrong groups")
+	}
+}
+
+// naive is union-find without union by rank or path compression, to show
+// what the two tricks buy.
+type naive []int
+
+func newNaive(n int) naive {
+	p := make(naive, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+func (p naive) find(x int) int {
+	for p[x] != x {
+		x = p[x]
+	}
+	return x
+}
+
+func (p naive) union(a, b int) {
+	p[p.find(a)] = p.find(b)
+}
+
+// chainOps is the worst case for the naive version: unions that build one
+// long chain, then finds from its far end.
+func chainOps(n int) [][2]int {
+	ops := make([][2]int, 0, 2*n)
+	for i := 0; i+1 < n; i++ {
+		ops = append(ops, [2]int{i, i + 1})
+	}
+	return ops
+}
+
+// Divide ns/op by n: with both tricks the cost per operation stays flat as
+// n grows, that's the amortized α(n) bound showing up.
+func BenchmarkChain(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		ops := chainOps(n)
+
+		b.Run(fmt.Sprintf("dsu/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d := New(n)
+				for _, op := range ops {
+					d.Union(op[0], op[1])
+				}
+				for x := 0; x < n; x++ {
+					d.Find(x)
+				}
+			}
+		})
+		if n > 10_000 {
+			continue // the naive version is quadratic here
+		}
+		b.Run(fmt.Sprintf("naive/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p := newNaive(n)
+				for _, op := range ops {
+					p.union(op[0], op[1])
+				}
+				for x := 0; x < n; x++ {
+					p.find(x)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRandomUnions(b *testing.B) {
+	for _, n := range []int{1_000, 100_000, 1_000_000} {
+		rng := rand.New(rand.NewSource(1))
+		pairs := make([][2]int, n)
+		for i := range pairs {
+			pairs[i] = [2]int{rng.Intn(n), rng.Intn(n)}
+		}
+
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				d := New(n)
+				for _, p := range pairs {
+					d.Union(p[0], p[1])
+					d.Connected(p[1], p[0])
+				}
+			}
+		})
+	}
+}
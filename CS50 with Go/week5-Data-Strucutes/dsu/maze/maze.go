@@ -0,0 +1,90 @@
+// Random maze generator: randomized Kruskal's algorithm on the dsu package.
+// Knock down walls in random order, but only between cells that aren't
+// connected yet, so the maze never gets a loop and every cell is reachable.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"dsu"
+	"rngutil"
+)
+
+// wall sits between cell a and the cell to its right (or below it).
+type wall struct {
+	a, b  int
+	right bool
+}
+
+func main() {
+	width := flag.Int("w", 12, "maze width in cells")
+	height := flag.Int("h", 8, "maze height in cells")
+	seed := flag.Int64("seed", 0, "random seed (0 = random)")
+	flag.Parse()
+
+	if *width < 1 || *height < 1 {
+		fmt.Println("Usage: ./maze [-w WIDTH] [-h HEIGHT] [-seed N]")
+		os.Exit(1)
+	}
+	rng := rngutil.New(seed)
+
+	// Every wall inside the grid
+	cell := func(x, y int) int { return y*(*width) + x }
+	var walls []wall
+	for y := 0; y < *height; y++ {
+		for x := 0; x < *width; x++ {
+			if x+1 < *width {
+				walls = append(walls, wall{cell(x, y), cell(x+1, y), true})
+			}
+			if y+1 < *height {
+				walls = append(walls, wall{cell(x, y), cell(x, y+1), false})
+			}
+		}
+	}
+	rng.Shuffle(len(walls), func(i, j int) { walls[i], walls[j] = walls[j], walls[i] })
+
+	// Open a wall only if it joins two separate regions (no cycles)
+	sets := dsu.New(*width * *height)
+	openRight := make([]bool, *width**height)
+	openDown := make([]bool, *width**height)
+	kept := 0
+	for _, w := range walls {
+		if !sets.Union(w.a, w.b) {
+			kept++ // both sides already connected: removing it would make a loop
+			continue
+		}
+		if w.right {
+			openRight[w.a] = true
+		} else {
+			openDown[w.a] = true
+		}
+	}
+
+	// Draw it
+	var b strings.Builder
+	b.WriteString("+" + strings.Repeat("--+", *width) + "\n")
+	for y := 0; y < *height; y++ {
+		row, floor := "|", "+"
+		for x := 0; x < *width; x++ {
+			c := cell(x, y)
+			if openRight[c] {
+				row += "   "
+			} else {
+				row += "  |"
+			}
+			if openDown[c] {
+				floor += "  +"
+			} else {
+				floor += "--+"
+			}
+		}
+		b.WriteString(row + "\n" + floor + "\n")
+	}
+	fmt.Print(b.String())
+	fmt.Printf("seed %d: %d walls removed, %d kept to avoid loops, %d region(s)\n",
+		*seed, len(walls)-kept, kept, sets.Sets())
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
 	"strings"
+
+	"rngutil"
 )
 
 // RecipeComponent defines a node in out recipe's dependency tree.
@@ -28,11 +31,14 @@ const INDENT_LENGTH = 4
 func main() {
 	// [[just say hello.🤙]]
 	fmt.Println("hello, world")
-	// seed the random number generator to ensure different results on each run.
-	// Using the current time is a common practice for getting a unique seed.
+	// seed the random number generator: -seed N gives the same recipe each
+	// run, and without it it's a different one every time.
+	seed := flag.Int64("seed", 0, rngutil.SEED_USAGE)
+	flag.Parse()
+	rng := rngutil.New(seed)
 
 	// Generate the entire recipe structure recursively.
-	finalDish := CreateRecipe(COMPLEXITY)
+	finalDish := CreateRecipe(COMPLEXITY, rng)
 
 	// Traverse the generated structure and print it to the console.
 	PrintRecipe(finalDish, 0)
@@ -40,7 +46,7 @@ func main() {
 
 // CreateRecipe recursively builds a component and its dependendies based on the
 // specified complexity level.
-func CreateRecipe(complexity int) *RecipeComponent {
+func CreateRecipe(complexity int, rng *rand.Rand) *RecipeComponent {
 	// Allocate memory for a new component.
 	newComponent := &RecipeComponent{}
 
@@ -48,8 +54,8 @@ func CreateRecipe(complexity int) *RecipeComponent {
 	// if mad of ther, simpler components.
 	if complexity > 1 {
 		// Recursively create the two sub-components that make up the current one.
-		subComponent0 := CreateRecipe(complexity - 1)
-		subComponent1 := CreateRecipe(complexity - 1)
+		subComponent0 := CreateRecipe(complexity - 1, rng)
+		subComponent1 := CreateRecipe(complexity - 1, rng)
 		
 		// Assign the newly created children to the current component's SubComponents slice.
 		newComponent.SubComponents = append(newComponent.SubComponents, subComponent0, subComponent1)
@@ -62,7 +68,7 @@ func CreateRecipe(complexity int) *RecipeComponent {
 		newComponent.SubComponents= nil
 		
 		// Assign a random base ingredient from out predefined list.
-		newComponent.PrimaryIngredient = randomIngredient()
+		newComponent.PrimaryIngredient = randomIngredient(rng)
 	}
 
 	// Return the pointer to the fully constructed component.
@@ -96,7 +102,7 @@ func PrintRecipe(component *RecipeComponent, level int) {
 }
 
 // randomIngredient is a utility function that retuerns a random base ingredient.
-func randomIngredient() string {
+func randomIngredient(rng *rand.Rand) string {
 		ingredients := []string{"Flour", "Sugar", "Eggs", "Butter", "Chocolate"}
-		return ingredients[rand.Intn(len(ingredients))]
+		return ingredients[rng.Intn(len(ingredients))]
 }
\ No newline at end of file
@@ -0,0 +1,161 @@
+// Degrees of separation: how many movies apart are two actors?
+// (adapted from CS50 AI's "degrees", using the graph package's BFS)
+
+package main
+
+import (
+	"cs50"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"graph"
+)
+
+// vertex is a person or a movie. People and movies share one graph, so a path
+// always alternates person -> movie -> person and every two steps is one degree.
+type vertex struct {
+	isMovie bool
+	name    string // person's name or movie title
+}
+
+func main() {
+	if len(os.Args) > 2 {
+		fmt.Println("Usage: go run ./degrees [directory]")
+		os.Exit(1)
+	}
+	directory := "degrees/small"
+	if len(os.Args) == 2 {
+		directory = os.Args[1]
+	}
+
+	// Load data from files into memory
+	fmt.Println("Loading data...")
+	g, names, err := load(directory)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("Data loaded.")
+
+	source, ok := personID(names, g, cs50.GetString("Name: "))
+	if !ok {
+		fmt.Println("Person not found.")
+		os.Exit(1)
+	}
+	target, ok := personID(names, g, cs50.GetString("Name: "))
+	if !ok {
+		fmt.Println("Person not found.")
+		os.Exit(1)
+	}
+
+	path, ok := g.ShortestPath(source, target)
+	if !ok {
+		fmt.Println("Not connected.")
+		return
+	}
+
+	degrees := (len(path) - 1) / 2
+	fmt.Printf("%d degrees of separation.\n", degrees)
+	for i := 0; i+2 < len(path); i += 2 {
+		person1, _ := g.Node(path[i])
+		movie, _ := g.Node(path[i+1])
+		person2, _ := g.Node(path[i+2])
+		fmt.Printf("%d: %s and %s starred in %s\n", i/2+1, person1.name, person2.name, movie.name)
+	}
+}
+
+// load reads people.csv, movies.csv and stars.csv from directory into an
+// undirected graph. names maps a lowercase name to every person ID using it.
+func load(directory string) (*graph.Graph[string, vertex], map[string][]string, error) {
+	g := graph.NewUndirected[string, vertex]()
+	names := map[string][]string{}
+
+	people, err := readCSV(filepath.Join(directory, "people.csv"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range people {
+		id := "p" + row["id"]
+		g.AddNode(id, vertex{name: row["name"]})
+		key := strings.ToLower(row["name"])
+		names[key] = append(names[key], id)
+	}
+
+	movies, err := readCSV(filepath.Join(directory, "movies.csv"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range movies {
+		g.AddNode("m"+row["id"], vertex{isMovie: true, name: row["title"]})
+	}
+
+	stars, err := readCSV(filepath.Join(directory, "stars.csv"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, row := range stars {
+		person, movie := "p"+row["person_id"], "m"+row["movie_id"]
+		// Skip rows pointing at people or movies we don't know
+		if g.HasNode(person) && g.HasNode(movie) {
+			g.AddEdge(person, movie)
+		}
+	}
+	return g, names, nil
+}
+
+// readCSV returns every row of a CSV file keyed by its header.
+func readCSV(path string) ([]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// personID resolves a name to a node ID, asking which one is meant when
+// several people share the name.
+func personID(names map[string][]string, g *graph.Graph[string, vertex], name string) (string, bool) {
+	ids := names[strings.ToLower(name)]
+	switch len(ids) {
+	case 0:
+		return "", false
+	case 1:
+		return ids[0], true
+	}
+
+	fmt.Printf("Which '%s'?\n", name)
+	for _, id := range ids {
+		fmt.Printf("ID: %s, Name: %s\n", strings.TrimPrefix(id, "p"), name)
+	}
+	id := "p" + cs50.GetString("Intended Person ID: ")
+	for _, candidate := range ids {
+		if candidate == id {
+			return id, true
+		}
+	}
+	return "", false
+}
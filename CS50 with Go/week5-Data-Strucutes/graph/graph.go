@@ -0,0 +1,304 @@
+// Package graph stores nodes and the edges between them as adjacency lists.
+// Each node has an ID (K) and a payload (V), e.g. a person's name, and the
+// graph can be directed (tideman's "locked" pairs) or undirected (co-stars).
+package graph
+
+// Graph is an adjacency-list graph. Nodes and neighbours keep the order they
+// were added in so traversals always visit them the same way.
+type Graph[K comparable, V any] struct {
+	directed bool
+	payloads map[K]V
+	adj      map[K][]K
+	order    []K
+}
+
+// NewDirected returns an empty graph where AddEdge(a, b) only links a -> b.
+func NewDirected[K comparable, V any]() *Graph[K, V] {
+	return &Graph[K, V]{directed: true, payloads: make(map[K]V), adj: make(map[K][]K)}
+}
+
+// NewUndirected returns an empty graph where AddEdge(a, b) links both ways.
+func NewUndirected[K comparable, V any]() *Graph[K, V] {
+	return &Graph[K, V]{payloads: make(map[K]V), adj: make(map[K][]K)}
+}
+
+// Directed reports whether edges have a direction.
+func (g *Graph[K, V]) Directed() bool {
+	return g.directed
+}
+
+// AddNode adds id with its payload, or replaces the payload if id exists.
+func (g *Graph[K, V]) AddNode(id K, payload V) {
+	if _, ok := g.payloads[id]; !ok {
+		g.order = append(g.order, id)
+	}
+	g.payloads[id] = payload
+}
+
+// Node returns the payload of id and whether id is in the graph.
+func (g *Graph[K, V]) Node(id K) (V, bool) {
+	payload, ok := g.payloads[id]
+	return payload, ok
+}
+
+// HasNode reports whether id is in the graph.
+func (g *Graph[K, V]) HasNode(id K) bool {
+	_, ok := g.payloads[id]
+	return ok
+}
+
+// Nodes returns every node ID in insertion order.
+func (g *Graph[K, V]) Nodes() []K {
+	return append([]K(nil), g.order...)
+}
+
+// Len returns the number of nodes.
+func (g *Graph[K, V]) Len() int {
+	return len(g.order)
+}
+
+// AddEdge links from -> to (and to -> from when undirected). Missing nodes are
+// added with a zero payload. Adding an existing edge does nothing.
+func (g *Graph[K, V]) AddEdge(from, to K) {
+	var zero V
+	if !g.HasNode(from) {
+		g.AddNode(from, zero)
+	}
+	if !g.HasNode(to) {
+		g.AddNode(to, zero)
+	}
+	if g.HasEdge(from, to) {
+		return
+	}
+
+	g.adj[from] = append(g.adj[from], to)
+	if !g.directed && from != to {
+		g.adj[to] = append(g.adj[to], from)
+	}
+}
+
+// HasEdge reports whether from -> to exists.
+func (g *Graph[K, V]) HasEdge(from, to K) bool {
+	for _, n := range g.adj[from] {
+		if n == to {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveEdge deletes from -> to (both ways when undirected).
+func (g *Graph[K, V]) RemoveEdge(from, to K) {
+	g.adj[from] = without(g.adj[from], to)
+	if !g.directed {
+		g.adj[to] = without(g.adj[to], from)
+	}
+}
+
+func without[K comparable](list []K, id K) []K {
+	for i, n := range list {
+		if n == id {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+// Neighbors returns the nodes reachable from id in one step.
+func (g *Graph[K, V]) Neighbors(id K) []K {
+	return append([]K(nil), g.adj[id]...)
+}
+
+// BFS visits nodes in breadth-first order starting at start, nearest first.
+// Returning false from visit stops the search.
+func (g *Graph[K, V]) BFS(start K, visit func(id K, depth int) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	// The frontier is a queue: first in, first out.
+	type item struct {
+		id    K
+		depth int
+	}
+	queue := []item{{start, 0}}
+	explored := map[K]bool{start: true}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !visit(current.id, current.depth) {
+			return
+		}
+		for _, n := range g.adj[current.id] {
+			if !explored[n] {
+				explored[n] = true
+				queue = append(queue, item{n, current.depth + 1})
+			}
+		}
+	}
+}
+
+// ShortestPath returns the nodes on a shortest path from -> to (both ends
+// included), counting every edge as 1. ok is false when to can't be reached.
+func (g *Graph[K, V]) ShortestPath(from, to K) (path []K, ok bool) {
+	if !g.HasNode(from) || !g.HasNode(to) {
+		return nil, false
+	}
+
+	// parent remembers how BFS first reached each node, so the path can be
+	// rebuilt by walking back from the goal.
+	parent := map[K]K{}
+	found := false
+	g.BFS(from, func(id K, _ int) bool {
+		if id == to {
+			found = true
+			return false
+		}
+		for _, n := range g.adj[id] {
+			if _, seen := parent[n]; !seen && n != from {
+				parent[n] = id
+			}
+		}
+		return true
+	})
+	if !found {
+		return nil, false
+	}
+
+	for cursor := to; cursor != from; cursor = parent[cursor] {
+		path = append(path, cursor)
+	}
+	path = append(path, from)
+
+	// Reverse, the walk above went goal -> start.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+// DFS visits nodes depth-first starting at start. Returning false from visit
+// stops the search.
+func (g *Graph[K, V]) DFS(start K, visit func(id K) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	// The frontier is a stack: last in, first out.
+	stack := []K{start}
+	explored := map[K]bool{}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if explored[id] {
+			continue
+		}
+		explored[id] = true
+		if !visit(id) {
+			return
+		}
+
+		// Push in reverse so the first neighbour is explored first.
+		neighbors := g.adj[id]
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !explored[neighbors[i]] {
+				stack = append(stack, neighbors[i])
+			}
+		}
+	}
+}
+
+// PathExists reports whether to can be reached from from.
+func (g *Graph[K, V]) PathExists(from, to K) bool {
+	found := false
+	g.DFS(from, func(id K) bool {
+		found = id == to
+		return !found
+	})
+	return found
+}
+
+// HasCycle reports whether the graph contains a cycle.
+func (g *Graph[K, V]) HasCycle() bool {
+	if g.directed {
+		return g.hasDirectedCycle()
+	}
+	return g.hasUndirectedCycle()
+}
+
+// WouldCreateCycle reports whether adding from -> to would close a cycle,
+// which is the question tideman asks before locking a pair.
+func (g *Graph[K, V]) WouldCreateCycle(from, to K) bool {
+	if from == to {
+		return true
+	}
+	if g.directed {
+		return g.PathExists(to, from)
+	}
+	return g.PathExists(from, to)
+}
+
+// Directed: DFS colouring. Reaching a node that is still on the current path
+// (grey) means we went around in a circle.
+func (g *Graph[K, V]) hasDirectedCycle() bool {
+	const (
+		white = iota // not visited yet
+		grey         // on the current DFS path
+		black        // finished
+	)
+	color := make(map[K]int, len(g.order))
+
+	var visit func(id K) bool
+	visit = func(id K) bool {
+		color[id] = grey
+		for _, n := range g.adj[id] {
+			if color[n] == grey {
+				return true
+			}
+			if color[n] == white && visit(n) {
+				return true
+			}
+		}
+		color[id] = black
+		return false
+	}
+
+	for _, id := range g.order {
+		if color[id] == white && visit(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Undirected: every edge shows up twice, so only an explored neighbour that
+// isn't the node we came from closes a cycle.
+func (g *Graph[K, V]) hasUndirectedCycle() bool {
+	explored := make(map[K]bool, len(g.order))
+
+	var visit func(id, parent K, root bool) bool
+	visit = func(id, parent K, root bool) bool {
+		explored[id] = true
+		for _, n := range g.adj[id] {
+			if n == id {
+				return true // self loop
+			}
+			if !explored[n] {
+				if visit(n, id, false) {
+					return true
+				}
+			} else if root || n != parent {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, id := range g.order {
+		if !explored[id] && visit(id, id, true) {
+			return true
+		}
+	}
+	return false
+}
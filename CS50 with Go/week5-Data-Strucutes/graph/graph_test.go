@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+// edges builds a graph of int nodes from pairs, directed or not.
+func edges(directed bool, pairs ...[2]int) *Graph[int, string] {
+	g := NewUndirected[int, string]()
+	if directed {
+		g = NewDirected[int, string]()
+	}
+	for _, p := range pairs {
+		g.AddEdge(p[0], p[1])
+	}
+	return g
+}
+
+// chain is 1 -> 2 -> ... -> n.
+func chain(n int) [][2]int {
+	var pairs [][2]int
+	for i := 1; i < n; i++ {
+		pairs = append(pairs, [2]int{i, i + 1})
+	}
+	return pairs
+}
+
+func TestShortestPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		g        *Graph[int, string]
+		from, to int
+		want     []int // nil: can't be reached
+	}{
+		{"itself", edges(true, [2]int{1, 2}), 1, 1, []int{1}},
+		{"one edge", edges(true, [2]int{1, 2}), 1, 2, []int{1, 2}},
+		{"against a directed edge", edges(true, [2]int{1, 2}), 2, 1, nil},
+		{"back along an undirected edge", edges(false, [2]int{1, 2}), 2, 1, []int{2, 1}},
+		{"self-loop", edges(true, [2]int{1, 1}, [2]int{1, 2}), 1, 2, []int{1, 2}},
+		{"the chain", edges(true, chain(6)...), 1, 6, []int{1, 2, 3, 4, 5, 6}},
+		{"a shortcut beats the chain", edges(true, append(chain(6), [2]int{2, 5})...), 1, 6, []int{1, 2, 5, 6}},
+		{"round a two-node cycle", edges(true, [2]int{1, 2}, [2]int{2, 1}, [2]int{2, 3}), 1, 3, []int{1, 2, 3}},
+		{"through a long back edge", edges(true, append(chain(6), [2]int{6, 1})...), 6, 2, []int{6, 1, 2}},
+		{"another component", edges(false, [2]int{1, 2}, [2]int{3, 4}), 1, 4, nil},
+		{"not in the graph", edges(false, [2]int{1, 2}), 1, 9, nil},
+		{"from nowhere", edges(false, [2]int{1, 2}), 9, 1, nil},
+	}
+	for _, test := range tests {
+		path, ok := test.g.ShortestPath(test.from, test.to)
+		if ok != (test.want != nil) || !slices.Equal(path, test.want) {
+			t.Errorf("%s: ShortestPath(%d, %d) = %v, %v, want %v", test.name, test.from, test.to, path, ok, test.want)
+		}
+	}
+}
+
+func TestHasCycle(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Graph[int, string]
+		want bool
+	}{
+		{"empty", edges(true), false},
+		{"one edge", edges(true, [2]int{1, 2}), false},
+		{"directed self-loop", edges(true, [2]int{1, 1}), true},
+		{"undirected self-loop", edges(false, [2]int{1, 1}), true},
+		{"directed two-node cycle", edges(true, [2]int{1, 2}, [2]int{2, 1}), true},
+		{"an undirected edge isn't a cycle", edges(false, [2]int{1, 2}, [2]int{2, 1}), false},
+		{"directed chain", edges(true, chain(10)...), false},
+		{"long back edge", edges(true, append(chain(10), [2]int{10, 1})...), true},
+		{"undirected long back edge", edges(false, append(chain(10), [2]int{10, 1})...), true},
+		{"undirected tree", edges(false, [2]int{1, 2}, [2]int{1, 3}, [2]int{3, 4}, [2]int{3, 5}), false},
+		{"diamond, directed", edges(true, [2]int{1, 2}, [2]int{1, 3}, [2]int{2, 4}, [2]int{3, 4}), false},
+		{"diamond, undirected", edges(false, [2]int{1, 2}, [2]int{1, 3}, [2]int{2, 4}, [2]int{3, 4}), true},
+		{"cycle in a later component", edges(true, [2]int{1, 2}, [2]int{3, 4}, [2]int{4, 5}, [2]int{5, 3}), true},
+	}
+	for _, test := range tests {
+		if got := test.g.HasCycle(); got != test.want {
+			t.Errorf("%s: HasCycle() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWouldCreateCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		g        *Graph[int, string]
+		from, to int
+		want     bool
+	}{
+		{"self-loop", edges(true), 1, 1, true},
+		{"two-node cycle", edges(true, [2]int{1, 2}), 2, 1, true},
+		{"the same edge again", edges(true, [2]int{1, 2}), 1, 2, false},
+		{"long back edge", edges(true, chain(10)...), 10, 1, true},
+		{"forward along the chain", edges(true, chain(10)...), 1, 10, false},
+		{"to a node out of reach", edges(true, [2]int{1, 2}, [2]int{3, 4}), 2, 3, false},
+		{"to a node not in the graph", edges(true, [2]int{1, 2}), 2, 9, false},
+		{"undirected, joining a component", edges(false, chain(4)...), 1, 4, true},
+		{"undirected, to another component", edges(false, [2]int{1, 2}, [2]int{3, 4}), 2, 3, false},
+	}
+	for _, test := range tests {
+		if got := test.g.WouldCreateCycle(test.from, test.to); got != test.want {
+			t.Errorf("%s: WouldCreateCycle(%d, %d) = %v, want %v", test.name, test.from, test.to, got, test.want)
+		}
+	}
+}
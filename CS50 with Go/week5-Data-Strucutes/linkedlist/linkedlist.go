@@ -0,0 +1,154 @@
+// Package linkedlist is the singly linked list from the week 5 lecture:
+// every node points to the next one and the last one points to nil.
+package linkedlist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Node is one number in the list.
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+// List keeps a pointer to the first node.
+type List struct {
+	Head *Node
+	size int
+}
+
+// New returns a list holding values in order.
+func New(values ...int) *List {
+	l := &List{}
+	for i := len(values) - 1; i >= 0; i-- {
+		l.InsertHead(values[i])
+	}
+	return l
+}
+
+// InsertHead puts value at the front, O(1).
+func (l *List) InsertHead(value int) {
+	// The new node points at the old head first, otherwise the list is lost
+	l.Head = &Node{Value: value, Next: l.Head}
+	l.size++
+}
+
+// Append puts value at the end, walking the whole list, O(n).
+func (l *List) Append(value int) {
+	n := &Node{Value: value}
+	if l.Head == nil {
+		l.Head = n
+		l.size++
+		return
+	}
+
+	cursor := l.Head
+	for cursor.Next != nil {
+		cursor = cursor.Next
+	}
+	cursor.Next = n
+	l.size++
+}
+
+// InsertAt puts value at position index (0 = head).
+func (l *List) InsertAt(index, value int) error {
+	if index < 0 || index > l.size {
+		return fmt.Errorf("index %d out of range [0, %d]", index, l.size)
+	}
+	if index == 0 {
+		l.InsertHead(value)
+		return nil
+	}
+
+	// Stop at the node before the gap
+	cursor := l.Head
+	for i := 0; i < index-1; i++ {
+		cursor = cursor.Next
+	}
+	cursor.Next = &Node{Value: value, Next: cursor.Next}
+	l.size++
+	return nil
+}
+
+// Delete removes the first node holding value and reports whether it found one.
+func (l *List) Delete(value int) bool {
+	var prev *Node
+	for cursor := l.Head; cursor != nil; prev, cursor = cursor, cursor.Next {
+		if cursor.Value != value {
+			continue
+		}
+		// Skip over the node, the garbage collector frees it (no free() in Go)
+		if prev == nil {
+			l.Head = cursor.Next
+		} else {
+			prev.Next = cursor.Next
+		}
+		l.size--
+		return true
+	}
+	return false
+}
+
+// Find returns the first node holding value, or nil.
+func (l *List) Find(value int) *Node {
+	for cursor := l.Head; cursor != nil; cursor = cursor.Next {
+		if cursor.Value == value {
+			return cursor
+		}
+	}
+	return nil
+}
+
+// Reverse flips every Next pointer in place.
+func (l *List) Reverse() {
+	l.ReverseSteps(nil)
+}
+
+// ReverseSteps reverses the list and calls step after each pointer flip with
+// the already reversed part (prev) and the part still to do (curr).
+func (l *List) ReverseSteps(step func(prev, curr *Node)) {
+	var prev *Node
+	curr := l.Head
+	for curr != nil {
+		next := curr.Next
+		curr.Next = prev
+		prev, curr = curr, next
+		if step != nil {
+			step(prev, curr)
+		}
+	}
+	l.Head = prev
+}
+
+// Len returns the number of nodes.
+func (l *List) Len() int {
+	return l.size
+}
+
+// Values returns the numbers from head to tail.
+func (l *List) Values() []int {
+	values := make([]int, 0, l.size)
+	for cursor := l.Head; cursor != nil; cursor = cursor.Next {
+		values = append(values, cursor.Value)
+	}
+	return values
+}
+
+// String draws the list as "head -> 1 -> 2 -> NULL".
+func (l *List) String() string {
+	return "head -> " + Chain(l.Head)
+}
+
+// Chain draws the nodes reachable from n as "1 -> 2 -> NULL".
+func Chain(n *Node) string {
+	var b strings.Builder
+	for cursor := n; cursor != nil; cursor = cursor.Next {
+		b.WriteString(strconv.Itoa(cursor.Value))
+		b.WriteString(" -> ")
+	}
+	b.WriteString("NULL")
+	return b.String()
+}
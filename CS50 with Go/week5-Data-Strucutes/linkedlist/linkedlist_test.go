@@ -0,0 +1,123 @@
+package linkedlist
+
+import (
+	"slices"
+	"testing"
+)
+
+// check fails unless l holds want, by walking it and by its size.
+func check(t *testing.T, name string, l *List, want []int) {
+	t.Helper()
+	if got := l.Values(); !slices.Equal(got, want) || l.Len() != len(want) {
+		t.Errorf("%s: Values() = %v with Len() %d, want %v", name, got, l.Len(), want)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	l := New()
+	check(t, "New()", l, []int{})
+	if l.Head != nil {
+		t.Errorf("New().Head = %v, want nil", l.Head)
+	}
+
+	l.InsertHead(2)
+	check(t, "InsertHead into empty", l, []int{2})
+	l.InsertHead(1)
+	check(t, "InsertHead", l, []int{1, 2})
+
+	l = New()
+	l.Append(1)
+	check(t, "Append to empty", l, []int{1})
+	l.Append(2)
+	check(t, "Append", l, []int{1, 2})
+
+	check(t, "New(1, 2, 3)", New(1, 2, 3), []int{1, 2, 3})
+}
+
+func TestInsertAt(t *testing.T) {
+	tests := []struct {
+		values []int
+		index  int
+		want   []int
+		err    bool
+	}{
+		{nil, 0, []int{9}, false},
+		{[]int{1, 2}, 0, []int{9, 1, 2}, false}, // head
+		{[]int{1, 2}, 1, []int{1, 9, 2}, false},
+		{[]int{1, 2}, 2, []int{1, 2, 9}, false}, // tail
+		{[]int{1, 2}, 3, []int{1, 2}, true},
+		{[]int{1, 2}, -1, []int{1, 2}, true},
+		{nil, 1, []int{}, true},
+	}
+	for _, tt := range tests {
+		l := New(tt.values...)
+		if err := l.InsertAt(tt.index, 9); (err != nil) != tt.err {
+			t.Errorf("New(%v).InsertAt(%d, 9) err = %v", tt.values, tt.index, err)
+		}
+		check(t, "InsertAt", l, tt.want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		values []int
+		delete int
+		found  bool
+		want   []int
+	}{
+		{nil, 1, false, []int{}},
+		{[]int{1}, 1, true, []int{}}, // the only node
+		{[]int{1}, 2, false, []int{1}},
+		{[]int{1, 2, 3}, 1, true, []int{2, 3}}, // head
+		{[]int{1, 2, 3}, 2, true, []int{1, 3}},
+		{[]int{1, 2, 3}, 3, true, []int{1, 2}}, // tail
+		{[]int{1, 2, 3}, 4, false, []int{1, 2, 3}},
+		{[]int{2, 1, 2}, 2, true, []int{1, 2}}, // only the first
+	}
+	for _, tt := range tests {
+		l := New(tt.values...)
+		if found := l.Delete(tt.delete); found != tt.found {
+			t.Errorf("New(%v).Delete(%d) = %v, want %v", tt.values, tt.delete, found, tt.found)
+		}
+		check(t, "Delete", l, tt.want)
+	}
+
+	// Appending after deleting the tail still reaches the end
+	l := New(1, 2)
+	l.Delete(2)
+	l.Append(3)
+	check(t, "Append after Delete", l, []int{1, 3})
+}
+
+func TestFind(t *testing.T) {
+	if n := New().Find(1); n != nil {
+		t.Errorf("New().Find(1) = %v, want nil", n)
+	}
+
+	l := New(1, 2, 3, 2)
+	if n := l.Find(1); n != l.Head {
+		t.Errorf("Find(1) = %p, want the head %p", n, l.Head)
+	}
+	if n := l.Find(2); n != l.Head.Next {
+		t.Errorf("Find(2) = %p, want the first 2 %p", n, l.Head.Next)
+	}
+	if n := l.Find(3); n == nil || n.Value != 3 || n.Next.Value != 2 {
+		t.Errorf("Find(3) = %v", n)
+	}
+	if n := l.Find(4); n != nil {
+		t.Errorf("Find(4) = %v, want nil", n)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	for _, values := range [][]int{{}, {1}, {1, 2}, {1, 2, 3}} {
+		l := New(values...)
+		l.Reverse()
+		want := slices.Clone(values)
+		slices.Reverse(want)
+		check(t, "Reverse", l, want)
+	}
+	if got := New(1, 2).String(); got != "head -> 1 -> 2 -> NULL" {
+		t.Errorf("String() = %q", got)
+	}
+}
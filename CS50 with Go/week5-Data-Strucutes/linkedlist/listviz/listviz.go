@@ -0,0 +1,134 @@
+// listviz: run a script of linked-list operations and draw the list after
+// every step, so the pointer changes are visible one at a time.
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedlist"
+)
+
+// defaultScript walks through every operation from the lecture.
+const defaultScript = `# build a list
+head 3
+head 2
+head 1
+# insert in the middle
+insert 2 9
+append 4
+# delete from the middle and from the head
+delete 9
+delete 1
+reverse
+`
+
+func main() {
+	scriptPath := flag.String("script", "", "file with one operation per line (default: built-in demo)")
+	animate := flag.Bool("animate", false, "redraw the screen for every step instead of scrolling")
+	delay := flag.Duration("delay", 800*time.Millisecond, "pause between steps when animating")
+	flag.Usage = func() {
+		fmt.Println("Usage: ./listviz [-script FILE] [-animate] [-delay 800ms]")
+		fmt.Println("Operations: head N | append N | insert INDEX N | delete N | reverse")
+	}
+	flag.Parse()
+
+	var script io.Reader = strings.NewReader(defaultScript)
+	if *scriptPath != "" {
+		file, err := os.Open(*scriptPath)
+		if err != nil {
+			fmt.Printf("Could not open %s.\n", *scriptPath)
+			os.Exit(1)
+		}
+		defer file.Close()
+		script = file
+	}
+
+	v := &viz{list: &linkedlist.List{}, animate: *animate, delay: *delay}
+	v.show("start", v.list.String())
+
+	scanner := bufio.NewScanner(script)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := v.run(line); err != nil {
+			fmt.Printf("line %d: %v\n", lineNo, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// viz draws each step, either scrolling or animated in place.
+type viz struct {
+	list    *linkedlist.List
+	animate bool
+	delay   time.Duration
+	step    int
+}
+
+// run applies one script line to the list and shows the result.
+func (v *viz) run(line string) error {
+	fields := strings.Fields(line)
+	args := make([]int, 0, len(fields)-1)
+	for _, f := range fields[1:] {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", f)
+		}
+		args = append(args, n)
+	}
+
+	want := map[string]int{"head": 1, "append": 1, "insert": 2, "delete": 1, "reverse": 0}
+	count, ok := want[fields[0]]
+	if !ok {
+		return fmt.Errorf("unknown operation %q", fields[0])
+	}
+	if len(args) != count {
+		return fmt.Errorf("%s takes %d number(s)", fields[0], count)
+	}
+
+	switch fields[0] {
+	case "head":
+		v.list.InsertHead(args[0])
+	case "append":
+		v.list.Append(args[0])
+	case "insert":
+		if err := v.list.InsertAt(args[0], args[1]); err != nil {
+			return err
+		}
+	case "delete":
+		if !v.list.Delete(args[0]) {
+			v.show(line, v.list.String()+"   (not found)")
+			return nil
+		}
+	case "reverse":
+		// Show every pointer flip: prev is done, curr is still to go
+		v.list.ReverseSteps(func(prev, curr *linkedlist.Node) {
+			v.show(line, fmt.Sprintf("prev -> %s   curr -> %s", linkedlist.Chain(prev), linkedlist.Chain(curr)))
+		})
+	}
+	v.show(line, v.list.String())
+	return nil
+}
+
+// show prints one frame.
+func (v *viz) show(operation, state string) {
+	if v.animate {
+		// ANSI: cursor home + clear screen
+		fmt.Print("\033[H\033[2J")
+	}
+	fmt.Printf("%2d. %-14s %s\n", v.step, operation, state)
+	v.step++
+	if v.animate {
+		time.Sleep(v.delay)
+	}
+}
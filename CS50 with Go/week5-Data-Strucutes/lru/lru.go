@@ -0,0 +1,117 @@
+// Package lru is a least-recently-used cache: a map finds entries in O(1)
+// and a doubly linked list keeps them ordered from most to least recently
+// used, so the entry to evict is always at the back.
+package lru
+
+// node is one entry in the doubly linked list.
+type node[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *node[K, V]
+	next  *node[K, V]
+}
+
+// Cache holds at most capacity entries. head and tail are sentinel nodes
+// (they never hold data) so inserting and unlinking never need nil checks.
+//
+//	head <-> most recent <-> ... <-> least recent <-> tail
+type Cache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*node[K, V]
+	head     *node[K, V]
+	tail     *node[K, V]
+}
+
+// New returns an empty cache holding at most capacity entries.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	head, tail := &node[K, V]{}, &node[K, V]{}
+	head.next = tail
+	tail.prev = head
+	return &Cache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*node[K, V], capacity),
+		head:     head,
+		tail:     tail,
+	}
+}
+
+// Get returns the value for key and marks it as most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	n, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.moveToFront(n)
+	return n.value, true
+}
+
+// Put stores value under key as the most recently used entry. If the cache
+// is full, the least recently used entry is evicted and returned.
+func (c *Cache[K, V]) Put(key K, value V) (evictedKey K, evicted bool) {
+	if n, ok := c.items[key]; ok {
+		n.value = value
+		c.moveToFront(n)
+		return evictedKey, false
+	}
+
+	if len(c.items) == c.capacity {
+		last := c.tail.prev
+		c.unlink(last)
+		delete(c.items, last.key)
+		evictedKey, evicted = last.key, true
+	}
+
+	n := &node[K, V]{key: key, value: value}
+	c.pushFront(n)
+	c.items[key] = n
+	return evictedKey, evicted
+}
+
+// Remove deletes key from the cache and reports whether it was there.
+func (c *Cache[K, V]) Remove(key K) bool {
+	n, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(n)
+	delete(c.items, key)
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Keys returns the keys from most to least recently used.
+func (c *Cache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.items))
+	for n := c.head.next; n != c.tail; n = n.next {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// pushFront links n right after the head sentinel.
+func (c *Cache[K, V]) pushFront(n *node[K, V]) {
+	n.prev = c.head
+	n.next = c.head.next
+	c.head.next.prev = n
+	c.head.next = n
+}
+
+// unlink takes n out of the list by pointing its neighbours at each other.
+func (c *Cache[K, V]) unlink(n *node[K, V]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev, n.next = nil, nil
+}
+
+func (c *Cache[K, V]) moveToFront(n *node[K, V]) {
+	c.unlink(n)
+	c.pushFront(n)
+}
@@ -0,0 +1,133 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+// op is one step of a scripted test: put (value != "") or get.
+type op struct {
+	put   bool
+	key   string
+	value string
+
+	wantValue   string
+	wantOK      bool
+	wantEvicted string
+}
+
+func TestCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ops      []op
+		wantKeys []string
+	}{
+		{
+			name:     "get missing",
+			capacity: 2,
+			ops:      []op{{key: "a"}},
+			wantKeys: []string{},
+		},
+		{
+			name:     "put then get",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", value: "1"},
+				{key: "a", wantValue: "1", wantOK: true},
+			},
+			wantKeys: []string{"a"},
+		},
+		{
+			name:     "evicts least recently put",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", value: "1"},
+				{put: true, key: "b", value: "2"},
+				{put: true, key: "c", value: "3", wantEvicted: "a"},
+				{key: "a"},
+			},
+			wantKeys: []string{"c", "b"},
+		},
+		{
+			name:     "get refreshes recency",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", value: "1"},
+				{put: true, key: "b", value: "2"},
+				{key: "a", wantValue: "1", wantOK: true},
+				{put: true, key: "c", value: "3", wantEvicted: "b"},
+			},
+			wantKeys: []string{"c", "a"},
+		},
+		{
+			name:     "update existing key doesn't evict",
+			capacity: 2,
+			ops: []op{
+				{put: true, key: "a", value: "1"},
+				{put: true, key: "b", value: "2"},
+				{put: true, key: "a", value: "one"},
+				{key: "a", wantValue: "one", wantOK: true},
+			},
+			wantKeys: []string{"a", "b"},
+		},
+		{
+			name:     "capacity one",
+			capacity: 1,
+			ops: []op{
+				{put: true, key: "a", value: "1"},
+				{put: true, key: "b", value: "2", wantEvicted: "a"},
+				{key: "b", wantValue: "2", wantOK: true},
+			},
+			wantKeys: []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New[string, string](tt.capacity)
+			for i, o := range tt.ops {
+				if o.put {
+					evicted, ok := c.Put(o.key, o.value)
+					if ok != (o.wantEvicted != "") || evicted != o.wantEvicted {
+						t.Fatalf("op %d: Put(%q) evicted %q, %v, want %q", i, o.key, evicted, ok, o.wantEvicted)
+					}
+					continue
+				}
+				value, ok := c.Get(o.key)
+				if value != o.wantValue || ok != o.wantOK {
+					t.Fatalf("op %d: Get(%q) = %q, %v, want %q, %v", i, o.key, value, ok, o.wantValue, o.wantOK)
+				}
+			}
+
+			if got := c.Keys(); !reflect.DeepEqual(got, tt.wantKeys) {
+				t.Errorf("Keys() = %v, want %v", got, tt.wantKeys)
+			}
+			if c.Len() != len(tt.wantKeys) {
+				t.Errorf("Len() = %d, want %d", c.Len(), len(tt.wantKeys))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	c := New[int, int](3)
+	for i := 1; i <= 3; i++ {
+		c.Put(i, i*i)
+	}
+
+	if !c.Remove(2) {
+		t.Fatal("Remove(2) = false, want true")
+	}
+	if c.Remove(2) {
+		t.Fatal("second Remove(2) = true, want false")
+	}
+	if got, want := c.Keys(), []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+
+	// The freed slot is reused without evicting anything.
+	if _, evicted := c.Put(4, 16); evicted {
+		t.Fatal("Put after Remove evicted an entry")
+	}
+}
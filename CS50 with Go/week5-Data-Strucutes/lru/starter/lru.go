@@ -0,0 +1,100 @@
+// Package lru is a least-recently-used cache: a map finds entries in O(1)
+// and a doubly linked list keeps them ordered from most to least recently
+// used, so the entry to evict is always at the back.
+//
+// Starter version: fill in the TODOs, then run the tests from ../lru_test.go
+// against it (copy the test file next to this one).
+package lru
+
+// node is one entry in the doubly linked list.
+type node[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *node[K, V]
+	next  *node[K, V]
+}
+
+// Cache holds at most capacity entries. head and tail are sentinel nodes
+// (they never hold data) so inserting and unlinking never need nil checks.
+//
+//	head <-> most recent <-> ... <-> least recent <-> tail
+type Cache[K comparable, V any] struct {
+	capacity int
+	items    map[K]*node[K, V]
+	head     *node[K, V]
+	tail     *node[K, V]
+}
+
+// New returns an empty cache holding at most capacity entries.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	head, tail := &node[K, V]{}, &node[K, V]{}
+	// TODO: Link the two sentinels to each other (head.next, tail.prev).
+
+	return &Cache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*node[K, V], capacity),
+		head:     head,
+		tail:     tail,
+	}
+}
+
+// Get returns the value for key and marks it as most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	// TODO: Look the key up in c.items.
+	// HINT: If it's there, move its node to the front before returning the value.
+
+	var zero V
+	return zero, false // This needs to be changed.
+}
+
+// Put stores value under key as the most recently used entry. If the cache
+// is full, the least recently used entry is evicted and returned.
+func (c *Cache[K, V]) Put(key K, value V) (evictedKey K, evicted bool) {
+	// TODO: If key already exists, update its value and move it to the front.
+
+	// TODO: If the cache is full, evict the node just before c.tail.
+	// HINT: Unlink it AND delete it from c.items, then set evictedKey/evicted.
+
+	// TODO: Create a new node, push it to the front and store it in c.items.
+
+	return evictedKey, evicted
+}
+
+// Remove deletes key from the cache and reports whether it was there.
+func (c *Cache[K, V]) Remove(key K) bool {
+	// TODO: Unlink the node and delete it from the map.
+	return false // This needs to be changed.
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Keys returns the keys from most to least recently used.
+func (c *Cache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.items))
+	for n := c.head.next; n != c.tail && n != nil; n = n.next {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// pushFront links n right after the head sentinel.
+func (c *Cache[K, V]) pushFront(n *node[K, V]) {
+	// TODO: Four pointers change here: n.prev, n.next, the old first node's
+	// prev and c.head.next. Draw it on paper first!
+}
+
+// unlink takes n out of the list by pointing its neighbours at each other.
+func (c *Cache[K, V]) unlink(n *node[K, V]) {
+	// TODO: n.prev should point forward to n.next, and n.next back to n.prev.
+}
+
+func (c *Cache[K, V]) moveToFront(n *node[K, V]) {
+	c.unlink(n)
+	c.pushFront(n)
+}
@@ -0,0 +1,105 @@
+// Package memstats measures what a piece of code did to the Go heap and
+// prints it like valgrind's HEAP SUMMARY, so data structures can be
+// compared by memory the way CS50 compares them with valgrind in C.
+package memstats
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+// Report is the heap activity between Start and Stop.
+type Report struct {
+	Label string
+
+	Allocs         uint64 // objects allocated (malloc calls)
+	Frees          uint64 // objects freed by the garbage collector
+	BytesAllocated uint64 // total bytes allocated, freed or not
+
+	InUseBytes  int64 // change in live heap bytes
+	InUseBlocks int64 // change in live heap objects
+}
+
+// Sampler remembers the heap state at Start.
+type Sampler struct {
+	label  string
+	before runtime.MemStats
+}
+
+// Start collects garbage (so older garbage isn't counted) and snapshots the heap.
+func Start(label string) *Sampler {
+	s := &Sampler{label: label}
+	runtime.GC()
+	runtime.ReadMemStats(&s.before)
+	return s
+}
+
+// Stop collects garbage again and returns the difference since Start. After
+// the collection, "in use" only counts memory that is still reachable.
+func (s *Sampler) Stop() Report {
+	var after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	return Report{
+		Label:          s.label,
+		Allocs:         after.Mallocs - s.before.Mallocs,
+		Frees:          after.Frees - s.before.Frees,
+		BytesAllocated: after.TotalAlloc - s.before.TotalAlloc,
+		InUseBytes:     int64(after.HeapAlloc) - int64(s.before.HeapAlloc),
+		InUseBlocks:    int64(after.HeapObjects) - int64(s.before.HeapObjects),
+	}
+}
+
+// Measure runs fn between Start and Stop.
+func Measure(label string, fn func()) Report {
+	s := Start(label)
+	fn()
+	return s.Stop()
+}
+
+// Fprint writes the report in valgrind's layout, e.g.
+//
+//	==load== HEAP SUMMARY:
+//	==load==     in use after: 7,912,000 bytes in 143,091 blocks
+//	==load==   total heap usage: 143,100 allocs, 9 frees, 8,013,096 bytes allocated
+func (r Report) Fprint(w io.Writer) {
+	prefix := "==" + r.Label + "=="
+	fmt.Fprintf(w, "%s HEAP SUMMARY:\n", prefix)
+	fmt.Fprintf(w, "%s     in use after: %s bytes in %s blocks\n", prefix, commasSigned(r.InUseBytes), commasSigned(r.InUseBlocks))
+	fmt.Fprintf(w, "%s   total heap usage: %s allocs, %s frees, %s bytes allocated\n",
+		prefix, commas(r.Allocs), commas(r.Frees), commas(r.BytesAllocated))
+	fmt.Fprintf(w, "%s\n", prefix)
+}
+
+// FormatBytes prints a byte count in B/KiB/MiB/... for tables.
+func FormatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// commas formats 8013096 as 8,013,096.
+func commas(n uint64) string {
+	s := strconv.FormatUint(n, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+func commasSigned(n int64) string {
+	if n < 0 {
+		return "-" + commas(uint64(-n))
+	}
+	return commas(uint64(n))
+}
@@ -0,0 +1,88 @@
+package memstats
+
+import (
+	"bytes"
+	"testing"
+)
+
+const (
+	BLOCKS     = 1000
+	BLOCK_SIZE = 1024 // a size class of its own, so no rounding up
+)
+
+// kept stays reachable after Stop's collection. The runtime allocates and
+// frees a little of its own meanwhile, so the checks allow some slack.
+var kept [][]byte
+
+func TestMeasure(t *testing.T) {
+	r := Measure("kept", func() {
+		kept = make([][]byte, BLOCKS)
+		for i := range kept {
+			kept[i] = make([]byte, BLOCK_SIZE)
+		}
+	})
+	if r.Label != "kept" {
+		t.Errorf("Label = %q", r.Label)
+	}
+	if r.Allocs < BLOCKS || r.Allocs > BLOCKS+50 {
+		t.Errorf("Allocs = %d, want about %d", r.Allocs, BLOCKS)
+	}
+	if r.BytesAllocated < BLOCKS*BLOCK_SIZE || r.BytesAllocated > BLOCKS*BLOCK_SIZE*11/10 {
+		t.Errorf("BytesAllocated = %d, want about %d", r.BytesAllocated, BLOCKS*BLOCK_SIZE)
+	}
+	if r.InUseBytes < BLOCKS*BLOCK_SIZE*9/10 || r.InUseBytes > BLOCKS*BLOCK_SIZE*11/10 {
+		t.Errorf("InUseBytes = %d, want about %d", r.InUseBytes, BLOCKS*BLOCK_SIZE)
+	}
+	if r.InUseBlocks < BLOCKS-50 || r.InUseBlocks > BLOCKS+50 {
+		t.Errorf("InUseBlocks = %d, want about %d", r.InUseBlocks, BLOCKS)
+	}
+
+	// Dropping them frees every block and gives the bytes back
+	r = Measure("dropped", func() { kept = nil })
+	if r.Frees < BLOCKS {
+		t.Errorf("Frees = %d, want at least %d", r.Frees, BLOCKS)
+	}
+	if r.InUseBytes > -BLOCKS*BLOCK_SIZE*9/10 || r.InUseBlocks > -(BLOCKS-50) {
+		t.Errorf("in use after dropping: %d bytes in %d blocks, want about -%d in -%d", r.InUseBytes, r.InUseBlocks, BLOCKS*BLOCK_SIZE, BLOCKS)
+	}
+}
+
+func TestFprint(t *testing.T) {
+	r := Report{
+		Label:          "load",
+		Allocs:         143100,
+		Frees:          9,
+		BytesAllocated: 8013096,
+		InUseBytes:     -1024,
+		InUseBlocks:    -1,
+	}
+	want := "==load== HEAP SUMMARY:\n" +
+		"==load==     in use after: -1,024 bytes in -1 blocks\n" +
+		"==load==   total heap usage: 143,100 allocs, 9 frees, 8,013,096 bytes allocated\n" +
+		"==load==\n"
+	var b bytes.Buffer
+	r.Fprint(&b)
+	if b.String() != want {
+		t.Errorf("Fprint =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{BLOCKS * BLOCK_SIZE, "1000.0 KiB"},
+		{1 << 20, "1.0 MiB"},
+		{5 << 30, "5.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package pqueue is a min-priority queue backed by a binary heap.
+//
+// container/heap makes you write five methods (Len, Less, Swap, Push, Pop)
+// on your own slice type and then call heap.Push(h, x) instead of h.Push(x).
+// Queue does that once, generically, so callers only see PushWithPriority
+// and PopMin.
+package pqueue
+
+import (
+	"cmp"
+	"container/heap"
+)
+
+// item is one value in the heap. seq breaks ties so values with the same
+// priority come out in the order they went in (first in, first out).
+type item[T any, P cmp.Ordered] struct {
+	value    T
+	priority P
+	seq      uint64
+}
+
+// items implements heap.Interface. Only Queue ever touches it.
+type items[T any, P cmp.Ordered] []item[T, P]
+
+func (h items[T, P]) Len() int { return len(h) }
+
+func (h items[T, P]) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h items[T, P]) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push and Pop are called by container/heap, not by users of Queue.
+func (h *items[T, P]) Push(x any) { *h = append(*h, x.(item[T, P])) }
+
+func (h *items[T, P]) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// Queue pops the value with the smallest priority first.
+type Queue[T any, P cmp.Ordered] struct {
+	heap items[T, P]
+	seq  uint64
+}
+
+// New returns an empty queue.
+func New[T any, P cmp.Ordered]() *Queue[T, P] {
+	return &Queue[T, P]{}
+}
+
+// PushWithPriority adds value, smaller priority means it comes out sooner.
+func (q *Queue[T, P]) PushWithPriority(value T, priority P) {
+	heap.Push(&q.heap, item[T, P]{value: value, priority: priority, seq: q.seq})
+	q.seq++
+}
+
+// PopMin removes and returns the value with the smallest priority.
+// ok is false when the queue is empty.
+func (q *Queue[T, P]) PopMin() (value T, priority P, ok bool) {
+	if len(q.heap) == 0 {
+		return value, priority, false
+	}
+	top := heap.Pop(&q.heap).(item[T, P])
+	return top.value, top.priority, true
+}
+
+// PeekMin returns the value PopMin would return without removing it.
+func (q *Queue[T, P]) PeekMin() (value T, priority P, ok bool) {
+	if len(q.heap) == 0 {
+		return value, priority, false
+	}
+	return q.heap[0].value, q.heap[0].priority, true
+}
+
+// Len returns the number of values waiting in the queue.
+func (q *Queue[T, P]) Len() int {
+	return len(q.heap)
+}
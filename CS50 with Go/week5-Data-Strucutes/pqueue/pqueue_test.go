@@ -0,0 +1,83 @@
+package pqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestPopMinOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []int
+	}{
+		{"empty", nil},
+		{"one", []int{7}},
+		{"sorted", []int{1, 2, 3, 4, 5}},
+		{"reversed", []int{5, 4, 3, 2, 1}},
+		{"duplicates", []int{3, 1, 3, 1, 2}},
+		{"negative", []int{0, -5, 10, -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := New[int, int]()
+			for _, p := range tt.priorities {
+				q.PushWithPriority(p*10, p)
+			}
+			if q.Len() != len(tt.priorities) {
+				t.Fatalf("Len() = %d, want %d", q.Len(), len(tt.priorities))
+			}
+
+			want := append([]int(nil), tt.priorities...)
+			sort.Ints(want)
+			for _, w := range want {
+				value, priority, ok := q.PopMin()
+				if !ok || priority != w || value != w*10 {
+					t.Fatalf("PopMin() = %d, %d, %v, want %d, %d, true", value, priority, ok, w*10, w)
+				}
+			}
+			if _, _, ok := q.PopMin(); ok {
+				t.Fatal("PopMin() on empty queue returned ok")
+			}
+		})
+	}
+}
+
+func TestEqualPrioritiesAreFIFO(t *testing.T) {
+	q := New[string, float64]()
+	q.PushWithPriority("first", 1.5)
+	q.PushWithPriority("urgent", 0.5)
+	q.PushWithPriority("second", 1.5)
+	q.PushWithPriority("third", 1.5)
+
+	for _, want := range []string{"urgent", "first", "second", "third"} {
+		if peek, _, _ := q.PeekMin(); peek != want {
+			t.Fatalf("PeekMin() = %q, want %q", peek, want)
+		}
+		if got, _, _ := q.PopMin(); got != want {
+			t.Fatalf("PopMin() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestInterleavedPushPop(t *testing.T) {
+	rng := rand.New(rand.NewSource(50))
+	q := New[int, int]()
+	var shadow []int
+
+	for i := 0; i < 1000; i++ {
+		if rng.Intn(3) > 0 || len(shadow) == 0 {
+			p := rng.Intn(100)
+			q.PushWithPriority(p, p)
+			shadow = append(shadow, p)
+			continue
+		}
+		sort.Ints(shadow)
+		got, _, _ := q.PopMin()
+		if got != shadow[0] {
+			t.Fatalf("step %d: PopMin() = %d, want %d", i, got, shadow[0])
+		}
+		shadow = shadow[1:]
+	}
+}
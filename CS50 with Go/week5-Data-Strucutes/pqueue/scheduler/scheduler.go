@@ -0,0 +1,47 @@
+// Task scheduler: the most urgent task always runs next (pqueue demo)
+
+package main
+
+import (
+	"cs50"
+	"fmt"
+
+	"pqueue"
+)
+
+// task is one job waiting for the (single) CPU.
+type task struct {
+	name     string
+	duration int
+}
+
+func main() {
+	queue := pqueue.New[task, int]()
+
+	// Collect tasks until an empty name is entered
+	fmt.Println("Enter tasks, leave the name empty to start running them.")
+	for {
+		name := cs50.GetString("Task: ")
+		if name == "" {
+			break
+		}
+		priority := cs50.GetInt("Priority (0 = most urgent): ")
+		duration := cs50.GetInt("Duration (minutes): ")
+		queue.PushWithPriority(task{name: name, duration: duration}, priority)
+	}
+
+	if queue.Len() == 0 {
+		fmt.Println("Nothing to do.")
+		return
+	}
+
+	// Run them: PopMin always hands back the most urgent task left.
+	fmt.Println("\nSCHEDULE")
+	clock := 0
+	for queue.Len() > 0 {
+		t, priority, _ := queue.PopMin()
+		fmt.Printf("%4d min  [p%d] %s (%d min)\n", clock, priority, t.name, t.duration)
+		clock += t.duration
+	}
+	fmt.Printf("%4d min  done\n", clock)
+}
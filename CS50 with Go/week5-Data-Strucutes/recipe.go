@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
 	"strings"
+
+	"rngutil"
 )
 
 // RecipeComponent defines a node in our recipe's dependency tree.
@@ -26,8 +29,13 @@ const INDENT_LENGTH = 4
 
 // main is the entry point of the application.
 func main() {
+	// -seed N gives the same recipe each run.
+	seed := flag.Int64("seed", 0, rngutil.SEED_USAGE)
+	flag.Parse()
+	rng := rngutil.New(seed)
+
 	// Generate the entire recipe structure recursively.
-	finalDish := CreateRecipe(COMPLEXITY)
+	finalDish := CreateRecipe(COMPLEXITY, rng)
 
 	// Traverse the generated structure and print it to the console.
 	PrintRecipe(finalDish, 0)
@@ -35,7 +43,7 @@ func main() {
 
 // CreateRecipe recursively builds a component and its dependencies based on the
 // specified complexity level.
-func CreateRecipe(complexity int) *RecipeComponent {
+func CreateRecipe(complexity int, rng *rand.Rand) *RecipeComponent {
 	// TODO: Allocate memory for a new component.
 	newComponent := &RecipeComponent{}
 	// HINT: newComponent := &RecipeComponent{}
@@ -44,8 +52,8 @@ func CreateRecipe(complexity int) *RecipeComponent {
 	// this component is made of other, simpler components.
 	if complexity > 1 {
 		// Recursively create the two sub-components that make up the current one.
-		subComponent0 := CreateRecipe(complexity - 1)
-		subComponent1 := CreateRecipe(complexity - 1)
+		subComponent0 := CreateRecipe(complexity - 1, rng)
+		subComponent1 := CreateRecipe(complexity - 1, rng)
 
 		// TODO: Assign the newly created children to the current component's SubComponents slice.
 		// HINT: Use the append() function.
@@ -61,7 +69,7 @@ func CreateRecipe(complexity int) *RecipeComponent {
 		// This terminates the recursion for this branch.
 
 		// TODO: Assign a random base ingredient from our predefined list.
-		// HINT: Call the randomIngredient() function.
+		// HINT: Call the randomIngredient(rng) function.
 	}
 
 	// TODO: Return the pointer to the fully constructed component.
@@ -95,7 +103,7 @@ func PrintRecipe(component *RecipeComponent, level int) {
 }
 
 // randomIngredient is a utility function that returns a random base ingredient.
-func randomIngredient() string {
+func randomIngredient(rng *rand.Rand) string {
 	ingredients := []string{"Flour", "Sugar", "Eggs", "Butter", "Chocolate"}
-	return ingredients[rand.Intn(len(ingredients))]
+	return ingredients[rng.Intn(len(ingredients))]
 }
\ No newline at end of file
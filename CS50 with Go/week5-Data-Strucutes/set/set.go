@@ -0,0 +1,118 @@
+// Package set is a generic set built on map[T]struct{}: the map's keys are
+// the members and the empty struct values take no memory.
+package set
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set holds distinct values of T. Make one with New, the zero value is a nil
+// map and can't be added to.
+type Set[T comparable] map[T]struct{}
+
+// New returns a set holding items.
+func New[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+	return s
+}
+
+// Add puts item in the set and reports whether it was new.
+func (s Set[T]) Add(item T) bool {
+	if _, ok := s[item]; ok {
+		return false
+	}
+	s[item] = struct{}{}
+	return true
+}
+
+// Remove takes item out of the set and reports whether it was there.
+func (s Set[T]) Remove(item T) bool {
+	if _, ok := s[item]; !ok {
+		return false
+	}
+	delete(s, item)
+	return true
+}
+
+// Contains reports whether item is in the set.
+func (s Set[T]) Contains(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// Len returns the number of members.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// All iterates over the members in no particular order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// Union returns a new set with the members of both a and b.
+func Union[T comparable](a, b Set[T]) Set[T] {
+	result := make(Set[T], max(len(a), len(b)))
+	for item := range a {
+		result[item] = struct{}{}
+	}
+	for item := range b {
+		result[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns a new set with the members found in both a and b.
+func Intersection[T comparable](a, b Set[T]) Set[T] {
+	// Walk the smaller set, look up in the bigger one
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	result := make(Set[T])
+	for item := range a {
+		if _, ok := b[item]; ok {
+			result[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with the members of a that are not in b.
+func Difference[T comparable](a, b Set[T]) Set[T] {
+	result := make(Set[T])
+	for item := range a {
+		if _, ok := b[item]; !ok {
+			result[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset reports whether every member of a is also in b.
+func IsSubset[T comparable](a, b Set[T]) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for item := range a {
+		if _, ok := b[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether a and b have exactly the same members.
+func Equal[T comparable](a, b Set[T]) bool {
+	return len(a) == len(b) && IsSubset(a, b)
+}
+
+// Sorted returns the members in ascending order, handy for stable output.
+func Sorted[T cmp.Ordered](s Set[T]) []T {
+	return slices.Sorted(maps.Keys(s))
+}
@@ -0,0 +1,133 @@
+package set
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestAlgebra(t *testing.T) {
+	a := New(1, 2, 3, 4)
+	b := New(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{"union", Union(a, b), []int{1, 2, 3, 4, 5}},
+		{"intersection", Intersection(a, b), []int{3, 4}},
+		{"difference a-b", Difference(a, b), []int{1, 2}},
+		{"difference b-a", Difference(b, a), []int{5}},
+		{"union with empty", Union(a, New[int]()), []int{1, 2, 3, 4}},
+		{"intersection with empty", Intersection(New[int](), b), []int{}},
+	}
+	for _, tt := range tests {
+		if got := Sorted(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The inputs are never modified
+	if !Equal(a, New(4, 3, 2, 1)) || !Equal(b, New(5, 4, 3)) {
+		t.Errorf("inputs changed: a = %v, b = %v", Sorted(a), Sorted(b))
+	}
+}
+
+func TestAddRemoveContains(t *testing.T) {
+	s := New[string]()
+	if !s.Add("cat") || s.Add("cat") {
+		t.Fatal("Add should report true only the first time")
+	}
+	if !s.Contains("cat") || s.Contains("dog") || s.Len() != 1 {
+		t.Fatalf("unexpected contents %v", Sorted(s))
+	}
+	if !s.Remove("cat") || s.Remove("cat") || s.Len() != 0 {
+		t.Fatal("Remove should report true only the first time")
+	}
+	if !IsSubset(New[string](), New("x")) || IsSubset(New("x", "y"), New("x")) {
+		t.Fatal("IsSubset wrong")
+	}
+}
+
+// sortedSet is the alternative the benchmarks compare against: a sorted
+// slice, where lookups are binary searches and set operations are merges.
+type sortedSet []int
+
+func newSortedSet(items []int) sortedSet {
+	s := append(sortedSet(nil), items...)
+	sort.Ints(s)
+	return slices.Compact(s)
+}
+
+func (s sortedSet) contains(x int) bool {
+	_, ok := slices.BinarySearch(s, x)
+	return ok
+}
+
+func (s sortedSet) intersection(o sortedSet) sortedSet {
+	var result sortedSet
+	for i, j := 0, 0; i < len(s) && j < len(o); {
+		switch {
+		case s[i] < o[j]:
+			i++
+		case s[i] > o[j]:
+			j++
+		default:
+			result = append(result, s[i])
+			i, j = i+1, j+1
+		}
+	}
+	return result
+}
+
+func benchData(n int) ([]int, []int) {
+	a := make([]int, n)
+	b := make([]int, n)
+	for i := range a {
+		a[i] = (i * 7919) % (2 * n)
+		b[i] = (i * 104729) % (2 * n)
+	}
+	return a, b
+}
+
+var sizes = []int{100, 10_000, 1_000_000}
+
+func BenchmarkContains(b *testing.B) {
+	for _, n := range sizes {
+		items, probes := benchData(n)
+		m := New(items...)
+		s := newSortedSet(items)
+
+		b.Run(fmt.Sprintf("map/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.Contains(probes[i%n])
+			}
+		})
+		b.Run(fmt.Sprintf("sorted/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.contains(probes[i%n])
+			}
+		})
+	}
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	for _, n := range sizes {
+		x, y := benchData(n)
+		mx, my := New(x...), New(y...)
+		sx, sy := newSortedSet(x), newSortedSet(y)
+
+		b.Run(fmt.Sprintf("map/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Intersection(mx, my)
+			}
+		})
+		b.Run(fmt.Sprintf("sorted/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sx.intersection(sy)
+			}
+		})
+	}
+}
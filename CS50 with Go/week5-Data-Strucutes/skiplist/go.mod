@@ -0,0 +1,13 @@
+module skiplist
+
+go 1.24.4
+
+require (
+	bst v0.0.0
+	rngutil v0.0.0
+)
+
+replace (
+	bst => ../bst
+	rngutil => ../../rngutil
+)
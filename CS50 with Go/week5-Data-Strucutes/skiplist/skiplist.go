@@ -0,0 +1,172 @@
+// Package skiplist is a sorted linked list with express lanes: every node
+// is on level 0, about half of them also on level 1, a quarter on level 2
+// and so on. Searching starts on the top lane and drops down, skipping most
+// nodes, so it costs O(log n) on average without any rebalancing.
+package skiplist
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"rngutil"
+)
+
+const (
+	// MAX_LEVEL caps the number of lanes (enough for ~2^32 keys at P = 0.5).
+	MAX_LEVEL = 32
+	// P is the chance a node also appears on the next lane up.
+	P = 0.5
+)
+
+// node has one forward pointer per lane it is on.
+type node[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+	next  []*node[K, V]
+}
+
+// List maps keys to values in ascending key order.
+type List[K cmp.Ordered, V any] struct {
+	head  *node[K, V] // sentinel, on every lane
+	level int         // lanes currently in use
+	size  int
+	rng   *rand.Rand
+}
+
+// New returns an empty list. rng decides the node levels; pass nil for a
+// random seed, or a seeded *rand.Rand for repeatable runs.
+func New[K cmp.Ordered, V any](rng *rand.Rand) *List[K, V] {
+	if rng == nil {
+		rng = rngutil.Random()
+	}
+	return &List[K, V]{
+		head:  &node[K, V]{next: make([]*node[K, V], MAX_LEVEL)},
+		level: 1,
+		rng:   rng,
+	}
+}
+
+// randomLevel flips coins: keep going up a lane while it comes up heads.
+func (l *List[K, V]) randomLevel() int {
+	level := 1
+	for level < MAX_LEVEL && l.rng.Float64() < P {
+		level++
+	}
+	return level
+}
+
+// findPath returns, for every lane, the last node before key.
+func (l *List[K, V]) findPath(key K) [MAX_LEVEL]*node[K, V] {
+	var path [MAX_LEVEL]*node[K, V]
+	cursor := l.head
+	for lane := l.level - 1; lane >= 0; lane-- {
+		for cursor.next[lane] != nil && cursor.next[lane].key < key {
+			cursor = cursor.next[lane]
+		}
+		path[lane] = cursor
+	}
+	return path
+}
+
+// Insert stores value under key, replacing the old value if key exists.
+func (l *List[K, V]) Insert(key K, value V) {
+	path := l.findPath(key)
+	if n := path[0].next[0]; n != nil && n.key == key {
+		n.value = value
+		return
+	}
+
+	level := l.randomLevel()
+	if level > l.level {
+		// New lanes start at the head
+		for lane := l.level; lane < level; lane++ {
+			path[lane] = l.head
+		}
+		l.level = level
+	}
+
+	// Splice the node into every lane it's on, like a linked-list insert
+	n := &node[K, V]{key: key, value: value, next: make([]*node[K, V], level)}
+	for lane := 0; lane < level; lane++ {
+		n.next[lane] = path[lane].next[lane]
+		path[lane].next[lane] = n
+	}
+	l.size++
+}
+
+// Search returns the value stored under key.
+func (l *List[K, V]) Search(key K) (V, bool) {
+	cursor := l.head
+	for lane := l.level - 1; lane >= 0; lane-- {
+		for cursor.next[lane] != nil && cursor.next[lane].key < key {
+			cursor = cursor.next[lane]
+		}
+	}
+	if n := cursor.next[0]; n != nil && n.key == key {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes key and reports whether it was in the list.
+func (l *List[K, V]) Delete(key K) bool {
+	path := l.findPath(key)
+	n := path[0].next[0]
+	if n == nil || n.key != key {
+		return false
+	}
+
+	for lane := 0; lane < len(n.next); lane++ {
+		path[lane].next[lane] = n.next[lane]
+	}
+	// Drop lanes that became empty
+	for l.level > 1 && l.head.next[l.level-1] == nil {
+		l.level--
+	}
+	l.size--
+	return true
+}
+
+// Len returns the number of keys.
+func (l *List[K, V]) Len() int {
+	return l.size
+}
+
+// Levels returns the number of lanes in use.
+func (l *List[K, V]) Levels() int {
+	return l.level
+}
+
+// Keys returns every key in ascending order.
+func (l *List[K, V]) Keys() []K {
+	keys := make([]K, 0, l.size)
+	for n := l.head.next[0]; n != nil; n = n.next[0] {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// String draws the lanes top to bottom, e.g.
+//
+//	L1: head -------> 5 -> NULL
+//	L0: head -> 2 -> 5 -> 9 -> NULL
+func (l *List[K, V]) String() string {
+	var b strings.Builder
+	for lane := l.level - 1; lane >= 0; lane-- {
+		fmt.Fprintf(&b, "L%d: head", lane)
+		for n := l.head.next[0]; n != nil; n = n.next[0] {
+			label := fmt.Sprint(n.key)
+			if len(n.next) > lane {
+				b.WriteString(" -> " + label)
+			} else {
+				// Not on this lane: draw the express lane passing over it
+				b.WriteString(strings.Repeat("-", len(label)+4))
+			}
+		}
+		b.WriteString(" -> NULL\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"bst"
+)
+
+func TestInsertSearchDelete(t *testing.T) {
+	l := New[int, string](rand.New(rand.NewSource(1)))
+	for _, k := range []int{5, 2, 9, 7, 1, 5} {
+		l.Insert(k, fmt.Sprint("v", k))
+	}
+
+	if got, want := l.Keys(), []int{1, 2, 5, 7, 9}; !slices.Equal(got, want) {
+		t.Fatalf("Keys() = %v, want %v", got, want)
+	}
+	if v, ok := l.Search(7); !ok || v != "v7" {
+		t.Fatalf("Search(7) = %q, %v", v, ok)
+	}
+	if _, ok := l.Search(3); ok {
+		t.Fatal("Search(3) found a missing key")
+	}
+
+	if !l.Delete(5) || l.Delete(5) || l.Len() != 4 {
+		t.Fatal("Delete(5) should succeed once")
+	}
+	if got, want := l.Keys(), []int{1, 2, 7, 9}; !slices.Equal(got, want) {
+		t.Fatalf("Keys() after delete = %v, want %v", got, want)
+	}
+}
+
+func TestMatchesMapOnRandomOps(t *testing.T) {
+	rng := rand.New(rand.NewSource(50))
+	l := New[int, int](rng)
+	shadow := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		k := rng.Intn(500)
+		switch rng.Intn(3) {
+		case 0:
+			l.Insert(k, i)
+			shadow[k] = i
+		case 1:
+			_, inShadow := shadow[k]
+			if l.Delete(k) != inShadow {
+				t.Fatalf("step %d: Delete(%d) disagrees with map", i, k)
+			}
+			delete(shadow, k)
+		default:
+			v, ok := l.Search(k)
+			if w, inShadow := shadow[k]; ok != inShadow || v != w {
+				t.Fatalf("step %d: Search(%d) = %d, %v, want %d, %v", i, k, v, ok, w, inShadow)
+			}
+		}
+	}
+	if l.Len() != len(shadow) {
+		t.Fatalf("Len() = %d, want %d", l.Len(), len(shadow))
+	}
+}
+
+// orders returns the keys 0..n-1 shuffled and sorted. Sorted input is the
+// adversarial case: the BST degenerates into a list, the skip list doesn't care.
+func orders(n int) map[string][]int {
+	random := rand.New(rand.NewSource(1)).Perm(n)
+	sorted := make([]int, n)
+	for i := range sorted {
+		sorted[i] = i
+	}
+	return map[string][]int{"random": random, "sorted": sorted}
+}
+
+func BenchmarkInsertAndSearch(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		for _, name := range []string{"random", "sorted"} {
+			keys := orders(n)[name]
+
+			b.Run(fmt.Sprintf("skiplist/%s/%d", name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					l := New[int, int](rand.New(rand.NewSource(int64(i))))
+					for _, k := range keys {
+						l.Insert(k, k)
+					}
+					for _, k := range keys {
+						l.Search(k)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("bst/%s/%d", name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					t := bst.New[int, int]()
+					for _, k := range keys {
+						t.Insert(k, k)
+					}
+					for _, k := range keys {
+						t.Search(k)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"memstats"
+	"render"
+	"speller/dictionary"
+)
+
+// benchStructures are compared in this order, map first as the baseline.
+var benchStructures = []string{"map", "hashtable", "trie"}
+
+// benchResult is one row of the comparison table, and of what --format
+// prints instead.
+type benchResult struct {
+	Name         string  `json:"structure"`
+	Size         int     `json:"words"`
+	Misspellings int     `json:"misspelled"`
+	Load         float64 `json:"load_seconds"`
+	Check        float64 `json:"check_seconds"`
+	PeakHeap     uint64  `json:"peak_heap_bytes"`
+	RetainedHeap uint64  `json:"retained_bytes"`
+}
+
+// runBench implements `speller bench [DICTIONARY] text...`.
+func runBench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	dictPath := fs.String("dict", DICTIONARY, "dictionary file to load")
+	fs.Usage = func() {
+		fmt.Println("Usage: ./speller bench [-dict DICTIONARY] text...")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	// Read every text up front so file I/O isn't part of the check time.
+	var words []string
+	for _, path := range fs.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Printf("Could not open %s.\n", path)
+			os.Exit(1)
+		}
+		err = dictionary.Words(file, func(word string) {
+			words = append(words, word)
+		})
+		file.Close()
+		if err != nil {
+			fmt.Printf("Error reading %s.\n", path)
+			os.Exit(1)
+		}
+	}
+
+	var results []benchResult
+	for _, name := range benchStructures {
+		result, err := benchOne(name, *dictPath, words)
+		if err != nil {
+			fmt.Printf("Could not load %s.\n", *dictPath)
+			os.Exit(1)
+		}
+		results = append(results, result)
+	}
+
+	if !render.Text() {
+		if err := render.Print(results); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Printf("\nDICTIONARY:  %s\n", *dictPath)
+	fmt.Printf("TEXTS:       %d\n", fs.NArg())
+	fmt.Printf("WORDS:       %d\n\n", len(words))
+	fmt.Printf("%-10s %10s %11s %10s %10s %12s %12s\n", "structure", "words", "misspelled", "load", "check", "peak heap", "retained")
+	for _, r := range results {
+		fmt.Printf("%-10s %10d %11d %9.3fs %9.3fs %12s %12s\n",
+			r.Name, r.Size, r.Misspellings, r.Load, r.Check, memstats.FormatBytes(r.PeakHeap), memstats.FormatBytes(r.RetainedHeap))
+	}
+	fmt.Println()
+}
+
+// benchOne loads the dictionary into a fresh structure and checks every word.
+func benchOne(name, dictPath string, words []string) (benchResult, error) {
+	result := benchResult{Name: name}
+
+	d, err := newDictionary(name)
+	if err != nil {
+		return result, err
+	}
+
+	// Start from a clean heap so the numbers only describe this structure.
+	var before, afterLoad, afterGC runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	err = dictionary.LoadFile(d, dictPath)
+	result.Load = time.Since(start).Seconds()
+	if err != nil {
+		return result, err
+	}
+
+	// Before collecting, the heap still holds the garbage made while loading,
+	// that's as close to the peak as MemStats can tell us.
+	runtime.ReadMemStats(&afterLoad)
+	runtime.GC()
+	runtime.ReadMemStats(&afterGC)
+	result.PeakHeap = heapDelta(before, afterLoad)
+	result.RetainedHeap = heapDelta(before, afterGC)
+
+	start = time.Now()
+	for _, word := range words {
+		if !d.Check(word) {
+			result.Misspellings++
+		}
+	}
+	result.Check = time.Since(start).Seconds()
+
+	result.Size = d.Size()
+	d.Unload()
+	return result, nil
+}
+
+func heapDelta(before, after runtime.MemStats) uint64 {
+	if after.HeapAlloc < before.HeapAlloc {
+		return 0
+	}
+	return after.HeapAlloc - before.HeapAlloc
+}
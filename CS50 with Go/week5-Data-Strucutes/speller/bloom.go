@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"speller/bloomfilter"
+	"speller/dictionary"
+)
+
+// bloomDictionary answers "definitely not a word" from the bloom filter and
+// only asks the real dictionary when the filter says "maybe".
+type bloomDictionary struct {
+	dictionary.Dictionary
+	filter *bloomfilter.Filter
+
+	// Counters are atomic so -parallel can share the wrapper.
+	rejected       atomic.Int64 // misspellings caught by the filter alone
+	falsePositives atomic.Int64 // filter said maybe, dictionary said no
+}
+
+func newBloomDictionary(d dictionary.Dictionary, bits uint64, hashes int) *bloomDictionary {
+	return &bloomDictionary{Dictionary: d, filter: bloomfilter.New(bits, hashes)}
+}
+
+// Load fills both the filter and the wrapped dictionary from the same words.
+func (b *bloomDictionary) Load(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word != "" {
+			b.filter.Add(word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return b.Dictionary.Load(bytes.NewReader(data))
+}
+
+// Check skips the dictionary lookup whenever the filter rules the word out.
+func (b *bloomDictionary) Check(word string) bool {
+	if !b.filter.MayContain(strings.ToLower(word)) {
+		b.rejected.Add(1)
+		return false
+	}
+
+	found := b.Dictionary.Check(word)
+	if !found {
+		b.falsePositives.Add(1)
+	}
+	return found
+}
+
+// printStats reports how much work the filter saved and how often it lied.
+func (b *bloomDictionary) printStats() {
+	rejected, falsePositives := b.rejected.Load(), b.falsePositives.Load()
+	absent := rejected + falsePositives
+	observed := 0.0
+	if absent > 0 {
+		observed = float64(falsePositives) / float64(absent) * 100
+	}
+
+	fmt.Printf("BLOOM FILTER:         %d bits, %d hashes\n", b.filter.Bits(), b.filter.Hashes())
+	fmt.Printf("BLOOM REJECTED:       %d\n", rejected)
+	fmt.Printf("BLOOM FALSE POSITIVE: %d\n", falsePositives)
+	fmt.Printf("FALSE POSITIVE RATE:  %.2f%% (expected %.2f%%)\n\n", observed, b.filter.EstimatedFalsePositiveRate()*100)
+}
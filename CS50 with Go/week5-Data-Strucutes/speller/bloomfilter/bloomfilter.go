@@ -0,0 +1,95 @@
+// Package bloomfilter is a probabilistic set: it can answer "definitely not
+// here" or "maybe here" using a fixed number of bits, no matter how long the
+// stored words are.
+package bloomfilter
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// Filter is an m-bit array where every added item sets k bits.
+type Filter struct {
+	bits []uint64
+	m    uint64
+	k    int
+	n    int
+}
+
+// New returns a filter with m bits and k hash functions.
+func New(m uint64, k int) *Filter {
+	if m == 0 {
+		m = 1
+	}
+	if k < 1 {
+		k = 1
+	}
+	return &Filter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// NewWithEstimates sizes a filter for n items at false-positive rate p,
+// using m = -n*ln(p)/ln(2)^2 and k = m/n*ln(2).
+func NewWithEstimates(n int, p float64) *Filter {
+	if n < 1 {
+		n = 1
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return New(uint64(m), int(k))
+}
+
+// Add sets the k bits for s.
+func (f *Filter) Add(s string) {
+	h1, h2 := hashes(s)
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+	f.n++
+}
+
+// MayContain returns false if s was never added. A true answer can be wrong
+// (a false positive) when other items happen to have set all of s's bits.
+func (f *Filter) MayContain(s string) bool {
+	h1, h2 := hashes(s)
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bits returns m, the size of the filter in bits.
+func (f *Filter) Bits() uint64 {
+	return f.m
+}
+
+// Hashes returns k, the number of bits set per item.
+func (f *Filter) Hashes() int {
+	return f.k
+}
+
+// Len returns the number of items added.
+func (f *Filter) Len() int {
+	return f.n
+}
+
+// EstimatedFalsePositiveRate is the theoretical rate (1 - e^(-kn/m))^k
+// for the items added so far.
+func (f *Filter) EstimatedFalsePositiveRate() float64 {
+	k, n, m := float64(f.k), float64(f.n), float64(f.m)
+	return math.Pow(1-math.Exp(-k*n/m), k)
+}
+
+// hashes returns the two base hashes used to simulate k hash functions
+// (Kirsch-Mitzenmacher: g_i = h1 + i*h2).
+func hashes(s string) (uint64, uint64) {
+	a := fnv.New64a()
+	a.Write([]byte(s))
+	b := fnv.New64()
+	b.Write([]byte(s))
+	// An odd h2 never gets stuck repeating the same bit.
+	return a.Sum64(), b.Sum64() | 1
+}
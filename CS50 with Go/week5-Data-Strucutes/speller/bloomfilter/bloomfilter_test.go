@@ -0,0 +1,109 @@
+package bloomfilter
+
+import (
+	"bufio"
+	"math"
+	"strings"
+	"testing"
+)
+
+// dictionary is every lowercase word of one to three letters, one per line:
+// 18,278 words, about the size of a small real one.
+func dictionary() string {
+	var b strings.Builder
+	var words func(prefix string, length int)
+	words = func(prefix string, length int) {
+		if length == 0 {
+			b.WriteString(prefix + "\n")
+			return
+		}
+		for c := 'a'; c <= 'z'; c++ {
+			words(prefix+string(c), length-1)
+		}
+	}
+	for length := 1; length <= 3; length++ {
+		words("", length)
+	}
+	return b.String()
+}
+
+// load adds every line of dict to f, the way speller's -bloom does.
+func load(t *testing.T, f *Filter, dict string) []string {
+	t.Helper()
+	var added []string
+	scanner := bufio.NewScanner(strings.NewReader(dict))
+	for scanner.Scan() {
+		f.Add(scanner.Text())
+		added = append(added, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return added
+}
+
+func TestNoFalseNegatives(t *testing.T) {
+	for _, f := range []*Filter{NewWithEstimates(18278, 0.01), New(1024, 3), New(0, 0)} {
+		words := load(t, f, dictionary())
+		if f.Len() != len(words) {
+			t.Errorf("m=%d k=%d: Len() = %d, want %d", f.Bits(), f.Hashes(), f.Len(), len(words))
+		}
+		for _, word := range words {
+			if !f.MayContain(word) {
+				t.Fatalf("m=%d k=%d: MayContain(%q) = false after Add", f.Bits(), f.Hashes(), word)
+			}
+		}
+	}
+}
+
+func TestFalsePositiveRate(t *testing.T) {
+	tests := []float64{0.1, 0.01, 0.001}
+	for _, p := range tests {
+		f := NewWithEstimates(18278, p)
+		load(t, f, dictionary())
+		if est := f.EstimatedFalsePositiveRate(); math.Abs(est-p) > p/10 {
+			t.Errorf("p=%g: EstimatedFalsePositiveRate() = %g", p, est)
+		}
+
+		// Four-letter words were never added, so every maybe is a lie
+		probes, positives := 0, 0
+		for _, a := range "abcdefghijklmnopqrstuvwxyz" {
+			for _, b := range "abcdefghijklmnopqrstuvwxyz" {
+				for _, c := range "aeiou" {
+					for _, d := range "bdgkmnprst" {
+						probes++
+						if f.MayContain(string([]rune{a, b, c, d})) {
+							positives++
+						}
+					}
+				}
+			}
+		}
+		// 33,800 probes: the measured rate is within a factor of two of p
+		if rate := float64(positives) / float64(probes); rate > 2*p || rate < p/2 {
+			t.Errorf("p=%g: %d false positives in %d probes, rate %g", p, positives, probes, rate)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		n      int
+		p      float64
+		bits   uint64
+		hashes int
+	}{
+		{1000, 0.01, 9586, 7}, // the textbook 9.6 bits and 7 hashes per item
+		{1000, 0.1, 4793, 3},
+		{0, 0.01, 10, 7}, // sized for at least one item
+	}
+	for _, tt := range tests {
+		f := NewWithEstimates(tt.n, tt.p)
+		if f.Bits() != tt.bits || f.Hashes() != tt.hashes {
+			t.Errorf("NewWithEstimates(%d, %g) = %d bits, %d hashes, want %d, %d", tt.n, tt.p, f.Bits(), f.Hashes(), tt.bits, tt.hashes)
+		}
+	}
+	if f := New(0, 0); f.Bits() != 1 || f.Hashes() != 1 {
+		t.Errorf("New(0, 0) = %d bits, %d hashes, want 1, 1", f.Bits(), f.Hashes())
+	}
+}
@@ -0,0 +1,111 @@
+// Package dictionary declares what every speller dictionary must do,
+// mirroring the load/check/size/unload functions of CS50's dictionary.h.
+package dictionary
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+// LENGTH is the maximum length for a word (same value as dictionary.h).
+// e.g. pneumonoultramicroscopicsilicovolcanoconiosis
+const LENGTH = 45
+
+// Dictionary is implemented by every data structure speller can use.
+// Words are checked case-insensitively.
+type Dictionary interface {
+	// Load reads one word per line from r into the dictionary.
+	Load(r io.Reader) error
+	// Check returns true if word is in the dictionary.
+	Check(word string) bool
+	// Size returns the number of words loaded.
+	Size() int
+	// Unload frees everything the dictionary holds.
+	Unload()
+}
+
+// LoadFile opens the dictionary file at path and loads it into d.
+func LoadFile(d Dictionary, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return d.Load(file)
+}
+
+// Words reads text from r exactly like speller.c does and calls fn for each word.
+// A word is letters plus apostrophes (not at the start), words that contain
+// digits are ignored and words longer than LENGTH are skipped.
+func Words(r io.Reader, fn func(word string)) error {
+	reader := bufio.NewReader(r)
+	word := make([]byte, 0, LENGTH+1)
+
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if isAlpha(c) || (c == '\'' && len(word) > 0) {
+			// Allow only alphabetical characters and apostrophes
+			word = append(word, c)
+
+			// Ignore alphabetical strings too long to be words
+			if len(word) > LENGTH {
+				if err := skip(reader, isAlpha); err != nil {
+					return err
+				}
+				word = word[:0]
+			}
+		} else if isDigit(c) {
+			// Ignore words with numbers (like MS Word can)
+			if err := skip(reader, isAlnum); err != nil {
+				return err
+			}
+			word = word[:0]
+		} else if len(word) > 0 {
+			// We must have found a whole word
+			fn(string(word))
+			word = word[:0]
+		}
+	}
+
+	// The last word of a file that doesn't end with a newline
+	if len(word) > 0 {
+		fn(string(word))
+	}
+	return nil
+}
+
+// skip consumes bytes while keep returns true, plus the first byte that doesn't.
+func skip(reader *bufio.Reader, keep func(byte) bool) error {
+	for {
+		c, err := reader.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !keep(c) {
+			return nil
+		}
+	}
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isAlnum(c byte) bool {
+	return isAlpha(c) || isDigit(c)
+}
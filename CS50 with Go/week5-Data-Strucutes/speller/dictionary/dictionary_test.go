@@ -0,0 +1,84 @@
+package dictionary_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"speller/dictionary"
+	"speller/hashtable"
+	"speller/mapdict"
+	"speller/trie"
+)
+
+func TestWords(t *testing.T) {
+	long := strings.Repeat("a", dictionary.LENGTH)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"A cat is not a caterpillar.", []string{"A", "cat", "is", "not", "a", "caterpillar"}},
+		{"Hello,world\n\tagain", []string{"Hello", "world", "again"}},
+		{"it's don't", []string{"it's", "don't"}},
+		{"'tis", []string{"tis"}},                 // no apostrophe at the start
+		{"dogs' toys", []string{"dogs'", "toys"}}, // but at the end, like speller.c
+		{"abc123 def", []string{"def"}},           // words with digits are skipped whole
+		{"123abc def", []string{"def"}},
+		{"CS50 is fun", []string{"is", "fun"}},
+		{long + " b", []string{long, "b"}}, // LENGTH letters is still a word
+		{long + "a b", []string{"b"}},      // one more isn't
+		{"last", []string{"last"}},         // no newline at the end
+	}
+	for _, tt := range tests {
+		var got []string
+		if err := dictionary.Words(strings.NewReader(tt.text), func(word string) { got = append(got, word) }); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+// structures is every Dictionary speller's -impl can pick.
+func structures() map[string]dictionary.Dictionary {
+	return map[string]dictionary.Dictionary{
+		"hashtable": hashtable.New(),
+		"sharded":   hashtable.NewSharded(4),
+		"trie":      trie.New(),
+		"map":       mapdict.New(),
+	}
+}
+
+// Every structure answers the same for the same dictionary, duplicates,
+// case and blank lines included.
+func TestConformance(t *testing.T) {
+	const words = "cat\nCaterpillar\ncat\n\n  dog  \nit's\nCAT\n"
+	for name, d := range structures() {
+		if err := d.Load(strings.NewReader(words)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if d.Size() != 4 {
+			t.Errorf("%s: Size() = %d, want 4", name, d.Size())
+		}
+		for _, word := range []string{"cat", "CAT", "cAterpillar", "dog", "it's", "IT'S"} {
+			if !d.Check(word) {
+				t.Errorf("%s: Check(%q) = false", name, word)
+			}
+		}
+		for _, word := range []string{"", "ca", "cats", "do", "dog ", "its", "caterpillars"} {
+			if d.Check(word) {
+				t.Errorf("%s: Check(%q) = true", name, word)
+			}
+		}
+
+		d.Unload()
+		if d.Size() != 0 || d.Check("cat") {
+			t.Errorf("%s: Unload() left words behind", name)
+		}
+		if err := d.Load(strings.NewReader("dog\n")); err != nil || d.Size() != 1 || !d.Check("dog") || d.Check("cat") {
+			t.Errorf("%s: loading again after Unload: Size() = %d, %v", name, d.Size(), err)
+		}
+	}
+}
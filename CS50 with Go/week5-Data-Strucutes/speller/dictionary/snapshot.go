@@ -0,0 +1,120 @@
+package dictionary
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// SNAPSHOT_MAGIC starts every snapshot file, the last byte is the format version.
+const SNAPSHOT_MAGIC = "SPELLER1"
+
+// ErrStaleSnapshot means the snapshot was built from another dictionary file
+// (or by another structure) and has to be rebuilt.
+var ErrStaleSnapshot = errors.New("dictionary: snapshot is stale")
+
+// Snapshotter is a Dictionary that can dump its loaded structure to a binary
+// snapshot and restore it without parsing the word list again.
+type Snapshotter interface {
+	Dictionary
+	// WriteSnapshot writes the loaded structure to w.
+	WriteSnapshot(w io.Writer) error
+	// ReadSnapshot replaces the dictionary's contents with a snapshot from r.
+	ReadSnapshot(r io.Reader) error
+}
+
+// LoadCached loads the dictionary at dictPath into d, going through the
+// snapshot at snapPath when it is still valid. kind names the structure so a
+// trie never reads a hash table's snapshot. A missing or stale snapshot is
+// (re)written after a normal load. fromSnapshot reports which path was taken.
+func LoadCached(d Snapshotter, kind, dictPath, snapPath string) (fromSnapshot bool, err error) {
+	source, err := os.ReadFile(dictPath)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(source)
+
+	if err := readSnapshotFile(d, kind, sum, snapPath); err == nil {
+		return true, nil
+	}
+
+	// No usable snapshot, parse the word list like LoadFile does.
+	d.Unload()
+	if err := d.Load(bytes.NewReader(source)); err != nil {
+		return false, err
+	}
+	if err := writeSnapshotFile(d, kind, sum, snapPath); err != nil {
+		return false, fmt.Errorf("dictionary: writing snapshot: %w", err)
+	}
+	return false, nil
+}
+
+// readSnapshotFile checks the header of the snapshot against kind and sum
+// before handing the rest of the file to d.
+func readSnapshotFile(d Snapshotter, kind string, sum [sha256.Size]byte, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, len(SNAPSHOT_MAGIC)+1)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return err
+	}
+	if string(header[:len(SNAPSHOT_MAGIC)]) != SNAPSHOT_MAGIC {
+		return ErrStaleSnapshot
+	}
+
+	gotKind := make([]byte, header[len(SNAPSHOT_MAGIC)])
+	if _, err := io.ReadFull(reader, gotKind); err != nil {
+		return err
+	}
+	var gotSum [sha256.Size]byte
+	if _, err := io.ReadFull(reader, gotSum[:]); err != nil {
+		return err
+	}
+	if string(gotKind) != kind || gotSum != sum {
+		return ErrStaleSnapshot
+	}
+
+	return d.ReadSnapshot(reader)
+}
+
+// writeSnapshotFile writes the header (magic, kind, source hash) and the
+// structure. It writes to a temporary file first so an interrupted run never
+// leaves a half-written snapshot behind.
+func writeSnapshotFile(d Snapshotter, kind string, sum [sha256.Size]byte, path string) error {
+	if len(kind) > 255 {
+		return fmt.Errorf("kind %q too long", kind)
+	}
+
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	writer.WriteString(SNAPSHOT_MAGIC)
+	writer.WriteByte(byte(len(kind)))
+	writer.WriteString(kind)
+	writer.Write(sum[:])
+	err = d.WriteSnapshot(writer)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
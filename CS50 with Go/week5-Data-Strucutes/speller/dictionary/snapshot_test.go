@@ -0,0 +1,109 @@
+package dictionary_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"speller/dictionary"
+	"speller/hashtable"
+	"speller/trie"
+)
+
+// snapshotters is every structure that can be snapshotted, by the kind
+// speller names its snapshots after.
+func snapshotters() map[string]func() dictionary.Snapshotter {
+	return map[string]func() dictionary.Snapshotter{
+		"hashtable": func() dictionary.Snapshotter { return hashtable.New() },
+		"trie":      func() dictionary.Snapshotter { return trie.New() },
+	}
+}
+
+const WORDS = "a\ncat\ncaterpillar\nit's\nzebra\n"
+
+// A snapshot holds the same words as the list it was made from.
+func TestSnapshotRoundTrip(t *testing.T) {
+	for kind, fresh := range snapshotters() {
+		d := fresh()
+		if err := d.Load(bytes.NewBufferString(WORDS)); err != nil {
+			t.Fatal(err)
+		}
+		var snapshot bytes.Buffer
+		if err := d.WriteSnapshot(&snapshot); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+
+		restored := fresh()
+		restored.Load(bytes.NewBufferString("dog\n")) // replaced, not added to
+		if err := restored.ReadSnapshot(&snapshot); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if restored.Size() != 5 || restored.Check("dog") {
+			t.Errorf("%s: restored Size() = %d, dog %v", kind, restored.Size(), restored.Check("dog"))
+		}
+		for _, word := range []string{"a", "CAT", "caterpillar", "it's", "zebra"} {
+			if !restored.Check(word) {
+				t.Errorf("%s: %q lost in the snapshot", kind, word)
+			}
+		}
+		for _, word := range []string{"ca", "cats", "its"} {
+			if restored.Check(word) {
+				t.Errorf("%s: %q appeared in the snapshot", kind, word)
+			}
+		}
+
+		// A cut-off snapshot is an error, not a smaller dictionary
+		var full bytes.Buffer
+		d.WriteSnapshot(&full)
+		if err := fresh().ReadSnapshot(bytes.NewReader(full.Bytes()[:full.Len()/2])); err == nil {
+			t.Errorf("%s: reading half a snapshot worked", kind)
+		}
+	}
+}
+
+// LoadCached parses the list once, then reads the snapshot until the
+// dictionary file changes.
+func TestLoadCached(t *testing.T) {
+	for kind, fresh := range snapshotters() {
+		dir := t.TempDir()
+		dictPath := filepath.Join(dir, "words")
+		snapPath := dictPath + "." + kind + ".snap"
+		os.WriteFile(dictPath, []byte(WORDS), 0644)
+
+		load := func(wantSnapshot bool, wantSize int) dictionary.Snapshotter {
+			t.Helper()
+			d := fresh()
+			fromSnapshot, err := dictionary.LoadCached(d, kind, dictPath, snapPath)
+			if err != nil || fromSnapshot != wantSnapshot || d.Size() != wantSize {
+				t.Fatalf("%s: LoadCached = %v, %v with %d words, want %v with %d", kind, fromSnapshot, err, d.Size(), wantSnapshot, wantSize)
+			}
+			return d
+		}
+
+		load(false, 5) // writes the snapshot
+		if _, err := os.Stat(snapPath); err != nil {
+			t.Fatalf("%s: no snapshot: %v", kind, err)
+		}
+		load(true, 5)
+
+		// Another word in the dictionary changes its SHA-256: the snapshot
+		// is stale and is rebuilt with the new word.
+		os.WriteFile(dictPath, []byte(WORDS+"dog\n"), 0644)
+		if d := load(false, 6); !d.Check("dog") {
+			t.Errorf("%s: the rebuilt dictionary has no dog", kind)
+		}
+		if d := load(true, 6); !d.Check("dog") {
+			t.Errorf("%s: the rebuilt snapshot has no dog", kind)
+		}
+
+		// So is a snapshot of another structure, and a file that isn't one
+		if fromSnapshot, err := dictionary.LoadCached(fresh(), "other", dictPath, snapPath); fromSnapshot || err != nil {
+			t.Errorf("%s: read its snapshot as another kind's: %v, %v", kind, fromSnapshot, err)
+		}
+		os.WriteFile(snapPath, []byte("not a snapshot"), 0644)
+		if fromSnapshot, err := dictionary.LoadCached(fresh(), kind, dictPath, snapPath); fromSnapshot || err != nil {
+			t.Errorf("%s: read garbage as a snapshot: %v, %v", kind, fromSnapshot, err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+module speller
+
+go 1.24.4
+
+require (
+	memstats v0.0.0
+	profiling v0.0.0
+	render v0.0.0
+	set v0.0.0
+)
+
+require runctx v0.0.0 // indirect
+
+replace (
+	memstats => ../memstats
+	profiling => ../../profiling
+	render => ../../render
+	runctx => ../../runctx
+	set => ../set
+)
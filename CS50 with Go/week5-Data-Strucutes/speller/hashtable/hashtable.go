@@ -0,0 +1,123 @@
+// Package hashtable implements the speller dictionary as a hash table with
+// separate chaining, the structure the CS50 distribution code starts from.
+package hashtable
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// N is the number of buckets in the hash table.
+const N = 1 << 16
+
+// node represents a word in one bucket's linked list.
+type node struct {
+	word string
+	next *node
+}
+
+// Table is a fixed-size hash table of linked lists.
+type Table struct {
+	buckets [N]*node
+	size    int
+}
+
+// New returns an empty hash table.
+func New() *Table {
+	return &Table{}
+}
+
+// Load reads one word per line from r and inserts each into its bucket.
+func (t *Table) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" {
+			continue
+		}
+
+		// A word listed twice is one word, like in the trie and the map.
+		index := hash(word)
+		if contains(t.buckets[index], word) {
+			continue
+		}
+
+		// Insert at the head of the list
+		t.buckets[index] = &node{word: word, next: t.buckets[index]}
+		t.size++
+	}
+	return scanner.Err()
+}
+
+// Check returns true if word is in the table, ignoring case.
+func (t *Table) Check(word string) bool {
+	word = strings.ToLower(word)
+	return contains(t.buckets[hash(word)], word)
+}
+
+// contains walks the chain starting at head looking for a lowercase word.
+func contains(head *node, word string) bool {
+	for cursor := head; cursor != nil; cursor = cursor.next {
+		if cursor.word == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete removes word and reports whether it was there, ignoring case.
+func (t *Table) Delete(word string) bool {
+	word = strings.ToLower(word)
+	// A pointer to the link to fix, so the head of the chain is no special case
+	for link := &t.buckets[hash(word)]; *link != nil; link = &(*link).next {
+		if (*link).word == word {
+			*link = (*link).next
+			t.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Buckets calls visit for every bucket with words in it, in order, with
+// the words as its chain holds them.
+func (t *Table) Buckets(visit func(index int, words []string)) {
+	for i, head := range t.buckets {
+		if head == nil {
+			continue
+		}
+		var words []string
+		for cursor := head; cursor != nil; cursor = cursor.next {
+			words = append(words, cursor.word)
+		}
+		visit(i, words)
+	}
+}
+
+// Size returns the number of words loaded.
+func (t *Table) Size() int {
+	return t.size
+}
+
+// Unload drops every bucket so the garbage collector can free the nodes.
+func (t *Table) Unload() {
+	for i := range t.buckets {
+		t.buckets[i] = nil
+	}
+	t.size = 0
+}
+
+// Bucket is the bucket word goes in, whatever its case.
+func Bucket(word string) int {
+	return int(hash(strings.ToLower(word)))
+}
+
+// hash maps a lowercase word to a bucket using djb2.
+func hash(word string) uint32 {
+	var h uint32 = 5381
+	for i := 0; i < len(word); i++ {
+		h = h*33 + uint32(word[i])
+	}
+	return h % N
+}
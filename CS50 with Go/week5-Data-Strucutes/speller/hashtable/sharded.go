@@ -0,0 +1,115 @@
+package hashtable
+
+import (
+	"bufio"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// STRIPES is the default number of locks guarding a Sharded table.
+const STRIPES = 64
+
+// Sharded is a Table that is safe for concurrent use. Instead of one lock for
+// the whole table, bucket i is guarded by lock i % stripes (lock striping),
+// so goroutines working on different buckets rarely wait for each other.
+type Sharded struct {
+	buckets [N]*node
+	locks   []sync.RWMutex
+
+	sizeLock sync.Mutex
+	size     int
+}
+
+// NewSharded returns an empty table guarded by the given number of locks.
+func NewSharded(stripes int) *Sharded {
+	if stripes < 1 {
+		stripes = STRIPES
+	}
+	return &Sharded{locks: make([]sync.RWMutex, stripes)}
+}
+
+// lockFor returns the lock guarding bucket index.
+func (t *Sharded) lockFor(index uint32) *sync.RWMutex {
+	return &t.locks[index%uint32(len(t.locks))]
+}
+
+// Add inserts word, it may be called from many goroutines at once. A word
+// that's already there isn't added again.
+func (t *Sharded) Add(word string) {
+	word = strings.ToLower(word)
+	index := hash(word)
+
+	lock := t.lockFor(index)
+	lock.Lock()
+	if contains(t.buckets[index], word) {
+		lock.Unlock()
+		return
+	}
+	t.buckets[index] = &node{word: word, next: t.buckets[index]}
+	lock.Unlock()
+
+	t.sizeLock.Lock()
+	t.size++
+	t.sizeLock.Unlock()
+}
+
+// Load reads one word per line from r and inserts them using one goroutine
+// per CPU.
+func (t *Sharded) Load(r io.Reader) error {
+	words := make(chan string, 1024)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for word := range words {
+				t.Add(word)
+			}
+		}()
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			words <- word
+		}
+	}
+	close(words)
+	wg.Wait()
+	return scanner.Err()
+}
+
+// Check returns true if word is in the table, ignoring case.
+func (t *Sharded) Check(word string) bool {
+	word = strings.ToLower(word)
+	index := hash(word)
+
+	lock := t.lockFor(index)
+	lock.RLock()
+	defer lock.RUnlock()
+	return contains(t.buckets[index], word)
+}
+
+// Size returns the number of words loaded.
+func (t *Sharded) Size() int {
+	t.sizeLock.Lock()
+	defer t.sizeLock.Unlock()
+	return t.size
+}
+
+// Unload drops every bucket, taking each stripe's lock in turn.
+func (t *Sharded) Unload() {
+	for s := range t.locks {
+		t.locks[s].Lock()
+		for i := s; i < N; i += len(t.locks) {
+			t.buckets[i] = nil
+		}
+		t.locks[s].Unlock()
+	}
+
+	t.sizeLock.Lock()
+	t.size = 0
+	t.sizeLock.Unlock()
+}
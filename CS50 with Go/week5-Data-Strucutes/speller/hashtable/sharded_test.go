@@ -0,0 +1,119 @@
+package hashtable
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testWords returns n distinct lowercase words.
+func testWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%c%c%c", 'a'+i%26, 'a'+i/26%26, 'a'+i/676%26)
+	}
+	return words
+}
+
+func TestShardedLoadMatchesTable(t *testing.T) {
+	words := testWords(5000)
+	text := strings.Join(words, "\n")
+
+	serial := New()
+	if err := serial.Load(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	sharded := NewSharded(8)
+	if err := sharded.Load(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+
+	if serial.Size() != sharded.Size() {
+		t.Fatalf("Size() = %d, want %d", sharded.Size(), serial.Size())
+	}
+	for _, w := range append(words, "missing", "WORDAAA") {
+		if got, want := sharded.Check(w), serial.Check(w); got != want {
+			t.Errorf("Check(%q) = %v, want %v", w, got, want)
+		}
+	}
+}
+
+// Run with -race: writers and readers touch the same stripes at once.
+func TestShardedConcurrentAddAndCheck(t *testing.T) {
+	words := testWords(4000)
+	table := NewSharded(4)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < len(words); i += 8 {
+				table.Add(words[i])
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for _, w := range words {
+				table.Check(w)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if table.Size() != len(words) {
+		t.Fatalf("Size() = %d, want %d", table.Size(), len(words))
+	}
+	for _, w := range words {
+		if !table.Check(w) {
+			t.Fatalf("Check(%q) = false after concurrent Add", w)
+		}
+	}
+
+	table.Unload()
+	if table.Size() != 0 || table.Check(words[0]) {
+		t.Fatal("Unload() left words behind")
+	}
+}
+
+func BenchmarkCheckSerial(b *testing.B) {
+	words := testWords(10000)
+	table := New()
+	table.Load(strings.NewReader(strings.Join(words, "\n")))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		table.Check(words[i%len(words)])
+	}
+}
+
+// Compare ns/op with -cpu 1,2,4,8 to see how checking scales.
+func BenchmarkCheckSharded(b *testing.B) {
+	words := testWords(10000)
+	table := NewSharded(STRIPES)
+	table.Load(strings.NewReader(strings.Join(words, "\n")))
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			table.Check(words[i%len(words)])
+			i++
+		}
+	})
+}
+
+func BenchmarkLoadSerial(b *testing.B) {
+	text := strings.Join(testWords(10000), "\n")
+	for i := 0; i < b.N; i++ {
+		New().Load(strings.NewReader(text))
+	}
+}
+
+func BenchmarkLoadSharded(b *testing.B) {
+	text := strings.Join(testWords(10000), "\n")
+	for i := 0; i < b.N; i++ {
+		NewSharded(STRIPES).Load(strings.NewReader(text))
+	}
+}
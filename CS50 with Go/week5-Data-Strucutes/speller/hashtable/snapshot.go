@@ -0,0 +1,84 @@
+package hashtable
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// WriteSnapshot writes the table as: word count, then for every non-empty
+// bucket its index, its length and its words (length-prefixed).
+// Bucket indexes are stored so loading never has to hash a word.
+func (t *Table) WriteSnapshot(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	putUvarint := func(v uint64) {
+		n := binary.PutUvarint(buf, v)
+		writer.Write(buf[:n])
+	}
+
+	putUvarint(uint64(t.size))
+	for index, head := range t.buckets {
+		if head == nil {
+			continue
+		}
+		length := 0
+		for cursor := head; cursor != nil; cursor = cursor.next {
+			length++
+		}
+
+		putUvarint(uint64(index))
+		putUvarint(uint64(length))
+		for cursor := head; cursor != nil; cursor = cursor.next {
+			putUvarint(uint64(len(cursor.word)))
+			writer.WriteString(cursor.word)
+		}
+	}
+	return writer.Flush()
+}
+
+// ReadSnapshot replaces the table's contents with a snapshot made by WriteSnapshot.
+func (t *Table) ReadSnapshot(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	t.Unload()
+
+	total, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return err
+	}
+
+	for uint64(t.size) < total {
+		index, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return err
+		}
+		length, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return err
+		}
+		if index >= N || length == 0 || uint64(t.size)+length > total {
+			return fmt.Errorf("hashtable: corrupt snapshot")
+		}
+
+		// Rebuild the chain back to front so it keeps its original order.
+		words := make([]string, length)
+		for i := range words {
+			n, err := binary.ReadUvarint(reader)
+			if err != nil {
+				return err
+			}
+			word := make([]byte, n)
+			if _, err := io.ReadFull(reader, word); err != nil {
+				return err
+			}
+			words[i] = string(word)
+		}
+		for i := len(words) - 1; i >= 0; i-- {
+			t.buckets[index] = &node{word: words[i], next: t.buckets[index]}
+		}
+		t.size += int(length)
+	}
+	return nil
+}
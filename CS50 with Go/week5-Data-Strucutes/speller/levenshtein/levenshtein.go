@@ -0,0 +1,133 @@
+// Package levenshtein measures how many single-letter edits (insert, delete,
+// substitute) turn one word into another, and finds close words fast with a
+// BK-tree.
+package levenshtein
+
+import "sort"
+
+// Distance returns the Levenshtein distance between a and b, counting
+// letters (runes), not bytes: café is one edit from cafe.
+func Distance(a, b string) int {
+	return distance([]rune(a), []rune(b))
+}
+
+func distance(a, b []rune) int {
+	// Only two rows of the DP table are needed at a time.
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// Match is a word found by Search and its distance from the query.
+type Match struct {
+	Word     string
+	Distance int
+}
+
+// bkNode keeps its children keyed by their distance to this node's word.
+type bkNode struct {
+	word     string
+	children map[int]*bkNode
+}
+
+// BKTree indexes words by edit distance. Because Levenshtein distance obeys
+// the triangle inequality, a search only has to visit children whose key is
+// within max of the distance to the current node.
+type BKTree struct {
+	root *bkNode
+	size int
+}
+
+// NewBKTree returns an empty tree.
+func NewBKTree() *BKTree {
+	return &BKTree{}
+}
+
+// Add inserts word into the tree. Adding the same word twice does nothing.
+func (t *BKTree) Add(word string) {
+	if t.root == nil {
+		t.root = &bkNode{word: word}
+		t.size++
+		return
+	}
+
+	cursor := t.root
+	for {
+		d := Distance(word, cursor.word)
+		if d == 0 {
+			return
+		}
+		child, ok := cursor.children[d]
+		if !ok {
+			if cursor.children == nil {
+				cursor.children = make(map[int]*bkNode)
+			}
+			cursor.children[d] = &bkNode{word: word}
+			t.size++
+			return
+		}
+		cursor = child
+	}
+}
+
+// Len returns the number of distinct words in the tree.
+func (t *BKTree) Len() int {
+	return t.size
+}
+
+// Search returns every word within max edits of word, closest first and
+// alphabetical among equals.
+func (t *BKTree) Search(word string, max int) []Match {
+	var matches []Match
+	if t.root == nil {
+		return matches
+	}
+
+	stack := []*bkNode{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := Distance(word, n.word)
+		if d <= max {
+			matches = append(matches, Match{Word: n.word, Distance: d})
+		}
+		for key, child := range n.children {
+			if key >= d-max && key <= d+max {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Word < matches[j].Word
+	})
+	return matches
+}
+
+// Closest returns at most n words within max edits of word, best first.
+func (t *BKTree) Closest(word string, max, n int) []Match {
+	matches := t.Search(word, max)
+	if len(matches) > n {
+		matches = matches[:n]
+	}
+	return matches
+}
@@ -0,0 +1,132 @@
+package levenshtein
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"cat", "cat", 0},
+		{"cat", "cut", 1},  // substitute
+		{"cat", "cats", 1}, // insert
+		{"cat", "at", 1},   // delete
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"intention", "execution", 5},
+		{"abc", "cba", 2}, // no transpositions: two substitutions
+		{"café", "cafe", 1},
+		{"naïve", "naive", 1},
+		{"日本語", "日本", 1},
+		{"über", "uber", 1},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+var WORDS = []string{
+	"book", "books", "boo", "boon", "cook", "cake", "cape", "cart", "cat",
+	"cats", "coat", "cut", "dog", "hat", "bat", "brook", "look", "take",
+}
+
+// bruteForce is Search done the slow way, for the tree to agree with.
+func bruteForce(word string, max int) []Match {
+	var matches []Match
+	for _, w := range WORDS {
+		if d := Distance(word, w); d <= max {
+			matches = append(matches, Match{Word: w, Distance: d})
+		}
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		if a.Word < b.Word {
+			return -1
+		}
+		return 1
+	})
+	return matches
+}
+
+func tree() *BKTree {
+	tree := NewBKTree()
+	for _, word := range WORDS {
+		tree.Add(word)
+	}
+	tree.Add("cat") // again
+	return tree
+}
+
+func TestAdd(t *testing.T) {
+	if got := tree().Len(); got != len(WORDS) {
+		t.Errorf("Len() = %d, want %d", got, len(WORDS))
+	}
+	if got := NewBKTree().Len(); got != 0 {
+		t.Errorf("NewBKTree().Len() = %d", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		word string
+		max  int
+		want []Match
+	}{
+		{"cat", 0, []Match{{"cat", 0}}},
+		{"cst", 0, nil},
+		{"cat", 1, []Match{{"cat", 0}, {"bat", 1}, {"cart", 1}, {"cats", 1}, {"coat", 1}, {"cut", 1}, {"hat", 1}}},
+		{"bok", 1, []Match{{"boo", 1}, {"book", 1}}},
+		{"xyzzy", 2, nil},
+	}
+	tree := tree()
+	for _, tt := range tests {
+		if got := tree.Search(tt.word, tt.max); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q, %d) = %v, want %v", tt.word, tt.max, got, tt.want)
+		}
+	}
+
+	// Pruning by the triangle inequality loses nothing
+	for _, word := range append(WORDS, "", "bok", "cst", "taek", "brooks", "z") {
+		for max := 0; max <= 4; max++ {
+			if got, want := tree.Search(word, max), bruteForce(word, max); !slices.Equal(got, want) {
+				t.Errorf("Search(%q, %d) = %v, want %v", word, max, got, want)
+			}
+		}
+	}
+
+	if got := NewBKTree().Search("cat", 3); len(got) != 0 {
+		t.Errorf("empty tree: Search = %v", got)
+	}
+}
+
+func TestClosest(t *testing.T) {
+	tree := tree()
+	tests := []struct {
+		word   string
+		max, n int
+		want   []Match
+	}{
+		{"cat", 1, 3, []Match{{"cat", 0}, {"bat", 1}, {"cart", 1}}},
+		{"bok", 1, 5, []Match{{"boo", 1}, {"book", 1}}}, // fewer than n
+		{"bok", 2, 2, []Match{{"boo", 1}, {"book", 1}}}, // the distance 2 words cut
+		{"cat", 1, 0, []Match{}},
+	}
+	for _, tt := range tests {
+		if got := tree.Closest(tt.word, tt.max, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("Closest(%q, %d, %d) = %v, want %v", tt.word, tt.max, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package mapdict implements the speller dictionary with Go's built-in map,
+// the baseline the hand-written structures are compared against.
+package mapdict
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Map is a set of lowercase words.
+type Map struct {
+	words map[string]struct{}
+}
+
+// New returns an empty map dictionary.
+func New() *Map {
+	return &Map{words: make(map[string]struct{})}
+}
+
+// Load reads one word per line from r into the map.
+func (m *Map) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" {
+			continue
+		}
+		m.words[word] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Check returns true if word is in the map, ignoring case.
+func (m *Map) Check(word string) bool {
+	_, ok := m.words[strings.ToLower(word)]
+	return ok
+}
+
+// Size returns the number of words loaded.
+func (m *Map) Size() int {
+	return len(m.words)
+}
+
+// Unload replaces the map so the old one can be garbage collected.
+func (m *Map) Unload() {
+	m.words = make(map[string]struct{})
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"sync"
+
+	"speller/dictionary"
+)
+
+// checkParallel splits words into one chunk per worker and checks the chunks
+// in separate goroutines. misspelled[i] reports words[i], so the caller can
+// still print misspellings in text order.
+func checkParallel(d dictionary.Dictionary, words []string, workers int) []bool {
+	misspelled := make([]bool, len(words))
+	if workers < 1 {
+		workers = 1
+	}
+	chunk := (len(words) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(words); start += chunk {
+		end := min(start+chunk, len(words))
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			// Each goroutine writes only its own range of the result slice.
+			for i := start; i < end; i++ {
+				misspelled[i] = !d.Check(words[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return misspelled
+}
@@ -0,0 +1,271 @@
+// Implements a spell-checker (CS50 week 5 speller, ported from speller.c)
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"memstats"
+	"profiling"
+	"render"
+	"set"
+	"speller/dictionary"
+	"speller/hashtable"
+	"speller/levenshtein"
+	"speller/mapdict"
+	"speller/trie"
+)
+
+// DICTIONARY is the default dictionary, same as the C version.
+const DICTIONARY = "dictionaries/large"
+
+// summary is the report at the end, the benchmarks, as --format prints it:
+// the counts and the times, without the words.
+type summary struct {
+	Misspelled int     `json:"words_misspelled"`
+	Distinct   int     `json:"distinct_misspelled,omitempty"` // with -distinct
+	Dictionary int     `json:"words_in_dictionary"`
+	Text       int     `json:"words_in_text"`
+	Load       float64 `json:"load_seconds"`
+	Check      float64 `json:"check_seconds"`
+	Size       float64 `json:"size_seconds"`
+	Unload     float64 `json:"unload_seconds"`
+	Total      float64 `json:"total_seconds"`
+}
+
+func main() {
+	defer profiling.Start()()
+	render.Init()
+
+	// `speller bench ...` compares every structure instead of spell-checking.
+	if len(os.Args) > 1 && os.Args[1] == "bench" {
+		runBench(os.Args[2:])
+		return
+	}
+
+	impl := flag.String("impl", "hashtable", "dictionary structure: hashtable, sharded, trie or map")
+	suggest := flag.Bool("suggest", false, "print up to 3 dictionary words within 2 edits of each misspelling")
+	bloom := flag.Bool("bloom", false, "pre-check words with a bloom filter before the dictionary")
+	bloomBits := flag.Uint64("bloom-bits", 1<<21, "bloom filter size in bits")
+	bloomHashes := flag.Int("bloom-hashes", 7, "bloom filter hash functions per word")
+	parallel := flag.Int("parallel", 1, "number of goroutines checking chunks of the text")
+	distinct := flag.Bool("distinct", false, "print each misspelled word once (case-insensitive)")
+	memStats := flag.Bool("memstats", false, "print a valgrind-style heap summary for load and unload to stderr")
+	snapshot := flag.Bool("snapshot", false, "load the dictionary from DICTIONARY.<impl>.snap, rebuilding it when DICTIONARY changes")
+	flag.Usage = func() {
+		fmt.Println("Usage: ./speller [-impl hashtable|sharded|trie|map] [-suggest] [-bloom] [-parallel N] [-snapshot] [DICTIONARY] text")
+		fmt.Println("       ./speller bench [-dict DICTIONARY] text...")
+	}
+	flag.Parse()
+
+	// Check for correct number of args
+	args := flag.Args()
+	if len(args) != 1 && len(args) != 2 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	d, err := newDictionary(*impl)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var filtered *bloomDictionary
+	if *bloom {
+		filtered = newBloomDictionary(d, *bloomBits, *bloomHashes)
+		d = filtered
+	}
+	if _, ok := d.(dictionary.Snapshotter); *snapshot && !ok {
+		fmt.Println("-snapshot only works with -impl hashtable or trie, without -bloom.")
+		os.Exit(1)
+	}
+
+	// Determine dictionary to use
+	dictPath := DICTIONARY
+	if len(args) == 2 {
+		dictPath = args[0]
+	}
+	textPath := args[len(args)-1]
+
+	// Load dictionary
+	var loadStats *memstats.Sampler
+	if *memStats {
+		loadStats = memstats.Start("load")
+	}
+	start := time.Now()
+	if *snapshot {
+		err = loadSnapshot(d, *impl, dictPath)
+	} else {
+		err = dictionary.LoadFile(d, dictPath)
+	}
+	timeLoad := time.Since(start)
+	if loadStats != nil {
+		loadStats.Stop().Fprint(os.Stderr)
+	}
+	if err != nil {
+		fmt.Printf("Could not load %s.\n", dictPath)
+		os.Exit(1)
+	}
+
+	// Index the dictionary by edit distance, outside of the timed phases
+	var suggestions *levenshtein.BKTree
+	if *suggest {
+		suggestions, err = loadSuggestions(dictPath)
+		if err != nil {
+			fmt.Printf("Could not load %s.\n", dictPath)
+			d.Unload()
+			os.Exit(1)
+		}
+	}
+
+	// Try to open text
+	file, err := os.Open(textPath)
+	if err != nil {
+		fmt.Printf("Could not open %s.\n", textPath)
+		d.Unload()
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	// Prepare to report misspellings
+	if render.Text() {
+		fmt.Print("\nMISSPELLED WORDS\n\n")
+	}
+
+	misspellings, words := 0, 0
+	var timeCheck time.Duration
+
+	// Misspellings seen so far, lowercase, for -distinct
+	seen := set.New[string]()
+
+	report := func(word string) {
+		misspellings++
+		if *distinct && !seen.Add(strings.ToLower(word)) {
+			return
+		}
+		if !render.Text() {
+			return // --format has the counts, not the words
+		}
+		if suggestions != nil {
+			printSuggestions(suggestions, word)
+		} else {
+			fmt.Println(word)
+		}
+	}
+
+	if *parallel > 1 {
+		// Read the whole text first, then check chunks in parallel
+		var text []string
+		err = dictionary.Words(file, func(word string) {
+			text = append(text, word)
+		})
+		if err == nil {
+			words = len(text)
+
+			start := time.Now()
+			misspelled := checkParallel(d, text, *parallel)
+			timeCheck = time.Since(start)
+
+			for i, word := range text {
+				if misspelled[i] {
+					report(word)
+				}
+			}
+		}
+	} else {
+		// Spell-check each word in text
+		err = dictionary.Words(file, func(word string) {
+			words++
+
+			start := time.Now()
+			misspelled := !d.Check(word)
+			timeCheck += time.Since(start)
+
+			if misspelled {
+				report(word)
+			}
+		})
+	}
+	if err != nil {
+		fmt.Printf("Error reading %s.\n", textPath)
+		d.Unload()
+		os.Exit(1)
+	}
+
+	// Determine dictionary's size
+	start = time.Now()
+	n := d.Size()
+	timeSize := time.Since(start)
+
+	// Unload dictionary
+	var unloadStats *memstats.Sampler
+	if *memStats {
+		unloadStats = memstats.Start("unload")
+	}
+	start = time.Now()
+	d.Unload()
+	timeUnload := time.Since(start)
+	if unloadStats != nil {
+		// Negative "in use" is memory the dictionary gave back
+		unloadStats.Stop().Fprint(os.Stderr)
+	}
+
+	// Report benchmarks
+	if !render.Text() {
+		total := timeLoad + timeCheck + timeSize + timeUnload
+		err := render.Print(summary{misspellings, seen.Len(), n, words,
+			timeLoad.Seconds(), timeCheck.Seconds(), timeSize.Seconds(), timeUnload.Seconds(), total.Seconds()})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Printf("\nWORDS MISSPELLED:     %d\n", misspellings)
+	if *distinct {
+		fmt.Printf("DISTINCT MISSPELLED:  %d\n", seen.Len())
+	}
+	fmt.Printf("WORDS IN DICTIONARY:  %d\n", n)
+	fmt.Printf("WORDS IN TEXT:        %d\n", words)
+	fmt.Printf("TIME IN load:         %.2f\n", timeLoad.Seconds())
+	fmt.Printf("TIME IN check:        %.2f\n", timeCheck.Seconds())
+	fmt.Printf("TIME IN size:         %.2f\n", timeSize.Seconds())
+	fmt.Printf("TIME IN unload:       %.2f\n", timeUnload.Seconds())
+	fmt.Printf("TIME IN TOTAL:        %.2f\n\n", (timeLoad + timeCheck + timeSize + timeUnload).Seconds())
+
+	if filtered != nil {
+		filtered.printStats()
+	}
+}
+
+// loadSnapshot loads the dictionary through its snapshot file, telling the
+// user on stderr when the snapshot had to be (re)built.
+func loadSnapshot(d dictionary.Dictionary, impl, dictPath string) error {
+	snapshotter := d.(dictionary.Snapshotter)
+	snapPath := dictPath + "." + impl + ".snap"
+	fromSnapshot, err := dictionary.LoadCached(snapshotter, impl, dictPath, snapPath)
+	if err == nil && !fromSnapshot {
+		fmt.Fprintf(os.Stderr, "Wrote snapshot %s.\n", snapPath)
+	}
+	return err
+}
+
+// newDictionary returns the dictionary structure selected with -impl.
+func newDictionary(name string) (dictionary.Dictionary, error) {
+	switch name {
+	case "hashtable":
+		return hashtable.New(), nil
+	case "sharded":
+		return hashtable.NewSharded(hashtable.STRIPES), nil
+	case "trie":
+		return trie.New(), nil
+	case "map":
+		return mapdict.New(), nil
+	}
+	return nil, fmt.Errorf("unknown dictionary implementation: %s", name)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"speller/levenshtein"
+)
+
+const (
+	// MAX_EDITS is how far a suggestion may be from the misspelled word.
+	MAX_EDITS = 2
+	// MAX_SUGGESTIONS is how many suggestions are printed per word.
+	MAX_SUGGESTIONS = 3
+)
+
+// loadSuggestions reads the dictionary file again into a BK-tree.
+func loadSuggestions(path string) (*levenshtein.BKTree, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tree := levenshtein.NewBKTree()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word != "" {
+			tree.Add(word)
+		}
+	}
+	return tree, scanner.Err()
+}
+
+// printSuggestions prints the misspelled word followed by its closest matches.
+// e.g. "helo -> hello, help, hero"
+func printSuggestions(tree *levenshtein.BKTree, word string) {
+	matches := tree.Closest(strings.ToLower(word), MAX_EDITS, MAX_SUGGESTIONS)
+	if len(matches) == 0 {
+		fmt.Println(word)
+		return
+	}
+
+	words := make([]string, len(matches))
+	for i, m := range matches {
+		words[i] = m.Word
+	}
+	fmt.Printf("%s -> %s\n", word, strings.Join(words, ", "))
+}
@@ -0,0 +1,82 @@
+package trie
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Each node is written in pre-order as a 32-bit little-endian mask:
+// bits 0-26 say which children exist, bit 31 marks the end of a word.
+const (
+	childBits = 1<<ALPHABET - 1
+	wordBit   = 1 << 31
+)
+
+// WriteSnapshot writes the trie's shape without any of its words as strings.
+func (t *Trie) WriteSnapshot(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	writeNode(writer, t.root)
+	return writer.Flush()
+}
+
+func writeNode(writer *bufio.Writer, n *node) {
+	var mask uint32
+	if n.isWord {
+		mask |= wordBit
+	}
+	for i, child := range n.children {
+		if child != nil {
+			mask |= 1 << i
+		}
+	}
+
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], mask)
+	writer.Write(buf[:])
+
+	for _, child := range n.children {
+		if child != nil {
+			writeNode(writer, child)
+		}
+	}
+}
+
+// ReadSnapshot replaces the trie's contents with a snapshot made by WriteSnapshot.
+func (t *Trie) ReadSnapshot(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	root := &node{}
+	size := 0
+	if err := readNode(reader, root, &size); err != nil {
+		return err
+	}
+	t.root = root
+	t.size = size
+	return nil
+}
+
+func readNode(reader *bufio.Reader, n *node, size *int) error {
+	var buf [4]byte
+	if _, err := io.ReadFull(reader, buf[:]); err != nil {
+		return err
+	}
+	mask := binary.LittleEndian.Uint32(buf[:])
+	if mask&^(wordBit|childBits) != 0 {
+		return fmt.Errorf("trie: corrupt snapshot")
+	}
+
+	if mask&wordBit != 0 {
+		n.isWord = true
+		*size++
+	}
+	for i := range n.children {
+		if mask&(1<<i) != 0 {
+			n.children[i] = &node{}
+			if err := readNode(reader, n.children[i], size); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package trie implements the speller dictionary as a trie: one node per
+// letter, so checking a word costs at most len(word) steps.
+package trie
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ALPHABET is a-z plus the apostrophe.
+const ALPHABET = 27
+
+// node is one letter position in the trie.
+type node struct {
+	isWord   bool
+	children [ALPHABET]*node
+}
+
+// Trie holds the root node and the number of words stored.
+type Trie struct {
+	root *node
+	size int
+}
+
+// New returns an empty trie.
+func New() *Trie {
+	return &Trie{root: &node{}}
+}
+
+// Load reads one word per line from r and inserts each into the trie.
+func (t *Trie) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" {
+			continue
+		}
+		if err := t.insert(word); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// insert walks (and grows) the path for word and marks its last node.
+func (t *Trie) insert(word string) error {
+	cursor := t.root
+	for i := 0; i < len(word); i++ {
+		index := letterIndex(word[i])
+		if index < 0 {
+			return fmt.Errorf("trie: invalid character %q in %q", word[i], word)
+		}
+		if cursor.children[index] == nil {
+			cursor.children[index] = &node{}
+		}
+		cursor = cursor.children[index]
+	}
+	if !cursor.isWord {
+		cursor.isWord = true
+		t.size++
+	}
+	return nil
+}
+
+// Check returns true if word is in the trie, ignoring case.
+func (t *Trie) Check(word string) bool {
+	cursor := t.root
+	for i := 0; i < len(word) && cursor != nil; i++ {
+		index := letterIndex(word[i])
+		if index < 0 {
+			return false
+		}
+		cursor = cursor.children[index]
+	}
+	return cursor != nil && cursor.isWord
+}
+
+// Size returns the number of words loaded.
+func (t *Trie) Size() int {
+	return t.size
+}
+
+// Delete removes word and reports whether it was there, freeing the nodes
+// no other word needs any more.
+func (t *Trie) Delete(word string) bool {
+	// The path down, so the nodes left empty can be cut off on the way back up
+	path := []*node{t.root}
+	for i := 0; i < len(word); i++ {
+		index := letterIndex(word[i])
+		if index < 0 || path[i].children[index] == nil {
+			return false
+		}
+		path = append(path, path[i].children[index])
+	}
+	last := path[len(path)-1]
+	if !last.isWord {
+		return false
+	}
+	last.isWord = false
+	t.size--
+	for i := len(word); i > 0 && !path[i].isWord && path[i].children == [ALPHABET]*node{}; i-- {
+		path[i-1].children[letterIndex(word[i-1])] = nil
+	}
+	return true
+}
+
+// Walk calls visit for every node but the root in alphabetical order,
+// parents first, with the letters down to it and whether they're a word.
+func (t *Trie) Walk(visit func(prefix string, isWord bool)) {
+	walk(t.root, nil, visit)
+}
+
+func walk(n *node, prefix []byte, visit func(string, bool)) {
+	for index, child := range n.children {
+		if child == nil {
+			continue
+		}
+		letter := byte('a' + index)
+		if index == ALPHABET-1 {
+			letter = '\''
+		}
+		next := append(prefix, letter)
+		visit(string(next), child.isWord)
+		walk(child, next, visit)
+	}
+}
+
+// Unload drops the whole tree so the garbage collector can free it.
+func (t *Trie) Unload() {
+	t.root = &node{}
+	t.size = 0
+}
+
+// letterIndex maps a-z (either case) to 0-25 and the apostrophe to 26.
+func letterIndex(c byte) int {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A')
+	case c == '\'':
+		return ALPHABET - 1
+	}
+	return -1
+}
@@ -0,0 +1,83 @@
+package trie
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// nodes counts the nodes under the root.
+func nodes(t *Trie) int {
+	count := 0
+	t.Walk(func(string, bool) { count++ })
+	return count
+}
+
+func TestLoad(t *testing.T) {
+	trie := New()
+	if err := trie.Load(strings.NewReader("cat\ncar\nCART\n")); err != nil {
+		t.Fatal(err)
+	}
+	// c-a shared, then t, r and r-t
+	if trie.Size() != 3 || nodes(trie) != 5 {
+		t.Errorf("Size() = %d with %d nodes, want 3 with 5", trie.Size(), nodes(trie))
+	}
+	if err := New().Load(strings.NewReader("cat\nca-t\n")); err == nil || !strings.Contains(err.Error(), `'-'`) {
+		t.Errorf("Load(ca-t) err = %v", err)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	trie := New()
+	trie.Load(strings.NewReader("ab\na\nb'\n"))
+	var got []string
+	trie.Walk(func(prefix string, isWord bool) {
+		if isWord {
+			prefix += "*"
+		}
+		got = append(got, prefix)
+	})
+	// alphabetical, parents first, the apostrophe after z
+	if want := []string{"a*", "ab*", "b", "b'*"}; !slices.Equal(got, want) {
+		t.Errorf("Walk = %q, want %q", got, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		words  string
+		delete string
+		found  bool
+		left   []string
+		nodes  int
+	}{
+		{"", "cat", false, nil, 0},                        // empty trie
+		{"cat\n", "ca", false, []string{"cat"}, 3},        // a prefix isn't a word
+		{"cat\n", "cats", false, []string{"cat"}, 3},      // nor a longer one
+		{"cat\n", "CAT", true, nil, 0},                    // every node goes with the only word
+		{"cat\ncats\n", "cat", true, []string{"cats"}, 4}, // cats still needs c-a-t
+		{"cat\ncats\n", "cats", true, []string{"cat"}, 3}, // only s goes
+		{"cat\ncar\n", "cat", true, []string{"car"}, 3},   // the shared c-a stays
+		{"a\nab\n", "a", true, []string{"ab"}, 2},         // a stays as ab's prefix
+		{"it's\n", "it's", true, nil, 0},
+		{"cat\n", "c4t", false, []string{"cat"}, 3},
+	}
+	for _, tt := range tests {
+		trie := New()
+		trie.Load(strings.NewReader(tt.words))
+		if found := trie.Delete(tt.delete); found != tt.found {
+			t.Errorf("%q: Delete(%q) = %v, want %v", tt.words, tt.delete, found, tt.found)
+		}
+		if trie.Size() != len(tt.left) || nodes(trie) != tt.nodes {
+			t.Errorf("%q after Delete(%q): Size() = %d with %d nodes, want %d with %d", tt.words, tt.delete, trie.Size(), nodes(trie), len(tt.left), tt.nodes)
+		}
+		for _, word := range tt.left {
+			if !trie.Check(word) {
+				t.Errorf("%q after Delete(%q): %q is gone", tt.words, tt.delete, word)
+			}
+		}
+		if tt.found && trie.Check(tt.delete) {
+			t.Errorf("%q after Delete(%q): still there", tt.words, tt.delete)
+		}
+	}
+}
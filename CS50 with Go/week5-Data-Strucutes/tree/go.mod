@@ -0,0 +1,13 @@
+module tree
+
+go 1.24.4
+
+require (
+	memstats v0.0.0
+	rngutil v0.0.0
+)
+
+replace (
+	memstats => ../memstats
+	rngutil => ../../rngutil
+)
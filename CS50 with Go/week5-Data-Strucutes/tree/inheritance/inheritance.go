@@ -0,0 +1,103 @@
+// Simulate genetic inheritance of blood type (CS50 week 5 inheritance, the
+// problem the recipe exercise was adapted from)
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"memstats"
+	"rngutil"
+	"tree"
+)
+
+// GENERATIONS is how many generations the family tree has, child included.
+const GENERATIONS = 3
+
+// person holds the two blood type alleles (A, B or O) of one family member.
+type person struct {
+	alleles [2]byte
+}
+
+func main() {
+	memStats := flag.Bool("memstats", false, "print a valgrind-style heap summary of building the family to stderr")
+	trials := flag.Int("trials", 0, "simulate N families and print observed vs expected genotypes per generation")
+	seed := flag.Int64("seed", 0, rngutil.SEED_USAGE)
+	flag.Parse()
+
+	if *trials < 0 {
+		fmt.Println("Usage: ./inheritance [-memstats] [-trials N] [-seed N]")
+		os.Exit(1)
+	}
+	rng := rngutil.New(seed)
+	if *trials > 0 {
+		// Monte Carlo mode: many families, statistics instead of one tree
+		var counts [GENERATIONS]map[string]int
+		run := func() { counts = runTrials(rng, *trials) }
+		if *memStats {
+			memstats.Measure("trials", run).Fprint(os.Stderr)
+		} else {
+			run()
+		}
+		printTrials(os.Stdout, *trials, counts)
+		return
+	}
+
+	// Create a new family with three generations
+	var family *tree.Node[person]
+	build := func() { family = newFamily(rng) }
+	if *memStats {
+		memstats.Measure("build", build).Fprint(os.Stderr)
+	} else {
+		build()
+	}
+
+	// Print family tree of blood types
+	tree.Print(os.Stdout, family, describe)
+}
+
+// newFamily is GENERATIONS of a family, the oldest with random alleles.
+func newFamily(rng *rand.Rand) *tree.Node[person] {
+	return tree.Build(GENERATIONS,
+		func() person { return randomPerson(rng) },
+		func(parent0, parent1 person) person { return inherit(rng, parent0, parent1) })
+}
+
+// randomPerson is someone in the oldest generation: both alleles are random.
+func randomPerson(rng *rand.Rand) person {
+	return person{alleles: [2]byte{randomAllele(rng), randomAllele(rng)}}
+}
+
+// inherit makes a child that gets one random allele from each parent.
+func inherit(rng *rand.Rand, parent0, parent1 person) person {
+	return person{alleles: [2]byte{
+		parent0.alleles[rng.Intn(2)],
+		parent1.alleles[rng.Intn(2)],
+	}}
+}
+
+// describe formats one person like the C version's print_family.
+func describe(generation int, p person) string {
+	return fmt.Sprintf("%s (Generation %d): blood type %c%c", role(generation), generation, p.alleles[0], p.alleles[1])
+}
+
+// role names a generation relative to the child.
+func role(generation int) string {
+	switch generation {
+	case 0:
+		return "Child"
+	case 1:
+		return "Parent"
+	default:
+		return strings.Repeat("Great-", generation-2) + "Grandparent"
+	}
+}
+
+// randomAllele randomly chooses a blood type allele.
+func randomAllele(rng *rand.Rand) byte {
+	return "ABO"[rng.Intn(3)]
+}
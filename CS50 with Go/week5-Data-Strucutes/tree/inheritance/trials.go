@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	"tree"
+)
+
+// GENOTYPES lists every unordered allele pair; AO and OA are the same genotype.
+var GENOTYPES = []string{"AA", "AB", "AO", "BB", "BO", "OO"}
+
+// CHI2_CRITICAL is the χ² value for 5 degrees of freedom (6 genotypes - 1)
+// at a 5% significance level. Above it, observed and expected disagree.
+const CHI2_CRITICAL = 11.07
+
+// runTrials builds trials random families and counts the genotypes seen in
+// each generation.
+func runTrials(rng *rand.Rand, trials int) [GENERATIONS]map[string]int {
+	var counts [GENERATIONS]map[string]int
+	for g := range counts {
+		counts[g] = make(map[string]int, len(GENOTYPES))
+	}
+
+	for i := 0; i < trials; i++ {
+		family := newFamily(rng)
+		tree.Walk(family, func(generation int, p person) {
+			counts[generation][genotype(p)]++
+		})
+	}
+	return counts
+}
+
+// genotype writes the alleles in ABO order so "OA" and "AO" count together.
+func genotype(p person) string {
+	a, b := p.alleles[0], p.alleles[1]
+	if a > b {
+		a, b = b, a
+	}
+	return string([]byte{a, b})
+}
+
+// expected is the theoretical share of a genotype (Punnett square). The
+// oldest generation draws each allele uniformly, so A, B and O all have
+// frequency 1/3. A child takes a random allele from each parent, which keeps
+// those frequencies, so every generation expects the same split:
+// 1/3 * 1/3 for a homozygous pair and twice that for a heterozygous one.
+func expected(genotype string) float64 {
+	const p = 1.0 / 3
+	if genotype[0] == genotype[1] {
+		return p * p
+	}
+	return 2 * p * p
+}
+
+// chiSquare is Pearson's goodness-of-fit score for one generation's counts:
+// the sum over genotypes of (observed - expected)² / expected.
+func chiSquare(seen map[string]int) float64 {
+	people := 0
+	for _, n := range seen {
+		people += n
+	}
+
+	chi2 := 0.0
+	for _, g := range GENOTYPES {
+		want := expected(g) * float64(people)
+		diff := float64(seen[g]) - want
+		chi2 += diff * diff / want
+	}
+	return chi2
+}
+
+// bloodType is the phenotype: A and B are dominant over O.
+func bloodType(genotype string) string {
+	switch genotype {
+	case "OO":
+		return "O"
+	case "AB":
+		return "AB"
+	}
+	return strings.Trim(genotype, "O")[:1]
+}
+
+// printTrials writes observed vs expected genotype shares per generation,
+// plus a χ² goodness-of-fit score.
+func printTrials(w io.Writer, trials int, counts [GENERATIONS]map[string]int) {
+	fmt.Fprintf(w, "%d families, %d generations\n", trials, GENERATIONS)
+	for generation, seen := range counts {
+		people := 0
+		for _, n := range seen {
+			people += n
+		}
+
+		fmt.Fprintf(w, "\n%s (Generation %d): %d people\n", role(generation), generation, people)
+		fmt.Fprintf(w, "    %-8s  %-10s  %8s  %8s\n", "genotype", "blood type", "observed", "expected")
+
+		for _, g := range GENOTYPES {
+			fmt.Fprintf(w, "    %-8s  %-10s  %7.2f%%  %7.2f%%\n",
+				g, bloodType(g), 100*float64(seen[g])/float64(people), 100*expected(g))
+		}
+
+		chi2 := chiSquare(seen)
+		verdict := "consistent with theory"
+		if chi2 > CHI2_CRITICAL {
+			verdict = "differs from theory"
+		}
+		fmt.Fprintf(w, "    χ² = %.2f (5%% critical value %.2f): %s\n", chi2, CHI2_CRITICAL, verdict)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGenotype(t *testing.T) {
+	tests := []struct {
+		alleles   [2]byte
+		genotype  string
+		bloodType string
+	}{
+		{[2]byte{'A', 'A'}, "AA", "A"},
+		{[2]byte{'A', 'O'}, "AO", "A"},
+		{[2]byte{'O', 'A'}, "AO", "A"}, // same genotype either way round
+		{[2]byte{'B', 'A'}, "AB", "AB"},
+		{[2]byte{'O', 'B'}, "BO", "B"},
+		{[2]byte{'B', 'B'}, "BB", "B"},
+		{[2]byte{'O', 'O'}, "OO", "O"},
+	}
+	for _, tt := range tests {
+		g := genotype(person{alleles: tt.alleles})
+		if g != tt.genotype || bloodType(g) != tt.bloodType {
+			t.Errorf("%c%c: genotype %s, blood type %s, want %s, %s", tt.alleles[0], tt.alleles[1], g, bloodType(g), tt.genotype, tt.bloodType)
+		}
+	}
+}
+
+func TestExpected(t *testing.T) {
+	tests := []struct {
+		genotype string
+		want     float64
+	}{
+		{"AA", 1.0 / 9},
+		{"BB", 1.0 / 9},
+		{"OO", 1.0 / 9},
+		{"AB", 2.0 / 9},
+		{"AO", 2.0 / 9},
+		{"BO", 2.0 / 9},
+	}
+	total := 0.0
+	for _, tt := range tests {
+		if got := expected(tt.genotype); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("expected(%s) = %g, want %g", tt.genotype, got, tt.want)
+		}
+		total += expected(tt.genotype)
+	}
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("the shares add up to %g", total)
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	tests := []struct {
+		name string
+		seen map[string]int
+		want float64
+	}{
+		// 900 people split 1:2:2:1:2:1, exactly as expected
+		{"perfect", map[string]int{"AA": 100, "AB": 200, "AO": 200, "BB": 100, "BO": 200, "OO": 100}, 0},
+		// 30²/100 + 30²/200 = 9 + 4.5
+		{"30 AB are AA", map[string]int{"AA": 130, "AB": 170, "AO": 200, "BB": 100, "BO": 200, "OO": 100}, 13.5},
+		// 80²/10 + 20²/20 * 3 + 10²/10 * 2 = 640 + 60 + 20
+		{"all AA", map[string]int{"AA": 90}, 720},
+	}
+	for _, tt := range tests {
+		if got := chiSquare(tt.seen); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: chiSquare = %g, want %g", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Simulated families match the Punnett square: a seeded run counts every
+// person once and passes the χ² test in every generation.
+func TestRunTrials(t *testing.T) {
+	const trials = 5000
+	counts := runTrials(rand.New(rand.NewSource(1)), trials)
+	for generation, seen := range counts {
+		people := 0
+		for _, n := range seen {
+			people += n
+		}
+		if want := trials << generation; people != want {
+			t.Errorf("generation %d: %d people, want %d", generation, people, want)
+		}
+		if chi2 := chiSquare(seen); chi2 > CHI2_CRITICAL {
+			t.Errorf("generation %d: χ² = %.2f, above %.2f: %v", generation, chi2, CHI2_CRITICAL, seen)
+		}
+	}
+}
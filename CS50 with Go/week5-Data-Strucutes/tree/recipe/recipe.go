@@ -0,0 +1,56 @@
+// Recipe breakdown rebuilt on the shared tree package: the same program as
+// finished-distributionCode/recipe-finished.go, minus the recursion plumbing.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"memstats"
+	"rngutil"
+	"tree"
+)
+
+// COMPLEXITY determines the total depth of the recipe tree.
+const COMPLEXITY = 3
+
+func main() {
+	memStats := flag.Bool("memstats", false, "print a valgrind-style heap summary of building the recipe to stderr")
+	seed := flag.Int64("seed", 0, rngutil.SEED_USAGE)
+	flag.Parse()
+	rng := rngutil.New(seed)
+
+	// Generate the entire recipe structure recursively.
+	var finalDish *tree.Node[string]
+	build := func() { finalDish = tree.Build(COMPLEXITY, func() string { return randomIngredient(rng) }, combine) }
+	if *memStats {
+		memstats.Measure("build", build).Fprint(os.Stderr)
+	} else {
+		build()
+	}
+
+	// Traverse the generated structure and print it to the console.
+	tree.Print(os.Stdout, finalDish, describe)
+}
+
+// combine names a complex component after its two sub-components.
+func combine(sub0, sub1 string) string {
+	return sub0 + " & " + sub1
+}
+
+// describe formats one component like recipe.go's PrintRecipe does.
+func describe(level int, ingredient string) string {
+	if level == 0 {
+		return fmt.Sprintf("Final Dish (Level %d): made of %s", level, ingredient)
+	}
+	return fmt.Sprintf("Sub-Component (Level %d): made of %s", level, ingredient)
+}
+
+// randomIngredient is a utility function that returns a random base ingredient.
+func randomIngredient(rng *rand.Rand) string {
+	ingredients := []string{"Flour", "Sugar", "Eggs", "Butter", "Chocolate"}
+	return ingredients[rng.Intn(len(ingredients))]
+}
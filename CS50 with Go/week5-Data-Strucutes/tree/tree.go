@@ -0,0 +1,79 @@
+// Package tree is the shared scaffolding of the "two parents" exercises:
+// CS50's inheritance (a person has two parents) and the recipe breakdown
+// (a component is made of two sub-components). Both build a full binary
+// tree recursively and print it depth-first with indentation.
+package tree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// INDENT_LENGTH is the number of spaces per level when printing.
+const INDENT_LENGTH = 4
+
+// Node is a value made from its two parents. Parents are both nil at the
+// oldest generation (the leaves).
+type Node[T any] struct {
+	Parents [2]*Node[T]
+	Value   T
+}
+
+// Build recursively creates a tree with the given number of generations.
+// The oldest generation gets its values from leaf, every younger node gets
+// its value from combine applied to its two parents.
+func Build[T any](generations int, leaf func() T, combine func(parent0, parent1 T) T) *Node[T] {
+	// Allocate memory for a new node
+	n := &Node[T]{}
+
+	// If there are still generations left to create
+	if generations > 1 {
+		// Create two new parents for the current node by recursively calling Build
+		n.Parents[0] = Build(generations-1, leaf, combine)
+		n.Parents[1] = Build(generations-1, leaf, combine)
+
+		// The node's value comes from its parents
+		n.Value = combine(n.Parents[0].Value, n.Parents[1].Value)
+	} else {
+		// Oldest generation: no parents, the value starts here
+		n.Value = leaf()
+	}
+	return n
+}
+
+// Print writes the tree depth-first, one line per node, indented by its
+// generation (0 = the root). label formats one node's line.
+func Print[T any](w io.Writer, n *Node[T], label func(generation int, value T) string) {
+	printNode(w, n, 0, label)
+}
+
+func printNode[T any](w io.Writer, n *Node[T], generation int, label func(int, T) string) {
+	// Handle base case
+	if n == nil {
+		return
+	}
+
+	// Print indentation, then the node itself
+	fmt.Fprint(w, strings.Repeat(" ", generation*INDENT_LENGTH))
+	fmt.Fprintln(w, label(generation, n.Value))
+
+	// Print parents of current generation
+	printNode(w, n.Parents[0], generation+1, label)
+	printNode(w, n.Parents[1], generation+1, label)
+}
+
+// Walk calls visit for every node depth-first (same order as Print) with its
+// generation, 0 being the root.
+func Walk[T any](n *Node[T], visit func(generation int, value T)) {
+	walkNode(n, 0, visit)
+}
+
+func walkNode[T any](n *Node[T], generation int, visit func(int, T)) {
+	if n == nil {
+		return
+	}
+	visit(generation, n.Value)
+	walkNode(n.Parents[0], generation+1, visit)
+	walkNode(n.Parents[1], generation+1, visit)
+}
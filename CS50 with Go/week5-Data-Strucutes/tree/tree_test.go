@@ -0,0 +1,101 @@
+package tree
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// letters is a leaf function handing out "a", "b", "c"... in call order, so
+// a tree's values show the order Build made its leaves in.
+func letters() func() string {
+	next := 'a'
+	return func() string {
+		s := string(next)
+		next++
+		return s
+	}
+}
+
+func concat(parent0, parent1 string) string {
+	return "(" + parent0 + parent1 + ")"
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		generations int
+		root        string
+		nodes       int
+		depth       int
+	}{
+		{0, "a", 1, 0}, // no generations left: just a leaf
+		{1, "a", 1, 0},
+		{2, "(ab)", 3, 1},
+		{3, "((ab)(cd))", 7, 2},
+		{4, "(((ab)(cd))((ef)(gh)))", 15, 3},
+	}
+	for _, test := range tests {
+		root := Build(test.generations, letters(), concat)
+		if root.Value != test.root {
+			t.Errorf("Build(%d) root = %q, want %q", test.generations, root.Value, test.root)
+		}
+		nodes, depth := 0, 0
+		Walk(root, func(generation int, _ string) {
+			nodes++
+			depth = max(depth, generation)
+		})
+		if nodes != test.nodes || depth != test.depth {
+			t.Errorf("Build(%d) has %d nodes %d deep, want %d nodes %d deep", test.generations, nodes, depth, test.nodes, test.depth)
+		}
+	}
+}
+
+// Leaves have no parents and everyone else has both.
+func TestBuildParents(t *testing.T) {
+	var check func(n *Node[string], generations int)
+	check = func(n *Node[string], generations int) {
+		if generations == 1 {
+			if n.Parents[0] != nil || n.Parents[1] != nil {
+				t.Errorf("leaf %q has parents", n.Value)
+			}
+			return
+		}
+		if n.Parents[0] == nil || n.Parents[1] == nil {
+			t.Fatalf("%q is missing a parent", n.Value)
+		}
+		check(n.Parents[0], generations-1)
+		check(n.Parents[1], generations-1)
+	}
+	check(Build(3, letters(), concat), 3)
+}
+
+func TestPrint(t *testing.T) {
+	label := func(generation int, value string) string {
+		return fmt.Sprintf("%d: %s", generation, value)
+	}
+	tests := []struct {
+		root *Node[string]
+		want string
+	}{
+		{nil, ""},
+		{Build(1, letters(), concat), "0: a\n"},
+		{Build(2, letters(), concat), "0: (ab)\n    1: a\n    1: b\n"},
+		{
+			Build(3, letters(), concat),
+			"0: ((ab)(cd))\n" +
+				"    1: (ab)\n" +
+				"        2: a\n" +
+				"        2: b\n" +
+				"    1: (cd)\n" +
+				"        2: c\n" +
+				"        2: d\n",
+		},
+	}
+	for _, test := range tests {
+		var out bytes.Buffer
+		Print(&out, test.root, label)
+		if out.String() != test.want {
+			t.Errorf("Print =\n%s\nwant\n%s", out.String(), test.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// DNA pset: read a database of STR counts and a DNA sequence, work out the
+// longest run of every STR in the sequence and print whose counts match.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"strs"
+)
+
+func main() {
+	os.Exit(run(os.Args, os.Stdout))
+}
+
+// run is main with the arguments and output passed in, returning the exit
+// code.
+func run(argv []string, stdout io.Writer) int {
+	// Check for command-line usage
+	if len(argv) != 3 {
+		fmt.Fprintln(stdout, "Usage: ./dna data.csv sequence.txt")
+		return 1
+	}
+
+	// Read database file into a variable
+	file, err := os.Open(argv[1])
+	if err != nil {
+		fmt.Fprintln(stdout, err)
+		return 1
+	}
+	defer file.Close()
+	db, err := strs.ReadDatabase(file)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s: %v\n", argv[1], err)
+		return 1
+	}
+
+	// Stream the DNA sequence instead of reading it into a variable, so a
+	// whole genome fits in a few MB
+	sequence, err := os.Open(argv[2])
+	if err != nil {
+		fmt.Fprintln(stdout, err)
+		return 1
+	}
+	defer sequence.Close()
+
+	// Find longest match of each STR in DNA sequence, check database for
+	// matching profiles
+	p, ok, err := db.IdentifyReader(sequence, true)
+	if err != nil {
+		fmt.Fprintf(stdout, "%s: %v\n", argv[2], err)
+		return 1
+	}
+	if ok {
+		fmt.Fprintln(stdout, p.Name)
+	} else {
+		fmt.Fprintln(stdout, "No match")
+	}
+	return 0
+}
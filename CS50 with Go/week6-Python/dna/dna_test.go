@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		argv   []string
+		output string
+		code   int
+	}{
+		{[]string{"./dna", "databases/small.csv", "testdata/made-up/1.txt"}, "Bob\n", 0},
+		{[]string{"./dna", "databases/small.csv", "testdata/made-up/2.txt"}, "No match\n", 0},
+		{[]string{"./dna", "testdata/made-up/large.csv", "testdata/made-up/3.txt"}, "No match\n", 0},
+		{[]string{"./dna", "testdata/made-up/large.csv", "testdata/made-up/4.txt"}, "Ben\n", 0},
+		{[]string{"./dna", "databases/small.csv"}, "Usage: ./dna data.csv sequence.txt\n", 1},
+		{[]string{"./dna", "databases/missing.csv", "testdata/made-up/1.txt"}, "no such file", 1},
+		{[]string{"./dna", "testdata/made-up/1.txt", "testdata/made-up/1.txt"}, "header must be", 1},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		code := run(tt.argv, &out)
+		if !strings.Contains(out.String(), tt.output) || code != tt.code {
+			t.Errorf("run(%q) printed %q and returned %d, want %q and %d", tt.argv, out.String(), code, tt.output, tt.code)
+		}
+	}
+}
+
+// TestDistribution checks the pset's own data against check50's answers,
+// when it's here: unzip dna.zip's databases/ and sequences/ into this
+// directory, or into $DNA_DIR. It isn't in the repo.
+func TestDistribution(t *testing.T) {
+	dir := os.Getenv("DNA_DIR")
+	if dir == "" {
+		dir = "."
+	}
+	for _, file := range []string{"databases/large.csv", "sequences/20.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Skipf("no dna.zip data: %v", err)
+		}
+	}
+
+	// sequences 1-4 go with small.csv, the rest with large.csv
+	want := []string{
+		"Bob", "No match", "No match", "Alice",
+		"Lavender", "Luna", "Ron", "Ginny", "Draco", "Albus", "Hermione", "Lily",
+		"No match", "Severus", "Sirius", "No match", "Harry", "No match", "Fred", "No match",
+	}
+	for i, name := range want {
+		database := "databases/large.csv"
+		if i < 4 {
+			database = "databases/small.csv"
+		}
+		sequence := fmt.Sprintf("sequences/%d.txt", i+1)
+		var out bytes.Buffer
+		argv := []string{"./dna", filepath.Join(dir, database), filepath.Join(dir, sequence)}
+		if code := run(argv, &out); code != 0 || out.String() != name+"\n" {
+			t.Errorf("%s with %s printed %q and returned %d, want %q", sequence, database, out.String(), code, name)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+module dna
+
+go 1.24.4
+
+require strs v0.0.0
+
+require set v0.0.0 // indirect
+
+replace (
+	set => ../../week5-Data-Strucutes/set
+	strs => ../strs
+)
@@ -0,0 +1,7 @@
+module strs
+
+go 1.24.4
+
+require set v0.0.0
+
+replace set => ../../week5-Data-Strucutes/set
@@ -0,0 +1,141 @@
+package strs
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// CHUNK_SIZE is how much of the sequence ProfileReader reads at a time, and
+// so about all the memory it needs however long the sequence is.
+const CHUNK_SIZE = 1 << 20
+
+// Counter finds the longest run of one STR in a sequence written to it a
+// piece at a time, in a single pass: the sequence is never held in memory.
+// Whitespace is skipped, so line-wrapped sequence files work too.
+//
+// It's a KMP automaton over str. Every time a full copy of str ends at a
+// position, the copy is part of a run if another one ended len(str) bases
+// earlier, which is the same alignment as LongestRun's walk.
+type Counter struct {
+	str     string
+	fail    []int // fail[i]: longest proper prefix of str[:i+1] that is also its suffix
+	state   int   // how much of str the latest bases match
+	pos     int64 // bases seen
+	lastEnd []int64
+	run     []int // per alignment, pos % len(str)
+	longest int
+}
+
+// NewCounter returns a Counter for str.
+func NewCounter(str string) *Counter {
+	n := len(str)
+	c := &Counter{str: str, fail: make([]int, n), lastEnd: make([]int64, n), run: make([]int, n)}
+	for i, k := 1, 0; i < n; i++ {
+		for k > 0 && str[i] != str[k] {
+			k = c.fail[k-1]
+		}
+		if str[i] == str[k] {
+			k++
+		}
+		c.fail[i] = k
+	}
+	for i := range c.lastEnd {
+		c.lastEnd[i] = -1 - int64(n) // no copy ended yet
+	}
+	return c
+}
+
+// Write feeds the next bases of the sequence. It never fails.
+func (c *Counter) Write(p []byte) (int, error) {
+	n := len(c.str)
+	if n == 0 {
+		return len(p), nil
+	}
+	for _, b := range p {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		for c.state > 0 && b != c.str[c.state] {
+			c.state = c.fail[c.state-1]
+		}
+		if b == c.str[c.state] {
+			c.state++
+		}
+		if c.state == n {
+			// A copy of str ends here
+			i := c.pos % int64(n)
+			if c.lastEnd[i] == c.pos-int64(n) {
+				c.run[i]++
+			} else {
+				c.run[i] = 1
+			}
+			c.lastEnd[i] = c.pos
+			c.longest = max(c.longest, c.run[i])
+			c.state = c.fail[n-1]
+		}
+		c.pos++
+	}
+	return len(p), nil
+}
+
+// Longest returns the longest run so far.
+func (c *Counter) Longest() int {
+	return c.longest
+}
+
+// ProfileReader is Profile for a sequence read from r, CHUNK_SIZE bytes at
+// a time. With parallel, every STR scans each chunk in its own goroutine,
+// which pays off when there are several STRs and a long sequence.
+func ProfileReader(r io.Reader, strs []string, parallel bool) ([]int, error) {
+	counters := make([]*Counter, len(strs))
+	for i, str := range strs {
+		counters[i] = NewCounter(str)
+	}
+
+	buffer := make([]byte, CHUNK_SIZE)
+	r = bufio.NewReaderSize(r, CHUNK_SIZE)
+	for {
+		n, err := io.ReadFull(r, buffer)
+		chunk := buffer[:n]
+		if parallel && len(counters) > 1 {
+			var wg sync.WaitGroup
+			for _, c := range counters {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					c.Write(chunk)
+				}()
+			}
+			wg.Wait()
+		} else {
+			for _, c := range counters {
+				c.Write(chunk)
+			}
+		}
+
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	counts := make([]int, len(counters))
+	for i, c := range counters {
+		counts[i] = c.Longest()
+	}
+	return counts, nil
+}
+
+// IdentifyReader is Identify for a sequence read from r.
+func (db *Database) IdentifyReader(r io.Reader, parallel bool) (Person, bool, error) {
+	profile, err := ProfileReader(r, db.STRs, parallel)
+	if err != nil {
+		return Person{}, false, err
+	}
+	p, ok := db.Match(profile)
+	return p, ok, nil
+}
@@ -0,0 +1,166 @@
+package strs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCounter(t *testing.T) {
+	tests := []struct {
+		sequence, str string
+		want          int
+	}{
+		{"TTAGATCAGATCAGATCGG", "AGATC", 3},
+		{"AAAAA", "AA", 2},
+		{"AATGAATGAATG", "ATGA", 2},
+		{"ABABABA", "ABA", 1}, // overlapping copies aren't a run
+		{"TTAGATCAGA\nTCAGATCGG\n", "AGATC", 3},
+		{"", "AATG", 0},
+	}
+	for _, tt := range tests {
+		c := NewCounter(tt.str)
+		// One base at a time, so every run straddles a Write
+		for i := range len(tt.sequence) {
+			c.Write([]byte{tt.sequence[i]})
+		}
+		if got := c.Longest(); got != tt.want {
+			t.Errorf("Counter(%q) on %q = %d, want %d", tt.str, tt.sequence, got, tt.want)
+		}
+	}
+}
+
+func TestProfileReaderMatchesProfile(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	strs := []string{"AGATC", "AATG", "TATC", "AA", "GAGAG"}
+	for range 100 {
+		sequence := make([]byte, rng.Intn(2000))
+		for i := range sequence {
+			sequence[i] = "AGTC"[rng.Intn(4)]
+		}
+		want := Profile(string(sequence), strs)
+
+		readers := map[string]io.Reader{
+			"whole":    bytes.NewReader(sequence),
+			"one byte": iotest.OneByteReader(bytes.NewReader(sequence)),
+			"half":     iotest.HalfReader(bytes.NewReader(sequence)),
+		}
+		for name, r := range readers {
+			parallel := name == "half"
+			got, err := ProfileReader(r, strs, parallel)
+			if err != nil || !slices.Equal(got, want) {
+				t.Fatalf("%s: ProfileReader = %v, %v, want %v", name, got, err, want)
+			}
+		}
+	}
+}
+
+func TestProfileReaderLongRuns(t *testing.T) {
+	// Runs across CHUNK_SIZE boundaries
+	strs := []string{"AGATC", "TTTTTTCT"}
+	counts := []int{CHUNK_SIZE / 5 * 2, 3}
+	sequence := "G" + strings.Repeat("AGATC", counts[0]) + "G" + strings.Repeat("TTTTTTCT", counts[1]) + "\n"
+	for _, parallel := range []bool{false, true} {
+		got, err := ProfileReader(strings.NewReader(sequence), strs, parallel)
+		if err != nil || !slices.Equal(got, counts) {
+			t.Errorf("parallel %v: %v, %v, want %v", parallel, got, err, counts)
+		}
+	}
+}
+
+func TestProfileReaderError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("AATG"), iotest.ErrReader(errors.New("disk on fire")))
+	if _, err := ProfileReader(r, []string{"AATG"}, false); err == nil || err.Error() != "disk on fire" {
+		t.Errorf("error %v, want disk on fire", err)
+	}
+}
+
+// dnaReader streams size bytes of DNA with runs of the STRs mixed in, so
+// benchmarks can scan hundreds of MB without holding them. It repeats one
+// random block over and over: generating bases as it goes would be slower
+// than scanning them.
+type dnaReader struct {
+	block []byte
+	at    int
+	left  int64
+}
+
+// DNA_BLOCK is the size of the block dnaReader repeats.
+const DNA_BLOCK = 4 << 20
+
+func newDNAReader(strs []string, size int64) *dnaReader {
+	rng := rand.New(rand.NewSource(1))
+	block := make([]byte, 0, DNA_BLOCK+1000)
+	for len(block) < DNA_BLOCK {
+		if rng.Intn(4) == 0 {
+			str := strs[rng.Intn(len(strs))]
+			block = append(block, strings.Repeat(str, 1+rng.Intn(20))...)
+		} else {
+			for range 64 {
+				block = append(block, BASES[rng.Intn(4)])
+			}
+		}
+	}
+	return &dnaReader{block: block, left: size}
+}
+
+func (d *dnaReader) Read(p []byte) (int, error) {
+	if d.left <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > d.left {
+		p = p[:d.left]
+	}
+	n := copy(p, d.block[d.at:])
+	d.at = (d.at + n) % len(d.block)
+	d.left -= int64(n)
+	return n, nil
+}
+
+// BENCH_SIZE is the synthetic sequence length, cut down with -short.
+const BENCH_SIZE = 256 << 20
+
+var LARGE_STRS = []string{"AGATC", "TTTTTTCT", "AATG", "TCTAG", "GATA", "TATC", "GAAA", "TCTG"}
+
+func benchSize() int64 {
+	if testing.Short() {
+		return 16 << 20
+	}
+	return BENCH_SIZE
+}
+
+// go test -bench . -benchmem: the streaming scan holds a couple of chunks,
+// the string version needs the whole sequence in memory first.
+func BenchmarkProfileReader(b *testing.B) {
+	for _, parallel := range []bool{false, true} {
+		name := "serial"
+		if parallel {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(benchSize())
+			for range b.N {
+				r := newDNAReader(LARGE_STRS, benchSize())
+				if _, err := ProfileReader(r, LARGE_STRS, parallel); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkProfile(b *testing.B) {
+	b.SetBytes(benchSize())
+	for range b.N {
+		sequence, err := io.ReadAll(newDNAReader(LARGE_STRS, benchSize()))
+		if err != nil {
+			b.Fatal(err)
+		}
+		Profile(string(sequence), LARGE_STRS)
+	}
+}
@@ -0,0 +1,121 @@
+// Package strs finds short tandem repeats (STRs) in a DNA sequence and
+// matches the resulting profile against a database of people, the core of
+// CS50's dna pset.
+package strs
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"set"
+)
+
+// LongestRun returns the most times str repeats back to back in sequence,
+// e.g. 3 for "AGATC" in "TTAGATCAGATCAGATCGG".
+//
+// The CS50 distribution code restarts the count at every position, which
+// rescans the same repeats over and over. Runs of str can only line up at
+// one of len(str) offsets, so walking each offset in steps of len(str) looks
+// at every character len(str) times at most.
+func LongestRun(sequence, str string) int {
+	n := len(str)
+	if n == 0 {
+		return 0
+	}
+
+	longest := 0
+	for offset := 0; offset < n; offset++ {
+		run := 0
+		for i := offset; i+n <= len(sequence); i += n {
+			if sequence[i:i+n] == str {
+				run++
+				longest = max(longest, run)
+			} else {
+				run = 0
+			}
+		}
+	}
+	return longest
+}
+
+// Profile returns LongestRun of every STR, in order.
+func Profile(sequence string, strs []string) []int {
+	counts := make([]int, len(strs))
+	for i, str := range strs {
+		counts[i] = LongestRun(sequence, str)
+	}
+	return counts
+}
+
+// Person is one row of a database: a name and a count per STR.
+type Person struct {
+	Name   string
+	Counts []int
+}
+
+// Database is the CSV the pset ships: a header "name,STR1,STR2,..." and a
+// row of counts per person.
+type Database struct {
+	STRs   []string
+	People []Person
+}
+
+// ReadDatabase parses a database CSV. Rows are checked for the right number
+// of whole, non-negative counts, and STRs and names must not repeat.
+func ReadDatabase(r io.Reader) (*Database, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || len(records[0]) < 2 || records[0][0] != "name" {
+		return nil, fmt.Errorf("header must be name,STR,...")
+	}
+
+	db := &Database{STRs: records[0][1:]}
+	seen := set.New[string]()
+	for _, str := range db.STRs {
+		if str == "" || strings.Trim(str, "ACGT") != "" {
+			return nil, fmt.Errorf("header: %q is not an STR", str)
+		}
+		if !seen.Add(str) {
+			return nil, fmt.Errorf("header: %s appears twice", str)
+		}
+	}
+
+	names := set.New[string]()
+	for i, record := range records[1:] {
+		row := i + 2 // header = row 1
+		if !names.Add(record[0]) {
+			return nil, fmt.Errorf("row %d: %s appears twice", row, record[0])
+		}
+		p := Person{Name: record[0], Counts: make([]int, len(db.STRs))}
+		for j, field := range record[1:] {
+			count, err := strconv.Atoi(field)
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("row %d: %s count %q is not a whole number", row, db.STRs[j], field)
+			}
+			p.Counts[j] = count
+		}
+		db.People = append(db.People, p)
+	}
+	return db, nil
+}
+
+// Match returns the person whose counts are exactly profile.
+func (db *Database) Match(profile []int) (Person, bool) {
+	for _, p := range db.People {
+		if slices.Equal(p.Counts, profile) {
+			return p, true
+		}
+	}
+	return Person{}, false
+}
+
+// Identify profiles sequence with the database's STRs and matches it.
+func (db *Database) Identify(sequence string) (Person, bool) {
+	return db.Match(Profile(sequence, db.STRs))
+}
@@ -0,0 +1,133 @@
+package strs
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLongestRun(t *testing.T) {
+	tests := []struct {
+		sequence, str string
+		want          int
+	}{
+		{"TTAGATCAGATCAGATCGG", "AGATC", 3},
+		{"AGATCTTAGATCAGATC", "AGATC", 2}, // the longer run comes second
+		{"AAAA", "AA", 2},
+		{"AAAAA", "AA", 2},
+		{"AATGAATGAATG", "ATGA", 2}, // runs needn't start at 0
+		{"CCCC", "AATG", 0},
+		{"AATG", "AATG", 1},
+		{"", "AATG", 0},
+		{"AATG", "", 0},
+	}
+	for _, tt := range tests {
+		if got := LongestRun(tt.sequence, tt.str); got != tt.want {
+			t.Errorf("LongestRun(%q, %q) = %d, want %d", tt.sequence, tt.str, got, tt.want)
+		}
+	}
+}
+
+// naiveLongestRun is the CS50 distribution code's longest_match.
+func naiveLongestRun(sequence, str string) int {
+	longest := 0
+	for i := range sequence {
+		count := 0
+		for {
+			start := i + count*len(str)
+			end := start + len(str)
+			if end > len(sequence) || sequence[start:end] != str {
+				break
+			}
+			count++
+		}
+		longest = max(longest, count)
+	}
+	return longest
+}
+
+func TestLongestRunMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 200 {
+		sequence := make([]byte, rng.Intn(200))
+		for i := range sequence {
+			sequence[i] = "AG"[rng.Intn(2)] // two letters make lots of runs
+		}
+		str := string(sequence[:min(len(sequence), 1+rng.Intn(3))])
+		if str == "" {
+			continue
+		}
+		if got, want := LongestRun(string(sequence), str), naiveLongestRun(string(sequence), str); got != want {
+			t.Fatalf("LongestRun(%q, %q) = %d, naive %d", sequence, str, got, want)
+		}
+	}
+}
+
+func TestReadDatabaseErrors(t *testing.T) {
+	tests := []struct {
+		csv, want string
+	}{
+		{"", "header"},
+		{"id,AATG\n", "header"},
+		{"name,AATG,XYZ\n", `"XYZ" is not an STR`},
+		{"name,AATG,AATG\n", "AATG appears twice"},
+		{"name,AATG\nAlice,1\nAlice,2\n", "row 3: Alice appears twice"},
+		{"name,AATG\nAlice,x\n", `row 2: AATG count "x"`},
+		{"name,AATG\nAlice,-1\n", `row 2: AATG count "-1"`},
+		{"name,AATG\nAlice,1,2\n", "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		_, err := ReadDatabase(strings.NewReader(tt.csv))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ReadDatabase(%q): error %v, want %q", tt.csv, err, tt.want)
+		}
+	}
+}
+
+func loadDatabase(t *testing.T, name string) *Database {
+	t.Helper()
+	file, err := os.Open(filepath.Join("..", "dna", "databases", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	db, err := ReadDatabase(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Everyone in the pset's databases is found in a sequence made from their
+// counts, and a sequence one repeat off matches nobody.
+func TestDatabases(t *testing.T) {
+	rng := rand.New(rand.NewSource(50))
+	for _, name := range []string{"small.csv", "large.csv"} {
+		db := loadDatabase(t, name)
+		for _, p := range db.People {
+			sequence := Synthesize(rng, db.STRs, p.Counts, 100)
+			if got, ok := db.Identify(sequence); !ok || got.Name != p.Name {
+				t.Errorf("%s: %s's sequence matched %q, %v", name, p.Name, got.Name, ok)
+			}
+
+			counts := slices.Clone(p.Counts)
+			counts[rng.Intn(len(counts))]++
+			if got, ok := db.Identify(Synthesize(rng, db.STRs, counts, 100)); ok {
+				t.Errorf("%s: %v matched %s", name, counts, got.Name)
+			}
+		}
+	}
+}
+
+func TestSmallDatabase(t *testing.T) {
+	db := loadDatabase(t, "small.csv")
+	if !slices.Equal(db.STRs, []string{"AGATC", "AATG", "TATC"}) || len(db.People) != 3 {
+		t.Fatalf("small.csv: %v, %d people", db.STRs, len(db.People))
+	}
+	if p, ok := db.Match([]int{4, 1, 5}); !ok || p.Name != "Bob" {
+		t.Errorf("Match(4,1,5) = %v, %v, want Bob", p, ok)
+	}
+}
@@ -0,0 +1,57 @@
+package strs
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// BASES are the four nucleotides.
+const BASES = "ACGT"
+
+// Synthesize makes a DNA sequence whose Profile for strs is counts: each run
+// in random order, separated by about filler random bases that never spell
+// one of the STRs. Tests and benchmarks use it instead of shipping big files.
+func Synthesize(rng *rand.Rand, strs []string, counts []int, filler int) string {
+	for {
+		var b strings.Builder
+		fill := func() {
+			for n := filler/2 + rng.Intn(filler+1); n > 0; n-- {
+				b.WriteByte(safeBase(rng, b.String(), strs))
+			}
+		}
+
+		fill()
+		for _, i := range rng.Perm(len(strs)) {
+			b.WriteString(strings.Repeat(strs[i], counts[i]))
+			fill()
+		}
+
+		// A run next to the filler can still, rarely, make an extra repeat
+		sequence := b.String()
+		if slices.Equal(Profile(sequence, strs), counts) {
+			return sequence
+		}
+	}
+}
+
+// safeBase returns a random base that doesn't complete an STR at the end of
+// sequence.
+func safeBase(rng *rand.Rand, sequence string, strs []string) byte {
+	start := rng.Intn(len(BASES))
+	for i := range len(BASES) {
+		base := BASES[(start+i)%len(BASES)]
+		ok := true
+		for _, str := range strs {
+			n := len(str)
+			if str[n-1] == base && strings.HasSuffix(sequence, str[:n-1]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return base
+		}
+	}
+	return BASES[start]
+}
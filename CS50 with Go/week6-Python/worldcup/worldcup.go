@@ -0,0 +1,147 @@
+// World Cup lab: simulate a knockout tournament many times from the teams'
+// FIFA ratings and report how often each team wins it.
+//
+//	./worldcup 2018m.csv
+//	./worldcup -n 100000 -seed 50 2019w.csv
+
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"rngutil"
+)
+
+// N is the number of simulations to run.
+const N = 1000
+
+// RATING_SCALE is the rating difference that makes the better team 10 times
+// as likely to win (the Elo-style formula the lab uses).
+const RATING_SCALE = 600
+
+// Team is one row of the ratings CSV.
+type Team struct {
+	Name   string
+	Rating int
+}
+
+func main() {
+	n := flag.Int("n", N, "number of tournaments to simulate")
+	seed := flag.Int64("seed", 0, "random seed, to get the same results again (0 = random)")
+	flag.Parse()
+
+	// Ensure correct usage
+	if flag.NArg() != 1 || *n < 1 {
+		fmt.Println("Usage: ./worldcup [-n N] [-seed S] FILENAME")
+		os.Exit(1)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	teams, err := readTeams(file)
+	file.Close()
+	if err != nil {
+		fmt.Printf("%s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+
+	rng := rngutil.New(seed)
+
+	// Simulate N tournaments and keep track of win counts
+	counts := make(map[string]int)
+	for range *n {
+		counts[simulateTournament(rng, teams)]++
+	}
+
+	// Print each team's chances of winning, according to simulation
+	report(os.Stdout, teams, counts, *n)
+	fmt.Printf("\n%d tournaments, seed %d\n", *n, *seed)
+}
+
+// readTeams reads a "team,rating" CSV. A knockout needs a power of two teams.
+func readTeams(r io.Reader) ([]Team, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || len(records[0]) != 2 || records[0][0] != "team" || records[0][1] != "rating" {
+		return nil, fmt.Errorf("header must be team,rating")
+	}
+
+	var teams []Team
+	for i, record := range records[1:] {
+		rating, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: rating %q is not a number", i+2, record[1])
+		}
+		teams = append(teams, Team{Name: record[0], Rating: rating})
+	}
+	if len(teams) < 2 || len(teams)&(len(teams)-1) != 0 {
+		return nil, fmt.Errorf("%d teams, a knockout needs 2, 4, 8, 16, ...", len(teams))
+	}
+	return teams, nil
+}
+
+// winProbability is the chance team1 beats team2.
+func winProbability(team1, team2 Team) float64 {
+	return 1 / (1 + math.Pow(10, float64(team2.Rating-team1.Rating)/RATING_SCALE))
+}
+
+// simulateGame reports whether team1 beats team2.
+func simulateGame(rng *rand.Rand, team1, team2 Team) bool {
+	return rng.Float64() < winProbability(team1, team2)
+}
+
+// simulateRound plays the teams in pairs and returns the winners.
+func simulateRound(rng *rand.Rand, teams []Team) []Team {
+	winners := make([]Team, 0, len(teams)/2)
+	for i := 0; i < len(teams); i += 2 {
+		if simulateGame(rng, teams[i], teams[i+1]) {
+			winners = append(winners, teams[i])
+		} else {
+			winners = append(winners, teams[i+1])
+		}
+	}
+	return winners
+}
+
+// simulateTournament plays rounds until one team is left and returns its
+// name.
+func simulateTournament(rng *rand.Rand, teams []Team) string {
+	for len(teams) > 1 {
+		teams = simulateRound(rng, teams)
+	}
+	return teams[0].Name
+}
+
+// report prints every team that won at least once, most wins first, as a
+// table.
+func report(w io.Writer, teams []Team, counts map[string]int, n int) {
+	sorted := append([]Team(nil), teams...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return counts[sorted[i].Name] > counts[sorted[j].Name]
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Team\tRating\tTitles\tChance")
+	for _, team := range sorted {
+		if counts[team.Name] == 0 {
+			continue
+		}
+		chance := float64(counts[team.Name]) * 100 / float64(n)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f%%\n", team.Name, team.Rating, counts[team.Name], chance)
+	}
+	tw.Flush()
+}
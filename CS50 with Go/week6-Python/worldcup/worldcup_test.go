@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWinProbability(t *testing.T) {
+	tests := []struct {
+		rating1, rating2 int
+		want             float64
+	}{
+		{1000, 1000, 0.5},
+		{1600, 1000, 10.0 / 11}, // 600 points better: 10 to 1
+		{1000, 1600, 1.0 / 11},
+	}
+	for _, tt := range tests {
+		got := winProbability(Team{Rating: tt.rating1}, Team{Rating: tt.rating2})
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("winProbability(%d, %d) = %f, want %f", tt.rating1, tt.rating2, got, tt.want)
+		}
+	}
+}
+
+func TestReadTeams(t *testing.T) {
+	for _, name := range []string{"2018m.csv", "2019w.csv"} {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		teams, err := readTeams(file)
+		file.Close()
+		if err != nil || len(teams) != 16 {
+			t.Errorf("%s: %d teams, %v", name, len(teams), err)
+		}
+	}
+
+	tests := []struct {
+		csv, want string
+	}{
+		{"name,rating\nA,1\nB,2\n", "header"},
+		{"team,rating\nA,1\nB,x\n", `row 3: rating "x"`},
+		{"team,rating\nA,1\nB,2\nC,3\n", "3 teams"},
+		{"team,rating\nA,1\n", "1 teams"},
+	}
+	for _, tt := range tests {
+		if _, err := readTeams(strings.NewReader(tt.csv)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("readTeams(%q): error %v, want %q", tt.csv, err, tt.want)
+		}
+	}
+}
+
+func TestSimulateTournament(t *testing.T) {
+	teams := []Team{{"A", 3000}, {"B", 0}, {"C", 0}, {"D", 0}}
+
+	// A is 10^5 times as likely to win every game
+	rng := rand.New(rand.NewSource(1))
+	for range 100 {
+		if got := simulateTournament(rng, teams); got != "A" {
+			t.Fatalf("%s won", got)
+		}
+	}
+
+	// The same seed gives the same tournaments
+	even := []Team{{"A", 1000}, {"B", 1000}, {"C", 1000}, {"D", 1000}}
+	run := func() string {
+		rng := rand.New(rand.NewSource(50))
+		var winners strings.Builder
+		for range 20 {
+			winners.WriteString(simulateTournament(rng, even))
+		}
+		return winners.String()
+	}
+	if a, b := run(), run(); a != b {
+		t.Errorf("seed 50 gave %s then %s", a, b)
+	}
+}
+
+func TestReport(t *testing.T) {
+	teams := []Team{{"Japan", 528}, {"Brazil", 1384}, {"Spain", 1162}}
+	var out bytes.Buffer
+	report(&out, teams, map[string]int{"Brazil": 3, "Spain": 1}, 4)
+	want := "Team    Rating  Titles  Chance\n" +
+		"Brazil  1384    3       75.0%\n" +
+		"Spain   1162    1       25.0%\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,96 @@
+// csvdb loads CSV files into SQLite, the Go version of the sqlite3 shell's
+// .import that the DNA and SQL weeks lean on, but with real column types.
+//
+//	./csvdb import shows.csv -db shows.db
+//	./csvdb import scores.csv -db out.db -table scores -type id=TEXT
+//	./csvdb import more.csv -db out.db -table scores -append
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dbutil"
+)
+
+const USAGE = "Usage: csvdb import data.csv -db out.db [-table t] [-append] [-type column=TYPE ...]"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
+
+// run handles one subcommand and returns the exit code.
+func run(args []string, w io.Writer) int {
+	if len(args) < 1 || args[0] != "import" {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(w)
+	dbPath := flags.String("db", "", "SQLite file to import into (created if missing)")
+	table := flags.String("table", "", "table name (default: the CSV file's name)")
+	appendRows := flags.Bool("append", false, "add the rows to an existing table")
+	types := typeOverrides{}
+	flags.Var(types, "type", "column=TYPE (INTEGER, REAL or TEXT) instead of the inferred type; repeatable")
+	positional, err := parseInterspersed(flags, args[1:])
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || *dbPath == "" {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+
+	path := positional[0]
+	if *table == "" {
+		*table = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+	defer file.Close()
+
+	db, err := dbutil.Create(*dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+
+	columns, n, err := importCSV(db, file, options{table: *table, append: *appendRows, types: types})
+	if err != nil {
+		fmt.Fprintf(w, "%s: %v\n", path, err)
+		return 2
+	}
+	fmt.Fprintf(w, "Imported %d rows into %s:\n", n, *table)
+	for _, c := range columns {
+		fmt.Fprintf(w, "    %-20s %s\n", c.name, c.typ)
+	}
+	return 0
+}
+
+// parseInterspersed lets flags come after the file name too
+// ("import data.csv -db out.db"); the flag package stops at the first
+// argument that isn't a flag.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"dbutil"
+)
+
+func TestInference(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "-2", "30"}, INTEGER},
+		{[]string{"1", "2.5", ""}, REAL},
+		{[]string{"1e6", "0.5", "-.25"}, REAL},
+		{[]string{"1", "two"}, TEXT},
+		{[]string{"02139", "10001"}, TEXT}, // zip codes keep their zeros
+		{[]string{"0", "7"}, INTEGER},
+		{[]string{"NaN"}, TEXT},
+		{[]string{"0x1F"}, TEXT},
+		{[]string{"", ""}, TEXT},
+		{nil, TEXT},
+	}
+	for _, tt := range tests {
+		var in inference
+		for _, v := range tt.values {
+			in.see(v)
+		}
+		if got := in.result(); got != tt.want {
+			t.Errorf("%q: %s, want %s", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestTypeOverrides(t *testing.T) {
+	types := typeOverrides{}
+	if err := types.Set("id=text,score=Real"); err != nil || types["id"] != TEXT || types["score"] != REAL {
+		t.Errorf("Set: %v, %v", types, err)
+	}
+	for _, bad := range []string{"id", "=TEXT", "id=BLOB"} {
+		if err := types.Set(bad); err == nil {
+			t.Errorf("Set(%q): want error", bad)
+		}
+	}
+}
+
+func TestInferColumns(t *testing.T) {
+	csv := "id,name,score,zip\n1,Ann,9.5,02139\n2,\"Bo, Jr.\",,10001\n"
+	columns, err := inferColumns(strings.NewReader(csv), typeOverrides{"id": TEXT})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []column{{"id", TEXT}, {"name", TEXT}, {"score", REAL}, {"zip", TEXT}}
+	if !slices.Equal(columns, want) {
+		t.Errorf("columns %v, want %v", columns, want)
+	}
+
+	for _, bad := range []string{"", "a,a\n", "a,\n", "a,b\n1\n"} {
+		if _, err := inferColumns(strings.NewReader(bad), nil); err == nil {
+			t.Errorf("inferColumns(%q): want error", bad)
+		}
+	}
+	if _, err := inferColumns(strings.NewReader("a\n1\n"), typeOverrides{"b": TEXT}); err == nil {
+		t.Error("override of a missing column: want error")
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	db := flags.String("db", "", "")
+	positional, err := parseInterspersed(flags, []string{"data.csv", "-db", "out.db"})
+	if err != nil || !slices.Equal(positional, []string{"data.csv"}) || *db != "out.db" {
+		t.Errorf("got %q, %q, %v", positional, *db, err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "shows.csv")
+	dbPath := filepath.Join(dir, "shows.db")
+	os.WriteFile(csvPath, []byte("title,year,rating\nThe Office,2005,9.0\nFriends,1994,\n"), 0o644)
+
+	if code := run([]string{"import", csvPath, "-db", dbPath}, io.Discard); code != 0 {
+		t.Fatalf("import: exit %d", code)
+	}
+	// Again without -append is refused and changes nothing
+	if code := run([]string{"import", csvPath, "-db", dbPath}, io.Discard); code != 2 {
+		t.Errorf("second import: exit %d, want 2", code)
+	}
+	if code := run([]string{"import", csvPath, "-db", dbPath, "-append"}, io.Discard); code != 0 {
+		t.Errorf("-append: exit %d", code)
+	}
+
+	db, err := dbutil.Open(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	table, err := dbutil.Query(db, "SELECT typeof(year), typeof(rating), COUNT(*) FROM shows GROUP BY 1, 2 ORDER BY 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, row := range table.Rows {
+		got = append(got, strings.Join(row, " "))
+	}
+	if want := []string{"integer null 2", "integer real 2"}; !slices.Equal(got, want) {
+		t.Errorf("stored %q, want %q", got, want)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"export"}, {"import"}, {"import", "a.csv"}, {"import", "a.csv", "b.csv", "-db", "x.db"}} {
+		var out strings.Builder
+		if code := run(args, &out); code != 1 || !strings.Contains(out.String(), "Usage:") {
+			t.Errorf("run(%q) = %d, %q", args, code, out.String())
+		}
+	}
+}
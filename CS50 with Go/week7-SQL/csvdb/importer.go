@@ -0,0 +1,173 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// options are import's flags.
+type options struct {
+	table  string
+	append bool          // add to an existing table instead of creating it
+	types  typeOverrides // column -> type, beats inference
+}
+
+// column is one CSV column and its SQLite type.
+type column struct {
+	name string
+	typ  string
+}
+
+// importCSV reads r twice: once to infer the column types, then again to
+// insert every row. It all happens in one transaction, which is what makes
+// bulk inserts fast (SQLite syncs to disk once, not per row) and means a bad
+// row leaves the database as it was. It returns the columns and the number
+// of rows imported.
+func importCSV(db *sql.DB, r io.ReadSeeker, opts options) ([]column, int, error) {
+	columns, err := inferColumns(r, opts.types)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback() // does nothing after Commit
+
+	exists, err := tableExists(tx, opts.table)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch {
+	case exists && !opts.append:
+		return nil, 0, fmt.Errorf("table %s already exists (use -append to add to it)", opts.table)
+	case !exists:
+		if _, err := tx.Exec(createTable(opts.table, columns)); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	n, err := insertRows(tx, csv.NewReader(r), opts.table, columns)
+	if err != nil {
+		return nil, 0, err
+	}
+	return columns, n, tx.Commit()
+}
+
+// inferColumns reads the header and every row, narrowing each column's type.
+func inferColumns(r io.Reader, overrides typeOverrides) ([]column, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty file, no header")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	for _, name := range header {
+		if strings.TrimSpace(name) == "" || seen[name] {
+			return nil, fmt.Errorf("header: column names must be unique and not blank, got %q", header)
+		}
+		seen[name] = true
+	}
+	for name := range overrides {
+		if !seen[name] {
+			return nil, fmt.Errorf("-type %s: no such column", name)
+		}
+	}
+
+	inferences := make([]inference, len(header))
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range record {
+			inferences[i].see(value)
+		}
+	}
+
+	columns := make([]column, len(header))
+	for i, name := range header {
+		columns[i] = column{name: name, typ: inferences[i].result()}
+		if typ, ok := overrides[name]; ok {
+			columns[i].typ = typ
+		}
+	}
+	return columns, nil
+}
+
+// quote makes name safe to use as an SQL identifier.
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func createTable(table string, columns []column) string {
+	definitions := make([]string, len(columns))
+	for i, c := range columns {
+		definitions[i] = quote(c.name) + " " + c.typ
+	}
+	return fmt.Sprintf("CREATE TABLE %s (%s)", quote(table), strings.Join(definitions, ", "))
+}
+
+func tableExists(tx *sql.Tx, table string) (bool, error) {
+	var n int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&n)
+	return n > 0, err
+}
+
+// insertRows skips the header and inserts the rest with one prepared
+// statement. Values go in as text and SQLite's type affinity stores them as
+// numbers in INTEGER and REAL columns; empty values are NULL.
+func insertRows(tx *sql.Tx, reader *csv.Reader, table string, columns []column) (int, error) {
+	if _, err := reader.Read(); err != nil {
+		return 0, err
+	}
+
+	names := make([]string, len(columns))
+	marks := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = quote(c.name)
+		marks[i] = "?"
+	}
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", quote(table), strings.Join(names, ", "), strings.Join(marks, ", ")))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	n := 0
+	values := make([]any, len(columns))
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		for i, value := range record {
+			values[i] = value
+			if value == "" {
+				values[i] = nil
+			}
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			return n, fmt.Errorf("row %d: %w", n+2, err)
+		}
+		n++
+	}
+}
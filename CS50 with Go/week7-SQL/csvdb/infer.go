@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SQLite column types csvdb creates.
+const (
+	INTEGER = "INTEGER"
+	REAL    = "REAL"
+	TEXT    = "TEXT"
+)
+
+// TYPES are the accepted -type overrides.
+var TYPES = []string{INTEGER, REAL, TEXT}
+
+// inference narrows a column's type as values are seen: it starts as
+// INTEGER and falls back to REAL, then TEXT, at the first value that doesn't
+// fit. Empty values become NULL and don't count.
+type inference struct {
+	typ  string
+	seen bool // a non-empty value
+}
+
+func (in *inference) see(value string) {
+	if value == "" || in.typ == TEXT {
+		return
+	}
+	in.seen = true
+	if in.typ == "" {
+		in.typ = INTEGER
+	}
+	if in.typ == INTEGER && !isInteger(value) {
+		in.typ = REAL
+	}
+	if in.typ == REAL && !isReal(value) {
+		in.typ = TEXT
+	}
+}
+
+// result is the inferred type; a column with no values at all is TEXT.
+func (in *inference) result() string {
+	if !in.seen {
+		return TEXT
+	}
+	return in.typ
+}
+
+// isInteger accepts whole numbers, but not ones with leading zeros like zip
+// codes or "007", which would lose the zeros as INTEGER.
+func isInteger(s string) bool {
+	if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+		return false
+	}
+	digits := strings.TrimLeft(s, "+-")
+	return len(digits) == 1 || digits[0] != '0'
+}
+
+// isReal accepts decimals like "3.14", "-0.5" and "1e6", with the same
+// rule about leading zeros, but not NaN, Inf or hex.
+func isReal(s string) bool {
+	if _, err := strconv.ParseFloat(s, 64); err != nil || strings.ContainsAny(s, "xXnNiI_") {
+		return false
+	}
+	whole, _, _ := strings.Cut(strings.TrimLeft(s, "+-"), ".")
+	return len(whole) <= 1 || whole[0] != '0'
+}
+
+// typeOverrides is the repeatable -type column=TYPE flag.
+type typeOverrides map[string]string
+
+func (t typeOverrides) String() string {
+	var pairs []string
+	for column, typ := range t {
+		pairs = append(pairs, column+"="+typ)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (t typeOverrides) Set(s string) error {
+	for _, pair := range strings.Split(s, ",") {
+		column, typ, ok := strings.Cut(pair, "=")
+		typ = strings.ToUpper(strings.TrimSpace(typ))
+		if !ok || column == "" {
+			return fmt.Errorf("want column=TYPE, got %q", pair)
+		}
+		valid := false
+		for _, name := range TYPES {
+			valid = valid || typ == name
+		}
+		if !valid {
+			return fmt.Errorf("type %q for %s (want %s)", typ, column, strings.Join(TYPES, ", "))
+		}
+		t[strings.TrimSpace(column)] = typ
+	}
+	return nil
+}
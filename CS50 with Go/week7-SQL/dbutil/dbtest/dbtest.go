@@ -0,0 +1,54 @@
+// Package dbtest has the databases the week 7 tests share, so a fixture
+// like the movies pset's is written once instead of in every test file.
+package dbtest
+
+import (
+	"database/sql"
+	_ "embed"
+	"path/filepath"
+	"testing"
+
+	"dbutil"
+)
+
+// MOVIES is testdata/movies.sql: the movies pset's schema and a few rows.
+//
+//go:embed testdata/movies.sql
+var MOVIES string
+
+// Load runs script in a fresh in-memory database, which is closed when the
+// test ends.
+func Load(t testing.TB, script string) *sql.DB {
+	t.Helper()
+	db, err := dbutil.Memory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(script); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// Movies is an in-memory copy of the movies fixture.
+func Movies(t testing.TB) *sql.DB {
+	t.Helper()
+	return Load(t, MOVIES)
+}
+
+// MoviesFile writes the movies fixture to a movies.db in a temporary
+// directory, for programs that open a file, and returns its path.
+func MoviesFile(t testing.TB) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "movies.db")
+	db, err := dbutil.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(MOVIES); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,34 @@
+-- A small stand-in for the movies pset's movies.db, with its schema, where
+-- every question has rows to find and rows to leave out. The NULL year and
+-- birth are there because the real data has them too.
+
+CREATE TABLE movies (id INTEGER, title TEXT NOT NULL, year NUMERIC, PRIMARY KEY(id));
+CREATE TABLE people (id INTEGER, name TEXT NOT NULL, birth NUMERIC, PRIMARY KEY(id));
+CREATE TABLE stars (movie_id INTEGER NOT NULL, person_id INTEGER NOT NULL);
+CREATE TABLE directors (movie_id INTEGER NOT NULL, person_id INTEGER NOT NULL);
+CREATE TABLE ratings (movie_id INTEGER NOT NULL, rating REAL NOT NULL, votes INTEGER NOT NULL);
+
+INSERT INTO movies VALUES
+    (1, 'Toy Story', 1995), (2, 'Iron Man', 2008), (3, 'The Dark Knight', 2008),
+    (4, 'Black Panther', 2018), (5, 'A Star Is Born', 2018),
+    (6, 'Harry Potter and the Goblet of Fire', 2005), (7, 'Harry Potter and the Chamber of Secrets', 2002),
+    (8, 'Inception', 2010), (9, 'Toy Story 3', 2010), (10, 'The Notebook', 2004),
+    (11, 'Silver Linings Playbook', 2012), (12, 'Apollo 13', 1995), (13, 'Get on Up', 2014),
+    (14, 'Untitled', NULL);
+
+INSERT INTO people VALUES
+    (1, 'Tom Hanks', 1956), (2, 'Tim Allen', 1953), (3, 'Emma Stone', 1988),
+    (4, 'Chadwick Boseman', 1976), (5, 'Bradley Cooper', 1975), (6, 'Jennifer Lawrence', 1990),
+    (7, 'Ryan Gosling', 1980), (8, 'Rachel McAdams', 1978), (9, 'Christopher Nolan', 1970),
+    (10, 'Kevin Bacon', 1958), (11, 'Kevin Bacon', 1979), (12, 'Bill Paxton', 1955),
+    (13, 'Joan Cusack', NULL);
+
+INSERT INTO stars VALUES
+    (1, 1), (1, 2), (4, 4), (13, 4), (5, 5), (11, 5), (11, 6),
+    (10, 7), (10, 8), (12, 1), (12, 10), (12, 12), (9, 1), (9, 11), (9, 13);
+
+INSERT INTO directors VALUES (3, 9), (8, 9), (5, 5);
+
+INSERT INTO ratings VALUES
+    (1, 8.3, 900000), (2, 7.9, 1000000), (3, 9.0, 2500000), (4, 7.3, 700000), (5, 7.6, 380000),
+    (8, 8.8, 2200000), (9, 8.3, 800000), (11, 7.7, 700000), (12, 7.7, 280000), (13, 6.9, 20000);
@@ -0,0 +1,128 @@
+// Package dbutil is the SQLite plumbing the week 7 programs share: opening
+// a database file with the pure-Go modernc driver (no cgo, so it builds
+// anywhere Go does) and printing query results as a table.
+package dbutil
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// DRIVER is the database/sql driver name modernc.org/sqlite registers.
+const DRIVER = "sqlite"
+
+// Open opens an existing SQLite file. Unlike sql.Open it fails when path
+// doesn't exist, instead of quietly creating an empty database and then
+// reporting "no such table".
+func Open(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return sql.Open(DRIVER, "file:"+path+"?_pragma=foreign_keys(1)")
+}
+
+// Create opens path, creating it if needed.
+func Create(path string) (*sql.DB, error) {
+	return sql.Open(DRIVER, "file:"+path+"?_pragma=foreign_keys(1)")
+}
+
+// Memory opens a fresh in-memory database, for tests. It's limited to one
+// connection because every connection to ":memory:" is a different
+// database.
+func Memory() (*sql.DB, error) {
+	db, err := sql.Open(DRIVER, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// Table is a query result with every value formatted as text.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Query runs query and reads the whole result into a Table.
+func Query(db *sql.DB, query string, args ...any) (*Table, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{Columns: columns}
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(values))
+		for i, v := range values {
+			row[i] = Format(v)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, rows.Err()
+}
+
+// Format writes a value the way the sqlite3 shell does: NULL as nothing,
+// floats without trailing zeros.
+func Format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Column returns the values of column i, e.g. the names a query listed.
+func (t *Table) Column(i int) []string {
+	values := make([]string, len(t.Rows))
+	for j, row := range t.Rows {
+		values[j] = row[i]
+	}
+	return values
+}
+
+// Fprint writes t as aligned columns under a header, then the row count.
+func (t *Table) Fprint(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Columns, "\t"))
+	rules := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		rules[i] = strings.Repeat("-", max(len(column), 3))
+	}
+	fmt.Fprintln(tw, strings.Join(rules, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	if len(t.Rows) == 1 {
+		fmt.Fprintln(w, "(1 row)")
+	} else {
+		fmt.Fprintf(w, "(%d rows)\n", len(t.Rows))
+	}
+}
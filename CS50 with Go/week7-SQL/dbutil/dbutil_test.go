@@ -0,0 +1,72 @@
+package dbutil
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{[]byte("Toy Story"), "Toy Story"},
+		{"Toy Story", "Toy Story"},
+		{int64(1995), "1995"},
+		{0.659, "0.659"},
+		{10.0, "10"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.value); got != tt.want {
+			t.Errorf("Format(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	db, err := Memory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE movies (id INTEGER PRIMARY KEY, title TEXT, year NUMERIC)",
+		"INSERT INTO movies VALUES (114709, 'Toy Story', 1995), (120363, 'Toy Story 2', 1999), (1, NULL, NULL)",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table, err := Query(db, "SELECT title, year FROM movies WHERE id > ? ORDER BY id DESC", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(table.Columns, []string{"title", "year"}) || !slices.Equal(table.Column(0), []string{"Toy Story 2", "Toy Story"}) {
+		t.Errorf("got %+v", table)
+	}
+
+	var out bytes.Buffer
+	table.Fprint(&out)
+	want := "title        year\n" +
+		"-----        ----\n" +
+		"Toy Story 2  1999\n" +
+		"Toy Story    1995\n" +
+		"(2 rows)\n"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+
+	if _, err := Query(db, "SELECT * FROM nope"); err == nil {
+		t.Error("query of a missing table: want error")
+	}
+}
+
+func TestOpenMissing(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Error("Open of a missing file: want error")
+	}
+}
@@ -0,0 +1,5 @@
+module dbutil
+
+go 1.25.0
+
+require modernc.org/sqlite v1.57.0
@@ -0,0 +1,262 @@
+// Fiftyville: an investigation console for the week 7 mystery. Browse the
+// tables, run the leads and your own SQL, bookmark clues and build a
+// timeline; the notes are saved next to the database as you go.
+//
+//	./fiftyville                  uses fiftyville.db and fiftyville.notes.json
+//	./fiftyville -db path/to/fiftyville.db -notes case.json
+
+package main
+
+import (
+	"cs50"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"dbutil"
+	"qb"
+)
+
+// BROWSE_ROWS is how many rows /browse shows unless told otherwise.
+const BROWSE_ROWS = 10
+
+const HELP = `Type SQL to run it, or a command:
+  /tables               every table and its number of rows
+  /schema [table]       CREATE statements
+  /browse table [N]     the first N rows of a table
+  /find table column=value ... [order=column,...] [limit=N]
+                        rows matching every filter; also != < <= > >= and
+                        ~ for LIKE, e.g. /find people license_plate~5P2%
+  /saved                the leads and your saved queries
+  /run N                run saved query N
+  /save name            save the last query under a name
+  /clue text            bookmark a clue (with the last query)
+  /clues                your clues
+  /at [day] time text   add to the timeline, e.g. /at 10:15am thief leaves
+  /timeline             the timeline, in order
+  /help, /quit`
+
+// investigation is one session: the database, the notes and where they go.
+type investigation struct {
+	db        *sql.DB
+	notes     *Notes
+	notesPath string
+	lastSQL   string
+	lastArgs  []any
+	w         io.Writer
+}
+
+func main() {
+	dbPath := flag.String("db", "fiftyville.db", "the pset's database")
+	notes := flag.String("notes", "", "where to keep your notes (default next to the database)")
+	flag.Parse()
+	if *notes == "" {
+		*notes = notesPath(*dbPath)
+	}
+
+	db, err := dbutil.Open(*dbPath)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	inv := &investigation{db: db, notesPath: *notes, w: os.Stdout}
+	if inv.notes, err = loadNotes(*notes); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println("The CS50 Duck has been stolen! It happened on July 28, 2021 on Humphrey Street.")
+	fmt.Printf("%d clues and %d timeline events so far. /help lists the commands.\n", len(inv.notes.Clues), len(inv.notes.Timeline))
+	for {
+		if !inv.handle(cs50.GetString("fiftyville> ")) {
+			return
+		}
+	}
+}
+
+// handle runs one line of input and reports whether to keep going.
+func (inv *investigation) handle(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return true
+	}
+	if !strings.HasPrefix(line, "/") {
+		inv.query(line)
+		return true
+	}
+
+	command, argument, _ := strings.Cut(line, " ")
+	argument = strings.TrimSpace(argument)
+	switch strings.ToLower(command) {
+	case "/quit", "/exit":
+		return false
+	case "/help":
+		fmt.Fprintln(inv.w, HELP)
+	case "/tables":
+		inv.tables()
+	case "/schema":
+		inv.schema(argument)
+	case "/browse":
+		inv.browse(argument)
+	case "/find":
+		inv.find(argument)
+	case "/saved":
+		for i, q := range inv.saved() {
+			fmt.Fprintf(inv.w, "%2d  %s\n", i+1, q.Name)
+		}
+	case "/run":
+		n, err := strconv.Atoi(argument)
+		saved := inv.saved()
+		if err != nil || n < 1 || n > len(saved) {
+			fmt.Fprintf(inv.w, "Pick a query from 1 to %d (see /saved).\n", len(saved))
+			break
+		}
+		q := saved[n-1]
+		fmt.Fprintln(inv.w, q.SQL)
+		if len(q.Args) > 0 {
+			fmt.Fprintln(inv.w, "-- with", q.Args)
+		}
+		inv.query(q.SQL, q.Args...)
+	case "/save":
+		if argument == "" || inv.lastSQL == "" {
+			fmt.Fprintln(inv.w, "Run a query first, then /save a name for it.")
+			break
+		}
+		inv.notes.saveQuery(argument, inv.lastSQL, inv.lastArgs)
+		inv.save()
+	case "/clue":
+		if argument == "" {
+			fmt.Fprintln(inv.w, "Usage: /clue what you found")
+			break
+		}
+		inv.notes.Clues = append(inv.notes.Clues, Clue{Text: argument, Query: inv.lastSQL, Args: inv.lastArgs, Added: time.Now()})
+		inv.save()
+	case "/clues":
+		for i, c := range inv.notes.Clues {
+			fmt.Fprintf(inv.w, "%2d  %s\n", i+1, c.Text)
+		}
+	case "/at":
+		if _, err := inv.notes.addEvent(argument); err != nil {
+			fmt.Fprintln(inv.w, err)
+			break
+		}
+		inv.save()
+	case "/timeline":
+		for _, e := range inv.notes.Timeline {
+			fmt.Fprintf(inv.w, "July %d  %s  %s\n", e.Day, e.Time, e.Text)
+		}
+	default:
+		fmt.Fprintf(inv.w, "Unknown command %s. /help lists them.\n", command)
+	}
+	return true
+}
+
+// saved returns the leads, then the queries you saved.
+func (inv *investigation) saved() []SavedQuery {
+	return append(append([]SavedQuery(nil), LEADS...), inv.notes.Queries...)
+}
+
+// query runs sql with args for its ?s and prints the result; it becomes
+// the query /save and /clue refer to.
+func (inv *investigation) query(sql string, args ...any) {
+	table, err := dbutil.Query(inv.db, sql, args...)
+	if err != nil {
+		fmt.Fprintln(inv.w, err)
+		return
+	}
+	inv.lastSQL, inv.lastArgs = sql, args
+	table.Fprint(inv.w)
+}
+
+func (inv *investigation) tables() {
+	names, err := dbutil.Query(inv.db, `SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name`)
+	if err != nil {
+		fmt.Fprintln(inv.w, err)
+		return
+	}
+	counts := &dbutil.Table{Columns: []string{"table", "rows"}}
+	for _, name := range names.Column(0) {
+		var n int
+		// Table names come from sqlite_master, so quoting them is enough
+		if err := inv.db.QueryRow(`SELECT COUNT(*) FROM "` + name + `"`).Scan(&n); err != nil {
+			fmt.Fprintln(inv.w, err)
+			return
+		}
+		counts.Rows = append(counts.Rows, []string{name, strconv.Itoa(n)})
+	}
+	counts.Fprint(inv.w)
+}
+
+func (inv *investigation) schema(table string) {
+	query, args := `SELECT sql FROM sqlite_master WHERE type = 'table' ORDER BY name`, []any{}
+	if table != "" {
+		query, args = `SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?`, []any{table}
+	}
+	result, err := dbutil.Query(inv.db, query, args...)
+	if err != nil {
+		fmt.Fprintln(inv.w, err)
+		return
+	}
+	if len(result.Rows) == 0 {
+		fmt.Fprintf(inv.w, "No table %q. /tables lists them.\n", table)
+	}
+	for _, sql := range result.Column(0) {
+		fmt.Fprintln(inv.w, sql+";")
+	}
+}
+
+func (inv *investigation) browse(argument string) {
+	fields := strings.Fields(argument)
+	if len(fields) == 0 || len(fields) > 2 {
+		fmt.Fprintln(inv.w, "Usage: /browse table [N]")
+		return
+	}
+	n := BROWSE_ROWS
+	if len(fields) == 2 {
+		var err error
+		if n, err = strconv.Atoi(fields[1]); err != nil || n < 1 {
+			fmt.Fprintln(inv.w, "Usage: /browse table [N]")
+			return
+		}
+	}
+
+	if !inv.tableExists(fields[0]) {
+		return
+	}
+	inv.runBuilt(qb.Select(`SELECT * FROM "` + fields[0] + `"`).Limit(n))
+}
+
+// tableExists checks name is a real table before it goes into the SQL,
+// and says so when it isn't.
+func (inv *investigation) tableExists(name string) bool {
+	var exists int
+	inv.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&exists)
+	if exists == 0 {
+		fmt.Fprintf(inv.w, "No table %q. /tables lists them.\n", name)
+	}
+	return exists > 0
+}
+
+// runBuilt builds q and runs it, or says what's wrong with it.
+func (inv *investigation) runBuilt(q *qb.Query) {
+	sql, args, err := q.Build()
+	if err != nil {
+		fmt.Fprintln(inv.w, err)
+		return
+	}
+	inv.query(sql, args...)
+}
+
+// save writes the notes, reporting (but surviving) a failure.
+func (inv *investigation) save() {
+	if err := inv.notes.save(inv.notesPath); err != nil {
+		fmt.Fprintln(inv.w, "Could not save notes:", err)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"dbutil"
+)
+
+func TestAddEvent(t *testing.T) {
+	notes := &Notes{}
+	for _, s := range []string{
+		"10:15am theft at the bakery",
+		"29 8:20 earliest flight out",
+		"9:30 thief withdraws cash on Leggett Street",
+		"10:25 car leaves the parking lot",
+	} {
+		if _, err := notes.addEvent(s); err != nil {
+			t.Fatalf("addEvent(%q): %v", s, err)
+		}
+	}
+	var got []string
+	for _, e := range notes.Timeline {
+		got = append(got, e.Time+" "+e.Text)
+	}
+	want := []string{
+		"09:30 thief withdraws cash on Leggett Street",
+		"10:15 theft at the bakery",
+		"10:25 car leaves the parking lot",
+		"08:20 earliest flight out", // the next day
+	}
+	if !slices.Equal(got, want) || notes.Timeline[3].Day != 29 || notes.Timeline[0].Day != THEFT_DAY {
+		t.Errorf("timeline %+v", notes.Timeline)
+	}
+
+	for _, bad := range []string{"", "10:15", "25:00 nope", "29 8:20"} {
+		if _, err := notes.addEvent(bad); err == nil {
+			t.Errorf("addEvent(%q): want error", bad)
+		}
+	}
+}
+
+func TestNotesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "case.notes.json")
+	notes, err := loadNotes(path)
+	if err != nil || len(notes.Clues) != 0 {
+		t.Fatalf("new notes: %+v, %v", notes, err)
+	}
+
+	notes.saveQuery("calls", "SELECT 1;", nil)
+	notes.saveQuery("Calls", "SELECT ?;", []any{2}) // same name replaces
+	notes.Clues = append(notes.Clues, Clue{Text: "call under a minute", Query: "SELECT ?;", Args: []any{2}})
+	notes.addEvent("10:15 theft")
+	if err := notes.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := loadNotes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Queries) != 1 || again.Queries[0].SQL != "SELECT ?;" || len(again.Queries[0].Args) != 1 || len(again.Clues) != 1 || len(again.Timeline) != 1 {
+		t.Errorf("loaded %+v", again)
+	}
+
+	if got := notesPath("data/fiftyville.db"); got != "data/fiftyville.notes.json" {
+		t.Errorf("notesPath = %q", got)
+	}
+}
+
+// A corner of the pset's schema, enough to drive the commands.
+var FIXTURE = []string{
+	`CREATE TABLE crime_scene_reports (id INTEGER, year INTEGER, month INTEGER, day INTEGER,
+		street TEXT, description TEXT, PRIMARY KEY(id))`,
+	`CREATE TABLE interviews (id INTEGER, name TEXT, year INTEGER, month INTEGER, day INTEGER,
+		transcript TEXT, PRIMARY KEY(id))`,
+	`INSERT INTO crime_scene_reports VALUES
+		(295, 2021, 7, 28, 'Humphrey Street', 'Theft of the CS50 duck took place at 10:15am.'),
+		(1, 2021, 7, 27, 'Humphrey Street', 'Nothing happened.')`,
+	`INSERT INTO interviews VALUES (161, 'Ruth', 2021, 7, 28, 'Within ten minutes of the theft...')`,
+}
+
+func TestHandle(t *testing.T) {
+	db, err := dbutil.Memory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range FIXTURE {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out strings.Builder
+	inv := &investigation{db: db, notes: &Notes{}, notesPath: filepath.Join(t.TempDir(), "notes.json"), w: &out}
+	steps := []struct {
+		line, want string
+	}{
+		{"/tables", "crime_scene_reports  2"},
+		{"/schema interviews", "CREATE TABLE interviews"},
+		{"/browse crime_scene_reports 1", "(1 row)"},
+		{"/browse duck", `No table "duck"`},
+		{"/run 1", "Theft of the CS50 duck took place at 10:15am."},
+		{"/save theft report", ""},
+		{"/saved", " 7  theft report"},
+		{"/clue theft at 10:15am", ""},
+		{"/find crime_scene_reports day<28", "Nothing happened."},
+		{`/find crime_scene_reports street="Humphrey Street" description~%duck%`, "(1 row)"},
+		{"/find crime_scene_reports order=-day limit=1", "295"},
+		{"/save duck reports", ""},
+		{"/run 8", "-- with [1]"},
+		{"/find crime_scene_reports 1=1;DROP", "isn't a column name"},
+		{"/find crime_scene_reports day", "Usage: /find"},
+		{"/find duck", `No table "duck"`},
+		{"SELECT name FROM interviews", "Ruth"},
+		{"/at 10:15am duck stolen", ""},
+		{"/timeline", "July 28  10:15  duck stolen"},
+		{"SELECT nonsense", "no such column"},
+		{"/dance", "Unknown command /dance"},
+	}
+	for _, step := range steps {
+		out.Reset()
+		if !inv.handle(step.line) {
+			t.Fatalf("%q quit", step.line)
+		}
+		if !strings.Contains(out.String(), step.want) {
+			t.Errorf("%q printed %q, want %q", step.line, out.String(), step.want)
+		}
+	}
+	if len(inv.notes.Clues) != 1 || !strings.Contains(inv.notes.Clues[0].Query, "crime_scene_reports") {
+		t.Errorf("clues %+v", inv.notes.Clues)
+	}
+	if inv.handle("/quit") {
+		t.Error("/quit kept going")
+	}
+
+	// Everything was saved as it happened
+	saved, err := loadNotes(inv.notesPath)
+	if err != nil || len(saved.Queries) != 2 || len(saved.Clues) != 1 || len(saved.Timeline) != 1 {
+		t.Errorf("saved notes %+v, %v", saved, err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"qb"
+)
+
+const FIND_USAGE = `Usage: /find table column=value ... [order=column,-column] [limit=N]
+  filters: =  !=  <  <=  >  >=  and ~ for LIKE; quote values with spaces,
+  street="Humphrey Street"; order=-column sorts it largest first`
+
+// FILTER is one /find filter: a column, an operator and a value.
+var FILTER = regexp.MustCompile(`^([^=!<>~]+)(<=|>=|!=|=|<|>|~)(.*)$`)
+
+// find runs "/find table column=value ...": the rows of table matching
+// every filter. It's put together with qb, so the values are bound and
+// only things that look like column names reach the SQL.
+func (inv *investigation) find(argument string) {
+	fields, err := splitQuoted(argument)
+	if err != nil || len(fields) == 0 {
+		fmt.Fprintln(inv.w, FIND_USAGE)
+		return
+	}
+	if !inv.tableExists(fields[0]) {
+		return
+	}
+
+	q := qb.Select(`SELECT * FROM "` + fields[0] + `"`)
+	for _, field := range fields[1:] {
+		m := FILTER.FindStringSubmatch(field)
+		switch {
+		case m == nil:
+			fmt.Fprintln(inv.w, FIND_USAGE)
+			return
+		case m[1] == "order" && m[2] == "=":
+			for _, column := range strings.Split(m[3], ",") {
+				if name, ok := strings.CutPrefix(column, "-"); ok {
+					column = name + " DESC"
+				}
+				q.OrderBy(column)
+			}
+		case m[1] == "limit" && m[2] == "=":
+			n, err := strconv.Atoi(m[3])
+			if err != nil || n < 1 {
+				fmt.Fprintln(inv.w, FIND_USAGE)
+				return
+			}
+			q.Limit(n)
+		case m[2] == "~":
+			q.Compare(m[1], "LIKE", m[3])
+		default:
+			q.Compare(m[1], m[2], value(m[3]))
+		}
+	}
+	inv.runBuilt(q)
+}
+
+// value is a filter's value as SQLite should compare it: a number when it
+// looks like one, so hour<10 doesn't compare text.
+func value(s string) any {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// splitQuoted splits s at spaces, except inside double quotes, which are
+// dropped: `a b="c d"` is "a" and "b=c d".
+func splitQuoted(s string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField, quoted := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted, inField = !quoted, true
+		case r == ' ' && !quoted:
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			inField = false
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unmatched quote in %s", s)
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
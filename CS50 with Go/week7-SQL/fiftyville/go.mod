@@ -0,0 +1,19 @@
+module fiftyville
+
+go 1.25.0
+
+require (
+	cs50 v0.0.0
+	dbutil v0.0.0
+	qb v0.0.0
+	timeparse v0.0.0
+)
+
+require modernc.org/sqlite v1.57.0 // indirect
+
+replace (
+	cs50 => ../../cs50
+	dbutil => ../dbutil
+	qb => ../qb
+	timeparse => ../../week2-Array/timeparse
+)
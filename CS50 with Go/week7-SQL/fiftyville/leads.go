@@ -0,0 +1,24 @@
+package main
+
+// LEADS are where the pset says to start: everything recorded on the day of
+// the theft. They're listed with the queries you save yourself.
+var LEADS = []SavedQuery{
+	{Name: "crime scene reports", SQL: `SELECT id, street, description FROM crime_scene_reports
+ WHERE year = 2021 AND month = 7 AND day = 28;`},
+	{Name: "interviews", SQL: `SELECT id, name, transcript FROM interviews
+ WHERE year = 2021 AND month = 7 AND day = 28;`},
+	{Name: "bakery security logs", SQL: `SELECT hour, minute, activity, license_plate FROM bakery_security_logs
+ WHERE year = 2021 AND month = 7 AND day = 28
+ ORDER BY hour, minute;`},
+	{Name: "atm transactions", SQL: `SELECT account_number, atm_location, transaction_type, amount FROM atm_transactions
+ WHERE year = 2021 AND month = 7 AND day = 28;`},
+	{Name: "phone calls", SQL: `SELECT caller, receiver, duration FROM phone_calls
+ WHERE year = 2021 AND month = 7 AND day = 28
+ ORDER BY duration;`},
+	{Name: "flights the next day", SQL: `SELECT flights.id, hour, minute, origin.city AS origin, destination.city AS destination
+  FROM flights
+  JOIN airports AS origin ON origin.id = flights.origin_airport_id
+  JOIN airports AS destination ON destination.id = flights.destination_airport_id
+ WHERE year = 2021 AND month = 7 AND day = 29
+ ORDER BY hour, minute;`},
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"timeparse"
+)
+
+// THEFT_DAY is the day of July 2021 the duck was stolen, the default day of
+// timeline events.
+const THEFT_DAY = 28
+
+// Notes is an investigation: queries worth keeping, clues and a timeline.
+// It's saved as JSON next to the database after every change.
+type Notes struct {
+	Queries  []SavedQuery `json:"queries"`
+	Clues    []Clue       `json:"clues"`
+	Timeline []Event      `json:"timeline"`
+}
+
+// SavedQuery is a query kept under a name to run again.
+type SavedQuery struct {
+	Name string `json:"name"`
+	SQL  string `json:"sql"`
+	Args []any  `json:"args,omitempty"` // values for the SQL's ?s, from /find
+}
+
+// Clue is a bookmarked finding and the query that turned it up.
+type Clue struct {
+	Text  string    `json:"text"`
+	Query string    `json:"query,omitempty"`
+	Args  []any     `json:"args,omitempty"`
+	Added time.Time `json:"added"`
+}
+
+// Event is something that happened at a time on a day of July 2021.
+type Event struct {
+	Day  int    `json:"day"`
+	Time string `json:"time"` // 24-hour "10:15", so it sorts as text
+	Text string `json:"text"`
+}
+
+// notesPath is where the notes for a database go: fiftyville.db keeps them
+// in fiftyville.notes.json.
+func notesPath(dbPath string) string {
+	return strings.TrimSuffix(dbPath, filepath.Ext(dbPath)) + ".notes.json"
+}
+
+// loadNotes reads notes from path. A missing file is a new investigation.
+func loadNotes(path string) (*Notes, error) {
+	notes := &Notes{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return notes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, notes); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return notes, nil
+}
+
+// save writes the notes to path, replacing the file in one step so a crash
+// can't leave it half written.
+func (n *Notes) save(path string) error {
+	data, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+// saveQuery keeps sql and its args under name, replacing a query with the
+// same name.
+func (n *Notes) saveQuery(name, sql string, args []any) {
+	for i, q := range n.Queries {
+		if strings.EqualFold(q.Name, name) {
+			n.Queries[i].SQL, n.Queries[i].Args = sql, args
+			return
+		}
+	}
+	n.Queries = append(n.Queries, SavedQuery{Name: name, SQL: sql, Args: args})
+}
+
+// addEvent parses "[day] time text", e.g. "10:15am thief leaves" or
+// "29 8:20 earliest flight out", and adds it to the timeline in order.
+func (n *Notes) addEvent(s string) (Event, error) {
+	fields := strings.Fields(s)
+	e := Event{Day: THEFT_DAY}
+	if len(fields) > 2 {
+		if day, err := strconv.Atoi(fields[0]); err == nil && day >= 1 && day <= 31 {
+			e.Day = day
+			fields = fields[1:]
+		}
+	}
+	if len(fields) < 2 {
+		return e, fmt.Errorf("usage: /at [day] time what happened")
+	}
+
+	clock, err := timeparse.ParseClock(fields[0])
+	if err != nil {
+		return e, err
+	}
+	e.Time = clock.Format24()
+	e.Text = strings.Join(fields[1:], " ")
+
+	n.Timeline = append(n.Timeline, e)
+	sort.SliceStable(n.Timeline, func(i, j int) bool {
+		a, b := n.Timeline[i], n.Timeline[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		return a.Time < b.Time
+	})
+	return e, nil
+}
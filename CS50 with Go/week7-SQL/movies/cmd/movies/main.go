@@ -0,0 +1,104 @@
+// movies prints the answers to the movies pset's questions, from the
+// typed functions of the movies package.
+//
+//	./movies                  list the questions
+//	./movies 8                who starred in Toy Story, from ./movies.db
+//	./movies -db ~/cs50/movies.db 1 2 13
+//	./movies all
+
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"dbutil"
+	"movies"
+)
+
+const USAGE = "Usage: movies [-db movies.db] [N ...|all]"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
+
+// run lists the questions or answers some, returning the exit code.
+func run(args []string, w io.Writer) int {
+	flags := flag.NewFlagSet("movies", flag.ContinueOnError)
+	flags.SetOutput(w)
+	dbPath := flags.String("db", "movies.db", "the pset's movies.db")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if flags.NArg() == 0 {
+		for _, q := range movies.QUESTIONS {
+			fmt.Fprintf(w, "%2d  %s\n", q.N, q.Text)
+		}
+		return 0
+	}
+	questions := movies.QUESTIONS
+	if flags.NArg() > 1 || flags.Arg(0) != "all" {
+		questions = nil
+		for _, arg := range flags.Args() {
+			n, err := strconv.Atoi(arg)
+			q, ok := movies.Find(n)
+			if err != nil || !ok {
+				fmt.Fprintf(w, "%s\nthere are questions 1 to %d\n", USAGE, len(movies.QUESTIONS))
+				return 1
+			}
+			questions = append(questions, q)
+		}
+	}
+
+	db, err := dbutil.Open(*dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+
+	for i, q := range questions {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := answer(w, db, q); err != nil {
+			fmt.Fprintf(w, "%d: %v\n", q.N, err)
+			return 2
+		}
+	}
+	return 0
+}
+
+// answer prints q and its answer, one movie or person a line.
+func answer(w io.Writer, db *sql.DB, q movies.Question) error {
+	result, err := q.Answer(db)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "-- %d. %s\n", q.N, q.Text)
+	switch result := result.(type) {
+	case []movies.Movie:
+		printAll(w, result)
+	case []movies.Person:
+		printAll(w, result)
+	case []movies.RatedMovie:
+		printAll(w, result)
+	case float64:
+		fmt.Fprintf(w, "%.2f\n", result)
+	default:
+		fmt.Fprintln(w, result)
+	}
+	return nil
+}
+
+func printAll[T fmt.Stringer](w io.Writer, rows []T) {
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+	fmt.Fprintf(w, "(%d rows)\n", len(rows))
+}
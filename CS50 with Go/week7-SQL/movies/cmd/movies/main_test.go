@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"dbutil"
+	"dbutil/dbtest"
+)
+
+func TestRun(t *testing.T) {
+	path := dbtest.MoviesFile(t)
+	// Without directors, "all" stops at question 10
+	noDirectors := dbtest.MoviesFile(t)
+	db, err := dbutil.Open(noDirectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("DROP TABLE directors"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{nil, 0, " 8  the names of all people who starred in Toy Story\n"},
+		{[]string{"-db", path, "8"}, 0, "-- 8. the names of all people who starred in Toy Story\nTom Hanks (born 1956)\nTim Allen (born 1953)\n(2 rows)\n"},
+		{[]string{"-db", path, "2", "1"}, 0, "-- 2. the birth year of Emma Stone\n1988\n\n-- 1. the titles of all movies released in 2008\nIron Man (2008)\nThe Dark Knight (2008)\n(2 rows)\n"},
+		{[]string{"-db", path, "all"}, 0, "Bill Paxton (born 1955)\n"},
+		{[]string{"-db", noDirectors, "all"}, 2, "10: no such table: directors"},
+		{[]string{"14"}, 1, "there are questions 1 to 13"},
+		{[]string{"all", "1"}, 1, "there are questions 1 to 13"},
+		{[]string{"-db", "missing.db", "1"}, 2, "missing.db"},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if code := run(tt.args, &out); code != tt.code || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("run(%q) = %d\n%s", tt.args, code, out.String())
+		}
+	}
+}
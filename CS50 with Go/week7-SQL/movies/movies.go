@@ -0,0 +1,214 @@
+// Package movies answers the 13 questions of CS50's movies pset as Go
+// functions over database/sql: each one runs the query from 1.sql ... 13.sql
+// with the pset's constants as parameters, and returns typed rows instead
+// of a table of text.
+//
+//	db, _ := dbutil.Open("movies.db")
+//	stars, err := movies.Stars(db, "Toy Story")   // []movies.Person
+package movies
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// Movie is a row of the movies table.
+type Movie struct {
+	ID    int64
+	Title string
+	Year  int // 0 when IMDb doesn't know
+}
+
+// Person is a row of the people table.
+type Person struct {
+	ID    int64
+	Name  string
+	Birth int // 0 when IMDb doesn't know
+}
+
+// RatedMovie is a movie and its IMDb rating out of 10.
+type RatedMovie struct {
+	Movie
+	Rating float64
+}
+
+func (m Movie) String() string {
+	if m.Year == 0 {
+		return m.Title
+	}
+	return fmt.Sprintf("%s (%d)", m.Title, m.Year)
+}
+
+func (p Person) String() string {
+	if p.Birth == 0 {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (born %d)", p.Name, p.Birth)
+}
+
+func (m RatedMovie) String() string {
+	return m.Movie.String() + " " + strconv.FormatFloat(m.Rating, 'f', 1, 64)
+}
+
+// ReleasedIn answers 1.sql: every movie released in year.
+func ReleasedIn(db *sql.DB, year int) ([]Movie, error) {
+	return queryMovies(db, "SELECT id, title, year FROM movies WHERE year = ?", year)
+}
+
+// BirthYear answers 2.sql: the year the person called name was born, or
+// sql.ErrNoRows when nobody is.
+func BirthYear(db *sql.DB, name string) (int, error) {
+	var birth sql.NullInt64
+	err := db.QueryRow("SELECT birth FROM people WHERE name = ?", name).Scan(&birth)
+	return int(birth.Int64), err
+}
+
+// ReleasedSince answers 3.sql: the movies released in year or later,
+// alphabetically.
+func ReleasedSince(db *sql.DB, year int) ([]Movie, error) {
+	return queryMovies(db, "SELECT id, title, year FROM movies WHERE year >= ? ORDER BY title", year)
+}
+
+// CountRated answers 4.sql: how many movies are rated exactly rating.
+func CountRated(db *sql.DB, rating float64) (int, error) {
+	var n int
+	err := db.QueryRow("SELECT COUNT(*) FROM ratings WHERE rating = ?", rating).Scan(&n)
+	return n, err
+}
+
+// TitledLike answers 5.sql: the movies whose title starts with prefix, in
+// release order.
+func TitledLike(db *sql.DB, prefix string) ([]Movie, error) {
+	return queryMovies(db, "SELECT id, title, year FROM movies WHERE title LIKE ? || '%' ORDER BY year", prefix)
+}
+
+// AverageRating answers 6.sql: the average rating of the movies released in
+// year, 0 when none of them are rated.
+func AverageRating(db *sql.DB, year int) (float64, error) {
+	var average sql.NullFloat64
+	err := db.QueryRow(`SELECT AVG(rating) FROM ratings
+		JOIN movies ON movies.id = ratings.movie_id
+		WHERE year = ?`, year).Scan(&average)
+	return average.Float64, err
+}
+
+// RatedIn answers 7.sql: the movies released in year with their ratings,
+// best first and ties by title.
+func RatedIn(db *sql.DB, year int) ([]RatedMovie, error) {
+	return queryRated(db, `SELECT id, title, year, rating FROM movies
+		JOIN ratings ON ratings.movie_id = movies.id
+		WHERE year = ?
+		ORDER BY rating DESC, title`, year)
+}
+
+// Stars answers 8.sql: the people who starred in the movie called title.
+func Stars(db *sql.DB, title string) ([]Person, error) {
+	return queryPeople(db, `SELECT people.id, name, birth FROM people
+		JOIN stars ON stars.person_id = people.id
+		JOIN movies ON movies.id = stars.movie_id
+		WHERE title = ?`, title)
+}
+
+// StarsIn answers 9.sql: the people who starred in a movie released in
+// year, once each however many they were in, oldest first.
+func StarsIn(db *sql.DB, year int) ([]Person, error) {
+	return queryPeople(db, `SELECT id, name, birth FROM people
+		WHERE id IN (SELECT person_id FROM stars
+		             JOIN movies ON movies.id = stars.movie_id
+		             WHERE year = ?)
+		ORDER BY birth`, year)
+}
+
+// DirectorsRatedAtLeast answers 10.sql: the people who directed a movie
+// rated rating or better.
+func DirectorsRatedAtLeast(db *sql.DB, rating float64) ([]Person, error) {
+	return queryPeople(db, `SELECT id, name, birth FROM people
+		WHERE id IN (SELECT person_id FROM directors
+		             JOIN ratings ON ratings.movie_id = directors.movie_id
+		             WHERE rating >= ?)`, rating)
+}
+
+// TopRated answers 11.sql: the n best rated movies the person called name
+// starred in, best first.
+func TopRated(db *sql.DB, name string, n int) ([]RatedMovie, error) {
+	return queryRated(db, `SELECT movies.id, title, year, rating FROM movies
+		JOIN stars ON stars.movie_id = movies.id
+		JOIN people ON people.id = stars.person_id
+		JOIN ratings ON ratings.movie_id = movies.id
+		WHERE name = ?
+		ORDER BY rating DESC
+		LIMIT ?`, name, n)
+}
+
+// CoStarring answers 12.sql: the movies both the people called a and b
+// starred in.
+func CoStarring(db *sql.DB, a, b string) ([]Movie, error) {
+	return queryMovies(db, `SELECT id, title, year FROM movies
+		WHERE id IN (SELECT movie_id FROM stars JOIN people ON people.id = stars.person_id
+		             WHERE name = ?)
+		  AND id IN (SELECT movie_id FROM stars JOIN people ON people.id = stars.person_id
+		             WHERE name = ?)`, a, b)
+}
+
+// CoStars answers 13.sql: everyone who starred in a movie with the person
+// called name born in birth (there's more than one Kevin Bacon), not
+// counting them.
+func CoStars(db *sql.DB, name string, birth int) ([]Person, error) {
+	return queryPeople(db, `SELECT id, name, birth FROM people
+		WHERE id IN (SELECT person_id FROM stars
+		             WHERE movie_id IN (SELECT movie_id FROM stars
+		                                WHERE person_id = (SELECT id FROM people WHERE name = ?1 AND birth = ?2)))
+		  AND id != (SELECT id FROM people WHERE name = ?1 AND birth = ?2)`, name, birth)
+}
+
+// queryMovies runs a query whose columns are id, title and year.
+func queryMovies(db *sql.DB, query string, args ...any) ([]Movie, error) {
+	return scanAll(db, query, args, func(rows *sql.Rows) (Movie, error) {
+		var m Movie
+		var year sql.NullInt64
+		err := rows.Scan(&m.ID, &m.Title, &year)
+		m.Year = int(year.Int64)
+		return m, err
+	})
+}
+
+// queryRated runs a query whose columns are id, title, year and rating.
+func queryRated(db *sql.DB, query string, args ...any) ([]RatedMovie, error) {
+	return scanAll(db, query, args, func(rows *sql.Rows) (RatedMovie, error) {
+		var m RatedMovie
+		var year sql.NullInt64
+		err := rows.Scan(&m.ID, &m.Title, &year, &m.Rating)
+		m.Year = int(year.Int64)
+		return m, err
+	})
+}
+
+// queryPeople runs a query whose columns are id, name and birth.
+func queryPeople(db *sql.DB, query string, args ...any) ([]Person, error) {
+	return scanAll(db, query, args, func(rows *sql.Rows) (Person, error) {
+		var p Person
+		var birth sql.NullInt64
+		err := rows.Scan(&p.ID, &p.Name, &birth)
+		p.Birth = int(birth.Int64)
+		return p, err
+	})
+}
+
+// scanAll runs query and turns every row into a T with scan.
+func scanAll[T any](db *sql.DB, query string, args []any, scan func(*sql.Rows) (T, error)) ([]T, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,162 @@
+package movies
+
+import (
+	"database/sql"
+	"os"
+	"slices"
+	"testing"
+
+	"dbutil"
+	"dbutil/dbtest"
+)
+
+// strs formats rows with their String methods, to compare them.
+func strs[T interface{ String() string }](rows []T) []string {
+	var s []string
+	for _, row := range rows {
+		s = append(s, row.String())
+	}
+	return s
+}
+
+func TestQuestions(t *testing.T) {
+	db := dbtest.Movies(t)
+	tests := []struct {
+		n       int
+		want    any
+		ordered bool // false where the pset doesn't ask for an order
+	}{
+		{1, []string{"Iron Man (2008)", "The Dark Knight (2008)"}, false},
+		{2, 1988, true},
+		{3, []string{"A Star Is Born (2018)", "Black Panther (2018)"}, true},
+		{4, 0, true},
+		{5, []string{"Harry Potter and the Chamber of Secrets (2002)", "Harry Potter and the Goblet of Fire (2005)"}, true},
+		{6, 7.7, true},
+		{7, []string{"Inception (2010) 8.8", "Toy Story 3 (2010) 8.3"}, true},
+		{8, []string{"Tim Allen (born 1953)", "Tom Hanks (born 1956)"}, false},
+		{9, []string{"Rachel McAdams (born 1978)", "Ryan Gosling (born 1980)"}, true},
+		{10, []string{"Christopher Nolan (born 1970)"}, false},
+		{11, []string{"Black Panther (2018) 7.3", "Get on Up (2014) 6.9"}, true},
+		{12, []string{"Silver Linings Playbook (2012)"}, false},
+		{13, []string{"Bill Paxton (born 1955)", "Tom Hanks (born 1956)"}, false},
+	}
+	for _, tt := range tests {
+		q, ok := Find(tt.n)
+		if !ok || q.N != tt.n || q.Text == "" {
+			t.Fatalf("Find(%d) = %+v, %v", tt.n, q, ok)
+		}
+		result, err := q.Answer(db)
+		if err != nil {
+			t.Errorf("%d: %v", tt.n, err)
+			continue
+		}
+		var got any
+		switch result := result.(type) {
+		case []Movie:
+			got = strs(result)
+		case []Person:
+			got = strs(result)
+		case []RatedMovie:
+			got = strs(result)
+		default:
+			got = result
+		}
+		if rows, ok := got.([]string); ok {
+			if !tt.ordered {
+				slices.Sort(rows)
+			}
+			if !slices.Equal(rows, tt.want.([]string)) {
+				t.Errorf("%d = %q, want %q", tt.n, rows, tt.want)
+			}
+		} else if got != tt.want {
+			t.Errorf("%d = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+	if _, ok := Find(14); ok {
+		t.Error("Find(14) should fail")
+	}
+}
+
+// The functions take other names and years than the pset's, and unknown
+// years and births come back as 0.
+func TestParameters(t *testing.T) {
+	db := dbtest.Movies(t)
+	if _, err := BirthYear(db, "Nobody"); err != sql.ErrNoRows {
+		t.Errorf("BirthYear(Nobody) err = %v", err)
+	}
+	if got, err := AverageRating(db, 1900); got != 0 || err != nil {
+		t.Errorf("AverageRating(1900) = %v, %v", got, err)
+	}
+	if got, _ := Stars(db, "Toy Story 3"); !slices.Contains(strs(got), "Joan Cusack") {
+		t.Errorf("Stars(Toy Story 3) = %v", got)
+	}
+	if got, _ := TopRated(db, "Chadwick Boseman", 1); len(got) != 1 || got[0].Title != "Black Panther" || got[0].ID != 4 {
+		t.Errorf("TopRated(Chadwick Boseman, 1) = %+v", got)
+	}
+	if got, _ := CoStars(db, "Kevin Bacon", 1979); !slices.Equal(strs(got), []string{"Tom Hanks (born 1956)", "Joan Cusack"}) {
+		t.Errorf("CoStars(Kevin Bacon, 1979) = %v", got)
+	}
+	if got, _ := ReleasedSince(db, 2100); got != nil {
+		t.Errorf("ReleasedSince(2100) = %v", got)
+	}
+	if got, _ := TitledLike(db, "Untitled"); len(got) != 1 || got[0].String() != "Untitled" {
+		t.Errorf("TitledLike(Untitled) = %v", got)
+	}
+}
+
+// TestDistribution checks answers everyone agrees on against the pset's
+// real movies.db, when it's here (or at $MOVIES_DB). It's IMDb-sized, so
+// it isn't in the repo.
+func TestDistribution(t *testing.T) {
+	path := os.Getenv("MOVIES_DB")
+	if path == "" {
+		path = "movies.db"
+	}
+	db, err := dbutil.Open(path)
+	if err != nil {
+		t.Skipf("no %s: %v", path, err)
+	}
+	defer db.Close()
+
+	if birth, err := BirthYear(db, "Emma Stone"); birth != 1988 || err != nil {
+		t.Errorf("Emma Stone born %d, %v", birth, err)
+	}
+	contains := func(what string, got []string, want ...string) {
+		t.Helper()
+		for _, w := range want {
+			if !slices.Contains(got, w) {
+				t.Errorf("%s: no %q in %d rows", what, w, len(got))
+			}
+		}
+	}
+	released, _ := ReleasedIn(db, 2008)
+	contains("2008", strs(released), "Iron Man (2008)", "The Dark Knight (2008)")
+	potter, _ := TitledLike(db, "Harry Potter")
+	if len(potter) == 0 || potter[0].String() != "Harry Potter and the Sorcerer's Stone (2001)" {
+		t.Errorf("Harry Potter starts with %v", potter)
+	}
+	toyStory, _ := Stars(db, "Toy Story")
+	contains("Toy Story", strs(toyStory), "Tom Hanks (born 1956)", "Tim Allen (born 1953)")
+	directors, _ := DirectorsRatedAtLeast(db, 9.0)
+	contains("directors", strs(directors), "Christopher Nolan (born 1970)")
+	boseman, _ := TopRated(db, "Chadwick Boseman", 5)
+	if len(boseman) != 5 || !slices.IsSortedFunc(boseman, func(a, b RatedMovie) int { return int(10 * (b.Rating - a.Rating)) }) {
+		t.Errorf("Chadwick Boseman's top 5 = %v", boseman)
+	}
+	both, _ := CoStarring(db, "Bradley Cooper", "Jennifer Lawrence")
+	contains("Cooper and Lawrence", strs(both), "Silver Linings Playbook (2012)", "American Hustle (2013)")
+	bacon, _ := CoStars(db, "Kevin Bacon", 1958)
+	contains("Kevin Bacon", strs(bacon), "Tom Hanks (born 1956)")
+	for _, p := range bacon {
+		if p.Name == "Kevin Bacon" && p.Birth == 1958 {
+			t.Error("Kevin Bacon is his own co-star")
+		}
+	}
+	if average, _ := AverageRating(db, 2012); average < 5 || average > 8 {
+		t.Errorf("2012's average rating = %v", average)
+	}
+	rated, _ := RatedIn(db, 2010)
+	if !slices.IsSortedFunc(rated, func(a, b RatedMovie) int { return int(10 * (b.Rating - a.Rating)) }) {
+		t.Error("2010's movies aren't best first")
+	}
+}
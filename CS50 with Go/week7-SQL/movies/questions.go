@@ -0,0 +1,49 @@
+package movies
+
+import "database/sql"
+
+// Question is one of the pset's questions and the function call that
+// answers it, with the pset's names and years filled in.
+type Question struct {
+	N      int
+	Text   string
+	Answer func(db *sql.DB) (any, error)
+}
+
+// QUESTIONS are the pset's 13 questions, in order.
+var QUESTIONS = []Question{
+	{1, "the titles of all movies released in 2008",
+		func(db *sql.DB) (any, error) { return ReleasedIn(db, 2008) }},
+	{2, "the birth year of Emma Stone",
+		func(db *sql.DB) (any, error) { return BirthYear(db, "Emma Stone") }},
+	{3, "the titles of all movies released in 2018 or later, alphabetically",
+		func(db *sql.DB) (any, error) { return ReleasedSince(db, 2018) }},
+	{4, "the number of movies with a 10.0 rating",
+		func(db *sql.DB) (any, error) { return CountRated(db, 10.0) }},
+	{5, "the titles and years of all Harry Potter movies, in release order",
+		func(db *sql.DB) (any, error) { return TitledLike(db, "Harry Potter") }},
+	{6, "the average rating of all movies released in 2012",
+		func(db *sql.DB) (any, error) { return AverageRating(db, 2012) }},
+	{7, "all movies released in 2010 and their ratings, best first, ties by title",
+		func(db *sql.DB) (any, error) { return RatedIn(db, 2010) }},
+	{8, "the names of all people who starred in Toy Story",
+		func(db *sql.DB) (any, error) { return Stars(db, "Toy Story") }},
+	{9, "the names of all people who starred in a movie released in 2004, by birth year",
+		func(db *sql.DB) (any, error) { return StarsIn(db, 2004) }},
+	{10, "the names of all people who directed a movie rated at least 9.0",
+		func(db *sql.DB) (any, error) { return DirectorsRatedAtLeast(db, 9.0) }},
+	{11, "the titles of the five highest rated movies Chadwick Boseman starred in",
+		func(db *sql.DB) (any, error) { return TopRated(db, "Chadwick Boseman", 5) }},
+	{12, "the titles of all movies in which both Bradley Cooper and Jennifer Lawrence starred",
+		func(db *sql.DB) (any, error) { return CoStarring(db, "Bradley Cooper", "Jennifer Lawrence") }},
+	{13, "the names of all people who starred in a movie with Kevin Bacon (born 1958)",
+		func(db *sql.DB) (any, error) { return CoStars(db, "Kevin Bacon", 1958) }},
+}
+
+// Find returns question n.
+func Find(n int) (Question, bool) {
+	if n < 1 || n > len(QUESTIONS) {
+		return Question{}, false
+	}
+	return QUESTIONS[n-1], true
+}
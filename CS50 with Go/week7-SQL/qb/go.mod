@@ -0,0 +1,3 @@
+module qb
+
+go 1.24.4
@@ -0,0 +1,144 @@
+// Package qb puts a SELECT together a piece at a time (WHERE conditions,
+// ORDER BY and LIMIT) for pages and commands whose filters are optional.
+// Values always travel as bound ? parameters, never pasted into the SQL,
+// and column names have to look like column names, so what a user types
+// can't change what the query does.
+//
+//	q := qb.Select("SELECT symbol, shares FROM transactions").
+//		Where("user_id = ?", id)
+//	if symbol != "" {
+//		q.Where("symbol = ?", symbol)
+//	}
+//	query, args, err := q.OrderBy("id DESC").Limit(50).Build()
+//	rows, err := db.Query(query, args...)
+//
+// It's deliberately small: a teaching aid for composing SQL safely, not an
+// ORM. Joins, grouping and the columns to select stay plain SQL in Select.
+package qb
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// OPERATORS are the comparisons Compare takes.
+var OPERATORS = []string{"=", "!=", "<", "<=", ">", ">=", "LIKE"}
+
+// IDENTIFIER is a column name, optionally with its table: "hour",
+// "flights.id". No quotes, spaces or semicolons, so nothing that could end
+// the query or start another.
+var IDENTIFIER = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Query is a SELECT being built. Its methods return it, so calls chain.
+type Query struct {
+	base      string
+	args      []any // Select's
+	where     []string
+	whereArgs []any
+	order     []string
+	limit     int
+	err       error
+}
+
+// Select starts a query from everything before WHERE: the columns, the
+// table and any joins, with ? for their own arguments.
+func Select(base string, args ...any) *Query {
+	q := &Query{base: strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(base), ";"))}
+	q.check(base, args)
+	q.args = args
+	return q
+}
+
+// Where adds a condition, ANDed with the others, with a ? for each of its
+// args: Where("day = ? AND hour < ?", 28, 11). Each condition goes in
+// parentheses, so an OR in one (however it's spelled or spaced) can't
+// reach into the next.
+func (q *Query) Where(cond string, args ...any) *Query {
+	if q.check(cond, args) {
+		q.where = append(q.where, "("+cond+")")
+		q.whereArgs = append(q.whereArgs, args...)
+	}
+	return q
+}
+
+// Compare adds "column op ?" with value bound, for filters where the
+// column and operator come from the user too: both are checked against
+// IDENTIFIER and OPERATORS first.
+func (q *Query) Compare(column, op string, value any) *Query {
+	op = strings.ToUpper(op)
+	switch {
+	case !IDENTIFIER.MatchString(column):
+		q.fail(fmt.Errorf("qb: %q isn't a column name", column))
+	case !slices.Contains(OPERATORS, op):
+		q.fail(fmt.Errorf("qb: %q isn't one of %s", op, strings.Join(OPERATORS, " ")))
+	default:
+		q.Where(column+" "+op+" ?", value)
+	}
+	return q
+}
+
+// OrderBy sorts by columns, each optionally followed by ASC or DESC:
+// OrderBy("hour", "minute DESC").
+func (q *Query) OrderBy(columns ...string) *Query {
+	for _, c := range columns {
+		name, direction, _ := strings.Cut(strings.TrimSpace(c), " ")
+		direction = strings.ToUpper(strings.TrimSpace(direction))
+		if !IDENTIFIER.MatchString(name) || (direction != "" && direction != "ASC" && direction != "DESC") {
+			q.fail(fmt.Errorf("qb: can't order by %q", c))
+			continue
+		}
+		q.order = append(q.order, strings.TrimSpace(name+" "+direction))
+	}
+	return q
+}
+
+// Limit keeps at most n rows; 0 means no limit.
+func (q *Query) Limit(n int) *Query {
+	if n < 0 {
+		q.fail(fmt.Errorf("qb: limit %d", n))
+	}
+	q.limit = n
+	return q
+}
+
+// Build returns the SQL and its arguments in order, ready for db.Query,
+// or the first mistake made putting the query together.
+func (q *Query) Build() (string, []any, error) {
+	if q.err != nil {
+		return "", nil, q.err
+	}
+	var sql strings.Builder
+	sql.WriteString(q.base)
+	args := append(append([]any(nil), q.args...), q.whereArgs...)
+	if len(q.where) > 0 {
+		sql.WriteString(" WHERE " + strings.Join(q.where, " AND "))
+	}
+	if len(q.order) > 0 {
+		sql.WriteString(" ORDER BY " + strings.Join(q.order, ", "))
+	}
+	if q.limit > 0 {
+		sql.WriteString(" LIMIT ?")
+		args = append(args, q.limit)
+	}
+	return sql.String(), args, nil
+}
+
+// check makes sure sql has a ? for every arg, the easiest mistake to make
+// and the hardest to spot once the pieces are joined. A ? inside a quoted
+// string counts too, so keep literals out of conditions and bind them.
+func (q *Query) check(sql string, args []any) bool {
+	if n := strings.Count(sql, "?"); n != len(args) {
+		q.fail(fmt.Errorf("qb: %q has %d ? for %d arguments", sql, n, len(args)))
+		return false
+	}
+	return true
+}
+
+// fail remembers the first error, for Build to return.
+func (q *Query) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
@@ -0,0 +1,84 @@
+package qb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		sql   string
+		args  []any
+	}{
+		{"just the base", Select("SELECT * FROM people;"), "SELECT * FROM people", nil},
+		{"where", Select("SELECT name FROM people").Where("birth = ?", 1958),
+			"SELECT name FROM people WHERE (birth = ?)", []any{1958}},
+		{"conditions are ANDed", Select("SELECT * FROM flights").Where("day = ? AND month = ?", 29, 7).Where("hour < ?", 12),
+			"SELECT * FROM flights WHERE (day = ? AND month = ?) AND (hour < ?)", []any{29, 7, 12}},
+		{"OR keeps to itself", Select("SELECT * FROM t").Where("a = ? OR b = ?", 1, 2).Where("c = ?", 3),
+			"SELECT * FROM t WHERE (a = ? OR b = ?) AND (c = ?)", []any{1, 2, 3}},
+		{"or in any spelling", Select("SELECT * FROM t").Where("a = ?\nor b = ?", 1, 2).Where("c = ?", 3),
+			"SELECT * FROM t WHERE (a = ?\nor b = ?) AND (c = ?)", []any{1, 2, 3}},
+		{"base args come first", Select("SELECT * FROM t JOIN u ON u.id = t.u AND u.x = ?", "x").Where("t.y = ?", "y").Limit(5),
+			"SELECT * FROM t JOIN u ON u.id = t.u AND u.x = ? WHERE (t.y = ?) LIMIT ?", []any{"x", "y", 5}},
+		{"compare", Select("SELECT * FROM bakery_security_logs").Compare("minute", "<=", 25).Compare("activity", "like", "exit%"),
+			"SELECT * FROM bakery_security_logs WHERE (minute <= ?) AND (activity LIKE ?)", []any{25, "exit%"}},
+		{"order and limit", Select("SELECT * FROM t").OrderBy("hour", "minute desc", "flights.id ASC").Limit(10),
+			"SELECT * FROM t ORDER BY hour, minute DESC, flights.id ASC LIMIT ?", []any{10}},
+		{"no limit", Select("SELECT * FROM t").Limit(0), "SELECT * FROM t", nil},
+	}
+	for _, tt := range tests {
+		sql, args, err := tt.query.Build()
+		if err != nil || sql != tt.sql || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: Build() = %q, %v, %v\nwant %q, %v", tt.name, sql, args, err, tt.sql, tt.args)
+		}
+	}
+}
+
+// What a user types only ever ends up as an argument, and anything that
+// would have to be pasted into the SQL is refused.
+func TestMistakes(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{"too few args", Select("SELECT * FROM t").Where("a = ? AND b = ?", 1), "2 ? for 1 arguments"},
+		{"too many args", Select("SELECT * FROM t WHERE x = 1", 5), "0 ? for 1 arguments"},
+		{"value pasted in", Select("SELECT * FROM t").Where("name = 'Kevin'", "Bacon"), "0 ? for 1 arguments"},
+		{"column injection", Select("SELECT * FROM t").Compare("1=1; DROP TABLE t; --", "=", 1), "isn't a column name"},
+		{"quoted column", Select("SELECT * FROM t").Compare(`"name"`, "=", 1), "isn't a column name"},
+		{"operator injection", Select("SELECT * FROM t").Compare("a", "= 1 OR 1 =", 1), "isn't one of"},
+		{"order injection", Select("SELECT * FROM t").OrderBy("(SELECT hash FROM users)"), "can't order by"},
+		{"order direction", Select("SELECT * FROM t").OrderBy("hour SIDEWAYS"), "can't order by"},
+		{"negative limit", Select("SELECT * FROM t").Limit(-1), "limit -1"},
+		{"first mistake wins", Select("SELECT * FROM t").OrderBy("x y").Limit(-1), "can't order by"},
+	}
+	for _, tt := range tests {
+		sql, args, err := tt.query.Build()
+		if err == nil || !strings.Contains(err.Error(), tt.want) || sql != "" || args != nil {
+			t.Errorf("%s: Build() = %q, %v, %v, want error %q", tt.name, sql, args, err, tt.want)
+		}
+	}
+
+	// An injection attempt as a value is just a strange value
+	sql, args, err := Select("SELECT * FROM people").Compare("name", "=", "x' OR '1'='1").Build()
+	if err != nil || sql != "SELECT * FROM people WHERE (name = ?)" || args[0] != "x' OR '1'='1" {
+		t.Errorf("value = %q, %v, %v", sql, args, err)
+	}
+}
+
+// Building twice, or after adding more, gives consistent results.
+func TestReuse(t *testing.T) {
+	q := Select("SELECT * FROM t").Where("a = ?", 1)
+	first, _, _ := q.Build()
+	again, _, _ := q.Build()
+	q.Where("b = ?", 2)
+	more, args, _ := q.Build()
+	if first != again || more != "SELECT * FROM t WHERE (a = ?) AND (b = ?)" || len(args) != 2 {
+		t.Errorf("Build() = %q, %q, %q %v", first, again, more, args)
+	}
+}
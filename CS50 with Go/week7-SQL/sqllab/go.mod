@@ -0,0 +1,9 @@
+module sqllab
+
+go 1.25.0
+
+require dbutil v0.0.0
+
+require modernc.org/sqlite v1.57.0 // indirect
+
+replace dbutil => ../dbutil
@@ -0,0 +1,112 @@
+package main
+
+// Query is one numbered question of a lab and the SQL that answers it, what
+// the pset has you write into 1.sql, 2.sql, ...
+type Query struct {
+	N        int
+	Question string
+	SQL      string
+}
+
+// Lab is a database and its questions.
+type Lab struct {
+	Name    string
+	File    string // default database file
+	Queries []Query
+}
+
+// Find returns query n.
+func (l Lab) Find(n int) (Query, bool) {
+	for _, q := range l.Queries {
+		if q.N == n {
+			return q, true
+		}
+	}
+	return Query{}, false
+}
+
+// SONGS is the songs lab: Spotify's top 100 songs of 2018.
+var SONGS = Lab{Name: "songs", File: "songs.db", Queries: []Query{
+	{1, "the names of all songs",
+		`SELECT name FROM songs;`},
+	{2, "the names of all songs in increasing order of tempo",
+		`SELECT name FROM songs ORDER BY tempo;`},
+	{3, "the names of the top 5 longest songs, longest first",
+		`SELECT name FROM songs ORDER BY duration_ms DESC LIMIT 5;`},
+	{4, "the names of songs with danceability, energy and valence over 0.75",
+		`SELECT name FROM songs WHERE danceability > 0.75 AND energy > 0.75 AND valence > 0.75;`},
+	{5, "the average energy of all the songs",
+		`SELECT AVG(energy) FROM songs;`},
+	{6, "the names of songs by Post Malone",
+		`SELECT name FROM songs WHERE artist_id = (SELECT id FROM artists WHERE name = 'Post Malone');`},
+	{7, "the average energy of songs by Drake",
+		`SELECT AVG(energy) FROM songs WHERE artist_id = (SELECT id FROM artists WHERE name = 'Drake');`},
+	{8, "the names of songs that feature other artists",
+		`SELECT name FROM songs WHERE name LIKE '%feat.%';`},
+}}
+
+// MOVIES is the movies pset over IMDb's data.
+var MOVIES = Lab{Name: "movies", File: "movies.db", Queries: []Query{
+	{1, "the titles of all movies released in 2008",
+		`SELECT title FROM movies WHERE year = 2008;`},
+	{2, "the birth year of Emma Stone",
+		`SELECT birth FROM people WHERE name = 'Emma Stone';`},
+	{3, "the titles of all movies released in 2018 or later, alphabetically",
+		`SELECT title FROM movies WHERE year >= 2018 ORDER BY title;`},
+	{4, "the number of movies with a 10.0 rating",
+		`SELECT COUNT(*) FROM ratings WHERE rating = 10.0;`},
+	{5, "the titles and years of all Harry Potter movies, in release order",
+		`SELECT title, year FROM movies WHERE title LIKE 'Harry Potter%' ORDER BY year;`},
+	{6, "the average rating of all movies released in 2012",
+		`SELECT AVG(rating) FROM ratings
+		   JOIN movies ON movies.id = ratings.movie_id
+		  WHERE year = 2012;`},
+	{7, "all movies released in 2010 and their ratings, best first, ties by title",
+		`SELECT title, rating FROM movies
+		   JOIN ratings ON ratings.movie_id = movies.id
+		  WHERE year = 2010
+		  ORDER BY rating DESC, title;`},
+	{8, "the names of all people who starred in Toy Story",
+		`SELECT name FROM people
+		   JOIN stars ON stars.person_id = people.id
+		   JOIN movies ON movies.id = stars.movie_id
+		  WHERE title = 'Toy Story';`},
+	{9, "the names of all people who starred in a movie released in 2004, by birth year",
+		`SELECT name FROM people
+		  WHERE id IN (SELECT person_id FROM stars
+		                 JOIN movies ON movies.id = stars.movie_id
+		                WHERE year = 2004)
+		  ORDER BY birth;`},
+	{10, "the names of all people who directed a movie rated at least 9.0",
+		`SELECT name FROM people
+		  WHERE id IN (SELECT person_id FROM directors
+		                 JOIN ratings ON ratings.movie_id = directors.movie_id
+		                WHERE rating >= 9.0);`},
+	{11, "the titles of the five highest rated movies Chadwick Boseman starred in",
+		`SELECT title FROM movies
+		   JOIN stars ON stars.movie_id = movies.id
+		   JOIN people ON people.id = stars.person_id
+		   JOIN ratings ON ratings.movie_id = movies.id
+		  WHERE name = 'Chadwick Boseman'
+		  ORDER BY rating DESC
+		  LIMIT 5;`},
+	{12, "the titles of all movies in which both Bradley Cooper and Jennifer Lawrence starred",
+		`SELECT title FROM movies
+		  WHERE id IN (SELECT movie_id FROM stars JOIN people ON people.id = stars.person_id
+		                WHERE name = 'Bradley Cooper')
+		    AND id IN (SELECT movie_id FROM stars JOIN people ON people.id = stars.person_id
+		                WHERE name = 'Jennifer Lawrence');`},
+	{13, "the names of all people who starred in a movie with Kevin Bacon (born 1958)",
+		`SELECT name FROM people
+		  WHERE id IN (SELECT person_id FROM stars
+		                WHERE movie_id IN (SELECT movie_id FROM stars
+		                                    WHERE person_id = (SELECT id FROM people
+		                                                        WHERE name = 'Kevin Bacon' AND birth = 1958)))
+		    AND id != (SELECT id FROM people WHERE name = 'Kevin Bacon' AND birth = 1958);`},
+}}
+
+// LABS are the labs sqllab knows, by name.
+var LABS = map[string]Lab{
+	SONGS.Name:  SONGS,
+	MOVIES.Name: MOVIES,
+}
@@ -0,0 +1,92 @@
+// The week 7 songs lab and movies pset as a Go program: every numbered
+// query is Go code that can be run and tested instead of a loose .sql file.
+//
+//	./sqllab movies           list the questions
+//	./sqllab movies 7         run query 7 against movies.db
+//	./sqllab -db ~/cs50/songs.db songs 4
+//	./sqllab -sql movies 7    print the SQL instead of running it
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"dbutil"
+)
+
+func main() {
+	dbPath := flag.String("db", "", "database file (default songs.db or movies.db)")
+	showSQL := flag.Bool("sql", false, "print the query instead of running it")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: sqllab [-db file] [-sql] %s [N]\n", strings.Join(labNames(), "|"))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	os.Exit(run(flag.Args(), *dbPath, *showSQL, os.Stdout))
+}
+
+// run lists a lab's questions or answers one, returning the exit code.
+func run(args []string, dbPath string, showSQL bool, w io.Writer) int {
+	if len(args) < 1 || len(args) > 2 {
+		flag.Usage()
+		return 1
+	}
+	lab, ok := LABS[args[0]]
+	if !ok {
+		fmt.Fprintf(w, "unknown lab %q (want %s)\n", args[0], strings.Join(labNames(), " or "))
+		return 1
+	}
+
+	if len(args) == 1 {
+		for _, q := range lab.Queries {
+			fmt.Fprintf(w, "%2d  %s\n", q.N, q.Question)
+		}
+		return 0
+	}
+
+	n, err := strconv.Atoi(args[1])
+	q, ok := lab.Find(n)
+	if err != nil || !ok {
+		fmt.Fprintf(w, "%s has queries 1 to %d\n", lab.Name, len(lab.Queries))
+		return 1
+	}
+	if showSQL {
+		fmt.Fprintln(w, q.SQL)
+		return 0
+	}
+
+	if dbPath == "" {
+		dbPath = lab.File
+	}
+	db, err := dbutil.Open(dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+
+	table, err := dbutil.Query(db, q.SQL)
+	if err != nil {
+		fmt.Fprintf(w, "%s %d: %v\n", lab.Name, q.N, err)
+		return 2
+	}
+	fmt.Fprintf(w, "-- %d. %s\n", q.N, q.Question)
+	table.Fprint(w)
+	return 0
+}
+
+// labNames returns the lab names, sorted.
+func labNames() []string {
+	var names []string
+	for name := range LABS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
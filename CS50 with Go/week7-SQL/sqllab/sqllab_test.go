@@ -0,0 +1,128 @@
+package main
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+
+	"dbutil"
+	"dbutil/dbtest"
+)
+
+// SONGS_FIXTURE is a small stand-in for songs.db with the pset's schema, just
+// enough rows for every query to have something to find and something to
+// leave out. movies.db's is dbtest's.
+const SONGS_FIXTURE = `
+CREATE TABLE artists (id INTEGER, name TEXT, PRIMARY KEY(id));
+CREATE TABLE songs (id INTEGER, name TEXT, artist_id INTEGER, danceability REAL, energy REAL,
+	key INTEGER, loudness REAL, speechiness REAL, valence REAL, tempo REAL, duration_ms INTEGER);
+INSERT INTO artists VALUES (1, 'Post Malone'), (2, 'Drake'), (3, 'Dua Lipa');
+INSERT INTO songs (id, name, artist_id, danceability, energy, valence, tempo, duration_ms) VALUES
+	(1, 'rockstar (feat. 21 Savage)', 1, 0.59, 0.52, 0.13, 160, 218000),
+	(2, 'Psycho (feat. Ty Dolla $ign)', 1, 0.75, 0.56, 0.46, 140, 221000),
+	(3, 'God''s Plan', 2, 0.75, 0.45, 0.36, 77, 199000),
+	(4, 'In My Feelings', 2, 0.84, 0.63, 0.35, 91, 217000),
+	(5, 'IDGAF', 3, 0.84, 0.54, 0.51, 97, 218100),
+	(6, 'New Rules', 3, 0.76, 0.70, 0.60, 116, 209000),
+	(7, 'Dance Test', 3, 0.80, 0.80, 0.80, 120, 100000);
+`
+
+func TestQueries(t *testing.T) {
+	tests := []struct {
+		lab  Lab
+		n    int
+		want []string // first column; rows joined by "|" when more
+	}{
+		{SONGS, 1, []string{"rockstar (feat. 21 Savage)", "Psycho (feat. Ty Dolla $ign)", "God's Plan", "In My Feelings", "IDGAF", "New Rules", "Dance Test"}},
+		{SONGS, 2, []string{"God's Plan", "In My Feelings", "IDGAF", "New Rules", "Dance Test", "Psycho (feat. Ty Dolla $ign)", "rockstar (feat. 21 Savage)"}},
+		{SONGS, 3, []string{"Psycho (feat. Ty Dolla $ign)", "IDGAF", "rockstar (feat. 21 Savage)", "In My Feelings", "New Rules"}},
+		{SONGS, 4, []string{"Dance Test"}},
+		{SONGS, 6, []string{"rockstar (feat. 21 Savage)", "Psycho (feat. Ty Dolla $ign)"}},
+		{SONGS, 7, []string{"0.54"}},
+		{SONGS, 8, []string{"rockstar (feat. 21 Savage)", "Psycho (feat. Ty Dolla $ign)"}},
+		{MOVIES, 1, []string{"Iron Man", "The Dark Knight"}},
+		{MOVIES, 2, []string{"1988"}},
+		{MOVIES, 3, []string{"A Star Is Born", "Black Panther"}},
+		{MOVIES, 4, []string{"0"}},
+		{MOVIES, 5, []string{"Harry Potter and the Chamber of Secrets|2002", "Harry Potter and the Goblet of Fire|2005"}},
+		{MOVIES, 6, []string{"7.7"}},
+		{MOVIES, 7, []string{"Inception|8.8", "Toy Story 3|8.3"}},
+		{MOVIES, 8, []string{"Tom Hanks", "Tim Allen"}},
+		{MOVIES, 9, []string{"Rachel McAdams", "Ryan Gosling"}},
+		{MOVIES, 10, []string{"Christopher Nolan"}},
+		{MOVIES, 11, []string{"Black Panther", "Get on Up"}},
+		{MOVIES, 12, []string{"Silver Linings Playbook"}},
+		{MOVIES, 13, []string{"Tom Hanks", "Bill Paxton"}},
+	}
+
+	dbs := map[string]*sql.DB{
+		"songs":  dbtest.Load(t, SONGS_FIXTURE),
+		"movies": dbtest.Movies(t),
+	}
+	for _, tt := range tests {
+		q, ok := tt.lab.Find(tt.n)
+		if !ok {
+			t.Fatalf("%s has no query %d", tt.lab.Name, tt.n)
+		}
+		table, err := dbutil.Query(dbs[tt.lab.Name], q.SQL)
+		if err != nil {
+			t.Errorf("%s %d: %v", tt.lab.Name, tt.n, err)
+			continue
+		}
+		var got []string
+		for _, row := range table.Rows {
+			got = append(got, strings.Join(row, "|"))
+		}
+		if tt.lab.Name == "movies" && (tt.n == 8 || tt.n == 10 || tt.n == 12 || tt.n == 13) {
+			// The pset doesn't ask for an order
+			slices.Sort(got)
+			tt.want = slices.Sorted(slices.Values(tt.want))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s %d = %q, want %q", tt.lab.Name, tt.n, got, tt.want)
+		}
+	}
+}
+
+// Every query is numbered 1, 2, ... and has a question.
+func TestLabs(t *testing.T) {
+	for name, lab := range LABS {
+		if lab.Name != name {
+			t.Errorf("LABS[%q] is %q", name, lab.Name)
+		}
+		for i, q := range lab.Queries {
+			if q.N != i+1 || q.Question == "" || !strings.HasSuffix(strings.TrimSpace(q.SQL), ";") {
+				t.Errorf("%s query %d: %+v", name, i+1, q)
+			}
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{[]string{"movies"}, 0, " 7  all movies released in 2010"},
+		{[]string{"songs", "9"}, 1, "songs has queries 1 to 8"},
+		{[]string{"films"}, 1, `unknown lab "films"`},
+		{[]string{"movies", "x"}, 1, "movies has queries 1 to 13"},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		if code := run(tt.args, "", false, &out); code != tt.code || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("run(%q) = %d, %q", tt.args, code, out.String())
+		}
+	}
+
+	var out strings.Builder
+	if code := run([]string{"songs", "5"}, "", true, &out); code != 0 || out.String() != "SELECT AVG(energy) FROM songs;\n" {
+		t.Errorf("-sql songs 5 = %d, %q", code, out.String())
+	}
+	out.Reset()
+	if code := run([]string{"songs", "1"}, "missing.db", false, &out); code != 2 {
+		t.Errorf("missing database: exit %d, %q", code, out.String())
+	}
+}
@@ -0,0 +1,245 @@
+// Birthdays lab as a Go web app: a list of birthdays in SQLite, a form to
+// add one, and (the lab's extra challenge) edit and delete.
+//
+//	./birthdays                      birthdays.db on http://localhost:8080
+//	./birthdays -db other.db -port 3000
+//	./birthdays -password secret     anyone can look, only you can edit
+
+package main
+
+import (
+	"cs50"
+	"embed"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"logging"
+	"profiling"
+	"sessions"
+	"webkit"
+	"webui"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver for cs50.OpenSQL
+)
+
+// SCHEMA is the lab's birthdays table, created on first run.
+const SCHEMA = `CREATE TABLE IF NOT EXISTS birthdays (
+    id INTEGER,
+    name TEXT,
+    month INTEGER,
+    day INTEGER,
+    PRIMARY KEY(id)
+)`
+
+//go:embed templates/*
+var templates embed.FS
+
+// app is the handlers' shared state.
+type app struct {
+	db    *cs50.SQL
+	store *sessions.Store
+	hash  string // of -password, or "" when anyone may edit
+	ui    *webui.UI
+}
+
+// page is what every template renders.
+type page struct {
+	CanEdit   bool
+	Locked    bool // there is a password to log in with
+	Birthdays []cs50.Row
+	ID        int64
+	Form      form
+	Problem   string
+}
+
+func main() {
+	logging.Init()
+	defer profiling.Start()()
+	dbPath := flag.String("db", "birthdays.db", "SQLite database")
+	port := flag.Int("port", 8080, "port to listen on")
+	password := flag.String("password", "", "require this password to add, edit or delete")
+	flag.Parse()
+
+	// An empty file is an empty SQLite database, and SCHEMA fills it in.
+	if _, err := os.Stat(*dbPath); os.IsNotExist(err) {
+		if err := os.WriteFile(*dbPath, nil, 0644); err != nil {
+			slog.Error("can't create the database", "path", *dbPath, "err", err)
+			os.Exit(1)
+		}
+	}
+	db, err := cs50.OpenSQL("sqlite:///" + *dbPath)
+	if err != nil {
+		slog.Error("can't open the database", "path", *dbPath, "err", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	hash := ""
+	if *password != "" {
+		if hash, err = sessions.HashPassword(*password); err != nil {
+			slog.Error("can't hash the password", "err", err)
+			os.Exit(1)
+		}
+	}
+	store, err := sessions.NewStore(SESSION_COOKIE, sessions.KeyFromEnv("SECRET_KEY"))
+	if err != nil {
+		slog.Error("can't make the session store", "err", err)
+		os.Exit(1)
+	}
+
+	a, err := newApp(db, store, hash)
+	if err != nil {
+		slog.Error("can't start", "err", err)
+		os.Exit(1)
+	}
+	addr := fmt.Sprintf("localhost:%d", *port)
+	slog.Info("Birthdays", "url", "http://"+addr+"/", "db", *dbPath)
+	err = http.ListenAndServe(addr, webkit.Logger(nil)(a.routes()))
+	slog.Error("stopped", "err", err)
+	os.Exit(1)
+}
+
+func newApp(db *cs50.SQL, store *sessions.Store, hash string) (*app, error) {
+	if _, err := db.Execute(SCHEMA); err != nil {
+		return nil, err
+	}
+	pages, err := fs.Sub(templates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	ui, err := webui.New(pages, nil, store)
+	if err != nil {
+		return nil, err
+	}
+	return &app{db: db, store: store, hash: hash, ui: ui}, nil
+}
+
+func (a *app) routes() http.Handler {
+	router := webkit.NewRouter()
+	router.Get("/{$}", a.list)
+	router.Get("/login", a.loginForm)
+	router.Post("/login", a.checkLogin)
+	router.Post("/logout", a.logout)
+	router.File("/styles.css", templates, "templates/styles.css")
+
+	owner := router.Group(a.ownerOnly)
+	owner.Post("/{$}", a.add)
+	owner.Get("/edit/{id}", a.editForm)
+	owner.Post("/edit/{id}", a.update)
+	owner.Post("/delete/{id}", a.delete)
+	return router
+}
+
+// list shows every birthday and an empty form.
+func (a *app) list(w http.ResponseWriter, r *http.Request) {
+	a.render(w, r, http.StatusOK, "index.html", page{})
+}
+
+// add inserts a birthday, or shows the form again with what's wrong.
+func (a *app) add(w http.ResponseWriter, r *http.Request) {
+	f := readForm(r)
+	month, day, problem := f.validate()
+	if problem != "" {
+		a.render(w, r, http.StatusBadRequest, "index.html", page{Form: f, Problem: problem})
+		return
+	}
+	if _, err := a.db.Execute("INSERT INTO birthdays (name, month, day) VALUES(?, ?, ?)", f.Name, month, day); err != nil {
+		a.serverError(w, err)
+		return
+	}
+	a.ui.Flash(w, r, "Added "+f.Name+".")
+	webkit.Redirect(w, r, "/")
+}
+
+// editForm shows one birthday in a form.
+func (a *app) editForm(w http.ResponseWriter, r *http.Request) {
+	id, ok := webkit.IntParam(r, "id")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	rows, err := a.db.Query("SELECT name, month, day FROM birthdays WHERE id = ?", id)
+	if err != nil {
+		a.serverError(w, err)
+		return
+	}
+	if len(rows) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	f := form{
+		Name:  fmt.Sprint(rows[0]["name"]),
+		Month: fmt.Sprint(rows[0]["month"]),
+		Day:   fmt.Sprint(rows[0]["day"]),
+	}
+	a.render(w, r, http.StatusOK, "edit.html", page{ID: id, Form: f})
+}
+
+// update saves an edited birthday.
+func (a *app) update(w http.ResponseWriter, r *http.Request) {
+	id, ok := webkit.IntParam(r, "id")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	f := readForm(r)
+	month, day, problem := f.validate()
+	if problem != "" {
+		a.render(w, r, http.StatusBadRequest, "edit.html", page{ID: id, Form: f, Problem: problem})
+		return
+	}
+	changed, err := a.db.Execute("UPDATE birthdays SET name = ?, month = ?, day = ? WHERE id = ?", f.Name, month, day, id)
+	if err != nil {
+		a.serverError(w, err)
+		return
+	}
+	if changed == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	a.ui.Flash(w, r, "Saved "+f.Name+".")
+	webkit.Redirect(w, r, "/")
+}
+
+// delete removes a birthday.
+func (a *app) delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := webkit.IntParam(r, "id")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	deleted, err := a.db.Execute("DELETE FROM birthdays WHERE id = ?", id)
+	if err != nil {
+		a.serverError(w, err)
+		return
+	}
+	if deleted == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	a.ui.Flash(w, r, "Deleted.")
+	webkit.Redirect(w, r, "/")
+}
+
+// render fills in the list of birthdays (the index page shows it under
+// the form, even when the form was rejected) and shows the page.
+func (a *app) render(w http.ResponseWriter, r *http.Request, status int, name string, p page) {
+	birthdays, err := a.db.Query("SELECT * FROM birthdays ORDER BY month, day, name")
+	if err != nil {
+		a.serverError(w, err)
+		return
+	}
+	p.Birthdays = birthdays
+	p.CanEdit = a.canEdit(r)
+	p.Locked = a.hash != ""
+	a.ui.Render(w, r, status, name, p)
+}
+
+func (a *app) serverError(w http.ResponseWriter, err error) {
+	slog.Error("database error", "err", err)
+	http.Error(w, "database error", http.StatusInternalServerError)
+}
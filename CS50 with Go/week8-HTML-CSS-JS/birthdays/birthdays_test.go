@@ -0,0 +1,193 @@
+package main
+
+import (
+	"cs50"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"sessions"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		f       form
+		problem string
+	}{
+		{form{"Harry", "7", "31"}, ""},
+		{form{"Leap", "2", "29"}, ""},
+		{form{"", "1", "1"}, "Missing name"},
+		{form{strings.Repeat("x", MAX_NAME+1), "1", "1"}, "Name is too long"},
+		{form{"Ron", "13", "1"}, "Invalid month"},
+		{form{"Ron", "March", "1"}, "Invalid month"},
+		{form{"Ron", "", "1"}, "Invalid month"},
+		{form{"Ron", "2", "30"}, "Invalid day"},
+		{form{"Ron", "4", "31"}, "Invalid day"},
+		{form{"Ron", "4", "0"}, "Invalid day"},
+	}
+	for _, tt := range tests {
+		if _, _, problem := tt.f.validate(); problem != tt.problem {
+			t.Errorf("validate(%+v) = %q, want %q", tt.f, problem, tt.problem)
+		}
+	}
+}
+
+// newTestApp serves an in-memory database. With a password, only the
+// owner can make changes.
+func newTestApp(t *testing.T, password string) http.Handler {
+	t.Helper()
+	db, err := cs50.OpenSQL("sqlite:///:memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := sessions.NewStore(SESSION_COOKIE, sessions.RandomKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := ""
+	if password != "" {
+		hash, _ = sessions.HashPassword(password)
+	}
+	a, err := newApp(db, store, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a.routes()
+}
+
+// do sends one request, with values as a form body when there are any.
+func do(h http.Handler, method, target string, values url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(values.Encode()))
+	if values != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func birthday(name, month, day string) url.Values {
+	return url.Values{"name": {name}, "month": {month}, "day": {day}}
+}
+
+func TestBirthdays(t *testing.T) {
+	h := newTestApp(t, "")
+
+	if w := do(h, "GET", "/", nil); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "No birthdays yet.") {
+		t.Fatalf("GET / on an empty database: %d\n%s", w.Code, w.Body)
+	}
+
+	for _, b := range []url.Values{birthday("Harry", "7", "31"), birthday("Hermione", "9", "19"), birthday("Ron", "3", "1")} {
+		if w := do(h, "POST", "/", b); w.Code != http.StatusSeeOther {
+			t.Fatalf("POST / %v: %d, want 303", b, w.Code)
+		}
+	}
+	body := do(h, "GET", "/", nil).Body.String()
+	ron, harry, hermione := strings.Index(body, "Ron"), strings.Index(body, "Harry"), strings.Index(body, "Hermione")
+	if ron < 0 || !(ron < harry && harry < hermione) {
+		t.Errorf("birthdays not listed in calendar order:\n%s", body)
+	}
+	if !strings.Contains(body, "7/31") {
+		t.Errorf("missing 7/31:\n%s", body)
+	}
+
+	// A rejected form comes back with the problem and what was typed.
+	w := do(h, "POST", "/", birthday("Draco", "6", "31"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("POST June 31st: %d, want 400", w.Code)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "Invalid day") || !strings.Contains(body, `value="Draco"`) {
+		t.Errorf("rejected form should keep the name and say why:\n%s", body)
+	}
+
+	// Names are escaped, not rendered as HTML.
+	do(h, "POST", "/", birthday("<b>Peeves</b>", "4", "1"))
+	if body := do(h, "GET", "/", nil).Body.String(); strings.Contains(body, "<b>Peeves") {
+		t.Errorf("name not escaped:\n%s", body)
+	}
+}
+
+func TestEditDelete(t *testing.T) {
+	h := newTestApp(t, "")
+	do(h, "POST", "/", birthday("Harry", "7", "30"))
+
+	w := do(h, "GET", "/edit/1", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `value="30"`) {
+		t.Fatalf("GET /edit/1: %d\n%s", w.Code, w.Body)
+	}
+	if w := do(h, "POST", "/edit/1", birthday("Harry", "7", "32")); w.Code != http.StatusBadRequest {
+		t.Errorf("POST /edit/1 with day 32: %d, want 400", w.Code)
+	}
+	if w := do(h, "POST", "/edit/1", birthday("Harry Potter", "7", "31")); w.Code != http.StatusSeeOther {
+		t.Errorf("POST /edit/1: %d, want 303", w.Code)
+	}
+	if body := do(h, "GET", "/", nil).Body.String(); !strings.Contains(body, "Harry Potter") || !strings.Contains(body, "7/31") {
+		t.Errorf("edit not saved:\n%s", body)
+	}
+
+	if w := do(h, "POST", "/delete/1", url.Values{}); w.Code != http.StatusSeeOther {
+		t.Errorf("POST /delete/1: %d, want 303", w.Code)
+	}
+	if body := do(h, "GET", "/", nil).Body.String(); strings.Contains(body, "Harry") {
+		t.Errorf("still listed after delete:\n%s", body)
+	}
+
+	for _, tt := range []struct{ method, target string }{
+		{"GET", "/edit/1"},
+		{"GET", "/edit/x"},
+		{"POST", "/delete/1"},
+		{"POST", "/edit/99"},
+	} {
+		if w := do(h, tt.method, tt.target, birthday("Ginny", "8", "11")); w.Code != http.StatusNotFound {
+			t.Errorf("%s %s: %d, want 404", tt.method, tt.target, w.Code)
+		}
+	}
+}
+
+func TestPassword(t *testing.T) {
+	h := newTestApp(t, "hunter2")
+
+	// Logged out: the list is public, changes go to the login page
+	body := do(h, "GET", "/", nil).Body.String()
+	if strings.Contains(body, "Add Birthday") || !strings.Contains(body, "Log in to edit") {
+		t.Errorf("logged-out page offers the form:\n%s", body)
+	}
+	for _, target := range []string{"/", "/edit/1", "/delete/1"} {
+		w := do(h, "POST", target, birthday("Harry", "7", "31"))
+		if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/login" {
+			t.Errorf("POST %s logged out: %d to %q, want 303 to /login", target, w.Code, w.Header().Get("Location"))
+		}
+	}
+	if body := do(h, "GET", "/", nil).Body.String(); !strings.Contains(body, "No birthdays yet.") {
+		t.Errorf("a logged-out POST added a birthday:\n%s", body)
+	}
+
+	if w := do(h, "POST", "/login", url.Values{"password": {"wrong"}}); w.Code != http.StatusForbidden {
+		t.Errorf("wrong password: %d, want 403", w.Code)
+	}
+	w := do(h, "POST", "/login", url.Values{"password": {"hunter2"}})
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("right password: %d, want 303", w.Code)
+	}
+	cookie := w.Result().Cookies()[0]
+
+	// Logged in: the form is back and adding works
+	r := httptest.NewRequest("POST", "/", strings.NewReader(birthday("Harry", "7", "31").Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/" {
+		t.Errorf("POST / logged in: %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	r = httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if body := w.Body.String(); !strings.Contains(body, "Add Birthday") || !strings.Contains(body, "Harry") {
+		t.Errorf("logged-in page:\n%s", body)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MAX_NAME is the longest name the form accepts.
+const MAX_NAME = 100
+
+// DAYS_IN_MONTH allows February 29th: birthdays don't have a year.
+var DAYS_IN_MONTH = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// form is what the add and edit forms send. The fields stay strings so a
+// rejected form can be shown again exactly as it was typed.
+type form struct {
+	Name  string
+	Month string
+	Day   string
+}
+
+func readForm(r *http.Request) form {
+	return form{
+		Name:  strings.TrimSpace(r.PostFormValue("name")),
+		Month: strings.TrimSpace(r.PostFormValue("month")),
+		Day:   strings.TrimSpace(r.PostFormValue("day")),
+	}
+}
+
+// validate checks f and returns the month and day, or a message saying
+// what's wrong with it.
+func (f form) validate() (month, day int, problem string) {
+	if f.Name == "" {
+		return 0, 0, "Missing name"
+	}
+	if len([]rune(f.Name)) > MAX_NAME {
+		return 0, 0, "Name is too long"
+	}
+	month, err := strconv.Atoi(f.Month)
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, "Invalid month"
+	}
+	day, err = strconv.Atoi(f.Day)
+	if err != nil || day < 1 || day > DAYS_IN_MONTH[month] {
+		return 0, 0, "Invalid day"
+	}
+	return month, day, ""
+}
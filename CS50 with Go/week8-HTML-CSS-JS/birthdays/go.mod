@@ -0,0 +1,28 @@
+module birthdays
+
+go 1.25.0
+
+require (
+	cs50 v0.0.0
+	logging v0.0.0
+	modernc.org/sqlite v1.57.0
+	profiling v0.0.0
+	sessions v0.0.0
+	webkit v0.0.0
+	webui v0.0.0
+)
+
+require (
+	golang.org/x/crypto v0.54.0 // indirect
+	runctx v0.0.0 // indirect
+)
+
+replace (
+	cs50 => ../../cs50
+	logging => ../../logging
+	profiling => ../../profiling
+	runctx => ../../runctx
+	sessions => ../../week9-Flask/sessions
+	webkit => ../../week9-Flask/webkit
+	webui => ../../week9-Flask/webui
+)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"net/http"
+
+	"sessions"
+	"webkit"
+)
+
+// SESSION_COOKIE names the cookie that says the owner is logged in.
+const SESSION_COOKIE = "birthdays_session"
+
+// OWNER_ID is the user ID in the owner's session. With -password there is
+// one account, so there's no users table to get an ID from.
+const OWNER_ID = 1
+
+// canEdit reports whether r may add, edit and delete: anyone can when no
+// -password was given, otherwise only the owner.
+func (a *app) canEdit(r *http.Request) bool {
+	if a.hash == "" {
+		return true
+	}
+	_, ok := a.store.User(r)
+	return ok
+}
+
+// ownerOnly guards the routes that change birthdays.
+func (a *app) ownerOnly(next http.Handler) http.Handler {
+	if a.hash == "" {
+		return next
+	}
+	return a.store.LoginRequired("/login", next)
+}
+
+// loginForm asks for the password.
+func (a *app) loginForm(w http.ResponseWriter, r *http.Request) {
+	a.render(w, r, http.StatusOK, "login.html", page{})
+}
+
+// checkLogin logs the owner in when the password is right.
+func (a *app) checkLogin(w http.ResponseWriter, r *http.Request) {
+	if a.hash == "" || !sessions.CheckPassword(a.hash, r.PostFormValue("password")) {
+		a.render(w, r, http.StatusForbidden, "login.html", page{Problem: "Wrong password"})
+		return
+	}
+	a.store.Login(w, OWNER_ID)
+	webkit.Redirect(w, r, "/")
+}
+
+// logout goes back to read-only.
+func (a *app) logout(w http.ResponseWriter, r *http.Request) {
+	a.store.Logout(w)
+	webkit.Redirect(w, r, "/")
+}
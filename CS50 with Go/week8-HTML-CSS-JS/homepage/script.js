@@ -0,0 +1,8 @@
+// Every click adds a duck
+document.addEventListener('DOMContentLoaded', function() {
+    let ducks = 0;
+    document.querySelector('#quack').addEventListener('click', function() {
+        ducks++;
+        document.querySelector('#duck').textContent = '🦆'.repeat(ducks);
+    });
+});
@@ -0,0 +1,13 @@
+module serve
+
+go 1.24.4
+
+require (
+	logging v0.0.0
+	webkit v0.0.0
+)
+
+replace (
+	logging => ../../logging
+	webkit => ../../week9-Flask/webkit
+)
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+
+	"webkit"
+)
+
+// MIME_TYPES are registered on top of the system's table, which differs by
+// OS (some Windows setups serve .js as text/plain, and browsers then refuse
+// to run modules).
+var MIME_TYPES = map[string]string{
+	".css":   "text/css; charset=utf-8",
+	".gif":   "image/gif",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/x-icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".mp4":   "video/mp4",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webp":  "image/webp",
+	".woff2": "font/woff2",
+}
+
+func init() {
+	for extension, typ := range MIME_TYPES {
+		mime.AddExtensionType(extension, typ)
+	}
+}
+
+// newHandler serves the files in root. Without listing, a directory with no
+// index.html is a 404 instead of a list of its files.
+func newHandler(root fs.FS, listing bool) http.Handler {
+	if !listing {
+		root = noListing{root}
+	}
+	return webkit.Logger(nil)(http.FileServerFS(root))
+}
+
+// noListing hides directories that have no index.html.
+type noListing struct {
+	fs.FS
+}
+
+func (n noListing) Open(name string) (fs.File, error) {
+	f, err := n.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		index, err := n.FS.Open(path.Join(name, "index.html"))
+		if err != nil {
+			f.Close()
+			return nil, fs.ErrNotExist
+		}
+		index.Close()
+	}
+	return f, nil
+}
@@ -0,0 +1,117 @@
+// serve: a static file server for the week 8 homepage, instead of
+// "python -m http.server". Ctrl-C finishes the requests in flight, then
+// exits.
+//
+//	./serve                         ../homepage on http://localhost:8080
+//	./serve -port 3000 -listing path/to/site
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"logging"
+)
+
+// SHUTDOWN_TIMEOUT is how long requests in flight get to finish.
+const SHUTDOWN_TIMEOUT = 5 * time.Second
+
+func main() {
+	logging.Init()
+	port := flag.Int("port", 8080, "port to listen on")
+	host := flag.String("host", "localhost", "interface to listen on (0.0.0.0 for every one)")
+	listing := flag.Bool("listing", false, "list the files of directories without an index.html")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: serve [-port N] [-host H] [-listing] [-v] [--log-format json] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "../homepage"
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(1)
+	}
+	if err := checkDir(dir); err != nil {
+		slog.Error("can't serve it", "err", err)
+		os.Exit(1)
+	}
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(*host, strconv.Itoa(*port)),
+		Handler:           newHandler(os.DirFS(dir), *listing),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		slog.Error("can't listen", "err", err)
+		os.Exit(1)
+	}
+
+	// Shut down gracefully on Ctrl-C (SIGINT) or kill (SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	slog.Info("serving (Ctrl-C to stop)", "dir", dir, "url", fmt.Sprintf("http://%s/", listener.Addr()))
+	if err := serve(ctx, server, listener); err != nil {
+		slog.Error("stopped", "err", err)
+		os.Exit(1)
+	}
+	slog.Info("stopped")
+}
+
+// serve runs server on listener until ctx is done, then gives the requests
+// in flight SHUTDOWN_TIMEOUT to finish.
+func serve(ctx context.Context, server *http.Server, listener net.Listener) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err // couldn't serve at all
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down", "timeout", SHUTDOWN_TIMEOUT)
+	shutdown, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil {
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// errNotDir is returned when the directory to serve isn't one.
+var errNotDir = errors.New("not a directory")
+
+// checkDir makes sure dir exists and is a directory.
+func checkDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: %w", dir, errNotDir)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var SITE = fstest.MapFS{
+	"index.html":      {Data: []byte("<h1>Home</h1>")},
+	"styles.css":      {Data: []byte("main { max-width: 48rem; }")},
+	"script.js":       {Data: []byte("console.log('quack')")},
+	"images/duck.svg": {Data: []byte("<svg></svg>")},
+	"notes/todo.txt":  {Data: []byte("finish week 8")},
+	"blog/index.html": {Data: []byte("<h1>Blog</h1>")},
+}
+
+func init() {
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+}
+
+func get(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+	return w
+}
+
+func TestMIMETypes(t *testing.T) {
+	handler := newHandler(SITE, false)
+	tests := []struct {
+		path, contentType string
+	}{
+		{"/", "text/html; charset=utf-8"},
+		{"/styles.css", "text/css; charset=utf-8"},
+		{"/script.js", "text/javascript; charset=utf-8"},
+		{"/images/duck.svg", "image/svg+xml"},
+		{"/notes/todo.txt", "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		w := get(t, handler, tt.path)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("%s: %d %q, want 200 %q", tt.path, w.Code, w.Header().Get("Content-Type"), tt.contentType)
+		}
+	}
+}
+
+func TestListing(t *testing.T) {
+	tests := []struct {
+		listing bool
+		path    string
+		code    int
+		body    string
+	}{
+		{false, "/notes/", http.StatusNotFound, ""},
+		{true, "/notes/", http.StatusOK, "todo.txt"},
+		{false, "/blog/", http.StatusOK, "<h1>Blog</h1>"}, // has an index.html
+		{false, "/missing.html", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		w := get(t, newHandler(SITE, tt.listing), tt.path)
+		if w.Code != tt.code || !strings.Contains(w.Body.String(), tt.body) {
+			t.Errorf("listing %v %s: %d %q", tt.listing, tt.path, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	// A slow request is in flight when the shutdown starts
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		io.WriteString(w, "done")
+	})}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("can't listen:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error)
+	go func() { served <- serve(ctx, server, listener) }()
+
+	bodies := make(chan string)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			bodies <- err.Error()
+			return
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		bodies <- string(body)
+	}()
+
+	<-started
+	cancel()
+	if body := <-bodies; body != "done" {
+		t.Errorf("request in flight got %q", body)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("serve: %v", err)
+	}
+}
+
+func TestCheckDir(t *testing.T) {
+	if err := checkDir("../homepage"); err != nil {
+		t.Error(err)
+	}
+	if err := checkDir("serve.go"); err == nil {
+		t.Error("checkDir(serve.go): want error")
+	}
+}
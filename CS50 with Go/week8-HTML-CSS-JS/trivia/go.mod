@@ -0,0 +1,25 @@
+module trivia
+
+go 1.25.0
+
+require (
+	logging v0.0.0
+	profiling v0.0.0
+	sessions v0.0.0
+	webkit v0.0.0
+)
+
+require (
+	cs50 v0.0.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+	runctx v0.0.0 // indirect
+)
+
+replace (
+	cs50 => ../../cs50
+	logging => ../../logging
+	profiling => ../../profiling
+	runctx => ../../runctx
+	sessions => ../../week9-Flask/sessions
+	webkit => ../../week9-Flask/webkit
+)
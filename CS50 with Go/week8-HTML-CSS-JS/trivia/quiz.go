@@ -0,0 +1,65 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+//go:embed questions.json
+var questionsJSON []byte
+
+// Question is one trivia question. With Choices it's multiple choice,
+// without it's free response and any of Accept counts too.
+type Question struct {
+	ID      string   `json:"id"`
+	Prompt  string   `json:"prompt"`
+	Choices []string `json:"choices,omitempty"`
+	Answer  string   `json:"answer"`
+	Accept  []string `json:"accept,omitempty"`
+}
+
+// MultipleChoice reports whether q shows buttons instead of a text box.
+func (q Question) MultipleChoice() bool {
+	return len(q.Choices) > 0
+}
+
+// Check reports whether answer is right. Free responses ignore case and
+// surrounding space, like the lab's JavaScript did.
+func (q Question) Check(answer string) bool {
+	if q.MultipleChoice() {
+		return answer == q.Answer
+	}
+	answer = strings.TrimSpace(answer)
+	for _, right := range append([]string{q.Answer}, q.Accept...) {
+		if strings.EqualFold(answer, right) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadQuestions parses and checks the questions: IDs unique, and a multiple
+// choice answer among the choices.
+func loadQuestions(data []byte) ([]Question, error) {
+	var questions []Question
+	if err := json.Unmarshal(data, &questions); err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, q := range questions {
+		if q.ID == "" || ids[q.ID] {
+			return nil, fmt.Errorf("question %q: IDs must be unique and not empty", q.ID)
+		}
+		ids[q.ID] = true
+		if q.Prompt == "" || q.Answer == "" {
+			return nil, fmt.Errorf("question %s: needs a prompt and an answer", q.ID)
+		}
+		if q.MultipleChoice() && !slices.Contains(q.Choices, q.Answer) {
+			return nil, fmt.Errorf("question %s: answer %q isn't one of the choices", q.ID, q.Answer)
+		}
+	}
+	return questions, nil
+}
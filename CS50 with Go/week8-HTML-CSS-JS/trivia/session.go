@@ -0,0 +1,41 @@
+package main
+
+import "sessions"
+
+// SESSION_COOKIE names the cookie that holds a player's score.
+const SESSION_COOKIE = "trivia_session"
+
+// Score is one player's results: question ID -> answered correctly. A
+// question answered again keeps the latest result.
+type Score map[string]bool
+
+// Correct counts the questions answered correctly.
+func (s Score) Correct() int {
+	n := 0
+	for _, correct := range s {
+		if correct {
+			n++
+		}
+	}
+	return n
+}
+
+// scoreOf reads a score out of a session, where each answered question is
+// stored as "1" (correct) or "0". The cookie is signed, so players can't
+// hand themselves points.
+func scoreOf(session *sessions.Session) Score {
+	score := Score{}
+	for id, result := range session.Values {
+		score[id] = result == "1"
+	}
+	return score
+}
+
+// record stores a result in the session.
+func record(session *sessions.Session, question string, correct bool) {
+	result := "0"
+	if correct {
+		result = "1"
+	}
+	session.Set(question, result)
+}
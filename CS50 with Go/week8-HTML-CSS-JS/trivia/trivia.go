@@ -0,0 +1,121 @@
+// Trivia lab as a Go web app: multiple-choice and free-response questions
+// from an embedded JSON file, checked on the server, with a score kept per
+// browser session.
+//
+//	./trivia               http://localhost:8080
+//	./trivia -port 3000
+
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"logging"
+	"profiling"
+	"sessions"
+	"webkit"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// app is the handlers' shared state.
+type app struct {
+	questions []Question
+	store     *sessions.Store
+	page      *template.Template
+}
+
+// page is what index.html renders.
+type page struct {
+	Questions []Question
+	Results   map[string]string // question ID -> "correct" or "incorrect"
+	Correct   int
+	Answered  bool
+}
+
+func main() {
+	logging.Init()
+	defer profiling.Start()()
+	port := flag.Int("port", 8080, "port to listen on")
+	flag.Parse()
+
+	// Set SECRET_KEY to keep scores across restarts.
+	store, err := sessions.NewStore(SESSION_COOKIE, sessions.KeyFromEnv("SECRET_KEY"))
+	if err != nil {
+		slog.Error("can't make the session store", "err", err)
+		os.Exit(1)
+	}
+	a, err := newApp(store)
+	if err != nil {
+		slog.Error("can't start", "err", err)
+		os.Exit(1)
+	}
+	addr := fmt.Sprintf("localhost:%d", *port)
+	slog.Info("Trivia", "url", "http://"+addr+"/")
+	err = http.ListenAndServe(addr, webkit.Logger(nil)(a.routes()))
+	slog.Error("stopped", "err", err)
+	os.Exit(1)
+}
+
+func newApp(store *sessions.Store) (*app, error) {
+	questions, err := loadQuestions(questionsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("questions.json: %w", err)
+	}
+	tmpl, err := template.ParseFS(templates, "templates/index.html")
+	if err != nil {
+		return nil, err
+	}
+	return &app{questions: questions, store: store, page: tmpl}, nil
+}
+
+func (a *app) routes() http.Handler {
+	router := webkit.NewRouter()
+	router.Get("/{$}", a.index)
+	router.Post("/answer", a.answer)
+	router.Post("/reset", a.reset)
+	router.File("/styles.css", templates, "templates/styles.css")
+	return router
+}
+
+// index shows every question, with feedback on the ones answered.
+func (a *app) index(w http.ResponseWriter, r *http.Request) {
+	score := scoreOf(a.store.Get(r))
+	p := page{Questions: a.questions, Results: map[string]string{}, Correct: score.Correct(), Answered: len(score) > 0}
+	for id, correct := range score {
+		p.Results[id] = "incorrect"
+		if correct {
+			p.Results[id] = "correct"
+		}
+	}
+	webkit.HTML(w, http.StatusOK, a.page, "index.html", p)
+}
+
+// answer checks one answer, then sends the browser back to the question
+// (post/redirect/get, so reloading doesn't answer again).
+func (a *app) answer(w http.ResponseWriter, r *http.Request) {
+	id := r.PostFormValue("id")
+	for _, q := range a.questions {
+		if q.ID == id {
+			session := a.store.Get(r)
+			record(session, id, q.Check(r.PostFormValue("answer")))
+			a.store.Save(w, session)
+			webkit.Redirect(w, r, "/#"+id)
+			return
+		}
+	}
+	http.Error(w, "no such question", http.StatusBadRequest)
+}
+
+// reset starts the quiz over.
+func (a *app) reset(w http.ResponseWriter, r *http.Request) {
+	a.store.Clear(w)
+	webkit.Redirect(w, r, "/")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"sessions"
+)
+
+func TestCheck(t *testing.T) {
+	mc := Question{Choices: []string{"4", "8"}, Answer: "8"}
+	fr := Question{Answer: "Harvard", Accept: []string{"Harvard University"}}
+	tests := []struct {
+		q      Question
+		answer string
+		want   bool
+	}{
+		{mc, "8", true},
+		{mc, "4", false},
+		{mc, " 8", false}, // buttons send the exact value
+		{fr, "harvard", true},
+		{fr, "  HARVARD UNIVERSITY ", true},
+		{fr, "Yale", false},
+		{fr, "", false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Check(tt.answer); got != tt.want {
+			t.Errorf("Check(%q) = %v, want %v", tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestLoadQuestions(t *testing.T) {
+	questions, err := loadQuestions(questionsJSON)
+	if err != nil || len(questions) == 0 {
+		t.Fatalf("questions.json: %d questions, %v", len(questions), err)
+	}
+
+	for _, bad := range []string{
+		`[{"id": "a", "prompt": "?", "answer": "x"}, {"id": "a", "prompt": "?", "answer": "y"}]`,
+		`[{"id": "a", "prompt": "?", "choices": ["x", "y"], "answer": "z"}]`,
+		`[{"id": "a", "prompt": "", "answer": "x"}]`,
+		`{}`,
+	} {
+		if _, err := loadQuestions([]byte(bad)); err == nil {
+			t.Errorf("loadQuestions(%s): want error", bad)
+		}
+	}
+}
+
+// A player answers two questions, reloads, and starts over.
+func newTestApp(t *testing.T) *app {
+	t.Helper()
+	store, err := sessions.NewStore(SESSION_COOKIE, sessions.RandomKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := newApp(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestQuiz(t *testing.T) {
+	a := newTestApp(t)
+	server := httptest.NewServer(a.routes())
+	defer server.Close()
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{Jar: jar}
+
+	page := func(resp *http.Response, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s: %s", resp.Request.URL, resp.Status)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+	answer := func(id, answer string) string {
+		return page(client.PostForm(server.URL+"/answer", url.Values{"id": {id}, "answer": {answer}}))
+	}
+
+	body := page(client.Get(server.URL))
+	if !strings.Contains(body, "Score: 0 / 7") || strings.Contains(body, "Correct!") {
+		t.Errorf("new player sees %q", body)
+	}
+
+	answer("bits", "8")
+	body = answer("harvard", "yale")
+	if !strings.Contains(body, "Score: 1 / 7") || strings.Count(body, "Correct!") != 1 || strings.Count(body, "Incorrect") != 1 {
+		t.Errorf("after two answers: %q", body)
+	}
+
+	// Answering again replaces the result
+	body = answer("harvard", " harvard ")
+	if !strings.Contains(body, "Score: 2 / 7") || strings.Contains(body, "Incorrect") {
+		t.Errorf("after answering again: %q", body)
+	}
+
+	// Another browser has its own score
+	other := page(http.Get(server.URL))
+	if !strings.Contains(other, "Score: 0 / 7") {
+		t.Error("a second player shares the first one's score")
+	}
+
+	// A cookie the player wrote themselves counts for nothing
+	forged, _ := http.NewRequest("GET", server.URL, nil)
+	forged.AddCookie(&http.Cookie{Name: SESSION_COOKIE, Value: `{"v":{"bits":"1","harvard":"1"}}`})
+	if body := page(http.DefaultClient.Do(forged)); !strings.Contains(body, "Score: 0 / 7") {
+		t.Error("forged cookie changed the score")
+	}
+
+	body = page(client.PostForm(server.URL+"/reset", nil))
+	if !strings.Contains(body, "Score: 0 / 7") {
+		t.Errorf("after reset: %q", body)
+	}
+
+	resp, err := client.PostForm(server.URL+"/answer", url.Values{"id": {"nope"}, "answer": {"x"}})
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown question: %v, %v", resp.Status, err)
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	a := newTestApp(t)
+	w := httptest.NewRecorder()
+	a.routes().ServeHTTP(w, httptest.NewRequest("GET", "/styles.css", nil))
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/css") {
+		t.Errorf("styles.css: %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,126 @@
+// api: the psets as JSON endpoints, so a web page can call the Go
+// solutions directly. GET / lists every route, OpenAPI style.
+//
+//	./api                            http://localhost:8000
+//	./api -port 3000 -origin http://localhost:5173
+//	./api --log-format json          every request as a line of JSON
+//
+//	curl localhost:8000/mario?h=3
+//	curl -d '{"number": "4003600000000014"}' localhost:8000/luhn
+
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"logging"
+	"profiling"
+	"webkit"
+)
+
+// route is one endpoint and how GET / describes it.
+type route struct {
+	method  string
+	path    string
+	summary string
+	params  []param // query parameters
+	request any     // example JSON body, nil for none
+	example any     // example response
+	handler http.HandlerFunc
+}
+
+// param is a query parameter of a GET route.
+type param struct {
+	name, description string
+	required          bool
+}
+
+func main() {
+	logging.Init()
+	defer profiling.Start()()
+	port := flag.Int("port", 8000, "port to listen on")
+	host := flag.String("host", "localhost", "interface to listen on (0.0.0.0 for every one)")
+	origin := flag.String("origin", "", "let pages from this origin call the API (CORS), * for any")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:              net.JoinHostPort(*host, strconv.Itoa(*port)),
+		Handler:           webkit.Chain(newMux(ROUTES), webkit.Logger(nil), webkit.CORS(*origin)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	slog.Info("CS50 API", "url", "http://"+server.Addr+"/", "origin", *origin)
+	err := server.ListenAndServe()
+	slog.Error("stopped", "err", err)
+	os.Exit(1)
+}
+
+// newMux serves routes, and their description at GET /.
+func newMux(routes []route) http.Handler {
+	router := webkit.NewRouter()
+	for _, rt := range routes {
+		router.HandleFunc(rt.method+" "+rt.path, rt.handler)
+	}
+	spec := openAPI(routes)
+	router.Get("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		webkit.JSON(w, http.StatusOK, spec)
+	})
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		webkit.Error(w, http.StatusNotFound, "no route "+r.Method+" "+r.URL.Path+", see GET /")
+	})
+	return router
+}
+
+// openAPI describes routes as an OpenAPI 3 document, enough for Swagger UI
+// or a person to find their way around.
+func openAPI(routes []route) map[string]any {
+	paths := map[string]any{}
+	for _, rt := range routes {
+		op := map[string]any{
+			"summary": rt.summary,
+			"responses": map[string]any{
+				"200": response("OK", rt.example),
+				"400": response("Bad input", webkit.ErrorBody{Error: "what was wrong"}),
+			},
+		}
+		if rt.request != nil {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"example": rt.request}},
+			}
+		}
+		var params []map[string]any
+		for _, p := range rt.params {
+			params = append(params, map[string]any{
+				"name": p.name, "in": "query", "required": p.required, "description": p.description,
+			})
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		methods, _ := paths[rt.path].(map[string]any)
+		if methods == nil {
+			methods = map[string]any{}
+			paths[rt.path] = methods
+		}
+		methods[strings.ToLower(rt.method)] = op
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": "CS50 with Go", "version": "1.0"},
+		"paths":   paths,
+	}
+}
+
+func response(description string, example any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     map[string]any{"application/json": map[string]any{"example": example}},
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"webkit"
+)
+
+// call sends method path with body (JSON, or "" for none) to the API.
+func call(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// compact re-encodes a JSON response without the indentation.
+func compact(t *testing.T, body string) string {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		t.Fatalf("%v: %s", err, body)
+	}
+	return marshal(t, v)
+}
+
+// Every example GET / shows is what the route really answers.
+func TestExamples(t *testing.T) {
+	h := newMux(ROUTES)
+	for _, rt := range ROUTES {
+		path, body := rt.path, ""
+		if rt.request != nil {
+			body = marshal(t, rt.request)
+		}
+		if rt.path == "/mario" {
+			path += "?h=3"
+		}
+		w := call(t, h, rt.method, path, body)
+		want := compact(t, marshal(t, rt.example))
+		if got := compact(t, w.Body.String()); w.Code != http.StatusOK || got != want {
+			t.Errorf("%s %s %s = %d %s, want %s", rt.method, path, body, w.Code, got, want)
+		}
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	tests := []struct {
+		method, path, body string
+		status             int
+		want               string
+	}{
+		{"POST", "/luhn", `{"number": "378282246310005"}`, 200, `"brand":"AMEX"`},
+		{"POST", "/luhn", `{"number": "4111 1111 1111 1113"}`, 200, `"valid":false`},
+		{"POST", "/luhn", `{"number": "4111x"}`, 400, `"error":"number must only contain digits, spaces and dashes"`},
+		{"POST", "/readability", `{"text": "There are more things in Heaven and Earth, Horatio, than are dreamt of in your philosophy."}`, 200, `"grade":"Grade 9"`},
+		{"POST", "/readability", `{"text": ""}`, 200, `"grade":"Before Grade 1"`},
+		{"POST", "/cipher/substitution", `{"key": "ABC", "plaintext": "x"}`, 400, `"error":"key must contain 26 characters"`},
+		{"POST", "/cipher/substitution", `{"key": "ZYXWVUTSRQPONMLKJIHGFEDCBA", "plaintext": "a!"}`, 200, `"ciphertext":"z!"`},
+		{"POST", "/cipher/caesar", `{"key": -1, "plaintext": "Aa"}`, 200, `"ciphertext":"Zz"`},
+		{"GET", "/mario?h=2&double=true", "", 200, `"rows":[" #  #","##  ##"]`},
+		{"GET", "/mario?h=8", "", 200, `"height":8`},
+		{"GET", "/mario?h=0", "", 400, `"error":"h must be a whole number from 1 to 8"`},
+		{"GET", "/mario?h=9", "", 400, `from 1 to 8`},
+		{"GET", "/mario?h=two", "", 400, `from 1 to 8`},
+		{"GET", "/mario", "", 400, `from 1 to 8`},
+		{"GET", "/mario?h=3&double=maybe", "", 400, `"error":"double must be true or false"`},
+		{"POST", "/scrabble", `{"words": ["hai!", "Oh,"]}`, 200, `"result":"Player 1 wins!"`},
+		{"POST", "/scrabble", `{"words": ["red", "wheelbarrow"]}`, 200, `"result":"Player 2 wins!","scores":[4,22]`},
+		{"POST", "/scrabble", `{"words": ["one"]}`, 400, `two words`},
+		{"POST", "/luhn", `{"number": 4003600000000014}`, 400, `invalid JSON`},
+		{"POST", "/luhn", `{"card": "4003600000000014"}`, 400, `unknown field`},
+		{"POST", "/luhn", ``, 400, `invalid JSON`},
+		{"POST", "/readability", `{"text": "` + strings.Repeat("a", webkit.MAX_BODY) + `"}`, 413, `body over`},
+		{"GET", "/nope", "", 404, `"error":"no route GET /nope, see GET /"`},
+	}
+	h := newMux(ROUTES)
+	for _, tt := range tests {
+		w := call(t, h, tt.method, tt.path, tt.body)
+		if got := compact(t, w.Body.String()); w.Code != tt.status || !strings.Contains(got, tt.want) {
+			t.Errorf("%s %s %.50s = %d %s, want %d with %s", tt.method, tt.path, tt.body, w.Code, got, tt.status, tt.want)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s: Content-Type %q", tt.method, tt.path, ct)
+		}
+	}
+}
+
+func TestListing(t *testing.T) {
+	w := call(t, newMux(ROUTES), "GET", "/", "")
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil || w.Code != http.StatusOK || spec.OpenAPI == "" {
+		t.Fatalf("GET / = %d %v\n%s", w.Code, err, w.Body)
+	}
+	for _, rt := range ROUTES {
+		if _, ok := spec.Paths[rt.path][strings.ToLower(rt.method)]; !ok {
+			t.Errorf("GET / doesn't list %s %s", rt.method, rt.path)
+		}
+	}
+	if !strings.Contains(w.Body.String(), `"in": "query"`) {
+		t.Error("GET / doesn't describe /mario's query parameters")
+	}
+}
+
+func TestCORS(t *testing.T) {
+	h := webkit.CORS("http://localhost:5173")(newMux(ROUTES))
+	w := call(t, h, "OPTIONS", "/luhn", "")
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" {
+		t.Errorf("preflight = %d %v", w.Code, w.Header())
+	}
+	w = call(t, h, "GET", "/mario?h=1", "")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Errorf("GET with CORS = %d %v", w.Code, w.Header())
+	}
+	if w := call(t, webkit.CORS("")(newMux(ROUTES)), "GET", "/mario?h=1", ""); w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("CORS header without -origin")
+	}
+}
@@ -0,0 +1,28 @@
+module api
+
+go 1.24.4
+
+require (
+	cipher v0.0.0
+	letters v0.0.0
+	logging v0.0.0
+	luhn v0.0.0
+	profiling v0.0.0
+	pyramid v0.0.0
+	readability v0.0.0
+	webkit v0.0.0
+)
+
+require runctx v0.0.0 // indirect
+
+replace (
+	cipher => ../../week2-Array/cipher
+	letters => ../../week2-Array/letters
+	logging => ../../logging
+	luhn => ../../week1-C/pset-w-go/luhn
+	profiling => ../../profiling
+	pyramid => ../../week1-C/pset-w-go/pyramid
+	readability => ../../week2-Array/readability
+	runctx => ../../runctx
+	webkit => ../webkit
+)
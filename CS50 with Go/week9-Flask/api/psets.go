@@ -0,0 +1,204 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"cipher"
+	"letters"
+	"luhn"
+	"pyramid"
+	"readability"
+
+	"webkit"
+)
+
+// ROUTES are the psets on offer, in the order GET / lists them.
+var ROUTES = []route{
+	{
+		method: "POST", path: "/luhn", summary: "credit: Luhn's checksum and the card's brand",
+		request: luhnRequest{"4003600000000014"},
+		example: luhnResponse{"4003600000000014", 20, true, "VISA"},
+		handler: luhnHandler,
+	},
+	{
+		method: "POST", path: "/readability", summary: "readability: the Coleman-Liau grade of some text",
+		request: readabilityRequest{"One fish. Two fish. Red fish. Blue fish."},
+		example: readabilityResponse{29, 8, 4, -9.29, "Before Grade 1"},
+		handler: readabilityHandler,
+	},
+	{
+		method: "POST", path: "/cipher/substitution", summary: "substitution: encipher with a 26-letter key",
+		request: substitutionRequest{"NQXPOMAFTRHLZGECYJIUWSKDVB", "Hello, world"},
+		example: cipherResponse{"Folle, kejlp"},
+		handler: substitutionHandler,
+	},
+	{
+		method: "POST", path: "/cipher/caesar", summary: "caesar: shift every letter by key (negative to decipher)",
+		request: caesarRequest{13, "hello, world"},
+		example: cipherResponse{"uryyb, jbeyq"},
+		handler: caesarHandler,
+	},
+	{
+		method: "GET", path: "/mario", summary: "mario: a pyramid of #s, one string per row",
+		params: []param{
+			{"h", "height, 1 to 8", true},
+			{"double", "true for mario-more's two pyramids", false},
+		},
+		example: marioResponse{3, []string{"  #", " ##", "###"}},
+		handler: marioHandler,
+	},
+	{
+		method: "POST", path: "/scrabble", summary: "scrabble: score two players' words",
+		request: scrabbleRequest{[]string{"Question?", "Question!"}},
+		example: scrabbleResponse{[]int{17, 17}, "Tie!"},
+		handler: scrabbleHandler,
+	},
+}
+
+type luhnRequest struct {
+	Number string `json:"number"`
+}
+
+type luhnResponse struct {
+	Number   string `json:"number"`
+	Checksum int    `json:"checksum"`
+	Valid    bool   `json:"valid"`
+	Brand    string `json:"brand"`
+}
+
+func luhnHandler(w http.ResponseWriter, r *http.Request) {
+	var req luhnRequest
+	if !webkit.ReadJSON(w, r, &req) {
+		return
+	}
+	card, err := luhn.Check(req.Number)
+	if err != nil {
+		webkit.Error(w, http.StatusBadRequest, "number must only contain digits, spaces and dashes")
+		return
+	}
+	webkit.JSON(w, http.StatusOK, luhnResponse{card.Number, card.Checksum, card.Valid, card.Brand})
+}
+
+type readabilityRequest struct {
+	Text string `json:"text"`
+}
+
+type readabilityResponse struct {
+	Letters   int     `json:"letters"`
+	Words     int     `json:"words"`
+	Sentences int     `json:"sentences"`
+	Index     float64 `json:"index"` // to 2 decimal places
+	Grade     string  `json:"grade"`
+}
+
+func readabilityHandler(w http.ResponseWriter, r *http.Request) {
+	var req readabilityRequest
+	if !webkit.ReadJSON(w, r, &req) {
+		return
+	}
+	c := readability.Count(req.Text)
+	index := c.Index()
+	rounded := math.Round(index*100) / 100
+	webkit.JSON(w, http.StatusOK, readabilityResponse{c.Letters, c.Words, c.Sentences, rounded, readability.Grade(index)})
+}
+
+type substitutionRequest struct {
+	Key       string `json:"key"`
+	Plaintext string `json:"plaintext"`
+}
+
+type caesarRequest struct {
+	Key       int    `json:"key"`
+	Plaintext string `json:"plaintext"`
+}
+
+type cipherResponse struct {
+	Ciphertext string `json:"ciphertext"`
+}
+
+func substitutionHandler(w http.ResponseWriter, r *http.Request) {
+	var req substitutionRequest
+	if !webkit.ReadJSON(w, r, &req) {
+		return
+	}
+	ciphertext, err := cipher.Substitute(req.Key, req.Plaintext)
+	if err != nil {
+		webkit.Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	webkit.JSON(w, http.StatusOK, cipherResponse{ciphertext})
+}
+
+func caesarHandler(w http.ResponseWriter, r *http.Request) {
+	var req caesarRequest
+	if !webkit.ReadJSON(w, r, &req) {
+		return
+	}
+	webkit.JSON(w, http.StatusOK, cipherResponse{cipher.Caesar(req.Key, req.Plaintext)})
+}
+
+type marioResponse struct {
+	Height int      `json:"height"`
+	Rows   []string `json:"rows"`
+}
+
+func marioHandler(w http.ResponseWriter, r *http.Request) {
+	h, err := strconv.Atoi(r.URL.Query().Get("h"))
+	if err != nil || h < 1 || h > pyramid.MAX_HEIGHT {
+		webkit.Error(w, http.StatusBadRequest, "h must be a whole number from 1 to "+strconv.Itoa(pyramid.MAX_HEIGHT))
+		return
+	}
+	double, err := strconv.ParseBool(r.URL.Query().Get("double"))
+	if err != nil && r.URL.Query().Has("double") {
+		webkit.Error(w, http.StatusBadRequest, "double must be true or false")
+		return
+	}
+	rows := pyramid.Left(h)
+	if double {
+		rows = pyramid.Double(h)
+	}
+	webkit.JSON(w, http.StatusOK, marioResponse{h, rows})
+}
+
+type scrabbleRequest struct {
+	Words []string `json:"words"`
+}
+
+type scrabbleResponse struct {
+	Scores []int  `json:"scores"`
+	Result string `json:"result"`
+}
+
+func scrabbleHandler(w http.ResponseWriter, r *http.Request) {
+	var req scrabbleRequest
+	if !webkit.ReadJSON(w, r, &req) {
+		return
+	}
+	if len(req.Words) != 2 {
+		webkit.Error(w, http.StatusBadRequest, "words must hold two words, one per player")
+		return
+	}
+	webkit.JSON(w, http.StatusOK, scrabble(req.Words))
+}
+
+// scrabble scores each player's word and says who won, like the lab.
+func scrabble(words []string) scrabbleResponse {
+	res := scrabbleResponse{Result: "Tie!"}
+	best, winner := -1, -1
+	for i, word := range words {
+		score := letters.Score(word)
+		res.Scores = append(res.Scores, score)
+		switch {
+		case score > best:
+			best, winner = score, i
+		case score == best:
+			winner = -1
+		}
+	}
+	if winner >= 0 {
+		res.Result = "Player " + strconv.Itoa(winner+1) + " wins!"
+	}
+	return res
+}
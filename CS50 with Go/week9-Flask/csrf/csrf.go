@@ -0,0 +1,191 @@
+// Package csrf stops other sites from submitting forms on a logged-in
+// user's behalf. Every form carries a token that only this server can make:
+// signed, tied to the browser it was made for, good for TTL, and good for
+// one submission only, so a token that leaks (or a form posted twice) can't
+// be replayed.
+package csrf
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	FIELD  = "csrf_token"   // form field the token is posted in
+	HEADER = "X-CSRF-Token" // or this header, for fetch()
+	COOKIE = "csrf_browser" // random ID tying tokens to one browser
+)
+
+// DEFAULT_TTL is how long a token lasts when Protector.TTL isn't set: long
+// enough to fill in a form after lunch.
+const DEFAULT_TTL = 2 * time.Hour
+
+// Why a request was refused.
+var (
+	ErrMissing  = errors.New("csrf: missing token")
+	ErrInvalid  = errors.New("csrf: invalid token")
+	ErrExpired  = errors.New("csrf: expired token")
+	ErrReplayed = errors.New("csrf: token already used")
+)
+
+// Protector makes and checks tokens.
+type Protector struct {
+	TTL time.Duration
+
+	// Failure answers refused requests; Error(r) says why. The default
+	// is a plain 403.
+	Failure http.Handler
+
+	key []byte
+	now func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time // token -> when it would have expired
+}
+
+// New returns a Protector signing tokens with key.
+func New(key []byte) *Protector {
+	return &Protector{key: key, now: time.Now, used: make(map[string]time.Time)}
+}
+
+type contextKey int
+
+const (
+	browserKey contextKey = iota
+	protectorKey
+	errorKey
+)
+
+// Protect makes tokens available to next through Token and refuses POST,
+// PUT, PATCH and DELETE requests without a valid one.
+func (p *Protector) Protect(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		browser := ""
+		if cookie, err := r.Cookie(COOKIE); err == nil && len(cookie.Value) == 32 {
+			browser = cookie.Value
+		} else {
+			b := make([]byte, 16)
+			rand.Read(b)
+			browser = hex.EncodeToString(b)
+			http.SetCookie(w, &http.Cookie{Name: COOKIE, Value: browser, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		}
+		ctx := context.WithValue(r.Context(), browserKey, browser)
+		ctx = context.WithValue(ctx, protectorKey, p)
+		r = r.WithContext(ctx)
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		default:
+			if err := p.verify(r, browser); err != nil {
+				failure := p.Failure
+				if failure == nil {
+					failure = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						http.Error(w, "Forbidden: "+Error(r).Error(), http.StatusForbidden)
+					})
+				}
+				failure.ServeHTTP(w, r.WithContext(context.WithValue(ctx, errorKey, err)))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Token returns a fresh token for a form on the page r is rendering, or ""
+// outside Protect.
+func Token(r *http.Request) string {
+	p, ok := r.Context().Value(protectorKey).(*Protector)
+	if !ok {
+		return ""
+	}
+	browser, _ := r.Context().Value(browserKey).(string)
+	return p.token(browser)
+}
+
+// TemplateField returns Token(r) as a hidden form input, for a page's data:
+// {{.CSRF}} inside the <form>.
+func TemplateField(r *http.Request) template.HTML {
+	return template.HTML(`<input name="` + FIELD + `" type="hidden" value="` + Token(r) + `">`)
+}
+
+// Error returns why Protect refused r, inside a Failure handler.
+func Error(r *http.Request) error {
+	err, _ := r.Context().Value(errorKey).(error)
+	return err
+}
+
+// A token is base64(nonce, expiry, HMAC-SHA256 of browser ID, nonce and
+// expiry).
+const (
+	NONCE_SIZE = 16
+	TOKEN_SIZE = NONCE_SIZE + 8 + sha256.Size
+)
+
+func (p *Protector) token(browser string) string {
+	raw := make([]byte, NONCE_SIZE+8, TOKEN_SIZE)
+	rand.Read(raw[:NONCE_SIZE])
+	binary.BigEndian.PutUint64(raw[NONCE_SIZE:], uint64(p.now().Add(p.ttl()).Unix()))
+	raw = append(raw, p.sign(browser, raw)...)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (p *Protector) verify(r *http.Request, browser string) error {
+	token := r.Header.Get(HEADER)
+	if token == "" {
+		token = r.PostFormValue(FIELD)
+	}
+	if token == "" {
+		return ErrMissing
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != TOKEN_SIZE {
+		return ErrInvalid
+	}
+	body, mac := raw[:NONCE_SIZE+8], raw[NONCE_SIZE+8:]
+	if !hmac.Equal(mac, p.sign(browser, body)) {
+		return ErrInvalid
+	}
+	expires := time.Unix(int64(binary.BigEndian.Uint64(body[NONCE_SIZE:])), 0)
+	now := p.now()
+	if !now.Before(expires) {
+		return ErrExpired
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, used := p.used[token]; used {
+		return ErrReplayed
+	}
+	// Forget tokens that have expired anyway, so the map doesn't grow
+	for t, e := range p.used {
+		if !now.Before(e) {
+			delete(p.used, t)
+		}
+	}
+	p.used[token] = expires
+	return nil
+}
+
+func (p *Protector) sign(browser string, body []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(browser))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func (p *Protector) ttl() time.Duration {
+	if p.TTL == 0 {
+		return DEFAULT_TTL
+	}
+	return p.TTL
+}
@@ -0,0 +1,151 @@
+package csrf
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// browser is one visitor: its csrf_browser cookie and the last page's token.
+type browser struct {
+	cookies []*http.Cookie
+}
+
+// page GETs a form page through h and returns the token on it.
+func (b *browser) page(t *testing.T, h http.Handler) string {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/form", nil)
+	for _, c := range b.cookies {
+		r.AddCookie(c)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if cookies := w.Result().Cookies(); len(cookies) > 0 {
+		b.cookies = cookies
+	}
+	return w.Body.String()
+}
+
+// submit POSTs token through h and returns the status.
+func (b *browser) submit(h http.Handler, token string) int {
+	form := url.Values{"shares": {"1"}}
+	if token != "" {
+		form.Set(FIELD, token)
+	}
+	r := httptest.NewRequest("POST", "/form", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, c := range b.cookies {
+		r.AddCookie(c)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w.Code
+}
+
+// form is a handler that shows its token on GET and accepts any POST that
+// gets past Protect.
+func form(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		w.Write([]byte(Token(r)))
+	}
+}
+
+func TestProtect(t *testing.T) {
+	p := New([]byte("0123456789abcdef0123456789abcdef"))
+	var refused error
+	p.Failure = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refused = Error(r)
+		w.WriteHeader(http.StatusForbidden)
+	})
+	h := p.Protect(http.HandlerFunc(form))
+	alice, mallory := &browser{}, &browser{}
+
+	token := alice.page(t, h)
+	if token == "" || len(alice.cookies) != 1 {
+		t.Fatalf("GET gave token %q and cookies %v", token, alice.cookies)
+	}
+	tests := []struct {
+		name   string
+		who    *browser
+		token  string
+		status int
+		err    error
+	}{
+		{"missing", alice, "", http.StatusForbidden, ErrMissing},
+		{"garbage", alice, "not-a-token", http.StatusForbidden, ErrInvalid},
+		{"another browser", mallory, token, http.StatusForbidden, ErrInvalid},
+		{"valid", alice, token, http.StatusOK, nil},
+		{"replayed", alice, token, http.StatusForbidden, ErrReplayed},
+	}
+	for _, tt := range tests {
+		refused = nil
+		if status := tt.who.submit(h, tt.token); status != tt.status || !errors.Is(refused, tt.err) {
+			t.Errorf("%s: status %d (%v), want %d (%v)", tt.name, status, refused, tt.status, tt.err)
+		}
+	}
+
+	// Every page gets its own token, so two tabs both work
+	tab1, tab2 := alice.page(t, h), alice.page(t, h)
+	if tab1 == tab2 {
+		t.Error("two pages got the same token")
+	}
+	if alice.submit(h, tab2) != http.StatusOK || alice.submit(h, tab1) != http.StatusOK {
+		t.Error("tokens from two tabs not both accepted")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	p := New([]byte("0123456789abcdef0123456789abcdef"))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+	h := p.Protect(http.HandlerFunc(form))
+	b := &browser{}
+
+	old := b.page(t, h)
+	used := b.page(t, h)
+	if b.submit(h, used) != http.StatusOK {
+		t.Fatal("fresh token refused")
+	}
+	now = now.Add(DEFAULT_TTL)
+	if status := b.submit(h, old); status != http.StatusForbidden {
+		t.Errorf("expired token: status %d", status)
+	}
+
+	// Expired tokens are forgotten, they're refused as expired anyway
+	b.submit(h, b.page(t, h))
+	if _, ok := p.used[used]; ok || len(p.used) != 1 {
+		t.Errorf("used tokens = %d, want only the latest", len(p.used))
+	}
+}
+
+func TestSafeMethods(t *testing.T) {
+	h := New([]byte("key")).Protect(http.HandlerFunc(form))
+	for _, method := range []string{"GET", "HEAD", "OPTIONS"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, "/", nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s without token: %d", method, w.Code)
+		}
+	}
+
+	// fetch() can send the token in a header instead
+	b := &browser{}
+	token := b.page(t, h)
+	r := httptest.NewRequest("DELETE", "/form", nil)
+	r.Header.Set(HEADER, token)
+	r.AddCookie(b.cookies[0])
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("DELETE with %s header: %d %s", HEADER, w.Code, w.Body)
+	}
+
+	// Outside Protect there's no token to give
+	if got := Token(httptest.NewRequest("GET", "/", nil)); got != "" {
+		t.Errorf("Token outside Protect = %q", got)
+	}
+}
@@ -0,0 +1,3 @@
+module csrf
+
+go 1.24.4
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"cs50"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+
+	"sessions"
+)
+
+const ADMIN_USAGE = `Usage:
+  finance admin init [-db finance.db]                     create the tables
+  finance admin seed [-db finance.db] [-password demo]    add demo users and trades
+  finance admin reset-cash USERNAME [-cash 10000.00] [-db finance.db]
+  finance admin check [-db finance.db]                    look for inconsistent accounts`
+
+// STARTING_CASH is what every account starts with, the schema's default.
+const STARTING_CASH = 1000000
+
+// SEED is the demo data `admin seed` adds: each user's trades, oldest
+// first, at fixed prices so the demo is the same every time. Their cash is
+// what's left of STARTING_CASH after trading.
+var SEED = []struct {
+	username string
+	trades   []accountTransaction
+}{
+	{"alice", []accountTransaction{
+		{"NFLX", 10, 50000, "2026-01-05 14:30:00"},
+		{"AAPL", 12, 17525, "2026-01-06 15:02:11"},
+		{"NFLX", -4, 52310, "2026-02-10 16:45:00"},
+	}},
+	{"bob", []accountTransaction{
+		{"TSLA", 6, 24870, "2026-01-12 09:31:45"},
+		{"MSFT", 8, 41599, "2026-01-20 10:00:00"},
+		{"TSLA", -6, 26105, "2026-03-02 11:15:30"},
+	}},
+	{"carol", nil},
+}
+
+// adminCommand runs `finance admin SUBCOMMAND` and returns the exit code.
+func adminCommand(args []string, w io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(w, ADMIN_USAGE)
+		return 1
+	}
+	flags := flag.NewFlagSet("admin "+args[0], flag.ContinueOnError)
+	flags.SetOutput(w)
+	dbPath := flags.String("db", "finance.db", "SQLite database (created if missing)")
+	password := flags.String("password", "demo", "seed: every demo user's password")
+	cash := flags.String("cash", "10000.00", "reset-cash: the user's new cash, in dollars")
+	positional, err := parseInterspersed(flags, args[1:])
+	if err != nil {
+		return 1
+	}
+	wantArgs := map[string]int{"init": 0, "seed": 0, "reset-cash": 1, "check": 0}
+	if n, ok := wantArgs[args[0]]; !ok || len(positional) != n {
+		fmt.Fprintln(w, ADMIN_USAGE)
+		return 1
+	}
+
+	db, err := openDB(*dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "init":
+		fmt.Fprintf(w, "Tables ready in %s.\n", *dbPath)
+	case "seed":
+		if err := seed(db, *password); err != nil {
+			fmt.Fprintln(w, err)
+			return 1
+		}
+		for _, user := range SEED {
+			fmt.Fprintf(w, "Added %s (password %q) with %d transactions.\n", user.username, *password, len(user.trades))
+		}
+	case "reset-cash":
+		amount, err := cents(*cash)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 1
+		}
+		if err := resetCash(db, positional[0], amount); err != nil {
+			fmt.Fprintln(w, err)
+			return 1
+		}
+		fmt.Fprintf(w, "%s now has %s.\n", positional[0], usd(amount))
+	case "check":
+		report, err := check(db)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 2
+		}
+		for _, warning := range report.warnings {
+			fmt.Fprintln(w, "warning:", warning)
+		}
+		for _, problem := range report.problems {
+			fmt.Fprintln(w, "problem:", problem)
+		}
+		fmt.Fprintf(w, "%d users, %d transactions, %d problems.\n", report.users, report.transactions, len(report.problems))
+		if len(report.problems) > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// seed adds the SEED users. It adds all of them or, when one already
+// exists, none.
+func seed(db *cs50.SQL, password string) error {
+	for _, user := range SEED {
+		if _, err := lookupUser(db, user.username); !errors.Is(err, errNoUser) {
+			return fmt.Errorf("%s is already in the database; seed a fresh one", user.username)
+		}
+	}
+	for _, user := range SEED {
+		id, err := sessions.Register(db, user.username, password)
+		if err != nil {
+			return err
+		}
+		acct := account{Version: EXPORT_VERSION, Cash: STARTING_CASH, Transactions: user.trades}
+		for _, t := range user.trades {
+			acct.Cash -= t.Shares * t.Price
+		}
+		acct.Holdings = holdings(acct.Transactions)
+		if err := acct.check(); err != nil {
+			return fmt.Errorf("SEED %s: %w", user.username, err)
+		}
+		if err := importAccount(db, id, acct); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetCash gives a user exactly amount cents of cash, keeping their
+// stocks.
+func resetCash(db *cs50.SQL, username string, amount int) error {
+	id, err := lookupUser(db, username)
+	if err != nil {
+		return err
+	}
+	_, err = db.Execute("UPDATE users SET cash = ? WHERE id = ?", amount, id)
+	return err
+}
+
+// checkReport is what check found. Problems are things trading can't
+// produce; warnings are cash that doesn't match the trades, which
+// reset-cash and hand edits do on purpose.
+type checkReport struct {
+	users, transactions int
+	problems, warnings  []string
+}
+
+// check replays every user's transactions, like a bank reconciling an
+// account: no stock sold before it was bought, no cash below zero, no
+// prices or share counts trading can't make, no transactions without a
+// user, and cash equal to STARTING_CASH less what the trades cost.
+func check(db *cs50.SQL) (checkReport, error) {
+	var report checkReport
+	users, err := db.Query("SELECT id, username, cash FROM users ORDER BY id")
+	if err != nil {
+		return report, err
+	}
+	report.users = len(users)
+	var ids []int64
+	for _, user := range users {
+		id, name, cash := user["id"].(int64), user["username"].(string), int(user["cash"].(int64))
+		ids = append(ids, id)
+		if cash < 0 {
+			report.problems = append(report.problems, fmt.Sprintf("%s has %s of cash", name, usd(cash)))
+		}
+
+		rows, err := db.Query("SELECT id, symbol, shares, price FROM transactions WHERE user_id = ? ORDER BY id", id)
+		if err != nil {
+			return report, err
+		}
+		ledger := STARTING_CASH
+		owned := map[string]int{}
+		for _, row := range rows {
+			tid, symbol := row["id"].(int64), row["symbol"].(string)
+			shares, price := int(row["shares"].(int64)), int(row["price"].(int64))
+			if shares == 0 || price <= 0 {
+				report.problems = append(report.problems, fmt.Sprintf("%s's transaction %d is %d %s at %s", name, tid, shares, symbol, usd(price)))
+			}
+			owned[symbol] += shares
+			if owned[symbol] < 0 {
+				report.problems = append(report.problems, fmt.Sprintf("%s's transaction %d sells %s they don't own", name, tid, symbol))
+				owned[symbol] = 0
+			}
+			ledger -= shares * price
+		}
+		if cash != ledger {
+			report.warnings = append(report.warnings, fmt.Sprintf("%s has %s of cash, the trades leave %s (off by %s)", name, usd(cash), usd(ledger), usd(cash-ledger)))
+		}
+	}
+
+	all, err := db.Query("SELECT id, user_id FROM transactions")
+	if err != nil {
+		return report, err
+	}
+	report.transactions = len(all)
+	for _, row := range all {
+		if !slices.Contains(ids, row["user_id"].(int64)) {
+			report.problems = append(report.problems, fmt.Sprintf("transaction %d belongs to user %d, who doesn't exist", row["id"].(int64), row["user_id"].(int64)))
+		}
+	}
+	return report, nil
+}
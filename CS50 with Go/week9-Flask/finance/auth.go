@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"sessions"
+	"webkit"
+)
+
+// SESSION_COOKIE names the cookie that remembers who is logged in.
+const SESSION_COOKIE = "finance_session"
+
+// loginRequired sends visitors who aren't logged in to /login, like the
+// pset's @login_required decorator. Handlers behind it get the user's ID
+// from sessions.UserID.
+func (a *app) loginRequired(next http.Handler) http.Handler {
+	return a.store.LoginRequired("/login", next)
+}
+
+// register creates an account and logs it in.
+func (a *app) register(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		a.render(w, r, "register.html", nil)
+		return
+	}
+
+	username := strings.TrimSpace(r.PostFormValue("username"))
+	password := r.PostFormValue("password")
+	switch {
+	case username == "":
+		a.apology(w, r, "must provide username", http.StatusBadRequest)
+		return
+	case password == "":
+		a.apology(w, r, "must provide password", http.StatusBadRequest)
+		return
+	case password != r.PostFormValue("confirmation"):
+		a.apology(w, r, "passwords don't match", http.StatusBadRequest)
+		return
+	}
+
+	id, err := sessions.Register(a.db, username, password)
+	switch {
+	case errors.Is(err, sessions.ErrUsernameTaken):
+		a.apology(w, r, "username taken", http.StatusBadRequest)
+		return
+	case errors.Is(err, sessions.ErrPasswordTooLong):
+		a.apology(w, r, "password too long", http.StatusBadRequest)
+		return
+	case err != nil:
+		a.serverError(w, r, err)
+		return
+	}
+	a.store.Login(w, id)
+	a.ui.Flash(w, r, "Registered!")
+	webkit.Redirect(w, r, "/")
+}
+
+// login checks a username and password.
+func (a *app) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		a.render(w, r, "login.html", nil)
+		return
+	}
+
+	username := strings.TrimSpace(r.PostFormValue("username"))
+	password := r.PostFormValue("password")
+	if username == "" {
+		a.apology(w, r, "must provide username", http.StatusForbidden)
+		return
+	}
+	if password == "" {
+		a.apology(w, r, "must provide password", http.StatusForbidden)
+		return
+	}
+
+	id, err := sessions.Authenticate(a.db, username, password)
+	switch {
+	case errors.Is(err, sessions.ErrInvalidLogin):
+		// Forget any user who was logged in
+		a.store.Logout(w)
+		a.apology(w, r, "invalid username and/or password", http.StatusForbidden)
+		return
+	case err != nil:
+		a.serverError(w, r, err)
+		return
+	}
+	a.store.Login(w, id)
+	webkit.Redirect(w, r, "/")
+}
+
+// logout ends the session.
+func (a *app) logout(w http.ResponseWriter, r *http.Request) {
+	a.store.Logout(w)
+	webkit.Redirect(w, r, "/")
+}
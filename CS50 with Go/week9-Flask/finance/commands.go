@@ -0,0 +1,171 @@
+package main
+
+import (
+	"cs50"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sessions"
+)
+
+const USAGE = `Usage:
+  finance [-db finance.db] [-port 8080] [-quotes cs50|iex|mock]
+  finance export USERNAME [-db finance.db] [-format json|csv]
+  finance import FILE [-db finance.db] [-user USERNAME] [-password PASSWORD]
+  finance admin init|seed|reset-cash|check ...`
+
+// runCommand runs the subcommand in args[0] and returns the exit code.
+// An export goes to stdout and its errors to stderr, so they don't end up
+// in the file.
+func runCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	switch args[0] {
+	case "export":
+		return exportCommand(args[1:], stdout, stderr)
+	case "import":
+		return importCommand(args[1:], stdin, stdout)
+	case "admin":
+		return adminCommand(args[1:], stdout)
+	}
+	fmt.Fprintln(stderr, USAGE)
+	return 1
+}
+
+// exportCommand writes a user's account to out, like /export.
+func exportCommand(args []string, out, w io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(w)
+	dbPath := flags.String("db", "finance.db", "SQLite database")
+	format := flags.String("format", "json", "json or csv")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 || (*format != "json" && *format != "csv") {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+
+	db, err := openDB(*dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+	id, err := lookupUser(db, positional[0])
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+	acct, err := exportAccount(db, id)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	if err := writeAccount(out, acct, *format); err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	return 0
+}
+
+// importCommand loads an export into a database, into the account named in
+// the file unless -user says otherwise. That account must have no
+// transactions yet; with -password it's created when it doesn't exist,
+// which is how an export moves to a fresh database.
+func importCommand(args []string, stdin io.Reader, w io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(w)
+	dbPath := flags.String("db", "finance.db", "SQLite database (created if missing)")
+	format := flags.String("format", "", "json or csv (default: from the file's extension)")
+	username := flags.String("user", "", "account to import into (default: the one in the file)")
+	password := flags.String("password", "", "create the account with this password if it doesn't exist")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 1
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(w, USAGE)
+		return 1
+	}
+
+	// "-" reads the export from standard input
+	path := positional[0]
+	in := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(w, err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+	if *format == "" {
+		*format = formatOf(path)
+	}
+	acct, err := readAccount(in, *format)
+	if err != nil {
+		fmt.Fprintf(w, "%s: %v\n", path, err)
+		return 1
+	}
+	if *username == "" {
+		*username = acct.Username
+	}
+
+	db, err := openDB(*dbPath)
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 2
+	}
+	defer db.Close()
+	id, err := lookupUser(db, *username)
+	switch {
+	case errors.Is(err, errNoUser) && *password != "":
+		id, err = sessions.Register(db, *username, *password)
+	case errors.Is(err, errNoUser):
+		err = fmt.Errorf("%w (give -password to create it)", err)
+	}
+	if err != nil {
+		fmt.Fprintln(w, err)
+		return 1
+	}
+	if err := importAccount(db, id, acct); err != nil {
+		fmt.Fprintf(w, "%s: %v\n", *username, err)
+		return 1
+	}
+	fmt.Fprintf(w, "Imported %d transactions into %s's account.\n", len(acct.Transactions), *username)
+	return 0
+}
+
+// lookupUser returns the ID of the account called username.
+func lookupUser(db *cs50.SQL, username string) (int64, error) {
+	rows, err := db.Query("SELECT id FROM users WHERE username = ?", username)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("%w: %s", errNoUser, username)
+	}
+	return rows[0]["id"].(int64), nil
+}
+
+// parseInterspersed lets flags come after the username or file too
+// ("export alice -format csv"); the flag package stops at the first
+// argument that isn't a flag.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
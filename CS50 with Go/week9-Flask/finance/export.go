@@ -0,0 +1,353 @@
+package main
+
+import (
+	"cs50"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"csrf"
+	"sessions"
+	"webkit"
+)
+
+// EXPORT_VERSION is the format of the exports this finance writes. Older
+// versions still import; a newer one is refused instead of half read.
+const EXPORT_VERSION = 1
+
+// TIME_LAYOUT is how SQLite's CURRENT_TIMESTAMP writes a transaction's time.
+const TIME_LAYOUT = "2006-01-02 15:04:05"
+
+// MAX_IMPORT is the biggest upload /import accepts.
+const MAX_IMPORT = 10 << 20
+
+// CSV_HEADER is the first row of a CSV export. Every row carries the
+// version and username, so a row copied out in a spreadsheet still makes
+// sense; record says whether it's the cash, a holding or a transaction.
+var CSV_HEADER = []string{"version", "username", "record", "symbol", "shares", "cents", "time"}
+
+var (
+	ErrExportVersion = errors.New("export is from a newer finance")
+	ErrNotEmpty      = errors.New("account already has transactions")
+
+	errNoUser = errors.New("no such user")
+)
+
+// account is everything one user has: what /export sends and /import
+// takes. Holdings follow from the transactions; they're there for people
+// reading the file, and imports check that they add up.
+type account struct {
+	Version      int                  `json:"version"`
+	Username     string               `json:"username"`
+	Exported     string               `json:"exported"`
+	Cash         int                  `json:"cash_cents"`
+	Holdings     []accountHolding     `json:"holdings"`
+	Transactions []accountTransaction `json:"transactions"`
+}
+
+type accountHolding struct {
+	Symbol string `json:"symbol"`
+	Shares int    `json:"shares"`
+}
+
+// accountTransaction is one buy or sell, oldest first.
+type accountTransaction struct {
+	Symbol string `json:"symbol"`
+	Shares int    `json:"shares"` // negative for a sale
+	Price  int    `json:"price_cents"`
+	Time   string `json:"time"`
+}
+
+// exportAccount reads user id's cash and transactions.
+func exportAccount(db *cs50.SQL, id int64) (account, error) {
+	users, err := db.Query("SELECT username, cash FROM users WHERE id = ?", id)
+	if err != nil {
+		return account{}, err
+	}
+	if len(users) != 1 {
+		return account{}, errNoUser
+	}
+	a := account{
+		Version:  EXPORT_VERSION,
+		Username: users[0]["username"].(string),
+		Exported: time.Now().UTC().Format(TIME_LAYOUT),
+		Cash:     int(users[0]["cash"].(int64)),
+	}
+	rows, err := db.Query("SELECT symbol, shares, price, time FROM transactions WHERE user_id = ? ORDER BY id", id)
+	if err != nil {
+		return account{}, err
+	}
+	for _, row := range rows {
+		a.Transactions = append(a.Transactions, accountTransaction{
+			Symbol: row["symbol"].(string),
+			Shares: int(row["shares"].(int64)),
+			Price:  int(row["price"].(int64)),
+			Time:   row["time"].(string),
+		})
+	}
+	a.Holdings = holdings(a.Transactions)
+	return a, nil
+}
+
+// importAccount gives user id the cash and transactions of a. The account
+// must have no transactions of its own yet, so an import can't be mixed
+// into a real history or applied twice.
+func importAccount(db *cs50.SQL, id int64, a account) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var n int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM transactions WHERE user_id = ?", id).Scan(&n); err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrNotEmpty
+	}
+	if _, err := tx.Exec("UPDATE users SET cash = ? WHERE id = ?", a.Cash, id); err != nil {
+		return err
+	}
+	for _, t := range a.Transactions {
+		if _, err := tx.Exec("INSERT INTO transactions (user_id, symbol, shares, price, time) VALUES(?, ?, ?, ?, ?)", id, t.Symbol, t.Shares, t.Price, t.Time); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// holdings adds up transactions into the shares owned of each stock, in
+// symbol order, leaving out the ones sold off.
+func holdings(transactions []accountTransaction) []accountHolding {
+	owned := map[string]int{}
+	for _, t := range transactions {
+		owned[t.Symbol] += t.Shares
+	}
+	var result []accountHolding
+	for symbol, shares := range owned {
+		if shares > 0 {
+			result = append(result, accountHolding{symbol, shares})
+		}
+	}
+	sortHoldings(result)
+	return result
+}
+
+func sortHoldings(h []accountHolding) {
+	slices.SortFunc(h, func(a, b accountHolding) int { return strings.Compare(a.Symbol, b.Symbol) })
+}
+
+// check refuses an export this finance can't import faithfully: an
+// unknown version, or numbers that couldn't have come from trading.
+func (a account) check() error {
+	switch {
+	case a.Version < 1:
+		return errors.New("not a finance export: no version")
+	case a.Version > EXPORT_VERSION:
+		return fmt.Errorf("%w: version %d, this one reads up to %d", ErrExportVersion, a.Version, EXPORT_VERSION)
+	case a.Cash < 0:
+		return errors.New("cash can't be negative")
+	}
+	owned := map[string]int{}
+	for i, t := range a.Transactions {
+		switch {
+		case t.Symbol == "" || t.Symbol != strings.ToUpper(t.Symbol):
+			return fmt.Errorf("transaction %d: symbol %q isn't an uppercase ticker", i+1, t.Symbol)
+		case t.Shares == 0 || t.Shares > MAX_SHARES || t.Shares < -MAX_SHARES:
+			return fmt.Errorf("transaction %d: %d shares", i+1, t.Shares)
+		case t.Price <= 0:
+			return fmt.Errorf("transaction %d: price must be positive", i+1)
+		}
+		if _, err := time.Parse(TIME_LAYOUT, t.Time); err != nil {
+			return fmt.Errorf("transaction %d: time %q isn't like %q", i+1, t.Time, TIME_LAYOUT)
+		}
+		owned[t.Symbol] += t.Shares
+		if owned[t.Symbol] < 0 {
+			return fmt.Errorf("transaction %d sells %s that wasn't bought", i+1, t.Symbol)
+		}
+	}
+	got := slices.Clone(a.Holdings)
+	sortHoldings(got)
+	if !slices.Equal(got, holdings(a.Transactions)) {
+		return errors.New("holdings don't add up to the transactions")
+	}
+	return nil
+}
+
+// formatOf picks json or csv from a file name, json when it can't tell.
+func formatOf(name string) string {
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		return "csv"
+	}
+	return "json"
+}
+
+// writeAccount writes a as JSON or CSV.
+func writeAccount(w io.Writer, a account, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(a)
+	case "csv":
+		out := csv.NewWriter(w)
+		version := strconv.Itoa(a.Version)
+		out.Write(CSV_HEADER)
+		out.Write([]string{version, a.Username, "cash", "", "", strconv.Itoa(a.Cash), a.Exported})
+		for _, h := range a.Holdings {
+			out.Write([]string{version, a.Username, "holding", h.Symbol, strconv.Itoa(h.Shares), "", ""})
+		}
+		for _, t := range a.Transactions {
+			out.Write([]string{version, a.Username, "transaction", t.Symbol, strconv.Itoa(t.Shares), strconv.Itoa(t.Price), t.Time})
+		}
+		out.Flush()
+		return out.Error()
+	}
+	return fmt.Errorf("unknown format %q (want json or csv)", format)
+}
+
+// readAccount reads an export written by writeAccount and checks it.
+func readAccount(r io.Reader, format string) (account, error) {
+	var a account
+	var err error
+	switch format {
+	case "json":
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&a)
+	case "csv":
+		a, err = readCSV(r)
+	default:
+		return account{}, fmt.Errorf("unknown format %q (want json or csv)", format)
+	}
+	if err != nil {
+		return account{}, err
+	}
+	return a, a.check()
+}
+
+func readCSV(r io.Reader) (account, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return account{}, err
+	}
+	if len(rows) == 0 || strings.Join(rows[0], ",") != strings.Join(CSV_HEADER, ",") {
+		return account{}, fmt.Errorf("not a finance export: the header should be %s", strings.Join(CSV_HEADER, ","))
+	}
+	var a account
+	sawCash := false
+	for i, row := range rows[1:] {
+		line := i + 2
+		version, err := strconv.Atoi(row[0])
+		if err != nil {
+			return account{}, fmt.Errorf("line %d: version %q isn't a number", line, row[0])
+		}
+		if i == 0 {
+			a.Version, a.Username = version, row[1]
+		} else if version != a.Version || row[1] != a.Username {
+			return account{}, fmt.Errorf("line %d: rows from two exports", line)
+		}
+		number := func(field string) (int, error) {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %q isn't a whole number", line, field)
+			}
+			return n, nil
+		}
+		switch row[2] {
+		case "cash":
+			if a.Cash, err = number(row[5]); err != nil {
+				return account{}, err
+			}
+			a.Exported, sawCash = row[6], true
+		case "holding":
+			shares, err := number(row[4])
+			if err != nil {
+				return account{}, err
+			}
+			a.Holdings = append(a.Holdings, accountHolding{row[3], shares})
+		case "transaction":
+			shares, err := number(row[4])
+			if err != nil {
+				return account{}, err
+			}
+			price, err := number(row[5])
+			if err != nil {
+				return account{}, err
+			}
+			a.Transactions = append(a.Transactions, accountTransaction{row[3], shares, price, row[6]})
+		default:
+			return account{}, fmt.Errorf("line %d: unknown record %q", line, row[2])
+		}
+	}
+	if !sawCash {
+		return account{}, errors.New("no cash row")
+	}
+	return a, nil
+}
+
+// exportPortfolio downloads the user's account, as JSON or with
+// ?format=csv as CSV.
+func (a *app) exportPortfolio(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		a.apology(w, r, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+	acct, err := exportAccount(a.db, sessions.UserID(r))
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="portfolio.`+format+`"`)
+	if err := writeAccount(w, acct, format); err != nil {
+		slog.Error("can't send the export", "format", format, "err", err)
+	}
+}
+
+// importPortfolio fills a new account from an uploaded export.
+func (a *app) importPortfolio(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		a.render(w, r, "import.html", tradePage{CSRF: csrf.TemplateField(r)})
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		a.apology(w, r, "must choose an export file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	acct, err := readAccount(file, formatOf(header.Filename))
+	if err != nil {
+		a.apology(w, r, header.Filename+": "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	switch err := importAccount(a.db, sessions.UserID(r), acct); {
+	case errors.Is(err, ErrNotEmpty):
+		a.apology(w, r, "you can only import into an account with no transactions yet", http.StatusBadRequest)
+		return
+	case err != nil:
+		a.serverError(w, r, err)
+		return
+	}
+	a.ui.Flash(w, r, fmt.Sprintf("Imported %d transactions.", len(acct.Transactions)))
+	webkit.Redirect(w, r, "/")
+}
@@ -0,0 +1,200 @@
+// C$50 Finance as a Go web app: register, look up stock quotes, buy and
+// sell with $10,000 of pretend cash, and see the portfolio and history.
+//
+//	./finance                         finance.db on http://localhost:8080
+//	./finance -db other.db -port 3000
+//	./finance -quotes mock            fixed prices, no network needed
+//	./finance export alice -format csv > alice.csv
+//	./finance import alice.csv -db fresh.db -password hunter2
+//	./finance admin seed -db demo.db  alice, bob and carol, password "demo"
+//	./finance admin check             cash and holdings that don't add up
+
+package main
+
+import (
+	"cs50"
+	"embed"
+	"flag"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"csrf"
+	"logging"
+	"profiling"
+	"quotes"
+	"sessions"
+	"webkit"
+	"webui"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver for cs50.OpenSQL
+)
+
+//go:embed schema.sql
+var schema string
+
+//go:embed templates
+var templates embed.FS
+
+// QUOTE_TTL is how long a price is reused before asking the API again.
+const QUOTE_TTL = time.Minute
+
+// app is the handlers' shared state.
+type app struct {
+	db     *cs50.SQL
+	quotes quotes.Provider
+	store  *sessions.Store
+	csrf   *csrf.Protector
+	ui     *webui.UI
+}
+
+func main() {
+	logging.Init()
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runCommand(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	defer profiling.Start()()
+
+	dbPath := flag.String("db", "finance.db", "SQLite database")
+	port := flag.Int("port", 8080, "port to listen on")
+	source := flag.String("quotes", "cs50", "where prices come from: cs50, iex (token in $IEX_TOKEN) or mock")
+	flag.Parse()
+
+	provider, err := quoteProvider(*source)
+	if err != nil {
+		slog.Error("no quotes", "source", *source, "err", err)
+		os.Exit(1)
+	}
+
+	db, err := openDB(*dbPath)
+	if err != nil {
+		slog.Error("can't open the database", "path", *dbPath, "err", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Set SECRET_KEY to keep people logged in across restarts.
+	key := sessions.KeyFromEnv("SECRET_KEY")
+	store, err := sessions.NewStore(SESSION_COOKIE, key)
+	if err != nil {
+		slog.Error("can't make the session store", "err", err)
+		os.Exit(1)
+	}
+
+	a, err := newApp(db, quotes.NewCache(provider, QUOTE_TTL), store, csrf.New(key))
+	if err != nil {
+		slog.Error("can't start", "err", err)
+		os.Exit(1)
+	}
+	addr := fmt.Sprintf("localhost:%d", *port)
+	slog.Info("C$50 Finance", "url", "http://"+addr+"/", "db", *dbPath, "quotes", *source)
+	err = http.ListenAndServe(addr, webkit.Logger(nil)(a.routes()))
+	slog.Error("stopped", "err", err)
+	os.Exit(1)
+}
+
+// openDB opens the SQLite file at path, creating it if it's missing: an
+// empty file is an empty SQLite database, and the schema fills it in.
+func openDB(path string) (*cs50.SQL, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			return nil, err
+		}
+	}
+	db, err := cs50.OpenSQL("sqlite:///" + path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Execute(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	return db, nil
+}
+
+// quoteProvider picks the -quotes source.
+func quoteProvider(source string) (quotes.Provider, error) {
+	switch source {
+	case "cs50":
+		return &quotes.HTTP{URL: quotes.CS50_URL}, nil
+	case "iex":
+		token := os.Getenv("IEX_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("-quotes iex needs an API token in $IEX_TOKEN")
+		}
+		return &quotes.HTTP{URL: quotes.IEX_URL, Token: token}, nil
+	case "mock":
+		return quotes.Mock{}, nil
+	}
+	return nil, fmt.Errorf("unknown -quotes %q (want cs50, iex or mock)", source)
+}
+
+func newApp(db *cs50.SQL, provider quotes.Provider, store *sessions.Store, protector *csrf.Protector) (*app, error) {
+	if _, err := db.Execute(schema); err != nil {
+		return nil, fmt.Errorf("schema: %w", err)
+	}
+	pages, err := fs.Sub(templates, "templates")
+	if err != nil {
+		return nil, err
+	}
+	ui, err := webui.New(pages, template.FuncMap{"usd": usd}, store)
+	if err != nil {
+		return nil, err
+	}
+	a := &app{db: db, quotes: provider, store: store, csrf: protector, ui: ui}
+	protector.Failure = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.apology(w, r, "this form has expired or was already sent, go back and reload it", http.StatusForbidden)
+	})
+	return a, nil
+}
+
+func (a *app) routes() http.Handler {
+	router := webkit.NewRouter()
+	router.Use(webkit.NoCache)
+	router.Get("/register", a.register)
+	router.Post("/register", a.register)
+	router.Get("/login", a.login)
+	router.Post("/login", a.login)
+	router.Get("/logout", a.logout)
+	router.File("/styles.css", templates, "templates/styles.css")
+
+	private := router.Group(a.loginRequired)
+	private.Get("/{$}", a.index)
+	private.Get("/quote", a.quote)
+	private.Post("/quote", a.quote)
+	private.Get("/history", a.history)
+	private.Get("/export", a.exportPortfolio)
+
+	// The pages that spend money also need a CSRF token from a page we
+	// served, so another site can't make a logged-in browser buy or sell.
+	trading := router.Group(a.csrf.Protect, a.loginRequired)
+	trading.Get("/buy", a.buy)
+	trading.Post("/buy", a.buy)
+	trading.Get("/sell", a.sell)
+	trading.Post("/sell", a.sell)
+
+	imports := router.Group(webkit.MaxBytes(MAX_IMPORT), a.csrf.Protect, a.loginRequired)
+	imports.Get("/import", a.importPortfolio)
+	imports.Post("/import", a.importPortfolio)
+	return router
+}
+
+// render shows a page.
+func (a *app) render(w http.ResponseWriter, r *http.Request, name string, data any) {
+	a.ui.Render(w, r, http.StatusOK, name, data)
+}
+
+// apology tells the user what went wrong, like apology() in helpers.py.
+func (a *app) apology(w http.ResponseWriter, r *http.Request, message string, status int) {
+	a.ui.Apology(w, r, status, message)
+}
+
+func (a *app) serverError(w http.ResponseWriter, r *http.Request, err error) {
+	slog.Error("server error", "method", r.Method, "path", r.URL.Path, "err", err)
+	a.apology(w, r, "something went wrong", http.StatusInternalServerError)
+}
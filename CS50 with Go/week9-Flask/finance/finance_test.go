@@ -0,0 +1,576 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"cs50"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"csrf"
+	"quotes"
+	"sessions"
+)
+
+func TestUSD(t *testing.T) {
+	tests := []struct {
+		cents int
+		want  string
+	}{
+		{0, "$0.00"},
+		{5, "$0.05"},
+		{1000000, "$10,000.00"},
+		{123456789, "$1,234,567.89"},
+		{-150, "-$1.50"},
+	}
+	for _, tt := range tests {
+		if got := usd(tt.cents); got != tt.want {
+			t.Errorf("usd(%d) = %q, want %q", tt.cents, got, tt.want)
+		}
+	}
+}
+
+// client is one browser: it keeps its cookies and follows redirects.
+type client struct {
+	t      *testing.T
+	server *httptest.Server
+	http   *http.Client
+}
+
+func newServer(t *testing.T, provider quotes.Provider) *httptest.Server {
+	t.Helper()
+	db, err := cs50.OpenSQL("sqlite:///:memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := sessions.NewStore(SESSION_COOKIE, sessions.RandomKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := newApp(db, provider, store, csrf.New(sessions.RandomKey()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(a.routes())
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newClient(t *testing.T, server *httptest.Server) *client {
+	jar, _ := cookiejar.New(nil)
+	return &client{t, server, &http.Client{Jar: jar}}
+}
+
+func (c *client) get(path string) (int, string) {
+	c.t.Helper()
+	resp, err := c.http.Get(c.server.URL + path)
+	return c.read(resp, err)
+}
+
+func (c *client) post(path string, form ...string) (int, string) {
+	c.t.Helper()
+	values := url.Values{}
+	for i := 0; i+1 < len(form); i += 2 {
+		values.Set(form[i], form[i+1])
+	}
+	resp, err := c.http.PostForm(c.server.URL+path, values)
+	return c.read(resp, err)
+}
+
+// TOKEN finds the CSRF token on a form page.
+var TOKEN = regexp.MustCompile(`name="` + csrf.FIELD + `" type="hidden" value="([^"]+)"`)
+
+// token loads the form page at path and returns its CSRF token.
+func (c *client) token(path string) string {
+	c.t.Helper()
+	status, body := c.get(path)
+	match := TOKEN.FindStringSubmatch(body)
+	if status != http.StatusOK || match == nil {
+		c.t.Fatalf("GET %s: %d, no CSRF token\n%s", path, status, body)
+	}
+	return match[1]
+}
+
+// trade fills in the buy or sell form at path, like a user would: load the
+// page, then post the form with its token.
+func (c *client) trade(path, symbol, shares string) (int, string) {
+	c.t.Helper()
+	return c.post(path, "symbol", symbol, "shares", shares, csrf.FIELD, c.token(path))
+}
+
+func (c *client) read(resp *http.Response, err error) (int, string) {
+	c.t.Helper()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+// expect checks a response's status and that body mentions every want.
+func expect(t *testing.T, what string, status int, body string, wantStatus int, want ...string) {
+	t.Helper()
+	if status != wantStatus {
+		t.Errorf("%s: status %d, want %d\n%s", what, status, wantStatus, body)
+		return
+	}
+	for _, w := range want {
+		if !strings.Contains(body, w) {
+			t.Errorf("%s: missing %q\n%s", what, w, body)
+		}
+	}
+}
+
+func TestRegisterLogin(t *testing.T) {
+	server := newServer(t, quotes.Mock{})
+	c := newClient(t, server)
+
+	// Everything but register and login needs a user
+	status, body := c.get("/")
+	expect(t, "GET / logged out", status, body, http.StatusOK, `action="/login"`)
+
+	status, body = c.post("/register", "username", "", "password", "x", "confirmation", "x")
+	expect(t, "no username", status, body, http.StatusBadRequest, "must provide username")
+	status, body = c.post("/register", "username", "alice", "password", "x", "confirmation", "y")
+	expect(t, "mismatched passwords", status, body, http.StatusBadRequest, "passwords don&#39;t match")
+	status, body = c.post("/register", "username", "alice", "password", "x", "confirmation", "x")
+	expect(t, "register", status, body, http.StatusOK, "$10,000.00", "Log Out", "Registered!")
+
+	c.get("/logout")
+	status, body = c.post("/register", "username", "alice", "password", "y", "confirmation", "y")
+	expect(t, "taken username", status, body, http.StatusBadRequest, "username taken")
+	status, body = c.post("/login", "username", "alice", "password", "wrong")
+	expect(t, "wrong password", status, body, http.StatusForbidden, "invalid username and/or password")
+	status, body = c.post("/login", "username", "bob", "password", "x")
+	expect(t, "unknown user", status, body, http.StatusForbidden, "invalid username and/or password")
+	status, body = c.post("/login", "username", "alice", "password", "x")
+	expect(t, "login", status, body, http.StatusOK, "$10,000.00")
+
+	status, body = c.get("/logout")
+	expect(t, "logout", status, body, http.StatusOK, `action="/login"`)
+	status, body = c.get("/history")
+	expect(t, "history after logout", status, body, http.StatusOK, `action="/login"`)
+}
+
+// One user trades while another's portfolio stays untouched.
+func TestTrading(t *testing.T) {
+	server := newServer(t, quotes.Mock{})
+	alice, bob := newClient(t, server), newClient(t, server)
+	alice.post("/register", "username", "alice", "password", "x", "confirmation", "x")
+	bob.post("/register", "username", "bob", "password", "y", "confirmation", "y")
+
+	status, body := alice.post("/quote", "symbol", "nflx")
+	expect(t, "quote", status, body, http.StatusOK, "Netflix, Inc. (NFLX) costs $500.00")
+	status, body = alice.post("/quote", "symbol", "NOPE")
+	expect(t, "quote unknown", status, body, http.StatusBadRequest, "invalid symbol")
+
+	// $10,000 buys 20 shares of NFLX, not 21
+	status, body = alice.trade("/buy", "NFLX", "21")
+	expect(t, "buy too many", status, body, http.StatusBadRequest, "can&#39;t afford")
+	for shares, problem := range map[string]string{
+		"0":       "shares must be at least 1",
+		"-1":      "shares must be at least 1",
+		"1000001": "shares must be at most 1000000",
+		"1.5":     "shares must be a whole number",
+		"two":     "shares must be a whole number",
+		"":        "must provide shares",
+	} {
+		status, body = alice.trade("/buy", "NFLX", shares)
+		expect(t, "buy "+shares, status, body, http.StatusBadRequest, problem)
+	}
+	status, body = alice.trade("/buy", "", "1")
+	expect(t, "buy no symbol", status, body, http.StatusBadRequest, "must provide symbol")
+	status, body = alice.trade("/buy", "NFLX", "10")
+	expect(t, "buy", status, body, http.StatusOK, "Bought!", "NFLX", "Netflix, Inc.", "$5,000.00", "$10,000.00")
+	status, body = alice.trade("/buy", "aapl", "4")
+	expect(t, "buy AAPL", status, body, http.StatusOK, "AAPL", "$701.00", "$4,299.00", "$10,000.00")
+
+	status, body = alice.trade("/sell", "NFLX", "11")
+	expect(t, "sell too many", status, body, http.StatusBadRequest, "too many shares")
+	status, body = alice.trade("/sell", "MSFT", "1")
+	expect(t, "sell unowned", status, body, http.StatusBadRequest, "you don&#39;t own MSFT")
+	status, body = alice.trade("/sell", "NFLX", "10")
+	expect(t, "sell all", status, body, http.StatusOK, "Sold!", "$9,299.00")
+	if strings.Contains(body, "Netflix") {
+		t.Errorf("sold-out stock still in the portfolio:\n%s", body)
+	}
+
+	status, body = alice.get("/sell")
+	expect(t, "sell form", status, body, http.StatusOK, `<option value="AAPL">`)
+	if strings.Contains(body, "Sold!") {
+		t.Errorf("flash shown twice:\n%s", body)
+	}
+	if strings.Contains(body, `value="NFLX"`) {
+		t.Errorf("sell form offers a stock that's gone:\n%s", body)
+	}
+
+	status, body = alice.get("/history")
+	expect(t, "history", status, body, http.StatusOK, "-10", "$500.00", "$175.25")
+	if strings.Count(body, "<tr>") != 4 { // header + 3 transactions
+		t.Errorf("history should list 3 transactions:\n%s", body)
+	}
+	status, body = alice.get("/history?symbol=nflx&type=sell")
+	expect(t, "filtered history", status, body, http.StatusOK, "-10", `<option value="NFLX" selected>`)
+	if strings.Count(body, "<tr>") != 2 {
+		t.Errorf("history of NFLX sales should list 1 transaction:\n%s", body)
+	}
+	status, body = alice.get("/history?to=2000-01-01")
+	expect(t, "history before the trades", status, body, http.StatusOK, "No transactions match.")
+	status, body = alice.get("/history?type=gift")
+	expect(t, "bad filter", status, body, http.StatusBadRequest, "type must be buy or sell")
+
+	status, body = bob.get("/")
+	expect(t, "bob's portfolio", status, body, http.StatusOK, "$10,000.00")
+	if strings.Contains(body, "AAPL") {
+		t.Errorf("bob sees alice's stock:\n%s", body)
+	}
+}
+
+func TestHistoryFilter(t *testing.T) {
+	const base = "SELECT symbol, shares, price, time FROM transactions WHERE (user_id = ?)"
+	tests := []struct {
+		filter historyFilter
+		where  string
+		args   []any
+	}{
+		{historyFilter{}, "", []any{int64(7)}},
+		{historyFilter{Symbol: "NFLX", Type: "sell"}, " AND (symbol = ?) AND (shares < 0)", []any{int64(7), "NFLX"}},
+		{historyFilter{Type: "buy", From: "2026-01-05", To: "2026-01-06"}, " AND (shares > 0) AND (time >= ?) AND (time < ?)",
+			[]any{int64(7), "2026-01-05 00:00:00", "2026-01-07 00:00:00"}},
+		// What the user typed stays an argument
+		{historyFilter{Symbol: "X' OR 1=1 --"}, " AND (symbol = ?)", []any{int64(7), "X' OR 1=1 --"}},
+	}
+	for _, tt := range tests {
+		query, args, err := tt.filter.query(7)
+		if err != nil || query != base+tt.where+" ORDER BY id DESC" || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%+v: %q, %v, %v", tt.filter, query, args, err)
+		}
+	}
+	for _, bad := range []historyFilter{{Type: "gift"}, {From: "yesterday"}, {To: "2026-13-01"}} {
+		if _, _, err := bad.query(7); err == nil {
+			t.Errorf("%+v: want error", bad)
+		}
+	}
+}
+
+func TestQuoteUnavailable(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{errors.New("connection refused"), http.StatusBadGateway},
+		{&quotes.RateLimitError{RetryAfter: time.Minute}, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		server := newServer(t, quotes.ProviderFunc(func(context.Context, string) (quotes.Quote, error) {
+			return quotes.Quote{}, tt.err
+		}))
+		c := newClient(t, server)
+		c.post("/register", "username", "alice", "password", "x", "confirmation", "x")
+
+		status, body := c.trade("/buy", "NFLX", "1")
+		expect(t, "buy when "+tt.err.Error(), status, body, tt.status, "try again")
+		status, body = c.get("/")
+		expect(t, "nothing bought", status, body, http.StatusOK, "$10,000.00")
+	}
+}
+
+// Buying and selling only work from forms this server handed out, and each
+// form only once.
+func TestCSRF(t *testing.T) {
+	server := newServer(t, quotes.Mock{})
+	alice, mallory := newClient(t, server), newClient(t, server)
+	alice.post("/register", "username", "alice", "password", "x", "confirmation", "x")
+	mallory.post("/register", "username", "mallory", "password", "y", "confirmation", "y")
+
+	status, body := alice.post("/buy", "symbol", "NFLX", "shares", "1")
+	expect(t, "buy without token", status, body, http.StatusForbidden, "expired or was already sent")
+
+	token := alice.token("/buy")
+	status, body = alice.post("/buy", "symbol", "NFLX", "shares", "1", csrf.FIELD, token)
+	expect(t, "buy", status, body, http.StatusOK, "Bought!")
+	status, body = alice.post("/buy", "symbol", "NFLX", "shares", "1", csrf.FIELD, token)
+	expect(t, "buy replayed", status, body, http.StatusForbidden, "expired or was already sent")
+
+	// A token from one browser doesn't work in another
+	status, body = alice.post("/sell", "symbol", "NFLX", "shares", "1", csrf.FIELD, mallory.token("/buy"))
+	expect(t, "sell with mallory's token", status, body, http.StatusForbidden, "expired or was already sent")
+
+	status, body = alice.get("/")
+	expect(t, "one share bought", status, body, http.StatusOK, "$9,500.00")
+}
+
+// ALICE is an account with a stock bought, part sold, and one sold off.
+var ALICE = account{
+	Version:  EXPORT_VERSION,
+	Username: "alice",
+	Exported: "2026-10-16 12:00:00",
+	Cash:     929900,
+	Holdings: []accountHolding{{"AAPL", 4}, {"NFLX", 1}},
+	Transactions: []accountTransaction{
+		{"NFLX", 2, 50000, "2026-10-15 09:30:00"},
+		{"AAPL", 4, 17525, "2026-10-15 09:31:00"},
+		{"TSLA", 1, 25000, "2026-10-15 09:32:00"},
+		{"NFLX", -1, 51000, "2026-10-16 10:00:00"},
+		{"TSLA", -1, 24000, "2026-10-16 10:01:00"},
+	},
+}
+
+func TestAccountFormats(t *testing.T) {
+	for _, format := range []string{"json", "csv"} {
+		var buf strings.Builder
+		if err := writeAccount(&buf, ALICE, format); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readAccount(strings.NewReader(buf.String()), format)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", format, err, buf.String())
+		}
+		if !reflect.DeepEqual(got, ALICE) {
+			t.Errorf("%s round trip = %+v, want %+v", format, got, ALICE)
+		}
+	}
+
+	if got := holdings(ALICE.Transactions); !reflect.DeepEqual(got, ALICE.Holdings) {
+		t.Errorf("holdings = %v, want %v", got, ALICE.Holdings)
+	}
+	for name, want := range map[string]string{"alice.csv": "csv", "ALICE.CSV": "csv", "alice.json": "json", "-": "json"} {
+		if got := formatOf(name); got != want {
+			t.Errorf("formatOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestAccountErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   string
+	}{
+		{"no version", "json", `{"username": "alice"}`, "no version"},
+		{"newer version", "json", `{"version": 99}`, "newer finance"},
+		{"unknown field", "json", `{"version": 1, "password": "x"}`, "unknown field"},
+		{"negative cash", "json", `{"version": 1, "cash_cents": -1}`, "negative"},
+		{"lowercase symbol", "json", `{"version": 1, "transactions": [{"symbol": "nflx", "shares": 1, "price_cents": 1, "time": "2026-10-15 09:30:00"}]}`, "uppercase"},
+		{"no shares", "json", `{"version": 1, "transactions": [{"symbol": "NFLX", "shares": 0, "price_cents": 1, "time": "2026-10-15 09:30:00"}]}`, "0 shares"},
+		{"free", "json", `{"version": 1, "transactions": [{"symbol": "NFLX", "shares": 1, "price_cents": 0, "time": "2026-10-15 09:30:00"}]}`, "price"},
+		{"bad time", "json", `{"version": 1, "transactions": [{"symbol": "NFLX", "shares": 1, "price_cents": 1, "time": "yesterday"}]}`, "time"},
+		{"sold short", "json", `{"version": 1, "transactions": [{"symbol": "NFLX", "shares": -1, "price_cents": 1, "time": "2026-10-15 09:30:00"}]}`, "wasn't bought"},
+		{"holdings off", "json", `{"version": 1, "holdings": [{"symbol": "NFLX", "shares": 2}], "transactions": [{"symbol": "NFLX", "shares": 1, "price_cents": 1, "time": "2026-10-15 09:30:00"}]}`, "don't add up"},
+		{"csv header", "csv", "a,b,c\n", "header"},
+		{"csv no cash", "csv", "version,username,record,symbol,shares,cents,time\n", "no cash"},
+		{"csv record", "csv", "version,username,record,symbol,shares,cents,time\n1,alice,gift,,,5,\n", `unknown record "gift"`},
+		{"csv two exports", "csv", "version,username,record,symbol,shares,cents,time\n1,alice,cash,,,5,\n1,bob,holding,NFLX,1,,\n", "two exports"},
+		{"csv shares", "csv", "version,username,record,symbol,shares,cents,time\n1,alice,cash,,,5,\n1,alice,transaction,NFLX,lots,1,2026-10-15 09:30:00\n", "line 3"},
+		{"format", "xml", "<account/>", "unknown format"},
+	}
+	for _, tt := range tests {
+		_, err := readAccount(strings.NewReader(tt.input), tt.format)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// upload posts a file to the form at path, with the form's CSRF token.
+func (c *client) upload(path, filename, content string) (int, string) {
+	c.t.Helper()
+	token := c.token(path)
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	form.WriteField(csrf.FIELD, token)
+	part, _ := form.CreateFormFile("file", filename)
+	io.WriteString(part, content)
+	form.Close()
+	resp, err := c.http.Post(c.server.URL+path, form.FormDataContentType(), &body)
+	return c.read(resp, err)
+}
+
+// An export from one server imports into a fresh account on another.
+func TestExportImport(t *testing.T) {
+	old := newClient(t, newServer(t, quotes.Mock{}))
+	old.post("/register", "username", "alice", "password", "x", "confirmation", "x")
+	old.trade("/buy", "NFLX", "2")
+	old.trade("/buy", "AAPL", "4")
+	old.trade("/sell", "NFLX", "1")
+
+	status, exported := old.get("/export")
+	expect(t, "export", status, exported, http.StatusOK, `"version": 1`, `"username": "alice"`, `"cash_cents": 879900`)
+	status, csvExport := old.get("/export?format=csv")
+	expect(t, "export csv", status, csvExport, http.StatusOK, "1,alice,holding,AAPL,4,,", "1,alice,transaction,NFLX,-1,50000,")
+	status, body := old.get("/export?format=xml")
+	expect(t, "export xml", status, body, http.StatusBadRequest, "format must be json or csv")
+
+	for _, file := range []struct{ name, content string }{{"alice.json", exported}, {"alice.csv", csvExport}} {
+		c := newClient(t, newServer(t, quotes.Mock{}))
+		c.post("/register", "username", "alice2", "password", "x", "confirmation", "x")
+		status, body = c.upload("/import", file.name, file.content)
+		expect(t, "import "+file.name, status, body, http.StatusOK, "Imported 3 transactions.", "AAPL", "NFLX", "$8,799.00", "$10,000.00")
+		_, body = c.get("/history")
+		if strings.Count(body, "<tr>") != 4 {
+			t.Errorf("%s: history should list 3 transactions:\n%s", file.name, body)
+		}
+		status, body = c.upload("/import", file.name, file.content)
+		expect(t, "import twice", status, body, http.StatusBadRequest, "no transactions yet")
+	}
+
+	c := newClient(t, newServer(t, quotes.Mock{}))
+	c.post("/register", "username", "bob", "password", "x", "confirmation", "x")
+	status, body = c.upload("/import", "bob.json", `{"version": 2}`)
+	expect(t, "import newer", status, body, http.StatusBadRequest, "newer finance")
+	status, body = c.get("/export")
+	expect(t, "nothing imported", status, body, http.StatusOK, `"cash_cents": 1000000`)
+}
+
+func TestCommands(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "src.db"), filepath.Join(dir, "dst.db")
+	export := filepath.Join(dir, "alice.csv")
+	var buf strings.Builder
+	writeAccount(&buf, ALICE, "csv")
+	os.WriteFile(export, []byte(buf.String()), 0644)
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr strings.Builder
+		code := runCommand(args, strings.NewReader(""), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+	if code, out, _ := run("import", export, "-db", src); code != 1 || !strings.Contains(out, "give -password") {
+		t.Errorf("import without an account = %d %q", code, out)
+	}
+	if code, out, _ := run("import", export, "-db", src, "-password", "x"); code != 0 || out != "Imported 5 transactions into alice's account.\n" {
+		t.Errorf("import = %d %q", code, out)
+	}
+	code, out, errs := run("export", "alice", "-db", src)
+	if code != 0 {
+		t.Fatalf("export = %d %q", code, errs)
+	}
+	got, err := readAccount(strings.NewReader(out), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Exported = ALICE.Exported
+	if !reflect.DeepEqual(got, ALICE) {
+		t.Errorf("export = %+v, want %+v", got, ALICE)
+	}
+
+	// Into another database, under another name, from stdin
+	var stdout strings.Builder
+	if code := runCommand([]string{"import", "-", "-db", dst, "-user", "carol", "-password", "y"}, strings.NewReader(out), &stdout, io.Discard); code != 0 {
+		t.Errorf("import from stdin = %d %q", code, stdout.String())
+	}
+	if code, _, errs := run("export", "dave", "-db", dst); code != 1 || !strings.Contains(errs, "no such user") {
+		t.Errorf("export unknown user = %d %q", code, errs)
+	}
+	if code, _, errs := run("frobnicate"); code != 1 || !strings.Contains(errs, "Usage") {
+		t.Errorf("unknown command = %d %q", code, errs)
+	}
+}
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		dollars string
+		want    int
+	}{
+		{"10000", 1000000},
+		{"10000.00", 1000000},
+		{"$1,234.5", 123450},
+		{"0.05", 5},
+		{" 7. ", 700},
+	}
+	for _, tt := range tests {
+		if got, err := cents(tt.dollars); err != nil || got != tt.want {
+			t.Errorf("cents(%q) = %d, %v, want %d", tt.dollars, got, err, tt.want)
+		}
+		if got, _ := cents(usd(tt.want)); got != tt.want {
+			t.Errorf("cents(usd(%d)) = %d", tt.want, got)
+		}
+	}
+	for _, bad := range []string{"", "-5", "1.234", "ten", ".50", "1e6", "99999999999999999999"} {
+		if _, err := cents(bad); err == nil {
+			t.Errorf("cents(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "admin.db")
+	admin := func(args ...string) (int, string) {
+		var out strings.Builder
+		code := runCommand(append([]string{"admin"}, append(args, "-db", dbPath)...), strings.NewReader(""), &out, io.Discard)
+		return code, out.String()
+	}
+
+	if code, out := admin("init"); code != 0 || !strings.Contains(out, "Tables ready") {
+		t.Errorf("init = %d %q", code, out)
+	}
+	if code, out := admin("seed"); code != 0 || !strings.Contains(out, `Added alice (password "demo") with 3 transactions.`) {
+		t.Errorf("seed = %d %q", code, out)
+	}
+	if code, out := admin("seed"); code != 1 || !strings.Contains(out, "alice is already in the database") {
+		t.Errorf("seed twice = %d %q", code, out)
+	}
+	if code, out := admin("check"); code != 0 || out != "3 users, 6 transactions, 0 problems.\n" {
+		t.Errorf("check after seed = %d %q", code, out)
+	}
+
+	// Seeded users can log in, and their cash is what's left after trading
+	db, err := openDB(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	id, err := sessions.Authenticate(db, "alice", "demo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acct, _ := exportAccount(db, id)
+	if want := STARTING_CASH - 10*50000 - 12*17525 + 4*52310; acct.Cash != want {
+		t.Errorf("alice's cash = %s, want %s", usd(acct.Cash), usd(want))
+	}
+
+	if code, out := admin("reset-cash", "bob", "-cash", "$50"); code != 0 || out != "bob now has $50.00.\n" {
+		t.Errorf("reset-cash = %d %q", code, out)
+	}
+	if code, out := admin("reset-cash", "dave"); code != 1 || !strings.Contains(out, "no such user") {
+		t.Errorf("reset-cash unknown user = %d %q", code, out)
+	}
+	if code, out := admin("check"); code != 0 || !strings.Contains(out, "warning: bob has $50.00 of cash, the trades leave") {
+		t.Errorf("check after reset-cash = %d %q", code, out)
+	}
+
+	// Things trading can't do
+	db.Execute("UPDATE users SET cash = -100 WHERE username = 'carol'")
+	db.Execute("INSERT INTO transactions (user_id, symbol, shares, price) VALUES(?, 'NFLX', -50, 50000)", id)
+	db.Execute("INSERT INTO transactions (user_id, symbol, shares, price) VALUES(99, 'NFLX', 1, 50000)")
+	code, out := admin("check")
+	for _, want := range []string{
+		"problem: carol has -$1.00 of cash",
+		"problem: alice's transaction 7 sells NFLX they don't own",
+		"problem: transaction 8 belongs to user 99, who doesn't exist",
+		"3 users, 8 transactions, 3 problems.",
+	} {
+		if code != 1 || !strings.Contains(out, want) {
+			t.Errorf("check = %d, missing %q\n%s", code, want, out)
+		}
+	}
+
+	if code, out := admin("frobnicate"); code != 1 || !strings.Contains(out, "Usage") {
+		t.Errorf("unknown subcommand = %d %q", code, out)
+	}
+}
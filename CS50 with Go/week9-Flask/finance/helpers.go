@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// usd formats cents as dollars, like the pset's usd filter: 123456 is
+// "$1,234.56".
+func usd(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	whole := strconv.Itoa(cents / 100)
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return fmt.Sprintf("%s$%s.%02d", sign, whole, cents%100)
+}
+
+// cents parses dollars like "10000", "$1,234.5" or "0.05" into cents, the
+// other way from usd.
+func cents(dollars string) (int, error) {
+	s := strings.ReplaceAll(strings.TrimPrefix(strings.TrimSpace(dollars), "$"), ",", "")
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > 2 || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, fmt.Errorf("%q isn't an amount of dollars like 10000.00", dollars)
+	}
+	n, err := strconv.Atoi(whole + (frac + "00")[:2])
+	if err != nil {
+		return 0, fmt.Errorf("%q is too much money", dollars)
+	}
+	return n, nil
+}
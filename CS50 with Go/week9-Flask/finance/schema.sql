@@ -0,0 +1,22 @@
+-- Money is stored in cents, so 10000.00 dollars of starting cash is 1000000.
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER,
+    username TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    cash INTEGER NOT NULL DEFAULT 1000000,
+    PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS username ON users (username);
+
+-- Every buy (shares > 0) and sell (shares < 0), at the price paid per share.
+CREATE TABLE IF NOT EXISTS transactions (
+    id INTEGER,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    shares INTEGER NOT NULL,
+    price INTEGER NOT NULL,
+    time TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY(id),
+    FOREIGN KEY(user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS user_symbol ON transactions (user_id, symbol);
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"cs50"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"csrf"
+	"qb"
+	"quotes"
+	"sessions"
+	"webkit"
+	"webui"
+)
+
+// MAX_SHARES caps one trade, well below where shares times price would
+// overflow.
+const MAX_SHARES = 1000000
+
+// holding is one row of the portfolio.
+type holding struct {
+	Symbol string
+	Name   string
+	Shares int
+	Price  int // current price per share, in cents
+	Total  int
+}
+
+// portfolio is what index.html shows.
+type portfolio struct {
+	Holdings []holding
+	Cash     int
+	Total    int // cash plus every holding
+}
+
+// tradePage is what buy.html, sell.html and import.html show.
+type tradePage struct {
+	CSRF    template.HTML // hidden token field for the form
+	Symbols []cs50.Row    // sell: the stocks the user owns
+}
+
+// transaction is one row of history.html.
+type transaction struct {
+	Symbol string
+	Shares int // negative for a sale
+	Price  int
+	Time   string
+}
+
+// historyFilter is history.html's form: every field is optional.
+type historyFilter struct {
+	Symbol string
+	Type   string // "buy", "sell" or "" for both
+	From   string // YYYY-MM-DD, inclusive
+	To     string
+}
+
+// historyPage is what history.html shows.
+type historyPage struct {
+	Filter       historyFilter
+	Symbols      []string // every stock the user has traded, for the form
+	Transactions []transaction
+}
+
+// query is the user's transactions matching f, newest first.
+func (f historyFilter) query(id int64) (string, []any, error) {
+	q := qb.Select("SELECT symbol, shares, price, time FROM transactions").Where("user_id = ?", id)
+	if f.Symbol != "" {
+		q.Compare("symbol", "=", f.Symbol)
+	}
+	switch f.Type {
+	case "buy":
+		q.Where("shares > 0")
+	case "sell":
+		q.Where("shares < 0")
+	case "":
+	default:
+		return "", nil, fmt.Errorf("type must be buy or sell")
+	}
+	// Times are stored as "YYYY-MM-DD HH:MM:SS", so they compare as text;
+	// To takes in its whole day by stopping before the next one.
+	if f.From != "" {
+		from, err := time.Parse(time.DateOnly, f.From)
+		if err != nil {
+			return "", nil, fmt.Errorf("from must be a date")
+		}
+		q.Compare("time", ">=", from.Format(TIME_LAYOUT))
+	}
+	if f.To != "" {
+		to, err := time.Parse(time.DateOnly, f.To)
+		if err != nil {
+			return "", nil, fmt.Errorf("to must be a date")
+		}
+		q.Compare("time", "<", to.AddDate(0, 0, 1).Format(TIME_LAYOUT))
+	}
+	return q.OrderBy("id DESC").Build()
+}
+
+// index shows the user's stocks at today's prices, and their cash.
+func (a *app) index(w http.ResponseWriter, r *http.Request) {
+	id := sessions.UserID(r)
+	cash, err := a.cash(id)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	owned, err := a.db.Query(`SELECT symbol, SUM(shares) AS shares FROM transactions
+		WHERE user_id = ? GROUP BY symbol HAVING SUM(shares) > 0 ORDER BY symbol`, id)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+
+	p := portfolio{Cash: cash, Total: cash}
+	for _, row := range owned {
+		q, err := a.quotes.Lookup(r.Context(), row["symbol"].(string))
+		if err != nil {
+			a.quoteError(w, r, err)
+			return
+		}
+		h := holding{Symbol: q.Symbol, Name: q.Name, Shares: int(row["shares"].(int64)), Price: q.Price}
+		h.Total = h.Shares * h.Price
+		p.Holdings = append(p.Holdings, h)
+		p.Total += h.Total
+	}
+	a.render(w, r, "index.html", p)
+}
+
+// quote looks up a stock's price.
+func (a *app) quote(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		a.render(w, r, "quote.html", nil)
+		return
+	}
+
+	symbol := strings.ToUpper(strings.TrimSpace(r.PostFormValue("symbol")))
+	if symbol == "" {
+		a.apology(w, r, "must provide symbol", http.StatusBadRequest)
+		return
+	}
+	q, err := a.quotes.Lookup(r.Context(), symbol)
+	if err != nil {
+		a.quoteError(w, r, err)
+		return
+	}
+	a.render(w, r, "quoted.html", q)
+}
+
+// buy buys shares of a stock, if the user can afford them.
+func (a *app) buy(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		a.render(w, r, "buy.html", tradePage{CSRF: csrf.TemplateField(r)})
+		return
+	}
+
+	symbol, shares, ok := a.tradeForm(w, r)
+	if !ok {
+		return
+	}
+	q, err := a.quotes.Lookup(r.Context(), symbol)
+	if err != nil {
+		a.quoteError(w, r, err)
+		return
+	}
+	cost := q.Price * shares
+
+	// Taking the cash and recording the purchase happen together or not
+	// at all; the WHERE makes sure the cash is there.
+	tx, err := a.db.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+	result, err := tx.Exec("UPDATE users SET cash = cash - ? WHERE id = ? AND cash >= ?", cost, sessions.UserID(r), cost)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		a.apology(w, r, "can't afford", http.StatusBadRequest)
+		return
+	}
+	if _, err := tx.Exec("INSERT INTO transactions (user_id, symbol, shares, price) VALUES(?, ?, ?, ?)", sessions.UserID(r), q.Symbol, shares, q.Price); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	a.ui.Flash(w, r, "Bought!")
+	webkit.Redirect(w, r, "/")
+}
+
+// sell sells shares the user owns.
+func (a *app) sell(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		symbols, err := a.db.Query(`SELECT symbol FROM transactions WHERE user_id = ?
+			GROUP BY symbol HAVING SUM(shares) > 0 ORDER BY symbol`, sessions.UserID(r))
+		if err != nil {
+			a.serverError(w, r, err)
+			return
+		}
+		a.render(w, r, "sell.html", tradePage{CSRF: csrf.TemplateField(r), Symbols: symbols})
+		return
+	}
+
+	symbol, shares, ok := a.tradeForm(w, r)
+	if !ok {
+		return
+	}
+
+	tx, err := a.db.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	defer tx.Rollback()
+	var owned int
+	if err := tx.QueryRow("SELECT COALESCE(SUM(shares), 0) FROM transactions WHERE user_id = ? AND symbol = ?", sessions.UserID(r), symbol).Scan(&owned); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if owned == 0 {
+		a.apology(w, r, "you don't own "+symbol, http.StatusBadRequest)
+		return
+	}
+	if shares > owned {
+		a.apology(w, r, "too many shares", http.StatusBadRequest)
+		return
+	}
+
+	q, err := a.quotes.Lookup(r.Context(), symbol)
+	if err != nil {
+		a.quoteError(w, r, err)
+		return
+	}
+	if _, err := tx.Exec("INSERT INTO transactions (user_id, symbol, shares, price) VALUES(?, ?, ?, ?)", sessions.UserID(r), symbol, -shares, q.Price); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if _, err := tx.Exec("UPDATE users SET cash = cash + ? WHERE id = ?", q.Price*shares, sessions.UserID(r)); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	a.ui.Flash(w, r, "Sold!")
+	webkit.Redirect(w, r, "/")
+}
+
+// history lists every transaction, newest first.
+func (a *app) history(w http.ResponseWriter, r *http.Request) {
+	id := sessions.UserID(r)
+	page := historyPage{Filter: historyFilter{
+		Symbol: strings.ToUpper(strings.TrimSpace(r.FormValue("symbol"))),
+		Type:   r.FormValue("type"),
+		From:   r.FormValue("from"),
+		To:     r.FormValue("to"),
+	}}
+	query, args, err := page.Filter.query(id)
+	if err != nil {
+		a.apology(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rows, err := a.db.Query(query, args...)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	symbols, err := a.db.Query("SELECT DISTINCT symbol FROM transactions WHERE user_id = ? ORDER BY symbol", id)
+	if err != nil {
+		a.serverError(w, r, err)
+		return
+	}
+	for _, row := range symbols {
+		page.Symbols = append(page.Symbols, row["symbol"].(string))
+	}
+	for _, row := range rows {
+		page.Transactions = append(page.Transactions, transaction{
+			Symbol: row["symbol"].(string),
+			Shares: int(row["shares"].(int64)),
+			Price:  int(row["price"].(int64)),
+			Time:   row["time"].(string),
+		})
+	}
+	a.render(w, r, "history.html", page)
+}
+
+// tradeForm reads the symbol and number of shares of a buy or sell form.
+// When they're no good it has already sent an apology.
+func (a *app) tradeForm(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	f := webui.NewForm(r)
+	symbol := strings.ToUpper(f.Required("symbol"))
+	shares := f.Int("shares", 1, MAX_SHARES)
+	if !f.Valid() {
+		a.apology(w, r, f.Problem, http.StatusBadRequest)
+		return "", 0, false
+	}
+	return symbol, shares, true
+}
+
+// cash returns how much cash a user has, in cents.
+func (a *app) cash(id int64) (int, error) {
+	rows, err := a.db.Query("SELECT cash FROM users WHERE id = ?", id)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, errors.New("no such user")
+	}
+	return int(rows[0]["cash"].(int64)), nil
+}
+
+// quoteError turns a failed lookup into an apology.
+func (a *app) quoteError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, quotes.ErrNotFound):
+		a.apology(w, r, "invalid symbol", http.StatusBadRequest)
+		return
+	case errors.Is(err, quotes.ErrRateLimited):
+		a.apology(w, r, "too many price lookups, try again in a minute", http.StatusServiceUnavailable)
+		return
+	}
+	a.apology(w, r, "couldn't look up the price, try again", http.StatusBadGateway)
+}
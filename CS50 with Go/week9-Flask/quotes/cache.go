@@ -0,0 +1,83 @@
+package quotes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache remembers quotes for TTL, so a portfolio page showing ten stocks
+// doesn't cost ten API calls on every reload.
+//
+// When the provider says it's rate limited, Cache stops asking it until the
+// Retry-After has passed (or TTL, if it didn't say) and meanwhile answers
+// with the last quote it had, however old; only a symbol it has never seen
+// gets the *RateLimitError.
+type Cache struct {
+	Provider Provider
+	TTL      time.Duration
+
+	now func() time.Time // time.Now, except in tests
+
+	mu      sync.Mutex
+	entries map[string]entry
+	backoff time.Time // don't call Provider before this
+}
+
+type entry struct {
+	quote   Quote
+	fetched time.Time
+}
+
+// NewCache wraps p.
+func NewCache(p Provider, ttl time.Duration) *Cache {
+	return &Cache{Provider: p, TTL: ttl, now: time.Now, entries: make(map[string]entry)}
+}
+
+func (c *Cache) Lookup(ctx context.Context, symbol string) (Quote, error) {
+	key := strings.ToUpper(symbol)
+	now := c.now()
+
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	backoff := c.backoff
+	c.mu.Unlock()
+	if ok && now.Sub(cached.fetched) < c.TTL {
+		return cached.quote, nil
+	}
+	if now.Before(backoff) {
+		if ok {
+			return cached.quote, nil
+		}
+		return Quote{}, &RateLimitError{RetryAfter: backoff.Sub(now)}
+	}
+
+	// Not holding the lock here: two requests for the same symbol may both
+	// reach the provider, which is cheaper than making every other symbol
+	// wait for a slow API.
+	q, err := c.Provider.Lookup(ctx, symbol)
+	var limited *RateLimitError
+	switch {
+	case errors.As(err, &limited):
+		wait := limited.RetryAfter
+		if wait == 0 {
+			wait = c.TTL
+		}
+		c.mu.Lock()
+		c.backoff = now.Add(wait)
+		c.mu.Unlock()
+		if ok {
+			return cached.quote, nil
+		}
+		return Quote{}, err
+	case err != nil:
+		return Quote{}, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = entry{q, now}
+	c.mu.Unlock()
+	return q, nil
+}
@@ -0,0 +1,72 @@
+package quotes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// counting is a provider that counts calls and can be switched to rate
+// limited.
+type counting struct {
+	calls   int
+	limited *RateLimitError
+}
+
+func (p *counting) Lookup(ctx context.Context, symbol string) (Quote, error) {
+	p.calls++
+	if p.limited != nil {
+		return Quote{}, p.limited
+	}
+	return Mock{}.Lookup(ctx, symbol)
+}
+
+func TestCache(t *testing.T) {
+	p := &counting{}
+	c := NewCache(p, time.Minute)
+	now := time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	lookup := func(symbol string, wantCalls int) (Quote, error) {
+		t.Helper()
+		q, err := c.Lookup(ctx, symbol)
+		if p.calls != wantCalls {
+			t.Errorf("after Lookup(%s) at %s: %d provider calls, want %d", symbol, now.Format("15:04:05"), p.calls, wantCalls)
+		}
+		return q, err
+	}
+
+	lookup("NFLX", 1)
+	lookup("nflx", 1) // symbols are case-insensitive
+	now = now.Add(59 * time.Second)
+	lookup("NFLX", 1)
+	now = now.Add(time.Second)
+	lookup("NFLX", 2) // expired
+	if _, err := lookup("ZZZZ", 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ZZZZ: %v", err)
+	}
+	lookup("ZZZZ", 4) // errors aren't cached
+
+	// Rate limited: stale quotes are better than none, and the provider
+	// gets left alone until Retry-After.
+	p.limited = &RateLimitError{RetryAfter: 10 * time.Minute}
+	now = now.Add(time.Hour)
+	if q, err := lookup("NFLX", 5); err != nil || q.Price != 50000 {
+		t.Errorf("rate limited NFLX = %+v, %v, want the stale quote", q, err)
+	}
+	now = now.Add(5 * time.Minute)
+	lookup("NFLX", 5)
+	_, err := lookup("AAPL", 5)
+	var limited *RateLimitError
+	if !errors.As(err, &limited) || limited.RetryAfter != 5*time.Minute {
+		t.Errorf("never-seen AAPL while backing off: %v, want rate limited for 5m", err)
+	}
+
+	now = now.Add(5 * time.Minute)
+	p.limited = nil
+	if q, err := lookup("AAPL", 6); err != nil || q.Symbol != "AAPL" {
+		t.Errorf("after backoff AAPL = %+v, %v", q, err)
+	}
+}
@@ -0,0 +1,91 @@
+package quotes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URL templates of the IEX-style APIs HTTP knows. {symbol} and {token} are
+// filled in per request.
+const (
+	CS50_URL = "https://finance.cs50.io/quote?symbol={symbol}"
+	IEX_URL  = "https://cloud.iexapis.com/stable/stock/{symbol}/quote?token={token}"
+)
+
+// TIMEOUT bounds one request when the context has no deadline of its own.
+const TIMEOUT = 5 * time.Second
+
+// HTTP quotes from an API that answers with IEX's quote JSON
+// ({"symbol", "companyName", "latestPrice", ...}), as finance.cs50.io does.
+type HTTP struct {
+	URL    string       // a template such as CS50_URL
+	Token  string       // API key, for URLs with {token}
+	Client *http.Client // http.DefaultClient when nil
+}
+
+// Lookup fetches one quote. 404 is ErrNotFound and 429 a *RateLimitError.
+func (p *HTTP) Lookup(ctx context.Context, symbol string) (Quote, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, TIMEOUT)
+		defer cancel()
+	}
+	target := strings.NewReplacer(
+		"{symbol}", url.PathEscape(symbol),
+		"{token}", url.QueryEscape(p.Token),
+	).Replace(p.URL)
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return Quote{}, err
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return Quote{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return Quote{}, ErrNotFound
+	case http.StatusTooManyRequests:
+		return Quote{}, &RateLimitError{RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	default:
+		return Quote{}, fmt.Errorf("quotes: %s", resp.Status)
+	}
+
+	var body struct {
+		Symbol      string  `json:"symbol"`
+		CompanyName string  `json:"companyName"`
+		LatestPrice float64 `json:"latestPrice"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Quote{}, fmt.Errorf("quotes: %w", err)
+	}
+	if body.Symbol == "" {
+		return Quote{}, ErrNotFound
+	}
+	return Quote{Symbol: body.Symbol, Name: body.CompanyName, Price: int(math.Round(body.LatestPrice * 100))}, nil
+}
+
+// retryAfter reads a Retry-After header, which is either seconds or a date.
+func retryAfter(header string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil && when.After(now) {
+		return when.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package quotes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeAPI answers like finance.cs50.io and IEX Cloud: NFLX is a stock, BUSY is always
+// rate limited, BROKEN sends bad JSON, anything else is a 404.
+func fakeAPI(t *testing.T) *httptest.Server {
+	quote := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("token") != "" && r.URL.Query().Get("token") != "secret" {
+			http.Error(w, "bad token", http.StatusForbidden)
+			return
+		}
+		switch r.PathValue("symbol") + r.URL.Query().Get("symbol") {
+		case "NFLX":
+			w.Write([]byte(`{"symbol": "NFLX", "companyName": "Netflix, Inc.", "latestPrice": 499.995}`))
+		case "BUSY":
+			w.Header().Set("Retry-After", "30")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+		case "BROKEN":
+			w.Write([]byte(`{"symbol": `))
+		default:
+			http.Error(w, "Unknown symbol", http.StatusNotFound)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/quote", quote)
+	mux.HandleFunc("/stable/stock/{symbol}/quote", quote)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTP(t *testing.T) {
+	server := fakeAPI(t)
+	providers := map[string]*HTTP{
+		"cs50-style": {URL: server.URL + "/quote?symbol={symbol}"},
+		"iex-style":  {URL: server.URL + "/stable/stock/{symbol}/quote?token={token}", Token: "secret"},
+	}
+
+	for name, p := range providers {
+		q, err := p.Lookup(context.Background(), "NFLX")
+		if err != nil || q != (Quote{"NFLX", "Netflix, Inc.", 50000}) {
+			t.Errorf("%s: Lookup(NFLX) = %+v, %v", name, q, err)
+		}
+
+		_, err = p.Lookup(context.Background(), "NOPE")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: Lookup(NOPE) error = %v, want ErrNotFound", name, err)
+		}
+
+		_, err = p.Lookup(context.Background(), "BUSY")
+		var limited *RateLimitError
+		if !errors.As(err, &limited) || limited.RetryAfter != 30*time.Second || !errors.Is(err, ErrRateLimited) {
+			t.Errorf("%s: Lookup(BUSY) error = %v, want rate limited for 30s", name, err)
+		}
+
+		if _, err = p.Lookup(context.Background(), "BROKEN"); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: Lookup(BROKEN) error = %v, want a decoding error", name, err)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"120", 2 * time.Minute},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0},
+		{"", 0},
+		{"soon", 0},
+		{"-5", 0},
+	}
+	for _, tt := range tests {
+		if got := retryAfter(tt.header, now); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMock(t *testing.T) {
+	for _, symbol := range []string{"NFLX", "nflx"} {
+		if q, err := (Mock{}).Lookup(context.Background(), symbol); err != nil || q.Price != 50000 {
+			t.Errorf("Lookup(%s) = %+v, %v", symbol, q, err)
+		}
+	}
+	if _, err := (Mock{}).Lookup(context.Background(), "ZZZZ"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Lookup(ZZZZ) error = %v, want ErrNotFound", err)
+	}
+}
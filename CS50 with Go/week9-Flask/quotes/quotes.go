@@ -0,0 +1,52 @@
+// Package quotes looks up stock prices for the finance app. A Provider is
+// anything that can quote a symbol: the HTTP APIs (finance.cs50.io or
+// IEX Cloud), a Mock that needs no network, or a Cache in front of either.
+package quotes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Quote is a stock's latest price, in cents.
+type Quote struct {
+	Symbol string
+	Name   string
+	Price  int
+}
+
+// Provider quotes stock symbols.
+type Provider interface {
+	Lookup(ctx context.Context, symbol string) (Quote, error)
+}
+
+// ProviderFunc lets an ordinary function be a Provider, like
+// http.HandlerFunc.
+type ProviderFunc func(ctx context.Context, symbol string) (Quote, error)
+
+func (f ProviderFunc) Lookup(ctx context.Context, symbol string) (Quote, error) {
+	return f(ctx, symbol)
+}
+
+// ErrNotFound is returned for a symbol that doesn't exist.
+var ErrNotFound = errors.New("quotes: no such symbol")
+
+// ErrRateLimited is what a RateLimitError wraps, for errors.Is.
+var ErrRateLimited = errors.New("quotes: rate limited")
+
+// RateLimitError means the API wants us to slow down. RetryAfter is how
+// long it asked us to wait, or 0 when it didn't say.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter == 0 {
+		return "quotes: rate limited"
+	}
+	return fmt.Sprintf("quotes: rate limited, retry in %v", e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
@@ -0,0 +1,51 @@
+package sessions
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// USER_KEY is the session value holding the logged-in user's ID, the
+// session["user_id"] of the pset.
+const USER_KEY = "user_id"
+
+// Login starts a fresh session for userID, dropping whatever the old one
+// held (so a session ID planted before login is worthless after it).
+func (s *Store) Login(w http.ResponseWriter, userID int64) {
+	session := &Session{}
+	session.Set(USER_KEY, strconv.FormatInt(userID, 10))
+	s.Save(w, session)
+}
+
+// Logout forgets who is logged in.
+func (s *Store) Logout(w http.ResponseWriter) {
+	s.Clear(w)
+}
+
+// User returns the ID of the user logged in on r, reading the cookie.
+func (s *Store) User(r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(s.Get(r).Get(USER_KEY), 10, 64)
+	return id, err == nil
+}
+
+type userKey struct{}
+
+// LoginRequired redirects visitors who aren't logged in to loginPath, like
+// the pset's @login_required. Behind it, UserID(r) is the user's ID.
+func (s *Store) LoginRequired(loginPath string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := s.User(r)
+		if !ok {
+			http.Redirect(w, r, loginPath, http.StatusSeeOther)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, id)))
+	})
+}
+
+// UserID returns the ID LoginRequired found, or 0 outside it.
+func UserID(r *http.Request) int64 {
+	id, _ := r.Context().Value(userKey{}).(int64)
+	return id
+}
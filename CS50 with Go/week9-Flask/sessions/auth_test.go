@@ -0,0 +1,71 @@
+package sessions
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoginRequired(t *testing.T) {
+	s := newTestStore(t, "app")
+	h := s.LoginRequired("/login", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "user %d", UserID(r))
+	}))
+
+	// Not logged in: off to the login page
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/login" {
+		t.Errorf("logged out: %d to %q, want 303 to /login", w.Code, w.Header().Get("Location"))
+	}
+
+	// Login starts over: the new session holds the user and nothing else
+	login := httptest.NewRecorder()
+	s.Login(login, 42)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(login.Result().Cookies()[0])
+	if got := s.Get(r); len(got.Values) != 1 || got.Get(USER_KEY) != "42" {
+		t.Errorf("session after Login = %v", got.Values)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "user 42" {
+		t.Errorf("logged in: %d %q", w.Code, w.Body)
+	}
+
+	logout := httptest.NewRecorder()
+	s.Logout(logout)
+	if c := logout.Result().Cookies()[0]; c.MaxAge >= 0 || c.Value != "" {
+		t.Errorf("Logout cookie = %+v, want it deleted", c)
+	}
+}
+
+func TestPasswords(t *testing.T) {
+	hash, err := HashPassword("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$2a$") || strings.Contains(hash, "hunter2") {
+		t.Errorf("hash = %q", hash)
+	}
+	if !CheckPassword(hash, "hunter2") {
+		t.Error("right password rejected")
+	}
+	for _, wrong := range []string{"hunter3", "", "Hunter2"} {
+		if CheckPassword(hash, wrong) {
+			t.Errorf("%q accepted", wrong)
+		}
+	}
+	if again, _ := HashPassword("hunter2"); again == hash {
+		t.Error("same hash twice: salt isn't random")
+	}
+	if CheckPassword("garbage", "hunter2") {
+		t.Error("malformed hash accepted")
+	}
+	if _, err := HashPassword(strings.Repeat("x", 73)); err != ErrPasswordTooLong {
+		t.Errorf("73-byte password: %v, want ErrPasswordTooLong", err)
+	}
+}
@@ -0,0 +1,10 @@
+module sessions
+
+go 1.25.0
+
+require (
+	cs50 v0.0.0
+	golang.org/x/crypto v0.54.0
+)
+
+replace cs50 => ../../cs50
@@ -0,0 +1,31 @@
+package sessions
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// COST is bcrypt's work factor: each +1 doubles the time a guess takes.
+var COST = bcrypt.DefaultCost
+
+// ErrPasswordTooLong is returned for passwords bcrypt can't hash: it only
+// looks at the first 72 bytes, and silently ignoring the rest would be
+// worse than saying so.
+var ErrPasswordTooLong = errors.New("sessions: password longer than 72 bytes")
+
+// HashPassword returns a salted bcrypt hash of password, the counterpart of
+// werkzeug's generate_password_hash.
+func HashPassword(password string) (string, error) {
+	if len(password) > 72 {
+		return "", ErrPasswordTooLong
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), COST)
+	return string(hash), err
+}
+
+// CheckPassword reports whether password matches hash, like
+// check_password_hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,175 @@
+// Package sessions is the login plumbing the week 8 and 9 web apps share:
+// a session kept in a signed cookie (like Flask's default session), bcrypt
+// password hashes, a users table in the pset's shape, and middleware that
+// sends visitors who aren't logged in to the login page.
+package sessions
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// MIN_KEY is the shortest signing key NewStore accepts, in bytes.
+const MIN_KEY = 32
+
+// DEFAULT_MAX_AGE is how long a session lasts when Store.MaxAge isn't set.
+const DEFAULT_MAX_AGE = 30 * 24 * time.Hour
+
+// Session is the data kept for one browser. It lives in the cookie itself,
+// signed so the browser can read it but not change it; keep it small and
+// don't put secrets in it.
+type Session struct {
+	Values  map[string]string
+	Expires time.Time
+}
+
+// Get returns a value, or "" when it isn't set.
+func (s *Session) Get(key string) string {
+	return s.Values[key]
+}
+
+// Set stores a value; Store.Save sends it to the browser.
+func (s *Session) Set(key, value string) {
+	if s.Values == nil {
+		s.Values = map[string]string{}
+	}
+	s.Values[key] = value
+}
+
+// Delete removes a value.
+func (s *Session) Delete(key string) {
+	delete(s.Values, key)
+}
+
+// Store reads and writes sessions as cookies named Name.
+type Store struct {
+	Name   string
+	MaxAge time.Duration // DEFAULT_MAX_AGE when 0
+	Secure bool          // only send the cookie over HTTPS
+
+	key []byte
+}
+
+// NewStore returns a store signing cookies with key, which has to stay the
+// same for sessions to survive a restart (RandomKey makes a throwaway one).
+func NewStore(name string, key []byte) (*Store, error) {
+	if len(key) < MIN_KEY {
+		return nil, errors.New("sessions: key must be at least 32 bytes")
+	}
+	return &Store{Name: name, key: key}, nil
+}
+
+// WithName returns a store for another cookie signed with the same key,
+// for data that shouldn't share a cookie with the login (flash messages).
+func (s *Store) WithName(name string) *Store {
+	return &Store{Name: name, MaxAge: s.MaxAge, Secure: s.Secure, key: s.key}
+}
+
+// RandomKey returns a new signing key. Sessions signed with it end when the
+// server restarts.
+func RandomKey() []byte {
+	key := make([]byte, MIN_KEY)
+	rand.Read(key)
+	return key
+}
+
+// KeyFromEnv returns the signing key in the environment variable name
+// (any string; SHA-256 stretches or shrinks it to MIN_KEY bytes), or a
+// RandomKey when it isn't set.
+func KeyFromEnv(name string) []byte {
+	secret := os.Getenv(name)
+	if secret == "" {
+		return RandomKey()
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:]
+}
+
+// Get returns r's session, or a new empty one when the cookie is missing,
+// tampered with or expired.
+func (s *Store) Get(r *http.Request) *Session {
+	if cookie, err := r.Cookie(s.Name); err == nil {
+		if session, ok := s.decode(cookie.Value, time.Now()); ok {
+			return session
+		}
+	}
+	return &Session{Values: map[string]string{}}
+}
+
+// Save sends session back to the browser, extending its expiry. Call it
+// before writing the response body.
+func (s *Store) Save(w http.ResponseWriter, session *Session) {
+	session.Expires = time.Now().Add(s.maxAge()).Truncate(time.Second)
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.Name,
+		Value:    s.encode(session),
+		Path:     "/",
+		Expires:  session.Expires,
+		HttpOnly: true,
+		Secure:   s.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// Clear deletes the session cookie.
+func (s *Store) Clear(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{Name: s.Name, Value: "", Path: "/", MaxAge: -1, HttpOnly: true, Secure: s.Secure})
+}
+
+func (s *Store) maxAge() time.Duration {
+	if s.MaxAge == 0 {
+		return DEFAULT_MAX_AGE
+	}
+	return s.MaxAge
+}
+
+// A cookie is base64(JSON) "." base64(HMAC-SHA256 of the cookie name and
+// the JSON). Signing the name too stops one app's cookie being replayed as
+// another's.
+type payload struct {
+	Values  map[string]string `json:"v"`
+	Expires int64             `json:"e"`
+}
+
+func (s *Store) encode(session *Session) string {
+	data, _ := json.Marshal(payload{session.Values, session.Expires.Unix()})
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded))
+}
+
+func (s *Store) decode(value string, now time.Time) (*Session, bool) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return nil, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil || !now.Before(time.Unix(p.Expires, 0)) {
+		return nil, false
+	}
+	if p.Values == nil {
+		p.Values = map[string]string{}
+	}
+	return &Session{Values: p.Values, Expires: time.Unix(p.Expires, 0)}, true
+}
+
+func (s *Store) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(s.Name + "|" + encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,117 @@
+package sessions
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T, name string) *Store {
+	t.Helper()
+	s, err := NewStore(name, bytes.Repeat([]byte("k"), MIN_KEY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// roundTrip saves session with from and reads the cookie back with to.
+func roundTrip(from, to *Store, session *Session, edit func(*http.Cookie)) *Session {
+	w := httptest.NewRecorder()
+	from.Save(w, session)
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, c := range w.Result().Cookies() {
+		if edit != nil {
+			edit(c)
+		}
+		r.AddCookie(c)
+	}
+	return to.Get(r)
+}
+
+func TestStore(t *testing.T) {
+	s := newTestStore(t, "app")
+	session := &Session{}
+	session.Set("user_id", "7")
+	session.Set("flash", "Bought!")
+
+	got := roundTrip(s, s, session, nil)
+	if got.Get("user_id") != "7" || got.Get("flash") != "Bought!" {
+		t.Errorf("round trip = %v", got.Values)
+	}
+	got.Delete("flash")
+	if got = roundTrip(s, s, got, nil); len(got.Values) != 1 {
+		t.Errorf("after Delete = %v", got.Values)
+	}
+
+	// Anything the browser changes makes the session empty
+	tampered := map[string]func(*http.Cookie){
+		"value":     func(c *http.Cookie) { c.Value = strings.Replace(c.Value, "N", "M", 1) },
+		"signature": func(c *http.Cookie) { c.Value += "A" },
+		"unsigned":  func(c *http.Cookie) { c.Value, _, _ = strings.Cut(c.Value, ".") },
+		"garbage":   func(c *http.Cookie) { c.Value = "not.base64!" },
+	}
+	for name, edit := range tampered {
+		if got := roundTrip(s, s, session, edit); len(got.Values) != 0 {
+			t.Errorf("%s tampered: session = %v, want empty", name, got.Values)
+		}
+	}
+
+	// A cookie signed for one app isn't valid in another with the same key
+	other := newTestStore(t, "other")
+	if got := roundTrip(s, other, session, func(c *http.Cookie) { c.Name = "other" }); len(got.Values) != 0 {
+		t.Errorf("cookie from another store accepted: %v", got.Values)
+	}
+	if got := roundTrip(s, s.WithName("other"), session, func(c *http.Cookie) { c.Name = "other" }); len(got.Values) != 0 {
+		t.Errorf("cookie accepted under another name: %v", got.Values)
+	}
+	flashes := s.WithName("app_flash")
+	if got := roundTrip(flashes, flashes, session, nil); got.Get("user_id") != "7" {
+		t.Errorf("WithName store can't read its own cookie: %v", got.Values)
+	}
+	differentKey, _ := NewStore("app", RandomKey())
+	if got := roundTrip(s, differentKey, session, nil); len(got.Values) != 0 {
+		t.Errorf("cookie signed with another key accepted: %v", got.Values)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	s := newTestStore(t, "app")
+	session := &Session{Values: map[string]string{"user_id": "1"}, Expires: time.Now().Add(time.Hour).Truncate(time.Second)}
+	value := s.encode(session)
+
+	if _, ok := s.decode(value, time.Now()); !ok {
+		t.Error("fresh session rejected")
+	}
+	if _, ok := s.decode(value, time.Now().Add(2*time.Hour)); ok {
+		t.Error("expired session accepted")
+	}
+
+	w := httptest.NewRecorder()
+	s.MaxAge = time.Minute
+	s.Save(w, session)
+	if left := time.Until(w.Result().Cookies()[0].Expires); left > time.Minute || left < 58*time.Second {
+		t.Errorf("cookie expires in %v, want about a minute", left)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	if _, err := NewStore("app", []byte("short")); err == nil {
+		t.Error("NewStore accepted a 5-byte key")
+	}
+	if a, b := RandomKey(), RandomKey(); len(a) != MIN_KEY || bytes.Equal(a, b) {
+		t.Error("RandomKey isn't random")
+	}
+
+	t.Setenv("TEST_SECRET_KEY", "correct horse")
+	if a, b := KeyFromEnv("TEST_SECRET_KEY"), KeyFromEnv("TEST_SECRET_KEY"); len(a) != MIN_KEY || !bytes.Equal(a, b) {
+		t.Error("KeyFromEnv isn't the same key every time")
+	}
+	t.Setenv("TEST_SECRET_KEY", "")
+	if a, b := KeyFromEnv("TEST_SECRET_KEY"), KeyFromEnv("TEST_SECRET_KEY"); bytes.Equal(a, b) {
+		t.Error("KeyFromEnv without a secret should be random")
+	}
+}
@@ -0,0 +1,54 @@
+package sessions
+
+import (
+	"cs50"
+	"errors"
+	"strings"
+)
+
+// USERS_TABLE is the users table of the finance pset (cash aside), which
+// Register and Authenticate expect: a unique username and a password hash.
+const USERS_TABLE = `CREATE TABLE IF NOT EXISTS users (
+    id INTEGER,
+    username TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS username ON users (username);`
+
+var (
+	ErrUsernameTaken = errors.New("username taken")
+	ErrInvalidLogin  = errors.New("invalid username and/or password")
+)
+
+// Register adds a user with a hashed password and returns their ID.
+func Register(db *cs50.SQL, username, password string) (int64, error) {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return 0, err
+	}
+	id, err := db.Execute("INSERT INTO users (username, hash) VALUES(?, ?)", username, hash)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE") {
+		return 0, ErrUsernameTaken
+	}
+	return id, err
+}
+
+// Authenticate checks a username and password against the users table and
+// returns the user's ID. A wrong username and a wrong password are the same
+// ErrInvalidLogin, so the login form doesn't reveal who has an account.
+func Authenticate(db *cs50.SQL, username, password string) (int64, error) {
+	rows, err := db.Query("SELECT id, hash FROM users WHERE username = ?", username)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, ErrInvalidLogin
+	}
+	hash, _ := rows[0]["hash"].(string)
+	if !CheckPassword(hash, password) {
+		return 0, ErrInvalidLogin
+	}
+	id, _ := rows[0]["id"].(int64)
+	return id, nil
+}
@@ -0,0 +1,7 @@
+module webkit
+
+go 1.24.4
+
+require logging v0.0.0
+
+replace logging => ../../logging
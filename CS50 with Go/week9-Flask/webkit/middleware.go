@@ -0,0 +1,89 @@
+package webkit
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"logging"
+)
+
+// Logger logs every request like Flask's development server: method,
+// path, status and how long it took, at info, or error for a 5xx. With
+// -vv its headers are logged too, at trace. A nil logger means slog's
+// default, which logging.Init sets up.
+func Logger(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			logger.Log(r.Context(), logging.LevelTrace, "headers", "method", r.Method, "path", r.URL.Path, "headers", r.Header)
+			next.ServeHTTP(recorder, r)
+			level := slog.LevelInfo
+			if recorder.status >= 500 {
+				level = slog.LevelError
+			}
+			logger.Log(r.Context(), level, "request", "method", r.Method, "path", r.URL.Path, "status", recorder.status, "duration", time.Since(start).Round(time.Microsecond))
+		})
+	}
+}
+
+// statusRecorder remembers the status code a handler sent.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the real writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// NoCache keeps browsers from showing a stale page after a form changed
+// something, like the finance pset's after_request hook.
+func NoCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MaxBytes limits request bodies to n bytes, before anything reads the
+// form: an upload route can allow more than ParseForm's 10MB, or less.
+func MaxBytes(n int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CORS lets pages from origin ("*" for any) call the routes from the
+// browser, answering preflight OPTIONS requests itself. An empty origin
+// adds nothing: same-origin only.
+func CORS(origin string) Middleware {
+	return func(next http.Handler) http.Handler {
+		if origin == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if r.Method == http.MethodOptions {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
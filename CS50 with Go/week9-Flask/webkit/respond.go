@@ -0,0 +1,69 @@
+package webkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+)
+
+// MAX_BODY is the most ReadJSON reads of a request body.
+const MAX_BODY = 1 << 20
+
+// ErrorBody is what Error sends: {"error": "..."}.
+type ErrorBody struct {
+	Error string `json:"error"`
+}
+
+// JSON sends v as indented JSON.
+func JSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		slog.Error("can't send JSON", "err", err)
+	}
+}
+
+// Error sends message as a JSON error.
+func Error(w http.ResponseWriter, status int, message string) {
+	JSON(w, status, ErrorBody{message})
+}
+
+// ReadJSON decodes r's body into v, refusing fields v doesn't have and
+// bodies over MAX_BODY. When it returns false it has already sent the
+// error.
+func ReadJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MAX_BODY))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	var tooBig *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooBig):
+		Error(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body over %d bytes", MAX_BODY))
+		return false
+	case err != nil:
+		Error(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// HTML executes the template name of tmpl with data and sends it. The page
+// is rendered into memory first, so a template error is a clean 500
+// instead of half a page.
+func HTML(w http.ResponseWriter, status int, tmpl *template.Template, name string, data any) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		slog.Error("can't render a template", "template", name, "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
@@ -0,0 +1,124 @@
+// Package webkit is the net/http boilerplate the web exercises kept
+// repeating: registering routes with their middleware, sending JSON or a
+// rendered page, and logging requests. It's a thin layer over
+// http.ServeMux, so patterns ("GET /edit/{id}"), r.PathValue and plain
+// http.Handlers all work as usual.
+package webkit
+
+import (
+	"io/fs"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Middleware wraps a handler to run code before or after it, like
+// sessions' LoginRequired or csrf's Protect.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h in middleware, the first one outermost: Chain(h, a, b)
+// runs a, then b, then h.
+func Chain(h http.Handler, middleware ...Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+	return h
+}
+
+// Router registers routes on an http.ServeMux.
+type Router struct {
+	mux        *http.ServeMux
+	middleware []Middleware
+	group      bool // shares a parent's mux; its middleware wraps each route
+
+	once    sync.Once
+	handler http.Handler
+}
+
+// NewRouter returns a Router with nothing registered.
+func NewRouter() *Router {
+	return &Router{mux: http.NewServeMux()}
+}
+
+// Use adds middleware. On the Router from NewRouter it wraps every
+// request, including ones no route matches (so a logger sees 404s); on a
+// Group it wraps that group's routes registered afterwards. Call it
+// before serving.
+func (rt *Router) Use(middleware ...Middleware) {
+	rt.middleware = append(rt.middleware, middleware...)
+}
+
+// Group returns a Router on the same mux whose routes all go through
+// middleware, after the routes' own groups' middleware:
+//
+//	private := router.Group(loginRequired)
+//	private.Get("/history", history)
+func (rt *Router) Group(middleware ...Middleware) *Router {
+	g := &Router{mux: rt.mux, group: true}
+	if rt.group {
+		g.middleware = append(g.middleware, rt.middleware...)
+	}
+	g.middleware = append(g.middleware, middleware...)
+	return g
+}
+
+// Handle registers h for pattern, the same patterns http.ServeMux takes,
+// going through the group's middleware and then route's own.
+func (rt *Router) Handle(pattern string, h http.Handler, middleware ...Middleware) {
+	h = Chain(h, middleware...)
+	if rt.group {
+		h = Chain(h, rt.middleware...)
+	}
+	rt.mux.Handle(pattern, h)
+}
+
+// HandleFunc is Handle for a function.
+func (rt *Router) HandleFunc(pattern string, h http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle(pattern, h, middleware...)
+}
+
+// Get registers h for GET (and HEAD) requests to path.
+func (rt *Router) Get(path string, h http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle("GET "+path, h, middleware...)
+}
+
+// Post registers h for POST requests to path.
+func (rt *Router) Post(path string, h http.HandlerFunc, middleware ...Middleware) {
+	rt.Handle("POST "+path, h, middleware...)
+}
+
+// File serves name from fsys at GET path, like every app's styles.css.
+func (rt *Router) File(path string, fsys fs.FS, name string) {
+	rt.Get(path, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, fsys, name)
+	})
+}
+
+// ServeHTTP makes the Router an http.Handler.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.once.Do(func() {
+		rt.handler = rt.mux
+		if !rt.group {
+			rt.handler = Chain(rt.mux, rt.middleware...)
+		}
+	})
+	rt.handler.ServeHTTP(w, r)
+}
+
+// Param returns the {name} part of the request's path.
+func Param(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+// IntParam returns {name} as a positive number, like the id in
+// /edit/{id}; ok is false when it isn't one.
+func IntParam(r *http.Request, name string) (n int64, ok bool) {
+	n, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	return n, err == nil && n > 0
+}
+
+// Redirect sends the browser to url with a GET, the end of
+// post/redirect/get: reloading the page doesn't post the form again.
+func Redirect(w http.ResponseWriter, r *http.Request, url string) {
+	http.Redirect(w, r, url, http.StatusSeeOther)
+}
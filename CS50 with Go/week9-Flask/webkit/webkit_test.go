@@ -0,0 +1,199 @@
+package webkit
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"logging"
+)
+
+// tag is middleware that appends its name to the X-Trace header, so tests
+// can see what ran in what order.
+func tag(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Trace", name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func serve(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter()
+	router.Use(tag("root"))
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + Param(r, "id")))
+	}
+	router.Get("/{$}", ok)
+	router.Post("/items/{id}", ok, tag("route"))
+	private := router.Group(tag("private"))
+	private.Get("/items/{id}", ok)
+	admin := private.Group(tag("admin"))
+	admin.Handle("DELETE /items/{id}", http.HandlerFunc(ok), tag("route"))
+	router.File("/styles.css", fstest.MapFS{"styles.css": {Data: []byte("body {}")}}, "styles.css")
+
+	tests := []struct {
+		method, path string
+		status       int
+		body         string
+		trace        string
+	}{
+		{"GET", "/", 200, "GET ", "root"},
+		{"POST", "/items/7", 200, "POST 7", "root,route"},
+		{"GET", "/items/7", 200, "GET 7", "root,private"},
+		{"HEAD", "/items/7", 200, "HEAD 7", "root,private"},
+		{"DELETE", "/items/7", 200, "DELETE 7", "root,private,admin,route"},
+		{"PUT", "/items/7", 405, "Method Not Allowed\n", "root"},
+		{"GET", "/nope", 404, "404 page not found\n", "root"},
+		{"GET", "/styles.css", 200, "body {}", "root"},
+	}
+	for _, tt := range tests {
+		w := serve(router, tt.method, tt.path, "")
+		trace := strings.Join(w.Header().Values("X-Trace"), ",")
+		if w.Code != tt.status || w.Body.String() != tt.body || trace != tt.trace {
+			t.Errorf("%s %s = %d %q via %s, want %d %q via %s", tt.method, tt.path, w.Code, w.Body, trace, tt.status, tt.body, tt.trace)
+		}
+	}
+}
+
+func TestIntParam(t *testing.T) {
+	router := NewRouter()
+	router.Get("/edit/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if id, ok := IntParam(r, "id"); ok {
+			fmt.Fprint(w, id)
+			return
+		}
+		http.NotFound(w, r)
+	})
+	for path, status := range map[string]int{"/edit/1": 200, "/edit/42": 200, "/edit/0": 404, "/edit/-1": 404, "/edit/x": 404, "/edit/99999999999999999999": 404} {
+		if w := serve(router, "GET", path, ""); w.Code != status {
+			t.Errorf("GET %s = %d, want %d", path, w.Code, status)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+	router := NewRouter()
+	router.Post("/items", func(w http.ResponseWriter, r *http.Request) {
+		var it item
+		if !ReadJSON(w, r, &it) {
+			return
+		}
+		if it.Name == "" {
+			Error(w, http.StatusBadRequest, "must provide name")
+			return
+		}
+		JSON(w, http.StatusCreated, it)
+	})
+	tests := []struct {
+		body   string
+		status int
+		want   string
+	}{
+		{`{"name": "cat"}`, 201, "{\n  \"name\": \"cat\"\n}\n"},
+		{`{"name": ""}`, 400, "{\n  \"error\": \"must provide name\"\n}\n"},
+		{`{"nom": "cat"}`, 400, `unknown field`},
+		{`{`, 400, `invalid JSON`},
+		{`{"name": "` + strings.Repeat("x", MAX_BODY) + `"}`, 413, `body over`},
+	}
+	for _, tt := range tests {
+		w := serve(router, "POST", "/items", tt.body)
+		if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.want) || w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("POST %.20s = %d %q, want %d with %q", tt.body, w.Code, w.Body, tt.status, tt.want)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	tmpl := template.Must(template.New("page").Parse(`<p>{{.Name}}</p>{{define "broken"}}{{.Missing}}{{end}}`))
+	w := httptest.NewRecorder()
+	HTML(w, http.StatusTeapot, tmpl, "page", map[string]string{"Name": "<cat>"})
+	if w.Code != http.StatusTeapot || w.Body.String() != "<p>&lt;cat&gt;</p>" || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Errorf("HTML = %d %q %v", w.Code, w.Body, w.Header())
+	}
+
+	var logged bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logged, nil)))
+	w = httptest.NewRecorder()
+	HTML(w, http.StatusOK, tmpl, "broken", 42)
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "<p>") {
+		t.Errorf("broken template = %d %q, want a clean 500", w.Code, w.Body)
+	}
+	if !strings.Contains(logged.String(), "level=ERROR") || !strings.Contains(logged.String(), "template=broken") {
+		t.Errorf("log = %q", logged.String())
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var logged bytes.Buffer
+	router := NewRouter()
+	router.Use(Logger(slog.New(slog.NewTextHandler(&logged, nil))), NoCache, CORS("http://localhost:5173"))
+	router.Post("/form", func(w http.ResponseWriter, r *http.Request) {
+		Redirect(w, r, "/")
+	})
+
+	w := serve(router, "POST", "/form", "")
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/" {
+		t.Errorf("Redirect = %d %v", w.Code, w.Header())
+	}
+	if w.Header().Get("Cache-Control") == "" || w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" {
+		t.Errorf("headers = %v", w.Header())
+	}
+	serve(router, "GET", "/missing", "")
+	if w := serve(router, "OPTIONS", "/form", ""); w.Code != http.StatusNoContent {
+		t.Errorf("preflight = %d", w.Code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logged.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], "msg=request method=POST path=/form status=303 ") ||
+		!strings.Contains(lines[1], "msg=request method=GET path=/missing status=404 ") || !strings.Contains(lines[2], "msg=request method=OPTIONS path=/form status=204 ") {
+		t.Errorf("log =\n%s", logged.String())
+	}
+
+	// -vv logs the headers too, and a 5xx is an error.
+	logged.Reset()
+	logger := slog.New(slog.NewTextHandler(&logged, &slog.HandlerOptions{Level: logging.LevelTrace}))
+	serve(Logger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})), "GET", "/quote", "")
+	if got := logged.String(); !strings.Contains(got, "msg=headers method=GET path=/quote") || !strings.Contains(got, "level=ERROR msg=request method=GET path=/quote status=502") {
+		t.Errorf("log at trace =\n%s", got)
+	}
+
+	// Without an origin CORS does nothing
+	if w := serve(CORS("")(http.NotFoundHandler()), "OPTIONS", "/", ""); w.Code != http.StatusNotFound || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("CORS(\"\") = %d %v", w.Code, w.Header())
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	h := MaxBytes(5)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}))
+	if w := serve(h, "POST", "/", "12345"); w.Code != http.StatusOK {
+		t.Errorf("5 bytes = %d", w.Code)
+	}
+	if w := serve(h, "POST", "/", "123456"); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("6 bytes = %d", w.Code)
+	}
+}
@@ -0,0 +1,35 @@
+package webui
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Flash queues a message for the next page rendered, typically after a
+// redirect: ui.Flash(w, r, "Bought!"), then http.Redirect.
+//
+// Flashes live in their own signed cookie next to the login one, so a
+// handler can log someone in and flash in the same response.
+func (ui *UI) Flash(w http.ResponseWriter, r *http.Request, message string) {
+	if ui.flashes == nil {
+		return
+	}
+	session := ui.flashes.Get(r)
+	session.Set(strconv.Itoa(len(session.Values)), message)
+	ui.flashes.Save(w, session)
+}
+
+// peekFlashes returns r's queued messages in the order they were flashed.
+func (ui *UI) peekFlashes(r *http.Request) []string {
+	if ui.flashes == nil {
+		return nil
+	}
+	session := ui.flashes.Get(r)
+	messages := make([]string, 0, len(session.Values))
+	for i := 0; i < len(session.Values); i++ {
+		if message, ok := session.Values[strconv.Itoa(i)]; ok {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
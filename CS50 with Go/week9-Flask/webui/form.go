@@ -0,0 +1,72 @@
+package webui
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Form checks a submitted form one field at a time, like WTForms'
+// validators. Each check returns the field's value and remembers the first
+// problem, so a handler reads every field and then asks Valid once:
+//
+//	f := webui.NewForm(r)
+//	symbol := f.Required("symbol")
+//	shares := f.Int("shares", 1, MAX_SHARES)
+//	if !f.Valid() {
+//		ui.Apology(w, r, http.StatusBadRequest, f.Problem)
+//		return
+//	}
+type Form struct {
+	r *http.Request
+
+	// Problem is the first check that failed, ready for an apology:
+	// "must provide symbol", "shares must be at least 1", ...
+	Problem string
+}
+
+// NewForm returns a Form reading r's posted fields.
+func NewForm(r *http.Request) *Form {
+	return &Form{r: r}
+}
+
+// Valid reports whether every check so far passed.
+func (f *Form) Valid() bool {
+	return f.Problem == ""
+}
+
+func (f *Form) fail(format string, args ...any) {
+	if f.Problem == "" {
+		f.Problem = fmt.Sprintf(format, args...)
+	}
+}
+
+// Required returns field with surrounding spaces trimmed, which must not
+// be empty.
+func (f *Form) Required(field string) string {
+	value := strings.TrimSpace(f.r.PostFormValue(field))
+	if value == "" {
+		f.fail("must provide %s", field)
+	}
+	return value
+}
+
+// Int returns field as a whole number from min to max.
+func (f *Form) Int(field string, min, max int) int {
+	value := f.Required(field)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	switch {
+	case err != nil:
+		f.fail("%s must be a whole number", field)
+		return 0
+	case n < min:
+		f.fail("%s must be at least %d", field, min)
+	case n > max:
+		f.fail("%s must be at most %d", field, max)
+	}
+	return n
+}
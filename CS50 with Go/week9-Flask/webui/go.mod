@@ -0,0 +1,15 @@
+module webui
+
+go 1.25.0
+
+require sessions v0.0.0
+
+require (
+	cs50 v0.0.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+)
+
+replace (
+	cs50 => ../../cs50
+	sessions => ../sessions
+)
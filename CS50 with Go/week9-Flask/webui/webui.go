@@ -0,0 +1,152 @@
+// Package webui is the html/template plumbing the web apps share, in the
+// spirit of Flask's render_template: a base layout every page extends, an
+// apology page, and flash messages that survive a redirect.
+//
+// An app's template directory holds layout.html, which defines "layout"
+// and leaves blocks for pages to fill ({{block "main" .Data}}{{end}}),
+// partials whose names start with "_", and one file per page that defines
+// those blocks. Every page is parsed with the layout and partials, plus the
+// built-in "flashes" partial and a fallback "apology.html".
+//
+// Embed the directory as templates/* rather than templates: go:embed skips
+// files starting with "_" when given just a directory.
+package webui
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+
+	"sessions"
+)
+
+// LAYOUT is the file every page extends, and the template executed.
+const LAYOUT = "layout.html"
+
+// APOLOGY is the page Apology renders.
+const APOLOGY = "apology.html"
+
+//go:embed templates
+var builtin embed.FS
+
+// View is what every template gets: the page's own data, plus what the
+// layout needs on every page.
+type View struct {
+	LoggedIn bool
+	Flashes  []string
+	Data     any
+}
+
+// Apology is the data of the apology page.
+type Apology struct {
+	Status  int
+	Message string
+}
+
+// UI renders an app's pages.
+type UI struct {
+	pages   map[string]*template.Template
+	store   *sessions.Store // login cookie, nil for apps without one
+	flashes *sessions.Store
+}
+
+// New parses the templates in fsys (the top directory, not subdirectories)
+// with funcs available to all of them. store may be nil, in which case
+// nobody is ever LoggedIn and Flash does nothing.
+func New(fsys fs.FS, funcs template.FuncMap, store *sessions.Store) (*UI, error) {
+	base := template.New(LAYOUT).Funcs(funcs)
+	if _, err := base.ParseFS(builtin, "templates/flashes.html"); err != nil {
+		return nil, err
+	}
+	files, err := fs.Glob(fsys, "*.html")
+	if err != nil {
+		return nil, err
+	}
+	var pages []string
+	for _, file := range files {
+		if file == LAYOUT || strings.HasPrefix(file, "_") {
+			if _, err := base.ParseFS(fsys, file); err != nil {
+				return nil, err
+			}
+		} else {
+			pages = append(pages, file)
+		}
+	}
+	if base.Lookup("layout") == nil {
+		return nil, fmt.Errorf("webui: no %s defining \"layout\"", LAYOUT)
+	}
+
+	ui := &UI{pages: map[string]*template.Template{}, store: store}
+	if store != nil {
+		ui.flashes = store.WithName(store.Name + "_flash")
+	}
+	// The built-in apology is a page too, unless the app has its own
+	pages = append(pages, APOLOGY)
+	for _, name := range pages {
+		if _, ok := ui.pages[name]; ok {
+			continue
+		}
+		page, err := base.Clone()
+		if err != nil {
+			return nil, err
+		}
+		src, source := fsys, name
+		if name == APOLOGY && !exists(fsys, name) {
+			src, source = builtin, path.Join("templates", APOLOGY)
+		}
+		if _, err := page.ParseFS(src, source); err != nil {
+			return nil, err
+		}
+		ui.pages[name] = page
+	}
+	return ui, nil
+}
+
+func exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+// Render executes page with data inside the layout. The page is rendered
+// into memory first, so a template error becomes a clean 500 instead of
+// half a page.
+func (ui *UI) Render(w http.ResponseWriter, r *http.Request, status int, page string, data any) {
+	tmpl, ok := ui.pages[page]
+	if !ok {
+		slog.Error("webui: no page", "page", page)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	view := View{Data: data}
+	if ui.store != nil {
+		_, view.LoggedIn = ui.store.User(r)
+	}
+	view.Flashes = ui.peekFlashes(r)
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", view); err != nil {
+		slog.Error("webui: can't render a page", "page", page, "err", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	// Shown now, so don't show them again
+	if len(view.Flashes) > 0 {
+		ui.flashes.Clear(w)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
+}
+
+// Apology renders the apology page, like apology() in the finance pset's
+// helpers.py.
+func (ui *UI) Apology(w http.ResponseWriter, r *http.Request, status int, message string) {
+	ui.Render(w, r, status, APOLOGY, Apology{status, message})
+}
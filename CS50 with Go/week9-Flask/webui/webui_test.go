@@ -0,0 +1,170 @@
+package webui
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"sessions"
+)
+
+// SITE is a small app's templates: a layout with two blocks, a partial,
+// and two pages, one of them overriding the title.
+var SITE = fstest.MapFS{
+	"layout.html": {Data: []byte(`{{define "layout"}}<title>{{block "title" .}}Site{{end}}</title>` +
+		`{{if .LoggedIn}}[in]{{end}}{{template "flashes" .}}<main>{{block "main" .Data}}{{end}}</main>{{template "_footer"}}{{end}}`)},
+	"_footer.html": {Data: []byte(`{{define "_footer"}}<footer>bye</footer>{{end}}`)},
+	"index.html":   {Data: []byte(`{{define "main"}}Hello, {{shout .}}{{end}}`)},
+	"about.html":   {Data: []byte(`{{define "title"}}About{{end}}{{define "main"}}About {{.}}{{end}}`)},
+	"broken.html":  {Data: []byte(`{{define "main"}}{{.Missing.Field}}{{end}}`)},
+}
+
+func newUI(t *testing.T, fsys fstest.MapFS) (*UI, *sessions.Store) {
+	t.Helper()
+	store, err := sessions.NewStore("app", sessions.RandomKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui, err := New(fsys, template.FuncMap{"shout": strings.ToUpper}, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ui, store
+}
+
+func TestRender(t *testing.T) {
+	ui, _ := newUI(t, SITE)
+	tests := []struct {
+		page   string
+		data   any
+		status int
+		want   string
+	}{
+		{"index.html", "world", http.StatusOK, "<title>Site</title><main>Hello, WORLD</main><footer>bye</footer>"},
+		{"about.html", "<us>", http.StatusOK, "<title>About</title><main>About &lt;us&gt;</main><footer>bye</footer>"},
+		{APOLOGY, Apology{400, "must provide symbol"}, http.StatusBadRequest, "<p>must provide symbol</p>"},
+		{"broken.html", 42, http.StatusInternalServerError, "Internal Server Error"},
+		{"missing.html", nil, http.StatusInternalServerError, "Internal Server Error"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		ui.Render(w, httptest.NewRequest("GET", "/", nil), tt.status, tt.page, tt.data)
+		if w.Code != tt.status || !strings.Contains(w.Body.String(), tt.want) {
+			t.Errorf("Render(%s) = %d %q, want %d containing %q", tt.page, w.Code, w.Body, tt.status, tt.want)
+		}
+	}
+
+	// The built-in apology fills the layout's blocks
+	w := httptest.NewRecorder()
+	ui.Apology(w, httptest.NewRequest("GET", "/", nil), http.StatusForbidden, "invalid username")
+	if body := w.Body.String(); w.Code != http.StatusForbidden || !strings.Contains(body, "<title>Apology</title>") || !strings.Contains(body, "403") {
+		t.Errorf("Apology = %d %q", w.Code, body)
+	}
+}
+
+func TestOwnApology(t *testing.T) {
+	site := fstest.MapFS{}
+	for name, file := range SITE {
+		site[name] = file
+	}
+	site[APOLOGY] = &fstest.MapFile{Data: []byte(`{{define "main"}}Sorry: {{.Message}}{{end}}`)}
+	ui, _ := newUI(t, site)
+	w := httptest.NewRecorder()
+	ui.Apology(w, httptest.NewRequest("GET", "/", nil), http.StatusBadRequest, "no")
+	if !strings.Contains(w.Body.String(), "<main>Sorry: no</main>") {
+		t.Errorf("app's apology.html not used: %q", w.Body)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"no layout":    {"index.html": SITE["index.html"]},
+		"syntax error": {"layout.html": SITE["layout.html"], "index.html": {Data: []byte(`{{define "main"}}{{if}}{{end}}`)}},
+	} {
+		if _, err := New(fsys, nil, nil); err == nil {
+			t.Errorf("%s: New succeeded", name)
+		}
+	}
+}
+
+// A flash set before a redirect shows once, on the next page, even when
+// the same response logs the user in.
+func TestFlash(t *testing.T) {
+	ui, store := newUI(t, SITE)
+
+	w := httptest.NewRecorder()
+	store.Login(w, 1)
+	ui.Flash(w, httptest.NewRequest("POST", "/login", nil), "Logged in!")
+	r := httptest.NewRequest("GET", "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+	flash := httptest.NewRecorder()
+	ui.Flash(flash, r, "Welcome back")
+	r = httptest.NewRequest("GET", "/", nil)
+	for _, c := range append(w.Result().Cookies()[:1], flash.Result().Cookies()...) {
+		r.AddCookie(c)
+	}
+
+	w = httptest.NewRecorder()
+	ui.Render(w, r, http.StatusOK, "index.html", "x")
+	body := w.Body.String()
+	first, second := strings.Index(body, "Logged in!"), strings.Index(body, "Welcome back")
+	if !strings.Contains(body, "[in]") || first < 0 || second < first {
+		t.Errorf("page after login = %q, want logged in with both flashes in order", body)
+	}
+	cleared := false
+	for _, c := range w.Result().Cookies() {
+		cleared = cleared || c.Name == "app_flash" && c.MaxAge < 0
+	}
+	if !cleared {
+		t.Error("flashes not cleared after being shown")
+	}
+
+	// Without a store there's nothing to flash into, and that's fine
+	plain, err := New(SITE, template.FuncMap{"shout": strings.ToUpper}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	plain.Flash(w, r, "ignored")
+	plain.Render(w, r, http.StatusOK, "index.html", "x")
+	if strings.Contains(w.Body.String(), "flash") || strings.Contains(w.Body.String(), "[in]") {
+		t.Errorf("store-less UI = %q", w.Body)
+	}
+}
+
+func TestForm(t *testing.T) {
+	tests := []struct {
+		form    string
+		symbol  string
+		shares  int
+		problem string
+	}{
+		{"symbol=NFLX&shares=10", "NFLX", 10, ""},
+		{"symbol=+NFLX+&shares=+1+", "NFLX", 1, ""},
+		{"symbol=NFLX&shares=100", "NFLX", 100, ""},
+		{"shares=10", "", 10, "must provide symbol"},
+		{"symbol=NFLX", "NFLX", 0, "must provide shares"},
+		{"symbol=NFLX&shares=0", "NFLX", 0, "shares must be at least 1"},
+		{"symbol=NFLX&shares=-1", "NFLX", -1, "shares must be at least 1"},
+		{"symbol=NFLX&shares=101", "NFLX", 101, "shares must be at most 100"},
+		{"symbol=NFLX&shares=1.5", "NFLX", 0, "shares must be a whole number"},
+		{"symbol=NFLX&shares=two", "NFLX", 0, "shares must be a whole number"},
+		{"symbol=NFLX&shares=99999999999999999999", "NFLX", 0, "shares must be a whole number"},
+		// Only the first problem is kept
+		{"shares=two", "", 0, "must provide symbol"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/buy", strings.NewReader(tt.form))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		f := NewForm(r)
+		symbol, shares := f.Required("symbol"), f.Int("shares", 1, 100)
+		if symbol != tt.symbol || shares != tt.shares || f.Problem != tt.problem || f.Valid() != (tt.problem == "") {
+			t.Errorf("%s: got %q, %d, %q; want %q, %d, %q", tt.form, symbol, shares, f.Problem, tt.symbol, tt.shares, tt.problem)
+		}
+	}
+}